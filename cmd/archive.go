@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/dagu-dev/dagu/internal/archive"
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/spf13/cobra"
+)
+
+func archiveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "archive [<DAG file>...]",
+		Short: "Move the log files of the runs older than the archive policy to the archive",
+		Long:  `dagu archive [--dry-run] [<DAG file>...]`,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(config.LoadConfig())
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := config.Get()
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			a := archive.New(&archive.Config{
+				DataStore: client.NewDataStoreFactory(cfg),
+				DryRun:    dryRun,
+			})
+			if cfg.ArchiveBackend != nil {
+				a.AfterDays = cfg.ArchiveBackend.AfterDays
+			}
+			r, err := a.Run(args...)
+			checkError(err)
+
+			verb := "archived"
+			if dryRun {
+				verb = "would archive"
+			}
+			for _, run := range r.Runs {
+				fmt.Printf("%s run %s of %s to %s\n", verb, run.RequestId, run.DAG, run.Location)
+			}
+			for _, e := range r.Errors {
+				log.Printf("error: %s", e)
+			}
+			fmt.Printf("%s %d runs (%d bytes)\n", verb, len(r.Runs), r.Size())
+		},
+	}
+	cmd.Flags().Bool("dry-run", false, "list the runs without archiving them")
+	return cmd
+}
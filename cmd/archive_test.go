@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestArchiveCommand(t *testing.T) {
+	tmpDir, _, _ := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	t.Setenv("DAGU_ARCHIVE_BACKEND", "dir")
+	t.Setenv("DAGU_ARCHIVE_DIR", filepath.Join(tmpDir, "archive"))
+	t.Setenv("DAGU_ARCHIVE_AFTER_DAYS", "0")
+
+	dagFile := testDAGFile("start.yaml")
+	testRunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile}})
+
+	testRunCommand(t, archiveCmd(), cmdTest{
+		args:        []string{"archive", "--dry-run", dagFile},
+		expectedOut: []string{"would archive run", "would archive 1 runs"},
+	})
+	testRunCommand(t, archiveCmd(), cmdTest{
+		args:        []string{"archive", dagFile},
+		expectedOut: []string{"archived 1 runs"},
+	})
+	testRunCommand(t, archiveCmd(), cmdTest{
+		args:        []string{"archive", dagFile},
+		expectedOut: []string{"archived 0 runs"},
+	})
+}
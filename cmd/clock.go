@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/clock"
+	"github.com/dagu-dev/dagu/internal/config"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	errNoFrozenClock = dagerrors.New(dagerrors.CodeNotFound, "no scheduler with a frozen clock; start it with DAGU_CLOCK")
+	errClockBack     = dagerrors.New(dagerrors.CodeInvalidArgument, "the clock does not go back")
+)
+
+func clockCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clock",
+		Short: "Show or move the frozen clock of the scheduler",
+		Long: `dagu clock
+dagu clock advance <duration>
+dagu clock set <time>`,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(config.LoadConfig())
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			now, err := readClock()
+			checkError(err)
+			fmt.Printf("the clock of the scheduler is at %s\n", utils.FormatTime(now))
+		},
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "advance <duration>",
+		Short: "Advance the frozen clock of the scheduler",
+		Long:  `dagu clock advance <duration>`,
+		Args:  cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(config.LoadConfig())
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			d, err := time.ParseDuration(args[0])
+			checkError(err)
+			now, err := readClock()
+			checkError(err)
+			checkError(setClock(now.Add(d)))
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "set <time>",
+		Short: "Set the frozen clock of the scheduler to a later time",
+		Long:  `dagu clock set <time>`,
+		Args:  cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(config.LoadConfig())
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			t, err := config.ParseClock(args[0])
+			checkError(err)
+			now, err := readClock()
+			checkError(err)
+			if t.Before(now) {
+				checkError(fmt.Errorf("%w: %s is before %s", errClockBack, utils.FormatTime(t), utils.FormatTime(now)))
+			}
+			checkError(setClock(t))
+		},
+	})
+	return cmd
+}
+
+// readClock returns the time of the frozen clock of the scheduler, which it
+// writes to the control file when it starts.
+func readClock() (time.Time, error) {
+	t, err := clock.ReadControl(clock.ControlFile(config.Get().DataDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, errNoFrozenClock
+	}
+	return t, err
+}
+
+// setClock writes the time to the control file, and the scheduler moves its
+// clock to it.
+func setClock(t time.Time) error {
+	if err := clock.WriteControl(clock.ControlFile(config.Get().DataDir), t); err != nil {
+		return err
+	}
+	fmt.Printf("set the clock of the scheduler to %s\n", utils.FormatTime(t))
+	return nil
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/clock"
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockCommand(t *testing.T) {
+	tmpDir, _, _ := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	file := clock.ControlFile(config.Get().DataDir)
+	now := time.Date(2024, 3, 31, 1, 58, 0, 0, time.Local)
+	require.NoError(t, clock.WriteControl(file, now))
+
+	testRunCommand(t, clockCmd(), cmdTest{
+		args:        []string{"clock"},
+		expectedOut: []string{"the clock of the scheduler is at 2024-03-31 01:58:00"},
+	})
+	testRunCommand(t, clockCmd(), cmdTest{
+		args:        []string{"clock", "advance", "2m"},
+		expectedOut: []string{"set the clock of the scheduler to 2024-03-31 02:00:00"},
+	})
+	got, err := clock.ReadControl(file)
+	require.NoError(t, err)
+	require.True(t, now.Add(2*time.Minute).Equal(got))
+
+	testRunCommand(t, clockCmd(), cmdTest{
+		args:        []string{"clock", "set", "2024-04-01T00:00:00"},
+		expectedOut: []string{"set the clock of the scheduler to 2024-04-01 00:00:00"},
+	})
+	got, err = clock.ReadControl(file)
+	require.NoError(t, err)
+	require.True(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.Local).Equal(got))
+}
@@ -6,12 +6,16 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/dagu-dev/dagu/internal/agent"
 	"github.com/dagu-dev/dagu/internal/config"
-	"github.com/dagu-dev/dagu/internal/dag"
 	"github.com/dagu-dev/dagu/internal/engine"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
 	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/secret"
 	"github.com/spf13/cobra"
 )
 
@@ -19,24 +23,58 @@ func execDAG(ctx context.Context, e engine.Engine, cmd *cobra.Command, args []st
 	params, err := cmd.Flags().GetString("params")
 	checkError(err)
 
-	loadedDAG, err := loadDAG(args[0], removeQuotes(params))
+	loadedDAG, err := loadDAGForRun(args[0], removeQuotes(params))
 	checkError(err)
 
-	err = start(ctx, e, loadedDAG, dry)
+	steps, schedule := getRunFlags(cmd)
+	cfg := &agent.Config{DAG: loadedDAG, Dry: dry, Steps: steps, Schedule: schedule}
+	// Set only for the start command when the DAG is run as a sub DAG, or
+	// through the API.
+	cfg.RequestId, _ = cmd.Flags().GetString("request-id")
+	cfg.OutputsFile, _ = cmd.Flags().GetString("outputs-file")
+	cfg.NoCache, _ = cmd.Flags().GetBool("no-cache")
+	token, _ := cmd.Flags().GetString("initiator-token")
+	user, _ := cmd.Flags().GetString("initiator-user")
+	if token != "" || user != "" {
+		cfg.Initiator = &model.Initiator{Token: token, User: user}
+	}
+	// Only the start command injects faults.
+	faults, _ := cmd.Flags().GetStringArray("chaos")
+	cfg.Faults, err = scheduler.ParseFaults(faults)
+	checkError(dagerrors.WithCode(dagerrors.CodeInvalidArgument, err))
+	if v, _ := cmd.Flags().GetString("logical-date"); v != "" {
+		cfg.LogicalDate, err = time.Parse(time.RFC3339, v)
+		checkError(dagerrors.WithCode(dagerrors.CodeInvalidArgument, err))
+	}
+	err = start(ctx, e, cfg)
 	if err != nil {
-		log.Fatalf("Failed to start DAG: %v", err) // nolint // deep-exit
+		log.Printf("Failed to start DAG: %v", err)
+		os.Exit(dagerrors.ExitCode(err)) // nolint // deep-exit
 	}
 }
 
-func start(ctx context.Context, e engine.Engine, d *dag.DAG, dry bool) error {
+func start(ctx context.Context, e engine.Engine, cfg *agent.Config) error {
 	// TODO: remove this
 	ds := client.NewDataStoreFactory(config.Get())
 
-	a := agent.New(&agent.Config{DAG: d, Dry: dry}, e, ds)
+	a := agent.New(cfg, e, ds)
 	listenSignals(ctx, a)
 	return a.Run(ctx)
 }
 
+// addRunFlags adds the flags to limit the command to some steps and to link
+// it to the schedule entry that triggered it.
+func addRunFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray("step", nil, "limit to the step (can be repeated)")
+	cmd.Flags().String("schedule", "", "cron expression of the schedule entry that triggered the command")
+}
+
+func getRunFlags(cmd *cobra.Command) (steps []string, schedule string) {
+	steps, _ = cmd.Flags().GetStringArray("step")
+	schedule, _ = cmd.Flags().GetString("schedule")
+	return steps, schedule
+}
+
 type signalListener interface {
 	Signal(os.Signal)
 }
@@ -46,6 +84,18 @@ var (
 )
 
 func listenSignals(ctx context.Context, a signalListener) {
+	// SIGHUP reads the secrets of the run again, e.g. the credentials of its
+	// notifications and the variables of its next steps, once they were
+	// rotated.
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			log.Printf("Reading the secrets again.")
+			secret.Refresh()
+		}
+	}()
+
 	go func() {
 		signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
 
@@ -58,10 +108,12 @@ func listenSignals(ctx context.Context, a signalListener) {
 	}()
 }
 
+// checkError exits the process with the exit code of the error category.
 // nolint // deep-exit
 func checkError(err error) {
 	if err != nil {
-		log.Fatal(err)
+		log.Print(err)
+		os.Exit(dagerrors.ExitCode(err))
 	}
 }
 
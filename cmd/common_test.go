@@ -2,10 +2,13 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"log"
 	"os"
+	"os/exec"
 	"path"
+	"strings"
 	"testing"
 	"time"
 
@@ -124,3 +127,35 @@ func testLastStatusEventual(t *testing.T, hs persistence.HistoryStore, dag strin
 		return expected == status[0].Status.Status
 	}, time.Millisecond*5000, time.Millisecond*50)
 }
+
+// TestExitCodes runs the commands in a process of the test binary, since
+// they exit with the code of the category of their errors.
+func TestExitCodes(t *testing.T) {
+	if args := os.Getenv("DAGU_TEST_EXIT_ARGS"); args != "" {
+		root := &cobra.Command{Use: "root"}
+		root.AddCommand(startCmd())
+		root.SetArgs(strings.Split(args, " "))
+		_ = root.Execute()
+		os.Exit(0)
+	}
+	tmpDir, _, _ := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	for _, tc := range []struct {
+		args     string
+		exitCode int
+	}{
+		{args: "start " + path.Join(tmpDir, "unknown.yaml"), exitCode: 3},
+		{args: "start " + testDAGFile("validate_invalid.yaml"), exitCode: 2},
+		{args: "start --chaos=unknown " + testDAGFile("start.yaml"), exitCode: 2},
+	} {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestExitCodes$")
+		cmd.Env = append(os.Environ(), "DAGU_TEST_EXIT_ARGS="+tc.args, "HOME="+tmpDir)
+		err := cmd.Run()
+		var exitErr *exec.ExitError
+		require.True(t, errors.As(err, &exitErr), tc.args)
+		require.Equal(t, tc.exitCode, exitErr.ExitCode(), tc.args)
+	}
+}
@@ -11,7 +11,7 @@ func dryCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "dry [flags] <DAG file>",
 		Short: "Dry-runs specified DAG",
-		Long:  `dagu dry [--params="param1 param2"] <DAG file>`,
+		Long:  `dagu dry [--params="param1 param2"] [--step=<step>] <DAG file>`,
 		Args:  cobra.ExactArgs(1),
 		PreRun: func(cmd *cobra.Command, args []string) {
 			cobra.CheckErr(config.LoadConfig())
@@ -23,5 +23,6 @@ func dryCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().StringP("params", "p", "", "parameters")
+	addRunFlags(cmd)
 	return cmd
 }
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+func execCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec [flags] <DAG file>",
+		Short: "Runs the DAG in-process and prints the status as JSON",
+		Long:  `dagu exec [--params="param1 param2"] [--step=<step>] [--log-dir=<dir>] [--quiet] <DAG file>`,
+		Args:  cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(config.LoadConfig())
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			params, err := cmd.Flags().GetString("params")
+			checkError(err)
+
+			loadedDAG, err := loadDAGForRun(args[0], removeQuotes(params))
+			checkError(err)
+
+			steps, _ := cmd.Flags().GetStringArray("step")
+			logDir, _ := cmd.Flags().GetString("log-dir")
+			quiet, _ := cmd.Flags().GetBool("quiet")
+
+			ds := client.NewDataStoreFactory(config.Get())
+			cfg := &runner.Config{
+				DAG:              loadedDAG,
+				Steps:            steps,
+				LogDir:           logDir,
+				Finder:           ds.NewDAGStore(),
+				DataStoreFactory: ds,
+			}
+			if !quiet {
+				cfg.StepOutput = os.Stderr
+			}
+			r := runner.New(cfg)
+			listenSignals(cmd.Context(), r)
+
+			status, err := r.Run(cmd.Context())
+			if status != nil {
+				b, jsonErr := status.ToJson()
+				checkError(jsonErr)
+				fmt.Println(string(b))
+			}
+			if err != nil {
+				log.Printf("DAG failed: %v", err)
+				os.Exit(dagerrors.ExitCode(err)) // nolint // deep-exit
+			}
+		},
+	}
+	cmd.Flags().StringP("params", "p", "", "parameters")
+	cmd.Flags().StringArray("step", nil, "limit to the step (can be repeated)")
+	cmd.Flags().String("log-dir", "", "directory of the step logs (default is the log directory of the DAG)")
+	cmd.Flags().BoolP("quiet", "q", false, "do not write the output of the steps to stderr")
+	return cmd
+}
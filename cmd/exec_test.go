@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+)
+
+func TestExecCommand(t *testing.T) {
+	tmpDir, _, _ := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	tests := []cmdTest{
+		{
+			args:        []string{"exec", "--quiet", testDAGFile("start.yaml")},
+			expectedOut: []string{`"StatusText":"finished"`},
+		},
+		{
+			args:        []string{"exec", `--params="p3 p4"`, testDAGFile("start_with_params.yaml")},
+			expectedOut: []string{`"Args":["params is p3 and p4"]`},
+		},
+	}
+
+	for _, tc := range tests {
+		testRunCommand(t, execCmd(), tc)
+	}
+}
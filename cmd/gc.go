@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/gc"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/spf13/cobra"
+)
+
+func gcCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Remove orphaned sockets, temporary files, lock files and artifacts",
+		Long:  `dagu gc [--dry-run] [--min-age=<duration>]`,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(config.LoadConfig())
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := config.Get()
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			minAge := time.Second * time.Duration(cfg.GCMinAgeSec)
+			if cmd.Flags().Changed("min-age") {
+				minAge, _ = cmd.Flags().GetDuration("min-age")
+			}
+			c := gc.New(&gc.Config{
+				DataStore: client.NewDataStoreFactory(cfg),
+				DataDir:   cfg.DataDir,
+				MinAge:    minAge,
+				DryRun:    dryRun,
+			})
+			// The report of a dry run does not replace the report of the
+			// last collection.
+			if !dryRun {
+				c.ReportFile = gc.ReportFile(cfg.DataDir)
+			}
+			r, err := c.Run()
+			checkError(err)
+
+			verb := "removed"
+			if dryRun {
+				verb = "would remove"
+			}
+			for _, it := range r.Removed {
+				fmt.Printf("%s %s %s\n", verb, it.Kind, it.Path)
+			}
+			for _, e := range r.Errors {
+				log.Printf("error: %s", e)
+			}
+			fmt.Printf("%s %d files (%d bytes)\n", verb, len(r.Removed), r.Size())
+		},
+	}
+	cmd.Flags().Bool("dry-run", false, "list the files without removing them")
+	cmd.Flags().Duration("min-age", 0, "how old temporary files and artifacts must be (default is $DAGU_GC_MIN_AGE_SEC)")
+	return cmd
+}
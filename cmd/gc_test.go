@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+)
+
+func TestGCCommand(t *testing.T) {
+	tmpDir, _, _ := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	testRunCommand(t, gcCmd(), cmdTest{
+		args:        []string{"gc", "--dry-run"},
+		expectedOut: []string{"would remove"},
+	})
+	testRunCommand(t, gcCmd(), cmdTest{
+		args:        []string{"gc", "--min-age=1h"},
+		expectedOut: []string{"removed"},
+	})
+}
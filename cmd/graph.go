@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/report"
+	"github.com/spf13/cobra"
+)
+
+func graphCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "graph [--format=dot|mermaid|svg] [--status] [--output=<file>] <DAG file>",
+		Short: "Export the dependency graph of the steps of the DAG",
+		Long:  `dagu graph [--format=dot|mermaid|svg] [--status] [--output=<file>] <DAG file>`,
+		Args:  cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(config.LoadConfig())
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			f, _ := filepath.Abs(args[0])
+			format, _ := cmd.Flags().GetString("format")
+			withStatus, _ := cmd.Flags().GetBool("status")
+			output, _ := cmd.Flags().GetString("output")
+
+			loadedDAG, err := loadDAG(args[0], "")
+			checkError(err)
+
+			var status *model.Status
+			if withStatus {
+				hs := client.NewDataStoreFactory(config.Get()).NewHistoryStore()
+				recent := hs.ReadStatusRecent(f, 1)
+				if len(recent) == 0 {
+					checkError(dagerrors.WithCode(dagerrors.CodeNotFound, fmt.Errorf("%w: %s", errNoRun, loadedDAG.Name)))
+				}
+				status = recent[0].Status
+			}
+
+			var buf bytes.Buffer
+			checkError(report.Graph(&buf, format, loadedDAG, status))
+			if output == "" {
+				_, err = buf.WriteTo(os.Stdout)
+				checkError(err)
+				return
+			}
+			checkError(os.WriteFile(output, buf.Bytes(), 0644))
+			fmt.Printf("graph of %s written to %s\n", loadedDAG.Name, output)
+		},
+	}
+	cmd.Flags().StringP("format", "f", report.FormatMermaid, "format of the graph: dot, mermaid or svg")
+	cmd.Flags().Bool("status", false, "color the steps by their statuses in the latest run")
+	cmd.Flags().StringP("output", "o", "", "file to write the graph to (default is stdout)")
+	return cmd
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphCommand(t *testing.T) {
+	tmpDir, _, _ := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	dagFile := testDAGFile("retry.yaml")
+	testRunCommand(t, graphCmd(), cmdTest{
+		args:        []string{"graph", dagFile},
+		expectedOut: []string{"graph LR", `n0["1"]`, "fill:lightblue"},
+	})
+
+	// The steps are colored by their statuses in the latest run.
+	testRunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile}})
+	out := path.Join(tmpDir, "graph.dot")
+	testRunCommand(t, graphCmd(), cmdTest{
+		args:        []string{"graph", "--format=dot", "--status", "--output", out, dagFile},
+		expectedOut: []string{"written to " + out},
+	})
+	b, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"1" [fillcolor="green"`)
+}
@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/persistence/jsondb"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+const historyFormatJSONL = "jsonl"
+
+var errUnknownHistoryFormat = errors.New("unknown history format")
+
+// historyRecord is a run in the export of the history, one JSON record per
+// line in the jsonl format.
+type historyRecord struct {
+	// DAG is the name of the DAG and File the name of its file in the DAGs
+	// directory, which the run is imported to.
+	DAG       string        `json:"dag"`
+	File      string        `json:"file"`
+	StartedAt time.Time     `json:"startedAt"`
+	Status    *model.Status `json:"status"`
+}
+
+func historyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Manage the history of the runs",
+		Long: `dagu history migrate [<DAG file>...]
+dagu history export [--dag=<DAG>]... [--format=jsonl] [--output=<file>]
+dagu history import [--format=jsonl] [<file>]`,
+	}
+	cmd.AddCommand(historyExportCmd())
+	cmd.AddCommand(historyImportCmd())
+	cmd.AddCommand(&cobra.Command{
+		Use:   "migrate [<DAG file>...]",
+		Short: "Copy the history of the DAGs from the status files to the database of the history backend",
+		Long:  `dagu history migrate [<DAG file>...]`,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(config.LoadConfig())
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := config.Get()
+			files := args
+			if len(files) == 0 {
+				dags, errs, err := client.NewDataStoreFactory(cfg).NewDAGStore().List()
+				checkError(err)
+				for _, e := range errs {
+					log.Printf("error: %s", e)
+				}
+				for _, d := range dags {
+					files = append(files, d.Location)
+				}
+			}
+			from := jsondb.New(cfg.DataDir, cfg.DAGs)
+			to := client.NewSQLHistoryStore(cfg)
+			total := 0
+			for _, f := range files {
+				f, _ = filepath.Abs(f)
+				n := 0
+				for _, st := range from.ReadStatusAll(f) {
+					startedAt, err := jsondb.StartedAt(st.File)
+					if err != nil {
+						log.Printf("skip %s: %v", st.File, err)
+						continue
+					}
+					checkError(to.Import(f, startedAt, st.Status))
+					n++
+				}
+				fmt.Printf("migrated %d runs of %s\n", n, f)
+				total += n
+			}
+			fmt.Printf("migrated %d runs to %s\n", total, to.Name())
+		},
+	})
+	return cmd
+}
+
+func historyExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the history of the DAGs, e.g. to migrate it to another host or to load it into an analytics tool",
+		Long:  `dagu history export [--dag=<DAG>]... [--format=jsonl] [--output=<file>]`,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(config.LoadConfig())
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			format, _ := cmd.Flags().GetString("format")
+			checkError(checkHistoryFormat(format))
+			names, _ := cmd.Flags().GetStringSlice("dag")
+			output, _ := cmd.Flags().GetString("output")
+
+			ds := client.NewDataStoreFactory(config.Get())
+			if len(names) == 0 {
+				dags, errs, err := ds.NewDAGStore().List()
+				checkError(err)
+				for _, e := range errs {
+					log.Printf("error: %s", e)
+				}
+				for _, d := range dags {
+					names = append(names, d.Location)
+				}
+			}
+			w := cmd.OutOrStdout()
+			if output != "" {
+				f, err := os.Create(output)
+				checkError(err)
+				defer func() {
+					_ = f.Close()
+				}()
+				w = f
+			}
+			bw := bufio.NewWriter(w)
+			total := 0
+			for _, name := range names {
+				n, err := exportHistory(bw, ds, name)
+				checkError(err)
+				total += n
+			}
+			checkError(bw.Flush())
+			if output != "" {
+				fmt.Printf("exported %d runs to %s\n", total, output)
+			}
+		},
+	}
+	cmd.Flags().StringSlice("dag", nil, "the name or the file of the DAG to export the history of, all the DAGs by default")
+	cmd.Flags().String("format", historyFormatJSONL, "the format of the export: jsonl")
+	cmd.Flags().StringP("output", "o", "", "the file to write the export to, the standard output by default")
+	return cmd
+}
+
+// exportHistory writes the runs of the DAG of the name to w, the latest
+// first, and returns the number of the runs.
+func exportHistory(w io.Writer, ds persistence.DataStoreFactory, name string) (int, error) {
+	d, err := ds.NewDAGStore().GetMetadata(name)
+	if err != nil {
+		return 0, err
+	}
+	enc := json.NewEncoder(w)
+	n := 0
+	for _, sf := range ds.NewHistoryStore().ReadStatusAll(d.Location) {
+		rec := &historyRecord{
+			DAG:       d.Name,
+			File:      filepath.Base(d.Location),
+			StartedAt: runStartedAt(sf),
+			Status:    sf.Status,
+		}
+		if err := enc.Encode(rec); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// runStartedAt returns the time the run of the status was opened at: the
+// time it started, or the time in the name of its status file if it never
+// started.
+func runStartedAt(sf *model.StatusFile) time.Time {
+	if t, err := utils.ParseTime(sf.Status.StartedAt); err == nil && !t.IsZero() {
+		return t
+	}
+	if t, err := jsondb.StartedAt(sf.File); err == nil {
+		return t
+	}
+	t, _ := utils.ParseTime(sf.Status.FinishedAt)
+	return t
+}
+
+func historyImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [<file>]",
+		Short: "Import the history exported by dagu history export",
+		Long:  `dagu history import [--format=jsonl] [<file>]`,
+		Args:  cobra.MaximumNArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(config.LoadConfig())
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			format, _ := cmd.Flags().GetString("format")
+			checkError(checkHistoryFormat(format))
+			r := cmd.InOrStdin()
+			if len(args) == 1 && args[0] != "-" {
+				f, err := os.Open(args[0])
+				checkError(err)
+				defer func() {
+					_ = f.Close()
+				}()
+				r = f
+			}
+			cfg := config.Get()
+			imported, skipped, err := importHistory(r, cfg.DAGs, client.NewDataStoreFactory(cfg).NewHistoryStore())
+			checkError(err)
+			fmt.Printf("imported %d runs, skipped %d runs already in the history\n", imported, skipped)
+		},
+	}
+	cmd.Flags().String("format", historyFormatJSONL, "the format of the export: jsonl")
+	return cmd
+}
+
+// importHistory writes the runs read from r to the history of the DAGs of
+// their file names in dagsDir. The runs that are already in the history are
+// skipped, so an export can be imported again.
+func importHistory(r io.Reader, dagsDir string, hs persistence.HistoryStore) (imported, skipped int, err error) {
+	dec := json.NewDecoder(r)
+	for line := 1; ; line++ {
+		rec := &historyRecord{}
+		if err := dec.Decode(rec); err == io.EOF {
+			return imported, skipped, nil
+		} else if err != nil {
+			return imported, skipped, fmt.Errorf("line %d: %w", line, err)
+		}
+		if rec.Status == nil || rec.Status.RequestId == "" || rec.File == "" {
+			return imported, skipped, fmt.Errorf("line %d: the run has no status or DAG file", line)
+		}
+		dagFile := filepath.Join(dagsDir, filepath.Base(rec.File))
+		if _, err := hs.FindByRequestId(dagFile, rec.Status.RequestId); err == nil {
+			skipped++
+			continue
+		}
+		if err := hs.Open(dagFile, rec.StartedAt, rec.Status.RequestId); err != nil {
+			return imported, skipped, err
+		}
+		if err := hs.Write(rec.Status); err != nil {
+			_ = hs.Close()
+			return imported, skipped, err
+		}
+		if err := hs.Close(); err != nil {
+			return imported, skipped, err
+		}
+		imported++
+	}
+}
+
+func checkHistoryFormat(format string) error {
+	if format != historyFormatJSONL {
+		return fmt.Errorf("%w: %s", errUnknownHistoryFormat, format)
+	}
+	return nil
+}
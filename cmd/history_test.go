@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/persistence/sqldb"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryMigrateCommand(t *testing.T) {
+	tmpDir, _, _ := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	dagFile := testDAGFile("start.yaml")
+	testRunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile}})
+
+	testRunCommand(t, historyCmd(), cmdTest{
+		args:        []string{"history", "migrate", dagFile},
+		expectedOut: []string{"migrated 1 runs"},
+	})
+
+	store := sqldb.NewSQLite(path.Join(tmpDir, ".dagu", "data", "history.db"), "")
+	recent := store.ReadStatusRecent(dagFile, 10)
+	require.Len(t, recent, 1)
+	require.Equal(t, scheduler.StatusSuccess, recent[0].Status.Status)
+}
+
+func TestHistoryExportImportCommand(t *testing.T) {
+	tmpDir, _, ds := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	dagFile := testDAGFile("start.yaml")
+	testRunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile}})
+	testRunCommand(t, historyCmd(), cmdTest{
+		args:        []string{"history", "export", "--dag", dagFile},
+		expectedOut: []string{`"dag":"start"`, `"file":"start.yaml"`},
+	})
+
+	export := path.Join(tmpDir, "history.jsonl")
+	testRunCommand(t, historyCmd(), cmdTest{
+		args:        []string{"history", "export", "--dag", dagFile, "--output", export},
+		expectedOut: []string{"exported 1 runs"},
+	})
+
+	// The runs are imported to the DAG of the same file in the DAGs
+	// directory.
+	testRunCommand(t, historyCmd(), cmdTest{
+		args:        []string{"history", "import", export},
+		expectedOut: []string{"imported 1 runs, skipped 0 runs"},
+	})
+	imported := path.Join(config.Get().DAGs, "start.yaml")
+	recent := ds.NewHistoryStore().ReadStatusRecent(imported, 10)
+	require.Len(t, recent, 1)
+	require.Equal(t, scheduler.StatusSuccess, recent[0].Status.Status)
+
+	testRunCommand(t, historyCmd(), cmdTest{
+		args:        []string{"history", "import", export},
+		expectedOut: []string{"imported 0 runs, skipped 1 runs"},
+	})
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/importer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	errUnknownJobFile = errors.New("unknown job file: expected a systemd timer (.timer) or a Task Scheduler export (.xml)")
+	errDAGFileExists  = errors.New("the DAG file already exists")
+)
+
+func importCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [--output=<dir>] [--force] <job file>...",
+		Short: "Convert systemd timers and Windows Task Scheduler tasks to DAGs",
+		Long:  `dagu import [--output=<dir>|-] [--force] <timer file or task XML>...`,
+		Args:  cobra.MinimumNArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(config.LoadConfig())
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			output, _ := cmd.Flags().GetString("output")
+			if output == "" {
+				output = config.Get().DAGs
+			}
+			force, _ := cmd.Flags().GetBool("force")
+			for _, f := range args {
+				checkError(importJob(f, output, force))
+			}
+		},
+	}
+	cmd.Flags().StringP("output", "o", "", "the directory to write the DAG files to, the DAGs directory by default, or - for the standard output")
+	cmd.Flags().Bool("force", false, "overwrite the DAG files that exist")
+	return cmd
+}
+
+// importJob converts the job of the file to a DAG file in the directory, or
+// prints it if the directory is -. The warnings of the conversion are
+// logged.
+func importJob(file, dir string, force bool) error {
+	var r *importer.Result
+	var err error
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".timer":
+		r, err = importer.Systemd(file)
+	case ".xml":
+		r, err = importer.TaskScheduler(file)
+	default:
+		return fmt.Errorf("%w: %s", errUnknownJobFile, file)
+	}
+	if err != nil {
+		return err
+	}
+	for _, w := range r.Warnings {
+		log.Printf("warning: %s: %s", file, w)
+	}
+	data, err := r.YAML()
+	if err != nil {
+		return err
+	}
+	if dir == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	out := filepath.Join(dir, r.Name+".yaml")
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !force {
+		flags |= os.O_EXCL
+	}
+	f, err := os.OpenFile(out, flags, 0644)
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("%w: %s (use --force to overwrite it)", errDAGFileExists, out)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("imported %s to %s\n", file, out)
+	return nil
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportCommand(t *testing.T) {
+	tmpDir, _, _ := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	timer := path.Join(tmpDir, "report.timer")
+	require.NoError(t, os.WriteFile(timer, []byte("[Timer]\nOnCalendar=Mon..Fri 07:00\nOnBootSec=5min\n"), 0600))
+	require.NoError(t, os.WriteFile(path.Join(tmpDir, "report.service"), []byte("[Service]\nExecStart=/usr/bin/echo report\n"), 0600))
+
+	testRunCommand(t, importCmd(), cmdTest{
+		args:        []string{"import", "--output", "-", timer},
+		expectedOut: []string{"warning: " + timer + ": OnBootSec=5min is not converted", "- 0 7 * * 1-5", "command: /usr/bin/echo report"},
+	})
+
+	testRunCommand(t, importCmd(), cmdTest{
+		args:        []string{"import", timer},
+		expectedOut: []string{"imported " + timer},
+	})
+	data, err := os.ReadFile(path.Join(config.Get().DAGs, "report.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "# WARNING: OnBootSec=5min is not converted")
+
+	// The DAG file is loaded and runs.
+	testRunCommand(t, startCmd(), cmdTest{
+		args:        []string{"start", path.Join(config.Get().DAGs, "report.yaml")},
+		expectedOut: []string{"report"},
+	})
+
+	testRunCommand(t, importCmd(), cmdTest{
+		args:        []string{"import", "--force", timer},
+		expectedOut: []string{"imported " + timer},
+	})
+	require.ErrorIs(t, importJob(timer, config.Get().DAGs, false), errDAGFileExists)
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/spf13/cobra"
+)
+
+func lintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint [flags] [<DAG file or directory>...]",
+		Short: "Check the DAG files for likely mistakes",
+		Long: `dagu lint [--json] [--enable=<rule>,...] [--disable=<rule>,...] [<DAG file or directory>...]
+dagu lint --rules
+
+The DAG files are validated too. The command fails if any problem is found.`,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(config.LoadConfig())
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			if list, _ := cmd.Flags().GetBool("rules"); list {
+				checkError(printLintRules(os.Stdout))
+				return
+			}
+			enable, _ := cmd.Flags().GetStringSlice("enable")
+			disable, _ := cmd.Flags().GetStringSlice("disable")
+			rules, err := dag.SelectLintRules(enable, disable)
+			checkError(err)
+			if len(args) == 0 {
+				args = []string{config.Get().DAGs}
+			}
+			asJSON, _ := cmd.Flags().GetBool("json")
+			n, err := lintFiles(os.Stdout, args, rules, asJSON)
+			checkError(err)
+			if n > 0 {
+				checkError(fmt.Errorf("%w: %d problems", errInvalidDAGs, n))
+			}
+		},
+	}
+	cmd.Flags().Bool("json", false, "print the problems as JSON")
+	cmd.Flags().StringSlice("enable", nil, "enable the rules, or all of them with all")
+	cmd.Flags().StringSlice("disable", nil, "disable the rules, or all of them with all")
+	cmd.Flags().Bool("rules", false, "list the rules")
+	return cmd
+}
+
+// lintFiles lints the DAG files and the DAG files in the directories with
+// the rules, and prints the problems. It returns the number of problems.
+func lintFiles(w io.Writer, paths []string, rules []dag.LintRule, asJSON bool) (int, error) {
+	files, err := dagFiles(paths)
+	if err != nil {
+		return 0, err
+	}
+
+	problems := []dag.Problem{}
+	for _, f := range files {
+		found, err := dag.Lint(f, rules)
+		if err != nil {
+			return 0, err
+		}
+		problems = append(problems, found...)
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return len(problems), enc.Encode(problems)
+	}
+	for _, p := range problems {
+		if _, err := fmt.Fprintln(w, p); err != nil {
+			return len(problems), err
+		}
+	}
+	_, err = fmt.Fprintf(w, "%d files, %d problems\n", len(files), len(problems))
+	return len(problems), err
+}
+
+func printLintRules(w io.Writer) error {
+	for _, r := range dag.LintRules {
+		state := "enabled"
+		if !r.Default {
+			state = "disabled"
+		}
+		if _, err := fmt.Fprintf(w, "%-22s %-9s %s\n", r.Name, state, r.Description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintCommand(t *testing.T) {
+	tmpDir, _, _ := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	testRunCommand(t, lintCmd(), cmdTest{
+		args:        []string{"lint", testDAGFile("start.yaml")},
+		expectedOut: []string{"1 files, 0 problems"},
+	})
+	testRunCommand(t, lintCmd(), cmdTest{
+		args:        []string{"lint", "--rules"},
+		expectedOut: []string{dag.RuleUnreachableStep, dag.RulePlaintextSecret},
+	})
+}
+
+func TestLintFiles(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "secret.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("params: USER=dagu PASSWORD=hunter2\nsteps:\n  - name: a\n    command: echo a\n"), 0600))
+
+	rules, err := dag.SelectLintRules(nil, nil)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	n, err := lintFiles(&buf, []string{file}, rules, false)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Contains(t, buf.String(), "secret.yaml:1: params: warning: PASSWORD is a secret in plaintext")
+
+	buf.Reset()
+	n, err = lintFiles(&buf, []string{file}, rules, true)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	var problems []dag.Problem
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &problems))
+	require.Equal(t, dag.RulePlaintextSecret, problems[0].Rule)
+
+	rules, err = dag.SelectLintRules(nil, []string{dag.RulePlaintextSecret})
+	require.NoError(t, err)
+	n, err = lintFiles(&buf, []string{file}, rules, false)
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
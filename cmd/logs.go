@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/engine"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/logfile"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/remote"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/spf13/cobra"
+)
+
+// logsPollInterval is the interval at which the followed logs and the status
+// of the run are read.
+const logsPollInterval = 500 * time.Millisecond
+
+var errUnknownStep = dagerrors.New(dagerrors.CodeNotFound, "step was not found")
+
+func logsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs [flags] <DAG file>",
+		Short: "Print the step logs of a run of the DAG",
+		Long: `dagu logs [--follow] [--step=<step>] [--req=<request-id>] <DAG file>
+
+The logs of the steps are printed with the names of the steps as prefixes,
+or only the log of the step of --step without them. --follow prints the
+lines as they are written until the run finishes, like tail -f. With the
+API URL of the profile, the logs of the run are read from the instance,
+and --follow follows them with its log stream.`,
+		Args: cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(config.LoadConfig())
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			follow, _ := cmd.Flags().GetBool("follow")
+			step, _ := cmd.Flags().GetString("step")
+			reqID, _ := cmd.Flags().GetString("req")
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			var err error
+			if rc := remoteClient(); rc != nil {
+				err = printRemoteLogs(ctx, os.Stdout, rc, remoteDAGID(args[0]), reqID, step, follow)
+			} else {
+				loadedDAG, lerr := loadDAG(args[0], "")
+				checkError(lerr)
+				e := engine.NewFactory(client.NewDataStoreFactory(config.Get()), config.Get()).Create()
+				err = printLogs(ctx, os.Stdout, e, loadedDAG, reqID, step, follow)
+			}
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			checkError(err)
+		},
+	}
+	cmd.Flags().BoolP("follow", "f", false, "print the lines of the logs as they are written until the run finishes")
+	cmd.Flags().StringP("step", "s", "", "print only the log of the step")
+	cmd.Flags().StringP("req", "r", "", "request-id (default is the latest run)")
+	return cmd
+}
+
+// printLogs writes the logs of the steps of the run, or of the latest run if
+// the request ID is empty, to w. With follow, the logs of the steps are
+// followed at the same time, as the log stream of the API follows them, until
+// the run finishes.
+func printLogs(ctx context.Context, w io.Writer, e engine.Engine, d *dag.DAG, reqID, step string, follow bool) error {
+	status, err := logsRun(e, d, reqID)
+	if err != nil {
+		return err
+	}
+	var names []string
+	if step != "" {
+		if engine.FindNode(status, step) == nil {
+			return fmt.Errorf("%w: %s", errUnknownStep, step)
+		}
+		names = []string{step}
+	} else {
+		names = stepNames(status)
+	}
+
+	writers := logWriters(w, names, step == "", status.LogFormat == dag.LogFormatJSON)
+
+	if !follow {
+		// The logs are printed one after the other as they are now.
+		for i, name := range names {
+			n := engine.FindNode(status, name)
+			if n == nil || n.Log == "" {
+				continue
+			}
+			if err := logfile.Follow(ctx, n.Log, 0, logsPollInterval, func() bool { return true }, writers[i]); err != nil {
+				return err
+			}
+			writers[i].flush()
+		}
+		return nil
+	}
+
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = engine.FollowStepLog(ctx, e, d, status.RequestId, name, 0, logsPollInterval, writers[i])
+			writers[i].flush()
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// printRemoteLogs writes the logs of the steps of the run on the instance of
+// the API like printLogs. The lines of the logs of the API are plain, so
+// they are written as they are.
+func printRemoteLogs(ctx context.Context, w io.Writer, rc *remote.Client, dagID, reqID, step string, follow bool) error {
+	status, err := rc.RunStatus(ctx, dagID, reqID)
+	if err != nil {
+		return err
+	}
+	if status.RequestId == "" {
+		return dagerrors.WithCode(dagerrors.CodeNotFound, fmt.Errorf("%w: %s", errNoRun, dagID))
+	}
+	names := status.StepNames()
+	if step != "" {
+		if status.Node(step) == nil {
+			return fmt.Errorf("%w: %s", errUnknownStep, step)
+		}
+		names = []string{step}
+	}
+	writers := logWriters(w, names, step == "", false)
+
+	if !follow {
+		for i, name := range names {
+			// The steps that have not started have no log.
+			if status.Node(name).StatusText == scheduler.NodeStatusNone.String() {
+				continue
+			}
+			content, err := rc.StepLog(ctx, dagID, status.RequestId, name)
+			if err != nil {
+				return err
+			}
+			_, _ = io.WriteString(writers[i], content)
+			writers[i].flush()
+		}
+		return nil
+	}
+
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = rc.FollowStepLog(ctx, dagID, status.RequestId, name, writers[i])
+			writers[i].flush()
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// logWriters returns the writers of the logs of the steps to w, with the
+// names of the steps as prefixes if prefixed is true.
+func logWriters(w io.Writer, names []string, prefixed, jsonLog bool) []*prefixWriter {
+	width := 0
+	for _, name := range names {
+		width = max(width, len(name))
+	}
+	var mu sync.Mutex
+	writers := make([]*prefixWriter, len(names))
+	for i, name := range names {
+		writers[i] = &prefixWriter{w: w, mu: &mu, json: jsonLog}
+		if prefixed {
+			writers[i].prefix = fmt.Sprintf("%-*s | ", width, name)
+		}
+	}
+	return writers
+}
+
+// logsRun returns the status of the run of the request ID, or of the running
+// or the latest run.
+func logsRun(e engine.Engine, d *dag.DAG, reqID string) (*model.Status, error) {
+	if reqID != "" {
+		return engine.RunStatus(e, d, reqID)
+	}
+	if status, _ := e.GetCurrentStatus(d); status != nil && status.RequestId != "" {
+		return status, nil
+	}
+	recent := e.GetRecentHistory(d, 1)
+	if len(recent) == 0 {
+		return nil, dagerrors.WithCode(dagerrors.CodeNotFound, fmt.Errorf("%w: %s", errNoRun, d.Name))
+	}
+	return recent[0].Status, nil
+}
+
+// stepNames returns the names of the steps and the handlers of the run.
+func stepNames(status *model.Status) []string {
+	var names []string
+	for _, n := range status.Nodes {
+		names = append(names, n.Name)
+	}
+	for _, n := range []*model.Node{status.OnSuccess, status.OnFailure, status.OnCancel, status.OnTimeout, status.OnExit} {
+		if n != nil {
+			names = append(names, n.Name)
+		}
+	}
+	return names
+}
+
+// prefixWriter writes the lines written to it with the prefix, each line at
+// once, so that the lines of the logs of the steps followed at the same time
+// are not mixed.
+type prefixWriter struct {
+	w      io.Writer
+	mu     *sync.Mutex
+	prefix string
+	json   bool
+	buf    []byte
+}
+
+func (pw *prefixWriter) Write(p []byte) (int, error) {
+	pw.buf = append(pw.buf, p...)
+	for {
+		i := bytes.IndexByte(pw.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := pw.writeLine(pw.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		pw.buf = pw.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// flush writes the last line if it does not end with a newline.
+func (pw *prefixWriter) flush() {
+	if len(pw.buf) > 0 {
+		_ = pw.writeLine(append(pw.buf, '\n'))
+		pw.buf = nil
+	}
+}
+
+func (pw *prefixWriter) writeLine(line []byte) error {
+	if pw.json {
+		line = scheduler.PlainLog(line)
+	}
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	_, err := fmt.Fprintf(pw.w, "%s%s", pw.prefix, line)
+	return err
+}
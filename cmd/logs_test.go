@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/engine"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/remote"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogsCommand(t *testing.T) {
+	tmpDir, _, _ := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	dagFile := testDAGFile("retry.yaml")
+	testRunCommand(t, startCmd(), cmdTest{args: []string{"start", `--params="foo"`, dagFile}})
+	testRunCommand(t, logsCmd(), cmdTest{
+		args:        []string{"logs", dagFile},
+		expectedOut: []string{"1 | param is foo"},
+	})
+}
+
+func TestPrintLogs(t *testing.T) {
+	tmpDir, _, df := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	e := engine.NewFactory(client.NewDataStoreFactory(config.Get()), config.Get()).Create()
+
+	dagFile := testDAGFile("logs.yaml")
+	d, err := loadDAG(dagFile, "")
+	require.NoError(t, err)
+	done := make(chan struct{})
+	go func() {
+		testRunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile}})
+		close(done)
+	}()
+	testLastStatusEventual(t, df.NewHistoryStore(), dagFile, scheduler.StatusRunning)
+
+	// The logs of the steps are followed until the run finishes.
+	var buf bytes.Buffer
+	require.NoError(t, printLogs(context.Background(), &buf, e, d, "", "", true))
+	<-done
+	require.Contains(t, buf.String(), "extract | extracting\n")
+	require.Contains(t, buf.String(), "extract | extracted\n")
+	require.Contains(t, buf.String(), "load    | loaded\n")
+
+	buf.Reset()
+	require.NoError(t, printLogs(context.Background(), &buf, e, d, "", "load", false))
+	require.Equal(t, "loaded\n", buf.String())
+
+	require.ErrorIs(t, printLogs(context.Background(), &buf, e, d, "", "unknown", false), errUnknownStep)
+}
+
+func TestPrintRemoteLogs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/dags/etl" && r.URL.Query().Get("tab") == "log":
+			require.Equal(t, "req-1", r.URL.Query().Get("requestId"))
+			_, _ = w.Write([]byte(`{"StepLog": {"Content": "log of ` + r.URL.Query().Get("step") + `\n"}}`))
+		case r.URL.Path == "/dags/etl":
+			_, _ = w.Write([]byte(`{"DAG": {"Status": {"RequestId": "req-1", "StatusText": "running", "Nodes": [
+				{"Step": {"Name": "extract"}, "StatusText": "finished"},
+				{"Step": {"Name": "load"}, "StatusText": "not started"}
+			]}}}`))
+		case r.URL.Path == "/dags/etl/runs/req-1/steps/extract/log/stream":
+			_, _ = w.Write([]byte("id: 8\ndata: extract\n\nevent: end\ndata: finished\n\n"))
+		case r.URL.Path == "/dags/etl/runs/req-1/steps/load/log/stream":
+			_, _ = w.Write([]byte("id: 5\ndata: load\n\nevent: end\ndata: finished\n\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	rc := remote.New(&config.Config{APIURL: srv.URL})
+	ctx := context.Background()
+
+	// The logs of the steps that have not started are not read.
+	var buf bytes.Buffer
+	require.NoError(t, printRemoteLogs(ctx, &buf, rc, "etl", "", "", false))
+	require.Equal(t, "extract | log of extract\n", buf.String())
+
+	// The logs are followed with the log stream of the API.
+	buf.Reset()
+	require.NoError(t, printRemoteLogs(ctx, &buf, rc, "etl", "", "", true))
+	require.Contains(t, buf.String(), "extract | extract\n")
+	require.Contains(t, buf.String(), "load    | load\n")
+	buf.Reset()
+	require.NoError(t, printRemoteLogs(ctx, &buf, rc, "etl", "", "load", true))
+	require.Equal(t, "load\n", buf.String())
+
+	require.ErrorIs(t, printRemoteLogs(ctx, &buf, rc, "etl", "", "unknown", true), errUnknownStep)
+}
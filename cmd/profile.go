@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func profileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Manage the profiles of the dagu environments",
+		Long:  `dagu profile list|use <name>`,
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List the profiles, marking the current one",
+		Long:  `dagu profile list`,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			names, err := config.ListProfiles()
+			checkError(err)
+			current := config.CurrentProfile()
+			for _, name := range names {
+				mark := " "
+				if name == current {
+					mark = "*"
+				}
+				fmt.Printf("%s %s\n", mark, name)
+			}
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "use <name>",
+		Short: "Select the profile the next commands run with",
+		Long:  `dagu profile use <name>`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			checkError(config.UseProfile(args[0]))
+			fmt.Printf("using profile %s\n", args[0])
+		},
+	})
+	return cmd
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileCommand(t *testing.T) {
+	tmpDir, _, _ := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+		config.SetProfile("")
+	}()
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("DAGU_PROFILE", "")
+
+	dir := config.ProfilesDir()
+	require.Equal(t, path.Join(tmpDir, ".config", "dagu", "profiles"), dir)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	for name, title := range map[string]string{"dev": "Development", "prod": "Production"} {
+		data := []byte("navbarTitle: " + title + "\n")
+		require.NoError(t, os.WriteFile(path.Join(dir, name+".yaml"), data, 0600))
+	}
+
+	testRunCommand(t, profileCmd(), cmdTest{
+		args:        []string{"profile", "list"},
+		expectedOut: []string{"  dev\n  prod\n"},
+	})
+	testRunCommand(t, profileCmd(), cmdTest{
+		args:        []string{"profile", "use", "prod"},
+		expectedOut: []string{"using profile prod"},
+	})
+	testRunCommand(t, profileCmd(), cmdTest{
+		args:        []string{"profile", "list"},
+		expectedOut: []string{"  dev\n* prod\n"},
+	})
+
+	// The keys of the profile replace the ones of the config file.
+	require.NoError(t, config.LoadConfig())
+	require.Equal(t, "Production", config.Get().NavbarTitle)
+
+	// The profile of the flag takes precedence.
+	config.SetProfile("dev")
+	require.NoError(t, config.LoadConfig())
+	require.Equal(t, "Development", config.Get().NavbarTitle)
+
+	config.SetProfile("staging")
+	require.ErrorContains(t, config.LoadConfig(), "profile not found: staging")
+	require.Error(t, config.UseProfile("../prod"))
+}
+
+func TestRemoteProfile(t *testing.T) {
+	tmpDir, _, _ := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+		// The keys of the profile stay merged in the configuration of the
+		// process.
+		_ = viper.MergeConfigMap(map[string]any{"apiURL": "", "authToken": "", "namespace": ""})
+		_ = config.LoadConfig()
+	}()
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	// The server is the API of the instance of the profile.
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer prod-token", r.Header.Get("Authorization"))
+		body := map[string]string{}
+		if r.Method == http.MethodPost {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		}
+		requests = append(requests, r.Method+" "+r.URL.RawPath+" "+body["action"]+" "+body["params"])
+		switch {
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"DAG": {"Status": {"Pid": 42, "StatusText": "running"}}}`))
+		case body["action"] == "start":
+			_, _ = w.Write([]byte(`{"RequestId": "remote-run"}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+
+	dir := config.ProfilesDir()
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	data := []byte("apiURL: " + srv.URL + "/api/v1\nauthToken: prod-token\nnamespace: staging\n")
+	require.NoError(t, os.WriteFile(path.Join(dir, "prod.yaml"), data, 0600))
+	t.Setenv("DAGU_PROFILE", "prod")
+
+	testRunCommand(t, startCmd(), cmdTest{
+		args:        []string{"start", "--params=X=1", "team-a/etl.yaml"},
+		expectedOut: []string{"Started the run remote-run of team-a/etl"},
+	})
+	testRunCommand(t, statusCmd(), cmdTest{
+		args:        []string{"status", "team-a/etl"},
+		expectedOut: []string{"Pid=42 Status=running"},
+	})
+	testRunCommand(t, stopCmd(), cmdTest{
+		args:        []string{"stop", "team-a/etl"},
+		expectedOut: []string{"Stopping..."},
+	})
+	require.Equal(t, []string{
+		"POST /api/v1/namespaces/staging/dags/team-a%2Fetl start X=1",
+		"GET /api/v1/namespaces/staging/dags/team-a%2Fetl  ",
+		"POST /api/v1/namespaces/staging/dags/team-a%2Fetl stop ",
+	}, requests)
+}
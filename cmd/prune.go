@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/prune"
+	"github.com/spf13/cobra"
+)
+
+func pruneCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune [<DAG file>...]",
+		Short: "Remove the runs and the log files older than the retention of the DAGs",
+		Long:  `dagu prune [--dry-run] [<DAG file>...]`,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(config.LoadConfig())
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			p := prune.New(&prune.Config{
+				DataStore: client.NewDataStoreFactory(config.Get()),
+				DryRun:    dryRun,
+				Workers:   config.Get().PruneWorkers,
+			})
+			r, err := p.Run(args...)
+			checkError(err)
+
+			verb := "removed"
+			if dryRun {
+				verb = "would remove"
+			}
+			for _, run := range r.Runs {
+				fmt.Printf("%s run %s of %s (%s)\n", verb, run.RequestId, run.DAG, run.Reason)
+			}
+			for _, f := range r.Logs {
+				fmt.Printf("%s log %s\n", verb, f.Path)
+			}
+			for _, e := range r.Errors {
+				log.Printf("error: %s", e)
+			}
+			fmt.Printf("%s %d runs and %d logs (%d bytes)\n", verb, len(r.Runs), len(r.Logs), r.Size())
+		},
+	}
+	cmd.Flags().Bool("dry-run", false, "list the runs and the files without removing them")
+	return cmd
+}
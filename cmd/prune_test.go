@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+)
+
+func TestPruneCommand(t *testing.T) {
+	tmpDir, _, _ := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	// The DAG keeps the latest run.
+	dagFile := testDAGFile("prune.yaml")
+	testRunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile}})
+	testRunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile}})
+
+	testRunCommand(t, pruneCmd(), cmdTest{
+		args:        []string{"prune", "--dry-run", dagFile},
+		expectedOut: []string{"would remove run", "(runs)"},
+	})
+	testRunCommand(t, pruneCmd(), cmdTest{
+		args:        []string{"prune", dagFile},
+		expectedOut: []string{"removed 1 runs"},
+	})
+	testRunCommand(t, pruneCmd(), cmdTest{
+		args:        []string{"prune", dagFile},
+		expectedOut: []string{"removed 0 runs"},
+	})
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/remote"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var errRemoteFlag = dagerrors.New(dagerrors.CodeInvalidArgument, "the flag is not supported with the API URL of the profile")
+
+// remoteClient returns the client of the API of the profile, or nil if the
+// commands act on the local files.
+func remoteClient() *remote.Client {
+	return remote.New(config.Get())
+}
+
+// remoteDAGID returns the ID of the DAG of the argument on the instance of
+// the API: its name, or its path in the DAGs directory of the instance,
+// without the extension.
+func remoteDAGID(arg string) string {
+	return strings.TrimSuffix(arg, filepath.Ext(arg))
+}
+
+// checkRemoteFlags exits if one of the flags the API does not take is set.
+func checkRemoteFlags(cmd *cobra.Command, supported ...string) {
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		for _, s := range supported {
+			if f.Name == s {
+				return
+			}
+		}
+		checkError(fmt.Errorf("%w: --%s", errRemoteFlag, f.Name))
+	})
+}
+
+func remoteStart(cmd *cobra.Command, rc *remote.Client, arg string) {
+	checkRemoteFlags(cmd, "params")
+	params, _ := cmd.Flags().GetString("params")
+	requestID, queued, err := rc.Start(cmd.Context(), remoteDAGID(arg), removeQuotes(params))
+	checkError(err)
+	if queued {
+		log.Printf("Queued the run %s of %s", requestID, remoteDAGID(arg))
+		return
+	}
+	log.Printf("Started the run %s of %s", requestID, remoteDAGID(arg))
+}
+
+func remoteStop(cmd *cobra.Command, rc *remote.Client, arg string) {
+	checkRemoteFlags(cmd)
+	log.Printf("Stopping...")
+	checkError(rc.Stop(cmd.Context(), remoteDAGID(arg)))
+}
+
+func remoteStatus(cmd *cobra.Command, rc *remote.Client, arg string) {
+	status, err := rc.Status(cmd.Context(), remoteDAGID(arg))
+	checkError(err)
+	log.Printf("Pid=%d Status=%s", status.Pid, status.StatusText)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var errNoRun = errors.New("the DAG has not run yet")
+
+func reportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report [--req=<request-id>] [--output=<file>] <DAG file>",
+		Short: "Export a run of the DAG to an HTML report",
+		Long:  `dagu report [--req=<request-id>] [--output=<file>] <DAG file>`,
+		Args:  cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(config.LoadConfig())
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			f, _ := filepath.Abs(args[0])
+			reqID, _ := cmd.Flags().GetString("req")
+			output, _ := cmd.Flags().GetString("output")
+
+			loadedDAG, err := loadDAG(args[0], "")
+			checkError(err)
+
+			hs := client.NewDataStoreFactory(config.Get()).NewHistoryStore()
+			var status *model.Status
+			if reqID != "" {
+				file, err := hs.FindByRequestId(f, reqID)
+				checkError(err)
+				status = file.Status
+			} else {
+				recent := hs.ReadStatusRecent(f, 1)
+				if len(recent) == 0 {
+					checkError(dagerrors.WithCode(dagerrors.CodeNotFound, fmt.Errorf("%w: %s", errNoRun, loadedDAG.Name)))
+				}
+				status = recent[0].Status
+			}
+
+			w := os.Stdout
+			if output != "" {
+				w, err = os.Create(output)
+				checkError(err)
+			}
+			checkError(report.Render(w, loadedDAG, status))
+			if output != "" {
+				checkError(w.Close())
+				fmt.Printf("report of %s written to %s\n", status.RequestId, output)
+			}
+		},
+	}
+	cmd.Flags().StringP("req", "r", "", "request-id (default is the latest run)")
+	cmd.Flags().StringP("output", "o", "", "file to write the report to (default is stdout)")
+	return cmd
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportCommand(t *testing.T) {
+	tmpDir, e, _ := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	dagFile := testDAGFile("retry.yaml")
+	testRunCommand(t, startCmd(), cmdTest{args: []string{"start", `--params="foo"`, dagFile}})
+
+	s, err := e.GetStatus(dagFile)
+	require.NoError(t, err)
+
+	out := path.Join(tmpDir, "report.html")
+	testRunCommand(t, reportCmd(), cmdTest{
+		args:        []string{"report", fmt.Sprintf("--req=%s", s.Status.RequestId), "--output", out, dagFile},
+		expectedOut: []string{"written to " + out},
+	})
+	b, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Contains(t, string(b), s.Status.RequestId)
+	require.Contains(t, string(b), "param is foo")
+
+	// The latest run is reported to stdout without a request ID.
+	testRunCommand(t, reportCmd(), cmdTest{
+		args:        []string{"report", dagFile},
+		expectedOut: []string{"<svg", s.Status.RequestId},
+	})
+}
@@ -2,22 +2,28 @@ package cmd
 
 import (
 	"log"
+	"strings"
 	"time"
 
+	"github.com/dagu-dev/dagu/internal/agent"
 	"github.com/dagu-dev/dagu/internal/config"
 	"github.com/dagu-dev/dagu/internal/dag"
 	"github.com/dagu-dev/dagu/internal/engine"
 	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
 	"github.com/dagu-dev/dagu/internal/scheduler"
 	"github.com/spf13/cobra"
 )
 
 func restartCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "restart <DAG file>",
+	cmd := &cobra.Command{
+		Use:   "restart [flags] <DAG file>",
 		Short: "Restart the DAG",
-		Long:  `dagu restart <DAG file>`,
-		Args:  cobra.ExactArgs(1),
+		Long: `dagu restart [--params="param1 param2"] [--step=<step>] <DAG file>
+
+--step restarts only the steps. If the DAG is running, they are run again
+in its run, and its other steps keep running.`,
+		Args: cobra.ExactArgs(1),
 		PreRun: func(cmd *cobra.Command, args []string) {
 			cobra.CheckErr(config.LoadConfig())
 		},
@@ -29,6 +35,13 @@ func restartCmd() *cobra.Command {
 			df := client.NewDataStoreFactory(config.Get())
 			e := engine.NewFactory(df, config.Get()).Create()
 
+			// The steps of the running DAG are restarted in its run, so
+			// that its other steps keep running.
+			steps, schedule := getRunFlags(cmd)
+			if len(steps) > 0 && restartStepsIfRunning(e, loadedDAG, steps) {
+				return
+			}
+
 			// Check the current status and stop the DAG if it is running.
 			stopDAGIfRunning(e, loadedDAG)
 
@@ -37,14 +50,39 @@ func restartCmd() *cobra.Command {
 
 			// Retrieve the parameter of the previous execution.
 			log.Printf("Restarting %s...", loadedDAG.Name)
-			params := getPreviousExecutionParams(e, loadedDAG)
+			prev := getPreviousExecution(e, loadedDAG)
+			params := prev.Params
+			if p, _ := cmd.Flags().GetString("params"); p != "" {
+				params = removeQuotes(p)
+			}
 
 			// Start the DAG with the same parameter.
-			loadedDAG, err = loadDAG(dagFile, params)
+			loadedDAG, err = loadDAGForRun(dagFile, params)
 			checkError(err)
-			cobra.CheckErr(start(cmd.Context(), e, loadedDAG, false))
+			cobra.CheckErr(start(cmd.Context(), e, &agent.Config{
+				DAG:           loadedDAG,
+				Steps:         steps,
+				Schedule:      schedule,
+				RestartedFrom: prev.RequestId,
+			}))
 		},
 	}
+	cmd.Flags().StringP("params", "p", "", "parameters (defaults to the parameters of the previous execution)")
+	addRunFlags(cmd)
+	return cmd
+}
+
+// restartStepsIfRunning runs the steps of the running DAG again in its run
+// and returns true, or returns false if the DAG is not running.
+func restartStepsIfRunning(e engine.Engine, d *dag.DAG, steps []string) bool {
+	st, err := e.GetCurrentStatus(d)
+	checkError(err)
+	if st.Status != scheduler.StatusRunning {
+		return false
+	}
+	log.Printf("Restarting %s of %s...", strings.Join(steps, ", "), d.Name)
+	checkError(e.RestartSteps(d, steps))
+	return true
 }
 
 func stopDAGIfRunning(e engine.Engine, d *dag.DAG) {
@@ -78,9 +116,9 @@ func waitForRestart(restartWait time.Duration) {
 	}
 }
 
-func getPreviousExecutionParams(e engine.Engine, d *dag.DAG) string {
+func getPreviousExecution(e engine.Engine, d *dag.DAG) *model.Status {
 	st, err := e.GetLatestStatus(d)
 	checkError(err)
 
-	return st.Params
+	return st
 }
@@ -2,12 +2,15 @@ package cmd
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/dagu-dev/dagu/internal/config"
 	"github.com/dagu-dev/dagu/internal/engine"
 	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
 	"github.com/dagu-dev/dagu/internal/scheduler"
 	"github.com/stretchr/testify/require"
 )
@@ -61,5 +64,74 @@ func TestRestartCommand(t *testing.T) {
 	require.Len(t, sts, 2)
 	require.Equal(t, sts[0].Status.Params, sts[1].Status.Params)
 
+	// Check the restarted run is linked to the previous one
+	restarted, prev := sts[0].Status, sts[1].Status
+	if restarted.RestartedFrom == "" {
+		restarted, prev = prev, restarted
+	}
+	require.Equal(t, prev.RequestId, restarted.RestartedFrom)
+
+	<-done
+}
+
+func TestRestartSteps(t *testing.T) {
+	tmpDir, e, _ := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	dagFile := testDAGFile("restart_steps.yaml")
+	starts := filepath.Join(tmpDir, "starts.txt")
+
+	// Start the DAG.
+	done := make(chan struct{})
+	go func() {
+		testRunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile}})
+		close(done)
+	}()
+
+	d, err := loadDAG(dagFile, "")
+	require.NoError(t, err)
+	stepStatus := func(name string) *model.Node {
+		st, err := e.GetCurrentStatus(d)
+		require.NoError(t, err)
+		for _, n := range st.Nodes {
+			if n.Step.Name == name {
+				return n
+			}
+		}
+		return nil
+	}
+	startCount := func() int {
+		b, _ := os.ReadFile(starts)
+		return strings.Count(string(b), "started")
+	}
+	require.Eventually(t, func() bool {
+		server, worker := stepStatus("server"), stepStatus("worker")
+		return server != nil && server.Status == scheduler.NodeStatusRunning &&
+			worker != nil && worker.Status == scheduler.NodeStatusRunning &&
+			startCount() == 1
+	}, time.Second*5, time.Millisecond*50)
+	worker := stepStatus("worker")
+
+	// Restart only the server step.
+	testRunCommand(t, restartCmd(), cmdTest{args: []string{"restart", "--step=server", dagFile}})
+
+	// The server step runs again in the same run.
+	require.Eventually(t, func() bool {
+		return startCount() == 2
+	}, time.Second*5, time.Millisecond*50)
+	require.Equal(t, scheduler.NodeStatusRunning, stepStatus("server").Status)
+
+	// The worker step was not stopped.
+	restarted := stepStatus("worker")
+	require.Equal(t, scheduler.NodeStatusRunning, restarted.Status)
+	require.Equal(t, worker.StartedAt, restarted.StartedAt)
+
+	testRunCommand(t, stopCmd(), cmdTest{args: []string{"stop", dagFile}})
+	testStatusEventual(t, e, dagFile, scheduler.StatusNone)
 	<-done
+
+	// There was a single run.
+	require.Len(t, e.GetRecentHistory(d, 2), 1)
 }
@@ -4,6 +4,7 @@ Copyright © 2023 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"log"
 	"os"
 	"path"
 
@@ -14,7 +15,9 @@ import (
 )
 
 var (
-	cfgFile string
+	cfgFile   string
+	profile   string
+	namespace string
 
 	// rootCmd represents the base command when called without any subcommands
 	rootCmd = &cobra.Command{
@@ -34,6 +37,8 @@ func Execute() error {
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.dagu/admin.yaml)")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "profile whose settings replace the ones of the config file")
+	rootCmd.PersistentFlags().StringVar(&namespace, "namespace", "", "namespace of the DAGs and the history (default is the default namespace)")
 
 	cobra.OnInitialize(initialize)
 
@@ -53,6 +58,8 @@ func init() {
 }
 
 func initialize() {
+	config.SetProfile(profile)
+	config.SetNamespace(namespace)
 	if cfgFile != "" {
 		viper.SetConfigFile(cfgFile)
 	} else {
@@ -75,6 +82,20 @@ func loadDAG(dagFile, params string) (d *dag.DAG, err error) {
 	return dagLoader.Load(dagFile, params)
 }
 
+// loadDAGForRun loads the DAG to start a run, reading the defaults of the
+// parameters from their sources.
+func loadDAGForRun(dagFile, params string) (d *dag.DAG, err error) {
+	dagLoader := &dag.Loader{BaseConfig: config.Get().BaseConfig}
+	d, err = dagLoader.LoadForRun(dagFile, params)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range d.Warnings {
+		log.Printf("Warning: %s", w)
+	}
+	return d, nil
+}
+
 func getFlagString(cmd *cobra.Command, name, fallback string) string {
 	if s, _ := cmd.Flags().GetString(name); s != "" {
 		return s
@@ -87,10 +108,24 @@ func registerCommands(root *cobra.Command) {
 	rootCmd.AddCommand(stopCmd())
 	rootCmd.AddCommand(restartCmd())
 	rootCmd.AddCommand(dryCmd())
+	rootCmd.AddCommand(execCmd())
 	rootCmd.AddCommand(statusCmd())
 	rootCmd.AddCommand(versionCmd())
 	rootCmd.AddCommand(serverCmd())
 	rootCmd.AddCommand(schedulerCmd())
 	rootCmd.AddCommand(retryCmd())
 	rootCmd.AddCommand(startAllCmd())
+	rootCmd.AddCommand(gcCmd())
+	rootCmd.AddCommand(pruneCmd())
+	rootCmd.AddCommand(archiveCmd())
+	rootCmd.AddCommand(reportCmd())
+	rootCmd.AddCommand(graphCmd())
+	rootCmd.AddCommand(logsCmd())
+	rootCmd.AddCommand(validateCmd())
+	rootCmd.AddCommand(lintCmd())
+	rootCmd.AddCommand(profileCmd())
+	rootCmd.AddCommand(historyCmd())
+	rootCmd.AddCommand(importCmd())
+	rootCmd.AddCommand(tokenCmd())
+	rootCmd.AddCommand(clockCmd())
 }
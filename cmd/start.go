@@ -11,17 +11,37 @@ func startCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "start [flags] <DAG file>",
 		Short: "Runs the DAG",
-		Long:  `dagu start [--params="param1 param2"] <DAG file>`,
+		Long:  `dagu start [--params="param1 param2"] [--step=<step>] <DAG file>`,
 		Args:  cobra.ExactArgs(1),
 		PreRun: func(cmd *cobra.Command, args []string) {
 			cobra.CheckErr(config.LoadConfig())
 		},
 		Run: func(cmd *cobra.Command, args []string) {
+			if rc := remoteClient(); rc != nil {
+				remoteStart(cmd, rc, args[0])
+				return
+			}
 			ds := client.NewDataStoreFactory(config.Get())
 			e := engine.NewFactory(ds, config.Get()).Create()
 			execDAG(cmd.Context(), e, cmd, args, false)
 		},
 	}
 	cmd.Flags().StringP("params", "p", "", "parameters")
+	cmd.Flags().Bool("no-cache", false, "run the steps with a cache policy and replace their cached results")
+	cmd.Flags().StringArray("chaos", nil, "inject a fault: fail=<step>[:<exit code>[:<attempts>]], delay=<step>:<duration> or kill=<step> (can be repeated)")
+	addRunFlags(cmd)
+	// These flags are used by the parent DAG to run the DAG as a sub DAG.
+	cmd.Flags().String("request-id", "", "request ID of the run")
+	cmd.Flags().String("outputs-file", "", "file to write the outputs of the steps to")
+	cobra.CheckErr(cmd.Flags().MarkHidden("request-id"))
+	cobra.CheckErr(cmd.Flags().MarkHidden("outputs-file"))
+	// The scheduler sets the time of the schedule entry that triggered the run.
+	cmd.Flags().String("logical-date", "", "time the run is scheduled at in RFC 3339")
+	cobra.CheckErr(cmd.Flags().MarkHidden("logical-date"))
+	// The server sets the token and the user that started the run.
+	cmd.Flags().String("initiator-token", "", "name of the API token the run was started with")
+	cmd.Flags().String("initiator-user", "", "user the run was started by")
+	cobra.CheckErr(cmd.Flags().MarkHidden("initiator-token"))
+	cobra.CheckErr(cmd.Flags().MarkHidden("initiator-user"))
 	return cmd
 }
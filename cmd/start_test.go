@@ -24,6 +24,10 @@ func TestStartCommand(t *testing.T) {
 			args:        []string{"start", `--params="p3 p4"`, testDAGFile("start_with_params.yaml")},
 			expectedOut: []string{"params is p3 and p4"},
 		},
+		{
+			args:        []string{"start", "--initiator-token=portal", "--initiator-user=alice", testDAGFile("start.yaml")},
+			expectedOut: []string{`started by "alice" with the API token "portal"`},
+		},
 	}
 
 	for _, tc := range tests {
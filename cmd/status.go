@@ -20,6 +20,10 @@ func statusCmd() *cobra.Command {
 			cobra.CheckErr(config.LoadConfig())
 		},
 		Run: func(cmd *cobra.Command, args []string) {
+			if rc := remoteClient(); rc != nil {
+				remoteStatus(cmd, rc, args[0])
+				return
+			}
 			loadedDAG, err := loadDAG(args[0], "")
 			checkError(err)
 
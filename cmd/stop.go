@@ -1,32 +1,139 @@
 package cmd
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
 	"log"
+	"os/user"
+	"strings"
+	"time"
 
 	"github.com/dagu-dev/dagu/internal/config"
 	"github.com/dagu-dev/dagu/internal/engine"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/persistence"
 	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
 	"github.com/spf13/cobra"
 )
 
+var errStopArgs = dagerrors.New(dagerrors.CodeInvalidArgument, "a DAG file, --all or --resume is required")
+
 func stopCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "stop <DAG file>",
+	cmd := &cobra.Command{
+		Use:   "stop [flags] <DAG file>",
 		Short: "Stop the running DAG",
-		Long:  `dagu stop <DAG file>`,
-		Args:  cobra.ExactArgs(1),
+		Long: `dagu stop [--step=<step>] <DAG file>
+dagu stop --all [--yes]
+dagu stop --resume
+
+--all stops all the running DAGs and halts the schedules of all the DAGs of
+all the namespaces until they are resumed with --resume.`,
+		Args: cobra.MaximumNArgs(1),
 		PreRun: func(cmd *cobra.Command, args []string) {
 			cobra.CheckErr(config.LoadConfig())
 		},
 		Run: func(cmd *cobra.Command, args []string) {
+			// The API stops a DAG at a time.
+			if rc := remoteClient(); rc != nil {
+				if len(args) == 0 {
+					checkError(errStopArgs)
+				}
+				remoteStop(cmd, rc, args[0])
+				return
+			}
+			all, _ := cmd.Flags().GetBool("all")
+			resume, _ := cmd.Flags().GetBool("resume")
+			df := client.NewDataStoreFactory(config.Get())
+			e := engine.NewFactory(df, config.Get()).Create()
+
+			switch {
+			case all:
+				yes, _ := cmd.Flags().GetBool("yes")
+				stopAll(cmd, config.Get(), yes)
+				return
+			case resume:
+				forNamespaces(config.Get(), func(_ string, e engine.Engine, as persistence.AuditStore) {
+					checkError(e.SetHalted(false))
+					auditCLI(as, &model.AuditEntry{Action: "resume-all"})
+				})
+				fmt.Println("The schedules are resumed.")
+				return
+			case len(args) == 0:
+				checkError(errStopArgs)
+			}
+
 			loadedDAG, err := loadDAG(args[0], "")
 			checkError(err)
 
 			log.Printf("Stopping...")
 
-			df := client.NewDataStoreFactory(config.Get())
-			e := engine.NewFactory(df, config.Get()).Create()
-			checkError(e.Stop(loadedDAG))
+			steps, schedule := getRunFlags(cmd)
+			checkError(e.StopWithOptions(loadedDAG, engine.RunOptions{Steps: steps, Schedule: schedule}))
 		},
 	}
+	addRunFlags(cmd)
+	cmd.Flags().Bool("all", false, "stop all the running DAGs and halt the schedules")
+	cmd.Flags().BoolP("yes", "y", false, "stop all the running DAGs without confirmation")
+	cmd.Flags().Bool("resume", false, "resume the schedules halted by --all")
+	return cmd
+}
+
+// stopAll stops all the running DAGs of all the namespaces once the user
+// confirms it, and halts the schedules. The DAGs of the namespaces other than
+// the default one are printed as <namespace>:<name>.
+func stopAll(cmd *cobra.Command, cfg *config.Config, yes bool) {
+	if !yes {
+		fmt.Print("Stop all the running DAGs and halt the schedules of all the DAGs? [y/N] ")
+		answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+			fmt.Println("Aborted.")
+			return
+		}
+	}
+	var errs []error
+	forNamespaces(cfg, func(namespace string, e engine.Engine, as persistence.AuditStore) {
+		stopped, err := e.StopAll()
+		auditCLI(as, &model.AuditEntry{Action: "stop-all", Detail: strings.Join(stopped, ",")})
+		for _, name := range stopped {
+			if namespace != config.DefaultNamespace {
+				name = namespace + ":" + name
+			}
+			fmt.Printf("stopped %s\n", name)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", namespace, err))
+		}
+	})
+	fmt.Println("The schedules are halted until they are resumed with dagu stop --resume.")
+	if err := errors.Join(errs...); err != nil {
+		checkError(fmt.Errorf("failed to stop some DAGs: %w", err))
+	}
+}
+
+// forNamespaces calls fn with the engine and the audit store of each of the
+// namespaces of the configuration, the default one first.
+func forNamespaces(cfg *config.Config, fn func(namespace string, e engine.Engine, as persistence.AuditStore)) {
+	names := []string{config.DefaultNamespace}
+	for _, ns := range cfg.Namespaces {
+		names = append(names, ns.Name)
+	}
+	for _, name := range names {
+		c, err := cfg.ForNamespace(name)
+		checkError(err)
+		df := client.NewDataStoreFactory(c)
+		fn(name, engine.NewFactory(df, c).Create(), df.NewAuditStore())
+	}
+}
+
+// auditCLI records the action of the user of the command in the audit log.
+func auditCLI(as persistence.AuditStore, entry *model.AuditEntry) {
+	entry.Time = time.Now()
+	if u, err := user.Current(); err == nil {
+		entry.Actor = u.Username
+	}
+	if err := as.Append(entry); err != nil {
+		log.Printf("failed to write the audit log: %v", err)
+	}
 }
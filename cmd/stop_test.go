@@ -2,10 +2,15 @@ package cmd
 
 import (
 	"os"
+	"path"
 	"testing"
 	"time"
 
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
 	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStopCommand(t *testing.T) {
@@ -39,3 +44,45 @@ func TestStopCommand(t *testing.T) {
 	testLastStatusEventual(t, ds.NewHistoryStore(), dagFile, scheduler.StatusCancel)
 	<-done
 }
+
+func TestStopAllCommand(t *testing.T) {
+	tmpDir, _, ds := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	// The running DAGs are the ones of the DAGs directory.
+	b, err := os.ReadFile(testDAGFile("stop.yaml"))
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(config.Get().DAGs, 0755))
+	dagFile := path.Join(config.Get().DAGs, "stop.yaml")
+	require.NoError(t, os.WriteFile(dagFile, b, 0600))
+	fs := client.NewDataStoreFactory(config.Get()).NewFlagStore()
+
+	done := make(chan struct{})
+	go func() {
+		testRunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile}})
+		close(done)
+	}()
+	testLastStatusEventual(t, ds.NewHistoryStore(), dagFile, scheduler.StatusRunning)
+
+	testRunCommand(t, stopCmd(), cmdTest{
+		args:        []string{"stop", "--all", "--yes"},
+		expectedOut: []string{"stopped stop", "halted"}})
+	testLastStatusEventual(t, ds.NewHistoryStore(), dagFile, scheduler.StatusCancel)
+	<-done
+	require.True(t, fs.IsHalted())
+
+	testRunCommand(t, stopCmd(), cmdTest{
+		args:        []string{"stop", "--resume"},
+		expectedOut: []string{"resumed"}})
+	require.False(t, fs.IsHalted())
+
+	entries, err := ds.NewAuditStore().List(&persistence.AuditQuery{})
+	require.NoError(t, err)
+	var actions []string
+	for _, entry := range entries {
+		actions = append(actions, entry.Action)
+	}
+	require.Subset(t, actions, []string{"stop-all", "resume-all"})
+}
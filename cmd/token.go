@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/dagu-dev/dagu/service/frontend/middleware"
+	"github.com/spf13/cobra"
+)
+
+func tokenCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Manage the API tokens",
+		Long: `dagu token create <name> --scope=<scope>... [--expires-in=<duration>]
+dagu token list
+dagu token delete <name>`,
+	}
+	cmd.AddCommand(tokenCreateCmd())
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List the API tokens",
+		Long:  `dagu token list`,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(config.LoadConfig())
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			tokens, err := client.NewDataStoreFactory(config.Get()).NewTokenStore().List()
+			checkError(err)
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "NAME\tSCOPES\tCREATED\tEXPIRES")
+			now := time.Now()
+			for _, t := range tokens {
+				expires := "never"
+				switch {
+				case t.Expired(now):
+					expires = "expired"
+				case !t.ExpiresAt.IsZero():
+					expires = utils.FormatTime(t.ExpiresAt)
+				}
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", t.Name, strings.Join(t.Scopes, ","), utils.FormatTime(t.CreatedAt), expires)
+			}
+			_ = w.Flush()
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete an API token",
+		Long:  `dagu token delete <name>`,
+		Args:  cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(config.LoadConfig())
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			checkError(client.NewDataStoreFactory(config.Get()).NewTokenStore().Delete(args[0]))
+			fmt.Printf("deleted token %s\n", args[0])
+		},
+	})
+	return cmd
+}
+
+func tokenCreateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create an API token and print its secret",
+		Long:  `dagu token create <name> --scope=<scope>... [--expires-in=<duration>]`,
+		Args:  cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(config.LoadConfig())
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			scopes, _ := cmd.Flags().GetStringSlice("scope")
+			expiresIn, _ := cmd.Flags().GetDuration("expires-in")
+			store := client.NewDataStoreFactory(config.Get()).NewTokenStore()
+			token, secret, err := middleware.CreateToken(store, args[0], scopes, expiresIn)
+			checkError(err)
+			fmt.Printf("created token %s with the scopes %s\n", token.Name, strings.Join(token.Scopes, ","))
+			if !token.ExpiresAt.IsZero() {
+				fmt.Printf("it expires at %s\n", utils.FormatTime(token.ExpiresAt))
+			}
+			fmt.Println("the secret is not shown again:")
+			fmt.Println(secret)
+		},
+	}
+	cmd.Flags().StringSlice("scope", []string{middleware.ScopeRead}, "scope of the token: read, trigger, admin or impersonate")
+	cmd.Flags().Duration("expires-in", 0, "duration until the token expires, e.g. 720h; it does not expire by default")
+	return cmd
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenCommand(t *testing.T) {
+	tmpDir, _, ds := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	testRunCommand(t, tokenCmd(), cmdTest{
+		args:        []string{"token", "create", "ci", "--scope", "trigger", "--expires-in", "24h"},
+		expectedOut: []string{"created token ci with the scopes trigger", "it expires at", "dagu_"},
+	})
+	testRunCommand(t, tokenCmd(), cmdTest{
+		args:        []string{"token", "create", "grafana"},
+		expectedOut: []string{"created token grafana with the scopes read"},
+	})
+	testRunCommand(t, tokenCmd(), cmdTest{
+		args:        []string{"token", "list"},
+		expectedOut: []string{"ci       trigger", "grafana  read", "never"},
+	})
+
+	testRunCommand(t, tokenCmd(), cmdTest{
+		args:        []string{"token", "delete", "ci"},
+		expectedOut: []string{"deleted token ci"},
+	})
+	tokens, err := ds.NewTokenStore().List()
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	require.Equal(t, "grafana", tokens[0].Name)
+}
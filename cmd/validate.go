@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/dag"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/dagu-dev/dagu/schemas"
+	"github.com/spf13/cobra"
+)
+
+var errInvalidDAGs = dagerrors.New(dagerrors.CodeInvalidDAG, "invalid DAGs")
+
+func validateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate [--json] [<DAG file or directory>...]",
+		Short: "Validate the DAG files without running them",
+		Long:  `dagu validate [--json] [--schema] [<DAG file or directory>...]`,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(config.LoadConfig())
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			if schema, _ := cmd.Flags().GetBool("schema"); schema {
+				_, err := os.Stdout.Write(schemas.DAG)
+				checkError(err)
+				return
+			}
+			if len(args) == 0 {
+				args = []string{config.Get().DAGs}
+			}
+			asJSON, _ := cmd.Flags().GetBool("json")
+			n, err := validateFiles(os.Stdout, args, asJSON)
+			checkError(err)
+			if n > 0 {
+				checkError(fmt.Errorf("%w: %d errors", errInvalidDAGs, n))
+			}
+		},
+	}
+	cmd.Flags().Bool("json", false, "print the problems as JSON")
+	cmd.Flags().Bool("schema", false, "print the JSON Schema of the DAG files")
+	return cmd
+}
+
+// validateFiles validates the DAG files and the DAG files in the
+// directories, and prints the problems. It returns the number of errors.
+func validateFiles(w io.Writer, paths []string, asJSON bool) (int, error) {
+	files, err := dagFiles(paths)
+	if err != nil {
+		return 0, err
+	}
+
+	problems := []dag.Problem{}
+	for _, f := range files {
+		found, err := dag.Validate(f)
+		if err != nil {
+			return 0, err
+		}
+		problems = append(problems, found...)
+	}
+	n := 0
+	for _, p := range problems {
+		if p.Level == dag.LevelError {
+			n++
+		}
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return n, enc.Encode(problems)
+	}
+	for _, p := range problems {
+		if _, err := fmt.Fprintln(w, p); err != nil {
+			return n, err
+		}
+	}
+	_, err = fmt.Fprintf(w, "%d files, %d errors, %d warnings\n", len(files), n, len(problems)-n)
+	return n, err
+}
+
+// dagFiles returns the DAG files and the DAG files in the directories.
+func dagFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		if !fi.IsDir() {
+			files = append(files, p)
+			continue
+		}
+		entries, err := os.ReadDir(p)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if !e.IsDir() && utils.MatchExtension(e.Name(), dag.EXTENSIONS) {
+				files = append(files, filepath.Join(p, e.Name()))
+			}
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCommand(t *testing.T) {
+	tmpDir, _, _ := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	testRunCommand(t, validateCmd(), cmdTest{
+		args:        []string{"validate", testDAGFile("start.yaml")},
+		expectedOut: []string{"1 files, 0 errors, 0 warnings"},
+	})
+	testRunCommand(t, validateCmd(), cmdTest{
+		args:        []string{"validate", "--schema"},
+		expectedOut: []string{`"$schema"`},
+	})
+}
+
+func TestValidateFiles(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := validateFiles(&buf, []string{testDAGFile("")}, false)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Contains(t, buf.String(), "validate_invalid.yaml:5: steps[0].depends[0]: depends on an unknown step: 2")
+
+	buf.Reset()
+	n, err = validateFiles(&buf, []string{testDAGFile("validate_invalid.yaml")}, true)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	var problems []dag.Problem
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &problems))
+	require.Len(t, problems, 1)
+	require.Equal(t, 5, problems[0].Line)
+}
@@ -5,19 +5,36 @@ import (
 
 	"github.com/dagu-dev/dagu/internal/config"
 	"github.com/dagu-dev/dagu/internal/constants"
+	"github.com/dagu-dev/dagu/internal/executor"
 	"github.com/spf13/cobra"
 )
 
 func versionCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "Display the binary version",
-		Long:  `dagu version`,
+		Long:  `dagu version [--capabilities]`,
 		PreRun: func(cmd *cobra.Command, args []string) {
 			cobra.CheckErr(config.LoadConfig())
 		},
 		Run: func(cmd *cobra.Command, args []string) {
 			fmt.Println(constants.Version)
+			if c, _ := cmd.Flags().GetBool("capabilities"); c {
+				printCapabilities()
+			}
 		},
 	}
+	cmd.Flags().Bool("capabilities", false, "show the platform and the executor capabilities")
+	return cmd
+}
+
+func printCapabilities() {
+	fmt.Printf("platform: %s\n", executor.Platform())
+	for _, c := range executor.Capabilities() {
+		status := "available"
+		if !c.Available {
+			status = "not available"
+		}
+		fmt.Printf("%s: %s (%s)\n", c.Name, status, c.Detail)
+	}
 }
@@ -12,3 +12,10 @@ func TestVersionCommand(t *testing.T) {
 		args:        []string{"version"},
 		expectedOut: []string{"1.2.3"}})
 }
+
+func TestVersionCommandCapabilities(t *testing.T) {
+	constants.Version = "1.2.3"
+	testRunCommand(t, versionCmd(), cmdTest{
+		args:        []string{"version", "--capabilities"},
+		expectedOut: []string{"platform: ", "process-group: ", "docker-socket: ", "cgroup: "}})
+}
@@ -2,14 +2,18 @@ package agent
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
 	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"syscall"
@@ -17,22 +21,34 @@ import (
 
 	"github.com/dagu-dev/dagu/internal/persistence"
 
+	"github.com/dagu-dev/dagu/internal/clock"
+	"github.com/dagu-dev/dagu/internal/config"
 	"github.com/dagu-dev/dagu/internal/constants"
 	"github.com/dagu-dev/dagu/internal/dag"
 	"github.com/dagu-dev/dagu/internal/engine"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/executor"
+	"github.com/dagu-dev/dagu/internal/logfile"
+	"github.com/dagu-dev/dagu/internal/logforward"
 	"github.com/dagu-dev/dagu/internal/logger"
 	"github.com/dagu-dev/dagu/internal/mailer"
 	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/pool"
 	"github.com/dagu-dev/dagu/internal/reporter"
 	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/secret"
 	"github.com/dagu-dev/dagu/internal/sock"
+	"github.com/dagu-dev/dagu/internal/summary"
+	"github.com/dagu-dev/dagu/internal/tracing"
 	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/dagu-dev/dagu/internal/webhook"
 	"github.com/google/uuid"
 )
 
 var (
 	errFailedStartSocketFrontend = errors.New("failed to start the socket frontend")
-	errDAGAlreadyRunning         = errors.New("the DAG is already running")
+	errDAGAlreadyRunning         = dagerrors.New(dagerrors.CodeDAGRunning, "the DAG is already running")
+	errUnknownFaultStep          = dagerrors.New(dagerrors.CodeInvalidArgument, "unknown step of the fault")
 )
 
 // Agent is the interface to run / cancel / signal / status / etc.
@@ -46,11 +62,22 @@ type Agent struct {
 	graph            *scheduler.ExecutionGraph
 	logManager       *logManager
 	reporter         *reporter.Reporter
+	notifier         *notifier
+	webhooks         *webhook.Sender
+	summary          *summary.Writer
+	tracer           *tracing.Tracer
+	runSpan          *tracing.Span
 	historyStore     persistence.HistoryStore
 	socketServer     *sock.Server
+	logForwarder     *logforward.Forwarder
 	requestId        string
-	finished         atomic.Bool
-	lock             sync.RWMutex
+	// logKey is the key the logs of the run are encrypted with, if any.
+	logKey        []byte
+	restartedFrom string
+	stoppedBy     string
+	host          *model.Host
+	finished      atomic.Bool
+	lock          sync.RWMutex
 }
 
 func New(config *Config, e engine.Engine, ds persistence.DataStoreFactory) *Agent {
@@ -66,22 +93,51 @@ type Config struct {
 	DAG     *dag.DAG
 	DAGsDir string
 	Dry     bool
+	// LogDir is the directory of the logs of the run. The directory of the
+	// DAG in its log directory is used if it is empty.
+	LogDir string
 
 	// RetryTarget is the status to retry.
 	RetryTarget *model.Status
+
+	// Steps limits the run to the given steps.
+	Steps []string
+	// Schedule is the cron expression of the schedule entry that started
+	// the run.
+	Schedule string
+	// RestartedFrom is the request ID of the run that is restarted.
+	RestartedFrom string
+	// Initiator is who started the run through the API.
+	Initiator *model.Initiator
+	// RequestId is the request ID of the run. A new one is generated if
+	// it is empty.
+	RequestId string
+	// OutputsFile is the file the outputs of the steps are written to as
+	// a JSON object when the run finishes.
+	OutputsFile string
+	// NoCache runs the steps with a cache policy even if they have a
+	// cached result.
+	NoCache bool
+	// LogicalDate is the time the run is scheduled at. The time the run
+	// starts at is used if it is zero.
+	LogicalDate time.Time
+	// Faults are the failures injected into the steps of the run, e.g. to
+	// test the handlers in a test environment.
+	Faults []scheduler.Fault
+	// Clock is the time of the run and of its history, the clock of the
+	// process if it is nil.
+	Clock clock.Clock
 }
 
 // Run starts the dags execution.
 func (a *Agent) Run(ctx context.Context) error {
-	if err := func() error {
-		a.lock.Lock()
-		defer a.lock.Unlock()
-		if err := a.setupRequestId(); err != nil {
-			return err
-		}
-		a.init()
-		return a.setupGraph()
-	}(); err != nil {
+	if err := a.setup(); err != nil {
+		return err
+	}
+	if err := a.DAG.LoadDotenv(); err != nil {
+		return err
+	}
+	if err := secret.ResolveEnv(ctx); err != nil {
 		return err
 	}
 	if err := a.checkPreconditions(); err != nil {
@@ -90,11 +146,17 @@ func (a *Agent) Run(ctx context.Context) error {
 	if a.Dry {
 		return a.dryRun()
 	}
+	host := model.NewHost(ctx, a.DAG)
+	a.lock.Lock()
+	a.host = host
+	a.lock.Unlock()
 	for _, fn := range []func() error{
 		a.checkIsRunning,
 		a.setupDatabase,
+		a.setupArtifactDir,
 		a.setupSocketServer,
 		a.logManager.setupLogFile,
+		a.setupLogForward,
 	} {
 		if err := fn(); err != nil {
 			return err
@@ -124,6 +186,16 @@ func (a *Agent) Status() *model.Status {
 	status := model.NewStatus(a.DAG, ns, scStatus, os.Getpid(), st, et)
 	status.RequestId = a.requestId
 	status.Log = a.logManager.logFilename
+	status.Schedule = a.Schedule
+	status.RestartedFrom = a.restartedFrom
+	status.Initiator = a.Initiator
+	status.StoppedBy = a.stoppedBy
+	status.Host = a.host
+	status.QueuePosition = a.scheduler.QueuePosition()
+	if a.scheduler.Lane == pool.LaneManual {
+		status.Lane = model.LaneManual
+	}
+	status.Overtook = a.scheduler.Overtook()
 	if node := a.scheduler.HandlerNode(constants.OnExit); node != nil {
 		status.OnExit = model.FromNode(node.State(), node.Step())
 	}
@@ -136,6 +208,9 @@ func (a *Agent) Status() *model.Status {
 	if node := a.scheduler.HandlerNode(constants.OnCancel); node != nil {
 		status.OnCancel = model.FromNode(node.State(), node.Step())
 	}
+	if node := a.scheduler.HandlerNode(constants.OnTimeout); node != nil {
+		status.OnTimeout = model.FromNode(node.State(), node.Step())
+	}
 	return status
 }
 
@@ -182,14 +257,75 @@ func (a *Agent) signal(sig os.Signal, allowOverride bool) {
 	}
 }
 
+// Setup sets up the run like Run before it starts the steps, without the
+// history, the socket server and the log of the agent, and returns the
+// scheduler and the graph of the run. It is used to run the DAG in-process.
+func (a *Agent) Setup() (*scheduler.Scheduler, *scheduler.ExecutionGraph, error) {
+	if err := a.setup(); err != nil {
+		return nil, nil, err
+	}
+	if err := a.setupArtifactDir(); err != nil {
+		return nil, nil, err
+	}
+	return a.scheduler, a.graph, nil
+}
+
+// setup sets up the request ID, the templates of the DAG rendered with the
+// logical date, the scheduler and the graph of the steps of the run.
+func (a *Agent) setup() error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if err := a.setupRequestId(); err != nil {
+		return err
+	}
+	if err := a.renderTemplates(); err != nil {
+		return err
+	}
+	if err := a.checkFaults(); err != nil {
+		return err
+	}
+	if err := a.setupLogKey(); err != nil {
+		return err
+	}
+	a.init()
+	return a.setupGraph()
+}
+
+// lane returns the lane of the run in the queues of the pools. The runs
+// started by hand, which have no schedule and are not sub DAG runs, are in
+// the manual lane if the configuration has it.
+func (a *Agent) lane(cfg *config.Config) int {
+	if cfg.ManualRunLane && a.Schedule == "" && a.OutputsFile == "" {
+		return pool.LaneManual
+	}
+	return pool.LaneScheduled
+}
+
 func (a *Agent) init() {
-	logDir := path.Join(a.DAG.LogDir, utils.ValidFilename(a.DAG.Name, "_"))
+	logDir := a.LogDir
+	if logDir == "" {
+		logDir = path.Join(a.DAG.LogDir, utils.ValidFilename(a.DAG.Name, "_"))
+	}
+	cfg := config.Get()
+	pools := pool.New(filepath.Join(cfg.DataDir, "pools"), cfg.ConcurrencyPools)
 	config := &scheduler.Config{
-		LogDir:        logDir,
-		MaxActiveRuns: a.DAG.MaxActiveRuns,
-		Delay:         a.DAG.Delay,
-		Dry:           a.Dry,
-		RequestId:     a.requestId,
+		LogDir:         logDir,
+		MaxActiveRuns:  a.DAG.MaxActiveRuns,
+		DAGName:        a.DAG.Name,
+		LogFormat:      a.DAG.LogFormat,
+		LogCompression: a.DAG.LogCompression,
+		LogRotation:    a.DAG.LogRotation,
+		LogKey:         a.logKey,
+		Pool:           a.DAG.Pool,
+		Priority:       a.DAG.Priority,
+		Lane:           a.lane(cfg),
+		QueueTTL:       a.DAG.QueueTTL,
+		Pools:          pools,
+		Delay:          a.DAG.Delay,
+		Dry:            a.Dry,
+		RequestId:      a.requestId,
+		HandlerTimeout: time.Second * time.Duration(cfg.HandlerTimeoutSec),
+		Clock:          a.Clock,
 	}
 
 	if a.DAG.HandlerOn.Exit != nil {
@@ -207,6 +343,37 @@ func (a *Agent) init() {
 	if a.DAG.HandlerOn.Cancel != nil {
 		config.OnCancel = a.DAG.HandlerOn.Cancel
 	}
+
+	if a.DAG.HandlerOn.Timeout != nil {
+		config.OnTimeout = a.DAG.HandlerOn.Timeout
+	}
+	config.InputFetcher = &inputFetcher{
+		dagStore:      a.dataStoreFactory.NewDAGStore(),
+		historyStore:  a.dataStoreFactory.NewHistoryStore(),
+		artifactStore: a.dataStoreFactory.NewArtifactStore(),
+		name:          a.DAG.Name,
+		requestId:     a.requestId,
+	}
+	config.Faults = a.Faults
+	config.StepCache = a.dataStoreFactory.NewStepCache(a.DAG.Name)
+	config.NoCache = a.NoCache
+	config.Params = strings.Join(a.DAG.Params, " ")
+	config.ArtifactSaver = &artifactSaver{
+		artifactStore: a.dataStoreFactory.NewArtifactStore(),
+		name:          a.DAG.Name,
+		requestId:     a.requestId,
+	}
+	if ls := a.dataStoreFactory.NewLogStore(); ls != nil {
+		config.LogUploader = &logUploader{
+			logStore:  ls,
+			name:      a.DAG.Name,
+			requestId: a.requestId,
+		}
+	}
+	if a.tracer = tracing.New(cfg); a.tracer != nil {
+		a.runSpan = a.startRunSpan()
+		config.Tracer = &stepTracer{tracer: a.tracer, run: a.runSpan}
+	}
 	a.scheduler = &scheduler.Scheduler{Config: config}
 	a.reporter = &reporter.Reporter{
 		Config: &reporter.Config{
@@ -216,16 +383,38 @@ func (a *Agent) init() {
 					Port:     a.DAG.Smtp.Port,
 					Username: a.DAG.Smtp.Username,
 					Password: a.DAG.Smtp.Password,
+					Timeout:  time.Second * time.Duration(cfg.NotificationTimeoutSec),
+					Retries:  cfg.NotificationRetries,
 				},
 			},
+			AlertStore: a.dataStoreFactory.NewAlertStore(),
 		}}
+	a.notifier = newNotifier(cfg.NotificationWorkers)
+	a.webhooks = webhook.New(cfg)
+	a.summary = summary.NewWriter(cfg)
 	logFilename := filepath.Join(
 		logDir, fmt.Sprintf("agent_%s.%s.%s.log",
 			utils.ValidFilename(a.DAG.Name, "_"),
-			time.Now().Format("20060102.15:04:05.000"),
+			a.clock().Now().Format("20060102.15:04:05.000"),
 			utils.TruncString(a.requestId, 8),
 		))
-	a.logManager = &logManager{logFilename: logFilename}
+	a.logManager = &logManager{logFilename: logFilename, key: a.logKey}
+}
+
+// setupLogKey loads the key the logs of the run are encrypted with, the one
+// of the namespace of the run. The run fails if the key is not loaded, so
+// that the logs are not written in plain text.
+func (a *Agent) setupLogKey() error {
+	file := config.Get().EncryptionKeyFile
+	if file == "" {
+		return nil
+	}
+	key, err := logfile.LoadKey(file)
+	if err != nil {
+		return fmt.Errorf("failed to load the key of the logs: %w", err)
+	}
+	a.logKey = key
+	return logfile.RegisterKey(key)
 }
 
 func (a *Agent) setupGraph() (err error) {
@@ -233,7 +422,13 @@ func (a *Agent) setupGraph() (err error) {
 		log.Printf("setup for retry")
 		return a.setupRetry()
 	}
-	a.graph, err = scheduler.NewExecutionGraph(a.DAG.Steps...)
+	steps := a.DAG.Steps
+	if len(a.Steps) > 0 {
+		if steps, err = a.DAG.SelectSteps(a.Steps); err != nil {
+			return dagerrors.WithCode(dagerrors.CodeInvalidArgument, err)
+		}
+	}
+	a.graph, err = scheduler.NewExecutionGraph(steps...)
 	return
 }
 
@@ -247,6 +442,10 @@ func (a *Agent) setupRetry() (err error) {
 }
 
 func (a *Agent) setupRequestId() error {
+	if a.RequestId != "" {
+		a.requestId = a.RequestId
+		return nil
+	}
 	id, err := uuid.NewRandom()
 	if err != nil {
 		return err
@@ -255,14 +454,72 @@ func (a *Agent) setupRequestId() error {
 	return nil
 }
 
+// renderTemplates renders the templates in the DAG with the logical date
+// and the metadata of the run.
+func (a *Agent) renderTemplates() error {
+	logicalDate := a.LogicalDate
+	if logicalDate.IsZero() {
+		logicalDate = a.clock().Now()
+	}
+	return dagerrors.WithCode(dagerrors.CodeInvalidArgument, a.DAG.RenderTemplates(&dag.TemplateData{
+		LogicalDate: logicalDate,
+		RequestId:   a.requestId,
+		Name:        a.DAG.Name,
+		Schedule:    a.Schedule,
+	}))
+}
+
 func (a *Agent) setupDatabase() error {
 	// TODO: do not use the persistence package directly.
 	a.historyStore = a.dataStoreFactory.NewHistoryStore()
 	if err := a.historyStore.RemoveOld(a.DAG.Location, a.DAG.HistRetentionDays); err != nil {
 		utils.LogErr("clean old history data", err)
 	}
+	a.linkRestartedRun()
 
-	return a.historyStore.Open(a.DAG.Location, time.Now(), a.requestId)
+	return a.historyStore.Open(a.DAG.Location, a.clock().Now(), a.requestId)
+}
+
+// linkRestartedRun records the request ID of the run on the run it
+// restarts, so that the history can be followed from either of them. A run
+// of a start entry restarts the latest run if a stop entry stopped it.
+func (a *Agent) linkRestartedRun() {
+	restartedFrom := a.RestartedFrom
+	var prev *model.Status
+	if restartedFrom != "" {
+		if f, err := a.historyStore.FindByRequestId(a.DAG.Location, restartedFrom); err == nil {
+			prev = f.Status
+		}
+	} else if a.Schedule != "" {
+		if recent := a.historyStore.ReadStatusRecent(a.DAG.Location, 1); len(recent) > 0 {
+			st := recent[0].Status
+			if st.StoppedBy != "" && st.RestartedAs == "" && st.Status == scheduler.StatusCancel {
+				prev, restartedFrom = st, st.RequestId
+			}
+		}
+	}
+	a.lock.Lock()
+	a.restartedFrom = restartedFrom
+	a.lock.Unlock()
+	if prev == nil {
+		return
+	}
+	prev.RestartedAs = a.requestId
+	utils.LogErr("link the restarted run", a.historyStore.Update(a.DAG.Location, prev.RequestId, prev))
+}
+
+func (a *Agent) clock() clock.Clock {
+	return clock.OrDefault(a.Clock)
+}
+
+// setupArtifactDir creates the directory of the artifacts of the run and
+// sets it to the environment of the steps.
+func (a *Agent) setupArtifactDir() error {
+	dir := a.dataStoreFactory.NewArtifactStore().Dir(a.DAG.Name, a.requestId)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.Setenv(envArtifactsDir, dir)
 }
 
 func (a *Agent) setupSocketServer() (err error) {
@@ -274,6 +531,80 @@ func (a *Agent) setupSocketServer() (err error) {
 	return
 }
 
+// setupLogForward sets up forwarding of the agent and step logs if it is
+// enabled in the configuration. Failing to set it up does not stop the run.
+func (a *Agent) setupLogForward() error {
+	cfg := config.Get().LogForward
+	if cfg == nil || cfg.Type == "" {
+		return nil
+	}
+	fwd, err := logforward.NewFromConfig(cfg)
+	if err != nil {
+		log.Printf("failed to setup log forwarding: %v", err)
+		return nil
+	}
+	a.logForwarder = fwd
+	a.scheduler.LogForwarder = fwd
+	a.scheduler.LogLabels = a.logLabels("step")
+	return nil
+}
+
+func (a *Agent) logLabels(source string) map[string]string {
+	return map[string]string{
+		"dag":    a.DAG.Name,
+		"run_id": a.requestId,
+		"source": source,
+	}
+}
+
+// writeOutputs writes the output variables of the steps to the outputs file
+// so that the parent DAG of a sub DAG run can read them.
+func (a *Agent) writeOutputs() error {
+	if a.OutputsFile == "" {
+		return nil
+	}
+	outputs := map[string]string{}
+	for _, n := range a.graph.Nodes() {
+		step := n.Step()
+		if step.OutputVariables == nil {
+			continue
+		}
+		step.OutputVariables.Range(func(_, value any) bool {
+			if k, v, ok := strings.Cut(value.(string), "="); ok {
+				outputs[k] = v
+			}
+			return true
+		})
+	}
+	b, err := json.Marshal(outputs)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(a.OutputsFile, b, 0600)
+}
+
+// checkFaults returns an error if a fault is injected into a step that is
+// not a step or a handler of the DAG.
+func (a *Agent) checkFaults() error {
+	var names []string
+	for _, s := range a.DAG.Steps {
+		names = append(names, s.Name)
+	}
+	h := a.DAG.HandlerOn
+	for _, s := range []*dag.Step{h.Exit, h.Success, h.Failure, h.Cancel, h.Timeout} {
+		if s != nil {
+			names = append(names, s.Name)
+		}
+	}
+	for _, f := range a.Faults {
+		if !slices.Contains(names, f.Step) {
+			return fmt.Errorf("%w: %s", errUnknownFaultStep, f.Step)
+		}
+		log.Printf("injecting the fault %s into \"%s\"", f.Kind, f.Step)
+	}
+	return nil
+}
+
 func (a *Agent) checkPreconditions() error {
 	if len(a.DAG.Preconditions) > 0 {
 		log.Printf("checking preconditions for \"%s\"", a.DAG.Name)
@@ -286,11 +617,24 @@ func (a *Agent) checkPreconditions() error {
 }
 
 func (a *Agent) run(ctx context.Context) error {
-	tl := &logger.Tee{Writer: a.logManager.logFile}
+	var logWriter io.Writer = a.logManager.logFile
+	if a.logForwarder != nil {
+		defer func() {
+			utils.LogErr("close log forwarder", a.logForwarder.Close())
+		}()
+		w := a.logForwarder.Writer(a.logLabels("agent"))
+		defer func() {
+			_ = w.Close()
+		}()
+		logWriter = io.MultiWriter(logWriter, w)
+	}
+	mw := secret.NewMaskWriter(logWriter)
+	tl := &logger.Tee{Writer: mw}
 	if err := tl.Open(); err != nil {
 		return err
 	}
 	defer func() {
+		utils.LogErr("close mask writer", mw.Close())
 		utils.LogErr("close log file", a.closeLogFile())
 		tl.Close()
 	}()
@@ -301,6 +645,7 @@ func (a *Agent) run(ctx context.Context) error {
 		}
 	}()
 
+	a.logCapabilities()
 	utils.LogErr("write status", a.historyStore.Write(a.Status()))
 
 	listen := make(chan error)
@@ -326,7 +671,13 @@ func (a *Agent) run(ctx context.Context) error {
 		for node := range done {
 			status := a.Status()
 			utils.LogErr("write status", a.historyStore.Write(status))
-			utils.LogErr("report step", a.reporter.ReportStep(a.DAG, status, node))
+			a.notifier.send("report step", func() error {
+				return a.reporter.ReportStep(a.DAG, status, node)
+			})
+			if st := node.State().Status; st == scheduler.NodeStatusError || st == scheduler.NodeStatusTimeout {
+				a.sendEvent(webhook.EventStepFailed, status, node, nil)
+			}
+			a.exportSpans()
 		}
 	}()
 
@@ -339,22 +690,60 @@ func (a *Agent) run(ctx context.Context) error {
 	}()
 
 	ctx = dag.NewContext(ctx, a.DAG, a.dataStoreFactory.NewDAGStore())
+	ctx = dag.WithNotifier(ctx, stepNotifier{a: a})
 
+	if a.Initiator != nil {
+		log.Printf("started by %q with the API token %q", a.Initiator.User, a.Initiator.Token)
+	}
+	started := a.Status()
+	started.Status = scheduler.StatusRunning
+	started.StatusText = started.Status.String()
+	a.sendEvent(webhook.EventRunStarted, started, nil, nil)
+	stopSLA := a.watchSLA()
 	lastErr := a.scheduler.Schedule(ctx, a.graph, done)
+	stopSLA()
 	status := a.Status()
 
 	log.Println("schedule finished.")
 	utils.LogErr("write status", a.historyStore.Write(a.Status()))
 
 	a.reporter.ReportSummary(status, lastErr)
-	utils.LogErr("send email", a.reporter.SendMail(a.DAG, status, lastErr))
+	utils.LogErr("write outputs", a.writeOutputs())
+	a.notifier.send("send email", func() error {
+		return a.reporter.SendMail(a.DAG, status, lastErr)
+	})
+	a.sendEvent(runEvent(status, lastErr), status, nil, lastErr)
+	a.writeSummary(status, lastErr)
+	a.endRunSpan(status, lastErr)
 
 	a.finished.Store(true)
 	utils.LogErr("close data file", a.historyStore.Close())
 
+	// The status of the run is final before the notifications are sent.
+	a.notifier.wait()
+
 	return lastErr
 }
 
+// logCapabilities logs the executor features the DAG depends on that are not
+// available on the platform.
+func (a *Agent) logCapabilities() {
+	usesDocker := false
+	for _, s := range a.DAG.Steps {
+		if s.ExecutorConfig.Type == "docker" {
+			usesDocker = true
+		}
+	}
+	for _, c := range executor.Capabilities() {
+		switch {
+		case c.Available:
+		case c.Name == executor.CapabilityProcessGroup,
+			c.Name == executor.CapabilityDockerSocket && usesDocker:
+			log.Printf("%s is not available: %s", c.Name, c.Detail)
+		}
+	}
+}
+
 func (a *Agent) dryRun() error {
 	done := make(chan *scheduler.Node)
 	defer func() {
@@ -375,6 +764,7 @@ func (a *Agent) dryRun() error {
 	lastErr := a.scheduler.Schedule(ctx, a.graph, done)
 	status := a.Status()
 	a.reporter.ReportSummary(status, lastErr)
+	utils.LogErr("write outputs", a.writeOutputs())
 
 	log.Printf("***** Finished DRY-RUN *****")
 
@@ -400,8 +790,16 @@ func (a *Agent) closeLogFile() error {
 }
 
 var (
-	statusRe = regexp.MustCompile(`^/status[/]?$`)
-	stopRe   = regexp.MustCompile(`^/stop[/]?$`)
+	statusRe  = regexp.MustCompile(`^/status[/]?$`)
+	stopRe    = regexp.MustCompile(`^/stop[/]?$`)
+	markRe    = regexp.MustCompile(`^/mark[/]?$`)
+	restartRe = regexp.MustCompile(`^/restart[/]?$`)
+
+	// markStatuses are the statuses a running step can be marked with.
+	markStatuses = map[string]scheduler.NodeStatus{
+		"success": scheduler.NodeStatusSuccess,
+		"failed":  scheduler.NodeStatusError,
+	}
 )
 
 func (a *Agent) HandleHTTP(w http.ResponseWriter, r *http.Request) {
@@ -418,12 +816,50 @@ func (a *Agent) HandleHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write(b)
 	case r.Method == http.MethodPost && stopRe.MatchString(r.URL.Path):
+		query := r.URL.Query()
+		if schedule := query.Get("schedule"); schedule != "" {
+			a.lock.Lock()
+			a.stoppedBy = schedule
+			a.lock.Unlock()
+		}
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("OK"))
+		if steps := query["step"]; len(steps) > 0 {
+			go func() {
+				log.Printf("stop request received. stopping %s...", strings.Join(steps, ", "))
+				a.scheduler.SignalSteps(a.graph, syscall.SIGTERM, steps)
+			}()
+			return
+		}
 		go func() {
 			log.Printf("stop request received. shutting down...")
 			a.signal(syscall.SIGTERM, true)
 		}()
+	case r.Method == http.MethodPost && markRe.MatchString(r.URL.Path):
+		query := r.URL.Query()
+		status, ok := markStatuses[query.Get("status")]
+		if !ok || query.Get("step") == "" || query.Get("reason") == "" {
+			encodeError(w, &HTTPError{Code: http.StatusBadRequest, Message: "step, status and reason are required"})
+			return
+		}
+		if err := a.scheduler.MarkStep(a.graph, query.Get("step"), status, query.Get("reason")); err != nil {
+			encodeError(w, &HTTPError{Code: dagerrors.CodeOf(err).Category().HTTPStatus(), Message: err.Error()})
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("OK"))
+	case r.Method == http.MethodPost && restartRe.MatchString(r.URL.Path):
+		steps := r.URL.Query()["step"]
+		if len(steps) == 0 {
+			encodeError(w, &HTTPError{Code: http.StatusBadRequest, Message: "step is required"})
+			return
+		}
+		if err := a.scheduler.RestartSteps(a.graph, steps); err != nil {
+			encodeError(w, &HTTPError{Code: dagerrors.CodeOf(err).Category().HTTPStatus(), Message: err.Error()})
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("OK"))
 	default:
 		encodeError(w, &HTTPError{Code: http.StatusNotFound, Message: "Not found"})
 	}
@@ -431,7 +867,9 @@ func (a *Agent) HandleHTTP(w http.ResponseWriter, r *http.Request) {
 
 type logManager struct {
 	logFilename string
-	logFile     *os.File
+	logFile     io.WriteCloser
+	// key is the key the log is encrypted with, if any.
+	key []byte
 }
 
 func (l *logManager) setupLogFile() (err error) {
@@ -439,8 +877,12 @@ func (l *logManager) setupLogFile() (err error) {
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return err
 	}
-	l.logFile, err = utils.OpenOrCreateFile(l.logFilename)
-	return
+	w, err := logfile.NewEncryptedWriter(l.logFilename, 0, 0, l.key)
+	if err != nil {
+		return err
+	}
+	l.logFile = w
+	return nil
 }
 
 type HTTPError struct {
@@ -457,6 +899,6 @@ func encodeError(w http.ResponseWriter, err error) {
 	if errors.As(err, &httpErr) {
 		http.Error(w, httpErr.Error(), httpErr.Code)
 	} else {
-		http.Error(w, httpErr.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
@@ -2,10 +2,18 @@ package agent_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path"
+	"runtime"
+	"strings"
+	"sync"
 	"syscall"
 	"testing"
 	"time"
@@ -15,12 +23,18 @@ import (
 	"github.com/dagu-dev/dagu/internal/persistence/client"
 
 	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/constants"
 	"github.com/dagu-dev/dagu/internal/dag"
 	"github.com/dagu-dev/dagu/internal/engine"
 	"github.com/dagu-dev/dagu/internal/persistence/model"
 	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/summary"
 	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/dagu-dev/dagu/internal/webhook"
 	"github.com/stretchr/testify/require"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
 )
 
 var testdataDir = path.Join(utils.MustGetwd(), "testdata")
@@ -325,6 +339,309 @@ func TestHandleHTTP(t *testing.T) {
 	require.Equal(t, status.Status, scheduler.StatusCancel)
 }
 
+func TestRunSelectedSteps(t *testing.T) {
+	tmpDir, e, df := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	d := testLoadDAG(t, "multiple_steps.yaml")
+	a := agent.New(&agent.Config{DAG: d, Steps: []string{"2"}, Schedule: "0 1 * * *"}, e, df)
+	err := a.Run(context.Background())
+	require.NoError(t, err)
+
+	status := a.Status()
+	require.Equal(t, scheduler.StatusSuccess, status.Status)
+	require.Len(t, status.Nodes, 1)
+	require.Equal(t, "2", status.Nodes[0].Name)
+	require.Equal(t, "0 1 * * *", status.Schedule)
+
+	a = agent.New(&agent.Config{DAG: d, Steps: []string{"unknown"}}, e, df)
+	err = a.Run(context.Background())
+	require.Error(t, err)
+}
+
+func TestWriteOutputs(t *testing.T) {
+	tmpDir, e, df := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	d := testLoadDAG(t, "outputs.yaml")
+	outputsFile := path.Join(tmpDir, "outputs.json")
+	a := agent.New(&agent.Config{DAG: d, RequestId: "sub-run", OutputsFile: outputsFile}, e, df)
+	err := a.Run(context.Background())
+	require.NoError(t, err)
+
+	status, err := e.GetStatusByRequestId(d, "sub-run")
+	require.NoError(t, err)
+	require.Equal(t, scheduler.StatusSuccess, status.Status)
+
+	b, err := os.ReadFile(outputsFile)
+	require.NoError(t, err)
+	var outputs map[string]string
+	require.NoError(t, json.Unmarshal(b, &outputs))
+	require.Equal(t, map[string]string{"GREETING": "hello", "NAME": "world"}, outputs)
+}
+
+func TestRenderTemplates(t *testing.T) {
+	tmpDir, e, df := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	d := testLoadDAG(t, "templates.yaml")
+	outputsFile := path.Join(tmpDir, "outputs.json")
+	logicalDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	a := agent.New(&agent.Config{DAG: d, OutputsFile: outputsFile, LogicalDate: logicalDate}, e, df)
+	require.NoError(t, a.Run(context.Background()))
+
+	b, err := os.ReadFile(outputsFile)
+	require.NoError(t, err)
+	var outputs map[string]string
+	require.NoError(t, json.Unmarshal(b, &outputs))
+	require.Equal(t, map[string]string{"RENDERED": "2024-03-01 templates"}, outputs)
+}
+
+func TestRecordHost(t *testing.T) {
+	tmpDir, e, df := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	d := testLoadDAG(t, "tool_versions.yaml")
+	a := agent.New(&agent.Config{DAG: d}, e, df)
+	require.NoError(t, a.Run(context.Background()))
+
+	status, err := e.GetLatestStatus(d)
+	require.NoError(t, err)
+	require.NotNil(t, status.Host)
+	require.Equal(t, runtime.GOOS, status.Host.OS)
+	require.Equal(t, []*model.ToolResult{{Name: "sh", Version: "sh-1.0"}}, status.Host.ToolVersions)
+}
+
+func TestFetchInputs(t *testing.T) {
+	tmpDir, e, _ := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	df := client.NewDataStoreFactory(&config.Config{
+		DataDir: path.Join(tmpDir, ".dagu", "data"),
+		DAGs:    testdataDir,
+	})
+	t.Setenv("INPUT_DIR", path.Join(tmpDir, "inputs"))
+
+	// There is no successful run of the producer yet.
+	consumer := testLoadDAG(t, "artifact_consumer.yaml")
+	a := agent.New(&agent.Config{DAG: consumer}, e, df)
+	require.Error(t, a.Run(context.Background()))
+	require.Contains(t, a.Status().Nodes[0].Error, "no successful run of artifact_producer")
+
+	producer := testLoadDAG(t, "artifact_producer.yaml")
+	a = agent.New(&agent.Config{DAG: producer}, e, df)
+	require.NoError(t, a.Run(context.Background()))
+
+	a = agent.New(&agent.Config{DAG: consumer}, e, df)
+	require.NoError(t, a.Run(context.Background()))
+	require.Equal(t, "a,b", os.Getenv("REPORT"))
+
+	// The run of the producer is older than the freshness window.
+	time.Sleep(10 * time.Millisecond)
+	a = agent.New(&agent.Config{DAG: testLoadDAG(t, "artifact_consumer_fresh.yaml")}, e, df)
+	require.Error(t, a.Run(context.Background()))
+	require.Contains(t, a.Status().Nodes[0].Error, "no successful run of artifact_producer within 1ms")
+}
+
+func TestSaveArtifacts(t *testing.T) {
+	tmpDir, e, df := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	t.Setenv("WORK_DIR", path.Join(tmpDir, "work"))
+	t.Setenv("INPUT_DIR", path.Join(tmpDir, "inputs"))
+	require.NoError(t, os.MkdirAll(path.Join(tmpDir, "work"), 0755))
+
+	d := testLoadDAG(t, "artifact_steps.yaml")
+	a := agent.New(&agent.Config{DAG: d}, e, df)
+	require.NoError(t, a.Run(context.Background()))
+	require.Equal(t, "app", os.Getenv("APP"))
+
+	status, err := e.GetLatestStatus(d)
+	require.NoError(t, err)
+	r, err := df.NewArtifactStore().Open(d.Name, status.RequestId, "dist/app.txt")
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	// The step fails if nothing matches its artifacts.
+	d.Steps[0].Artifacts = []string{"build/*.txt"}
+	a = agent.New(&agent.Config{DAG: d}, e, df)
+	require.Error(t, a.Run(context.Background()))
+	require.Contains(t, a.Status().Nodes[0].Error, "no files match the artifact pattern: build/*.txt")
+}
+
+func TestResolveSecrets(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"password":"vault-password"}}`))
+	}))
+	defer srv.Close()
+	t.Setenv("DAGU_VAULT_ADDR", srv.URL)
+	t.Setenv("DAGU_VAULT_TOKEN", "root")
+
+	tmpDir, e, df := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	d := testLoadDAG(t, "secret.yaml")
+	a := agent.New(&agent.Config{DAG: d}, e, df)
+	err := a.Run(context.Background())
+	require.NoError(t, err)
+
+	status, err := e.GetLatestStatus(d)
+	require.NoError(t, err)
+	require.Equal(t, scheduler.StatusSuccess, status.Status)
+
+	b, err := os.ReadFile(e.GetRecentHistory(d, 1)[0].File)
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "vault-password")
+
+	log, err := os.ReadFile(status.Nodes[0].Log)
+	require.NoError(t, err)
+	require.Equal(t, "*****\n", string(log))
+}
+
+func TestStopSteps(t *testing.T) {
+	tmpDir, e, df := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	d := testLoadDAG(t, "stop_steps.yaml")
+	a := agent.New(&agent.Config{DAG: d}, e, df)
+
+	done := make(chan struct{})
+	go func() {
+		_ = a.Run(context.Background())
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool {
+		return a.Status().Status == scheduler.StatusRunning
+	}, time.Second*2, time.Millisecond*50)
+
+	var mockResponseWriter = mockResponseWriter{}
+	req := &http.Request{
+		Method: "POST",
+		URL: &url.URL{
+			Path:     "/stop",
+			RawQuery: url.Values{"step": {"server"}, "schedule": {"0 2 * * *"}}.Encode(),
+		},
+	}
+	a.HandleHTTP(&mockResponseWriter, req)
+	require.Equal(t, http.StatusOK, mockResponseWriter.status)
+
+	<-done
+
+	status := a.Status()
+	require.Equal(t, scheduler.NodeStatusCancel, status.Nodes[0].Status)
+	require.Equal(t, scheduler.NodeStatusSuccess, status.Nodes[1].Status)
+	require.Equal(t, "0 2 * * *", status.StoppedBy)
+}
+
+func TestLinkRestartedRun(t *testing.T) {
+	tmpDir, e, df := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	d := testLoadDAG(t, "sleep.yaml")
+	runAndStop := func(cfg *agent.Config, query url.Values) *model.Status {
+		cfg.DAG = d
+		a := agent.New(cfg, e, df)
+		done := make(chan struct{})
+		go func() {
+			_ = a.Run(context.Background())
+			close(done)
+		}()
+		require.Eventually(t, func() bool {
+			return a.Status().Status == scheduler.StatusRunning
+		}, time.Second*2, time.Millisecond*50)
+		var mockResponseWriter = mockResponseWriter{}
+		a.HandleHTTP(&mockResponseWriter, &http.Request{
+			Method: "POST",
+			URL:    &url.URL{Path: "/stop", RawQuery: query.Encode()},
+		})
+		require.Equal(t, http.StatusOK, mockResponseWriter.status)
+		<-done
+		return a.Status()
+	}
+	restartedAs := func(requestId string) string {
+		status, err := e.GetStatusByRequestId(d, requestId)
+		require.NoError(t, err)
+		return status.RestartedAs
+	}
+
+	// A run of a start entry restarts the run a stop entry stopped.
+	stopped := runAndStop(&agent.Config{}, url.Values{"schedule": {"0 20 * * *"}})
+	started := runAndStop(&agent.Config{Schedule: "0 8 * * *"}, nil)
+	require.Equal(t, stopped.RequestId, started.RestartedFrom)
+	require.Equal(t, started.RequestId, restartedAs(stopped.RequestId))
+
+	// The run the restart command stopped is linked to the new run.
+	restarted := runAndStop(&agent.Config{RestartedFrom: started.RequestId}, nil)
+	require.Equal(t, started.RequestId, restarted.RestartedFrom)
+	require.Equal(t, restarted.RequestId, restartedAs(started.RequestId))
+
+	// A run of a start entry does not restart a run stopped by hand.
+	next := runAndStop(&agent.Config{Schedule: "0 8 * * *"}, nil)
+	require.Empty(t, next.RestartedFrom)
+	require.Empty(t, restartedAs(restarted.RequestId))
+}
+
+func TestMarkStep(t *testing.T) {
+	tmpDir, e, df := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	d := testLoadDAG(t, "mark_step.yaml")
+	a := agent.New(&agent.Config{DAG: d}, e, df)
+
+	done := make(chan struct{})
+	go func() {
+		_ = a.Run(context.Background())
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool {
+		return a.Status().Nodes[0].Status == scheduler.NodeStatusRunning
+	}, time.Second*2, time.Millisecond*50)
+
+	mark := func(query url.Values) int {
+		var mockResponseWriter = mockResponseWriter{}
+		a.HandleHTTP(&mockResponseWriter, &http.Request{
+			Method: "POST",
+			URL:    &url.URL{Path: "/mark", RawQuery: query.Encode()},
+		})
+		return mockResponseWriter.status
+	}
+
+	// the reason is required
+	require.Equal(t, http.StatusBadRequest, mark(url.Values{"step": {"stuck"}, "status": {"success"}}))
+	require.Equal(t, http.StatusBadRequest, mark(url.Values{"step": {"stuck"}, "status": {"skipped"}, "reason": {"done"}}))
+	require.Equal(t, http.StatusNotFound, mark(url.Values{"step": {"missing"}, "status": {"success"}, "reason": {"done"}}))
+	require.Equal(t, http.StatusConflict, mark(url.Values{"step": {"downstream"}, "status": {"success"}, "reason": {"done"}}))
+	require.Equal(t, http.StatusOK, mark(url.Values{"step": {"stuck"}, "status": {"success"}, "reason": {"confirmed by the upstream"}}))
+
+	<-done
+
+	status := a.Status()
+	require.Equal(t, scheduler.StatusSuccess, status.Status)
+	require.Equal(t, scheduler.NodeStatusSuccess, status.Nodes[0].Status)
+	require.Equal(t, "confirmed by the upstream", status.Nodes[0].MarkedReason)
+	require.Equal(t, scheduler.NodeStatusSuccess, status.Nodes[1].Status)
+}
+
 type mockResponseWriter struct {
 	status int
 	body   string
@@ -356,3 +673,128 @@ func testLoadDAG(t *testing.T, name string) *dag.DAG {
 	require.NoError(t, err)
 	return d
 }
+
+func TestWebhookEvents(t *testing.T) {
+	tmpDir, e, df := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	var mu sync.Mutex
+	var events []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ev := &webhook.Event{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(ev))
+		require.Equal(t, ev.Event, r.Header.Get(webhook.HeaderEvent))
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, ev.Event+" "+ev.Step)
+	}))
+	defer srv.Close()
+	cfg := config.Get()
+	cfg.Webhooks = []config.Webhook{{Name: "test", URL: srv.URL}}
+	defer func() {
+		cfg.Webhooks = nil
+	}()
+
+	received := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		ret := events
+		events = nil
+		return ret
+	}
+
+	a := agent.New(&agent.Config{DAG: testLoadDAG(t, "error.yaml")}, e, df)
+	require.Error(t, a.Run(context.Background()))
+	require.ElementsMatch(t, []string{"run.started ", "step.failed 1", "run.failed "}, received())
+
+	a = agent.New(&agent.Config{DAG: testLoadDAG(t, "sla.yaml")}, e, df)
+	require.NoError(t, a.Run(context.Background()))
+	require.ElementsMatch(t, []string{"run.started ", "sla.missed ", "run.succeeded "}, received())
+}
+
+func TestRunSummary(t *testing.T) {
+	tmpDir, e, df := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	file := path.Join(tmpDir, "summaries", "runs.jsonl")
+	cfg := config.Get()
+	cfg.RunSummary = &config.RunSummary{Sink: summary.SinkFile, Path: file}
+	defer func() {
+		cfg.RunSummary = nil
+	}()
+
+	a := agent.New(&agent.Config{DAG: testLoadDAG(t, "summary.yaml")}, e, df)
+	require.Error(t, a.Run(context.Background()))
+
+	b, err := os.ReadFile(file)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	require.Len(t, lines, 1)
+	s := &summary.Summary{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), s))
+	require.Equal(t, a.DAG.Name, s.DAG)
+	require.Equal(t, scheduler.StatusError.String(), s.Status)
+	require.Equal(t, 1, s.Retries)
+
+	require.Len(t, s.Steps, 3)
+	require.Equal(t, "1", s.Steps[0].Name)
+	sum := sha256.Sum256([]byte("hello"))
+	require.Equal(t, map[string]string{"GREETING": "sha256:" + hex.EncodeToString(sum[:])}, s.Steps[0].Outputs)
+	require.Equal(t, 1, s.Steps[1].Retries)
+	require.Equal(t, scheduler.NodeStatusError.String(), s.Steps[1].Status)
+	require.Equal(t, constants.OnExit, s.Steps[2].Name)
+}
+
+func TestTracing(t *testing.T) {
+	tmpDir, e, df := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	var mu sync.Mutex
+	spans := map[string]*tracepb.Span{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/traces", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req := &coltracepb.ExportTraceServiceRequest{}
+		require.NoError(t, proto.Unmarshal(body, req))
+		mu.Lock()
+		defer mu.Unlock()
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, s := range ss.Spans {
+					spans[s.Name] = s
+				}
+			}
+		}
+	}))
+	defer srv.Close()
+	cfg := config.Get()
+	cfg.Tracing = &config.Tracing{Endpoint: srv.URL}
+	defer func() {
+		cfg.Tracing = nil
+	}()
+
+	d := testLoadDAG(t, "trace.yaml")
+	a := agent.New(&agent.Config{DAG: d}, e, df)
+	require.Error(t, a.Run(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	run, step1, step2 := spans[d.Name], spans["1"], spans["2"]
+	require.Len(t, spans, 3)
+	require.Empty(t, run.ParentSpanId)
+	require.Equal(t, tracepb.Status_STATUS_CODE_ERROR, run.Status.Code)
+	require.Equal(t, run.SpanId, step1.ParentSpanId)
+	require.Equal(t, run.TraceId, step2.TraceId)
+	require.Equal(t, tracepb.Status_STATUS_CODE_OK, step1.Status.Code)
+	require.Equal(t, tracepb.Status_STATUS_CODE_ERROR, step2.Status.Code)
+
+	// The step gets the trace context of its span.
+	require.Equal(t, "00-"+hex.EncodeToString(step1.TraceId)+"-"+hex.EncodeToString(step1.SpanId)+"-01", os.Getenv("TRACE_OUT"))
+}
@@ -0,0 +1,86 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+)
+
+var errNoArtifactMatch = errors.New("no files match the artifact pattern")
+
+// artifactSaver copies the artifacts of the steps to the directory of the
+// artifacts of the run and publishes them to the backend of the store.
+type artifactSaver struct {
+	artifactStore persistence.ArtifactStore
+	name          string
+	requestId     string
+}
+
+var _ scheduler.ArtifactSaver = (*artifactSaver)(nil)
+
+func (s *artifactSaver) Save(ctx context.Context, dir string, patterns []string) error {
+	if dir == "" {
+		dir = "."
+	}
+	dst := s.artifactStore.Dir(s.name, s.requestId)
+	var paths []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return err
+		}
+		if len(matches) == 0 {
+			return fmt.Errorf("%w: %s", errNoArtifactMatch, pattern)
+		}
+		for _, m := range matches {
+			// The directories are saved with all their files.
+			err := filepath.WalkDir(m, func(p string, e fs.DirEntry, err error) error {
+				if err != nil || e.IsDir() {
+					return err
+				}
+				rel, err := filepath.Rel(dir, p)
+				if err != nil {
+					return err
+				}
+				if err := copyFile(p, filepath.Join(dst, rel)); err != nil {
+					return err
+				}
+				paths = append(paths, filepath.ToSlash(rel))
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return s.artifactStore.Publish(ctx, s.name, s.requestId, paths)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,89 @@
+package agent
+
+import (
+	"log"
+	"sync"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/summary"
+	"github.com/dagu-dev/dagu/internal/webhook"
+)
+
+// sendEvent posts the event of the run, or of the step of the node if it is
+// not nil, to the webhooks. The errors of the webhooks are logged.
+func (a *Agent) sendEvent(kind string, status *model.Status, node *scheduler.Node, err error) {
+	if a.webhooks == nil {
+		return
+	}
+	e := &webhook.Event{
+		Event:      kind,
+		Time:       a.clock().Now(),
+		DAG:        a.DAG.Name,
+		RequestId:  a.requestId,
+		Namespace:  config.Get().Namespace,
+		Status:     status.StatusText,
+		StartedAt:  status.StartedAt,
+		FinishedAt: status.FinishedAt,
+	}
+	if node != nil {
+		e.Step = node.Step().Name
+		err = node.State().Error
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	a.notifier.send("send webhook", func() error {
+		return a.webhooks.Send(e)
+	})
+}
+
+// writeSummary writes the summary of the finished run to the sink of the run
+// summaries. The errors of the sink are logged.
+func (a *Agent) writeSummary(status *model.Status, err error) {
+	if a.summary == nil {
+		return
+	}
+	s := summary.New(status, config.Get().Namespace, err)
+	a.notifier.send("write run summary", func() error {
+		return a.summary.Write(s)
+	})
+}
+
+// runEvent returns the kind of the event of the finished run.
+func runEvent(status *model.Status, err error) string {
+	switch {
+	case status.Status == scheduler.StatusCancel, status.Status == scheduler.StatusExpired:
+		return webhook.EventRunCancelled
+	case err != nil, status.Status == scheduler.StatusError:
+		return webhook.EventRunFailed
+	}
+	return webhook.EventRunSucceeded
+}
+
+// watchSLA sends the sla.missed event if the run is still running after the
+// SLA of the DAG. The returned function stops the watch before the run
+// finishes.
+func (a *Agent) watchSLA() func() {
+	if a.DAG.SLA <= 0 || a.webhooks == nil {
+		return func() {}
+	}
+	missed := a.clock().After(a.DAG.SLA)
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		select {
+		case <-missed:
+			log.Printf("the run missed the SLA of %s", a.DAG.SLA)
+			a.sendEvent(webhook.EventSLAMissed, a.Status(), nil, nil)
+		case <-stop:
+		}
+	}()
+	return func() {
+		close(stop)
+		wg.Wait()
+	}
+}
@@ -0,0 +1,114 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/utils"
+)
+
+const (
+	// envArtifactsDir is the environment variable of the directory the
+	// steps write the artifacts of the run to.
+	envArtifactsDir = "DAG_ARTIFACTS_DIR"
+	// recentRunsToSearch is the number of recent runs searched for the
+	// latest successful run.
+	recentRunsToSearch = 100
+)
+
+var (
+	errNoSuccessfulRun = errors.New("no successful run")
+	errRunNotSucceeded = errors.New("the run did not succeed")
+)
+
+// inputFetcher fetches the input artifacts of the steps from the artifacts of
+// the current run or of the runs of other DAGs.
+type inputFetcher struct {
+	dagStore      persistence.DAGStore
+	historyStore  persistence.HistoryStore
+	artifactStore persistence.ArtifactStore
+	// name and requestId are of the current run.
+	name      string
+	requestId string
+}
+
+var _ scheduler.InputFetcher = (*inputFetcher)(nil)
+
+func (f *inputFetcher) Fetch(_ context.Context, input dag.Input, dst string) error {
+	ref, err := dag.ParseArtifactRef(input.Ref)
+	if err != nil {
+		return err
+	}
+	name, requestId := f.name, f.requestId
+	if ref.DAG != "" {
+		d, err := f.dagStore.GetMetadata(ref.DAG)
+		if err != nil {
+			return err
+		}
+		name = d.Name
+		if requestId, err = f.findRun(d, ref.Run, input.MaxAge); err != nil {
+			return err
+		}
+	}
+	src, err := f.artifactStore.Open(name, requestId, ref.Path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, src); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// findRun returns the request ID of the run. For the latest run it is the
+// latest successful run that finished within maxAge.
+func (f *inputFetcher) findRun(d *dag.DAG, run string, maxAge time.Duration) (string, error) {
+	if run != dag.ArtifactRunLatest {
+		file, err := f.historyStore.FindByRequestId(d.Location, run)
+		if err != nil {
+			return "", err
+		}
+		if file.Status.Status != scheduler.StatusSuccess {
+			return "", fmt.Errorf("%w: %s of %s is %s", errRunNotSucceeded, run, d.Name, file.Status.StatusText)
+		}
+		return run, nil
+	}
+	for _, file := range f.historyStore.ReadStatusRecent(d.Location, recentRunsToSearch) {
+		st := file.Status
+		if st.Status != scheduler.StatusSuccess {
+			continue
+		}
+		if maxAge > 0 {
+			finishedAt, err := utils.ParseTime(st.FinishedAt)
+			if err != nil || time.Since(finishedAt) > maxAge {
+				// The runs are sorted from the latest, so the others are
+				// older.
+				break
+			}
+		}
+		return st.RequestId, nil
+	}
+	if maxAge > 0 {
+		return "", fmt.Errorf("%w of %s within %s", errNoSuccessfulRun, d.Name, maxAge)
+	}
+	return "", fmt.Errorf("%w of %s", errNoSuccessfulRun, d.Name)
+}
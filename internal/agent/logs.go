@@ -0,0 +1,35 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+)
+
+// logUploader uploads the log files of the steps of the run to the log
+// store, from which the server reads them if they are not on its disk.
+type logUploader struct {
+	logStore  persistence.LogStore
+	name      string
+	requestId string
+}
+
+var _ scheduler.LogUploader = (*logUploader)(nil)
+
+func (u *logUploader) Upload(ctx context.Context, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return u.logStore.Put(ctx, u.name, u.requestId, filepath.Base(file), f, info.Size())
+}
@@ -0,0 +1,56 @@
+package agent
+
+import (
+	"sync"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/utils"
+)
+
+// notifier sends the notifications of a run, e.g. the mails of the failed
+// steps, with a bounded number of workers. The steps and the status of the
+// run do not wait for the notifications, so that a slow mail server does not
+// hold the run.
+type notifier struct {
+	workers chan struct{}
+	wg      sync.WaitGroup
+}
+
+func newNotifier(workers int) *notifier {
+	return &notifier{workers: make(chan struct{}, max(workers, 1))}
+}
+
+// send runs the notification when a worker is free. Its error is logged
+// with the name.
+func (n *notifier) send(name string, notify func() error) {
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		n.workers <- struct{}{}
+		defer func() {
+			<-n.workers
+		}()
+		utils.LogErr(name, notify())
+	}()
+}
+
+// wait waits for the notifications that were sent.
+func (n *notifier) wait() {
+	n.wg.Wait()
+}
+
+// stepNotifier sends the notifications of the steps of the run with the
+// notifier and the mailer of the agent.
+type stepNotifier struct {
+	a *Agent
+}
+
+var _ dag.Notifier = stepNotifier{}
+
+// Notify queues the notification. Its error is logged when it is sent.
+func (n stepNotifier) Notify(to, subject, body string) error {
+	n.a.notifier.send("send notification", func() error {
+		return n.a.reporter.SendNotification(n.a.DAG, to, subject, body)
+	})
+	return nil
+}
@@ -0,0 +1,107 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/tracing"
+)
+
+var errRunFailed = errors.New("the run failed")
+
+// defaultExportTimeout is the timeout of an export of the spans if the
+// notifications have no timeout.
+const defaultExportTimeout = 10 * time.Second
+
+// startRunSpan starts the span of the run. It is a child of the span of the
+// TRACEPARENT variable of the environment, e.g. of the step of the parent
+// run of a sub DAG run.
+func (a *Agent) startRunSpan() *tracing.Span {
+	s := a.tracer.StartRemote(a.DAG.Name, os.Getenv(tracing.EnvTraceparent))
+	s.SetAttr("dagu.dag.name", a.DAG.Name)
+	s.SetAttr("dagu.request_id", a.requestId)
+	if ns := config.Get().Namespace; ns != "" {
+		s.SetAttr("dagu.namespace", ns)
+	}
+	log.Printf("trace ID: %s", s.TraceID())
+	return s
+}
+
+// endRunSpan ends the span of the run with its final status, exports the
+// remaining spans and stops the tracer.
+func (a *Agent) endRunSpan(status *model.Status, err error) {
+	if a.tracer == nil {
+		return
+	}
+	a.runSpan.SetAttr("dagu.status", status.StatusText)
+	if err == nil && status.Status == scheduler.StatusError {
+		err = errRunFailed
+	}
+	a.runSpan.End(err)
+	a.export(a.tracer.Shutdown)
+}
+
+// exportSpans exports the ended spans with the notifications, so that a
+// collector that is down does not delay the run.
+func (a *Agent) exportSpans() {
+	if a.tracer == nil {
+		return
+	}
+	a.export(a.tracer.Flush)
+}
+
+func (a *Agent) export(flush func(context.Context) error) {
+	timeout := time.Second * time.Duration(config.Get().NotificationTimeoutSec)
+	if timeout <= 0 {
+		timeout = defaultExportTimeout
+	}
+	a.notifier.send("export spans", func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		return flush(ctx)
+	})
+}
+
+// stepTracer traces the attempts of the steps as the children of the span of
+// the run.
+type stepTracer struct {
+	tracer *tracing.Tracer
+	run    *tracing.Span
+}
+
+func (t *stepTracer) StartStep(step dag.Step, retryCount int) (string, func(error)) {
+	s := t.tracer.Start(step.Name, t.run)
+	executor := step.ExecutorConfig.Type
+	if executor == "" {
+		executor = "command"
+	}
+	s.SetAttr("dagu.step.name", step.Name)
+	s.SetAttr("dagu.step.executor", executor)
+	s.SetAttr("dagu.step.retry_count", retryCount)
+	return s.Traceparent(), func(err error) {
+		if code, ok := exitCode(err); ok {
+			s.SetAttr("process.exit_code", code)
+		}
+		s.End(err)
+	}
+}
+
+// exitCode returns the exit code of the command of a step that returned the
+// error, if it is known.
+func exitCode(err error) (int, bool) {
+	if err == nil {
+		return 0, true
+	}
+	var exitErr interface{ ExitCode() int }
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,267 @@
+// Package archive moves the log files of the runs of the DAGs that are older
+// than the archive policy to the cold tier, e.g. a bucket of an object
+// storage. The statuses of the archived runs are kept in the history as the
+// index of the archive, and their logs are read from the archive when they
+// are no longer on the local disk.
+package archive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/logfile"
+	"github.com/dagu-dev/dagu/internal/logger"
+	"github.com/dagu-dev/dagu/internal/logger/tag"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/utils"
+)
+
+// StatusFile is the name of the copy of the status of a run in the archive.
+const StatusFile = "status.json"
+
+var errNotConfigured = errors.New("the archive backend is not configured")
+
+// Config contains the configuration for an Archiver.
+type Config struct {
+	DataStore persistence.DataStoreFactory
+	// DryRun reports the runs without archiving them.
+	DryRun bool
+	// AfterDays is how old in days the runs are when they are archived.
+	AfterDays int
+	// Interval is the interval of the archiving run by Start.
+	Interval time.Duration
+	Logger   logger.Logger
+}
+
+// Archiver moves the log files of the runs older than AfterDays to the
+// archive store. The runs that are running are kept.
+type Archiver struct {
+	*Config
+}
+
+func New(cfg *Config) *Archiver {
+	return &Archiver{Config: cfg}
+}
+
+// Start archives the old runs every interval in the background until done
+// is closed.
+func (a *Archiver) Start(done chan any) {
+	go func() {
+		ticker := time.NewTicker(a.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				a.runAndLog()
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+func (a *Archiver) runAndLog() {
+	r, err := a.Run()
+	if err != nil {
+		a.Logger.Error("failed to archive the runs", tag.Error(err))
+		return
+	}
+	for _, e := range r.Errors {
+		a.Logger.Warn("failed to archive the run", "error", e)
+	}
+	if len(r.Runs) > 0 {
+		a.Logger.Info("archived the runs", "runs", len(r.Runs), "size", r.Size())
+	}
+}
+
+// Report is the result of an archiving.
+type Report struct {
+	DryRun bool   `json:"dryRun"`
+	Runs   []*Run `json:"runs"`
+	// Errors are the runs that could not be archived.
+	Errors []string `json:"errors"`
+}
+
+// Run is an archived run.
+type Run struct {
+	DAG       string `json:"dag"`
+	RequestId string `json:"requestId"`
+	// Location is where the files of the run are stored in the archive.
+	Location string `json:"location"`
+	// Files are the log files moved to the archive.
+	Files []string `json:"files"`
+	Size  int64    `json:"size"`
+}
+
+// Size returns the total size of the log files moved to the archive.
+func (r *Report) Size() int64 {
+	var n int64
+	for _, run := range r.Runs {
+		n += run.Size
+	}
+	return n
+}
+
+// Run archives the old runs of the DAGs of the names, or of all the DAGs if
+// there is none, and returns the report.
+func (a *Archiver) Run(names ...string) (*Report, error) {
+	as := a.DataStore.NewArchiveStore()
+	if as == nil {
+		return nil, errNotConfigured
+	}
+	r := &Report{DryRun: a.DryRun, Runs: []*Run{}, Errors: []string{}}
+	ds := a.DataStore.NewDAGStore()
+	if len(names) == 0 {
+		dags, errs, err := ds.List()
+		if err != nil {
+			return nil, err
+		}
+		r.Errors = append(r.Errors, errs...)
+		for _, d := range dags {
+			names = append(names, d.Location)
+		}
+	}
+	cutoff := time.Now().AddDate(0, 0, -a.AfterDays)
+	for _, name := range names {
+		d, err := ds.GetDetails(name)
+		if err != nil {
+			r.Errors = append(r.Errors, err.Error())
+			continue
+		}
+		a.archive(r, as, d, cutoff)
+	}
+	return r, nil
+}
+
+func (a *Archiver) archive(r *Report, as persistence.ArchiveStore, d *dag.DAG, cutoff time.Time) {
+	hs := a.DataStore.NewHistoryStore()
+	for _, sf := range hs.ReadStatusAll(d.Location) {
+		st := sf.Status
+		if st.Status == scheduler.StatusRunning || st.Archive != nil || !isBefore(startedAt(st), cutoff) {
+			continue
+		}
+		run := &Run{DAG: d.Name, RequestId: st.RequestId, Location: as.Location(d.Name, st.RequestId), Files: []string{}}
+		files := logFiles(st)
+		for _, f := range files {
+			run.Files = append(run.Files, f.path)
+			run.Size += f.size
+		}
+		if !a.DryRun {
+			if err := a.move(as, hs, d, st, run.Location, files); err != nil {
+				r.Errors = append(r.Errors, fmt.Sprintf("%s of %s: %s", st.RequestId, d.Name, err))
+				continue
+			}
+		}
+		r.Runs = append(r.Runs, run)
+	}
+}
+
+// move uploads the log files and the status of the run to the archive,
+// records the archive in the status and removes the local files. The files
+// are kept if the run could not be archived.
+func (a *Archiver) move(as persistence.ArchiveStore, hs persistence.HistoryStore, d *dag.DAG, st *model.Status, location string, files []*file) error {
+	ctx := context.Background()
+	for _, f := range files {
+		if err := put(ctx, as, d.Name, st.RequestId, f); err != nil {
+			return fmt.Errorf("failed to archive %s: %w", f.path, err)
+		}
+	}
+	st.Archive = &model.Archive{Location: location, ArchivedAt: utils.FormatTime(time.Now())}
+	b, err := st.ToJson()
+	if err != nil {
+		return err
+	}
+	if err := as.Put(ctx, d.Name, st.RequestId, StatusFile, bytes.NewReader(b), int64(len(b))); err != nil {
+		return fmt.Errorf("failed to archive the status: %w", err)
+	}
+	if err := hs.Update(d.Location, st.RequestId, st); err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func put(ctx context.Context, as persistence.ArchiveStore, name, requestId string, f *file) error {
+	src, err := os.Open(f.path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+	return as.Put(ctx, name, requestId, filepath.Base(f.path), src, f.size)
+}
+
+// OpenLog opens the log at path of the archived run from the archive. The
+// contents of a compressed log are decompressed like logfile.Open.
+func OpenLog(ctx context.Context, as persistence.ArchiveStore, st *model.Status, path string) (io.ReadCloser, error) {
+	if as == nil {
+		return nil, errNotConfigured
+	}
+	r, err := logfile.OpenFrom(func(name string) (io.ReadCloser, error) {
+		return as.Open(ctx, st.Name, st.RequestId, name)
+	}, filepath.Base(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the log from %s: %w", as.Location(st.Name, st.RequestId), err)
+	}
+	return r, nil
+}
+
+// ReadLog reads the log at path of the archived run like OpenLog.
+func ReadLog(ctx context.Context, as persistence.ArchiveStore, st *model.Status, path string) ([]byte, error) {
+	r, err := OpenLog(ctx, as, st, path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	return io.ReadAll(r)
+}
+
+type file struct {
+	path string
+	size int64
+}
+
+// logFiles returns the log files of the run that exist, including the
+// rotated and the compressed ones.
+func logFiles(st *model.Status) []*file {
+	var files []*file
+	for _, path := range st.LogPaths() {
+		for _, f := range logfile.Files(path) {
+			if info, err := os.Stat(f); err == nil && info.Mode().IsRegular() {
+				files = append(files, &file{path: f, size: info.Size()})
+			}
+		}
+	}
+	return files
+}
+
+func isBefore(t, cutoff time.Time) bool {
+	return !t.IsZero() && t.Before(cutoff)
+}
+
+// startedAt returns the time the run started, or finished if it never
+// started. It is zero if the run has neither.
+func startedAt(st *model.Status) time.Time {
+	for _, s := range []string{st.StartedAt, st.FinishedAt} {
+		if t, err := utils.ParseTime(s); err == nil && !t.IsZero() {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,103 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/logfile"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiver(t *testing.T) {
+	tmpDir := utils.MustTempDir("test-archive")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	cfg := &config.Config{
+		DAGs:           filepath.Join(tmpDir, "dags"),
+		DataDir:        filepath.Join(tmpDir, "data"),
+		ArchiveBackend: &config.ArchiveBackend{Type: "dir", Dir: filepath.Join(tmpDir, "archive")},
+	}
+	df := client.NewDataStoreFactory(cfg)
+	logDir := filepath.Join(tmpDir, "logs")
+	spec := fmt.Sprintf("logDir: %s\nsteps:\n  - name: step1\n    command: echo 1\n", logDir)
+	_, err := df.NewDAGStore().Create("etl", []byte(spec))
+	require.NoError(t, err)
+	d, err := df.NewDAGStore().GetDetails("etl")
+	require.NoError(t, err)
+	dir := filepath.Join(logDir, "etl")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+
+	hs := df.NewHistoryStore()
+	now := time.Now()
+	runs := []struct {
+		requestId string
+		startedAt time.Time
+		status    scheduler.Status
+	}{
+		{"recent", now, scheduler.StatusSuccess},
+		{"running", now.AddDate(0, 0, -40), scheduler.StatusRunning},
+		{"old", now.AddDate(0, 0, -31), scheduler.StatusSuccess},
+	}
+	logs := map[string]string{}
+	for _, run := range runs {
+		logs[run.requestId] = filepath.Join(dir, run.requestId+".log")
+		require.NoError(t, os.WriteFile(logs[run.requestId], []byte("0123456789"), 0600))
+		require.NoError(t, hs.Open(d.Location, run.startedAt, run.requestId))
+		st := model.NewStatus(d, nil, run.status, 10000, model.Time(run.startedAt), nil)
+		st.RequestId = run.requestId
+		st.Log = logs[run.requestId]
+		require.NoError(t, hs.Write(st))
+		require.NoError(t, hs.Close())
+	}
+	// The rotated files of the log are archived too.
+	require.NoError(t, os.WriteFile(logs["old"]+".1", []byte("rotated"), 0600))
+	require.NoError(t, logfile.Compress(logs["old"], logfile.CompressionGzip))
+
+	a := New(&Config{DataStore: df, DryRun: true, AfterDays: 30})
+	r, err := a.Run()
+	require.NoError(t, err)
+	require.Empty(t, r.Errors)
+	require.Len(t, r.Runs, 1)
+	require.Equal(t, "old", r.Runs[0].RequestId)
+	require.Len(t, r.Runs[0].Files, 2)
+	require.FileExists(t, logs["old"]+".gz")
+
+	a.DryRun = false
+	r, err = a.Run("etl")
+	require.NoError(t, err)
+	require.Empty(t, r.Errors)
+	require.Len(t, r.Runs, 1)
+	require.NoFileExists(t, logs["old"]+".gz")
+	require.NoFileExists(t, logs["old"]+".1.gz")
+	require.FileExists(t, logs["recent"])
+	require.FileExists(t, logs["running"])
+
+	// The status stays in the history and the log is read from the archive.
+	sf, err := hs.FindByRequestId(d.Location, "old")
+	require.NoError(t, err)
+	require.NotNil(t, sf.Status.Archive)
+	require.Equal(t, filepath.Join(tmpDir, "archive", "etl", "old"), sf.Status.Archive.Location)
+	require.FileExists(t, filepath.Join(sf.Status.Archive.Location, StatusFile))
+	b, err := ReadLog(context.Background(), df.NewArchiveStore(), sf.Status, logs["old"])
+	require.NoError(t, err)
+	require.Equal(t, "0123456789", string(b))
+
+	// The archived runs are not archived again.
+	r, err = a.Run("etl")
+	require.NoError(t, err)
+	require.Empty(t, r.Runs)
+
+	_, err = New(&Config{DataStore: client.NewDataStoreFactory(&config.Config{DAGs: cfg.DAGs, DataDir: cfg.DataDir})}).Run()
+	require.ErrorIs(t, err, errNotConfigured)
+}
@@ -0,0 +1,213 @@
+// Package aws is the client of the AWS services with the configuration of
+// the aws section of the config: the configuration of the SDK, and the
+// requests of the REST API of S3, also of Google Cloud Storage with HMAC
+// keys, signed with Signature Version 4.
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/dagu-dev/dagu/internal/config"
+)
+
+const (
+	// unsignedPayload is the payload hash of S3 requests whose body is not
+	// signed.
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+
+	// gcsEndpoint is the endpoint of the XML API of Google Cloud Storage,
+	// which accepts the requests of S3 signed with HMAC keys in the region
+	// auto.
+	gcsEndpoint = "https://storage.googleapis.com"
+	gcsRegion   = "auto"
+)
+
+var (
+	// ErrRegion is returned if no region is configured.
+	ErrRegion = errors.New("aws region is not configured")
+	// ErrRequestFailed is returned for a response that is not successful.
+	ErrRequestFailed = errors.New("aws request failed")
+)
+
+// Client loads the configuration of the SDK, whose credentials are cached
+// until they expire, and signs the requests with it.
+type Client struct {
+	config func() *config.AWS
+	client *awshttp.BuildableClient
+	signer *v4.Signer
+
+	mu     sync.Mutex
+	key    config.AWS
+	loaded *aws.Config
+}
+
+// New returns the client of the configuration.
+func New(cfg func() *config.AWS) *Client {
+	return &Client{
+		config: cfg,
+		client: awshttp.NewBuildableClient().WithTimeout(30 * time.Second),
+		// The paths of S3 are signed as they are sent.
+		signer: v4.NewSigner(func(o *v4.SignerOptions) {
+			o.DisableURIPathEscaping = true
+		}),
+	}
+}
+
+// Default is the client of the aws section of the config. It is shared so
+// that the credentials are cached once.
+var Default = New(func() *config.AWS {
+	return config.Get().AWS
+})
+
+// Config returns the configuration of the SDK: the region and the
+// credentials of the shared files of the profile, the environment, a web
+// identity token, the container or the EC2 instance, in the order of the
+// SDK, and the endpoint of the configuration if any. It is loaded again
+// when the configuration changes.
+func (c *Client) Config(ctx context.Context) (aws.Config, error) {
+	cfg := config.AWS{}
+	if v := c.config(); v != nil {
+		cfg = *v
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.loaded != nil && c.key == cfg {
+		return *c.loaded, nil
+	}
+	opts := []func(*awsconfig.LoadOptions) error{awsconfig.WithHTTPClient(c.client)}
+	if cfg.Region != "" {
+		opts = append(opts, awsconfig.WithRegion(cfg.Region))
+	}
+	if cfg.Profile != "" {
+		opts = append(opts, awsconfig.WithSharedConfigProfile(cfg.Profile))
+	}
+	loaded, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return aws.Config{}, err
+	}
+	if cfg.Endpoint != "" {
+		loaded.BaseEndpoint = aws.String(cfg.Endpoint)
+	}
+	c.key, c.loaded = cfg, &loaded
+	return loaded, nil
+}
+
+// endpoint returns the endpoint of the service in the configured region.
+func (c *Client) endpoint(ctx context.Context, service string) (aws.Config, string, error) {
+	cfg, err := c.Config(ctx)
+	if err != nil {
+		return cfg, "", err
+	}
+	if cfg.Region == "" {
+		return cfg, "", ErrRegion
+	}
+	if cfg.BaseEndpoint != nil {
+		return cfg, *cfg.BaseEndpoint, nil
+	}
+	return cfg, fmt.Sprintf("https://%s.%s.amazonaws.com", service, cfg.Region), nil
+}
+
+// Send sends the request to the REST API of the service in the configured
+// region. The path is appended to the endpoint of the service, and the body
+// is streamed without being signed as S3 allows. A response with status
+// 404 is returned as is so that a missing object can be told apart from a
+// failed request.
+func (c *Client) Send(ctx context.Context, method, service, path string, body io.Reader, size int64) (*http.Response, error) {
+	cfg, endpoint, err := c.endpoint(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+	return c.sendTo(ctx, cfg, endpoint, cfg.Region, method, service, path, body, size)
+}
+
+// SendGCS sends a request of S3 to the XML API of Google Cloud Storage like
+// Send. The configured AWS credentials are the HMAC keys of the service
+// account.
+func (c *Client) SendGCS(ctx context.Context, method, service, path string, body io.Reader, size int64) (*http.Response, error) {
+	cfg, err := c.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.sendTo(ctx, cfg, gcsEndpoint, gcsRegion, method, service, path, body, size)
+}
+
+func (c *Client) sendTo(ctx context.Context, cfg aws.Config, endpoint, region, method, service, path string, body io.Reader, size int64) (*http.Response, error) {
+	creds, err := cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(endpoint, "/")+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	if err := c.signer.SignHTTP(ctx, creds, req, unsignedPayload, service, region, time.Now()); err != nil {
+		return nil, err
+	}
+	// The client of the configuration has the CA bundle of the profile or
+	// of AWS_CA_BUNDLE, if any.
+	resp, err := cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotFound {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s: %s", ErrRequestFailed, resp.Status, strings.TrimSpace(string(b)))
+	}
+	return resp, nil
+}
+
+// Presign returns the URL of a request to the REST API of the service
+// signed with Signature Version 4 in its query, e.g. to download an object
+// of S3, which is valid for the duration without any other credentials. The
+// path is appended to the endpoint of the service like Send.
+func (c *Client) Presign(ctx context.Context, method, service, path string, expires time.Duration) (string, error) {
+	cfg, endpoint, err := c.endpoint(ctx, service)
+	if err != nil {
+		return "", err
+	}
+	creds, err := cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(endpoint, "/")+path, nil)
+	if err != nil {
+		return "", err
+	}
+	q := req.URL.Query()
+	q.Set("X-Amz-Expires", strconv.FormatInt(int64(expires/time.Second), 10))
+	req.URL.RawQuery = q.Encode()
+	u, _, err := c.signer.PresignHTTP(ctx, creds, req, unsignedPayload, service, cfg.Region, time.Now())
+	return u, err
+}
+
+// Send sends the request with the default client, see Client.Send.
+func Send(ctx context.Context, method, service, path string, body io.Reader, size int64) (*http.Response, error) {
+	return Default.Send(ctx, method, service, path, body, size)
+}
+
+// SendGCS sends the request with the default client, see Client.SendGCS.
+func SendGCS(ctx context.Context, method, service, path string, body io.Reader, size int64) (*http.Response, error) {
+	return Default.SendGCS(ctx, method, service, path, body, size)
+}
+
+// Presign signs the URL with the default client, see Client.Presign.
+func Presign(ctx context.Context, method, service, path string, expires time.Duration) (string, error) {
+	return Default.Presign(ctx, method, service, path, expires)
+}
@@ -0,0 +1,127 @@
+package aws
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Contains(t, r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/")
+		require.Contains(t, r.Header.Get("Authorization"), "/us-east-1/s3/aws4_request")
+		require.Contains(t, r.Header.Get("Authorization"), "x-amz-content-sha256")
+		require.Equal(t, "UNSIGNED-PAYLOAD", r.Header.Get("X-Amz-Content-Sha256"))
+		switch r.URL.Path {
+		case "/bucket/a.txt":
+			b, _ := io.ReadAll(r.Body)
+			require.Equal(t, "a", string(b))
+		case "/bucket/missing.txt":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("AccessDenied"))
+		}
+	}))
+	defer srv.Close()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "SECRET")
+
+	ctx := context.Background()
+	client := New(func() *config.AWS {
+		return &config.AWS{Region: "us-east-1", Endpoint: srv.URL}
+	})
+	resp, err := client.Send(ctx, http.MethodPut, "s3", "/bucket/a.txt", strings.NewReader("a"), 1)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = client.Send(ctx, http.MethodGet, "s3", "/bucket/missing.txt", nil, 0)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	_, err = client.Send(ctx, http.MethodGet, "s3", "/other/a.txt", nil, 0)
+	require.ErrorIs(t, err, ErrRequestFailed)
+	require.Contains(t, err.Error(), "AccessDenied")
+}
+
+func TestPresign(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "SECRET")
+	t.Setenv("AWS_SESSION_TOKEN", "TOKEN")
+
+	client := New(func() *config.AWS {
+		return &config.AWS{Region: "eu-west-1"}
+	})
+	signed, err := client.Presign(context.Background(), http.MethodGet, "s3", "/bucket/a%20b.txt", time.Hour)
+	require.NoError(t, err)
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	require.Equal(t, "s3.eu-west-1.amazonaws.com", u.Host)
+	require.Equal(t, "/bucket/a%20b.txt", u.EscapedPath())
+	q := u.Query()
+	require.Equal(t, "3600", q.Get("X-Amz-Expires"))
+	require.Equal(t, "host", q.Get("X-Amz-SignedHeaders"))
+	require.Equal(t, "TOKEN", q.Get("X-Amz-Security-Token"))
+	require.True(t, strings.HasPrefix(q.Get("X-Amz-Credential"), "AKID/"))
+	require.NotEmpty(t, q.Get("X-Amz-Signature"))
+}
+
+func TestConfig(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+
+	dir := t.TempDir()
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "credentials"), []byte(`[default]
+aws_access_key_id = DEFAULT
+aws_secret_access_key = default-secret
+
+[ci]
+aws_access_key_id = CI
+aws_secret_access_key = ci-secret
+aws_session_token = ci-token
+`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config"), []byte(`[profile ci]
+region = ap-northeast-1
+`), 0600))
+
+	// The credentials and the region are those of the profile, and are
+	// loaded again once the configuration changes.
+	cfg := &config.AWS{Profile: "ci"}
+	client := New(func() *config.AWS { return cfg })
+	ctx := context.Background()
+	loaded, err := client.Config(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "ap-northeast-1", loaded.Region)
+	creds, err := loaded.Credentials.Retrieve(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "CI", creds.AccessKeyID)
+	require.Equal(t, "ci-token", creds.SessionToken)
+
+	cfg = &config.AWS{}
+	loaded, err = client.Config(ctx)
+	require.NoError(t, err)
+	creds, err = loaded.Credentials.Retrieve(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "DEFAULT", creds.AccessKeyID)
+	_, err = client.Send(ctx, http.MethodGet, "s3", "/bucket/a.txt", nil, 0)
+	require.ErrorIs(t, err, ErrRegion)
+}
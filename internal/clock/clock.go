@@ -0,0 +1,151 @@
+// Package clock is the source of the time of the schedules, the runs and
+// their history. The tests replace it with a fake clock that they set and
+// advance, so that the schedules, the retries and the timeouts are tested
+// without waiting for them.
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock tells the time and waits for durations.
+type Clock interface {
+	Now() time.Time
+	// After returns a channel that receives the time once d elapsed.
+	After(d time.Duration) <-chan time.Time
+	// Sleep waits for d.
+	Sleep(d time.Duration)
+}
+
+// Real is the clock of the system.
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+
+var (
+	mu           sync.RWMutex
+	defaultClock = Real
+)
+
+// Default returns the clock of the process, the real one unless it was
+// replaced with SetDefault.
+func Default() Clock {
+	mu.RLock()
+	defer mu.RUnlock()
+	return defaultClock
+}
+
+// SetDefault replaces the clock of the process. A nil clock restores the
+// real one.
+func SetDefault(c Clock) {
+	mu.Lock()
+	defer mu.Unlock()
+	if c == nil {
+		c = Real
+	}
+	defaultClock = c
+}
+
+// OrDefault returns the clock, or the one of the process if it is nil.
+func OrDefault(c Clock) Clock {
+	if c == nil {
+		return Default()
+	}
+	return c
+}
+
+// Fake is a clock whose time only moves when it is set or advanced. The
+// channels of After receive the time once the clock reaches their
+// deadlines.
+type Fake struct {
+	// Auto advances the clock by the durations of the sleeps instead of
+	// waiting for them, so that the waits of the retries and the repeats
+	// of the runs pass at once in simulated time.
+	Auto bool
+
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*waiter
+}
+
+type waiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// NewFake returns a fake clock frozen at the time.
+func NewFake(t time.Time) *Fake {
+	return &Fake{now: t}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- f.now
+		return ch
+	}
+	f.waiters = append(f.waiters, &waiter{at: f.now.Add(d), ch: ch})
+	return ch
+}
+
+func (f *Fake) Sleep(d time.Duration) {
+	if f.Auto {
+		f.Advance(d)
+		return
+	}
+	<-f.After(d)
+}
+
+// Advance moves the clock forward by d.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.set(f.now.Add(d))
+}
+
+// Set sets the time of the clock. The channels whose deadlines it reached
+// receive it, the earliest deadline first.
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.set(t)
+}
+
+func (f *Fake) set(t time.Time) {
+	f.now = t
+	sort.SliceStable(f.waiters, func(i, j int) bool {
+		return f.waiters[i].at.Before(f.waiters[j].at)
+	})
+	var pending []*waiter
+	for _, w := range f.waiters {
+		if w.at.After(t) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- t
+	}
+	f.waiters = pending
+}
+
+// Waiters returns the number of the channels of After and the sleeps that
+// wait for the clock, so that a test advances it once the code under test
+// waits.
+func (f *Fake) Waiters() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.waiters)
+}
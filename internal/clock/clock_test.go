@@ -0,0 +1,56 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	f := NewFake(start)
+	require.Equal(t, start, f.Now())
+
+	later := f.After(time.Hour)
+	sooner := f.After(time.Minute)
+	require.Len(t, f.After(0), 1)
+	require.Equal(t, 2, f.Waiters())
+
+	f.Advance(30 * time.Second)
+	require.Empty(t, sooner)
+
+	f.Advance(30 * time.Second)
+	require.Equal(t, start.Add(time.Minute), <-sooner)
+	require.Empty(t, later)
+	require.Equal(t, 1, f.Waiters())
+
+	f.Set(start.Add(2 * time.Hour))
+	require.Equal(t, start.Add(2*time.Hour), <-later)
+	require.Zero(t, f.Waiters())
+
+	// A sleep waits for the clock to be advanced.
+	done := make(chan struct{})
+	go func() {
+		f.Sleep(time.Minute)
+		close(done)
+	}()
+	require.Eventually(t, func() bool { return f.Waiters() == 1 }, time.Second, time.Millisecond)
+	f.Advance(time.Minute)
+	<-done
+
+	// An automatic clock advances itself instead.
+	f.Auto = true
+	f.Sleep(time.Hour)
+	require.Equal(t, start.Add(3*time.Hour+time.Minute), f.Now())
+}
+
+func TestDefault(t *testing.T) {
+	require.Equal(t, Real, Default())
+	f := NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	SetDefault(f)
+	defer SetDefault(nil)
+	require.Equal(t, f, Default())
+	require.Equal(t, f, OrDefault(nil))
+	require.Equal(t, Real, OrDefault(Real))
+}
@@ -0,0 +1,37 @@
+package clock
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ControlFile returns the path of the file the time of the frozen clock of
+// the scheduler is set with, in the data directory. The scheduler writes its
+// time to it when it starts, and moves its clock to the time written to it
+// later, e.g. by dagu clock advance.
+func ControlFile(dataDir string) string {
+	return filepath.Join(dataDir, "scheduler.clock")
+}
+
+// ReadControl returns the time of the control file.
+func ReadControl(file string) (time.Time, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339Nano, strings.TrimSpace(string(b)))
+}
+
+// WriteControl writes the time to the control file.
+func WriteControl(file string, t time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, []byte(t.Format(time.RFC3339Nano)+"\n"), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
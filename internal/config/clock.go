@@ -0,0 +1,43 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/clock"
+)
+
+var errInvalidClock = errors.New("invalid clock")
+
+// clockLayouts are the layouts of the time of the clock, which is in the
+// local time zone if it has none.
+var clockLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05"}
+
+// applyClock freezes the clock of the process at the time of the
+// configuration, if it has one, to test the DAGs at a given time. The waits
+// of the runs, their delays, retries and repeats, advance the clock at once
+// instead of waiting.
+func applyClock(cfg *Config) error {
+	if cfg.Clock == "" {
+		return nil
+	}
+	t, err := ParseClock(cfg.Clock)
+	if err != nil {
+		return err
+	}
+	c := clock.NewFake(t)
+	c.Auto = true
+	clock.SetDefault(c)
+	return nil
+}
+
+// ParseClock returns the time of the value of a clock.
+func ParseClock(value string) (time.Time, error) {
+	for _, layout := range clockLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %s", errInvalidClock, value)
+}
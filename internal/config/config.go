@@ -23,6 +23,7 @@ type Config struct {
 	LogDir             string
 	DataDir            string
 	SuspendFlagsDir    string
+	ArtifactDir        string
 	AdminLogsDir       string
 	BaseConfig         string
 	NavbarColor        string
@@ -32,17 +33,419 @@ type Config struct {
 	IsAuthToken        bool
 	AuthToken          string
 	LatestStatusToday  bool
+
+	// APITokens are named bearer tokens of the API, in addition to
+	// AuthToken. A token with the impersonate scope can act on behalf of
+	// another user.
+	APITokens []APIToken
+
+	// AccessRules grant the users and the tokens roles on the DAGs. Once a
+	// rule is defined, the requests of the users and the tokens without
+	// one are forbidden.
+	AccessRules []AccessRule
+
+	// OIDC enables the login with an OpenID Connect provider.
+	OIDC *OIDC
+
+	// LDAP authenticates the users of basic auth with an LDAP directory.
+	LDAP *LDAP
+
+	// Namespaces partition the DAGs, the history and the base configs, so
+	// that teams or environments share a server. Namespace is the one of
+	// the process, whose directories replace the ones of the
+	// configuration.
+	Namespaces []Namespace
+	Namespace  string
+
+	// APIURL is the base URL of the REST API of the dagu instance the
+	// start, stop, status and logs commands act on instead of the local
+	// files, e.g. https://prod.example.com/api/v1, usually set by a profile.
+	// logs --follow follows the log stream of the API. The requests are
+	// authenticated with AuthToken, and Namespace is the namespace of the
+	// instance.
+	APIURL string
+
+	// ChangeControl makes the edits of the DAGs pending changes that a
+	// user other than their author approves before they are saved. The
+	// one of a namespace is the one of its configuration.
+	ChangeControl bool
+
+	// EncryptionKeyFile is the file of the key the logs of the runs are
+	// encrypted with, 32 bytes encoded in base64. The logs are not
+	// encrypted if it is empty. The one of a namespace is the one of its
+	// configuration, so that the operators of a namespace do not read the
+	// logs of the others.
+	EncryptionKeyFile string
+
+	// IsSchedulerHA enables leader election so that only one of several
+	// scheduler instances sharing SchedulerLeaseFile fires schedules.
+	IsSchedulerHA        bool
+	SchedulerLeaseFile   string
+	SchedulerLeaseTTLSec int
+
+	// GCIntervalSec is the interval of the garbage collection of orphaned
+	// files in the scheduler process. Zero disables it.
+	GCIntervalSec int
+	// GCMinAgeSec is how old temporary files and artifacts must be to be
+	// collected.
+	GCMinAgeSec int
+
+	// HistRetentionDays, HistRetentionRuns and HistRetentionBytes are the
+	// retention of the history of the DAGs that set none: how old, how many
+	// and how large the runs kept are. Zero runs or bytes keeps them all.
+	HistRetentionDays  int
+	HistRetentionRuns  int
+	HistRetentionBytes int64
+	// PruneIntervalSec is the interval of the pruning of the history in the
+	// scheduler process. Zero disables it.
+	PruneIntervalSec int
+	// PruneWorkers is the number of the DAGs whose history is pruned at the
+	// same time.
+	PruneWorkers int
+	// RecoverLostRuns marks the runs whose agents are gone as lost when the
+	// scheduler or the server starts. LostRunFailureHandler runs the failure
+	// handlers of their DAGs too.
+	RecoverLostRuns       bool
+	LostRunFailureHandler bool
+	// LogCompression is the compression of the step logs of the DAGs that
+	// set none, gzip or zstd. The logs are not compressed if it is empty.
+	LogCompression string
+
+	// StrictMode rejects the fields of the DAGs whose names only match in a
+	// different case, e.g. retrypolicy. A DAG can override it with strict.
+	StrictMode bool
+
+	// AllowChaos allows the runs started with the API to inject faults into
+	// their steps, which only a test environment should allow.
+	AllowChaos bool
+
+	// ConcurrencyPools are the sizes of the concurrency pools by name. No
+	// more than the size of a pool of the steps and the runs of the DAGs in
+	// it run at the same time.
+	ConcurrencyPools map[string]int
+	// ManualRunLane queues the runs started by hand, e.g. with the web UI,
+	// the API or the start command, ahead of the scheduled runs in the
+	// pools, whatever their priorities, e.g. to remediate an incident.
+	ManualRunLane bool
+
+	// SearchLogRuns is the number of the recent runs of each DAG whose step
+	// logs the server indexes for the search. Zero disables the search of
+	// the logs.
+	SearchLogRuns int
+
+	// Clock freezes the clock of the process at the time, e.g.
+	// 2024-01-01T09:00:00Z, to test the schedules and the runs of the DAGs
+	// at that time. The waits of the runs advance it instead of waiting.
+	Clock string
+
+	// HandlerTimeoutSec is the timeout of the handler steps of the DAGs that
+	// have none. Zero disables it.
+	HandlerTimeoutSec int
+	// NotificationWorkers is the number of the notifications of a run that
+	// are sent at the same time. NotificationTimeoutSec is the timeout of an
+	// attempt to send one, and NotificationRetries the number of times it
+	// is sent again after it failed.
+	NotificationWorkers    int
+	NotificationTimeoutSec int
+	NotificationRetries    int
+
+	LogForward *LogForward
+
+	ArtifactBackend *ArtifactBackend
+
+	HistoryBackend *HistoryBackend
+
+	TriggerQueue *TriggerQueue
+
+	ArchiveBackend *ArchiveBackend
+
+	LogBackend *LogBackend
+
+	Vault *Vault
+	AWS   *AWS
+
+	// RemoteNodes are the other dagu instances whose DAG definitions and
+	// versions are compared with the ones of this instance in the drift
+	// report.
+	RemoteNodes []RemoteNode
+
+	// Webhooks are the URLs the events of the runs are posted to.
+	Webhooks []Webhook
+
+	// RunSummary writes a summary of each finished run.
+	RunSummary *RunSummary
+
+	// Tracing exports the traces of the runs and their steps to an
+	// OpenTelemetry collector.
+	Tracing *Tracing
 }
 
 func (cfg *Config) GetAPIBaseURL() string {
 	return "/api/v1"
 }
 
+// APIToken is a bearer token of the API. Its name is recorded with the
+// actions taken with it.
+type APIToken struct {
+	Name   string
+	Token  string
+	Scopes []string
+}
+
+// AccessRule grants a user, the users of an OIDC or LDAP group or a token a
+// role, viewer, operator or admin, on the DAGs whose names start with one of
+// the prefixes or that have one of the tags, or on all the DAGs if it has
+// neither. It applies to the namespaces of the list, or to all of them if
+// the list is empty.
+type AccessRule struct {
+	User     string
+	Token    string
+	Role     string
+	Prefixes []string
+	Tags     []string
+
+	Group      string
+	Namespaces []string
+}
+
+// OIDC configures the login of the users with an OpenID Connect provider in
+// the authorization code flow.
+type OIDC struct {
+	// Issuer is the URL of the provider. The login is disabled if it is
+	// empty.
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the URL of the callback of dagu registered with the
+	// provider, e.g. https://dagu.example.com/oidc/callback.
+	RedirectURL string
+	// Scopes are requested in addition to openid, e.g. the one of the
+	// groups claim.
+	Scopes []string
+	// UsernameClaim and GroupsClaim are the claims of the ID token of the
+	// name and the groups of the user.
+	UsernameClaim string
+	GroupsClaim   string
+	// SessionSecret signs the session cookies, so that the servers sharing
+	// it accept each other's sessions. A random one is used if it is empty,
+	// and the users log in again after a restart.
+	SessionSecret string
+	SessionTTLSec int
+}
+
+// LDAP configures the authentication of the users with a bind to an LDAP
+// directory, e.g. Active Directory.
+type LDAP struct {
+	// URL is the URL of the server, ldap://host:389 or ldaps://host:636.
+	// The authentication is disabled if it is empty.
+	URL string
+	// StartTLS upgrades the connection of an ldap URL to TLS.
+	StartTLS           bool
+	CACertFile         string
+	InsecureSkipVerify bool
+	// BindDN and BindPassword are the credentials of the account that
+	// searches the users and their groups.
+	BindDN       string
+	BindPassword string
+	BaseDN       string
+	// UserFilter finds the entry of a user, with {username} replaced by the
+	// name of the user, e.g. (sAMAccountName={username}) for Active
+	// Directory.
+	UserFilter string
+	// GroupFilter finds the groups of a user, with {dn} replaced by the DN
+	// of the user, under GroupBaseDN, BaseDN if it is empty.
+	GroupBaseDN    string
+	GroupFilter    string
+	GroupAttribute string
+	// RequiredGroups are the groups one of which the users must be in to
+	// log in, if there are any.
+	RequiredGroups []string
+	TimeoutSec     int
+}
+
+// RemoteNode is another dagu instance, e.g. the staging one of a
+// production instance.
+type RemoteNode struct {
+	Name string
+	// APIBaseURL is the base URL of its REST API, e.g.
+	// https://staging.example.com/api/v1.
+	APIBaseURL string
+	// AuthToken is the bearer token of its API, if it requires one.
+	AuthToken string
+}
+
+// Webhook is a URL the events of the runs, e.g. run.failed, are posted to.
+type Webhook struct {
+	Name string
+	URL  string
+	// Secret signs the requests with HMAC-SHA256 if it is not empty.
+	Secret string
+	// Events are the kinds of the events posted, all of them if it is
+	// empty.
+	Events []string
+	// Template is the text/template of the body, which is the event in
+	// JSON by default.
+	Template string
+	Headers  map[string]string
+}
+
+// RunSummary configures the sink of the summaries of the finished runs.
+type RunSummary struct {
+	// Sink is stdout, file or webhook. The summaries are not written if it
+	// is empty.
+	Sink string
+	// Path is the file the summaries are appended to by the file sink.
+	Path string
+	// URL, Secret and Headers are the ones of the webhook the summaries are
+	// posted to by the webhook sink, as for the webhooks of the events.
+	URL     string
+	Secret  string
+	Headers map[string]string
+}
+
 type TLS struct {
 	CertFile string
 	KeyFile  string
 }
 
+// LogForward configures shipping of step and agent logs to an external
+// log store.
+type LogForward struct {
+	// Type is one of syslog, loki or elasticsearch.
+	Type string
+	// URL is the base URL of Loki or Elasticsearch.
+	URL   string
+	Index string
+	// Network, Address and Tag are used for syslog.
+	Network          string
+	Address          string
+	Tag              string
+	Labels           map[string]string
+	BatchSize        int
+	FlushIntervalSec int
+	// RateLimit is the maximum number of lines per second.
+	RateLimit int
+}
+
+// ArtifactBackend configures where the artifacts saved by the steps are
+// published in addition to the artifact directory.
+type ArtifactBackend struct {
+	// Type is local or s3. The default is local.
+	Type string
+	// Bucket and Prefix are the location of the artifacts in S3. They are
+	// stored as <prefix>/<DAG name>/<request ID>/<path>.
+	Bucket string
+	Prefix string
+}
+
+// HistoryBackend configures where the statuses of the runs are stored.
+type HistoryBackend struct {
+	// Type is file, sqlite or postgres. The default is file, a file per run
+	// in the data directory.
+	Type string
+	// Path is the SQLite database file. The default is history.db in the
+	// data directory. It is the directory of the databases if the history
+	// is sharded, history in the data directory by default.
+	Path string
+	// Sharded keeps the history of each DAG in its own SQLite database, so
+	// that the writes and the pruning of the runs of a DAG do not wait for
+	// the lock of the database of the others.
+	Sharded bool
+	// URL is the URL of the PostgreSQL database, e.g.
+	// postgres://dagu:secret@db:5432/dagu.
+	URL string
+}
+
+// TriggerQueue configures the queue the server adds the runs started with
+// the API to instead of starting them, so that the scheduler starts them,
+// the ones accepted while it was down or restarting too once it is back.
+type TriggerQueue struct {
+	// Type is sqlite. The server starts the runs if it is empty.
+	Type string
+	// Path is the SQLite database file. The default is triggers.db in the
+	// data directory.
+	Path string
+	// IntervalSec is how often the scheduler reads the queue.
+	IntervalSec int
+}
+
+// ArchiveBackend configures the cold tier the log files of the old runs are
+// moved to. Their statuses are kept in the history as the index of the
+// archive, and their logs are read from the archive when they are opened.
+type ArchiveBackend struct {
+	// Type is dir or s3. The runs are not archived if it is empty.
+	Type string
+	// Dir is the directory of the archive of the dir type, e.g. on a
+	// network file system.
+	Dir string
+	// Bucket and Prefix are the location of the archive in S3. The files
+	// of a run are stored as <prefix>/<DAG name>/<request ID>/<file>.
+	Bucket string
+	Prefix string
+	// AfterDays is how old in days the runs are when they are archived.
+	AfterDays int
+	// IntervalSec is the interval of the archiving in the scheduler
+	// process. Zero disables it.
+	IntervalSec int
+}
+
+// LogBackend configures the object storage the agents upload the step logs
+// to when the steps finish. The server reads the logs from it when they are
+// not on its local disk, e.g. when the agents run on ephemeral machines.
+type LogBackend struct {
+	// Type is s3 or gcs. The logs are only kept locally if it is empty.
+	Type string
+	// Bucket and Prefix are the location of the logs in the bucket. The
+	// logs of a run are stored as <prefix>/<DAG name>/<request ID>/<file>.
+	Bucket string
+	Prefix string
+}
+
+// Vault configures the HashiCorp Vault client used to resolve secret
+// references.
+type Vault struct {
+	Address   string
+	Namespace string
+	// AuthMethod is one of token, approle or kubernetes. The default is
+	// token.
+	AuthMethod string
+	Token      string
+	// AuthMount is the mount path of the auth method. The name of the
+	// method is used if it is empty.
+	AuthMount string
+	// RoleID and SecretID are used for the approle auth method.
+	RoleID   string
+	SecretID string
+	// Role and TokenFile are used for the kubernetes auth method.
+	Role      string
+	TokenFile string
+}
+
+// AWS configures the client used to resolve AWS Secrets Manager and SSM
+// Parameter Store references. The credentials are read from the default
+// credential chain.
+type AWS struct {
+	// Region overrides the region of the environment and the shared
+	// config file.
+	Region  string
+	Profile string
+	// Endpoint replaces the endpoints of the services, e.g. for LocalStack.
+	Endpoint string
+}
+
+// Tracing configures the export of the spans of the runs and the steps with
+// the OTLP/HTTP protocol.
+type Tracing struct {
+	// Endpoint is the base URL of the receiver of the collector, e.g.
+	// http://localhost:4318. The spans are posted to <endpoint>/v1/traces.
+	// The runs are not traced if it is empty.
+	Endpoint string
+	// Headers are added to the requests, e.g. the API key of a vendor.
+	Headers map[string]string
+	// ServiceName is the service.name of the spans, dagu by default.
+	ServiceName string
+}
+
 var (
 	cache = &configCache{}
 )
@@ -92,6 +495,7 @@ func LoadConfig() error {
 	_ = viper.BindEnv("logDir", "DAGU_LOG_DIR")
 	_ = viper.BindEnv("dataDir", "DAGU_DATA_DIR")
 	_ = viper.BindEnv("suspendFlagsDir", "DAGU_SUSPEND_FLAGS_DIR")
+	_ = viper.BindEnv("artifactDir", "DAGU_ARTIFACT_DIR")
 	_ = viper.BindEnv("adminLogsDir", "DAGU_ADMIN_LOG_DIR")
 	_ = viper.BindEnv("navbarColor", "DAGU_NAVBAR_COLOR")
 	_ = viper.BindEnv("navbarTitle", "DAGU_NAVBAR_TITLE")
@@ -99,7 +503,79 @@ func LoadConfig() error {
 	_ = viper.BindEnv("tls.keyFile", "DAGU_KEY_FILE")
 	_ = viper.BindEnv("isAuthToken", "DAGU_IS_AUTHTOKEN")
 	_ = viper.BindEnv("authToken", "DAGU_AUTHTOKEN")
+	_ = viper.BindEnv("apiURL", "DAGU_API_URL")
 	_ = viper.BindEnv("latestStatusToday", "DAGU_LATEST_STATUS")
+	_ = viper.BindEnv("isSchedulerHA", "DAGU_IS_SCHEDULER_HA")
+	_ = viper.BindEnv("schedulerLeaseFile", "DAGU_SCHEDULER_LEASE_FILE")
+	_ = viper.BindEnv("schedulerLeaseTTLSec", "DAGU_SCHEDULER_LEASE_TTL_SEC")
+	_ = viper.BindEnv("gcIntervalSec", "DAGU_GC_INTERVAL_SEC")
+	_ = viper.BindEnv("gcMinAgeSec", "DAGU_GC_MIN_AGE_SEC")
+	_ = viper.BindEnv("histRetentionDays", "DAGU_HIST_RETENTION_DAYS")
+	_ = viper.BindEnv("histRetentionRuns", "DAGU_HIST_RETENTION_RUNS")
+	_ = viper.BindEnv("histRetentionBytes", "DAGU_HIST_RETENTION_BYTES")
+	_ = viper.BindEnv("pruneIntervalSec", "DAGU_PRUNE_INTERVAL_SEC")
+	_ = viper.BindEnv("pruneWorkers", "DAGU_PRUNE_WORKERS")
+	_ = viper.BindEnv("recoverLostRuns", "DAGU_RECOVER_LOST_RUNS")
+	_ = viper.BindEnv("lostRunFailureHandler", "DAGU_LOST_RUN_FAILURE_HANDLER")
+	_ = viper.BindEnv("logCompression", "DAGU_LOG_COMPRESSION")
+	_ = viper.BindEnv("strictMode", "DAGU_STRICT_MODE")
+	_ = viper.BindEnv("allowChaos", "DAGU_ALLOW_CHAOS")
+	_ = viper.BindEnv("changeControl", "DAGU_CHANGE_CONTROL")
+	_ = viper.BindEnv("encryptionKeyFile", "DAGU_ENCRYPTION_KEY_FILE")
+	_ = viper.BindEnv("manualRunLane", "DAGU_MANUAL_RUN_LANE")
+	_ = viper.BindEnv("searchLogRuns", "DAGU_SEARCH_LOG_RUNS")
+	_ = viper.BindEnv("clock", "DAGU_CLOCK")
+	_ = viper.BindEnv("handlerTimeoutSec", "DAGU_HANDLER_TIMEOUT_SEC")
+	_ = viper.BindEnv("notificationWorkers", "DAGU_NOTIFICATION_WORKERS")
+	_ = viper.BindEnv("notificationTimeoutSec", "DAGU_NOTIFICATION_TIMEOUT_SEC")
+	_ = viper.BindEnv("notificationRetries", "DAGU_NOTIFICATION_RETRIES")
+	_ = viper.BindEnv("logForward.type", "DAGU_LOG_FORWARD_TYPE")
+	_ = viper.BindEnv("logForward.url", "DAGU_LOG_FORWARD_URL")
+	_ = viper.BindEnv("logForward.address", "DAGU_LOG_FORWARD_ADDRESS")
+	_ = viper.BindEnv("artifactBackend.type", "DAGU_ARTIFACT_BACKEND")
+	_ = viper.BindEnv("artifactBackend.bucket", "DAGU_ARTIFACT_BUCKET")
+	_ = viper.BindEnv("artifactBackend.prefix", "DAGU_ARTIFACT_PREFIX")
+	_ = viper.BindEnv("historyBackend.type", "DAGU_HISTORY_BACKEND")
+	_ = viper.BindEnv("historyBackend.path", "DAGU_HISTORY_DB")
+	_ = viper.BindEnv("historyBackend.url", "DAGU_HISTORY_DB_URL")
+	_ = viper.BindEnv("historyBackend.sharded", "DAGU_HISTORY_SHARDED")
+	_ = viper.BindEnv("triggerQueue.type", "DAGU_TRIGGER_QUEUE")
+	_ = viper.BindEnv("triggerQueue.path", "DAGU_TRIGGER_QUEUE_DB")
+	_ = viper.BindEnv("triggerQueue.intervalSec", "DAGU_TRIGGER_QUEUE_INTERVAL_SEC")
+	_ = viper.BindEnv("archiveBackend.type", "DAGU_ARCHIVE_BACKEND")
+	_ = viper.BindEnv("archiveBackend.dir", "DAGU_ARCHIVE_DIR")
+	_ = viper.BindEnv("archiveBackend.bucket", "DAGU_ARCHIVE_BUCKET")
+	_ = viper.BindEnv("archiveBackend.prefix", "DAGU_ARCHIVE_PREFIX")
+	_ = viper.BindEnv("archiveBackend.afterDays", "DAGU_ARCHIVE_AFTER_DAYS")
+	_ = viper.BindEnv("archiveBackend.intervalSec", "DAGU_ARCHIVE_INTERVAL_SEC")
+	_ = viper.BindEnv("logBackend.type", "DAGU_LOG_BACKEND")
+	_ = viper.BindEnv("logBackend.bucket", "DAGU_LOG_BUCKET")
+	_ = viper.BindEnv("logBackend.prefix", "DAGU_LOG_PREFIX")
+	_ = viper.BindEnv("vault.address", "DAGU_VAULT_ADDR", "VAULT_ADDR")
+	_ = viper.BindEnv("vault.namespace", "DAGU_VAULT_NAMESPACE", "VAULT_NAMESPACE")
+	_ = viper.BindEnv("vault.token", "DAGU_VAULT_TOKEN", "VAULT_TOKEN")
+	_ = viper.BindEnv("vault.authMethod", "DAGU_VAULT_AUTH_METHOD")
+	_ = viper.BindEnv("vault.roleId", "DAGU_VAULT_ROLE_ID")
+	_ = viper.BindEnv("vault.secretId", "DAGU_VAULT_SECRET_ID")
+	_ = viper.BindEnv("vault.role", "DAGU_VAULT_ROLE")
+	_ = viper.BindEnv("oidc.issuer", "DAGU_OIDC_ISSUER")
+	_ = viper.BindEnv("oidc.clientId", "DAGU_OIDC_CLIENT_ID")
+	_ = viper.BindEnv("oidc.clientSecret", "DAGU_OIDC_CLIENT_SECRET")
+	_ = viper.BindEnv("oidc.redirectURL", "DAGU_OIDC_REDIRECT_URL")
+	_ = viper.BindEnv("oidc.sessionSecret", "DAGU_OIDC_SESSION_SECRET")
+	_ = viper.BindEnv("ldap.url", "DAGU_LDAP_URL")
+	_ = viper.BindEnv("ldap.bindDN", "DAGU_LDAP_BIND_DN")
+	_ = viper.BindEnv("ldap.bindPassword", "DAGU_LDAP_BIND_PASSWORD")
+	_ = viper.BindEnv("ldap.baseDN", "DAGU_LDAP_BASE_DN")
+	_ = viper.BindEnv("namespace", NamespaceEnv)
+	_ = viper.BindEnv("runSummary.sink", "DAGU_RUN_SUMMARY_SINK")
+	_ = viper.BindEnv("runSummary.path", "DAGU_RUN_SUMMARY_PATH")
+	_ = viper.BindEnv("runSummary.url", "DAGU_RUN_SUMMARY_URL")
+	_ = viper.BindEnv("tracing.endpoint", "DAGU_TRACING_ENDPOINT", "OTEL_EXPORTER_OTLP_ENDPOINT")
+	_ = viper.BindEnv("tracing.serviceName", "DAGU_TRACING_SERVICE_NAME", "OTEL_SERVICE_NAME")
+	_ = viper.BindEnv("aws.region", "DAGU_AWS_REGION")
+	_ = viper.BindEnv("aws.profile", "DAGU_AWS_PROFILE")
+	_ = viper.BindEnv("aws.endpoint", "DAGU_AWS_ENDPOINT")
 
 	executable, err := os.Executable()
 	if err != nil {
@@ -125,10 +601,45 @@ func LoadConfig() error {
 	viper.SetDefault("isAuthToken", "0")
 	viper.SetDefault("authToken", "0")
 	viper.SetDefault("latestStatusToday", "0")
+	viper.SetDefault("isSchedulerHA", "0")
+	viper.SetDefault("schedulerLeaseFile", path.Join(appHome, "data", "scheduler.lease"))
+	viper.SetDefault("schedulerLeaseTTLSec", "15")
+	viper.SetDefault("gcIntervalSec", "3600")
+	viper.SetDefault("gcMinAgeSec", "86400")
+	viper.SetDefault("histRetentionDays", "30")
+	viper.SetDefault("histRetentionRuns", "0")
+	viper.SetDefault("histRetentionBytes", "0")
+	viper.SetDefault("pruneIntervalSec", "3600")
+	viper.SetDefault("pruneWorkers", "4")
+	viper.SetDefault("recoverLostRuns", "1")
+	viper.SetDefault("lostRunFailureHandler", "0")
+	viper.SetDefault("triggerQueue.intervalSec", "5")
+	viper.SetDefault("archiveBackend.afterDays", "90")
+	viper.SetDefault("archiveBackend.intervalSec", "3600")
+	viper.SetDefault("oidc.scopes", []string{"profile", "email"})
+	viper.SetDefault("oidc.usernameClaim", "preferred_username")
+	viper.SetDefault("oidc.groupsClaim", "groups")
+	viper.SetDefault("oidc.sessionTTLSec", "28800")
+	viper.SetDefault("ldap.userFilter", "(uid={username})")
+	viper.SetDefault("ldap.groupFilter", "(member={dn})")
+	viper.SetDefault("ldap.groupAttribute", "cn")
+	viper.SetDefault("ldap.timeoutSec", "10")
+	viper.SetDefault("strictMode", "0")
+	viper.SetDefault("allowChaos", "0")
+	viper.SetDefault("changeControl", "0")
+	viper.SetDefault("manualRunLane", "0")
+	viper.SetDefault("searchLogRuns", "3")
+	viper.SetDefault("handlerTimeoutSec", "600")
+	viper.SetDefault("notificationWorkers", "2")
+	viper.SetDefault("notificationTimeoutSec", "30")
+	viper.SetDefault("notificationRetries", "2")
 
 	viper.AutomaticEnv()
 
 	_ = viper.ReadInConfig()
+	if err := mergeProfile(); err != nil {
+		return err
+	}
 
 	cfg := &Config{}
 	if err := viper.Unmarshal(cfg); err != nil {
@@ -136,6 +647,18 @@ func LoadConfig() error {
 	}
 	loadLegacyEnvs(cfg)
 	loadEnvs(cfg)
+	if namespace != "" {
+		cfg.Namespace = namespace
+	}
+	// The namespace of a remote instance is not configured locally.
+	if cfg.APIURL == "" {
+		if err := applyNamespace(cfg); err != nil {
+			return err
+		}
+	}
+	if err := applyClock(cfg); err != nil {
+		return err
+	}
 
 	cache.setConfig(cfg)
 
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// NamespaceEnv selects the namespace of a process, e.g. of the agent of
+	// a run of a DAG of the namespace.
+	NamespaceEnv = "DAGU_NAMESPACE"
+	// DefaultNamespace is the name of the DAGs and the history of the
+	// configuration itself, outside of the namespaces.
+	DefaultNamespace = "default"
+)
+
+var (
+	errUnknownNamespace = errors.New("unknown namespace")
+	errInvalidNamespace = errors.New("invalid namespace name")
+
+	reNamespace = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+)
+
+// namespace is the namespace selected with the --namespace flag. It takes
+// precedence over DAGU_NAMESPACE and the namespace key of the
+// configuration.
+var namespace string
+
+// SetNamespace selects the namespace the configuration is loaded with. It
+// is set in the environment too, so that the commands of the process, e.g.
+// the agents of the sub workflows, run in the namespace.
+func SetNamespace(name string) {
+	namespace = name
+	if name != "" {
+		_ = os.Setenv(NamespaceEnv, name)
+	}
+}
+
+// Namespace partitions the DAGs, the history and the base config of a team
+// or an environment, e.g. staging, from the ones of the other namespaces.
+// The directories are under ${DAGU_HOME}/namespaces/<name> by default.
+type Namespace struct {
+	Name       string
+	DAGs       string
+	DataDir    string
+	LogDir     string
+	BaseConfig string
+	// ChangeControl makes the edits of the DAGs of the namespace pending
+	// changes until they are approved.
+	ChangeControl bool
+	// EncryptionKeyFile is the file of the key the logs of the namespace
+	// are encrypted with.
+	EncryptionKeyFile string
+}
+
+// ForNamespace returns the configuration of the namespace, or the one
+// outside of the namespaces for the default namespace.
+func (cfg *Config) ForNamespace(name string) (*Config, error) {
+	if name == DefaultNamespace {
+		name = ""
+	}
+	if name == cfg.Namespace {
+		return cfg, nil
+	}
+	c := &Config{}
+	if err := viper.Unmarshal(c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cfg file: %w", err)
+	}
+	loadLegacyEnvs(c)
+	c.Namespace = name
+	if err := applyNamespace(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// applyNamespace replaces the directories of the configuration with the
+// ones of its namespace, if it has one.
+func applyNamespace(cfg *Config) error {
+	if cfg.Namespace == "" || cfg.Namespace == DefaultNamespace {
+		cfg.Namespace = ""
+		return nil
+	}
+	var ns *Namespace
+	for i := range cfg.Namespaces {
+		if cfg.Namespaces[i].Name == cfg.Namespace {
+			ns = &cfg.Namespaces[i]
+		}
+	}
+	if ns == nil {
+		return fmt.Errorf("%w: %s", errUnknownNamespace, cfg.Namespace)
+	}
+	if err := ValidateNamespaceName(ns.Name); err != nil {
+		return err
+	}
+	dir := path.Join(appHomeDir(), "namespaces", ns.Name)
+	cfg.DAGs = orDefault(ns.DAGs, path.Join(dir, "dags"))
+	cfg.DataDir = orDefault(ns.DataDir, path.Join(dir, "data"))
+	cfg.LogDir = orDefault(ns.LogDir, path.Join(dir, "logs"))
+	cfg.BaseConfig = orDefault(ns.BaseConfig, path.Join(dir, "config.yaml"))
+	cfg.ChangeControl = ns.ChangeControl
+	cfg.EncryptionKeyFile = ns.EncryptionKeyFile
+	// The other files of the history and the scheduler are in the data
+	// directory of the namespace.
+	cfg.SuspendFlagsDir = path.Join(cfg.DataDir, "suspend")
+	cfg.SchedulerLeaseFile = path.Join(cfg.DataDir, "scheduler.lease")
+	cfg.ArtifactDir = ""
+	if b := cfg.HistoryBackend; b != nil && b.Type == "sqlite" {
+		b.Path = ""
+	}
+	if q := cfg.TriggerQueue; q != nil {
+		q.Path = ""
+	}
+	// The objects of the namespace are under their own prefix, so that the
+	// access to them is granted per namespace, e.g. with the IAM policies of
+	// the bucket.
+	prefix := path.Join("namespaces", ns.Name)
+	if b := cfg.ArtifactBackend; b != nil {
+		b.Prefix = path.Join(b.Prefix, prefix)
+	}
+	if b := cfg.ArchiveBackend; b != nil {
+		b.Prefix = path.Join(b.Prefix, prefix)
+		if b.Dir != "" {
+			b.Dir = path.Join(b.Dir, prefix)
+		}
+	}
+	if b := cfg.LogBackend; b != nil {
+		b.Prefix = path.Join(b.Prefix, prefix)
+	}
+	return nil
+}
+
+// ValidateNamespaceName returns an error if the name cannot be the one of a
+// namespace. It is also a directory and a segment of the paths of the API.
+func ValidateNamespaceName(name string) error {
+	if !reNamespace.MatchString(name) || name == DefaultNamespace {
+		return fmt.Errorf("%w: %s", errInvalidNamespace, name)
+	}
+	return nil
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
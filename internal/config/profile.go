@@ -0,0 +1,120 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	profileEnv     = "DAGU_PROFILE"
+	profileExt     = ".yaml"
+	currentProfile = "current"
+)
+
+var (
+	errProfileNotFound = errors.New("profile not found")
+	errInvalidProfile  = errors.New("invalid profile name")
+)
+
+// profile is the profile selected with the --profile flag. It takes
+// precedence over DAGU_PROFILE and the profile selected with UseProfile.
+var profile string
+
+// SetProfile selects the profile the configuration is loaded with.
+func SetProfile(name string) {
+	profile = name
+}
+
+// ProfilesDir returns the directory of the profiles, by default
+// ~/.config/dagu/profiles. A profile is a YAML file named after it that has
+// the same keys as admin.yaml, e.g. host, port and authToken. Its keys
+// replace the ones of admin.yaml, and the environment variables replace
+// both.
+func ProfilesDir() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		dir = path.Join(homeDir(), ".config")
+	}
+	return path.Join(dir, "dagu", "profiles")
+}
+
+// CurrentProfile returns the name of the profile the configuration is
+// loaded with, or an empty string if there is none.
+func CurrentProfile() string {
+	if profile != "" {
+		return profile
+	}
+	if name := os.Getenv(profileEnv); name != "" {
+		return name
+	}
+	b, err := os.ReadFile(path.Join(ProfilesDir(), currentProfile))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
+
+// ListProfiles returns the names of the profiles in ProfilesDir.
+func ListProfiles() ([]string, error) {
+	entries, err := os.ReadDir(ProfilesDir())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != profileExt {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(e.Name(), profileExt))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// UseProfile selects the profile for the next commands.
+func UseProfile(name string) error {
+	if _, err := profileFile(name); err != nil {
+		return err
+	}
+	return os.WriteFile(path.Join(ProfilesDir(), currentProfile), []byte(name+"\n"), 0600)
+}
+
+func profileFile(name string) (string, error) {
+	if name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("%w: %s", errInvalidProfile, name)
+	}
+	file := path.Join(ProfilesDir(), name+profileExt)
+	if _, err := os.Stat(file); err != nil {
+		return "", fmt.Errorf("%w: %s", errProfileNotFound, name)
+	}
+	return file, nil
+}
+
+// mergeProfile merges the keys of the current profile over the
+// configuration file.
+func mergeProfile() error {
+	name := CurrentProfile()
+	if name == "" {
+		return nil
+	}
+	file, err := profileFile(name)
+	if err != nil {
+		return err
+	}
+	v := viper.New()
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read profile %s: %w", name, err)
+	}
+	return viper.MergeConfigMap(v.AllSettings())
+}
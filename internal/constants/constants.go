@@ -8,6 +8,7 @@ const (
 	OnSuccess = "onSuccess"
 	OnFailure = "onFailure"
 	OnCancel  = "onCancel"
+	OnTimeout = "onTimeout"
 	OnExit    = "onExit"
 )
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/dagu-dev/dagu/internal/constants"
 	// aliasing errors package to avoid conflict with the standard library
 	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/logfile"
 	"github.com/dagu-dev/dagu/internal/utils"
 	"github.com/robfig/cron/v3"
 	"golang.org/x/sys/unix"
@@ -23,6 +25,9 @@ type BuildDAGOptions struct {
 	parameters       string
 	skipEnvEval      bool
 	skipEnvSetup     bool
+	// resolveParamSources reads the defaults of the parameters from their
+	// sources.
+	resolveParamSources bool
 }
 type DAGBuilder struct {
 	options    BuildDAGOptions
@@ -56,6 +61,29 @@ var (
 	errExecutorConfigValueMustBeMap       = errors.New("executor.config value must be a map")
 	errExecutorHasInvalidKey              = errors.New("executor has invalid key")
 	errExecutorConfigMustBeStringOrMap    = errors.New("executor config must be string or map")
+	errForeachItemsRequired               = errors.New("foreach items must be specified")
+	errForeachItemsMustBeArrayOrString    = errors.New("foreach items must be an array or a string")
+	errForeachNegativeMaxParallel         = errors.New("foreach maxParallel must not be negative")
+	errScheduleEntryCronRequired          = errors.New("schedule entry must have a cron expression or an interval")
+	errScheduleEntryCronAndEvery          = errors.New("schedule entry must not have both a cron expression and an interval")
+	errScheduleEntryEveryTooShort         = errors.New("interval of a schedule entry must be at least a minute")
+	errScheduleEntryEveryOnlyStart        = errors.New("interval schedule entries can only start a DAG")
+	errScheduleEntryHasInvalidKey         = errors.New("schedule entry has invalid key")
+	errScheduleEntryValueMustBeString     = errors.New("schedule entry value must be a string")
+	errSubWorkflowParamsMustBeStringOrMap = errors.New("params of sub workflow must be a string or a map")
+	errDotenvMustBeStringOrArray          = errors.New("dotenv must be a string or an array")
+	errDotenvHasInvalidKey                = errors.New("dotenv has invalid key")
+	errDotenvFileRequired                 = errors.New("dotenv file must be specified")
+	errDotenvInvalidMissingPolicy         = errors.New("dotenv missing must be error, warn or ignore")
+	errToolVersionsMustBeArrayOrMap       = errors.New("toolVersions must be an array or a map")
+	errStepIfAndWhen                      = errors.New("only one of if and when can be specified")
+	errInvalidLogFormat                   = errors.New("logFormat must be text or json")
+	errRepeatNegativeLimit                = errors.New("repeatPolicy limit must not be negative")
+	errInvalidBackoff                     = errors.New("backoff must be exponential or a number of at least 1")
+	errRetryInvalidJitter                 = errors.New("retryPolicy jitter must be between 0 and 1")
+	errNegativeRetention                  = errors.New("histRetentionRuns and histRetentionBytes must not be negative")
+	errInvalidLogCompression              = errors.New("logCompression must be gzip or zstd")
+	errNegativeLogRotation                = errors.New("logRotation maxBytes and maxFiles must not be negative")
 )
 
 func (b *DAGBuilder) buildFromDefinition(def *configDefinition, baseConfig *DAG) (d *DAG, err error) {
@@ -92,8 +120,11 @@ func buildAll(def *configDefinition, d *DAG, options BuildDAGOptions) error {
 	errList := &dagerrors.ErrorList{}
 
 	errList.Add(buildLogDir(def, d))
+	errList.Add(buildDotenv(def, d))
+	errList.Add(buildToolVersions(def, d))
 	errList.Add(assertFunctions(def.Functions))
 	errList.Add(buildSteps(def, d, options))
+	errList.Add(assertScheduleSteps(d))
 	errList.Add(buildHandlers(def, d, options))
 	errList.Add(buildConfig(def, d))
 	errList.Add(buildSMTPConfig(def, d))
@@ -111,6 +142,11 @@ const (
 	scheduleStart   = "start"
 	scheduleStop    = "stop"
 	scheduleRestart = "restart"
+
+	scheduleEntryCron   = "cron"
+	scheduleEntryParams = "params"
+	scheduleEntrySteps  = "steps"
+	scheduleEntryEvery  = "every"
 )
 
 func setDAGProperties(def *configDefinition, d *DAG) {
@@ -124,33 +160,32 @@ func setDAGProperties(def *configDefinition, d *DAG) {
 		d.MailOn = &MailOn{
 			Failure: def.MailOn.Failure,
 			Success: def.MailOn.Success,
+			Expired: def.MailOn.Expired,
 		}
 	}
-	d.Delay = time.Second * time.Duration(def.DelaySec)
-	d.RestartWait = time.Second * time.Duration(def.RestartWaitSec)
 	d.Tags = parseTags(def.Tags)
+	d.Warnings = def.warnings
 }
 
 func buildSchedule(def *configDefinition, d *DAG) error {
-	var starts []string
-	var stops []string
-	var restarts []string
+	var starts []*scheduleEntry
+	var stops []*scheduleEntry
+	var restarts []*scheduleEntry
 
 	switch (def.Schedule).(type) {
 	case string:
-		starts = append(starts, def.Schedule.(string))
+		starts = append(starts, &scheduleEntry{cron: def.Schedule.(string)})
 	case []interface{}:
 		schedules, ok := def.Schedule.([]interface{})
 		if !ok {
 			return fmt.Errorf("%w, got %T: ", errScheduleMustBeArray, def.Schedule)
 		}
 		for _, s := range schedules {
-			s, ok := s.(string)
-			if !ok {
-				return fmt.Errorf("%w, got %T: ", errScheduleMustBeStringOrArray, s)
+			entry, err := parseScheduleEntry(s)
+			if err != nil {
+				return err
 			}
-
-			starts = append(starts, s)
+			starts = append(starts, entry)
 		}
 	case map[interface{}]interface{}:
 		if err := parseScheduleMap(def.Schedule.(map[interface{}]interface{}), &starts, &stops, &restarts); err != nil {
@@ -196,8 +231,50 @@ func buildLogDir(def *configDefinition, d *DAG) (err error) {
 	return err
 }
 
+func buildDotenv(def *configDefinition, d *DAG) (err error) {
+	d.Dotenv, err = parseDotenvDef(def.Dotenv)
+	return err
+}
+
+// buildToolVersions builds the tools whose versions are recorded with the
+// runs. A tool in a list is given by its name and its version is printed by
+// "<name> --version". In a map, the value is the command to print it.
+func buildToolVersions(def *configDefinition, d *DAG) error {
+	switch v := def.ToolVersions.(type) {
+	case nil:
+	case []any:
+		for _, name := range v {
+			n := fmt.Sprint(name)
+			d.ToolVersions = append(d.ToolVersions, ToolVersion{Name: n, Command: n + " --version"})
+		}
+	case map[any]any:
+		for name, cmd := range v {
+			d.ToolVersions = append(d.ToolVersions, ToolVersion{Name: fmt.Sprint(name), Command: fmt.Sprint(cmd)})
+		}
+	case map[string]any:
+		for name, cmd := range v {
+			d.ToolVersions = append(d.ToolVersions, ToolVersion{Name: name, Command: fmt.Sprint(cmd)})
+		}
+	default:
+		return errToolVersionsMustBeArrayOrMap
+	}
+	if _, ok := def.ToolVersions.([]any); !ok {
+		// Keep the order of the map stable.
+		sort.Slice(d.ToolVersions, func(i, j int) bool {
+			return d.ToolVersions[i].Name < d.ToolVersions[j].Name
+		})
+	}
+	return nil
+}
+
 func buildParams(def *configDefinition, d *DAG, options BuildDAGOptions) (err error) {
-	d.DefaultParams = def.Params
+	if d.ParamDefs, err = parseParamDefs(def.Params); err != nil {
+		return err
+	}
+	if d.ParamDefs != nil {
+		return buildDeclaredParams(d, options)
+	}
+	d.DefaultParams, _ = def.Params.(string)
 	p := d.DefaultParams
 	if options.parameters != "" {
 		p = options.parameters
@@ -210,6 +287,170 @@ func buildParams(def *configDefinition, d *DAG, options BuildDAGOptions) (err er
 	return
 }
 
+// buildDeclaredParams sets the declared parameters of the DAG to the given
+// values or their defaults, and validates them.
+func buildDeclaredParams(d *DAG, options BuildDAGOptions) error {
+	eval := !options.skipEnvEval
+	d.DefaultParams = defaultParams(d.ParamDefs)
+	defaults, err := utils.ParseParams(d.DefaultParams, eval)
+	if err != nil {
+		return err
+	}
+	given, err := utils.ParseParams(options.parameters, eval)
+	if err != nil {
+		return err
+	}
+	if eval {
+		expandParams(defaults)
+		expandParams(given)
+	}
+	params, err := resolveParams(d.ParamDefs, defaults, given)
+	if err != nil {
+		return err
+	}
+	if eval && options.resolveParamSources {
+		if err := resolveParamSources(d.ParamDefs, params, given); err != nil {
+			return err
+		}
+	}
+	// Without evaluation the DAG is loaded to be shown or edited, so the
+	// values are only validated when a run is started.
+	if eval {
+		if err := validateParams(d.ParamDefs, params, true); err != nil {
+			return err
+		}
+	}
+	var envs []string
+	d.Params, envs, err = setParameters(params, false, options)
+	if err == nil {
+		d.Env = append(d.Env, envs...)
+	}
+	return err
+}
+
+// parseRepeatUntil parses the options of a repeat policy that repeats the
+// step until a condition is met. Until implies repeat.
+func parseRepeatUntil(p *RepeatPolicy, def *repeatPolicyDef) error {
+	if def.Until != "" {
+		if _, err := parseExpr(def.Until); err != nil {
+			return err
+		}
+		p.Repeat = true
+		p.Until = def.Until
+	}
+	if def.Limit < 0 {
+		return errRepeatNegativeLimit
+	}
+	backoff, err := parseBackoff(def.Backoff)
+	if err != nil {
+		return fmt.Errorf("repeatPolicy.backoff: %w", err)
+	}
+	maxInterval, err := ParseDuration(def.MaxInterval)
+	if err != nil {
+		return fmt.Errorf("repeatPolicy.maxInterval: %w", err)
+	}
+	p.Limit = def.Limit
+	p.Backoff = backoff
+	p.MaxInterval = maxInterval
+	return nil
+}
+
+func parseRetryPolicy(def *retryPolicyDef) (*RetryPolicy, error) {
+	interval, _, err := parseDurationField("retryPolicy.interval", def.Interval, def.IntervalSec)
+	if err != nil {
+		return nil, err
+	}
+	backoff, err := parseBackoff(def.Backoff)
+	if err != nil {
+		return nil, fmt.Errorf("retryPolicy.backoff: %w", err)
+	}
+	maxInterval, err := ParseDuration(def.MaxInterval)
+	if err != nil {
+		return nil, fmt.Errorf("retryPolicy.maxInterval: %w", err)
+	}
+	if def.Jitter < 0 || def.Jitter > 1 {
+		return nil, errRetryInvalidJitter
+	}
+	return &RetryPolicy{
+		Limit:       def.Limit,
+		Interval:    interval,
+		Backoff:     backoff,
+		MaxInterval: maxInterval,
+		Jitter:      def.Jitter,
+		ExitCodes:   def.ExitCodes,
+	}, nil
+}
+
+// backoffExponential is the backoff that doubles the interval.
+const backoffExponential = "exponential"
+
+// parseBackoff parses the multiplier of an interval. Exponential or true
+// doubles the interval.
+func parseBackoff(v any) (float64, error) {
+	var backoff float64
+	switch v := v.(type) {
+	case nil:
+		return 0, nil
+	case bool:
+		if v {
+			backoff = 2
+		}
+	case int:
+		backoff = float64(v)
+	case float64:
+		backoff = v
+	case string:
+		if v == backoffExponential {
+			return 2, nil
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s", errInvalidBackoff, v)
+		}
+		backoff = f
+	default:
+		return 0, fmt.Errorf("%w: %v", errInvalidBackoff, v)
+	}
+	if backoff != 0 && backoff < 1 {
+		return 0, fmt.Errorf("%w: %v", errInvalidBackoff, v)
+	}
+	return backoff, nil
+}
+
+// parseIf parses the expression of the step so that syntax errors are found
+// when the DAG is loaded. It is evaluated when the step is ready to run.
+func parseIf(step *Step, def *stepDef) error {
+	if def.If != "" && def.When != "" {
+		return errStepIfAndWhen
+	}
+	step.If = utils.StringWithFallback(def.If, def.When)
+	if step.If == "" {
+		return nil
+	}
+	if _, err := parseExpr(step.If); err != nil {
+		return err
+	}
+	return nil
+}
+
+func parseStepTimeout(step *Step, def *stepDef) (err error) {
+	if step.Timeout, _, err = parseDurationField("timeout", def.Timeout, def.TimeoutSec); err != nil {
+		return err
+	}
+	grace, ok, err := parseDurationField("killGracePeriod", def.KillGracePeriod, def.KillGracePeriodSec)
+	if err != nil {
+		return err
+	}
+	if step.Timeout == 0 {
+		return nil
+	}
+	if !ok {
+		grace = defaultKillGracePeriod
+	}
+	step.KillGracePeriod = grace
+	return nil
+}
+
 func buildHandlers(def *configDefinition, d *DAG, options BuildDAGOptions) (err error) {
 	if def.HandlerOn.Exit != nil {
 		def.HandlerOn.Exit.Name = constants.OnExit
@@ -238,6 +479,13 @@ func buildHandlers(def *configDefinition, d *DAG, options BuildDAGOptions) (err
 			return
 		}
 	}
+
+	if def.HandlerOn.Timeout != nil {
+		def.HandlerOn.Timeout.Name = constants.OnTimeout
+		if d.HandlerOn.Timeout, err = buildStep(d.Env, def.HandlerOn.Timeout, def.Functions, options); err != nil {
+			return
+		}
+	}
 	return nil
 }
 
@@ -245,15 +493,73 @@ func buildConfig(def *configDefinition, d *DAG) (err error) {
 	if def.HistRetentionDays != nil {
 		d.HistRetentionDays = *def.HistRetentionDays
 	}
+	if def.HistRetentionRuns < 0 || def.HistRetentionBytes < 0 {
+		return errNegativeRetention
+	}
+	d.HistRetentionRuns = def.HistRetentionRuns
+	d.HistRetentionBytes = def.HistRetentionBytes
 	d.Preconditions = loadPreCondition(def.Preconditions)
 	d.MaxActiveRuns = def.MaxActiveRuns
+	d.Pool = def.Pool
+	d.Priority = def.Priority
+	switch def.LogFormat {
+	case "", LogFormatText, LogFormatJSON:
+		d.LogFormat = def.LogFormat
+	default:
+		return fmt.Errorf("%w: %s", errInvalidLogFormat, def.LogFormat)
+	}
+	switch def.LogCompression {
+	case "", logfile.CompressionGzip, logfile.CompressionZstd:
+		d.LogCompression = def.LogCompression
+	default:
+		return fmt.Errorf("%w: %s", errInvalidLogCompression, def.LogCompression)
+	}
+	if d.LogRotation, err = parseLogRotation(def.LogRotation); err != nil {
+		return err
+	}
 
-	if def.MaxCleanUpTimeSec != nil {
-		d.MaxCleanUpTime = time.Second * time.Duration(*def.MaxCleanUpTimeSec)
+	if d.Delay, _, err = parseDurationField("delay", def.Delay, def.DelaySec); err != nil {
+		return err
+	}
+	if d.RestartWait, _, err = parseDurationField("restartWait", def.RestartWait, def.RestartWaitSec); err != nil {
+		return err
+	}
+	if d.QueueTTL, err = ParseDuration(def.QueueTTL); err != nil {
+		return fmt.Errorf("queueTTL: %w", err)
+	}
+	if d.SLA, err = ParseDuration(def.Sla); err != nil {
+		return fmt.Errorf("sla: %w", err)
+	}
+	if t, ok, err := parseDurationField("maxCleanUpTime", def.MaxCleanUpTime, def.MaxCleanUpTimeSec); err != nil {
+		return err
+	} else if ok {
+		d.MaxCleanUpTime = t
+	}
+	if def.AlertPolicy != nil {
+		d.AlertPolicy = &AlertPolicy{NotifyResolved: def.AlertPolicy.NotifyResolved}
+		if d.AlertPolicy.Window, _, err = parseDurationField("alertPolicy.window", def.AlertPolicy.Window, def.AlertPolicy.WindowSec); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// parseLogRotation parses the rotation of the step logs. Five rotated files
+// are kept if maxFiles is not set.
+func parseLogRotation(def *logRotationDef) (*LogRotation, error) {
+	if def == nil {
+		return nil, nil
+	}
+	if def.MaxBytes < 0 || def.MaxFiles < 0 {
+		return nil, errNegativeLogRotation
+	}
+	r := &LogRotation{MaxBytes: def.MaxBytes, MaxFiles: def.MaxFiles}
+	if r.MaxFiles == 0 {
+		r.MaxFiles = 5
+	}
+	return r, nil
+}
+
 func parseParameters(value string, eval bool, options BuildDAGOptions) (
 	params []string,
 	envs []string,
@@ -264,7 +570,16 @@ func parseParameters(value string, eval bool, options BuildDAGOptions) (
 	if err != nil {
 		return
 	}
+	return setParameters(parsedParams, eval, options)
+}
 
+// setParameters sets the parameters to the environment as positional
+// parameters, and as variables for the named ones.
+func setParameters(parsedParams []utils.Parameter, eval bool, options BuildDAGOptions) (
+	params []string,
+	envs []string,
+	err error,
+) {
 	ret := []string{}
 	for i, p := range parsedParams {
 		if eval {
@@ -360,7 +675,11 @@ func buildSteps(def *configDefinition, d *DAG, options BuildDAGOptions) error {
 		}
 		ret = append(ret, *step)
 	}
-	d.Steps = ret
+	stageSteps, err := buildStages(def, d, options)
+	if err != nil {
+		return err
+	}
+	d.Steps = append(ret, stageSteps...)
 
 	return nil
 }
@@ -373,6 +692,14 @@ func buildStep(variables []string, def *stepDef, funcs []*funcDef, options Build
 	step := &Step{}
 	step.Name = def.Name
 	step.Description = def.Description
+	group, err := parseGroup(def.Group)
+	if err != nil {
+		return nil, err
+	}
+	step.Group = group
+	if step.LogRotation, err = parseLogRotation(def.LogRotation); err != nil {
+		return nil, err
+	}
 
 	if err := parseFuncCall(step, def.Call, funcs); err != nil {
 		return nil, err
@@ -381,6 +708,9 @@ func buildStep(variables []string, def *stepDef, funcs []*funcDef, options Build
 	if err := parseCommand(step, def.Command); err != nil {
 		return nil, err
 	}
+	if err := parsePlatforms(step, def.Platforms); err != nil {
+		return nil, err
+	}
 
 	step.Script = def.Script
 	step.Stdout = expandEnv(def.Stdout, options)
@@ -407,14 +737,22 @@ func buildStep(variables []string, def *stepDef, funcs []*funcDef, options Build
 		step.ContinueOn.Failure = def.ContinueOn.Failure
 	}
 	if def.RetryPolicy != nil {
-		step.RetryPolicy = &RetryPolicy{
-			Limit:    def.RetryPolicy.Limit,
-			Interval: time.Second * time.Duration(def.RetryPolicy.IntervalSec),
+		retryPolicy, err := parseRetryPolicy(def.RetryPolicy)
+		if err != nil {
+			return nil, err
 		}
+		step.RetryPolicy = retryPolicy
 	}
 	if def.RepeatPolicy != nil {
+		interval, _, err := parseDurationField("repeatPolicy.interval", def.RepeatPolicy.Interval, def.RepeatPolicy.IntervalSec)
+		if err != nil {
+			return nil, err
+		}
 		step.RepeatPolicy.Repeat = def.RepeatPolicy.Repeat
-		step.RepeatPolicy.Interval = time.Second * time.Duration(def.RepeatPolicy.IntervalSec)
+		step.RepeatPolicy.Interval = interval
+		if err := parseRepeatUntil(&step.RepeatPolicy, def.RepeatPolicy); err != nil {
+			return nil, err
+		}
 	}
 	if def.SignalOnStop != nil {
 		sigDef := *def.SignalOnStop
@@ -424,20 +762,159 @@ func buildStep(variables []string, def *stepDef, funcs []*funcDef, options Build
 		}
 		step.SignalOnStop = sigDef
 	}
+	if err := parseStepTimeout(step, def); err != nil {
+		return nil, err
+	}
 	step.MailOnError = def.MailOnError
+	step.Pool = def.Pool
+	step.ConcurrencyKey = def.ConcurrencyKey
+	step.Generator = def.Generator
 	step.Preconditions = loadPreCondition(def.Preconditions)
+	if err := parseIf(step, def); err != nil {
+		return nil, err
+	}
 
 	if err := parseSubWorkflow(step, def.Run, def.Params); err != nil {
 		return nil, err
 	}
 
+	dotenv, err := parseDotenvDef(def.Dotenv)
+	if err != nil {
+		return nil, err
+	}
+	step.Dotenv = dotenv
+
+	if step.Inputs, err = parseInputs(def.Inputs); err != nil {
+		return nil, err
+	}
+	for i := range step.Inputs {
+		step.Inputs[i].Path = expandEnv(step.Inputs[i].Path, options)
+	}
+	if step.Artifacts, err = parseArtifacts(def.Artifacts); err != nil {
+		return nil, err
+	}
+	if step.Cache, err = parseCachePolicy(def.Cache); err != nil {
+		return nil, err
+	}
+	if step.Guard, err = parseGuard(def.Guard); err != nil {
+		return nil, err
+	}
+	if step.ExpectedFiles, err = parseExpectedFiles(def.ExpectedFiles); err != nil {
+		return nil, err
+	}
+	if step.Stdin, err = parseStdin(def.Stdin); err != nil {
+		return nil, err
+	}
+
+	if err := parseForeach(step, def.Foreach); err != nil {
+		return nil, err
+	}
+
 	return step, nil
 }
 
-func parseSubWorkflow(step *Step, name, params string) error {
+func parseForeach(step *Step, def *foreachDef) error {
+	if def == nil {
+		return nil
+	}
+	if def.MaxParallel < 0 {
+		return fmt.Errorf("%w: %d", errForeachNegativeMaxParallel, def.MaxParallel)
+	}
+	foreach := &Foreach{MaxParallel: def.MaxParallel}
+	switch val := def.Items.(type) {
+	case nil:
+		return errForeachItemsRequired
+	case string:
+		if val == "" {
+			return errForeachItemsRequired
+		}
+		foreach.ItemsExpr = val
+	case []any:
+		for _, v := range val {
+			foreach.Items = append(foreach.Items, fmt.Sprintf("%v", v))
+		}
+	default:
+		return errForeachItemsMustBeArrayOrString
+	}
+	step.Foreach = foreach
+	return nil
+}
+
+const (
+	dotenvFile    = "file"
+	dotenvMissing = "missing"
+)
+
+// parseDotenvDef parses a dotenv file or a list of them. Each entry is a
+// file name or a map with the file name and the policy for a missing file.
+func parseDotenvDef(def any) ([]Dotenv, error) {
+	var entries []any
+	switch val := def.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		entries = []any{val}
+	case []any:
+		entries = val
+	default:
+		return nil, errDotenvMustBeStringOrArray
+	}
+	var files []Dotenv
+	for _, entry := range entries {
+		f, err := parseDotenvEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+func parseDotenvEntry(entry any) (Dotenv, error) {
+	var f Dotenv
+	switch val := entry.(type) {
+	case string:
+		f.File = val
+	case map[string]any:
+		m := make(map[any]any, len(val))
+		for k, v := range val {
+			m[k] = v
+		}
+		return parseDotenvEntry(m)
+	case map[any]any:
+		for k, v := range val {
+			s, ok := v.(string)
+			switch {
+			case k == dotenvFile && ok:
+				f.File = s
+			case k == dotenvMissing && ok:
+				f.Missing = s
+			default:
+				return f, fmt.Errorf("%w: %v", errDotenvHasInvalidKey, k)
+			}
+		}
+	default:
+		return f, errDotenvMustBeStringOrArray
+	}
+	if f.File == "" {
+		return f, errDotenvFileRequired
+	}
+	switch f.Missing {
+	case "", DotenvMissingError, DotenvMissingWarn, DotenvMissingIgnore:
+	default:
+		return f, fmt.Errorf("%w: %s", errDotenvInvalidMissingPolicy, f.Missing)
+	}
+	return f, nil
+}
+
+func parseSubWorkflow(step *Step, name string, paramsDef any) error {
 	if name == "" {
 		return nil
 	}
+	params, err := parseSubWorkflowParams(paramsDef)
+	if err != nil {
+		return err
+	}
 	step.SubWorkflow = &SubWorkflow{
 		Name:   name,
 		Params: params,
@@ -449,6 +926,39 @@ func parseSubWorkflow(step *Step, name, params string) error {
 	return nil
 }
 
+// parseSubWorkflowParams returns the parameters passed to a sub workflow.
+// Parameters given as a map are passed as named parameters sorted by name.
+func parseSubWorkflowParams(paramsDef any) (string, error) {
+	switch val := paramsDef.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return val, nil
+	case map[string]any:
+		m := make(map[any]any, len(val))
+		for k, v := range val {
+			m[k] = v
+		}
+		return parseSubWorkflowParams(m)
+	case map[any]any:
+		params := make([]string, 0, len(val))
+		for k, v := range val {
+			name, ok := k.(string)
+			if !ok {
+				return "", fmt.Errorf("%w: %v", errInvalidKeyType, k)
+			}
+			params = append(params, utils.StringifyParam(utils.Parameter{
+				Name:  name,
+				Value: fmt.Sprint(v),
+			}))
+		}
+		sort.Strings(params)
+		return strings.Join(params, " "), nil
+	default:
+		return "", fmt.Errorf("%w: %T", errSubWorkflowParamsMustBeStringOrMap, val)
+	}
+}
+
 func parseExecutor(step *Step, executor any) error {
 	if executor == nil {
 		return nil
@@ -658,21 +1168,58 @@ func parseTags(value string) []string {
 	return ret
 }
 
-func parseSchedule(values []string) ([]*Schedule, error) {
+func parseSchedule(entries []*scheduleEntry) ([]*Schedule, error) {
 	ret := []*Schedule{}
-	for _, v := range values {
-		paresed, err := cronParser.Parse(v)
+	for _, e := range entries {
+		if e.every > 0 {
+			ret = append(ret, &Schedule{
+				Expression: fmt.Sprintf("every %s after previous run", formatInterval(e.every)),
+				Parsed:     cron.Every(e.every),
+				Interval:   e.every,
+				Params:     e.params,
+				Steps:      e.steps,
+			})
+			continue
+		}
+		paresed, err := cronParser.Parse(e.cron)
 		if err != nil {
 			return nil, fmt.Errorf("%w: %s", errInvalidSchedule, err)
 		}
 		ret = append(ret, &Schedule{
-			Expression: v,
+			Expression: e.cron,
 			Parsed:     paresed,
+			Params:     e.params,
+			Steps:      e.steps,
 		})
 	}
 	return ret, nil
 }
 
+// formatInterval formats the interval without the zero units, e.g. 2h
+// instead of 2h0m0s.
+func formatInterval(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// assertScheduleSteps checks that the steps of the schedule entries exist.
+func assertScheduleSteps(d *DAG) error {
+	for _, schedules := range [][]*Schedule{d.Schedule, d.StopSchedule, d.RestartSchedule} {
+		for _, s := range schedules {
+			if _, err := d.SelectSteps(s.Steps); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // only assert functions clause
 func assertFunctions(funcs []*funcDef) error {
 	if funcs == nil {
@@ -707,7 +1254,7 @@ func assertStepDef(def *stepDef, funcs []*funcDef) error {
 		return errStepNameRequired
 	}
 	// TODO: Refactor the validation check for each executor.
-	if def.Executor == nil && def.Command == nil && def.Call == nil && def.Run == "" {
+	if def.Executor == nil && def.Command == nil && def.Call == nil && def.Run == "" && len(def.Platforms) == 0 {
 		return errStepCommandOrCallRequired
 	}
 
@@ -741,44 +1288,129 @@ func assertStepDef(def *stepDef, funcs []*funcDef) error {
 }
 
 // nolint // cognitive complexity
-func parseScheduleMap(scheduleMap map[interface{}]interface{}, starts, stops, restarts *[]string) error {
+func parseScheduleMap(scheduleMap map[interface{}]interface{}, starts, stops, restarts *[]*scheduleEntry) error {
 	for k, v := range scheduleMap {
 		if _, ok := k.(string); !ok {
 			return errScheduleKeyMustBeString
 		}
+		var entries []*scheduleEntry
 		switch k.(string) {
 		case scheduleStart, scheduleStop, scheduleRestart:
 			switch v := (v).(type) {
-			case string:
-				switch k {
-				case scheduleStart:
-					*starts = append(*starts, v)
-				case scheduleStop:
-					*stops = append(*stops, v)
-				case scheduleRestart:
-					*restarts = append(*restarts, v)
-				}
 			case []interface{}:
 				for _, item := range v {
-					if item, ok := item.(string); ok {
-						switch k {
-						case scheduleStart:
-							*starts = append(*starts, item)
-						case scheduleStop:
-							*stops = append(*stops, item)
-						case scheduleRestart:
-							*restarts = append(*restarts, item)
-						}
-					} else {
-						return errScheduleMustBeStringOrArray
+					entry, err := parseScheduleEntry(item)
+					if err != nil {
+						return err
 					}
+					entries = append(entries, entry)
 				}
 			default:
-				return errScheduleMustBeStringOrArray
+				entry, err := parseScheduleEntry(v)
+				if err != nil {
+					return err
+				}
+				entries = append(entries, entry)
 			}
 		default:
 			return errScheduleKeyMustBeStartOrStop
 		}
+		if k != scheduleStart {
+			for _, e := range entries {
+				if e.every > 0 {
+					return fmt.Errorf("%w: %s", errScheduleEntryEveryOnlyStart, k)
+				}
+			}
+		}
+		switch k {
+		case scheduleStart:
+			*starts = append(*starts, entries...)
+		case scheduleStop:
+			*stops = append(*stops, entries...)
+		case scheduleRestart:
+			*restarts = append(*restarts, entries...)
+		}
 	}
 	return nil
 }
+
+// scheduleEntry is a cron expression or an interval with the options of
+// the runs it triggers.
+type scheduleEntry struct {
+	cron   string
+	every  time.Duration
+	params string
+	steps  []string
+}
+
+// parseScheduleEntry parses a schedule entry given either as a cron
+// expression or as a map with the cron or every, params and steps keys.
+func parseScheduleEntry(v interface{}) (*scheduleEntry, error) {
+	switch v := v.(type) {
+	case string:
+		return &scheduleEntry{cron: v}, nil
+	case map[interface{}]interface{}:
+		entry := &scheduleEntry{}
+		for key, val := range v {
+			switch key {
+			case scheduleEntryCron:
+				cron, ok := val.(string)
+				if !ok {
+					return nil, fmt.Errorf("%w: %s", errScheduleEntryValueMustBeString, key)
+				}
+				entry.cron = cron
+			case scheduleEntryParams:
+				params, ok := val.(string)
+				if !ok {
+					return nil, fmt.Errorf("%w: %s", errScheduleEntryValueMustBeString, key)
+				}
+				entry.params = params
+			case scheduleEntrySteps:
+				steps, err := parseScheduleEntrySteps(val)
+				if err != nil {
+					return nil, err
+				}
+				entry.steps = steps
+			case scheduleEntryEvery:
+				every, err := ParseDuration(val)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %s", err, key)
+				}
+				if every < time.Minute {
+					return nil, fmt.Errorf("%w: %v", errScheduleEntryEveryTooShort, val)
+				}
+				entry.every = every
+			default:
+				return nil, fmt.Errorf("%w: %v", errScheduleEntryHasInvalidKey, key)
+			}
+		}
+		if entry.cron == "" && entry.every == 0 {
+			return nil, errScheduleEntryCronRequired
+		}
+		if entry.cron != "" && entry.every > 0 {
+			return nil, errScheduleEntryCronAndEvery
+		}
+		return entry, nil
+	default:
+		return nil, errScheduleMustBeStringOrArray
+	}
+}
+
+func parseScheduleEntrySteps(v interface{}) ([]string, error) {
+	switch v := v.(type) {
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		var steps []string
+		for _, s := range v {
+			s, ok := s.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", errScheduleEntryValueMustBeString, scheduleEntrySteps)
+			}
+			steps = append(steps, s)
+		}
+		return steps, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errScheduleEntryValueMustBeString, scheduleEntrySteps)
+	}
+}
@@ -1,13 +1,17 @@
 package dag
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/constants"
 	"github.com/stretchr/testify/require"
 )
 
@@ -36,6 +40,239 @@ env:
 		{
 			input: `schedule: "1"`,
 		},
+		{
+			input: `toolVersions: python3`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    command: "true"
+    retryPolicy:
+      limit: 3
+      backoff: fast`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    command: "true"
+    retryPolicy:
+      limit: 3
+      jitter: 2`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    command: "true"
+    timeout: 1m
+    timeoutSec: 60`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    command: "true"
+    timeoutSec: -1`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    command: "true"
+    repeatPolicy:
+      until: "$STATUS =="`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    command: "true"
+    repeatPolicy:
+      until: "$STATUS == DONE"
+      backoff: 0.5`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    command: "true"
+    repeatPolicy:
+      repeat: true
+      limit: -1`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    command: "true"
+    inputs: [other/latest/a.csv]`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    command: "true"
+    inputs: ["dag://other/latest/../a.csv"]`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    command: "true"
+    inputs:
+      - ref: dag://other/1234/a.csv
+        maxAge: 1h`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    command: "true"
+    inputs: ["artifact://../a.csv"]`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    command: "true"
+    artifacts: [/tmp/*.csv]`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    command: "true"
+    artifacts: ["dist/[a-"]`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    command: "true"
+    if: "$ENV =="`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    platforms:
+      macos:
+        command: "true"`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    platforms:
+      linux/x86:
+        command: "true"`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    platforms:
+      linux: {}`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    command: "true"
+    if: "true"
+    when: "true"`,
+		},
+		{
+			input: `delay: 1 minute`,
+		},
+		{
+			input: `
+delay: 1m
+delaySec: 60`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    command: "true"
+    retryPolicy:
+      interval: -1s`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    command: echo ${ITEM}
+    foreach:
+      maxParallel: 2`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    command: echo ${ITEM}
+    foreach:
+      items: 1`,
+		},
+		{
+			input: `
+steps:
+  - name: step 1
+    command: echo ${ITEM}
+    foreach:
+      items: [a]
+      maxParallel: -1`,
+		},
+		{
+			input: `
+steps:
+  - name: "1"
+    run: sub
+    params: [A, B]`,
+		},
+		{
+			input: `
+dotenv: 1`,
+		},
+		{
+			input: `
+dotenv:
+  - file: .env
+    missing: skip`,
+		},
+		{
+			input: `
+steps:
+  - name: "1"
+    command: "true"
+    dotenv:
+      - missing: ignore`,
+		},
+		{
+			input: `
+steps:
+  - name: "1"
+    command: "true"
+    cache: 1`,
+		},
+		{
+			input: `
+steps:
+  - name: "1"
+    command: "true"
+    cache:
+      ttl: 1h`,
+		},
+		{
+			input: `
+steps:
+  - name: "1"
+    command: "true"
+    cache:
+      maxAge: soon`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -346,6 +583,66 @@ schedule:
 			input: `
 schedule:
   invalid: "* * * * * * *"
+`,
+			isErr: true,
+		},
+		{
+			input: `
+schedule:
+  restart:
+    - params: "foo"
+`,
+			isErr: true,
+		},
+		{
+			input: `
+schedule:
+  restart:
+    - cron: "0 12 * * *"
+      unknown: "foo"
+`,
+			isErr: true,
+		},
+		{
+			input: `
+schedule:
+  restart:
+    - cron: "0 12 * * *"
+      steps: [server]
+`,
+			isErr: true,
+		},
+		{
+			input: `
+schedule:
+  - every: 2h
+  - every: 90m
+    params: "MODE=fast"
+`,
+			expected: map[string][]string{
+				"start": {"every 2h after previous run", "every 1h30m after previous run"},
+			},
+		},
+		{
+			input: `
+schedule:
+  stop:
+    - every: 2h
+`,
+			isErr: true,
+		},
+		{
+			input: `
+schedule:
+  - every: 30s
+`,
+			isErr: true,
+		},
+		{
+			input: `
+schedule:
+  - every: 1h
+    cron: "0 * * * *"
 `,
 			isErr: true,
 		},
@@ -395,6 +692,39 @@ schedule:
 	}
 }
 
+func TestBuildingScheduleEntries(t *testing.T) {
+	dat := `schedule:
+  start: "0 8 * * *"
+  restart:
+    - cron: "0 0 * * *"
+      params: "MODE=nightly"
+      steps: server
+  stop:
+    - cron: "0 20 * * *"
+      steps: [server, worker]
+steps:
+  - name: server
+    command: server.sh
+  - name: worker
+    command: worker.sh
+`
+	l := &Loader{}
+	d, err := l.LoadData([]byte(dat))
+	require.NoError(t, err)
+
+	require.Len(t, d.Schedule, 1)
+	require.Empty(t, d.Schedule[0].Params)
+	require.Empty(t, d.Schedule[0].Steps)
+
+	require.Len(t, d.RestartSchedule, 1)
+	require.Equal(t, "0 0 * * *", d.RestartSchedule[0].Expression)
+	require.Equal(t, "MODE=nightly", d.RestartSchedule[0].Params)
+	require.Equal(t, []string{"server"}, d.RestartSchedule[0].Steps)
+
+	require.Len(t, d.StopSchedule, 1)
+	require.Equal(t, []string{"server", "worker"}, d.StopSchedule[0].Steps)
+}
+
 func TestGeneratingSockAddr(t *testing.T) {
 	d := &DAG{Location: "testdata/testDag.yml"}
 	require.Regexp(t, `^/tmp/@dagu-testDag-[0-9a-f]+\.sock$`, d.SockAddr())
@@ -508,6 +838,140 @@ steps:
 	}
 }
 
+func TestBuildingForeach(t *testing.T) {
+	dat := `name: test DAG
+steps:
+  - name: "1"
+    command: echo ${ITEM}
+    foreach:
+      items: [a, 1]
+      maxParallel: 2
+  - name: "2"
+    command: echo ${ITEM}
+    foreach:
+      items: $OUT
+`
+	l := &Loader{}
+	ret, err := l.LoadData([]byte(dat))
+	require.NoError(t, err)
+
+	require.Equal(t, &Foreach{Items: []string{"a", "1"}, MaxParallel: 2}, ret.Steps[0].Foreach)
+	require.Equal(t, &Foreach{ItemsExpr: "$OUT"}, ret.Steps[1].Foreach)
+}
+
+func TestBuildingSubWorkflow(t *testing.T) {
+	dat := `name: test DAG
+steps:
+  - name: "1"
+    run: sub
+    params: "A B"
+  - name: "2"
+    run: sub
+    params:
+      NAME: foo bar
+      COUNT: 3
+`
+	l := &Loader{}
+	ret, err := l.LoadData([]byte(dat))
+	require.NoError(t, err)
+
+	require.Equal(t, &SubWorkflow{Name: "sub", Params: "A B"}, ret.Steps[0].SubWorkflow)
+	require.Equal(t, &SubWorkflow{Name: "sub", Params: `COUNT="3" NAME="foo bar"`}, ret.Steps[1].SubWorkflow)
+	require.Equal(t, ExecutorTypeSubWorkflow, ret.Steps[1].ExecutorConfig.Type)
+}
+
+func TestBuildingDotenv(t *testing.T) {
+	dat := `name: test DAG
+dotenv: .env
+steps:
+  - name: "1"
+    command: "true"
+    dotenv:
+      - .env.production
+      - file: .env.local
+        missing: ignore
+`
+	l := &Loader{}
+	ret, err := l.LoadData([]byte(dat))
+	require.NoError(t, err)
+
+	require.Equal(t, []Dotenv{{File: ".env"}}, ret.Dotenv)
+	require.Equal(t, []Dotenv{
+		{File: ".env.production"},
+		{File: ".env.local", Missing: DotenvMissingIgnore},
+	}, ret.Steps[0].Dotenv)
+}
+
+func TestBuildingAlertPolicy(t *testing.T) {
+	dat := `name: test DAG
+alertPolicy:
+  windowSec: 3600
+  notifyResolved: true
+steps:
+  - name: "1"
+    command: "true"
+`
+	l := &Loader{}
+	ret, err := l.LoadData([]byte(dat))
+	require.NoError(t, err)
+
+	require.Equal(t, &AlertPolicy{Window: time.Hour, NotifyResolved: true}, ret.AlertPolicy)
+}
+
+func TestBuildingDurations(t *testing.T) {
+	l := &Loader{}
+	ret, err := l.LoadData([]byte(`delay: 1m30s
+restartWait: 90
+maxCleanUpTime: 2m
+alertPolicy:
+  window: 1h
+steps:
+  - name: "1"
+    command: "true"
+    retryPolicy:
+      limit: 2
+      interval: 500ms
+    repeatPolicy:
+      repeat: true
+      intervalSec: "1h"
+`))
+	require.NoError(t, err)
+	require.Equal(t, 90*time.Second, ret.Delay)
+	require.Equal(t, 90*time.Second, ret.RestartWait)
+	require.Equal(t, 2*time.Minute, ret.MaxCleanUpTime)
+	require.Equal(t, time.Hour, ret.AlertPolicy.Window)
+	require.Equal(t, 500*time.Millisecond, ret.Steps[0].RetryPolicy.Interval)
+	require.Equal(t, time.Hour, ret.Steps[0].RepeatPolicy.Interval)
+}
+
+func TestBuildingToolVersions(t *testing.T) {
+	l := &Loader{}
+	ret, err := l.LoadData([]byte(`toolVersions: [python3, node]
+steps:
+  - name: "1"
+    command: "true"
+`))
+	require.NoError(t, err)
+	require.Equal(t, []ToolVersion{
+		{Name: "python3", Command: "python3 --version"},
+		{Name: "node", Command: "node --version"},
+	}, ret.ToolVersions)
+
+	ret, err = l.LoadData([]byte(`toolVersions:
+  terraform: terraform version
+  java: java -version
+steps:
+  - name: "1"
+    command: "true"
+`))
+	require.NoError(t, err)
+	require.Equal(t, []ToolVersion{
+		{Name: "java", Command: "java -version"},
+		{Name: "terraform", Command: "terraform version"},
+	}, ret.ToolVersions)
+
+}
+
 func TestConvertMap(t *testing.T) {
 	data := map[string]interface{}{
 		"key1": "value1",
@@ -554,3 +1018,539 @@ func TestConvertMapError(t *testing.T) {
 	err := convertMap(data)
 	require.Error(t, err)
 }
+
+func TestBuildingIf(t *testing.T) {
+	l := &Loader{}
+	ret, err := l.LoadData([]byte(`steps:
+  - name: "1"
+    command: "true"
+    if: '$ENV == "prod"'
+  - name: "2"
+    command: "true"
+    when: "$DRY_RUN"
+`))
+	require.NoError(t, err)
+	require.Equal(t, `$ENV == "prod"`, ret.Steps[0].If)
+	require.Equal(t, "$DRY_RUN", ret.Steps[1].If)
+}
+
+func TestBuildingInputs(t *testing.T) {
+	l := &Loader{}
+	ret, err := l.LoadData([]byte(`steps:
+  - name: "1"
+    command: "true"
+    inputs:
+      - dag://etl/latest/out/report.csv
+      - ref: dag://etl/latest/out/report.csv
+        path: data/latest.csv
+        maxAge: 24h
+      - artifact://dist/app.tar.gz
+    artifacts: [dist/*.tar.gz, coverage]
+`))
+	require.NoError(t, err)
+	require.Equal(t, []Input{
+		{Ref: "dag://etl/latest/out/report.csv", Path: "report.csv"},
+		{Ref: "dag://etl/latest/out/report.csv", Path: "data/latest.csv", MaxAge: 24 * time.Hour},
+		{Ref: "artifact://dist/app.tar.gz", Path: "app.tar.gz"},
+	}, ret.Steps[0].Inputs)
+	require.Equal(t, []string{"dist/*.tar.gz", "coverage"}, ret.Steps[0].Artifacts)
+
+	ref, err := ParseArtifactRef("dag://etl/8c2f5d24/out/./report.csv")
+	require.NoError(t, err)
+	require.Equal(t, ArtifactRef{DAG: "etl", Run: "8c2f5d24", Path: "out/report.csv"}, ref)
+
+	ref, err = ParseArtifactRef("artifact://dist/app.tar.gz")
+	require.NoError(t, err)
+	require.Equal(t, ArtifactRef{Path: "dist/app.tar.gz"}, ref)
+}
+
+func TestBuildingPlatforms(t *testing.T) {
+	l := &Loader{}
+	ret, err := l.LoadData([]byte(`steps:
+  - name: "1"
+    command: xdg-open report.html
+    platforms:
+      darwin:
+        command: open report.html
+      windows/amd64:
+        command: [cmd, /c, start, report.html]
+      linux/arm64:
+        script: echo arm
+`))
+	require.NoError(t, err)
+	s := ret.Steps[0]
+	require.Equal(t, map[string]PlatformCommand{
+		"darwin":        {CmdWithArgs: "open report.html", Command: "open", Args: []string{"report.html"}},
+		"windows/amd64": {Command: "cmd", Args: []string{"/c", "start", "report.html"}},
+		"linux/arm64":   {Script: "echo arm"},
+	}, s.Platforms)
+
+	darwin := s
+	require.NoError(t, darwin.SelectPlatform("darwin", "arm64"))
+	require.Equal(t, "open", darwin.Command)
+
+	arm := s
+	require.NoError(t, arm.SelectPlatform("linux", "arm64"))
+	require.Equal(t, "xdg-open", arm.Command)
+	require.Equal(t, "echo arm", arm.Script)
+
+	linux := s
+	require.NoError(t, linux.SelectPlatform("linux", "amd64"))
+	require.Equal(t, "xdg-open report.html", linux.CmdWithArgs)
+
+	// A step can have only platform commands.
+	ret, err = l.LoadData([]byte(`steps:
+  - name: "1"
+    platforms:
+      darwin:
+        command: open report.html
+`))
+	require.NoError(t, err)
+	require.ErrorIs(t, ret.Steps[0].SelectPlatform("linux", "amd64"), errNoPlatformCommand)
+}
+
+func TestBuildingCache(t *testing.T) {
+	l := &Loader{}
+	ret, err := l.LoadData([]byte(`steps:
+  - name: "1"
+    command: make build
+    cache: true
+  - name: "2"
+    command: make test
+    cache:
+      maxAge: 24h
+      key: go1.22
+  - name: "3"
+    command: make lint
+    cache: false
+`))
+	require.NoError(t, err)
+	require.Equal(t, &CachePolicy{}, ret.Steps[0].Cache)
+	require.Equal(t, &CachePolicy{MaxAge: 24 * time.Hour, Key: "go1.22"}, ret.Steps[1].Cache)
+	require.Nil(t, ret.Steps[2].Cache)
+}
+
+func TestBuildingGuard(t *testing.T) {
+	l := &Loader{}
+	ret, err := l.LoadData([]byte(`steps:
+  - name: "1"
+    command: bq query
+    guard:
+      http: https://billing.example.com/credits
+      headers:
+        Authorization: Bearer $TOKEN
+      path: credits.remaining
+      expr: $GUARD_VALUE > 100
+      onExceeded: skip
+      timeout: 10s
+  - name: "2"
+    command: bq query
+    guard:
+      command: psql -tAc "select sum(credits) from usage"
+      expr: $GUARD_VALUE < 1000
+`))
+	require.NoError(t, err)
+	require.Equal(t, &Guard{
+		HTTP:       "https://billing.example.com/credits",
+		Headers:    map[string]string{"Authorization": "Bearer $TOKEN"},
+		Path:       "credits.remaining",
+		Expr:       "$GUARD_VALUE > 100",
+		OnExceeded: GuardSkip,
+		Timeout:    10 * time.Second,
+	}, ret.Steps[0].Guard)
+	require.Equal(t, GuardFail, ret.Steps[1].Guard.OnExceeded)
+
+	for spec, want := range map[string]error{
+		"http: x\n      command: y\n      expr: a": errInvalidGuard,
+		"expr: a":                 errInvalidGuard,
+		"http: x":                 errInvalidGuard,
+		"http: x\n      expr: (a": errInvalidExpr,
+		"http: x\n      expr: a\n      onExceeded: wait": errGuardPolicy,
+		"http: x\n      expr: a\n      limit: 1":         errInvalidGuard,
+	} {
+		_, err := l.LoadData([]byte("steps:\n  - name: \"1\"\n    command: echo\n    guard:\n      " + spec + "\n"))
+		require.ErrorContains(t, err, want.Error(), spec)
+	}
+}
+
+func TestBuildingExpectedFiles(t *testing.T) {
+	l := &Loader{}
+	ret, err := l.LoadData([]byte(`steps:
+  - name: "1"
+    command: vendor-export
+    expectedFiles:
+      - out/report.csv
+      - path: out/*.parquet
+        minSize: 1.5KB
+        minCount: 3
+        fresh: true
+      - path: out/manifest.json
+        minSize: 10
+`))
+	require.NoError(t, err)
+	require.Equal(t, []ExpectedFile{
+		{Path: "out/report.csv", MinCount: 1},
+		{Path: "out/*.parquet", MinSize: 1536, MinCount: 3, Fresh: true},
+		{Path: "out/manifest.json", MinSize: 10, MinCount: 1},
+	}, ret.Steps[0].ExpectedFiles)
+
+	for spec, want := range map[string]error{
+		"out.csv":                   errExpectedFilesMustBeArray,
+		"[1]":                       errExpectedFileInvalid,
+		"[{minSize: 1}]":            errExpectedFilePathRequired,
+		"[{path: a, size: 1}]":      errExpectedFileInvalidKey,
+		"[{path: a, minSize: 1XB}]": errInvalidSize,
+		"[{path: a, minCount: 0}]":  errInvalidMinCount,
+		"[\"out/[\"]":               errExpectedFileInvalidPath,
+	} {
+		_, err := l.LoadData([]byte("steps:\n  - name: \"1\"\n    command: echo\n    expectedFiles: " + spec + "\n"))
+		require.ErrorContains(t, err, want.Error(), spec)
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	for v, want := range map[any]int64{
+		0: 0, 512: 512, "512": 512, "10KB": 10 << 10, "10k": 10 << 10,
+		"1.5 MB": 3 << 19, "2G": 2 << 30, "1TB": 1 << 40,
+	} {
+		got, err := parseSize(v)
+		require.NoError(t, err, v)
+		require.Equal(t, want, got, v)
+	}
+	for _, v := range []any{-1, "", "KB", "10 PB", 1.5, true} {
+		_, err := parseSize(v)
+		require.ErrorIs(t, err, errInvalidSize, v)
+	}
+}
+
+func TestBuildingPools(t *testing.T) {
+	l := &Loader{}
+	ret, err := l.LoadData([]byte("pool: warehouse\npriority: 10\nqueueTTL: 6h\nmailOn:\n  expired: true\nsteps:\n  - name: \"1\"\n    command: echo\n    pool: database\n    concurrencyKey: \"customer-${CUSTOMER_ID}\"\n"))
+	require.NoError(t, err)
+	require.Equal(t, "warehouse", ret.Pool)
+	require.Equal(t, 10, ret.Priority)
+	require.Equal(t, time.Hour*6, ret.QueueTTL)
+	require.True(t, ret.MailOn.Expired)
+	require.Equal(t, "database", ret.Steps[0].Pool)
+	// The key is expanded when the step starts.
+	require.Equal(t, "customer-${CUSTOMER_ID}", ret.Steps[0].ConcurrencyKey)
+
+	_, err = l.LoadData([]byte("queueTTL: soon\nsteps:\n  - name: \"1\"\n    command: echo\n"))
+	require.ErrorContains(t, err, "queueTTL")
+}
+
+func TestBuildingSLA(t *testing.T) {
+	l := &Loader{}
+	ret, err := l.LoadData([]byte("sla: 30m\nsteps:\n  - name: \"1\"\n    command: echo\n"))
+	require.NoError(t, err)
+	require.Equal(t, time.Minute*30, ret.SLA)
+
+	_, err = l.LoadData([]byte("sla: later\nsteps:\n  - name: \"1\"\n    command: echo\n"))
+	require.ErrorContains(t, err, "sla")
+}
+
+func TestBuildingLogFormat(t *testing.T) {
+	l := &Loader{}
+	ret, err := l.LoadData([]byte("logFormat: json\nsteps:\n  - name: \"1\"\n    command: echo\n"))
+	require.NoError(t, err)
+	require.Equal(t, LogFormatJSON, ret.LogFormat)
+
+	_, err = l.LoadData([]byte("logFormat: xml\nsteps:\n  - name: \"1\"\n    command: echo\n"))
+	require.ErrorContains(t, err, errInvalidLogFormat.Error())
+}
+
+func TestBuildingLogCompression(t *testing.T) {
+	l := &Loader{}
+	ret, err := l.LoadData([]byte(`logCompression: zstd
+logRotation:
+  maxBytes: 1048576
+steps:
+  - name: "1"
+    command: echo
+  - name: "2"
+    command: echo
+    logRotation:
+      maxBytes: 1024
+      maxFiles: 2
+`))
+	require.NoError(t, err)
+	require.Equal(t, "zstd", ret.LogCompression)
+	require.Equal(t, &LogRotation{MaxBytes: 1048576, MaxFiles: 5}, ret.LogRotation)
+	require.Nil(t, ret.Steps[0].LogRotation)
+	require.Equal(t, &LogRotation{MaxBytes: 1024, MaxFiles: 2}, ret.Steps[1].LogRotation)
+
+	_, err = l.LoadData([]byte("logCompression: bzip2\nsteps:\n  - name: \"1\"\n    command: echo\n"))
+	require.ErrorContains(t, err, errInvalidLogCompression.Error())
+
+	_, err = l.LoadData([]byte("steps:\n  - name: \"1\"\n    command: echo\n    logRotation:\n      maxBytes: -1\n"))
+	require.ErrorContains(t, err, errNegativeLogRotation.Error())
+}
+
+func TestBuildingTimeoutHandler(t *testing.T) {
+	l := &Loader{}
+	ret, err := l.LoadData([]byte(`handlerOn:
+  timeout:
+    command: echo timed out
+steps:
+  - name: "1"
+    command: echo
+`))
+	require.NoError(t, err)
+	require.NotNil(t, ret.HandlerOn.Timeout)
+	require.Equal(t, constants.OnTimeout, ret.HandlerOn.Timeout.Name)
+	require.Equal(t, "echo timed out", ret.HandlerOn.Timeout.CmdWithArgs)
+}
+
+func TestBuildingStages(t *testing.T) {
+	// restore the variable the DAG sets
+	t.Setenv("REGION", "")
+
+	build := func(input string) (*DAG, error) {
+		fl := &fileLoader{}
+		m, err := fl.unmarshalData([]byte(input))
+		require.NoError(t, err)
+		cdl := &configDefinitionLoader{}
+		def, err := cdl.decode(m)
+		require.NoError(t, err)
+		b := &DAGBuilder{}
+		return b.buildFromDefinition(def, nil)
+	}
+	ret, err := build(`env:
+  - REGION: us
+steps:
+  - name: setup
+    command: echo setup
+stages:
+  - name: extract
+    env:
+      - SOURCE: s3
+    steps:
+      - name: orders
+        command: echo orders
+        depends: [setup]
+      - name: users
+        command: echo users
+        depends: [setup]
+  - name: load
+    depends: [extract]
+    continueOn:
+      failure: true
+    steps:
+      - name: upload
+        command: echo upload
+      - name: notify
+        command: echo notify
+        depends: [upload]
+        continueOn:
+          skipped: true
+`)
+	require.NoError(t, err)
+	require.Len(t, ret.Steps, 5)
+
+	setup, orders, upload, notify := ret.Steps[0], ret.Steps[1], ret.Steps[3], ret.Steps[4]
+	require.Empty(t, setup.Stage)
+	require.Equal(t, "extract", orders.Stage)
+	require.Equal(t, []string{"setup"}, orders.Depends)
+	require.Contains(t, orders.Variables, "SOURCE=s3")
+	require.Contains(t, orders.Variables, "REGION=us")
+	require.NotContains(t, upload.Variables, "SOURCE=s3")
+
+	require.Equal(t, "load", upload.Stage)
+	require.Equal(t, []string{"orders", "users"}, upload.Depends)
+	require.Equal(t, []string{"upload", "orders", "users"}, notify.Depends)
+	require.True(t, upload.ContinueOn.Failure)
+	// The continueOn of a step replaces the one of its stage.
+	require.False(t, notify.ContinueOn.Failure)
+	require.True(t, notify.ContinueOn.Skipped)
+
+	for _, tt := range []struct {
+		input string
+		err   error
+	}{
+		{"stages:\n  - steps:\n      - name: a\n        command: echo\n", errStageNameRequired},
+		{"stages:\n  - name: a\n    steps: []\n  - name: a\n    steps: []\n", errDuplicateStage},
+		{"stages:\n  - name: a\n    depends: [b]\n    steps: []\n", errUnknownStage},
+		{"stages:\n  - name: a\n    depends: [a]\n    steps: []\n", errUnknownStage},
+	} {
+		_, err := build(tt.input)
+		require.ErrorContains(t, err, tt.err.Error())
+	}
+}
+
+func TestBuildingGroups(t *testing.T) {
+	build := func(input string) (*DAG, error) {
+		fl := &fileLoader{}
+		m, err := fl.unmarshalData([]byte(input))
+		require.NoError(t, err)
+		cdl := &configDefinitionLoader{}
+		def, err := cdl.decode(m)
+		require.NoError(t, err)
+		b := &DAGBuilder{}
+		return b.buildFromDefinition(def, nil)
+	}
+	ret, err := build(`steps:
+  - name: setup
+    command: echo setup
+  - name: orders
+    command: echo orders
+    group: " extract / db/"
+  - name: users
+    command: echo users
+    group: extract/api
+stages:
+  - name: load
+    steps:
+      - name: upload
+        command: echo upload
+      - name: notify
+        command: echo notify
+        group: report
+`)
+	require.NoError(t, err)
+	require.Empty(t, ret.Steps[0].Group)
+	require.Equal(t, "extract/db", ret.Steps[1].Group)
+	// The steps of a stage are grouped under the stage.
+	require.Equal(t, "load", ret.Steps[3].Group)
+	require.Equal(t, "load/report", ret.Steps[4].Group)
+
+	groups := StepGroups(ret.Steps)
+	require.Len(t, groups, 2)
+	require.Equal(t, "extract", groups[0].Path)
+	require.Empty(t, groups[0].Steps)
+	require.Len(t, groups[0].Groups, 2)
+	require.Equal(t, "db", groups[0].Groups[0].Name)
+	require.Equal(t, "extract/api", groups[0].Groups[1].Path)
+	require.Equal(t, []string{"users"}, groups[0].Groups[1].Steps)
+	require.Equal(t, []string{"upload"}, groups[1].Steps)
+	require.Equal(t, []string{"notify"}, groups[1].Groups[0].Steps)
+
+	_, err = build("steps:\n  - name: a\n    command: echo\n    group: a//b\n")
+	require.ErrorContains(t, err, errInvalidGroup.Error())
+}
+
+func TestBuildingRepeatUntil(t *testing.T) {
+	l := &Loader{}
+	ret, err := l.LoadData([]byte(`steps:
+  - name: "1"
+    command: "true"
+    repeatPolicy:
+      until: '$STATUS == "DONE"'
+      interval: 10s
+      limit: 5
+      backoff: 2
+      maxInterval: 30s
+`))
+	require.NoError(t, err)
+	p := ret.Steps[0].RepeatPolicy
+	require.Equal(t, RepeatPolicy{
+		Repeat:      true,
+		Interval:    10 * time.Second,
+		Until:       `$STATUS == "DONE"`,
+		Limit:       5,
+		Backoff:     2,
+		MaxInterval: 30 * time.Second,
+	}, p)
+	require.Equal(t, 10*time.Second, p.IntervalAt(1))
+	require.Equal(t, 20*time.Second, p.IntervalAt(2))
+	require.Equal(t, 30*time.Second, p.IntervalAt(3))
+	require.Equal(t, 30*time.Second, p.IntervalAt(10))
+}
+
+func TestBuildingStepTimeout(t *testing.T) {
+	l := &Loader{}
+	ret, err := l.LoadData([]byte(`steps:
+  - name: "1"
+    command: "true"
+    timeout: 1m
+    killGracePeriodSec: 30
+  - name: "2"
+    command: "true"
+    timeoutSec: 90
+  - name: "3"
+    command: "true"
+`))
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, ret.Steps[0].Timeout)
+	require.Equal(t, 30*time.Second, ret.Steps[0].KillGracePeriod)
+	require.Equal(t, 90*time.Second, ret.Steps[1].Timeout)
+	require.Equal(t, defaultKillGracePeriod, ret.Steps[1].KillGracePeriod)
+	require.Zero(t, ret.Steps[2].Timeout)
+	require.Zero(t, ret.Steps[2].KillGracePeriod)
+}
+
+func TestBuildingRetryBackoff(t *testing.T) {
+	l := &Loader{}
+	ret, err := l.LoadData([]byte(`steps:
+  - name: "1"
+    command: "true"
+    retryPolicy:
+      limit: 5
+      interval: 1s
+      backoff: exponential
+      maxInterval: 10s
+      jitter: 0.2
+      exitCodes: [75, 124]
+  - name: "2"
+    command: "true"
+    retryPolicy:
+      limit: 1
+      backoff: 1.5
+`))
+	require.NoError(t, err)
+	p := ret.Steps[0].RetryPolicy
+	require.Equal(t, &RetryPolicy{
+		Limit:       5,
+		Interval:    time.Second,
+		Backoff:     2,
+		MaxInterval: 10 * time.Second,
+		Jitter:      0.2,
+		ExitCodes:   []int{75, 124},
+	}, p)
+	require.Equal(t, 1.5, ret.Steps[1].RetryPolicy.Backoff)
+
+	for n, want := range map[int]time.Duration{1: time.Second, 3: 4 * time.Second, 10: 10 * time.Second} {
+		got := p.IntervalAt(n)
+		require.GreaterOrEqual(t, got, time.Duration(float64(want)*0.8), n)
+		require.LessOrEqual(t, got, time.Duration(float64(want)*1.2), n)
+	}
+
+	require.True(t, p.ShouldRetry(exec.Command("sh", "-c", "exit 75").Run()))
+	require.False(t, p.ShouldRetry(exec.Command("sh", "-c", "exit 1").Run()))
+	require.False(t, p.ShouldRetry(errors.New("no exit code")))
+	require.True(t, ret.Steps[1].RetryPolicy.ShouldRetry(errors.New("no exit code")))
+}
+
+func TestBuildingStdin(t *testing.T) {
+	l := &Loader{}
+	ret, err := l.LoadData([]byte(`steps:
+  - name: "1"
+    command: jq .name
+    stdin: '{"name": "$NAME"}'
+  - name: "2"
+    command: jq .name
+    stdin:
+      file: input.json
+  - name: "3"
+    command: psql
+    stdin:
+      param: QUERY
+  - name: "4"
+    command: jq .name
+    stdin:
+      output: RESPONSE
+`))
+	require.NoError(t, err)
+	require.Equal(t, &Stdin{Value: `{"name": "$NAME"}`}, ret.Steps[0].Stdin)
+	require.Equal(t, &Stdin{File: "input.json"}, ret.Steps[1].Stdin)
+	require.Equal(t, &Stdin{Param: "QUERY"}, ret.Steps[2].Stdin)
+	require.Equal(t, &Stdin{Output: "RESPONSE"}, ret.Steps[3].Stdin)
+
+	for _, spec := range []string{
+		"file: a\n      param: b",
+		"url: a",
+		"{}",
+	} {
+		_, err := l.LoadData([]byte("steps:\n  - name: \"1\"\n    command: cat\n    stdin:\n      " + spec + "\n"))
+		require.ErrorContains(t, err, errInvalidStdin.Error(), spec)
+	}
+}
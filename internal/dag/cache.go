@@ -0,0 +1,56 @@
+package dag
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+var errInvalidCache = errors.New("cache must be a boolean or a map")
+
+// CachePolicy makes a step reuse the result of a previous successful run
+// instead of running again when its cache key is the same. The key is made
+// of the command, the parameters and the inputs of the step.
+type CachePolicy struct {
+	// MaxAge is how long the result is reused. It is reused until the key
+	// changes if it is zero.
+	MaxAge time.Duration `json:"MaxAge,omitempty"`
+	// Key is added to the cache key, e.g. the version of a dependency
+	// that the command does not show.
+	Key string `json:"Key,omitempty"`
+}
+
+type cachePolicyDef struct {
+	MaxAge any
+	Key    string
+}
+
+func parseCachePolicy(def any) (*CachePolicy, error) {
+	switch v := def.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		if !v {
+			return nil, nil
+		}
+		return &CachePolicy{}, nil
+	case map[string]any, map[any]any:
+		var pd cachePolicyDef
+		md, _ := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			ErrorUnused: true,
+			Result:      &pd,
+		})
+		if err := md.Decode(v); err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidCache, err)
+		}
+		maxAge, err := ParseDuration(pd.MaxAge)
+		if err != nil {
+			return nil, fmt.Errorf("%w: maxAge: %s", errInvalidCache, err)
+		}
+		return &CachePolicy{MaxAge: maxAge, Key: pd.Key}, nil
+	default:
+		return nil, errInvalidCache
+	}
+}
@@ -18,9 +18,25 @@ type DAGFinder interface {
 	FindByName(name string) (*DAG, error)
 }
 
+// Notifier sends the notifications of the steps, e.g. the escalation of a
+// healthcheck step, the same way as the mails of the failures of the run.
+type Notifier interface {
+	Notify(to, subject, body string) error
+}
+
 type Context struct {
 	DAG    *DAG
 	Finder DAGFinder
+	// Notifier is nil if the steps cannot send notifications, e.g. in a
+	// dry run.
+	Notifier Notifier
+}
+
+// WithNotifier sets the notifier of the steps to the context of the DAG.
+func WithNotifier(ctx context.Context, n Notifier) context.Context {
+	c, _ := GetContext(ctx)
+	c.Notifier = n
+	return context.WithValue(ctx, ctxKey{}, c)
 }
 
 // ctxKey is used as the key for storing the DAG in the context.
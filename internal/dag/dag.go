@@ -2,6 +2,7 @@ package dag
 
 import (
 	"crypto/md5"
+	"errors"
 	"fmt"
 	"os"
 	"path"
@@ -12,20 +13,30 @@ import (
 	"github.com/robfig/cron/v3"
 )
 
+// Formats of the step logs. In the JSON format, each line is written as a
+// record with the time, the stream and the names of the DAG and the step.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
 // DAG represents a DAG configuration.
 type DAG struct {
-	Location          string
-	Group             string
-	Name              string
-	Schedule          []*Schedule
-	StopSchedule      []*Schedule
-	RestartSchedule   []*Schedule
-	Description       string
-	Env               []string
-	LogDir            string
+	Location        string
+	Group           string
+	Name            string
+	Schedule        []*Schedule
+	StopSchedule    []*Schedule
+	RestartSchedule []*Schedule
+	Description     string
+	Env             []string
+	LogDir          string
+	// LogFormat is the format of the step logs, text or json.
+	LogFormat         string
 	HandlerOn         HandlerOn
 	Steps             []Step
 	MailOn            *MailOn
+	AlertPolicy       *AlertPolicy
 	ErrorMail         *MailConfig
 	InfoMail          *MailConfig
 	Smtp              *SmtpConfig
@@ -34,27 +45,97 @@ type DAG struct {
 	HistRetentionDays int
 	Preconditions     []*Condition
 	MaxActiveRuns     int
-	Params            []string
-	DefaultParams     string
-	MaxCleanUpTime    time.Duration
-	Tags              []string
+	// Pool is the concurrency pool of the server configuration that the
+	// runs of the DAG take a slot of.
+	Pool string
+	// QueueTTL is how long a run waits for a slot of its pool before it is
+	// canceled with the expired status. Zero means no limit.
+	QueueTTL time.Duration
+	// SLA is how long a run is expected to take. The sla.missed event is
+	// sent to the webhooks when a run is still running after it. Zero
+	// means no SLA.
+	SLA time.Duration
+	// Priority is the priority of the runs in the queues of the pools. The
+	// runs with a higher priority get the free slots first.
+	Priority       int
+	Params         []string
+	DefaultParams  string
+	ParamDefs      []ParamDef
+	MaxCleanUpTime time.Duration
+	Tags           []string
+	Dotenv         []Dotenv
+	ToolVersions   []ToolVersion
+
+	// HistRetentionRuns is the number of the latest runs kept in the
+	// history, and HistRetentionBytes the total size of their log files.
+	// Zero keeps them all. The older runs are removed with their logs by
+	// the pruning of the history.
+	HistRetentionRuns  int
+	HistRetentionBytes int64
+
+	// LogCompression is the compression of the step logs, gzip or zstd,
+	// applied when the steps finish. LogRotation is the rotation of the
+	// logs of the steps that have none.
+	LogCompression string
+	LogRotation    *LogRotation
+
+	// Warnings are the problems found in the definition that do not
+	// prevent the DAG from running, e.g. deprecated fields.
+	Warnings []Warning
 }
 
 type Schedule struct {
 	Expression string
 	Parsed     cron.Schedule
+	// Interval is the time between the end of a run and the start of the
+	// next one for the schedules that start a run after the previous one
+	// finishes, e.g. every 2h. Parsed is not used for these schedules.
+	Interval time.Duration
+	// Params overrides the parameters of the runs triggered by the schedule.
+	Params string
+	// Steps limits the runs triggered by the schedule to the given steps.
+	Steps []string
 }
 
 type HandlerOn struct {
 	Failure *Step
 	Success *Step
 	Cancel  *Step
+	// Timeout runs instead of Failure when the run failed because a step
+	// timed out.
+	Timeout *Step
 	Exit    *Step
 }
 
 type MailOn struct {
 	Failure bool
 	Success bool
+	// Expired sends the error mail when a run expires in the queue.
+	Expired bool
+}
+
+// LogRotation rotates the log of a step before it grows larger than
+// MaxBytes, keeping the MaxFiles latest rotated files.
+type LogRotation struct {
+	MaxBytes int64
+	MaxFiles int
+}
+
+// ToolVersion is a tool whose version is recorded with each run of the DAG.
+type ToolVersion struct {
+	Name string
+	// Command prints the version of the tool.
+	Command string
+}
+
+// AlertPolicy collapses the failure notifications of the DAG. Failures
+// within Window after a notification are counted and reported in the next
+// notification instead of each sending its own.
+type AlertPolicy struct {
+	Window time.Duration
+	// NotifyResolved sends a notification when the DAG succeeds after
+	// failing.
+	NotifyResolved bool
 }
 
 func ReadFile(file string) (string, error) {
@@ -80,6 +161,41 @@ func (d *DAG) SockAddr() string {
 	return path.Join("/tmp", fmt.Sprintf("@dagu-%s-%x.sock", name, bs))
 }
 
+var errStepNotFound = errors.New("step not found")
+
+// SelectSteps returns the steps with the given names. Dependencies on steps
+// that are not selected are removed.
+func (d *DAG) SelectSteps(names []string) ([]Step, error) {
+	selected := map[string]bool{}
+	for _, name := range names {
+		selected[name] = true
+	}
+	var ret []Step
+	for _, step := range d.Steps {
+		if !selected[step.Name] {
+			continue
+		}
+		delete(selected, step.Name)
+		var depends []string
+		for _, dep := range step.Depends {
+			for _, name := range names {
+				if dep == name {
+					depends = append(depends, dep)
+					break
+				}
+			}
+		}
+		step.Depends = depends
+		ret = append(ret, step)
+	}
+	for _, name := range names {
+		if selected[name] {
+			return nil, fmt.Errorf("%w: %s", errStepNotFound, name)
+		}
+	}
+	return ret, nil
+}
+
 func (d *DAG) Clone() *DAG {
 	ret := *d
 	return &ret
@@ -108,9 +224,21 @@ func (d *DAG) setDefaults() {
 	if d.LogDir == "" {
 		d.LogDir = config.Get().LogDir
 	}
+	if d.HistRetentionDays == 0 {
+		d.HistRetentionDays = config.Get().HistRetentionDays
+	}
 	if d.HistRetentionDays == 0 {
 		d.HistRetentionDays = 30
 	}
+	if d.HistRetentionRuns == 0 {
+		d.HistRetentionRuns = config.Get().HistRetentionRuns
+	}
+	if d.HistRetentionBytes == 0 {
+		d.HistRetentionBytes = config.Get().HistRetentionBytes
+	}
+	if d.LogCompression == "" {
+		d.LogCompression = config.Get().LogCompression
+	}
 	if d.MaxCleanUpTime == 0 {
 		d.MaxCleanUpTime = time.Second * 60
 	}
@@ -123,6 +251,7 @@ func (d *DAG) setupHandlers() {
 		d.HandlerOn.Success,
 		d.HandlerOn.Failure,
 		d.HandlerOn.Cancel,
+		d.HandlerOn.Timeout,
 	} {
 		if handlerStep != nil {
 			handlerStep.setup(dir)
@@ -55,3 +55,21 @@ steps:
 	require.NoError(t, err)
 	require.Equal(t, input, ret)
 }
+
+func TestSelectSteps(t *testing.T) {
+	d := &DAG{Steps: []Step{
+		{Name: "1"},
+		{Name: "2", Depends: []string{"1"}},
+		{Name: "3", Depends: []string{"1", "2"}},
+	}}
+
+	steps, err := d.SelectSteps([]string{"2", "3"})
+	require.NoError(t, err)
+	require.Len(t, steps, 2)
+	require.Empty(t, steps[0].Depends)
+	require.Equal(t, []string{"2"}, steps[1].Depends)
+	require.Equal(t, []string{"1"}, d.Steps[1].Depends)
+
+	_, err = d.SelectSteps([]string{"4"})
+	require.Error(t, err)
+}
@@ -6,22 +6,61 @@ type configDefinition struct {
 	Description       string
 	Schedule          interface{}
 	LogDir            string
+	LogFormat         string
 	Env               interface{}
 	HandlerOn         handerOnDef
 	Functions         []*funcDef
 	Steps             []*stepDef
+	Stages            []*stageDef
 	Smtp              smtpConfigDef
 	MailOn            *mailOnDef
+	AlertPolicy       *alertPolicyDef
 	ErrorMail         mailConfigDef
 	InfoMail          mailConfigDef
-	DelaySec          int
-	RestartWaitSec    int
+	DelaySec          interface{}
+	Delay             interface{}
+	RestartWaitSec    interface{}
+	RestartWait       interface{}
 	HistRetentionDays *int
 	Preconditions     []*conditionDef
 	MaxActiveRuns     int
-	Params            string
-	MaxCleanUpTimeSec *int
+	Pool              string
+	Priority          int
+	QueueTTL          interface{}
+	Sla               interface{}
+	Params            interface{}
+	MaxCleanUpTimeSec interface{}
+	MaxCleanUpTime    interface{}
 	Tags              string
+	Dotenv            interface{}
+	ToolVersions      interface{}
+
+	// Strict rejects the fields whose names only match in a different
+	// case. It overrides the strictMode of the global configuration.
+	Strict *bool
+
+	// HistRetentionRuns and HistRetentionBytes limit the number of the
+	// runs in the history and the size of their log files.
+	HistRetentionRuns  int
+	HistRetentionBytes int64
+
+	// LogCompression compresses the step logs when the steps finish, and
+	// LogRotation rotates them when they grow too large.
+	LogCompression string
+	LogRotation    *logRotationDef
+
+	warnings []Warning
+}
+
+// stageDef groups steps. The depends, env and continueOn of the stage apply
+// to all of its steps.
+type stageDef struct {
+	Name        string
+	Description string
+	Depends     []string
+	Env         interface{}
+	ContinueOn  *continueOnDef
+	Steps       []*stepDef
 }
 
 type conditionDef struct {
@@ -33,6 +72,7 @@ type handerOnDef struct {
 	Failure *stepDef
 	Success *stepDef
 	Cancel  *stepDef
+	Timeout *stepDef
 	Exit    *stepDef
 }
 
@@ -52,11 +92,52 @@ type stepDef struct {
 	RepeatPolicy  *repeatPolicyDef
 	MailOnError   bool
 	Preconditions []*conditionDef
+	If            string
+	When          string // When is an alias of If
 	SignalOnStop  *string
 	Env           string
 	Call          *callFuncDef
 	Run           string // Run is a sub workflow to run
-	Params        string // Params is a string of parameters to pass to the sub workflow
+	Params        any    // Params is a string or a map of parameters to pass to the sub workflow
+	Foreach       *foreachDef
+	Generator     bool
+	Dotenv        interface{}
+	Inputs        interface{}
+	Artifacts     []string
+	ExpectedFiles any
+	Platforms     map[string]*platformDef
+	Cache         any
+	Guard         any
+	Pool          string
+	Group         string
+	// ConcurrencyKey is expanded when the step starts.
+	ConcurrencyKey string
+
+	// Timeout limits each run of the step. KillGracePeriod is the time
+	// between the stop signal and SIGKILL when it times out.
+	TimeoutSec         interface{}
+	Timeout            interface{}
+	KillGracePeriodSec interface{}
+	KillGracePeriod    interface{}
+
+	// LogRotation overrides the logRotation of the DAG for the step.
+	LogRotation *logRotationDef
+
+	// Stdin is a string or a map with one of value, file, param and
+	// output.
+	Stdin any
+}
+
+type logRotationDef struct {
+	MaxBytes int64
+	MaxFiles int
+}
+
+// platformDef is the command of a step on a platform, e.g. darwin or
+// linux/arm64.
+type platformDef struct {
+	Command any
+	Script  string
 }
 
 type funcDef struct {
@@ -77,12 +158,27 @@ type continueOnDef struct {
 
 type repeatPolicyDef struct {
 	Repeat      bool
-	IntervalSec int
+	IntervalSec interface{}
+	Interval    interface{}
+	Until       string
+	Limit       int
+	Backoff     interface{}
+	MaxInterval interface{}
+}
+
+type foreachDef struct {
+	Items       interface{}
+	MaxParallel int
 }
 
 type retryPolicyDef struct {
 	Limit       int
-	IntervalSec int
+	IntervalSec interface{}
+	Interval    interface{}
+	Backoff     interface{}
+	MaxInterval interface{}
+	Jitter      float64
+	ExitCodes   []int
 }
 
 type smtpConfigDef struct {
@@ -102,4 +198,11 @@ type mailConfigDef struct {
 type mailOnDef struct {
 	Failure bool
 	Success bool
+	Expired bool
+}
+
+type alertPolicyDef struct {
+	WindowSec      interface{}
+	Window         interface{}
+	NotifyResolved bool
 }
@@ -0,0 +1,133 @@
+package dag
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Policies for a dotenv file that does not exist.
+const (
+	DotenvMissingError  = "error"
+	DotenvMissingWarn   = "warn"
+	DotenvMissingIgnore = "ignore"
+)
+
+var errInvalidDotenvLine = errors.New("invalid line in dotenv file")
+
+// Dotenv is a file the environment variables are loaded from when the DAG or
+// the step runs.
+type Dotenv struct {
+	File string `json:"File"`
+	// Missing is the policy for a file that does not exist. The run fails
+	// if it is empty.
+	Missing string `json:"Missing,omitempty"`
+}
+
+// LoadDotenv loads the environment variables of the DAG from its dotenv
+// files and sets them to the process environment.
+func (d *DAG) LoadDotenv() error {
+	vars, err := LoadDotenv(d.Dotenv, filepath.Dir(d.Location))
+	if err != nil {
+		return err
+	}
+	for _, v := range vars {
+		key, value, _ := strings.Cut(v, "=")
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadDotenv reads the dotenv files and returns their variables as
+// KEY=VALUE pairs. Relative paths are resolved against dir. Values can refer
+// to the environment and to variables defined earlier in the files.
+func LoadDotenv(files []Dotenv, dir string) ([]string, error) {
+	var vars []string
+	loaded := map[string]string{}
+	lookup := func(key string) string {
+		if v, ok := loaded[key]; ok {
+			return v
+		}
+		return os.Getenv(key)
+	}
+	for _, f := range files {
+		file := os.Expand(f.File, lookup)
+		if !filepath.IsAbs(file) && dir != "" {
+			file = filepath.Join(dir, file)
+		}
+		b, err := os.ReadFile(file)
+		if errors.Is(err, fs.ErrNotExist) {
+			switch f.Missing {
+			case DotenvMissingIgnore:
+				continue
+			case DotenvMissingWarn:
+				log.Printf("dotenv file %s not found", file)
+				continue
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read dotenv file: %w", err)
+		}
+		pairs, err := parseDotenv(string(b), lookup)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		for _, p := range pairs {
+			loaded[p[0]] = p[1]
+			vars = append(vars, p[0]+"="+p[1])
+		}
+	}
+	return vars, nil
+}
+
+// parseDotenv parses the KEY=VALUE lines of a dotenv file. Values in single
+// quotes are taken literally. Other values are expanded, and values in double
+// quotes can contain escaped newlines.
+func parseDotenv(data string, lookup func(string) string) ([][2]string, error) {
+	var pairs [][2]string
+	vars := map[string]string{}
+	expand := func(s string) string {
+		return os.Expand(s, func(key string) string {
+			if v, ok := vars[key]; ok {
+				return v
+			}
+			return lookup(key)
+		})
+	}
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%w at line %d", errInvalidDotenvLine, n)
+		}
+		value = strings.TrimSpace(value)
+		switch {
+		case len(value) > 1 && value[0] == '\'' && value[len(value)-1] == '\'':
+			value = value[1 : len(value)-1]
+		case len(value) > 1 && value[0] == '"' && value[len(value)-1] == '"':
+			value = strings.NewReplacer(`\n`, "\n", `\"`, `"`, `\\`, `\`).Replace(value[1 : len(value)-1])
+			value = expand(value)
+		default:
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = strings.TrimSpace(value[:i])
+			}
+			value = expand(value)
+		}
+		vars[key] = value
+		pairs = append(pairs, [2]string{key, value})
+	}
+	return pairs, scanner.Err()
+}
@@ -0,0 +1,51 @@
+package dag
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDotenv(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.Setenv("DOTENV_TEST_HOME", "/home/test")
+	err := os.WriteFile(filepath.Join(dir, ".env"), []byte(`# comment
+A=1
+export B="line1\nline2"
+C='${A} literal'
+D=${A}-${DOTENV_TEST_HOME} # trailing comment
+`), 0600)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, ".env.local"), []byte("A=2\nE=${A}${B}\n"), 0600)
+	require.NoError(t, err)
+
+	vars, err := LoadDotenv([]Dotenv{{File: ".env"}, {File: ".env.local"}}, dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"A=1",
+		"B=line1\nline2",
+		"C=${A} literal",
+		"D=1-/home/test",
+		"A=2",
+		"E=2line1\nline2",
+	}, vars)
+}
+
+func TestLoadDotenvMissing(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := LoadDotenv([]Dotenv{{File: ".env"}}, dir)
+	require.Error(t, err)
+
+	for _, policy := range []string{DotenvMissingWarn, DotenvMissingIgnore} {
+		vars, err := LoadDotenv([]Dotenv{{File: ".env", Missing: policy}}, dir)
+		require.NoError(t, err)
+		require.Empty(t, vars)
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("INVALID\n"), 0600))
+	_, err = LoadDotenv([]Dotenv{{File: ".env"}}, dir)
+	require.ErrorIs(t, err, errInvalidDotenvLine)
+}
@@ -0,0 +1,74 @@
+package dag
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errInvalidDuration  = errors.New("invalid duration")
+	errNegativeDuration = errors.New("duration must not be negative")
+	errDurationConflict = errors.New("duration must not be specified in both fields")
+)
+
+// ParseDuration parses a duration given as a string such as "1h30m" or
+// "45s", or as a number of seconds. A string of digits is also a number of
+// seconds. Nil is a zero duration.
+func ParseDuration(v any) (time.Duration, error) {
+	var d time.Duration
+	switch v := v.(type) {
+	case nil:
+		return 0, nil
+	case int:
+		d = time.Duration(v) * time.Second
+	case int64:
+		d = time.Duration(v) * time.Second
+	case uint64:
+		d = time.Duration(v) * time.Second
+	case float64:
+		d = time.Duration(v * float64(time.Second))
+	case string:
+		s := strings.TrimSpace(v)
+		if s == "" {
+			return 0, nil
+		}
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			d = time.Duration(n * float64(time.Second))
+			break
+		}
+		var err error
+		if d, err = time.ParseDuration(s); err != nil {
+			return 0, fmt.Errorf("%w: %q", errInvalidDuration, v)
+		}
+	default:
+		return 0, fmt.Errorf("%w: %v", errInvalidDuration, v)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("%w: %v", errNegativeDuration, v)
+	}
+	return d, nil
+}
+
+// parseDurationField parses a duration field that also has a legacy field
+// in seconds, e.g. delay and delaySec. Both accept the formats of
+// ParseDuration. It reports whether any of them is set.
+func parseDurationField(name string, v, legacy any) (time.Duration, bool, error) {
+	if v != nil && legacy != nil {
+		return 0, false, fmt.Errorf("%w: %s and %sSec", errDurationConflict, name, name)
+	}
+	field := name
+	if v == nil {
+		v, field = legacy, name+"Sec"
+	}
+	if v == nil {
+		return 0, false, nil
+	}
+	d, err := ParseDuration(v)
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", field, err)
+	}
+	return d, true, nil
+}
@@ -0,0 +1,61 @@
+package dag
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDuration(t *testing.T) {
+	for _, tc := range []struct {
+		input any
+		want  time.Duration
+	}{
+		{nil, 0},
+		{"", 0},
+		{30, 30 * time.Second},
+		{int64(5), 5 * time.Second},
+		{1.5, 1500 * time.Millisecond},
+		{"45", 45 * time.Second},
+		{"45s", 45 * time.Second},
+		{"1h30m", 90 * time.Minute},
+		{" 500ms ", 500 * time.Millisecond},
+	} {
+		d, err := ParseDuration(tc.input)
+		require.NoError(t, err, tc.input)
+		require.Equal(t, tc.want, d, tc.input)
+	}
+
+	for _, input := range []any{"1 hour", "abc", true, []any{1}} {
+		_, err := ParseDuration(input)
+		require.ErrorIs(t, err, errInvalidDuration, input)
+	}
+	for _, input := range []any{-1, "-5s"} {
+		_, err := ParseDuration(input)
+		require.ErrorIs(t, err, errNegativeDuration, input)
+	}
+}
+
+func TestParseDurationField(t *testing.T) {
+	d, ok, err := parseDurationField("delay", "1m", nil)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, time.Minute, d)
+
+	d, ok, err = parseDurationField("delay", nil, 10)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 10*time.Second, d)
+
+	_, ok, err = parseDurationField("delay", nil, nil)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, _, err = parseDurationField("delay", "1m", 10)
+	require.ErrorIs(t, err, errDurationConflict)
+
+	_, _, err = parseDurationField("delay", nil, "soon")
+	require.ErrorIs(t, err, errInvalidDuration)
+	require.Contains(t, err.Error(), "delaySec")
+}
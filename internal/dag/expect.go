@@ -0,0 +1,139 @@
+package dag
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	expectPath     = "path"
+	expectMinSize  = "minSize"
+	expectMinCount = "minCount"
+	expectFresh    = "fresh"
+)
+
+var (
+	errExpectedFilesMustBeArray = errors.New("expectedFiles must be an array")
+	errExpectedFileInvalid      = errors.New("expected file must be a string or a map")
+	errExpectedFileInvalidKey   = errors.New("expected file has invalid key")
+	errExpectedFilePathRequired = errors.New("expected file path must be specified")
+	errExpectedFileInvalidPath  = errors.New("invalid expected file pattern")
+	errInvalidSize              = errors.New("invalid size")
+	errInvalidMinCount          = errors.New("expected file minCount must be a positive integer")
+)
+
+// ExpectedFile is a postcondition of a step: the files that must exist once
+// its command exits 0, e.g. the outputs of a vendor tool that can exit 0
+// without doing anything. The step fails if they do not.
+type ExpectedFile struct {
+	// Path is the file, or a glob pattern of the files, relative to the
+	// directory of the step. It is expanded with the variables of the run.
+	Path string `json:"Path"`
+	// MinSize is the size in bytes each file must have at least.
+	MinSize int64 `json:"MinSize,omitempty"`
+	// MinCount is how many files the pattern must match, 1 by default.
+	MinCount int `json:"MinCount,omitempty"`
+	// Fresh requires the files to be modified while the step ran, so that
+	// the outputs of a previous run do not pass.
+	Fresh bool `json:"Fresh,omitempty"`
+}
+
+func parseExpectedFiles(def any) ([]ExpectedFile, error) {
+	if def == nil {
+		return nil, nil
+	}
+	entries, ok := def.([]any)
+	if !ok {
+		return nil, errExpectedFilesMustBeArray
+	}
+	var files []ExpectedFile
+	for _, entry := range entries {
+		f, err := parseExpectedFile(entry)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+func parseExpectedFile(entry any) (ExpectedFile, error) {
+	f := ExpectedFile{MinCount: 1}
+	switch val := entry.(type) {
+	case string:
+		f.Path = val
+	case map[string]any:
+		m := make(map[any]any, len(val))
+		for k, v := range val {
+			m[k] = v
+		}
+		return parseExpectedFile(m)
+	case map[any]any:
+		for k, v := range val {
+			var err error
+			switch k {
+			case expectPath:
+				f.Path = fmt.Sprint(v)
+			case expectMinSize:
+				f.MinSize, err = parseSize(v)
+			case expectMinCount:
+				n, ok := v.(int)
+				if !ok || n < 1 {
+					err = fmt.Errorf("%w: %v", errInvalidMinCount, v)
+				}
+				f.MinCount = n
+			case expectFresh:
+				b, ok := v.(bool)
+				if !ok {
+					err = fmt.Errorf("%w: %v: %v", errExpectedFileInvalidKey, k, v)
+				}
+				f.Fresh = b
+			default:
+				err = fmt.Errorf("%w: %v", errExpectedFileInvalidKey, k)
+			}
+			if err != nil {
+				return f, err
+			}
+		}
+	default:
+		return f, errExpectedFileInvalid
+	}
+	if f.Path == "" {
+		return f, errExpectedFilePathRequired
+	}
+	if _, err := filepath.Match(f.Path, ""); err != nil {
+		return f, fmt.Errorf("%w: %s: %s", errExpectedFileInvalidPath, f.Path, err)
+	}
+	return f, nil
+}
+
+var sizeUnits = map[string]int64{"": 1, "K": 1 << 10, "M": 1 << 20, "G": 1 << 30, "T": 1 << 40}
+
+var reSize = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([KMGT]?B?)$`)
+
+// parseSize parses a size in bytes, e.g. 1024, or with a unit of 1024
+// bytes, e.g. 10KB, 1.5MB or 2G.
+func parseSize(v any) (int64, error) {
+	switch val := v.(type) {
+	case int:
+		if val < 0 {
+			return 0, fmt.Errorf("%w: %d", errInvalidSize, val)
+		}
+		return int64(val), nil
+	case string:
+		m := reSize.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(val)))
+		if m == nil {
+			return 0, fmt.Errorf("%w: %s", errInvalidSize, val)
+		}
+		n, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s", errInvalidSize, val)
+		}
+		return int64(n * float64(sizeUnits[strings.TrimSuffix(m[2], "B")])), nil
+	}
+	return 0, fmt.Errorf("%w: %v", errInvalidSize, v)
+}
@@ -0,0 +1,351 @@
+package dag
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dagu-dev/dagu/internal/utils"
+)
+
+var (
+	errInvalidExpr = errors.New("invalid expression")
+	errEvalExpr    = errors.New("failed to evaluate expression")
+)
+
+// EvalExpr evaluates the expression of the if field of a step.
+//
+// An expression combines comparisons with &&, || and !, and parentheses.
+// The comparison operators are ==, !=, <, <=, >, >=, =~ and !~ (regular
+// expression match). Operands are words, "double quoted" or 'single quoted'
+// strings. Variables such as $ENV or ${1} and command substitutions are
+// evaluated, except in single quotes. An operand without an operator is true
+// unless it is empty, "0" or "false". Sides that are both numbers are
+// compared as numbers.
+func EvalExpr(expr string) (bool, error) {
+	return EvalExprWith(expr, nil)
+}
+
+// EvalExprWith evaluates the expression like EvalExpr. The variables are
+// expanded from vars before the environment. Their values are substituted
+// literally, after the command substitutions, so that a value, e.g. the
+// response of a guard, is not evaluated.
+func EvalExprWith(expr string, vars map[string]string) (bool, error) {
+	e, err := parseExpr(expr)
+	if err != nil {
+		return false, err
+	}
+	ok, err := e.eval(vars)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s: %v", errEvalExpr, expr, err)
+	}
+	return ok, nil
+}
+
+type exprNode interface {
+	// eval evaluates the node with the variables substituted literally.
+	eval(vars map[string]string) (bool, error)
+}
+
+type exprOperand struct {
+	value string
+	// literal is true for single-quoted strings, which are not evaluated.
+	literal bool
+}
+
+// varMark delimits the variables of vars while the commands are
+// substituted, since it is not in the operands of the expressions.
+const varMark = "\x00"
+
+func (o exprOperand) expand(vars map[string]string) (string, error) {
+	if o.literal {
+		return o.value, nil
+	}
+	v, err := utils.ParseCommand(os.Expand(o.value, func(key string) string {
+		if _, ok := vars[key]; ok {
+			return varMark + key + varMark
+		}
+		return os.Getenv(key)
+	}))
+	if err != nil {
+		return "", err
+	}
+	for key, value := range vars {
+		v = strings.ReplaceAll(v, varMark+key+varMark, value)
+	}
+	return v, nil
+}
+
+func (o exprOperand) eval(vars map[string]string) (bool, error) {
+	v, err := o.expand(vars)
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "", "0", "false":
+		return false, nil
+	}
+	return true, nil
+}
+
+type exprCompare struct {
+	op          string
+	left, right exprOperand
+}
+
+func (c exprCompare) eval(vars map[string]string) (bool, error) {
+	l, err := c.left.expand(vars)
+	if err != nil {
+		return false, err
+	}
+	r, err := c.right.expand(vars)
+	if err != nil {
+		return false, err
+	}
+	switch c.op {
+	case "==":
+		return l == r, nil
+	case "!=":
+		return l != r, nil
+	case "=~", "!~":
+		re, err := regexp.Compile(r)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(l) == (c.op == "=~"), nil
+	}
+	var cmp int
+	lf, lerr := strconv.ParseFloat(strings.TrimSpace(l), 64)
+	rf, rerr := strconv.ParseFloat(strings.TrimSpace(r), 64)
+	switch {
+	case lerr == nil && rerr == nil && lf < rf:
+		cmp = -1
+	case lerr == nil && rerr == nil && lf > rf:
+		cmp = 1
+	case lerr == nil && rerr == nil:
+		cmp = 0
+	default:
+		cmp = strings.Compare(l, r)
+	}
+	switch c.op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	default:
+		return cmp >= 0, nil
+	}
+}
+
+type exprNot struct {
+	x exprNode
+}
+
+func (n exprNot) eval(vars map[string]string) (bool, error) {
+	ok, err := n.x.eval(vars)
+	return !ok, err
+}
+
+type exprLogical struct {
+	and         bool
+	left, right exprNode
+}
+
+func (l exprLogical) eval(vars map[string]string) (bool, error) {
+	ok, err := l.left.eval(vars)
+	if err != nil {
+		return false, err
+	}
+	// The right side is not evaluated if the left side decides the result,
+	// so that its commands do not run.
+	if ok != l.and {
+		return ok, nil
+	}
+	return l.right.eval(vars)
+}
+
+type exprToken struct {
+	// op is the operator or parenthesis. It is empty for an operand.
+	op      string
+	operand exprOperand
+}
+
+var exprOperators = []string{"&&", "||", "==", "!=", "=~", "!~", "<=", ">=", "<", ">", "!", "(", ")"}
+
+func tokenizeExpr(s string) ([]exprToken, error) {
+	var tokens []exprToken
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c == ' ' || c == '\t' || c == '\n' {
+			i++
+			continue
+		}
+		if op := exprOperatorAt(s, i); op != "" {
+			tokens = append(tokens, exprToken{op: op})
+			i += len(op)
+			continue
+		}
+		switch c {
+		case '"', '\'', '`':
+			j := i + 1
+			var b strings.Builder
+			for ; j < len(s) && s[j] != c; j++ {
+				if c == '"' && s[j] == '\\' && j+1 < len(s) {
+					j++
+				}
+				b.WriteByte(s[j])
+			}
+			if j == len(s) {
+				return nil, fmt.Errorf("%w: unterminated string in %s", errInvalidExpr, s)
+			}
+			value := b.String()
+			if c == '`' {
+				value = "`" + value + "`"
+			}
+			tokens = append(tokens, exprToken{operand: exprOperand{value: value, literal: c == '\''}})
+			i = j + 1
+		default:
+			j := i
+			for j < len(s) && !strings.ContainsRune(" \t\n\"'`", rune(s[j])) && exprOperatorAt(s, j) == "" {
+				j++
+			}
+			tokens = append(tokens, exprToken{operand: exprOperand{value: s[i:j]}})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+func exprOperatorAt(s string, i int) string {
+	for _, op := range exprOperators {
+		if strings.HasPrefix(s[i:], op) {
+			return op
+		}
+	}
+	return ""
+}
+
+type exprParser struct {
+	expr   string
+	tokens []exprToken
+	pos    int
+}
+
+func parseExpr(s string) (exprNode, error) {
+	tokens, err := tokenizeExpr(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty expression", errInvalidExpr)
+	}
+	p := &exprParser{expr: s, tokens: tokens}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, p.unexpected()
+	}
+	return e, nil
+}
+
+func (p *exprParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos].op
+	}
+	return ""
+}
+
+func (p *exprParser) unexpected() error {
+	if p.pos >= len(p.tokens) {
+		return fmt.Errorf("%w: unexpected end of %s", errInvalidExpr, p.expr)
+	}
+	t := p.tokens[p.pos]
+	if t.op == "" {
+		return fmt.Errorf("%w: unexpected %q in %s", errInvalidExpr, t.operand.value, p.expr)
+	}
+	return fmt.Errorf("%w: unexpected %q in %s", errInvalidExpr, t.op, p.expr)
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "||" {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = exprLogical{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "&&" {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = exprLogical{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	switch p.peek() {
+	case "!":
+		p.pos++
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return exprNot{x: x}, nil
+	case "(":
+		p.pos++
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, p.unexpected()
+		}
+		p.pos++
+		return x, nil
+	}
+	left, ok := p.operand()
+	if !ok {
+		return nil, p.unexpected()
+	}
+	switch op := p.peek(); op {
+	case "==", "!=", "=~", "!~", "<", "<=", ">", ">=":
+		p.pos++
+		right, ok := p.operand()
+		if !ok {
+			return nil, p.unexpected()
+		}
+		return exprCompare{op: op, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *exprParser) operand() (exprOperand, bool) {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].op != "" {
+		return exprOperand{}, false
+	}
+	p.pos++
+	return p.tokens[p.pos-1].operand, true
+}
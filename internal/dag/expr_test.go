@@ -0,0 +1,77 @@
+package dag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvalExpr(t *testing.T) {
+	t.Setenv("EXPR_ENV", "prod")
+	t.Setenv("EXPR_COUNT", "10")
+	t.Setenv("EXPR_EMPTY", "")
+
+	for expr, want := range map[string]bool{
+		`$EXPR_ENV == "prod"`:                    true,
+		`${EXPR_ENV} != prod`:                    false,
+		`'$EXPR_ENV' == "$EXPR_ENV"`:             false,
+		`$EXPR_COUNT > 9`:                        true,
+		`$EXPR_COUNT < 9`:                        false,
+		`$EXPR_COUNT >= 10 && $EXPR_COUNT <= 10`: true,
+		`"b" > "a"`:                              true,
+		`$EXPR_ENV =~ "^pr"`:                     true,
+		`$EXPR_ENV !~ "^pr"`:                     false,
+		`$EXPR_EMPTY`:                            false,
+		`!$EXPR_EMPTY`:                           true,
+		`false || (true && !0)`:                  true,
+		`"say \"hi\"" == 'say "hi"'`:             true,
+		"`echo prod` == $EXPR_ENV":               true,
+		`prod == $EXPR_ENV && FALSE`:             false,
+	} {
+		got, err := EvalExpr(expr)
+		require.NoError(t, err, expr)
+		require.Equal(t, want, got, expr)
+	}
+}
+
+func TestEvalExprErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"a ==",
+		"(a == b",
+		"a == b)",
+		"a b",
+		`"a`,
+		"&& a",
+		"a == == b",
+	} {
+		_, err := EvalExpr(expr)
+		require.ErrorIs(t, err, errInvalidExpr, expr)
+	}
+
+	// The right side is not evaluated if the left side decides the result.
+	_, err := EvalExpr("true || `false`")
+	require.NoError(t, err)
+	_, err = EvalExpr("`false`")
+	require.ErrorIs(t, err, errEvalExpr)
+	_, err = EvalExpr(`a =~ "("`)
+	require.ErrorIs(t, err, errEvalExpr)
+}
+
+func TestEvalExprWith(t *testing.T) {
+	t.Setenv("EXPR_LIMIT", "100")
+	ok, err := EvalExprWith("$VALUE < $EXPR_LIMIT", map[string]string{"VALUE": "40"})
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = EvalExprWith("$VALUE < $EXPR_LIMIT", map[string]string{"VALUE": "400"})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// The values of the variables are substituted literally.
+	ok, err = EvalExprWith(`"x $VALUE" == 'x `+"`echo 1`"+`'`, map[string]string{"VALUE": "`echo 1`"})
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = EvalExprWith("$VALUE == `echo 1`", map[string]string{"VALUE": "1"})
+	require.NoError(t, err)
+	require.True(t, ok)
+}
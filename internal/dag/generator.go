@@ -0,0 +1,57 @@
+package dag
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+var (
+	errGeneratorOutputMustBeArray  = errors.New("generator output must be a JSON array")
+	errGeneratorOutputMixedEntries = errors.New("generator output must be either a list of steps or a list of items")
+)
+
+// BuildGeneratedSteps builds the steps from the output of a generator step.
+// The output must be a JSON array of either step definitions or items. A
+// list of items returns no steps; it is meant to be consumed by a foreach
+// step through the generator's output variable.
+func BuildGeneratedSteps(variables []string, output string) ([]Step, error) {
+	var entries []any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &entries); err != nil {
+		return nil, fmt.Errorf("%w: %s", errGeneratorOutputMustBeArray, err)
+	}
+
+	var defs []map[string]any
+	for _, e := range entries {
+		if m, ok := e.(map[string]any); ok {
+			defs = append(defs, m)
+		}
+	}
+	if len(defs) == 0 {
+		return nil, nil
+	}
+	if len(defs) != len(entries) {
+		return nil, errGeneratorOutputMixedEntries
+	}
+
+	var steps []Step
+	for _, m := range defs {
+		def := &stepDef{}
+		md, _ := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			ErrorUnused: true,
+			Result:      def,
+		})
+		if err := md.Decode(m); err != nil {
+			return nil, err
+		}
+		step, err := buildStep(variables, def, nil, BuildDAGOptions{})
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, *step)
+	}
+	return steps, nil
+}
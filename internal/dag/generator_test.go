@@ -0,0 +1,38 @@
+package dag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildGeneratedSteps(t *testing.T) {
+	steps, err := BuildGeneratedSteps([]string{"FOO=BAR"}, `[
+  {"name": "partition 1", "command": "process.sh 1", "retryPolicy": {"limit": 2}},
+  {"name": "partition 2", "command": "process.sh 2", "depends": ["partition 1"]}
+]`)
+	require.NoError(t, err)
+	require.Len(t, steps, 2)
+
+	require.Equal(t, "partition 1", steps[0].Name)
+	require.Equal(t, "process.sh", steps[0].Command)
+	require.Equal(t, []string{"1"}, steps[0].Args)
+	require.Equal(t, 2, steps[0].RetryPolicy.Limit)
+	require.Equal(t, []string{"FOO=BAR"}, steps[0].Variables)
+	require.Equal(t, []string{"partition 1"}, steps[1].Depends)
+
+	steps, err = BuildGeneratedSteps(nil, `["a", "b"]`)
+	require.NoError(t, err)
+	require.Empty(t, steps)
+
+	for _, output := range []string{
+		"a b",
+		`{"name": "step"}`,
+		`[{"name": "step", "command": "echo 1"}, "a"]`,
+		`[{"name": "step", "command": "echo 1", "unknown": true}]`,
+		`[{"command": "echo 1"}]`,
+	} {
+		_, err := BuildGeneratedSteps(nil, output)
+		require.Error(t, err, output)
+	}
+}
@@ -0,0 +1,67 @@
+package dag
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var errInvalidGroup = errors.New("invalid group")
+
+// StepGroup is a group of the steps of a DAG in the graph. The groups of
+// the steps are nested by their paths. The web UI draws a group around its
+// steps and can collapse it into a single node.
+type StepGroup struct {
+	Name string
+	// Path is the names of the group and its parents separated by a slash.
+	Path string
+	// Steps are the names of the steps directly in the group.
+	Steps  []string
+	Groups []*StepGroup
+}
+
+// parseGroup trims the slashes and the spaces around the names of the
+// group path.
+func parseGroup(group string) (string, error) {
+	if strings.TrimSpace(group) == "" {
+		return "", nil
+	}
+	names := strings.Split(strings.Trim(group, "/"), "/")
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+		if names[i] == "" {
+			return "", fmt.Errorf("%w: %s", errInvalidGroup, group)
+		}
+	}
+	return strings.Join(names, "/"), nil
+}
+
+// StepGroups returns the tree of the groups of the steps, in the order of
+// their first steps.
+func StepGroups(steps []Step) []*StepGroup {
+	root := &StepGroup{}
+	byPath := map[string]*StepGroup{"": root}
+	var groupOf func(path string) *StepGroup
+	groupOf = func(path string) *StepGroup {
+		if g, ok := byPath[path]; ok {
+			return g
+		}
+		parent, name := "", path
+		if i := strings.LastIndex(path, "/"); i >= 0 {
+			parent, name = path[:i], path[i+1:]
+		}
+		g := &StepGroup{Name: name, Path: path}
+		p := groupOf(parent)
+		p.Groups = append(p.Groups, g)
+		byPath[path] = g
+		return g
+	}
+	for _, s := range steps {
+		if s.Group == "" {
+			continue
+		}
+		g := groupOf(s.Group)
+		g.Steps = append(g.Steps, s.Name)
+	}
+	return root.Groups
+}
@@ -0,0 +1,208 @@
+package dag
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// Policies of a guard whose expression is false.
+const (
+	GuardSkip = "skip"
+	GuardFail = "fail"
+)
+
+// GuardVariable is the variable the expression of a guard refers to the
+// value of the sensor with.
+const GuardVariable = "GUARD_VALUE"
+
+// defaultGuardTimeout limits the request or the command of a guard.
+const defaultGuardTimeout = time.Second * 30
+
+var (
+	errInvalidGuard      = errors.New("invalid guard")
+	errGuardSensor       = errors.New("guard must have one of http and command")
+	errGuardExprRequired = errors.New("guard expr must be specified")
+	errGuardPolicy       = errors.New("guard onExceeded must be skip or fail")
+	errGuardCheck        = errors.New("failed to check guard")
+	errGuardPath         = errors.New("path not found")
+)
+
+// Guard is checked before a step starts, e.g. to stop launching queries
+// when the daily budget of a warehouse is exhausted. The value of the
+// sensor, the response of the HTTP request or the output of the command, is
+// set to GUARD_VALUE and the step runs only if the expression is true.
+// Otherwise it is skipped or fails according to OnExceeded.
+type Guard struct {
+	// HTTP is the URL the value is read from with a GET request.
+	HTTP    string            `json:"HTTP,omitempty"`
+	Headers map[string]string `json:"Headers,omitempty"`
+	// Command is run with sh to read the value, e.g. with the client of a
+	// database.
+	Command string `json:"Command,omitempty"`
+	// Path is the path of the value in the JSON response, e.g.
+	// credits.remaining or items.0.used.
+	Path       string        `json:"Path,omitempty"`
+	Expr       string        `json:"Expr"`
+	OnExceeded string        `json:"OnExceeded"`
+	Timeout    time.Duration `json:"Timeout,omitempty"`
+}
+
+type guardDef struct {
+	HTTP       string
+	Headers    map[string]string
+	Command    string
+	Path       string
+	Expr       string
+	OnExceeded string
+	Timeout    any
+}
+
+func parseGuard(def any) (*Guard, error) {
+	if def == nil {
+		return nil, nil
+	}
+	var gd guardDef
+	md, _ := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		ErrorUnused: true,
+		Result:      &gd,
+	})
+	if err := md.Decode(def); err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidGuard, err)
+	}
+	if (gd.HTTP == "") == (gd.Command == "") {
+		return nil, fmt.Errorf("%w: %s", errInvalidGuard, errGuardSensor)
+	}
+	if gd.Expr == "" {
+		return nil, fmt.Errorf("%w: %s", errInvalidGuard, errGuardExprRequired)
+	}
+	if _, err := parseExpr(gd.Expr); err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidGuard, err)
+	}
+	g := &Guard{
+		HTTP:       gd.HTTP,
+		Headers:    gd.Headers,
+		Command:    gd.Command,
+		Path:       gd.Path,
+		Expr:       gd.Expr,
+		OnExceeded: gd.OnExceeded,
+	}
+	switch g.OnExceeded {
+	case "":
+		g.OnExceeded = GuardFail
+	case GuardSkip, GuardFail:
+	default:
+		return nil, fmt.Errorf("%w: %s", errGuardPolicy, g.OnExceeded)
+	}
+	timeout, err := ParseDuration(gd.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("%w: timeout: %s", errInvalidGuard, err)
+	}
+	g.Timeout = timeout
+	return g, nil
+}
+
+// Check reads the value of the sensor and evaluates the expression. It
+// returns the value and whether the step can run.
+func (g *Guard) Check(ctx context.Context) (string, bool, error) {
+	timeout := g.Timeout
+	if timeout == 0 {
+		timeout = defaultGuardTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var (
+		out []byte
+		err error
+	)
+	if g.HTTP != "" {
+		out, err = g.get(ctx)
+	} else {
+		out, err = exec.CommandContext(ctx, "sh", "-c", g.Command).Output()
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("%w: %s", errGuardCheck, err)
+	}
+	value := strings.TrimSpace(string(out))
+	if g.Path != "" {
+		if value, err = jsonPath(out, g.Path); err != nil {
+			return "", false, fmt.Errorf("%w: %s", errGuardCheck, err)
+		}
+	}
+	ok, err := EvalExprWith(g.Expr, map[string]string{GuardVariable: value})
+	if err != nil {
+		return value, false, fmt.Errorf("%w: %s", errGuardCheck, err)
+	}
+	return value, ok, nil
+}
+
+func (g *Guard) get(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, os.ExpandEnv(g.HTTP), nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range g.Headers {
+		req.Header.Set(k, os.ExpandEnv(v))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// jsonPath returns the value at the dot-separated path of the JSON, e.g.
+// credits.remaining. The numbers are array indexes.
+func jsonPath(data []byte, path string) (string, error) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return "", err
+	}
+	for _, key := range strings.Split(path, ".") {
+		switch c := v.(type) {
+		case map[string]any:
+			item, ok := c[key]
+			if !ok {
+				return "", fmt.Errorf("%w: %s", errGuardPath, path)
+			}
+			v = item
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(c) {
+				return "", fmt.Errorf("%w: %s", errGuardPath, path)
+			}
+			v = c[i]
+		default:
+			return "", fmt.Errorf("%w: %s", errGuardPath, path)
+		}
+	}
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case nil:
+		return "", nil
+	}
+	b, err := json.Marshal(v)
+	return string(b), err
+}
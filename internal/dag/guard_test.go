@@ -0,0 +1,36 @@
+package dag
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuardValueNotEvaluated(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "executed")
+	response := fmt.Sprintf("`touch %s`", marker)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"quota": %q}`, response)
+	}))
+	defer srv.Close()
+
+	// The command substitution of the response is compared as is, and it
+	// is not run.
+	g := &Guard{HTTP: srv.URL, Path: "quota", Expr: "$GUARD_VALUE == 'ok'"}
+	value, ok, err := g.Check(context.Background())
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, response, value)
+	g.Expr = "$GUARD_VALUE"
+	_, ok, err = g.Check(context.Background())
+	require.NoError(t, err)
+	require.True(t, ok)
+	_, err = os.Stat(marker)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
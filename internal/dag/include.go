@@ -0,0 +1,203 @@
+package dag
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+)
+
+var (
+	errInvalidInclude = dagerrors.New(dagerrors.CodeInvalidDAG, "invalid include")
+	errIncludeCycle   = errors.New("include cycle")
+	errIncludePath    = errors.New("must be a file name or a list of file names")
+)
+
+// appendedFields are the lists that are appended to the ones of the
+// included files instead of replacing them.
+var appendedFields = map[string]bool{
+	"env":           true,
+	"functions":     true,
+	"preconditions": true,
+	"stages":        true,
+	"steps":         true,
+}
+
+// resolveIncludes merges the files of extends and include into the
+// definition, and the step templates of the extends of the steps into the
+// steps. The paths are relative to the directory of the file.
+//
+// The included files are merged in order and the definition is merged over
+// them: the lists of appendedFields are appended, the maps such as
+// handlerOn are merged by key, and the other fields are replaced.
+func (fl *fileLoader) resolveIncludes(file string, cm map[string]any) (map[string]any, error) {
+	inc := &fileLoader{stack: slices.Clip(fl.stack)}
+	if file != "" {
+		if abs, err := filepath.Abs(file); err == nil {
+			file = abs
+		}
+		inc.stack = append(inc.stack, file)
+	}
+	if err := inc.extendSteps(file, cm); err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, key := range []string{"extends", "include"} {
+		v, ok := cm[key]
+		if !ok {
+			continue
+		}
+		delete(cm, key)
+		paths, err := includePaths(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %s %s", errInvalidInclude, file, key, err)
+		}
+		files = append(files, paths...)
+	}
+	if len(files) == 0 {
+		return cm, nil
+	}
+
+	base := map[string]any{}
+	for _, f := range files {
+		included, err := inc.readInclude(file, f)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %s: %s", errInvalidInclude, file, f, err)
+		}
+		base = mergeFields(base, included)
+	}
+	return mergeFields(base, cm), nil
+}
+
+// extendSteps merges the step templates of the extends of the steps and the
+// handlers into them.
+func (fl *fileLoader) extendSteps(file string, cm map[string]any) error {
+	steps, _ := cm["steps"].([]any)
+	for i, s := range steps {
+		step, err := fl.extendStep(file, s)
+		if err != nil {
+			return fmt.Errorf("%w: %s: steps[%d].extends: %s", errInvalidInclude, file, i, err)
+		}
+		steps[i] = step
+	}
+	handlers, _ := cm["handlerOn"].(map[any]any)
+	for k, s := range handlers {
+		step, err := fl.extendStep(file, s)
+		if err != nil {
+			return fmt.Errorf("%w: %s: handlerOn.%v.extends: %s", errInvalidInclude, file, k, err)
+		}
+		handlers[k] = step
+	}
+	return nil
+}
+
+func (fl *fileLoader) extendStep(file string, s any) (any, error) {
+	step, ok := s.(map[any]any)
+	if !ok {
+		return s, nil
+	}
+	v, ok := step["extends"]
+	if !ok {
+		return s, nil
+	}
+	f, ok := v.(string)
+	if !ok {
+		return nil, errIncludePath
+	}
+	delete(step, "extends")
+	tmpl, err := fl.readInclude(file, f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", f, err)
+	}
+	base := make(map[any]any, len(tmpl))
+	for k, v := range tmpl {
+		base[k] = v
+	}
+	return mergeFields(base, step), nil
+}
+
+// readInclude reads the included file f of the file.
+func (fl *fileLoader) readInclude(file, f string) (map[string]any, error) {
+	if !filepath.IsAbs(f) {
+		f = filepath.Join(filepath.Dir(file), f)
+	}
+	f, err := filepath.Abs(f)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(fl.stack, f) {
+		return nil, fmt.Errorf("%w: %s", errIncludeCycle, strings.Join(append(slices.Clip(fl.stack), f), " -> "))
+	}
+	return fl.readFile(f)
+}
+
+func includePaths(v any) ([]string, error) {
+	switch v := v.(type) {
+	case string:
+		return []string{v}, nil
+	case []any:
+		var paths []string
+		for _, p := range v {
+			s, ok := p.(string)
+			if !ok {
+				return nil, errIncludePath
+			}
+			paths = append(paths, s)
+		}
+		return paths, nil
+	}
+	return nil, errIncludePath
+}
+
+func mergeFields[K comparable](base, def map[K]any) map[K]any {
+	merged := make(map[K]any, len(base)+len(def))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range def {
+		name, _ := any(k).(string)
+		merged[k] = mergeField(name, merged[k], v)
+	}
+	return merged
+}
+
+func mergeField(name string, base, v any) any {
+	if base == nil {
+		return v
+	}
+	if v == nil {
+		return base
+	}
+	if appendedFields[name] {
+		return append(append([]any{}, toList(base)...), toList(v)...)
+	}
+	b, ok := base.(map[any]any)
+	if !ok {
+		return v
+	}
+	m, ok := v.(map[any]any)
+	if !ok {
+		return v
+	}
+	merged := make(map[any]any, len(b)+len(m))
+	for k, v := range b {
+		merged[k] = v
+	}
+	for k, v := range m {
+		merged[k] = v
+	}
+	return merged
+}
+
+// toList returns the list, or the value as a list, e.g. env in the form of
+// a map.
+func toList(v any) []any {
+	if l, ok := v.([]any); ok {
+		return l
+	}
+	return []any{v}
+}
@@ -0,0 +1,173 @@
+package dag
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	artifactRefPrefix = "dag://"
+	// runArtifactRefPrefix refers to the artifacts of the current run.
+	runArtifactRefPrefix = "artifact://"
+	// ArtifactRunLatest refers to the latest successful run of a DAG.
+	ArtifactRunLatest = "latest"
+
+	inputRef    = "ref"
+	inputPath   = "path"
+	inputMaxAge = "maxAge"
+)
+
+var (
+	errInvalidArtifactRef       = errors.New("invalid artifact reference")
+	errInputsMustBeArray        = errors.New("inputs must be an array")
+	errInputHasInvalidKey       = errors.New("input has invalid key")
+	errInputRefRequired         = errors.New("input ref must be specified")
+	errInputValueMustBeString   = errors.New("input value must be a string")
+	errInputMustBeStringOrMap   = errors.New("input must be a string or a map")
+	errInputMaxAgeWithoutLatest = errors.New("input maxAge is only allowed for the latest run")
+	errInvalidArtifactPattern   = errors.New("invalid artifact pattern")
+)
+
+// Input is an artifact of the current run or of a run of another DAG that
+// is fetched before the step runs.
+type Input struct {
+	Ref string `json:"Ref"`
+	// Path is where the artifact is copied to. A relative path is resolved
+	// against the directory of the step.
+	Path string `json:"Path"`
+	// MaxAge is how recently the run must have finished. Any successful
+	// run is used if it is zero.
+	MaxAge time.Duration `json:"MaxAge,omitempty"`
+}
+
+// ArtifactRef is a reference to an artifact of a run of a DAG in the form
+// dag://<name>/<request ID or latest>/<path>, or to an artifact saved by a
+// previous step of the current run in the form artifact://<path>. DAG and
+// Run are empty for the current run.
+type ArtifactRef struct {
+	DAG  string
+	Run  string
+	Path string
+}
+
+// ParseArtifactRef parses a reference to an artifact.
+func ParseArtifactRef(value string) (ArtifactRef, error) {
+	var ref ArtifactRef
+	if rest, ok := strings.CutPrefix(value, runArtifactRefPrefix); ok {
+		ref.Path = rest
+	} else {
+		rest, ok := strings.CutPrefix(value, artifactRefPrefix)
+		if !ok {
+			return ArtifactRef{}, fmt.Errorf("%w: %s", errInvalidArtifactRef, value)
+		}
+		parts := strings.SplitN(rest, "/", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+			return ArtifactRef{}, fmt.Errorf("%w: %s", errInvalidArtifactRef, value)
+		}
+		ref = ArtifactRef{DAG: parts[0], Run: parts[1], Path: parts[2]}
+	}
+	if !isRelativePath(ref.Path) {
+		return ArtifactRef{}, fmt.Errorf("%w: %s", errInvalidArtifactRef, value)
+	}
+	ref.Path = path.Clean(ref.Path)
+	return ref, nil
+}
+
+// isRelativePath reports whether p is a path within the directory it is
+// relative to.
+func isRelativePath(p string) bool {
+	p = path.Clean(p)
+	return p != "." && p != ".." && !strings.HasPrefix(p, "../") && !path.IsAbs(p)
+}
+
+// parseArtifacts validates the patterns of the artifacts of a step. They
+// are matched with filepath.Glob relative to the directory of the step.
+func parseArtifacts(patterns []string) ([]string, error) {
+	for _, p := range patterns {
+		if !isRelativePath(filepath.ToSlash(p)) {
+			return nil, fmt.Errorf("%w: %s", errInvalidArtifactPattern, p)
+		}
+		if _, err := filepath.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("%w: %s: %s", errInvalidArtifactPattern, p, err)
+		}
+	}
+	return patterns, nil
+}
+
+func parseInputs(def any) ([]Input, error) {
+	if def == nil {
+		return nil, nil
+	}
+	entries, ok := def.([]any)
+	if !ok {
+		return nil, errInputsMustBeArray
+	}
+	var inputs []Input
+	for _, entry := range entries {
+		in, err := parseInputEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, in)
+	}
+	return inputs, nil
+}
+
+func parseInputEntry(entry any) (Input, error) {
+	var in Input
+	var maxAge any
+	switch val := entry.(type) {
+	case string:
+		in.Ref = val
+	case map[string]any:
+		m := make(map[any]any, len(val))
+		for k, v := range val {
+			m[k] = v
+		}
+		return parseInputEntry(m)
+	case map[any]any:
+		for k, v := range val {
+			if k == inputMaxAge {
+				maxAge = v
+				continue
+			}
+			s, ok := v.(string)
+			if !ok {
+				return in, fmt.Errorf("%w: %v", errInputValueMustBeString, k)
+			}
+			switch k {
+			case inputRef:
+				in.Ref = s
+			case inputPath:
+				in.Path = s
+			default:
+				return in, fmt.Errorf("%w: %v", errInputHasInvalidKey, k)
+			}
+		}
+	default:
+		return in, errInputMustBeStringOrMap
+	}
+	if in.Ref == "" {
+		return in, errInputRefRequired
+	}
+	ref, err := ParseArtifactRef(in.Ref)
+	if err != nil {
+		return in, err
+	}
+	if in.Path == "" {
+		in.Path = path.Base(ref.Path)
+	}
+	if maxAge != nil {
+		if in.MaxAge, err = ParseDuration(maxAge); err != nil {
+			return in, err
+		}
+		if ref.Run != ArtifactRunLatest {
+			return in, fmt.Errorf("%w: %s", errInputMaxAgeWithoutLatest, in.Ref)
+		}
+	}
+	return in, nil
+}
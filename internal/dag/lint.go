@@ -0,0 +1,465 @@
+package dag
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/mitchellh/mapstructure"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// The rules of Lint.
+const (
+	RuleUnreachableStep     = "unreachable-step"
+	RuleNetworkRetry        = "network-retry"
+	RuleStepDescription     = "step-description"
+	RuleOverlappingSchedule = "overlapping-schedule"
+	RulePlaintextSecret     = "plaintext-secret"
+)
+
+// LintRule is a check of Lint.
+type LintRule struct {
+	Name        string
+	Description string
+	// Default is true if the rule is enabled unless it is disabled.
+	Default bool
+	check   func(l *linter)
+}
+
+// LintRules are the rules of Lint.
+var LintRules = []LintRule{
+	{
+		Name:        RuleUnreachableStep,
+		Description: "steps that never run, since their preconditions or if can never be met",
+		Default:     true,
+		check:       (*linter).checkUnreachable,
+	},
+	{
+		Name:        RuleNetworkRetry,
+		Description: "http, ssh and mail steps without a retry policy",
+		Default:     true,
+		check:       (*linter).checkNetworkRetry,
+	},
+	{
+		Name:        RuleStepDescription,
+		Description: "steps without a description",
+		check:       (*linter).checkDescription,
+	},
+	{
+		Name:        RuleOverlappingSchedule,
+		Description: "schedules that start or stop the DAG at the same time",
+		Default:     true,
+		check:       (*linter).checkSchedules,
+	},
+	{
+		Name:        RulePlaintextSecret,
+		Description: "passwords, tokens and keys written in the file instead of secret references",
+		Default:     true,
+		check:       (*linter).checkSecrets,
+	},
+}
+
+var errUnknownLintRule = dagerrors.New(dagerrors.CodeInvalidArgument, "unknown lint rule")
+
+// networkExecutors are the executors whose steps fail when the network or
+// the server they connect to is down for a moment.
+var networkExecutors = []string{"http", "ssh", "mail"}
+
+var (
+	// reSecretName matches the names of the fields and the variables of
+	// secrets, e.g. DB_PASSWORD or apiKey.
+	reSecretName = regexp.MustCompile(`(?i)(password|passwd|secret|token|api_?key|private_?key|access_?key|credential)`)
+	// reAssignment matches the assignments of variables in a string, e.g. in
+	// the params or a command.
+	reAssignment = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_]*)=("[^"]*"|'[^']*'|\S+)`)
+	reBearer     = regexp.MustCompile(`(?i)\bbearer\s+([^\s"']+)`)
+	// reSecretFile matches the names of the fields of the files and the
+	// names of secrets, e.g. passwordFile, which are not secrets themselves.
+	reSecretFile = regexp.MustCompile(`(?i)(file|path|name)$`)
+	// reReference matches the references resolved when the DAG runs: the
+	// variables, the command substitutions and the secret references, e.g.
+	// secret://vault/app#password. The secret package is not used for it,
+	// since it imports this one.
+	reReference = regexp.MustCompile("\\$|`|^[a-z][a-z0-9+.-]*://")
+	// scheduleWindow is the time in which the schedules are checked for
+	// overlaps.
+	scheduleWindow = 366 * 24 * time.Hour
+)
+
+// SelectLintRules returns the rules enabled by default, the enabled ones
+// and not the disabled ones. "all" enables or disables all the rules.
+func SelectLintRules(enable, disable []string) ([]LintRule, error) {
+	on := make(map[string]bool)
+	for _, r := range LintRules {
+		on[r.Name] = r.Default
+	}
+	set := func(names []string, v bool) error {
+		for _, name := range names {
+			if name == "all" {
+				for k := range on {
+					on[k] = v
+				}
+				continue
+			}
+			if _, ok := on[name]; !ok {
+				return fmt.Errorf("%w: %s", errUnknownLintRule, name)
+			}
+			on[name] = v
+		}
+		return nil
+	}
+	if err := set(enable, true); err != nil {
+		return nil, err
+	}
+	if err := set(disable, false); err != nil {
+		return nil, err
+	}
+	var rules []LintRule
+	for _, r := range LintRules {
+		if on[r.Name] {
+			rules = append(rules, r)
+		}
+	}
+	return rules, nil
+}
+
+// Lint checks the DAG in the file for the problems that Validate does not
+// report, since the DAG is valid but probably not what the author meant,
+// with the rules. The findings are warnings with the names of their rules.
+// The problems of Validate are reported too, and the DAG is not linted if
+// there are errors in them.
+func Lint(file string, rules []LintRule) ([]Problem, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return LintData(data, file, rules), nil
+}
+
+// LintData checks the DAG in the data as Lint does.
+func LintData(data []byte, file string, rules []LintRule) []Problem {
+	problems := ValidateData(data, file)
+	for _, p := range problems {
+		if p.Level == LevelError {
+			return problems
+		}
+	}
+	l := &linter{validator: &validator{file: file, lines: map[string]int{}}, paths: map[string]string{}}
+	if err := l.load(data); err != nil {
+		l.add("", err.Error())
+		return append(problems, l.problems...)
+	}
+	for _, r := range rules {
+		l.rule = r.Name
+		r.check(l)
+	}
+	problems = append(problems, l.problems...)
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Line < problems[j].Line
+	})
+	return problems
+}
+
+type linter struct {
+	*validator
+	raw  map[string]any
+	dag  *DAG
+	rule string
+	// paths are the paths of the steps in the file by their names.
+	paths map[string]string
+}
+
+// load builds the DAG as Validate does, without evaluating the variables,
+// so that the values are checked as they are written.
+func (l *linter) load(data []byte) error {
+	var node yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	l.indexLines("", &node)
+
+	fl := &fileLoader{}
+	raw, err := fl.unmarshalData(data)
+	if err != nil {
+		return err
+	}
+	if raw, err = fl.resolveIncludes(l.file, raw); err != nil {
+		return err
+	}
+	def := &configDefinition{}
+	md, _ := mapstructure.NewDecoder(&mapstructure.DecoderConfig{Result: def})
+	if err := md.Decode(raw); err != nil {
+		return err
+	}
+	b := &DAGBuilder{options: BuildDAGOptions{skipEnvEval: true, skipEnvSetup: true}}
+	if l.dag, err = b.buildFromDefinition(def, nil); err != nil {
+		return err
+	}
+	l.raw = raw
+
+	addPaths := func(path string, items any) {
+		list, _ := items.([]any)
+		for i, item := range list {
+			step, _ := rawMap(item)
+			if name, ok := step["name"].(string); ok {
+				l.paths[name] = fmt.Sprintf("%s[%d]", path, i)
+			}
+		}
+	}
+	addPaths("steps", raw["steps"])
+	stages, _ := raw["stages"].([]any)
+	for i, item := range stages {
+		stage, _ := rawMap(item)
+		addPaths(fmt.Sprintf("stages[%d].steps", i), stage["steps"])
+	}
+	return nil
+}
+
+func (l *linter) report(field, format string, args ...any) {
+	l.problems = append(l.problems, Problem{
+		File: l.file, Line: l.line(field), Field: field, Level: LevelWarning,
+		Message: fmt.Sprintf(format, args...), Rule: l.rule,
+	})
+}
+
+// steps returns the steps and the handlers of the DAG with their paths.
+func (l *linter) steps() ([]Step, []string) {
+	var steps []Step
+	var paths []string
+	for _, s := range l.dag.Steps {
+		steps = append(steps, s)
+		paths = append(paths, l.paths[s.Name])
+	}
+	h := l.dag.HandlerOn
+	for _, hs := range []struct {
+		key  string
+		step *Step
+	}{{"exit", h.Exit}, {"success", h.Success}, {"failure", h.Failure}, {"cancel", h.Cancel}, {"timeout", h.Timeout}} {
+		if hs.step != nil {
+			steps = append(steps, *hs.step)
+			paths = append(paths, "handlerOn."+hs.key)
+		}
+	}
+	return steps, paths
+}
+
+// checkUnreachable reports the steps that are always skipped: the steps whose
+// if is always false or whose preconditions are never met, and the steps that
+// depend on them. Only the conditions without variables and commands are
+// evaluated, since the others are known only when the DAG runs.
+func (l *linter) checkUnreachable() {
+	if i := unmetCondition(l.dag.Preconditions); i >= 0 {
+		l.report(fmt.Sprintf("preconditions[%d]", i), "the precondition is never met, so the DAG never runs")
+		return
+	}
+
+	// The steps that are not taken as branches are skipped, and so are the
+	// steps that depend only on them. The steps that depend on a skipped step
+	// are skipped unless it continues on skipped.
+	const (
+		reachable = iota + 1
+		skipped
+		notTaken
+	)
+	steps := make(map[string]*Step, len(l.dag.Steps))
+	for i := range l.dag.Steps {
+		steps[l.dag.Steps[i].Name] = &l.dag.Steps[i]
+	}
+	state := make(map[string]int)
+	reason := make(map[string]string)
+	var visit func(name string) int
+	visit = func(name string) int {
+		if s, ok := state[name]; ok {
+			return s
+		}
+		// A cycle is reported by Validate.
+		state[name] = reachable
+		s := steps[name]
+		switch {
+		case s.If != "" && isConstant(s.If) && !evalConstant(s.If):
+			state[name], reason[name] = notTaken, fmt.Sprintf("if %q is always false", s.If)
+			return notTaken
+		case unmetCondition(s.Preconditions) >= 0:
+			state[name], reason[name] = skipped, "the precondition is never met"
+			return skipped
+		}
+		taken, branches := 0, 0
+		for _, dep := range s.Depends {
+			if _, ok := steps[dep]; !ok {
+				continue
+			}
+			switch visit(dep) {
+			case skipped, notTaken:
+				if steps[dep].ContinueOn.Skipped {
+					taken++
+					continue
+				}
+				if state[dep] == notTaken {
+					branches++
+					continue
+				}
+				state[name], reason[name] = skipped, fmt.Sprintf("it depends on %s, which never runs", dep)
+				return skipped
+			default:
+				taken++
+			}
+		}
+		if branches > 0 && taken == 0 {
+			state[name], reason[name] = notTaken, "none of the branches it depends on is taken"
+			return notTaken
+		}
+		return reachable
+	}
+	for _, s := range l.dag.Steps {
+		if visit(s.Name) != reachable {
+			l.report(l.paths[s.Name], "step %s never runs: %s", s.Name, reason[s.Name])
+		}
+	}
+}
+
+// unmetCondition returns the index of the first condition that is never met,
+// or -1.
+func unmetCondition(conds []*Condition) int {
+	for i, c := range conds {
+		if isConstant(c.Condition) && c.Condition != c.Expected {
+			return i
+		}
+	}
+	return -1
+}
+
+func isConstant(s string) bool {
+	return !strings.ContainsAny(s, "$`") && !strings.Contains(s, "{{")
+}
+
+func evalConstant(expr string) bool {
+	ok, err := EvalExprWith(expr, nil)
+	// An invalid expression fails the step when it runs.
+	return ok || err != nil
+}
+
+func (l *linter) checkNetworkRetry() {
+	steps, paths := l.steps()
+	for i, s := range steps {
+		if !slices.Contains(networkExecutors, s.ExecutorConfig.Type) {
+			continue
+		}
+		if s.RetryPolicy == nil || s.RetryPolicy.Limit == 0 {
+			l.report(paths[i], "step %s uses the %s executor without a retryPolicy", s.Name, s.ExecutorConfig.Type)
+		}
+	}
+}
+
+func (l *linter) checkDescription() {
+	for _, s := range l.dag.Steps {
+		if strings.TrimSpace(s.Description) == "" {
+			l.report(l.paths[s.Name], "step %s has no description", s.Name)
+		}
+	}
+}
+
+// checkSchedules reports the schedules that start the same run at the same
+// time in the next year, which runs the DAG twice or skips a run, and the
+// schedules that stop the DAG when it starts. The start schedules with
+// different params or steps start different runs.
+func (l *linter) checkSchedules() {
+	from := time.Now().Truncate(time.Minute)
+	report := func(a, b *Schedule, what string) {
+		if t, ok := firstOverlap(a.Parsed, b.Parsed, from); ok {
+			l.report("schedule", "schedules %q and %q %s at the same time, e.g. %s",
+				a.Expression, b.Expression, what, t.Format("2006-01-02 15:04"))
+		}
+	}
+	starts := slices.DeleteFunc(slices.Clone(l.dag.Schedule), func(s *Schedule) bool { return s.Parsed == nil })
+	for i, a := range starts {
+		for _, b := range starts[i+1:] {
+			if a.Params == b.Params && slices.Equal(a.Steps, b.Steps) {
+				report(a, b, "start the DAG")
+			}
+		}
+	}
+	for _, a := range starts {
+		for _, b := range l.dag.StopSchedule {
+			if b.Parsed != nil {
+				report(a, b, "start and stop the DAG")
+			}
+		}
+	}
+}
+
+// firstOverlap returns the first time in the schedule window after from at
+// which both schedules fire.
+func firstOverlap(a, b interface{ Next(time.Time) time.Time }, from time.Time) (time.Time, bool) {
+	end := from.Add(scheduleWindow)
+	ta, tb := a.Next(from), b.Next(from)
+	for !ta.IsZero() && !tb.IsZero() && ta.Before(end) && tb.Before(end) {
+		switch {
+		case ta.Equal(tb):
+			return ta, true
+		case ta.Before(tb):
+			ta = a.Next(tb.Add(-time.Second))
+		default:
+			tb = b.Next(ta.Add(-time.Second))
+		}
+	}
+	return time.Time{}, false
+}
+
+// checkSecrets reports the values of the fields and the variables named as
+// secrets, and the bearer tokens, that are written in the file instead of
+// being read from the environment or a secret reference.
+func (l *linter) checkSecrets() {
+	var walk func(path string, v any)
+	walk = func(path string, v any) {
+		switch v := v.(type) {
+		case string:
+			for _, m := range reAssignment.FindAllStringSubmatch(v, -1) {
+				if isSecretName(m[1]) && isPlaintext(strings.Trim(m[2], `"'`)) {
+					l.report(path, "%s is a secret in plaintext; use a secret reference or a variable", m[1])
+				}
+			}
+			for _, m := range reBearer.FindAllStringSubmatch(v, -1) {
+				if isPlaintext(m[1]) {
+					l.report(path, "the bearer token is a secret in plaintext; use a secret reference or a variable")
+				}
+			}
+		case []any:
+			for i, item := range v {
+				walk(fmt.Sprintf("%s[%d]", path, i), item)
+			}
+		default:
+			m, ok := rawMap(v)
+			if !ok {
+				return
+			}
+			keys := make([]string, 0, len(m))
+			for k := range m {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				p := joinPath(path, k)
+				if s, ok := m[k].(string); ok && isSecretName(k) && isPlaintext(s) {
+					l.report(p, "%s is a secret in plaintext; use a secret reference or a variable", k)
+					continue
+				}
+				walk(p, m[k])
+			}
+		}
+	}
+	walk("", l.raw)
+}
+
+func isSecretName(name string) bool {
+	return reSecretName.MatchString(name) && !reSecretFile.MatchString(name)
+}
+
+func isPlaintext(v string) bool {
+	return v != "" && !reReference.MatchString(v)
+}
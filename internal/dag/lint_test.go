@@ -0,0 +1,102 @@
+package dag
+
+import (
+	"fmt"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	rules, err := SelectLintRules([]string{"all"}, nil)
+	require.NoError(t, err)
+	file := path.Join(testdataDir, "lint/issues.yaml")
+	problems, err := Lint(file, rules)
+	require.NoError(t, err)
+
+	var actual []string
+	for _, p := range problems {
+		require.Equal(t, file, p.File)
+		require.Equal(t, LevelWarning, p.Level)
+		actual = append(actual, fmt.Sprintf("%d: %s: %s", p.Line, p.Field, p.Rule))
+	}
+	require.Equal(t, []string{
+		"1: schedule: overlapping-schedule",
+		"5: env[0].DB_PASSWORD: plaintext-secret",
+		"9: steps[0]: network-retry",
+		"15: steps[0].executor.config.headers.Authorization: plaintext-secret",
+		"17: steps[1]: unreachable-step",
+		"23: steps[2]: unreachable-step",
+		"28: steps[3]: step-description",
+	}, actual)
+	require.Equal(t, "step after-disabled never runs: it depends on disabled, which never runs", problems[5].Message)
+
+	problems, err = Lint(path.Join(testdataDir, "lint/clean.yaml"), rules)
+	require.NoError(t, err)
+	require.Empty(t, problems)
+
+	// The DAG is not linted if it is invalid.
+	problems, err = Lint(path.Join(testdataDir, "validate/cycle.yaml"), rules)
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	require.Equal(t, LevelError, problems[0].Level)
+}
+
+func TestLintData(t *testing.T) {
+	rules, err := SelectLintRules(nil, nil)
+	require.NoError(t, err)
+	file := path.Join(testdataDir, "lint/editing.yaml")
+
+	// A step runs if one of the branches it joins is taken.
+	problems := LintData([]byte(`steps:
+  - name: a
+    command: echo a
+    if: "1 == 2"
+  - name: b
+    command: echo b
+    if: "$X == 1"
+  - name: c
+    command: echo c
+    depends: [a, b]
+  - name: d
+    command: echo d
+    depends: [a]
+`), file, rules)
+	var actual []string
+	for _, p := range problems {
+		actual = append(actual, fmt.Sprintf("%d: %s", p.Line, p.Message))
+	}
+	require.Equal(t, []string{
+		`2: step a never runs: if "1 == 2" is always false`,
+		`11: step d never runs: none of the branches it depends on is taken`,
+	}, actual)
+
+	problems = LintData([]byte("schedule:\n  start: \"0 8 * * *\"\n  stop: \"0 8 * * 1\"\nsteps:\n  - name: a\n    command: echo\n"), file, rules)
+	require.Len(t, problems, 1)
+	require.Equal(t, RuleOverlappingSchedule, problems[0].Rule)
+}
+
+func TestSelectLintRules(t *testing.T) {
+	names := func(rules []LintRule) []string {
+		var names []string
+		for _, r := range rules {
+			names = append(names, r.Name)
+		}
+		return names
+	}
+	rules, err := SelectLintRules(nil, nil)
+	require.NoError(t, err)
+	require.NotContains(t, names(rules), RuleStepDescription)
+
+	rules, err = SelectLintRules([]string{RuleStepDescription}, []string{RuleNetworkRetry, RulePlaintextSecret})
+	require.NoError(t, err)
+	require.Equal(t, []string{RuleUnreachableStep, RuleStepDescription, RuleOverlappingSchedule}, names(rules))
+
+	rules, err = SelectLintRules(nil, []string{"all"})
+	require.NoError(t, err)
+	require.Empty(t, rules)
+
+	_, err = SelectLintRules(nil, []string{"unknown"})
+	require.ErrorIs(t, err, errUnknownLintRule)
+}
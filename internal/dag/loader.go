@@ -10,6 +10,8 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/dagu-dev/dagu/internal/config"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
 	"github.com/dagu-dev/dagu/internal/utils"
 	"github.com/imdario/mergo"
 	"github.com/mitchellh/mapstructure"
@@ -23,7 +25,7 @@ type Loader struct {
 }
 
 var (
-	errConfigFileRequired = errors.New("config file was not specified")
+	errConfigFileRequired = dagerrors.New(dagerrors.CodeInvalidArgument, "config file was not specified")
 	errReadFile           = errors.New("failed to read file")
 )
 
@@ -32,6 +34,12 @@ func (cl *Loader) Load(f, params string) (*DAG, error) {
 	return cl.loadWithOptions(f, params, false, false, false)
 }
 
+// LoadForRun loads config from file to start a run. The defaults of the
+// parameters that have a source are read from it.
+func (cl *Loader) LoadForRun(f, params string) (*DAG, error) {
+	return cl.loadDAG(f, &BuildDAGOptions{parameters: params, resolveParamSources: true})
+}
+
 // LoadWithoutEval loads config from file without evaluating env variables.
 func (cl *Loader) LoadWithoutEval(f string) (*DAG, error) {
 	return cl.loadWithOptions(f, "", false, true, true)
@@ -54,13 +62,24 @@ func (cl *Loader) loadWithOptions(f, params string, loadMetadataOnly, skipEnvEva
 	)
 }
 
-// LoadData loads config from given data.
+// LoadData loads config from given data. The included files are relative
+// to the working directory.
 func (cl *Loader) LoadData(data []byte) (*DAG, error) {
+	return cl.LoadDataAt(data, "")
+}
+
+// LoadDataAt loads config from given data of the file, e.g. to validate the
+// new content of the file. The included files are relative to the file.
+func (cl *Loader) LoadDataAt(data []byte, file string) (*DAG, error) {
 	fl := &fileLoader{}
 	raw, err := fl.unmarshalData(data)
 	if err != nil {
 		return nil, err
 	}
+	raw, err = fl.resolveIncludes(file, raw)
+	if err != nil {
+		return nil, err
+	}
 	cdl := &configDefinitionLoader{}
 	def, err := cdl.decode(raw)
 	if err != nil {
@@ -93,7 +112,13 @@ func (cl *Loader) loadBaseConfig(file string, opts *BuildDAGOptions) (*DAG, erro
 	b := &DAGBuilder{
 		options: buildOpts,
 	}
-	return b.buildFromDefinition(def, nil)
+	d, err := b.buildFromDefinition(def, nil)
+	if err != nil {
+		return nil, err
+	}
+	// The warnings of the base config are not the warnings of the DAG.
+	d.Warnings = nil
+	return d, nil
 }
 
 func (cl *Loader) loadDAG(f string, opts *BuildDAGOptions) (*DAG, error) {
@@ -116,7 +141,7 @@ func (cl *Loader) loadDAG(f string, opts *BuildDAGOptions) (*DAG, error) {
 
 	def, err := cdl.decode(raw)
 	if err != nil {
-		return nil, err
+		return nil, dagerrors.WithCode(dagerrors.CodeInvalidDAG, err)
 	}
 
 	b := DAGBuilder{options: *opts}
@@ -133,8 +158,13 @@ func (cl *Loader) loadDAG(f string, opts *BuildDAGOptions) (*DAG, error) {
 
 	dst.Location = file
 
-	if !opts.skipEnvSetup {
+	switch {
+	case !opts.skipEnvSetup:
 		dst.setup()
+	case !opts.loadMetadataOnly:
+		// The defaults do not depend on the environment, e.g. the retention
+		// of the history pruned from the details of the DAGs.
+		dst.setDefaults()
 	}
 
 	return dst, nil
@@ -191,15 +221,26 @@ func (cl *Loader) readFile(file string) (config map[string]interface{}, err erro
 }
 
 // fileLoader is a helper struct to load and process configuration files.
-type fileLoader struct{}
+type fileLoader struct {
+	// stack is the files that include the file being read, to detect
+	// cycles.
+	stack []string
+}
 
 // readFile reads the contents of the file into a map.
 func (fl *fileLoader) readFile(file string) (config map[string]interface{}, err error) {
 	data, err := os.ReadFile(file)
 	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, dagerrors.WithCode(dagerrors.CodeNotFound, fmt.Errorf("%w %s: %v", errReadFile, file, err))
+		}
 		return nil, fmt.Errorf("%w %s: %v", errReadFile, file, err)
 	}
-	return fl.unmarshalData(data)
+	cm, err := fl.unmarshalData(data)
+	if err != nil {
+		return nil, dagerrors.WithCode(dagerrors.CodeInvalidDAG, err)
+	}
+	return fl.resolveIncludes(file, cm)
 }
 
 // unmarshalData unmarshals the data into a map.
@@ -223,7 +264,15 @@ func (cdl *configDefinitionLoader) decode(cm map[string]interface{}) (*configDef
 		Result:      c,
 		TagName:     "",
 	})
-	err := md.Decode(cm)
+	if err := md.Decode(cm); err != nil {
+		return c, err
+	}
+	strict := config.Get().StrictMode
+	if c.Strict != nil {
+		strict = *c.Strict
+	}
+	var err error
+	c.warnings, err = checkFields(cm, strict)
 	return c, err
 }
 
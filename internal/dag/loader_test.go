@@ -1,12 +1,14 @@
 package dag
 
 import (
+	"os"
 	"path"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/constants"
 	"github.com/stretchr/testify/require"
 )
 
@@ -54,6 +56,44 @@ func TestLoaingErrors(t *testing.T) {
 	}
 }
 
+func TestLoadingIncludes(t *testing.T) {
+	// restore the variables the DAG sets
+	t.Setenv("LOG_LEVEL", "")
+	t.Setenv("REGION", "")
+
+	l := &Loader{}
+	d, err := l.Load(path.Join(testdataDir, "include/include.yaml"), "")
+	require.NoError(t, err)
+
+	require.ElementsMatch(t, []string{"LOG_LEVEL=info", "REGION=us"}, d.Env)
+	require.Equal(t, []string{"shared"}, d.Tags)
+	require.Equal(t, "echo failed", d.HandlerOn.Failure.CmdWithArgs)
+	require.Equal(t, "echo done", d.HandlerOn.Exit.CmdWithArgs)
+
+	require.Len(t, d.Steps, 2)
+	require.Equal(t, "setup", d.Steps[0].Name)
+	train := d.Steps[1]
+	require.Equal(t, "python train.py", train.CmdWithArgs)
+	require.Equal(t, "/opt/app", train.Dir)
+	require.Equal(t, 3, train.RetryPolicy.Limit)
+	require.Equal(t, []string{"setup"}, train.Depends)
+}
+
+func TestLoadingIncludeErrors(t *testing.T) {
+	for file, expected := range map[string]string{
+		"include/cycle_a.yaml": "include cycle: " + strings.Join([]string{
+			path.Join(testdataDir, "include/cycle_a.yaml"),
+			path.Join(testdataDir, "include/cycle_b.yaml"),
+			path.Join(testdataDir, "include/cycle_a.yaml"),
+		}, " -> "),
+		"include/missing.yaml": "steps[0].extends: shared/missing_step.yaml",
+	} {
+		_, err := (&Loader{}).Load(path.Join(testdataDir, file), "")
+		require.ErrorIs(t, err, errInvalidInclude, file)
+		require.Contains(t, err.Error(), expected, file)
+	}
+}
+
 func TestLoadingHeadlineOnly(t *testing.T) {
 	l := &Loader{}
 
@@ -81,6 +121,44 @@ func TestLoadingBaseConfig(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestLoadingBaseConfigHandlers(t *testing.T) {
+	dir := t.TempDir()
+	base := path.Join(dir, "base.yaml")
+	require.NoError(t, os.WriteFile(base, []byte(`
+handlerOn:
+  failure:
+    command: notify failure
+  exit:
+    command: notify exit
+mailOn:
+  failure: true
+`), 0600))
+	own := path.Join(dir, "own.yaml")
+	require.NoError(t, os.WriteFile(own, []byte(`
+handlerOn:
+  failure:
+    command: own failure
+steps:
+  - name: "1"
+    command: "true"
+`), 0600))
+	l := &Loader{BaseConfig: base}
+
+	// The handlers of the base config apply to every DAG.
+	d, err := l.Load(path.Join(testdataDir, "default.yaml"), "")
+	require.NoError(t, err)
+	require.Equal(t, "notify", d.HandlerOn.Failure.Command)
+	require.Equal(t, constants.OnFailure, d.HandlerOn.Failure.Name)
+	require.Equal(t, "notify", d.HandlerOn.Exit.Command)
+	require.True(t, d.MailOn.Failure)
+
+	// A handler of the DAG overrides the one of the base config only.
+	d, err = l.Load(own, "")
+	require.NoError(t, err)
+	require.Equal(t, "own", d.HandlerOn.Failure.Command)
+	require.Equal(t, "notify", d.HandlerOn.Exit.Command)
+}
+
 func TestLoadingDeafultValues(t *testing.T) {
 	l := &Loader{}
 	d, err := l.Load(path.Join(testdataDir, "default.yaml"), "")
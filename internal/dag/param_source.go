@@ -0,0 +1,297 @@
+package dag
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/mitchellh/mapstructure"
+)
+
+// Failure policies of the parameter sources.
+const (
+	// ParamSourceOnErrorFail fails the run if the source cannot be read.
+	ParamSourceOnErrorFail = "fail"
+	// ParamSourceOnErrorDefault uses the default of the parameter.
+	ParamSourceOnErrorDefault = "default"
+	// ParamSourceOnErrorCached uses the last value read from the source,
+	// however old it is.
+	ParamSourceOnErrorCached = "cached"
+
+	paramSourceTimeout = time.Second * 30
+)
+
+var (
+	errInvalidParamSource = errors.New("invalid parameter source")
+	errUnknownParamSource = errors.New("unknown parameter source")
+	errParamSourceFailed  = errors.New("failed to read parameter source")
+	errParamSourceKey     = errors.New("key not found in parameter source")
+)
+
+// ParamSource is the external source of the default value of a parameter.
+// It is read when a run is started.
+type ParamSource struct {
+	// Ref is the URL of the source, e.g. https://config/etl.json#region,
+	// file:///etc/dagu/params.json#region or ssm://prod/etl/region. The
+	// fragment is the key of the value in a JSON document; nested keys are
+	// separated by dots. Without it, the whole content is the value.
+	Ref string `json:"Ref"`
+	// Cache is how long a value read from the source is used without
+	// reading it again.
+	Cache time.Duration `json:"Cache,omitempty"`
+	// OnError is the policy when the source cannot be read: fail, default
+	// or cached.
+	OnError string `json:"OnError,omitempty"`
+}
+
+// ParamFetcher reads the content at the URL of a parameter source.
+type ParamFetcher interface {
+	Fetch(ctx context.Context, ref string) (string, error)
+}
+
+// ParamFetcherFunc is a function that implements ParamFetcher.
+type ParamFetcherFunc func(ctx context.Context, ref string) (string, error)
+
+func (f ParamFetcherFunc) Fetch(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+var (
+	paramFetchers   = make(map[string]ParamFetcher)
+	paramFetchersMu sync.RWMutex
+)
+
+// RegisterParamFetcher registers the fetcher of the sources with the URL
+// scheme.
+func RegisterParamFetcher(scheme string, f ParamFetcher) {
+	paramFetchersMu.Lock()
+	defer paramFetchersMu.Unlock()
+	paramFetchers[scheme] = f
+}
+
+func paramFetcher(scheme string) (ParamFetcher, bool) {
+	paramFetchersMu.RLock()
+	defer paramFetchersMu.RUnlock()
+	f, ok := paramFetchers[scheme]
+	return f, ok
+}
+
+type paramSourceDef struct {
+	Ref     string
+	Cache   any
+	OnError string
+}
+
+func parseParamSource(v any) (*ParamSource, error) {
+	var def paramSourceDef
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		def.Ref = v
+	default:
+		md, _ := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			ErrorUnused: true,
+			Result:      &def,
+		})
+		if err := md.Decode(v); err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidParamSource, err)
+		}
+	}
+	if _, rest, ok := strings.Cut(def.Ref, "://"); !ok || rest == "" {
+		return nil, fmt.Errorf("%w: %q", errInvalidParamSource, def.Ref)
+	}
+	cache, err := ParseDuration(def.Cache)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cache: %s", errInvalidParamSource, err)
+	}
+	switch def.OnError {
+	case "":
+		def.OnError = ParamSourceOnErrorFail
+	case ParamSourceOnErrorFail, ParamSourceOnErrorDefault, ParamSourceOnErrorCached:
+	default:
+		return nil, fmt.Errorf("%w: unknown onError %q", errInvalidParamSource, def.OnError)
+	}
+	return &ParamSource{Ref: def.Ref, Cache: cache, OnError: def.OnError}, nil
+}
+
+// resolveParamSources replaces the values of the parameters that are not
+// given to the run with the values read from their sources.
+func resolveParamSources(defs []ParamDef, params, given []utils.Parameter) error {
+	isGiven := givenParams(defs, given)
+	for i := range defs {
+		src := defs[i].Source
+		if src == nil || isGiven[defs[i].Name] {
+			continue
+		}
+		v, err := src.read(paramSourceCacheDir())
+		switch {
+		case err == nil:
+			params[i].Value = v
+		case src.OnError == ParamSourceOnErrorDefault:
+			log.Printf("%s: %s, using the default", defs[i].Name, err)
+		default:
+			return fmt.Errorf("%s: %w", defs[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// givenParams returns the names of the declared parameters that are given,
+// by name or by position.
+func givenParams(defs []ParamDef, given []utils.Parameter) map[string]bool {
+	isGiven := map[string]bool{}
+	for i, p := range given {
+		name := p.Name
+		if name == "" && i < len(defs) {
+			name = defs[i].Name
+		}
+		isGiven[name] = true
+	}
+	return isGiven
+}
+
+func paramSourceCacheDir() string {
+	return filepath.Join(config.Get().DataDir, "param-cache")
+}
+
+// paramSourceCache is the last content read from a source.
+type paramSourceCache struct {
+	Content   string
+	FetchedAt time.Time
+}
+
+// read returns the value from the cache if it is fresh, and from the source
+// otherwise. With the cached policy, the cached value is used regardless of
+// its age if the source cannot be read.
+func (s *ParamSource) read(cacheDir string) (string, error) {
+	url, key, _ := strings.Cut(os.ExpandEnv(s.Ref), "#")
+	sum := md5.Sum([]byte(url))
+	cacheFile := filepath.Join(cacheDir, fmt.Sprintf("%x.json", sum))
+	cached := readParamSourceCache(cacheFile)
+
+	content := ""
+	if cached != nil && s.Cache > 0 && time.Since(cached.FetchedAt) < s.Cache {
+		content = cached.Content
+	} else {
+		var err error
+		content, err = fetchParamSource(url)
+		switch {
+		case err == nil:
+			writeParamSourceCache(cacheFile, &paramSourceCache{Content: content, FetchedAt: time.Now()})
+		case s.OnError == ParamSourceOnErrorCached && cached != nil:
+			log.Printf("%s, using the value read at %s", err, cached.FetchedAt.Format(time.RFC3339))
+			content = cached.Content
+		default:
+			return "", err
+		}
+	}
+	if key == "" {
+		return strings.TrimSpace(content), nil
+	}
+	return jsonValue(content, key)
+}
+
+func fetchParamSource(url string) (string, error) {
+	scheme, _, _ := strings.Cut(url, "://")
+	f, ok := paramFetcher(scheme)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", errUnknownParamSource, scheme)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), paramSourceTimeout)
+	defer cancel()
+	content, err := f.Fetch(ctx, url)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %s", errParamSourceFailed, url, err)
+	}
+	return content, nil
+}
+
+func readParamSourceCache(file string) *paramSourceCache {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	var c paramSourceCache
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil
+	}
+	return &c
+}
+
+func writeParamSourceCache(file string, c *paramSourceCache) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		log.Printf("failed to write the parameter cache: %s", err)
+		return
+	}
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		log.Printf("failed to write the parameter cache: %s", err)
+		return
+	}
+	utils.LogErr("write the parameter cache", os.Rename(tmp, file))
+}
+
+// jsonValue returns the value of the dotted key in the JSON document.
+func jsonValue(content, key string) (string, error) {
+	v, err := utils.JSONPath(content, key)
+	switch {
+	case errors.Is(err, utils.ErrJSONPathNotFound):
+		return "", fmt.Errorf("%w: %s", errParamSourceKey, key)
+	case err != nil:
+		return "", fmt.Errorf("%w: %s", errParamSourceFailed, err)
+	}
+	return v, nil
+}
+
+var paramHTTPClient = &http.Client{Timeout: paramSourceTimeout}
+
+func fetchHTTPParam(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := paramHTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode >= 300 {
+		return "", errors.New(resp.Status)
+	}
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, resp.Body); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func fetchFileParam(_ context.Context, url string) (string, error) {
+	b, err := os.ReadFile(strings.TrimPrefix(url, "file://"))
+	return string(b), err
+}
+
+func init() {
+	RegisterParamFetcher("http", ParamFetcherFunc(fetchHTTPParam))
+	RegisterParamFetcher("https", ParamFetcherFunc(fetchHTTPParam))
+	RegisterParamFetcher("file", ParamFetcherFunc(fetchFileParam))
+}
@@ -0,0 +1,152 @@
+package dag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamSourceRead(t *testing.T) {
+	tmpDir := utils.MustTempDir("test-param-source")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	cacheDir := filepath.Join(tmpDir, "cache")
+
+	region := "eu-west-1"
+	up := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !up {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"etl": {"region": "` + region + `", "batch": 100}}`))
+	}))
+	defer srv.Close()
+
+	src := &ParamSource{Ref: srv.URL + "#etl.region", OnError: ParamSourceOnErrorFail}
+	v, err := src.read(cacheDir)
+	require.NoError(t, err)
+	require.Equal(t, "eu-west-1", v)
+
+	batch := &ParamSource{Ref: srv.URL + "#etl.batch", OnError: ParamSourceOnErrorFail}
+	v, err = batch.read(cacheDir)
+	require.NoError(t, err)
+	require.Equal(t, "100", v)
+
+	missing := &ParamSource{Ref: srv.URL + "#etl.zone", OnError: ParamSourceOnErrorFail}
+	_, err = missing.read(cacheDir)
+	require.ErrorIs(t, err, errParamSourceKey)
+
+	// A fresh cached value is used without reading the source.
+	region = "us-east-1"
+	src.Cache = time.Hour
+	v, err = src.read(cacheDir)
+	require.NoError(t, err)
+	require.Equal(t, "eu-west-1", v)
+
+	src.Cache = 0
+	v, err = src.read(cacheDir)
+	require.NoError(t, err)
+	require.Equal(t, "us-east-1", v)
+
+	// The last value is used if the source is down and the policy allows it.
+	up = false
+	_, err = src.read(cacheDir)
+	require.ErrorIs(t, err, errParamSourceFailed)
+	src.OnError = ParamSourceOnErrorCached
+	v, err = src.read(cacheDir)
+	require.NoError(t, err)
+	require.Equal(t, "us-east-1", v)
+
+	file := filepath.Join(tmpDir, "params.txt")
+	require.NoError(t, os.WriteFile(file, []byte("value\n"), 0600))
+	v, err = (&ParamSource{Ref: "file://" + file}).read(cacheDir)
+	require.NoError(t, err)
+	require.Equal(t, "value", v)
+
+	_, err = (&ParamSource{Ref: "unknown://x"}).read(cacheDir)
+	require.ErrorIs(t, err, errUnknownParamSource)
+}
+
+func TestLoadingParamSources(t *testing.T) {
+	tmpDir := utils.MustTempDir("test-param-source")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	dataDir := config.Get().DataDir
+	config.Get().DataDir = tmpDir
+	defer func() {
+		config.Get().DataDir = dataDir
+	}()
+
+	params := filepath.Join(tmpDir, "params.json")
+	require.NoError(t, os.WriteFile(params, []byte(`{"region": "eu-west-1"}`), 0600))
+	file := filepath.Join(tmpDir, "sources.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`params:
+  - name: REGION
+    default: us-east-1
+    source: file://`+params+`#region
+  - name: ZONE
+    default: a
+    source:
+      ref: file://`+filepath.Join(tmpDir, "missing.json")+`
+      onError: default
+steps:
+  - name: step1
+    command: echo $REGION
+`), 0600))
+
+	l := &Loader{}
+	d, err := l.Load(file, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{`REGION="us-east-1"`, `ZONE="a"`}, d.Params)
+
+	d, err = l.LoadForRun(file, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{`REGION="eu-west-1"`, `ZONE="a"`}, d.Params)
+
+	// The given values are not read from the sources.
+	d, err = l.LoadForRun(file, "REGION=ap-east-1")
+	require.NoError(t, err)
+	require.Equal(t, []string{`REGION="ap-east-1"`, `ZONE="a"`}, d.Params)
+	d, err = l.LoadForRun(file, "ap-east-1")
+	require.NoError(t, err)
+	require.Equal(t, []string{`REGION="ap-east-1"`, `ZONE="a"`}, d.Params)
+
+	require.NoError(t, os.Remove(params))
+	_, err = l.LoadForRun(file, "")
+	require.ErrorContains(t, err, errParamSourceFailed.Error())
+}
+
+func TestValidateParamsWithSources(t *testing.T) {
+	d, err := (&Loader{}).LoadData([]byte(`params:
+  - name: REGION
+    type: enum
+    values: [us-east-1, eu-west-1]
+    required: true
+    source: file:///etc/dagu/params.json#region
+  - name: COUNT
+    type: int
+steps:
+  - name: step1
+    command: echo $REGION
+`))
+	require.NoError(t, err)
+
+	// The parameters that are read from their sources are validated when
+	// the run starts, and the given ones now.
+	require.NoError(t, d.ValidateParams(""))
+	require.NoError(t, d.ValidateParams("COUNT=1"))
+	require.NoError(t, d.ValidateParams("REGION=eu-west-1"))
+	require.ErrorIs(t, d.ValidateParams("REGION=ap-east-1"), errInvalidParam)
+	require.ErrorIs(t, d.ValidateParams("ap-east-1"), errInvalidParam)
+	require.ErrorIs(t, d.ValidateParams("COUNT=x"), errInvalidParam)
+}
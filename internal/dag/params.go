@@ -0,0 +1,323 @@
+package dag
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/mitchellh/mapstructure"
+)
+
+// Types of the declared parameters.
+const (
+	ParamTypeString = "string"
+	ParamTypeInt    = "int"
+	ParamTypeBool   = "bool"
+	ParamTypeEnum   = "enum"
+	ParamTypeDate   = "date"
+)
+
+// ParamDateFormat is the format of the values of date parameters.
+const ParamDateFormat = "2006-01-02"
+
+var (
+	errParamsMustBeStringOrArray = errors.New("params must be a string or an array")
+	errInvalidParamDef           = errors.New("invalid parameter definition")
+	errInvalidParam              = errors.New("invalid parameter")
+	errUnknownParam              = errors.New("unknown parameter")
+	errParamRequired             = errors.New("parameter is required")
+
+	paramNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// ParamDef declares a named parameter of the DAG.
+type ParamDef struct {
+	Name        string `json:"Name"`
+	Type        string `json:"Type"`
+	Description string `json:"Description,omitempty"`
+	Default     string `json:"Default,omitempty"`
+	// Required rejects the runs without a value for the parameter.
+	Required bool `json:"Required,omitempty"`
+	// Values are the allowed values of an enum parameter.
+	Values []string `json:"Values,omitempty"`
+	// Pattern is a regular expression a string parameter must match.
+	Pattern string `json:"Pattern,omitempty"`
+	// Min and Max are the bounds of an int parameter.
+	Min *int `json:"Min,omitempty"`
+	Max *int `json:"Max,omitempty"`
+	// Source is the external source of the default, read when a run is
+	// started.
+	Source *ParamSource `json:"Source,omitempty"`
+}
+
+// Validate returns an error if the value is not valid for the parameter.
+// An empty value is valid unless the parameter is required.
+func (p *ParamDef) Validate(value string) error {
+	if value == "" {
+		if p.Required {
+			return fmt.Errorf("%w: %s", errParamRequired, p.Name)
+		}
+		return nil
+	}
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %s=%q %s", errInvalidParam, p.Name, value, reason)
+	}
+	switch p.Type {
+	case ParamTypeString:
+		if p.Pattern != "" {
+			if ok, _ := regexp.MatchString(p.Pattern, value); !ok {
+				return invalid(fmt.Sprintf("does not match %s", p.Pattern))
+			}
+		}
+	case ParamTypeInt:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return invalid("is not an integer")
+		}
+		if p.Min != nil && n < *p.Min {
+			return invalid(fmt.Sprintf("is less than %d", *p.Min))
+		}
+		if p.Max != nil && n > *p.Max {
+			return invalid(fmt.Sprintf("is greater than %d", *p.Max))
+		}
+	case ParamTypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return invalid("is not a boolean")
+		}
+	case ParamTypeEnum:
+		for _, v := range p.Values {
+			if v == value {
+				return nil
+			}
+		}
+		return invalid(fmt.Sprintf("is not one of %s", strings.Join(p.Values, ", ")))
+	case ParamTypeDate:
+		if _, err := time.Parse(ParamDateFormat, value); err != nil {
+			return invalid("is not a date in YYYY-MM-DD format")
+		}
+	}
+	return nil
+}
+
+// ValidateParams validates the parameters given to a run of the DAG against
+// its declared parameters. Command substitutions and variables are not
+// evaluated, so the values that contain them are not validated. Neither are
+// the parameters with a source that are not given, which are read from
+// their sources and validated when the run starts.
+func (d *DAG) ValidateParams(params string) error {
+	if len(d.ParamDefs) == 0 {
+		return nil
+	}
+	defaults, err := utils.ParseParams(d.DefaultParams, false)
+	if err != nil {
+		return err
+	}
+	given, err := utils.ParseParams(params, false)
+	if err != nil {
+		return err
+	}
+	resolved, err := resolveParams(d.ParamDefs, defaults, given)
+	if err != nil {
+		return err
+	}
+	isGiven := givenParams(d.ParamDefs, given)
+	var defs []ParamDef
+	var values []utils.Parameter
+	for i := range d.ParamDefs {
+		if d.ParamDefs[i].Source != nil && !isGiven[d.ParamDefs[i].Name] {
+			continue
+		}
+		defs = append(defs, d.ParamDefs[i])
+		values = append(values, resolved[i])
+	}
+	return validateParams(defs, values, false)
+}
+
+type paramDefDef struct {
+	Name        string
+	Type        string
+	Description string
+	// Default is any so that YAML booleans and numbers keep their form.
+	Default  any
+	Required bool
+	Values   []string
+	Pattern  string
+	Min      *int
+	Max      *int
+	Source   any
+}
+
+// parseParamDefs parses the declared parameters. It returns nil if the
+// parameters are given as a string.
+func parseParamDefs(value any) ([]ParamDef, error) {
+	switch value.(type) {
+	case nil, string:
+		return nil, nil
+	case []any:
+	default:
+		return nil, errParamsMustBeStringOrArray
+	}
+	var ret []ParamDef
+	seen := map[string]bool{}
+	for _, v := range value.([]any) {
+		var def paramDefDef
+		md, _ := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			ErrorUnused:      true,
+			WeaklyTypedInput: true,
+			Result:           &def,
+		})
+		err := md.Decode(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidParamDef, err)
+		}
+		var dflt string
+		if def.Default != nil {
+			dflt = fmt.Sprint(def.Default)
+		}
+		p := ParamDef{
+			Name:        def.Name,
+			Type:        def.Type,
+			Description: def.Description,
+			Default:     dflt,
+			Required:    def.Required,
+			Values:      def.Values,
+			Pattern:     def.Pattern,
+			Min:         def.Min,
+			Max:         def.Max,
+		}
+		if p.Type == "" {
+			p.Type = ParamTypeString
+		}
+		if p.Source, err = parseParamSource(def.Source); err != nil {
+			return nil, fmt.Errorf("%s: %w", p.Name, err)
+		}
+		if err := assertParamDef(&p); err != nil {
+			return nil, err
+		}
+		if seen[p.Name] {
+			return nil, fmt.Errorf("%w: duplicate name %s", errInvalidParamDef, p.Name)
+		}
+		seen[p.Name] = true
+		ret = append(ret, p)
+	}
+	return ret, nil
+}
+
+func assertParamDef(p *ParamDef) error {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %s: %s", errInvalidParamDef, p.Name, reason)
+	}
+	if !paramNameRegex.MatchString(p.Name) {
+		return fmt.Errorf("%w: invalid name %q", errInvalidParamDef, p.Name)
+	}
+	switch p.Type {
+	case ParamTypeString, ParamTypeInt, ParamTypeBool, ParamTypeDate:
+	case ParamTypeEnum:
+		if len(p.Values) == 0 {
+			return invalid("values are required for an enum")
+		}
+	default:
+		return invalid(fmt.Sprintf("unknown type %q", p.Type))
+	}
+	if p.Pattern != "" {
+		if p.Type != ParamTypeString {
+			return invalid("pattern is only allowed for a string")
+		}
+		if _, err := regexp.Compile(p.Pattern); err != nil {
+			return invalid(err.Error())
+		}
+	}
+	if (p.Min != nil || p.Max != nil) && p.Type != ParamTypeInt {
+		return invalid("min and max are only allowed for an int")
+	}
+	if len(p.Values) > 0 && p.Type != ParamTypeEnum {
+		return invalid("values are only allowed for an enum")
+	}
+	if p.Default != "" && !isEvaluated(p.Default) && !isTemplate(p.Default) {
+		return p.Validate(p.Default)
+	}
+	return nil
+}
+
+// defaultParams returns the defaults of the declared parameters in the
+// format of the params field.
+func defaultParams(defs []ParamDef) string {
+	var ret []string
+	for _, p := range defs {
+		if p.Default != "" {
+			ret = append(ret, utils.StringifyParam(utils.Parameter{Name: p.Name, Value: p.Default}))
+		}
+	}
+	return strings.Join(ret, " ")
+}
+
+// resolveParams returns the values of the declared parameters in their
+// order. The given parameters override the defaults either by name or, if
+// they have no name, by position.
+func resolveParams(defs []ParamDef, defaults, given []utils.Parameter) ([]utils.Parameter, error) {
+	values := map[string]string{}
+	for _, p := range defaults {
+		values[p.Name] = p.Value
+	}
+	for i, p := range given {
+		name := p.Name
+		if name == "" {
+			if i >= len(defs) {
+				return nil, fmt.Errorf("%w: %q at position %d", errUnknownParam, p.Value, i+1)
+			}
+			name = defs[i].Name
+		} else if !hasParamDef(defs, name) {
+			return nil, fmt.Errorf("%w: %s", errUnknownParam, name)
+		}
+		values[name] = p.Value
+	}
+	var ret []utils.Parameter
+	for i := range defs {
+		ret = append(ret, utils.Parameter{Name: defs[i].Name, Value: values[defs[i].Name]})
+	}
+	return ret, nil
+}
+
+// validateParams validates the resolved parameters against their
+// declarations. If the parameters are not evaluated, the values that contain
+// command substitutions or variables are not validated. The values with
+// templates are validated when they are rendered.
+func validateParams(defs []ParamDef, params []utils.Parameter, evaluated bool) error {
+	for i := range defs {
+		v := params[i].Value
+		if (!evaluated && isEvaluated(v)) || isTemplate(v) {
+			continue
+		}
+		if err := defs[i].Validate(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasParamDef(defs []ParamDef, name string) bool {
+	for _, p := range defs {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isEvaluated reports whether the value contains command substitutions or
+// variables that are evaluated when the DAG runs.
+func isEvaluated(value string) bool {
+	return strings.ContainsAny(value, "`$")
+}
+
+// expandParams expands the variables in the values of the parameters.
+func expandParams(params []utils.Parameter) {
+	for i := range params {
+		params[i].Value = os.ExpandEnv(params[i].Value)
+	}
+}
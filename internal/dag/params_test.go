@@ -0,0 +1,114 @@
+package dag
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamDefValidate(t *testing.T) {
+	min, max := 1, 10
+	for _, tc := range []struct {
+		def     ParamDef
+		valid   []string
+		invalid []string
+	}{
+		{
+			def:     ParamDef{Name: "S", Type: ParamTypeString, Pattern: "^[a-z]+$"},
+			valid:   []string{"abc", ""},
+			invalid: []string{"ABC", "a1"},
+		},
+		{
+			def:     ParamDef{Name: "N", Type: ParamTypeInt, Min: &min, Max: &max},
+			valid:   []string{"1", "10"},
+			invalid: []string{"0", "11", "1.5", "x"},
+		},
+		{
+			def:     ParamDef{Name: "B", Type: ParamTypeBool},
+			valid:   []string{"true", "false", "1"},
+			invalid: []string{"yes"},
+		},
+		{
+			def:     ParamDef{Name: "E", Type: ParamTypeEnum, Values: []string{"dev", "prod"}},
+			valid:   []string{"dev", "prod"},
+			invalid: []string{"staging"},
+		},
+		{
+			def:     ParamDef{Name: "D", Type: ParamTypeDate},
+			valid:   []string{"2024-02-29"},
+			invalid: []string{"2023-02-29", "02/01/2024"},
+		},
+	} {
+		for _, v := range tc.valid {
+			require.NoError(t, tc.def.Validate(v), "%s=%s", tc.def.Name, v)
+		}
+		for _, v := range tc.invalid {
+			require.ErrorIs(t, tc.def.Validate(v), errInvalidParam, "%s=%s", tc.def.Name, v)
+		}
+	}
+
+	required := ParamDef{Name: "R", Type: ParamTypeString, Required: true}
+	require.ErrorIs(t, required.Validate(""), errParamRequired)
+}
+
+func TestLoadingTypedParams(t *testing.T) {
+	file := path.Join(testdataDir, "typed_params.yaml")
+	l := &Loader{}
+
+	d, err := l.Load(file, "DATE=2024-01-31 COUNT=3")
+	require.NoError(t, err)
+	require.Len(t, d.ParamDefs, 4)
+	require.Equal(t, `ENV="dev" COUNT="1" DRY_RUN="false"`, d.DefaultParams)
+	require.Equal(t, []string{`ENV="dev"`, `COUNT="3"`, `DATE="2024-01-31"`, `DRY_RUN="false"`}, d.Params)
+	require.Equal(t, "3", os.Getenv("COUNT"))
+
+	// Positional values are assigned in the order of the declarations.
+	d, err = l.Load(file, `prod 5 2024-01-31`)
+	require.NoError(t, err)
+	require.Equal(t, []string{`ENV="prod"`, `COUNT="5"`, `DATE="2024-01-31"`, `DRY_RUN="false"`}, d.Params)
+
+	for params, wantErr := range map[string]error{
+		"":                             errParamRequired,
+		"DATE=2024-01-31 ENV=staging":  errInvalidParam,
+		"DATE=2024-01-31 COUNT=11":     errInvalidParam,
+		"DATE=tomorrow":                errInvalidParam,
+		"DATE=2024-01-31 UNKNOWN=1":    errUnknownParam,
+		"prod 5 2024-01-31 true extra": errUnknownParam,
+	} {
+		// The loader collects the build errors in a list that does not
+		// unwrap.
+		_, err := l.Load(file, params)
+		require.ErrorContains(t, err, wantErr.Error(), params)
+	}
+
+	// The API validates the parameters without evaluating them.
+	d, err = l.LoadWithoutEval(file)
+	require.NoError(t, err)
+	require.NoError(t, d.ValidateParams("DATE=2024-01-31"))
+	require.NoError(t, d.ValidateParams("DATE=`date +%Y-%m-%d`"))
+	require.ErrorIs(t, d.ValidateParams("DATE=2024-01-31 COUNT=0"), errInvalidParam)
+	require.ErrorIs(t, d.ValidateParams(""), errParamRequired)
+}
+
+func TestParamDefErrors(t *testing.T) {
+	for _, input := range []string{
+		`params: [{type: string}]`,
+		`params: [{name: "1X"}]`,
+		`params: [{name: X, type: float}]`,
+		`params: [{name: X, type: enum}]`,
+		`params: [{name: X, type: int, pattern: "^a"}]`,
+		`params: [{name: X, pattern: "("}]`,
+		`params: [{name: X, min: 1}]`,
+		`params: [{name: X, type: int, default: abc}]`,
+		`params: [{name: X}, {name: X}]`,
+		`params: [{name: X, unknown: 1}]`,
+		`params: [{name: X, source: "config.json"}]`,
+		`params: [{name: X, source: {ref: "file:///p.json", onError: retry}}]`,
+		`params: [{name: X, source: {ref: "file:///p.json", cache: soon}}]`,
+	} {
+		_, err := (&Loader{}).LoadData([]byte(input))
+		require.Error(t, err, input)
+	}
+}
@@ -0,0 +1,100 @@
+package dag
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	errInvalidPlatform   = errors.New("invalid platform")
+	errPlatformNoCommand = errors.New("platform must have a command or a script")
+	errNoPlatformCommand = errors.New("the step has no command for the platform")
+)
+
+// knownOS and knownArch are the values of GOOS and GOARCH that a platform
+// can refer to.
+var (
+	knownOS = map[string]bool{
+		"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true,
+		"illumos": true, "ios": true, "linux": true, "netbsd": true, "openbsd": true,
+		"plan9": true, "solaris": true, "windows": true,
+	}
+	knownArch = map[string]bool{
+		"386": true, "amd64": true, "arm": true, "arm64": true, "loong64": true,
+		"mips": true, "mips64": true, "mips64le": true, "mipsle": true, "ppc64": true,
+		"ppc64le": true, "riscv64": true, "s390x": true,
+	}
+)
+
+// PlatformCommand replaces the command or the script of a step on a
+// platform.
+type PlatformCommand struct {
+	CmdWithArgs string   `json:"CmdWithArgs,omitempty"`
+	Command     string   `json:"Command,omitempty"`
+	Args        []string `json:"Args,omitempty"`
+	Script      string   `json:"Script,omitempty"`
+}
+
+// SelectPlatform replaces the command and the script of the step with those
+// of the platform. The variant of the OS and the architecture, e.g.
+// linux/arm64, takes precedence over the variant of the OS. The step keeps
+// its own command if no variant matches, and it is an error if it has none.
+func (s *Step) SelectPlatform(goos, goarch string) error {
+	if len(s.Platforms) == 0 {
+		return nil
+	}
+	p, ok := s.Platforms[goos+"/"+goarch]
+	if !ok {
+		p, ok = s.Platforms[goos]
+	}
+	if !ok {
+		if s.Command == "" && s.Script == "" {
+			return fmt.Errorf("%w: %s/%s", errNoPlatformCommand, goos, goarch)
+		}
+		return nil
+	}
+	if p.Command != "" {
+		s.CmdWithArgs, s.Command, s.Args = p.CmdWithArgs, p.Command, p.Args
+	}
+	if p.Script != "" {
+		s.Script = p.Script
+	}
+	return nil
+}
+
+func parsePlatforms(step *Step, def map[string]*platformDef) error {
+	for key, pd := range def {
+		if err := validatePlatform(key); err != nil {
+			return err
+		}
+		if pd == nil || (pd.Command == nil && pd.Script == "") {
+			return fmt.Errorf("%w: %s", errPlatformNoCommand, key)
+		}
+		// The command is parsed like the command of the step.
+		var tmp Step
+		if err := parseCommand(&tmp, pd.Command); err != nil {
+			return fmt.Errorf("platform %s: %w", key, err)
+		}
+		if step.Platforms == nil {
+			step.Platforms = map[string]PlatformCommand{}
+		}
+		step.Platforms[key] = PlatformCommand{
+			CmdWithArgs: tmp.CmdWithArgs,
+			Command:     tmp.Command,
+			Args:        tmp.Args,
+			Script:      pd.Script,
+		}
+	}
+	return nil
+}
+
+// validatePlatform checks that the key is an OS or an OS and an
+// architecture, e.g. linux/arm64.
+func validatePlatform(key string) error {
+	goos, goarch, hasArch := strings.Cut(key, "/")
+	if !knownOS[goos] || (hasArch && !knownArch[goarch]) {
+		return fmt.Errorf("%w: %s", errInvalidPlatform, key)
+	}
+	return nil
+}
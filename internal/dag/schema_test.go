@@ -0,0 +1,50 @@
+package dag
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/dagu-dev/dagu/schemas"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSchemaFields checks that the schema has the fields of the definition
+// and no others, except for the fields that are resolved before the
+// definition is decoded.
+func TestSchemaFields(t *testing.T) {
+	var schema struct {
+		Properties  map[string]any
+		Definitions struct {
+			Step struct {
+				Properties map[string]any
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(schemas.DAG, &schema))
+
+	for _, tt := range []struct {
+		typ        reflect.Type
+		properties map[string]any
+		resolved   []string
+	}{
+		{reflect.TypeOf(configDefinition{}), schema.Properties, []string{"extends", "include"}},
+		{reflect.TypeOf(stepDef{}), schema.Definitions.Step.Properties, []string{"extends"}},
+	} {
+		var fields []string
+		for i := 0; i < tt.typ.NumField(); i++ {
+			if f := tt.typ.Field(i); f.IsExported() {
+				fields = append(fields, fieldKey(f.Name))
+			}
+		}
+		fields = append(fields, tt.resolved...)
+		var properties []string
+		for k := range tt.properties {
+			properties = append(properties, k)
+		}
+		sort.Strings(fields)
+		sort.Strings(properties)
+		require.Equal(t, fields, properties, tt.typ.Name())
+	}
+}
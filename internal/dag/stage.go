@@ -0,0 +1,79 @@
+package dag
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+)
+
+var (
+	errStageNameRequired = errors.New("stage name must be specified")
+	errDuplicateStage    = errors.New("duplicate stage")
+	errUnknownStage      = errors.New("unknown stage")
+)
+
+// buildStages builds the steps of the stages. A stage runs after the
+// stages it depends on: each of its steps depends on all the steps of
+// those stages. The env of a stage is added to the env of the DAG for its
+// steps, and its continueOn applies to the steps that do not set their own.
+func buildStages(def *configDefinition, d *DAG, options BuildDAGOptions) ([]Step, error) {
+	stepsOf := make(map[string][]string)
+	for _, sd := range def.Stages {
+		if sd.Name == "" {
+			return nil, errStageNameRequired
+		}
+		if _, ok := stepsOf[sd.Name]; ok {
+			return nil, fmt.Errorf("%w: %s", errDuplicateStage, sd.Name)
+		}
+		names := []string{}
+		for _, s := range sd.Steps {
+			names = append(names, s.Name)
+		}
+		stepsOf[sd.Name] = names
+	}
+
+	var ret []Step
+	for _, sd := range def.Stages {
+		var upstream []string
+		for _, dep := range sd.Depends {
+			names, ok := stepsOf[dep]
+			if !ok || dep == sd.Name {
+				return nil, fmt.Errorf("%w: stage %s depends on %s", errUnknownStage, sd.Name, dep)
+			}
+			upstream = append(upstream, names...)
+		}
+		variables := slices.Clone(d.Env)
+		if !options.skipEnvEval {
+			// The env of the stage is not set to the environment of the
+			// process, so that it does not leak into the other stages.
+			envOptions := options
+			envOptions.skipEnvSetup = true
+			env, err := loadVariables(sd.Env, envOptions)
+			if err != nil {
+				return nil, fmt.Errorf("stage %s: %w", sd.Name, err)
+			}
+			variables = append(variables, buildConfigEnv(env)...)
+		}
+
+		for _, stepDef := range sd.Steps {
+			if stepDef.ContinueOn == nil {
+				stepDef.ContinueOn = sd.ContinueOn
+			}
+			step, err := buildStep(variables, stepDef, def.Functions, options)
+			if err != nil {
+				return nil, err
+			}
+			step.Stage = sd.Name
+			step.Group = path.Join(sd.Name, step.Group)
+			step.Depends = slices.Clone(step.Depends)
+			for _, name := range upstream {
+				if !slices.Contains(step.Depends, name) {
+					step.Depends = append(step.Depends, name)
+				}
+			}
+			ret = append(ret, *step)
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,65 @@
+package dag
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+var (
+	errInvalidStdin = errors.New("invalid stdin")
+	errStdinSource  = errors.New("stdin must have one of value, file, param and output")
+)
+
+// Stdin is the standard input of a step, e.g. for a command that is not run
+// with a shell. It is read from one of its sources, which are expanded with
+// the variables of the run when the step starts.
+type Stdin struct {
+	// Value is the content of the input.
+	Value string `json:"Value,omitempty"`
+	// File is the path of the file the input is read from. A relative path
+	// is resolved against the directory of the step.
+	File string `json:"File,omitempty"`
+	// Param is the name of the parameter whose value is the input.
+	Param string `json:"Param,omitempty"`
+	// Output is the name of the output variable of a previous step whose
+	// value is the input.
+	Output string `json:"Output,omitempty"`
+}
+
+type stdinDef struct {
+	Value  string
+	File   string
+	Param  string
+	Output string
+}
+
+// parseStdin parses the stdin of a step, a string that is the value of the
+// input, or a map with one of its sources.
+func parseStdin(def any) (*Stdin, error) {
+	if def == nil {
+		return nil, nil
+	}
+	if s, ok := def.(string); ok {
+		return &Stdin{Value: s}, nil
+	}
+	var sd stdinDef
+	md, _ := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		ErrorUnused: true,
+		Result:      &sd,
+	})
+	if err := md.Decode(def); err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidStdin, err)
+	}
+	n := 0
+	for _, s := range []string{sd.Value, sd.File, sd.Param, sd.Output} {
+		if s != "" {
+			n++
+		}
+	}
+	if n != 1 {
+		return nil, fmt.Errorf("%w: %s", errInvalidStdin, errStdinSource)
+	}
+	return &Stdin{Value: sd.Value, File: sd.File, Param: sd.Param, Output: sd.Output}, nil
+}
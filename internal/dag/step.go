@@ -1,7 +1,9 @@
 package dag
 
 import (
+	"errors"
 	"fmt"
+	"math/rand"
 	"path"
 	"strings"
 	"time"
@@ -9,6 +11,10 @@ import (
 	"github.com/dagu-dev/dagu/internal/utils"
 )
 
+// defaultKillGracePeriod is the time a timed out step has to exit after the
+// stop signal before it is killed.
+const defaultKillGracePeriod = time.Second * 10
+
 // Step represents a step in a DAG.
 type Step struct {
 	Name            string         `json:"Name"`
@@ -30,8 +36,47 @@ type Step struct {
 	RepeatPolicy    RepeatPolicy   `json:"RepeatPolicy,omitempty"`
 	MailOnError     bool           `json:"MailOnError,omitempty"`
 	Preconditions   []*Condition   `json:"Preconditions,omitempty"`
+	If              string         `json:"If,omitempty"`
 	SignalOnStop    string         `json:"SignalOnStop,omitempty"`
+	Timeout         time.Duration  `json:"Timeout,omitempty"`
+	KillGracePeriod time.Duration  `json:"KillGracePeriod,omitempty"`
 	SubWorkflow     *SubWorkflow   `json:"SubWorkflow,omitempty"`
+	Foreach         *Foreach       `json:"Foreach,omitempty"`
+	Generator       bool           `json:"Generator,omitempty"`
+	Dotenv          []Dotenv       `json:"Dotenv,omitempty"`
+	Inputs          []Input        `json:"Inputs,omitempty"`
+	Artifacts       []string       `json:"Artifacts,omitempty"`
+	Cache           *CachePolicy   `json:"Cache,omitempty"`
+	Guard           *Guard         `json:"Guard,omitempty"`
+	// ExpectedFiles are the files that must exist once the command exits
+	// 0, or the step fails.
+	ExpectedFiles []ExpectedFile `json:"ExpectedFiles,omitempty"`
+	// Pool is the concurrency pool of the server configuration that the
+	// step takes a slot of while it runs.
+	Pool string `json:"Pool,omitempty"`
+	// ConcurrencyKey serializes the step with the steps of the other runs
+	// of the DAG with the same key. It is expanded with the variables of
+	// the run when the step starts, e.g. ${CUSTOMER_ID}.
+	ConcurrencyKey string `json:"ConcurrencyKey,omitempty"`
+	// Stage is the name of the stage the step is defined in.
+	Stage string `json:"Stage,omitempty"`
+	// Group is the path of the group the step is drawn in, e.g.
+	// load/warehouses, with the names of the nested groups separated by a
+	// slash. The group of a step of a stage is in the group of the stage.
+	Group string `json:"Group,omitempty"`
+	// LogRotation is the rotation of the log of the step. The one of the
+	// DAG applies if it is nil.
+	LogRotation *LogRotation `json:"LogRotation,omitempty"`
+	// Stdin is the standard input of the command of the step.
+	Stdin *Stdin `json:"Stdin,omitempty"`
+
+	// Platforms are the commands of the step by platform. The one of the
+	// platform the step runs on is selected with SelectPlatform.
+	Platforms map[string]PlatformCommand `json:"Platforms,omitempty"`
+
+	// StepOutputs are the structured outputs of the steps of the run by
+	// step name. Like OutputVariables, it is shared by the steps.
+	StepOutputs *utils.SyncMap `json:"StepOutputs,omitempty"`
 }
 
 type SubWorkflow struct {
@@ -39,6 +84,15 @@ type SubWorkflow struct {
 	Params string
 }
 
+// Foreach expands a step into parallel instances, one per item.
+// Either Items or ItemsExpr is set. ItemsExpr is evaluated when the step
+// starts, so it can refer to parameters or outputs of previous steps.
+type Foreach struct {
+	Items       []string `json:"Items,omitempty"`
+	ItemsExpr   string   `json:"ItemsExpr,omitempty"`
+	MaxParallel int      `json:"MaxParallel,omitempty"`
+}
+
 // ExecutorConfig represents the configuration for the executor of a step.
 type ExecutorConfig struct {
 	Type   string
@@ -53,12 +107,78 @@ const (
 type RetryPolicy struct {
 	Limit    int
 	Interval time.Duration
+	// Backoff multiplies the interval after each retry.
+	Backoff float64 `json:"Backoff,omitempty"`
+	// MaxInterval caps the interval increased by Backoff.
+	MaxInterval time.Duration `json:"MaxInterval,omitempty"`
+	// Jitter randomizes the interval by up to this fraction of it so that
+	// the retries of many steps do not happen at the same time.
+	Jitter float64 `json:"Jitter,omitempty"`
+	// ExitCodes limits the retries to the failures with these exit codes.
+	ExitCodes []int `json:"ExitCodes,omitempty"`
+}
+
+// IntervalAt returns the interval before the nth retry of the step.
+func (p RetryPolicy) IntervalAt(n int) time.Duration {
+	interval := backoffInterval(p.Interval, p.Backoff, p.MaxInterval, n)
+	if p.Jitter > 0 {
+		// The interval is scaled by a random factor in [1-Jitter, 1+Jitter].
+		interval = time.Duration(float64(interval) * (1 + p.Jitter*(2*rand.Float64()-1)))
+	}
+	return interval
+}
+
+// ShouldRetry reports whether the step is retried after it failed with the
+// error.
+func (p RetryPolicy) ShouldRetry(err error) bool {
+	if len(p.ExitCodes) == 0 {
+		return true
+	}
+	var exitErr interface{ ExitCode() int }
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+	for _, code := range p.ExitCodes {
+		if code == exitErr.ExitCode() {
+			return true
+		}
+	}
+	return false
 }
 
 // RepeatPolicy represents the repeat policy for a step.
 type RepeatPolicy struct {
 	Repeat   bool
 	Interval time.Duration
+	// Until is the expression that stops the repetition when it is true.
+	// It is evaluated after each run of the step.
+	Until string `json:"Until,omitempty"`
+	// Limit is the maximum number of runs. The step fails if Until is set
+	// and is still false after the last run.
+	Limit int `json:"Limit,omitempty"`
+	// Backoff multiplies the interval after each run.
+	Backoff float64 `json:"Backoff,omitempty"`
+	// MaxInterval caps the interval increased by Backoff.
+	MaxInterval time.Duration `json:"MaxInterval,omitempty"`
+}
+
+// IntervalAt returns the interval after the nth run of the step.
+func (p RepeatPolicy) IntervalAt(n int) time.Duration {
+	return backoffInterval(p.Interval, p.Backoff, p.MaxInterval, n)
+}
+
+// backoffInterval returns the nth interval that starts at interval and is
+// multiplied by backoff each time, up to max.
+func backoffInterval(interval time.Duration, backoff float64, max time.Duration, n int) time.Duration {
+	if backoff > 1 {
+		for i := 1; i < n; i++ {
+			interval = time.Duration(float64(interval) * backoff)
+			if max > 0 && interval >= max {
+				return max
+			}
+		}
+	}
+	return interval
 }
 
 // ContinueOn represents the conditions under which the step continues.
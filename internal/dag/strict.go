@@ -0,0 +1,187 @@
+package dag
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Codes of the warnings.
+const (
+	// WarningDeprecated is a field that is still accepted but has a
+	// replacement.
+	WarningDeprecated = "deprecated"
+	// WarningFieldCase is a field whose name differs from the documented
+	// one only in case, e.g. retrypolicy. It is rejected in strict mode.
+	WarningFieldCase = "fieldCase"
+)
+
+var errUnknownField = errors.New("unknown field")
+
+// Warning is a problem in the definition of a DAG that does not prevent it
+// from running.
+type Warning struct {
+	Code string `json:"Code"`
+	// Field is the path of the field, e.g. steps[0].retrypolicy.
+	Field   string `json:"Field"`
+	Message string `json:"Message"`
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s", w.Field, w.Message)
+}
+
+// fieldTypes are the definitions of the fields that are decoded into an
+// interface and parsed later, so that their keys are checked as well.
+var fieldTypes = map[string]reflect.Type{
+	"configDefinition.Params": reflect.TypeOf(paramDefDef{}),
+	"paramDefDef.Source":      reflect.TypeOf(paramSourceDef{}),
+	"stepDef.Cache":           reflect.TypeOf(cachePolicyDef{}),
+	"stepDef.Guard":           reflect.TypeOf(guardDef{}),
+	"stepDef.Stdin":           reflect.TypeOf(stdinDef{}),
+}
+
+// checkFields checks the keys of the definition against the fields they
+// are decoded into. The keys that are not known at all are rejected by the
+// decoder. In strict mode, the keys that only match a field in a different
+// case are rejected too; otherwise they are reported as warnings.
+func checkFields(raw map[string]any, strict bool) ([]Warning, error) {
+	c := &fieldChecker{strict: strict}
+	c.check("", raw, reflect.TypeOf(configDefinition{}))
+	if len(c.errs) > 0 {
+		return nil, fmt.Errorf("%w: %s", errUnknownField, strings.Join(c.errs, ", "))
+	}
+	return c.warnings, nil
+}
+
+type fieldChecker struct {
+	strict   bool
+	warnings []Warning
+	errs     []string
+	// unknown are the fields that are not known at all, which the decoder
+	// rejects one at a time.
+	unknown []string
+}
+
+func (c *fieldChecker) check(path string, raw any, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice:
+		items, ok := raw.([]any)
+		if !ok {
+			return
+		}
+		for i, item := range items {
+			c.check(fmt.Sprintf("%s[%d]", path, i), item, t.Elem())
+		}
+	case reflect.Map:
+		keys, ok := rawMap(raw)
+		if !ok {
+			return
+		}
+		for _, key := range sortedKeys(keys) {
+			c.check(joinPath(path, key), keys[key], t.Elem())
+		}
+	case reflect.Struct:
+		c.checkStruct(path, raw, t)
+	}
+}
+
+func (c *fieldChecker) checkStruct(path string, raw any, t reflect.Type) {
+	keys, ok := rawMap(raw)
+	if !ok {
+		return
+	}
+	for _, key := range sortedKeys(keys) {
+		v := keys[key]
+		f, ok := lookupField(t, key)
+		if !ok {
+			c.unknown = append(c.unknown, joinPath(path, key))
+			continue
+		}
+		name := fieldKey(f.Name)
+		field := joinPath(path, key)
+		if key != name {
+			if c.strict {
+				c.errs = append(c.errs, fmt.Sprintf("%s (did you mean %s?)", field, name))
+			} else {
+				c.warnings = append(c.warnings, Warning{
+					Code:    WarningFieldCase,
+					Field:   field,
+					Message: fmt.Sprintf("should be %s; it is rejected in strict mode", name),
+				})
+			}
+		}
+		if base, ok := strings.CutSuffix(f.Name, "Sec"); ok {
+			if _, ok := t.FieldByName(base); ok {
+				c.warnings = append(c.warnings, Warning{
+					Code:    WarningDeprecated,
+					Field:   field,
+					Message: fmt.Sprintf("%s is deprecated, use %s instead", name, fieldKey(base)),
+				})
+			}
+		}
+		ft := f.Type
+		if et, ok := fieldTypes[t.Name()+"."+f.Name]; ok {
+			ft = reflect.SliceOf(et)
+			if _, isList := v.([]any); !isList {
+				ft = et
+			}
+		}
+		c.check(field, v, ft)
+	}
+}
+
+func rawMap(raw any) (map[string]any, bool) {
+	switch m := raw.(type) {
+	case map[string]any:
+		return m, true
+	case map[any]any:
+		keys := make(map[string]any, len(m))
+		for k, v := range m {
+			if s, ok := k.(string); ok {
+				keys[s] = v
+			}
+		}
+		return keys, true
+	}
+	return nil, false
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// lookupField returns the field the key is decoded into. Like the decoder,
+// it matches the name of the field regardless of case.
+func lookupField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.IsExported() && strings.EqualFold(f.Name, key) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// fieldKey returns the documented name of the field, e.g. retryPolicy for
+// RetryPolicy.
+func fieldKey(name string) string {
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
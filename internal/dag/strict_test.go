@@ -0,0 +1,66 @@
+package dag
+
+import (
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadingWarnings(t *testing.T) {
+	d, err := (&Loader{}).LoadData([]byte(`
+delaySec: 1
+params:
+  - name: REGION
+    Default: us-east-1
+steps:
+  - name: step1
+    command: echo 1
+    retrypolicy:
+      limit: 2
+      intervalSec: 5
+`))
+	require.NoError(t, err)
+	require.Equal(t, []Warning{
+		{Code: WarningDeprecated, Field: "delaySec", Message: "delaySec is deprecated, use delay instead"},
+		{Code: WarningFieldCase, Field: "params[0].Default", Message: "should be default; it is rejected in strict mode"},
+		{Code: WarningFieldCase, Field: "steps[0].retrypolicy", Message: "should be retryPolicy; it is rejected in strict mode"},
+		{Code: WarningDeprecated, Field: "steps[0].retrypolicy.intervalSec", Message: "intervalSec is deprecated, use interval instead"},
+	}, d.Warnings)
+	require.Equal(t, 2, d.Steps[0].RetryPolicy.Limit)
+
+	d, err = (&Loader{}).LoadData([]byte("steps:\n  - name: step1\n    command: echo 1\n"))
+	require.NoError(t, err)
+	require.Empty(t, d.Warnings)
+}
+
+func TestLoadingStrict(t *testing.T) {
+	spec := `
+steps:
+  - name: step1
+    command: echo 1
+    retrypolicy:
+      limit: 2
+`
+	_, err := (&Loader{}).LoadData([]byte("strict: true\n" + spec))
+	require.ErrorIs(t, err, errUnknownField)
+	require.ErrorContains(t, err, "steps[0].retrypolicy (did you mean retryPolicy?)")
+
+	strictMode := config.Get().StrictMode
+	config.Get().StrictMode = true
+	defer func() {
+		config.Get().StrictMode = strictMode
+	}()
+	_, err = (&Loader{}).LoadData([]byte(spec))
+	require.ErrorIs(t, err, errUnknownField)
+
+	// A DAG can opt out of the global strict mode.
+	d, err := (&Loader{}).LoadData([]byte("strict: false\n" + spec))
+	require.NoError(t, err)
+	require.Len(t, d.Warnings, 1)
+
+	// Deprecated fields are still accepted in strict mode.
+	d, err = (&Loader{}).LoadData([]byte("delaySec: 1\nsteps:\n  - name: step1\n    command: echo 1\n"))
+	require.NoError(t, err)
+	require.Equal(t, WarningDeprecated, d.Warnings[0].Code)
+}
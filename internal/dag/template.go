@@ -0,0 +1,242 @@
+package dag
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/utils"
+)
+
+var errInvalidTemplate = errors.New("invalid template")
+
+// TemplateData is the data available to the templates in the parameters,
+// the environment and the commands of a DAG, e.g.
+// {{ .LogicalDate | date "2006-01-02" }}.
+type TemplateData struct {
+	// LogicalDate is the time the run is scheduled at. It is the time the
+	// run is started at if it is not started by a schedule.
+	LogicalDate time.Time
+	RequestId   string
+	Name        string
+	// Schedule is the cron expression of the schedule entry that started
+	// the run.
+	Schedule string
+	// Params are the named parameters of the run after they are rendered.
+	Params map[string]string
+}
+
+// templateFuncs are the functions available to the templates. Like sprig,
+// the value the functions apply to is the last argument, so that it can be
+// piped.
+var templateFuncs = template.FuncMap{
+	"now": time.Now,
+	"date": func(layout string, t time.Time) string {
+		return t.Format(layout)
+	},
+	"toDate": func(layout, s string) (time.Time, error) {
+		return time.Parse(layout, s)
+	},
+	"dateModify": func(d string, t time.Time) (time.Time, error) {
+		v, err := time.ParseDuration(d)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return t.Add(v), nil
+	},
+	"addDays": func(n int, t time.Time) time.Time {
+		return t.AddDate(0, 0, n)
+	},
+	"unixEpoch": func(t time.Time) string {
+		return strconv.FormatInt(t.Unix(), 10)
+	},
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"trim":       strings.TrimSpace,
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+	"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+	"split":      func(sep, s string) []string { return strings.Split(s, sep) },
+	"join":       func(sep string, v []string) string { return strings.Join(v, sep) },
+	"repeat":     func(n int, s string) string { return strings.Repeat(s, n) },
+	"quote":      strconv.Quote,
+	"default": func(d string, v any) any {
+		if v == nil || v == "" {
+			return d
+		}
+		return v
+	},
+	"env": os.Getenv,
+}
+
+// RenderTemplates renders the templates in the parameters, the environment
+// and the commands of the DAG with the data. The parameters are rendered
+// first and set to the environment again, so that the other fields can
+// refer to them with .Params.
+func (d *DAG) RenderTemplates(data *TemplateData) error {
+	data.Params = make(map[string]string)
+	// params are the rendered named parameters by the unrendered ones,
+	// which are also in the environment of the steps.
+	params := make(map[string]string)
+	for i, p := range d.Params {
+		if !isTemplate(p) {
+			if param, ok := parseParam(p); ok && param.Name != "" {
+				data.Params[param.Name] = param.Value
+			}
+			continue
+		}
+		param, ok := parseParam(p)
+		if !ok {
+			continue
+		}
+		v, err := renderTemplate(fmt.Sprintf("params[%d]", i), param.Value, data)
+		if err != nil {
+			return err
+		}
+		param.Value = v
+		if i < len(d.ParamDefs) {
+			if err := d.ParamDefs[i].Validate(v); err != nil {
+				return err
+			}
+		}
+		rendered := utils.StringifyParam(param)
+		pos := param.Value
+		if param.Name != "" {
+			params[p] = rendered
+			data.Params[param.Name] = param.Value
+			pos = rendered
+			if err := os.Setenv(param.Name, param.Value); err != nil {
+				return err
+			}
+		}
+		d.Params[i] = rendered
+		if err := os.Setenv(strconv.Itoa(i+1), pos); err != nil {
+			return err
+		}
+	}
+	if err := renderEnv("env", d.Env, params, data, true); err != nil {
+		return err
+	}
+	for i := range d.Steps {
+		if err := d.Steps[i].renderTemplates(fmt.Sprintf("steps[%d]", i), params, data); err != nil {
+			return err
+		}
+	}
+	for name, h := range map[string]*Step{
+		"handlerOn.exit":    d.HandlerOn.Exit,
+		"handlerOn.success": d.HandlerOn.Success,
+		"handlerOn.failure": d.HandlerOn.Failure,
+		"handlerOn.cancel":  d.HandlerOn.Cancel,
+		"handlerOn.timeout": d.HandlerOn.Timeout,
+	} {
+		if h == nil {
+			continue
+		}
+		if err := h.renderTemplates(name, params, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Step) renderTemplates(field string, params map[string]string, data *TemplateData) error {
+	if err := renderEnv(field+".env", s.Variables, params, data, false); err != nil {
+		return err
+	}
+	if err := renderCommand(field, &s.CmdWithArgs, &s.Command, &s.Args, data); err != nil {
+		return err
+	}
+	for name, p := range s.Platforms {
+		if err := renderCommand(field+".platforms."+name, &p.CmdWithArgs, &p.Command, &p.Args, data); err != nil {
+			return err
+		}
+		s.Platforms[name] = p
+	}
+	return nil
+}
+
+// renderCommand renders the command and its arguments. The command is split
+// again after it is rendered, since a template can contain spaces.
+func renderCommand(field string, cmdWithArgs, command *string, args *[]string, data *TemplateData) (err error) {
+	if *cmdWithArgs != "" {
+		if !isTemplate(*cmdWithArgs) {
+			return nil
+		}
+		if *cmdWithArgs, err = renderTemplate(field+".command", *cmdWithArgs, data); err != nil {
+			return err
+		}
+		*command, *args = utils.SplitCommand(*cmdWithArgs, false)
+		return nil
+	}
+	if *command, err = renderTemplate(field+".command", *command, data); err != nil {
+		return err
+	}
+	for i, arg := range *args {
+		if (*args)[i], err = renderTemplate(fmt.Sprintf("%s.command[%d]", field, i+1), arg, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderEnv renders the values of the variables in the form of KEY=VALUE.
+// The named parameters are replaced with the rendered ones.
+func renderEnv(field string, vars []string, params map[string]string, data *TemplateData, setenv bool) error {
+	for i, kv := range vars {
+		if p, ok := params[kv]; ok {
+			vars[i] = p
+			continue
+		}
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || !isTemplate(v) {
+			continue
+		}
+		v, err := renderTemplate(fmt.Sprintf("%s.%s", field, k), v, data)
+		if err != nil {
+			return err
+		}
+		vars[i] = k + "=" + v
+		if setenv {
+			if err := os.Setenv(k, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func renderTemplate(field, s string, data *TemplateData) (string, error) {
+	if !isTemplate(s) {
+		return s, nil
+	}
+	t, err := template.New(field).Option("missingkey=error").Funcs(templateFuncs).Parse(s)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %s", errInvalidTemplate, field, err)
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("%w: %s: %s", errInvalidTemplate, field, err)
+	}
+	return b.String(), nil
+}
+
+// isTemplate reports whether the value contains a template that is rendered
+// when the DAG runs.
+func isTemplate(value string) bool {
+	return strings.Contains(value, "{{")
+}
+
+func parseParam(p string) (utils.Parameter, bool) {
+	parsed, err := utils.ParseParams(p, false)
+	if err != nil || len(parsed) != 1 {
+		return utils.Parameter{}, false
+	}
+	return parsed[0], true
+}
@@ -0,0 +1,87 @@
+package dag
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderTemplates(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "report.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+params: DATE={{ .LogicalDate | date "2006-01-02" }} {{ .Name | upper }}
+env:
+  - TEMPLATE_YESTERDAY: '{{ .LogicalDate | addDays -1 | date "20060102" }}'
+steps:
+  - name: "1"
+    command: echo {{ .Params.DATE }} "{{ .RequestId | trimPrefix "req-" }}"
+    platforms:
+      darwin:
+        command: echo {{ .Schedule }}
+  - name: "2"
+    command: [echo, '{{ env "TEMPLATE_YESTERDAY" }}', plain]
+handlerOn:
+  exit:
+    command: echo {{ .LogicalDate | unixEpoch }}
+`), 0600))
+	d, err := (&Loader{}).LoadForRun(file, "")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.Unsetenv("TEMPLATE_YESTERDAY")
+	}()
+
+	date := time.Date(2024, 3, 1, 4, 0, 0, 0, time.UTC)
+	data := &TemplateData{LogicalDate: date, RequestId: "req-1", Name: d.Name, Schedule: "0 4 * * *"}
+	require.NoError(t, d.RenderTemplates(data))
+
+	require.Equal(t, []string{`DATE="2024-03-01"`, `"REPORT"`}, d.Params)
+	require.Equal(t, map[string]string{"DATE": "2024-03-01"}, data.Params)
+	require.Equal(t, "2024-03-01", os.Getenv("DATE"))
+	require.Equal(t, "REPORT", os.Getenv("2"))
+	require.Equal(t, "20240229", os.Getenv("TEMPLATE_YESTERDAY"))
+	require.Contains(t, d.Steps[0].Variables, `DATE="2024-03-01"`)
+
+	s := d.Steps[0]
+	require.Equal(t, `echo 2024-03-01 "1"`, s.CmdWithArgs)
+	require.Equal(t, "echo", s.Command)
+	require.Equal(t, []string{"2024-03-01", "1"}, s.Args)
+	require.Equal(t, "echo 0 4 * * *", s.Platforms["darwin"].CmdWithArgs)
+	require.Equal(t, []string{"0", "4", "*", "*", "*"}, s.Platforms["darwin"].Args)
+	require.Equal(t, []string{"20240229", "plain"}, d.Steps[1].Args)
+	require.Equal(t, "echo 1709265600", d.HandlerOn.Exit.CmdWithArgs)
+}
+
+func TestRenderTemplatesErrors(t *testing.T) {
+	for _, spec := range []string{
+		"steps:\n  - name: \"1\"\n    command: echo {{ .Missing }}\n",
+		"steps:\n  - name: \"1\"\n    command: echo {{ .Name\n",
+		"params: X={{ .LogicalDate | dateModify \"soon\" }}\nsteps:\n  - name: \"1\"\n    command: echo\n",
+	} {
+		d, err := (&Loader{}).LoadData([]byte(spec))
+		require.NoError(t, err)
+		err = d.RenderTemplates(&TemplateData{LogicalDate: time.Now()})
+		require.ErrorIs(t, err, errInvalidTemplate, spec)
+	}
+}
+
+func TestRenderTypedParams(t *testing.T) {
+	d, err := (&Loader{}).LoadData([]byte(`
+params:
+  - name: DATE
+    type: date
+    default: '{{ .LogicalDate | date "2006-01-02" }}'
+  - name: COUNT
+    type: int
+    default: '{{ .Name }}'
+steps:
+  - name: "1"
+    command: echo $DATE
+`))
+	require.NoError(t, err)
+	err = d.RenderTemplates(&TemplateData{LogicalDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Name: "x"})
+	require.ErrorIs(t, err, errInvalidParam)
+	require.Equal(t, `DATE="2024-03-01"`, d.Params[0])
+}
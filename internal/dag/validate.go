@@ -0,0 +1,383 @@
+package dag
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/mitchellh/mapstructure"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Levels of the problems.
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+)
+
+// Problem is a problem in the definition of a DAG found by Validate.
+type Problem struct {
+	File string `json:"File"`
+	// Line is the line of the field, or 0 if it is not known, e.g. for the
+	// fields of an included file.
+	Line    int    `json:"Line"`
+	Field   string `json:"Field"`
+	Level   string `json:"Level"`
+	Message string `json:"Message"`
+	// Rule is the rule of Lint that found the problem.
+	Rule string `json:"Rule,omitempty"`
+}
+
+func (p Problem) String() string {
+	var b strings.Builder
+	b.WriteString(p.File)
+	if p.Line > 0 {
+		fmt.Fprintf(&b, ":%d", p.Line)
+	}
+	b.WriteString(": ")
+	if p.Field != "" {
+		b.WriteString(p.Field + ": ")
+	}
+	if p.Level == LevelWarning {
+		b.WriteString("warning: ")
+	}
+	b.WriteString(p.Message)
+	if p.Rule != "" {
+		fmt.Fprintf(&b, " (%s)", p.Rule)
+	}
+	return b.String()
+}
+
+var (
+	reYAMLLine      = regexp.MustCompile(`line (\d+)`)
+	reDecodeField   = regexp.MustCompile(`^'([^']*)' (.*)$`)
+	errUnknownStep  = errors.New("depends on an unknown step")
+	errDependsCycle = errors.New("dependency cycle")
+)
+
+// Validate checks the definition of the DAG in the file without running
+// it. Unlike Load, it reports all the problems it finds with the lines of
+// the fields: unknown fields, values of the wrong type, invalid cron
+// expressions and dependencies on unknown steps. The problems that are
+// found when the DAG is built are reported only if there are no others.
+// The error is returned only if the file cannot be read.
+func Validate(file string) ([]Problem, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return ValidateData(data, file), nil
+}
+
+// ValidateData checks the definition of the DAG in the data as Validate
+// does, e.g. the content of an editor before it is saved. The included
+// files are relative to the file, which does not need to exist.
+func ValidateData(data []byte, file string) []Problem {
+	v := &validator{file: file, lines: map[string]int{}}
+	v.validate(data)
+	sort.SliceStable(v.problems, func(i, j int) bool {
+		return v.problems[i].Line < v.problems[j].Line
+	})
+	return v.problems
+}
+
+type validator struct {
+	file     string
+	lines    map[string]int
+	problems []Problem
+}
+
+func (v *validator) validate(data []byte) {
+	var node yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &node); err != nil {
+		v.addLine(yamlErrorLine(err), "", err.Error())
+		return
+	}
+	v.indexLines("", &node)
+
+	fl := &fileLoader{}
+	raw, err := fl.unmarshalData(data)
+	if err != nil {
+		v.addLine(yamlErrorLine(err), "", err.Error())
+		return
+	}
+	raw, err = fl.resolveIncludes(v.file, raw)
+	if err != nil {
+		v.add("include", err.Error())
+		return
+	}
+
+	def := &configDefinition{}
+	md, _ := mapstructure.NewDecoder(&mapstructure.DecoderConfig{Result: def})
+	if err := md.Decode(raw); err != nil {
+		v.addDecodeError(err)
+	}
+
+	strict := config.Get().StrictMode
+	if def.Strict != nil {
+		strict = *def.Strict
+	}
+	c := &fieldChecker{strict: strict}
+	c.check("", raw, reflect.TypeOf(configDefinition{}))
+	for _, f := range c.unknown {
+		v.add(f, errUnknownField.Error())
+	}
+	for _, e := range c.errs {
+		f, _, _ := strings.Cut(e, " ")
+		v.add(f, fmt.Sprintf("%s: %s", errUnknownField, e))
+	}
+	for _, w := range c.warnings {
+		v.problems = append(v.problems, Problem{
+			File: v.file, Line: v.line(w.Field), Field: w.Field, Level: LevelWarning, Message: w.Message,
+		})
+	}
+
+	v.checkSchedule("schedule", raw["schedule"])
+	v.checkDepends(raw)
+
+	if v.hasErrors() {
+		return
+	}
+	b := &DAGBuilder{options: BuildDAGOptions{skipEnvEval: true, skipEnvSetup: true}}
+	if _, err := b.buildFromDefinition(def, nil); err != nil {
+		for _, e := range flattenErrors(err) {
+			v.add("", e.Error())
+		}
+	}
+}
+
+// addDecodeError adds the errors of the decoder, e.g.
+// 'Steps[0].RetryPolicy.Limit' expected type 'int', got 'string'.
+func (v *validator) addDecodeError(err error) {
+	var me *mapstructure.Error
+	if !errors.As(err, &me) {
+		v.add("", err.Error())
+		return
+	}
+	for _, e := range me.Errors {
+		m := reDecodeField.FindStringSubmatch(e)
+		if m == nil {
+			v.add("", e)
+			continue
+		}
+		// The unknown fields are reported by the field checker with
+		// their paths.
+		if strings.HasPrefix(m[2], "has invalid keys") {
+			continue
+		}
+		v.add(decodedPath(m[1]), m[2])
+	}
+}
+
+func (v *validator) checkSchedule(path string, s any) {
+	switch s := s.(type) {
+	case string:
+		if _, err := cronParser.Parse(s); err != nil {
+			v.add(path, fmt.Sprintf("%s %q: %s", errInvalidSchedule, s, err))
+		}
+	case []any:
+		for i, item := range s {
+			v.checkSchedule(fmt.Sprintf("%s[%d]", path, i), item)
+		}
+	case map[any]any:
+		for _, k := range []string{scheduleEntryCron, scheduleStart, scheduleStop, scheduleRestart} {
+			if item, ok := s[k]; ok {
+				v.checkSchedule(path+"."+k, item)
+			}
+		}
+	}
+}
+
+// checkDepends checks that the steps depend on the steps that exist, and
+// that the dependencies do not make a cycle. It reads the raw definition,
+// since the steps with values of the wrong type are not decoded. The steps
+// of a stage also depend on all the steps of the stages it depends on.
+func (v *validator) checkDepends(raw map[string]any) {
+	var paths, names []string
+	deps := make(map[string][]string)
+	addSteps := func(path string, items any) []string {
+		var added []string
+		list, _ := items.([]any)
+		for i, item := range list {
+			step, _ := rawMap(item)
+			name, _ := step["name"].(string)
+			paths = append(paths, fmt.Sprintf("%s[%d]", path, i))
+			names = append(names, name)
+			added = append(added, name)
+			for _, dep := range toList(step["depends"]) {
+				if dep, ok := dep.(string); ok {
+					deps[name] = append(deps[name], dep)
+				}
+			}
+		}
+		return added
+	}
+	addSteps("steps", raw["steps"])
+	stages, _ := raw["stages"].([]any)
+	stepsOf := make(map[string][]string)
+	stageNames := make([]string, len(stages))
+	for i, item := range stages {
+		stage, _ := rawMap(item)
+		stageNames[i], _ = stage["name"].(string)
+		stepsOf[stageNames[i]] = addSteps(fmt.Sprintf("stages[%d].steps", i), stage["steps"])
+	}
+	for i, path := range paths {
+		for j, dep := range deps[names[i]] {
+			if !slices.Contains(names, dep) {
+				v.add(fmt.Sprintf("%s.depends[%d]", path, j), fmt.Sprintf("%s: %s", errUnknownStep, dep))
+			}
+		}
+	}
+	for i, item := range stages {
+		stage, _ := rawMap(item)
+		name := stageNames[i]
+		for j, dep := range toList(stage["depends"]) {
+			dep, ok := dep.(string)
+			if !ok {
+				continue
+			}
+			upstream, ok := stepsOf[dep]
+			if !ok || dep == name {
+				v.add(fmt.Sprintf("stages[%d].depends[%d]", i, j), fmt.Sprintf("%s: %s", errUnknownStage, dep))
+				continue
+			}
+			for _, step := range stepsOf[name] {
+				deps[step] = append(deps[step], upstream...)
+			}
+		}
+	}
+
+	// The steps on the path of the search are visiting, and the steps
+	// whose dependencies are all searched are done.
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int)
+	var visit func(name string, path []string) []string
+	visit = func(name string, path []string) []string {
+		switch state[name] {
+		case visiting:
+			start := slices.Index(path, name)
+			return append(slices.Clone(path[start:]), name)
+		case done:
+			return nil
+		}
+		state[name] = visiting
+		for _, dep := range deps[name] {
+			if cycle := visit(dep, append(path, name)); cycle != nil {
+				return cycle
+			}
+		}
+		state[name] = done
+		return nil
+	}
+	for i, name := range names {
+		if cycle := visit(name, nil); cycle != nil {
+			v.add(paths[i]+".depends", fmt.Sprintf("%s: %s", errDependsCycle, strings.Join(cycle, " -> ")))
+			return
+		}
+	}
+}
+
+func (v *validator) add(field, msg string) {
+	v.problems = append(v.problems, Problem{
+		File: v.file, Line: v.line(field), Field: field, Level: LevelError, Message: msg,
+	})
+}
+
+func (v *validator) addLine(line int, field, msg string) {
+	v.problems = append(v.problems, Problem{
+		File: v.file, Line: line, Field: field, Level: LevelError, Message: msg,
+	})
+}
+
+func (v *validator) hasErrors() bool {
+	for _, p := range v.problems {
+		if p.Level == LevelError {
+			return true
+		}
+	}
+	return false
+}
+
+// line returns the line of the field, or of the closest parent of it that
+// is in the file.
+func (v *validator) line(field string) int {
+	for field != "" {
+		if l, ok := v.lines[strings.ToLower(field)]; ok {
+			return l
+		}
+		i := strings.LastIndexAny(field, ".[")
+		if i < 0 {
+			break
+		}
+		field = field[:i]
+	}
+	return 0
+}
+
+// indexLines records the lines of the fields by their paths, e.g.
+// steps[0].depends[1].
+func (v *validator) indexLines(path string, n *yamlv3.Node) {
+	switch n.Kind {
+	case yamlv3.DocumentNode:
+		for _, c := range n.Content {
+			v.indexLines(path, c)
+		}
+	case yamlv3.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, val := n.Content[i], n.Content[i+1]
+			p := joinPath(path, key.Value)
+			v.lines[strings.ToLower(p)] = key.Line
+			v.indexLines(p, val)
+		}
+	case yamlv3.SequenceNode:
+		for i, c := range n.Content {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			v.lines[strings.ToLower(p)] = c.Line
+			v.indexLines(p, c)
+		}
+	}
+}
+
+// decodedPath returns the documented path of a field in the errors of the
+// decoder, e.g. steps[0].retryPolicy.limit for Steps[0].RetryPolicy.Limit.
+func decodedPath(path string) string {
+	segs := strings.Split(path, ".")
+	for i, s := range segs {
+		if s != "" {
+			segs[i] = fieldKey(s)
+		}
+	}
+	return strings.Join(segs, ".")
+}
+
+func yamlErrorLine(err error) int {
+	m := reYAMLLine.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0
+	}
+	l, _ := strconv.Atoi(m[1])
+	return l
+}
+
+func flattenErrors(err error) []error {
+	var list *dagerrors.ErrorList
+	if !errors.As(err, &list) {
+		return []error{err}
+	}
+	var errs []error
+	for _, e := range list.Errors() {
+		errs = append(errs, flattenErrors(e)...)
+	}
+	return errs
+}
@@ -0,0 +1,78 @@
+package dag
+
+import (
+	"fmt"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	file := path.Join(testdataDir, "validate/invalid.yaml")
+	problems, err := Validate(file)
+	require.NoError(t, err)
+
+	var actual []string
+	for _, p := range problems {
+		require.Equal(t, file, p.File)
+		require.Equal(t, LevelError, p.Level)
+		actual = append(actual, p.String()[len(file):])
+	}
+	require.Equal(t, []string{
+		`:3: schedule[1]: invalid schedule "61 * * * *": end of range (61) above maximum (59): 61`,
+		`:5: stepz: unknown field`,
+		`:10: steps[0].retryPolicy.limit: expected type 'int', got unconvertible type 'string', value: 'many'`,
+		`:13: steps[1].unknownField: unknown field`,
+		`:16: steps[1].depends[1]: depends on an unknown step: transform`,
+	}, actual)
+}
+
+func TestValidateDAGs(t *testing.T) {
+	problems, err := Validate(path.Join(testdataDir, "validate/cycle.yaml"))
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	require.Equal(t, 4, problems[0].Line)
+	require.Equal(t, "dependency cycle: a -> c -> b -> a", problems[0].Message)
+
+	// A field in a different case is a warning, and the problems of
+	// building the DAG are reported if there are no others.
+	problems, err = Validate(path.Join(testdataDir, "validate/valid.yaml"))
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	require.Equal(t, LevelWarning, problems[0].Level)
+	require.Equal(t, 5, problems[0].Line)
+
+	problems, err = Validate(path.Join(testdataDir, "err_parse.yaml"))
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+
+	problems, err = Validate(path.Join(testdataDir, "validate/stages.yaml"))
+	require.NoError(t, err)
+	var messages []string
+	for _, p := range problems {
+		messages = append(messages, fmt.Sprintf("%d: %s: %s", p.Line, p.Field, p.Message))
+	}
+	require.Equal(t, []string{
+		"7: stages[1].depends[1]: unknown stage: transform",
+		"11: stages[1].steps[0].depends[0]: depends on an unknown step: cleanup",
+	}, messages)
+
+	_, err = Validate(path.Join(testdataDir, "not_existing_file.yaml"))
+	require.Error(t, err)
+}
+
+func TestValidateData(t *testing.T) {
+	file := path.Join(testdataDir, "validate/editing.yaml")
+	problems := ValidateData([]byte("steps:\n  - name: a\n    command: echo\n  - name: b\n    command: x: y\n"), file)
+	require.Len(t, problems, 1)
+	require.Equal(t, file, problems[0].File)
+	require.Equal(t, 5, problems[0].Line)
+
+	problems = ValidateData([]byte("steps:\n  - name: a\n    command: echo\n    depends: [b]\n"), file)
+	require.Len(t, problems, 1)
+	require.Equal(t, "steps[0].depends[0]", problems[0].Field)
+	require.Equal(t, 4, problems[0].Line)
+
+	require.Empty(t, ValidateData([]byte("steps:\n  - name: a\n    command: echo\n"), file))
+}
@@ -0,0 +1,155 @@
+// Package dispatch starts the runs of the triggers in the trigger queue,
+// the ones the server accepted while the scheduler was down or restarting
+// included.
+package dispatch
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/engine"
+	"github.com/dagu-dev/dagu/internal/logger"
+	"github.com/dagu-dev/dagu/internal/logger/tag"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+)
+
+const (
+	// batchSize is the number of the triggers claimed at a time.
+	batchSize = 100
+	// defaultLease is how long a claimed trigger is not claimed again, so
+	// that the triggers of a scheduler that stopped before it started their
+	// runs are started by the next one.
+	defaultLease = time.Minute
+)
+
+var errDAGRunning = errors.New("the DAG is running")
+
+// Config contains the configuration for a Dispatcher.
+type Config struct {
+	Queue         persistence.TriggerQueue
+	EngineFactory engine.Factory
+	// Interval is the interval at which Start reads the queue.
+	Interval time.Duration
+	// Lease is how long a claimed trigger is not claimed again, a minute by
+	// default.
+	Lease  time.Duration
+	Logger logger.Logger
+}
+
+// Dispatcher starts the runs of the triggers in the queue with the request
+// IDs the server returned for them. A started trigger stays claimed, and it
+// is removed when it is claimed again after the lease and its run is found
+// in the history, so that a run whose agent failed to start is started
+// again. A trigger of a DAG that is running stays in the queue until the
+// DAG finishes.
+type Dispatcher struct {
+	*Config
+}
+
+func New(cfg *Config) *Dispatcher {
+	return &Dispatcher{Config: cfg}
+}
+
+// Start reads the queue at once, for the triggers queued while the
+// scheduler was down, and then every interval in the background until done
+// is closed.
+func (d *Dispatcher) Start(done chan any) {
+	go func() {
+		ticker := time.NewTicker(d.Interval)
+		defer ticker.Stop()
+		for {
+			d.runAndLog()
+			select {
+			case <-ticker.C:
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+func (d *Dispatcher) runAndLog() {
+	rep, err := d.Run()
+	if err != nil {
+		d.Logger.Error("failed to read the trigger queue", tag.Error(err))
+		return
+	}
+	for _, t := range rep.Started {
+		d.Logger.Info("started the run of the trigger", "dag", t.DAG, "requestId", t.RequestId, "receivedAt", t.ReceivedAt)
+	}
+	for _, t := range rep.Dropped {
+		d.Logger.Error("dropped the trigger", "dag", t.DAG, "requestId", t.RequestId, "error", t.Error)
+	}
+}
+
+// Report is the result of a dispatch.
+type Report struct {
+	Started []*model.Trigger
+	// Pending are the triggers left in the queue, with the reasons their
+	// runs were not started.
+	Pending []*model.Trigger
+	// Dropped are the triggers removed without starting their runs, since
+	// their DAGs are not found or not valid anymore.
+	Dropped []*model.Trigger
+}
+
+// Run starts the runs of the triggers claimed from the queue, at most
+// batchSize of them, and returns the report.
+func (d *Dispatcher) Run() (*Report, error) {
+	lease := d.Lease
+	if lease == 0 {
+		lease = defaultLease
+	}
+	triggers, err := d.Queue.Claim(batchSize, lease)
+	if err != nil {
+		return nil, err
+	}
+	rep := &Report{}
+	e := d.EngineFactory.Create()
+	// The DAGs whose runs are started by the run, which are not running
+	// yet, so that a DAG triggered twice is started once at a time.
+	started := map[string]bool{}
+	for _, t := range triggers {
+		d.dispatch(rep, e, t, started)
+	}
+	return rep, nil
+}
+
+func (d *Dispatcher) dispatch(rep *Report, e engine.Engine, t *model.Trigger, started map[string]bool) {
+	status, err := e.GetStatus(t.DAG)
+	if err != nil {
+		t.Error = err.Error()
+		rep.Dropped = append(rep.Dropped, t)
+		d.done(t)
+		return
+	}
+	// The run was started by the claim before this one.
+	if _, err := e.GetStatusByRequestId(status.DAG, t.RequestId); err == nil {
+		d.done(t)
+		return
+	}
+	if status.Status.Status == scheduler.StatusRunning || started[status.DAG.Location] {
+		t.Error = errDAGRunning.Error()
+		rep.Pending = append(rep.Pending, t)
+		if err := d.Queue.Release(t.ID, errDAGRunning); err != nil {
+			d.Logger.Error("failed to release the trigger", "requestId", t.RequestId, tag.Error(err))
+		}
+		return
+	}
+	started[status.DAG.Location] = true
+	e.StartAsyncWithOptions(status.DAG, engine.RunOptions{
+		Params:    t.Params,
+		Initiator: t.Initiator,
+		Faults:    t.Faults,
+		RequestId: t.RequestId,
+	})
+	rep.Started = append(rep.Started, t)
+}
+
+func (d *Dispatcher) done(t *model.Trigger) {
+	if err := d.Queue.Done(t.ID); err != nil {
+		d.Logger.Error("failed to remove the trigger", "requestId", t.RequestId, tag.Error(err))
+	}
+}
@@ -0,0 +1,142 @@
+package dispatch
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/engine"
+	"github.com/dagu-dev/dagu/internal/logger"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatcher(t *testing.T) {
+	tmpDir := utils.MustTempDir("test-dispatch")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	_ = os.Setenv("HOME", tmpDir)
+	_ = config.LoadConfig()
+
+	// The agents write the history to the data directory of HOME.
+	cfg := &config.Config{
+		DAGs:         filepath.Join(tmpDir, ".dagu", "dags"),
+		DataDir:      filepath.Join(tmpDir, ".dagu", "data"),
+		Executable:   path.Join(utils.MustGetwd(), "../../bin/dagu"),
+		TriggerQueue: &config.TriggerQueue{Type: "sqlite"},
+	}
+	df := client.NewDataStoreFactory(cfg)
+	_, err := df.NewDAGStore().Create("etl", []byte("params: X=0\nsteps:\n  - name: step1\n    command: echo $X\n"))
+	require.NoError(t, err)
+
+	q := df.NewTriggerQueue()
+	require.NotNil(t, q)
+	for _, tr := range []*model.Trigger{
+		{DAG: "etl", RequestId: "first", Params: "X=1"},
+		{DAG: "missing", RequestId: "missing"},
+		// The DAG is started once at a time.
+		{DAG: "etl", RequestId: "second", Params: "X=2"},
+	} {
+		require.NoError(t, q.Enqueue(tr))
+	}
+
+	e := engine.NewFactory(df, cfg).Create()
+	d := New(&Config{Queue: q, EngineFactory: engine.NewFactory(df, cfg), Interval: time.Second, Logger: logger.NewSlogLogger()})
+	rep, err := d.Run()
+	require.NoError(t, err)
+	require.Len(t, rep.Started, 1)
+	require.Equal(t, "first", rep.Started[0].RequestId)
+	require.Len(t, rep.Dropped, 1)
+	require.Equal(t, "missing", rep.Dropped[0].RequestId)
+	require.Len(t, rep.Pending, 1)
+	require.Equal(t, "second", rep.Pending[0].RequestId)
+
+	status, err := e.GetStatus("etl")
+	require.NoError(t, err)
+	waitSuccess(t, e, status.DAG, "first")
+
+	// The second trigger stays in the queue until the agent of the first
+	// run exits.
+	require.Eventually(t, func() bool {
+		rep, err = d.Run()
+		require.NoError(t, err)
+		return len(rep.Started) == 1
+	}, time.Second*10, time.Millisecond*100)
+	require.Equal(t, "second", rep.Started[0].RequestId)
+	waitSuccess(t, e, status.DAG, "second")
+
+	st, err := e.GetStatusByRequestId(status.DAG, "second")
+	require.NoError(t, err)
+	require.Equal(t, `X="2"`, st.Params)
+
+	// The started triggers stay claimed until they are claimed again after
+	// the lease, and are removed then since their runs are in the history.
+	triggers, err := q.List()
+	require.NoError(t, err)
+	require.Len(t, triggers, 2)
+	d.Lease = time.Millisecond
+	time.Sleep(d.Lease)
+	rep, err = d.Run()
+	require.NoError(t, err)
+	require.Empty(t, rep.Started)
+	triggers, err = q.List()
+	require.NoError(t, err)
+	require.Empty(t, triggers)
+}
+
+func TestDispatcherRestart(t *testing.T) {
+	tmpDir := utils.MustTempDir("test-dispatch")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	_ = os.Setenv("HOME", tmpDir)
+	_ = config.LoadConfig()
+
+	cfg := &config.Config{
+		DAGs:         filepath.Join(tmpDir, ".dagu", "dags"),
+		DataDir:      filepath.Join(tmpDir, ".dagu", "data"),
+		Executable:   path.Join(tmpDir, "missing"),
+		TriggerQueue: &config.TriggerQueue{Type: "sqlite"},
+	}
+	df := client.NewDataStoreFactory(cfg)
+	_, err := df.NewDAGStore().Create("etl", []byte("steps:\n  - name: step1\n    command: echo 1\n"))
+	require.NoError(t, err)
+	q := df.NewTriggerQueue()
+	require.NoError(t, q.Enqueue(&model.Trigger{DAG: "etl", RequestId: "first"}))
+
+	// The agent of the run fails to start, and the trigger is started again
+	// once its lease expires.
+	d := New(&Config{Queue: q, EngineFactory: engine.NewFactory(df, cfg), Interval: time.Second, Lease: time.Millisecond * 100, Logger: logger.NewSlogLogger()})
+	rep, err := d.Run()
+	require.NoError(t, err)
+	require.Len(t, rep.Started, 1)
+	rep, err = d.Run()
+	require.NoError(t, err)
+	require.Empty(t, rep.Started)
+
+	cfg.Executable = path.Join(utils.MustGetwd(), "../../bin/dagu")
+	d.EngineFactory = engine.NewFactory(df, cfg)
+	time.Sleep(d.Lease)
+	rep, err = d.Run()
+	require.NoError(t, err)
+	require.Len(t, rep.Started, 1)
+	status, err := d.EngineFactory.Create().GetStatus("etl")
+	require.NoError(t, err)
+	waitSuccess(t, d.EngineFactory.Create(), status.DAG, "first")
+}
+
+func waitSuccess(t *testing.T, e engine.Engine, d *dag.DAG, requestId string) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		st, err := e.GetStatusByRequestId(d, requestId)
+		return err == nil && st.Status == scheduler.StatusSuccess
+	}, time.Second*10, time.Millisecond*100)
+}
@@ -0,0 +1,232 @@
+// Package drift compares the DAG definitions and the versions of this
+// instance with the ones of the other dagu instances, e.g. staging and
+// production, so that the definitions that silently diverged are found.
+//
+// The instances are compared by their inventories: the digests of the
+// files of their DAGs, which each instance serves on its API.
+package drift
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/klauspost/compress/zstd"
+)
+
+// InventoryPath is the path of the inventory of an instance relative to the
+// base URL of its API.
+const InventoryPath = "/drift/inventory"
+
+// The kinds of the differences of the DAGs of a remote instance.
+const (
+	// KindChanged is a DAG whose definition differs on the remote.
+	KindChanged = "changed"
+	// KindMissing is a DAG of this instance that the remote does not have.
+	KindMissing = "missing"
+	// KindExtra is a DAG of the remote that this instance does not have.
+	KindExtra = "extra"
+)
+
+var (
+	errUnexpectedStatus   = errors.New("unexpected status of the inventory request")
+	errUnexpectedEncoding = errors.New("unexpected content encoding of the inventory")
+)
+
+// acceptEncoding are the compressions of the responses of the remote
+// instances, which are requested to save the transfer over the WAN.
+const acceptEncoding = "zstd, gzip"
+
+const fetchTimeout = time.Second * 10
+
+// Inventory is the version of an instance and the digests of the files of
+// its DAGs.
+type Inventory struct {
+	Version     string        `json:"Version"`
+	Definitions []*Definition `json:"Definitions"`
+}
+
+// Definition is the digest of the file of a DAG.
+type Definition struct {
+	Name string `json:"Name"`
+	// File is the name of the file in the DAG directory.
+	File string `json:"File"`
+	// Digest is the SHA-256 of the contents of the file in hex.
+	Digest string `json:"Digest"`
+}
+
+// Collect returns the inventory of the DAGs of the store, sorted by file.
+// The DAGs that cannot be read are returned as the errors.
+func Collect(ds persistence.DAGStore, version string) (*Inventory, []string, error) {
+	dags, errs, err := ds.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	inv := &Inventory{Version: version, Definitions: []*Definition{}}
+	for _, d := range dags {
+		spec, err := ds.GetSpec(d.Location)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		sum := sha256.Sum256([]byte(spec))
+		inv.Definitions = append(inv.Definitions, &Definition{
+			Name:   d.Name,
+			File:   filepath.Base(d.Location),
+			Digest: hex.EncodeToString(sum[:]),
+		})
+	}
+	sort.Slice(inv.Definitions, func(i, j int) bool {
+		return inv.Definitions[i].File < inv.Definitions[j].File
+	})
+	return inv, errs, nil
+}
+
+// Fetch requests the inventory of the remote instance.
+func Fetch(ctx context.Context, node config.RemoteNode) (*Inventory, error) {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+	url := strings.TrimSuffix(node.APIBaseURL, "/") + InventoryPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if node.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+node.AuthToken)
+	}
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s", errUnexpectedStatus, resp.Status)
+	}
+	body, err := decodeBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = body.Close()
+	}()
+	inv := &Inventory{}
+	if err := json.NewDecoder(body).Decode(inv); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// decodeBody returns the reader of the body of the response, decompressed
+// according to its content encoding. Closing it does not close the body.
+func decodeBody(resp *http.Response) (io.ReadCloser, error) {
+	switch enc := resp.Header.Get("Content-Encoding"); enc {
+	case "":
+		return io.NopCloser(resp.Body), nil
+	case "gzip":
+		return gzip.NewReader(resp.Body)
+	case "zstd":
+		zr, err := zstd.NewReader(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnexpectedEncoding, enc)
+	}
+}
+
+// Report is the result of a comparison of this instance with the remote
+// instances.
+type Report struct {
+	Version   string        `json:"version"`
+	CheckedAt time.Time     `json:"checkedAt"`
+	Nodes     []*NodeReport `json:"nodes"`
+}
+
+// NodeReport is the comparison with a remote instance.
+type NodeReport struct {
+	Name    string `json:"name"`
+	URL     string `json:"url"`
+	Version string `json:"version"`
+	// Error is why the remote could not be compared, e.g. it is down.
+	Error       string        `json:"error,omitempty"`
+	Differences []*Difference `json:"differences"`
+}
+
+// InSync returns true if the remote was compared and it has the same
+// version and definitions.
+func (r *NodeReport) InSync(version string) bool {
+	return r.Error == "" && r.Version == version && len(r.Differences) == 0
+}
+
+// Difference is a DAG that differs between the instances.
+type Difference struct {
+	File         string `json:"file"`
+	Kind         string `json:"kind"`
+	LocalDigest  string `json:"localDigest,omitempty"`
+	RemoteDigest string `json:"remoteDigest,omitempty"`
+}
+
+// Check compares the inventory of this instance with the ones of the remote
+// instances.
+func Check(ctx context.Context, local *Inventory, nodes []config.RemoteNode) *Report {
+	r := &Report{
+		Version:   local.Version,
+		CheckedAt: time.Now(),
+		Nodes:     []*NodeReport{},
+	}
+	for _, node := range nodes {
+		nr := &NodeReport{Name: node.Name, URL: node.APIBaseURL, Differences: []*Difference{}}
+		remote, err := Fetch(ctx, node)
+		if err != nil {
+			nr.Error = err.Error()
+		} else {
+			nr.Version = remote.Version
+			nr.Differences = Compare(local, remote)
+		}
+		r.Nodes = append(r.Nodes, nr)
+	}
+	return r
+}
+
+// Compare returns the differences of the DAGs of the remote inventory from
+// the local one, sorted by file. The DAGs are matched by their files.
+func Compare(local, remote *Inventory) []*Difference {
+	digests := map[string]string{}
+	for _, d := range remote.Definitions {
+		digests[d.File] = d.Digest
+	}
+	diffs := []*Difference{}
+	for _, d := range local.Definitions {
+		digest, ok := digests[d.File]
+		delete(digests, d.File)
+		switch {
+		case !ok:
+			diffs = append(diffs, &Difference{File: d.File, Kind: KindMissing, LocalDigest: d.Digest})
+		case digest != d.Digest:
+			diffs = append(diffs, &Difference{File: d.File, Kind: KindChanged, LocalDigest: d.Digest, RemoteDigest: digest})
+		}
+	}
+	for file, digest := range digests {
+		diffs = append(diffs, &Difference{File: file, Kind: KindExtra, RemoteDigest: digest})
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].File < diffs[j].File
+	})
+	return diffs
+}
@@ -0,0 +1,99 @@
+package drift
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	tmpDir := t.TempDir()
+	df := client.NewDataStoreFactory(&config.Config{
+		DAGs:    filepath.Join(tmpDir, "dags"),
+		DataDir: filepath.Join(tmpDir, "data"),
+	})
+	ds := df.NewDAGStore()
+	for _, name := range []string{"etl", "report", "same"} {
+		_, err := ds.Create(name, []byte("steps:\n  - name: step1\n    command: echo "+name+"\n"))
+		require.NoError(t, err)
+	}
+	local, errs, err := Collect(ds, "1.2.0")
+	require.NoError(t, err)
+	require.Empty(t, errs)
+	require.Len(t, local.Definitions, 3)
+	require.Equal(t, "etl.yaml", local.Definitions[0].File)
+
+	remote := &Inventory{Version: "1.1.0", Definitions: []*Definition{
+		{Name: "etl", File: "etl.yaml", Digest: "changed"},
+		{Name: "same", File: "same.yaml", Digest: local.Definitions[2].Digest},
+		{Name: "cleanup", File: "cleanup.yaml", Digest: "extra"},
+	}}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1"+InventoryPath || r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(remote)
+	}))
+	defer srv.Close()
+
+	r := Check(context.Background(), local, []config.RemoteNode{
+		{Name: "staging", APIBaseURL: srv.URL + "/api/v1/", AuthToken: "secret"},
+		{Name: "unauthorized", APIBaseURL: srv.URL + "/api/v1"},
+	})
+	require.Len(t, r.Nodes, 2)
+	staging := r.Nodes[0]
+	require.Empty(t, staging.Error)
+	require.Equal(t, "1.1.0", staging.Version)
+	require.Equal(t, []*Difference{
+		{File: "cleanup.yaml", Kind: KindExtra, RemoteDigest: "extra"},
+		{File: "etl.yaml", Kind: KindChanged, LocalDigest: local.Definitions[0].Digest, RemoteDigest: "changed"},
+		{File: "report.yaml", Kind: KindMissing, LocalDigest: local.Definitions[1].Digest},
+	}, staging.Differences)
+	require.False(t, staging.InSync(r.Version))
+	require.Contains(t, r.Nodes[1].Error, "401")
+	require.False(t, r.Nodes[1].InSync(r.Version))
+
+	// The instances with the same version and definitions are in sync.
+	remote = local
+	r = Check(context.Background(), local, []config.RemoteNode{{Name: "staging", APIBaseURL: srv.URL + "/api/v1", AuthToken: "secret"}})
+	require.Empty(t, r.Nodes[0].Differences)
+	require.True(t, r.Nodes[0].InSync(r.Version))
+}
+
+func TestFetchCompressed(t *testing.T) {
+	remote := &Inventory{Version: "1.1.0", Definitions: []*Definition{
+		{Name: "etl", File: "etl.yaml", Digest: "digest"},
+	}}
+	for _, enc := range []string{"zstd", "gzip"} {
+		t.Run(enc, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Contains(t, r.Header.Get("Accept-Encoding"), enc)
+				w.Header().Set("Content-Encoding", enc)
+				var zw io.WriteCloser
+				if enc == "zstd" {
+					zw, _ = zstd.NewWriter(w)
+				} else {
+					zw = gzip.NewWriter(w)
+				}
+				_ = json.NewEncoder(zw).Encode(remote)
+				_ = zw.Close()
+			}))
+			defer srv.Close()
+
+			inv, err := Fetch(context.Background(), config.RemoteNode{Name: "staging", APIBaseURL: srv.URL})
+			require.NoError(t, err)
+			require.Equal(t, remote, inv)
+		})
+	}
+}
@@ -3,11 +3,16 @@ package engine
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"syscall"
+	"time"
 
+	"github.com/dagu-dev/dagu/internal/config"
 	"github.com/dagu-dev/dagu/internal/dag"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
 	"github.com/dagu-dev/dagu/internal/persistence"
 	"github.com/dagu-dev/dagu/internal/persistence/model"
 	"github.com/dagu-dev/dagu/internal/scheduler"
@@ -18,30 +23,103 @@ import (
 type Engine interface {
 	CreateDAG(name string) (string, error)
 	GetDAGSpec(id string) (string, error)
-	Grep(pattern string) ([]*persistence.GrepResult, []string, error)
+	// Rename renames the DAG, or moves it to the folder of the new name,
+	// with its history.
 	Rename(oldDAGPath, newDAGPath string) error
+	// Folders returns the folders of the DAGs directory, e.g. team-a/etl.
+	Folders() ([]string, error)
+	CreateFolder(folder string) error
+	// DeleteFolder deletes the folder, which must be empty.
+	DeleteFolder(folder string) error
 	Stop(d *dag.DAG) error
 	StartAsync(d *dag.DAG, params string)
+	StartAsyncWithOptions(d *dag.DAG, opts RunOptions)
 	Start(d *dag.DAG, params string) error
 	Restart(d *dag.DAG) error
+	StartWithOptions(d *dag.DAG, opts RunOptions) error
+	StopWithOptions(d *dag.DAG, opts RunOptions) error
+	RestartWithOptions(d *dag.DAG, opts RunOptions) error
 	Retry(d *dag.DAG, reqId string) error
 	GetCurrentStatus(d *dag.DAG) (*model.Status, error)
 	GetStatusByRequestId(d *dag.DAG, requestId string) (*model.Status, error)
 	GetLatestStatus(d *dag.DAG) (*model.Status, error)
 	GetRecentHistory(d *dag.DAG, n int) []*model.StatusFile
 	UpdateStatus(d *dag.DAG, status *model.Status) error
+	// MarkStep marks a running step of the running DAG as succeeded or
+	// failed with the reason of the operator.
+	MarkStep(d *dag.DAG, step string, status scheduler.NodeStatus, reason string) error
+	// RestartSteps runs the running steps of the running DAG again in the
+	// same run, and keeps its other steps running.
+	RestartSteps(d *dag.DAG, steps []string) error
 	UpdateDAG(id string, spec string) error
 	DeleteDAG(name, loc string) error
 	GetAllStatus() (statuses []*persistence.DAGStatus, errs []string, err error)
 	GetStatus(dagLocation string) (*persistence.DAGStatus, error)
 	IsSuspended(id string) bool
 	ToggleSuspend(id string, suspend bool) error
+	// StopAll halts the schedules of all the DAGs and stops the running
+	// DAGs, e.g. during an incident, and returns the names of the DAGs it
+	// stopped. The schedules stay halted until SetHalted resumes them.
+	StopAll() ([]string, error)
+	IsHalted() bool
+	SetHalted(halted bool) error
+}
+
+// RunOptions are the options of a start, stop or restart triggered by a
+// schedule entry.
+type RunOptions struct {
+	// Params overrides the parameters of the run.
+	Params string
+	// Steps limits the action to the given steps.
+	Steps []string
+	// Schedule is the cron expression of the schedule entry.
+	Schedule string
+	// LogicalDate is the time the run is scheduled at. It is only set for
+	// the start command.
+	LogicalDate time.Time
+	// Initiator is who started the run through the API.
+	Initiator *model.Initiator
+	// Faults are the specs of the faults injected into the steps of the
+	// run. They are only set for the start command.
+	Faults []string
+	// RequestId is the request ID of the run, so that the caller finds its
+	// status. It is only set for the start command.
+	RequestId string
+}
+
+func (o RunOptions) args() []string {
+	var args []string
+	if o.Params != "" {
+		args = append(args, "-p")
+		args = append(args, fmt.Sprintf(`"%s"`, utils.EscapeArg(o.Params, false)))
+	}
+	for _, s := range o.Steps {
+		args = append(args, fmt.Sprintf("--step=%s", s))
+	}
+	if o.Schedule != "" {
+		args = append(args, fmt.Sprintf("--schedule=%s", o.Schedule))
+	}
+	if !o.LogicalDate.IsZero() {
+		args = append(args, fmt.Sprintf("--logical-date=%s", o.LogicalDate.Format(time.RFC3339)))
+	}
+	if o.Initiator != nil {
+		args = append(args, fmt.Sprintf("--initiator-token=%s", o.Initiator.Token))
+		args = append(args, fmt.Sprintf("--initiator-user=%s", o.Initiator.User))
+	}
+	for _, f := range o.Faults {
+		args = append(args, fmt.Sprintf("--chaos=%s", f))
+	}
+	if o.RequestId != "" {
+		args = append(args, fmt.Sprintf("--request-id=%s", o.RequestId))
+	}
+	return args
 }
 
 type engineImpl struct {
 	dataStoreFactory persistence.DataStoreFactory
 	executable       string
 	workDir          string
+	namespace        string
 }
 
 var (
@@ -55,7 +133,8 @@ var (
 	errCreateDAGFile = errors.New("failed to create DAG file")
 	errRenameDAG     = errors.New("failed to rename DAG")
 	errGetStatus     = errors.New("failed to get status")
-	errDAGIsRunning  = errors.New("the DAG is running")
+	errDAGIsRunning  = dagerrors.New(dagerrors.CodeDAGRunning, "the DAG is running")
+	errInvalidMark   = errors.New("a step can only be marked as success or failed")
 )
 
 func (e *engineImpl) GetDAGSpec(id string) (string, error) {
@@ -72,15 +151,16 @@ func (e *engineImpl) CreateDAG(name string) (string, error) {
 	return id, nil
 }
 
-func (e *engineImpl) Grep(pattern string) ([]*persistence.GrepResult, []string, error) {
-	ds := e.dataStoreFactory.NewDAGStore()
-	return ds.Grep(pattern)
-}
-
 func (e *engineImpl) Rename(oldName, newName string) error {
 	ds := e.dataStoreFactory.NewDAGStore()
+	// The history of a running DAG would be written to the old name.
+	if d, err := ds.GetMetadata(oldName); err == nil {
+		if status, err := e.GetCurrentStatus(d); err == nil && status.Status == scheduler.StatusRunning {
+			return errDAGIsRunning
+		}
+	}
 	if err := ds.Rename(oldName, newName); err != nil {
-		return fmt.Errorf("%w: %s", errRenameDAG, err)
+		return fmt.Errorf("%s: %w", errRenameDAG, err)
 	}
 	hs := e.dataStoreFactory.NewHistoryStore()
 	if err := hs.Rename(oldName, newName); err != nil {
@@ -89,31 +169,63 @@ func (e *engineImpl) Rename(oldName, newName string) error {
 	return nil
 }
 
+func (e *engineImpl) Folders() ([]string, error) {
+	return e.dataStoreFactory.NewDAGStore().Folders()
+}
+
+func (e *engineImpl) CreateFolder(folder string) error {
+	return e.dataStoreFactory.NewDAGStore().CreateFolder(folder)
+}
+
+func (e *engineImpl) DeleteFolder(folder string) error {
+	return e.dataStoreFactory.NewDAGStore().DeleteFolder(folder)
+}
+
 func (e *engineImpl) Stop(d *dag.DAG) error {
+	return e.StopWithOptions(d, RunOptions{})
+}
+
+func (e *engineImpl) StopWithOptions(d *dag.DAG, opts RunOptions) error {
+	query := url.Values{}
+	for _, s := range opts.Steps {
+		query.Add("step", s)
+	}
+	if opts.Schedule != "" {
+		query.Set("schedule", opts.Schedule)
+	}
+	path := "/stop"
+	if len(query) > 0 {
+		path = fmt.Sprintf("%s?%s", path, query.Encode())
+	}
 	// TODO: fix this not to connect to the DAG directly
 	client := sock.Client{Addr: d.SockAddr()}
-	_, err := client.Request("POST", "/stop")
+	_, err := client.Request("POST", path)
 	return err
 }
 
 func (e *engineImpl) StartAsync(d *dag.DAG, params string) {
+	e.StartAsyncWithOptions(d, RunOptions{Params: params})
+}
+
+func (e *engineImpl) StartAsyncWithOptions(d *dag.DAG, opts RunOptions) {
 	go func() {
-		err := e.Start(d, params)
+		err := e.StartWithOptions(d, opts)
 		utils.LogErr("starting a DAG", err)
 	}()
 }
 
 func (e *engineImpl) Start(d *dag.DAG, params string) error {
+	return e.StartWithOptions(d, RunOptions{Params: params})
+}
+
+func (e *engineImpl) StartWithOptions(d *dag.DAG, opts RunOptions) error {
 	args := []string{"start"}
-	if params != "" {
-		args = append(args, "-p")
-		args = append(args, fmt.Sprintf(`"%s"`, utils.EscapeArg(params, false)))
-	}
+	args = append(args, opts.args()...)
 	args = append(args, d.Location)
 	cmd := exec.Command(e.executable, args...)
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true, Pgid: 0}
 	cmd.Dir = e.workDir
-	cmd.Env = os.Environ()
+	cmd.Env = e.environ()
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -124,12 +236,24 @@ func (e *engineImpl) Start(d *dag.DAG, params string) error {
 	return cmd.Wait()
 }
 
+// environ returns the environment of the commands, which run in the
+// namespace of the engine.
+func (e *engineImpl) environ() []string {
+	return append(os.Environ(), config.NamespaceEnv+"="+e.namespace)
+}
+
 func (e *engineImpl) Restart(d *dag.DAG) error {
-	args := []string{"restart", d.Location}
+	return e.RestartWithOptions(d, RunOptions{})
+}
+
+func (e *engineImpl) RestartWithOptions(d *dag.DAG, opts RunOptions) error {
+	args := []string{"restart"}
+	args = append(args, opts.args()...)
+	args = append(args, d.Location)
 	cmd := exec.Command(e.executable, args...)
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true, Pgid: 0}
 	cmd.Dir = e.workDir
-	cmd.Env = os.Environ()
+	cmd.Env = e.environ()
 	err := cmd.Start()
 	if err != nil {
 		return err
@@ -144,7 +268,7 @@ func (e *engineImpl) Retry(d *dag.DAG, reqId string) error {
 	cmd := exec.Command(e.executable, args...)
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true, Pgid: 0}
 	cmd.Dir = e.workDir
-	cmd.Env = os.Environ()
+	cmd.Env = e.environ()
 	err := cmd.Start()
 	if err != nil {
 		return err
@@ -223,6 +347,48 @@ func (e *engineImpl) UpdateStatus(d *dag.DAG, status *model.Status) error {
 	return e.dataStoreFactory.NewHistoryStore().Update(d.Location, status.RequestId, status)
 }
 
+func (e *engineImpl) MarkStep(d *dag.DAG, step string, status scheduler.NodeStatus, reason string) error {
+	query := url.Values{"step": {step}, "reason": {reason}}
+	switch status {
+	case scheduler.NodeStatusSuccess:
+		query.Set("status", "success")
+	case scheduler.NodeStatusError:
+		query.Set("status", "failed")
+	default:
+		return dagerrors.WithCode(dagerrors.CodeInvalidArgument, fmt.Errorf("%w: %s", errInvalidMark, status))
+	}
+	client := sock.Client{Addr: d.SockAddr()}
+	_, err := client.Request("POST", "/mark?"+query.Encode())
+	return stepRequestError(err)
+}
+
+func (e *engineImpl) RestartSteps(d *dag.DAG, steps []string) error {
+	query := url.Values{"step": steps}
+	client := sock.Client{Addr: d.SockAddr()}
+	_, err := client.Request("POST", "/restart?"+query.Encode())
+	return stepRequestError(err)
+}
+
+// stepRequestError returns the error of a request about the steps of the
+// running DAG to its agent with the code of the response.
+func stepRequestError(err error) error {
+	var re *sock.ResponseError
+	switch {
+	case err == nil:
+		return nil
+	case !errors.As(err, &re):
+		return dagerrors.WithCode(dagerrors.CodeDAGNotRunning, err)
+	case re.StatusCode == http.StatusBadRequest:
+		return dagerrors.WithCode(dagerrors.CodeInvalidArgument, err)
+	case re.StatusCode == http.StatusNotFound:
+		return dagerrors.WithCode(dagerrors.CodeNotFound, err)
+	case re.StatusCode == http.StatusConflict:
+		return dagerrors.WithCode(dagerrors.CodeStepNotRunning, err)
+	default:
+		return err
+	}
+}
+
 func (e *engineImpl) UpdateDAG(id string, spec string) error {
 	ds := e.dataStoreFactory.NewDAGStore()
 	return ds.UpdateSpec(id, []byte(spec))
@@ -247,6 +413,7 @@ func (e *engineImpl) GetAllStatus() (statuses []*persistence.DAGStatus, errs []s
 		if err != nil {
 			errs = append(errs, err.Error())
 		}
+		status.Folder = ds.FolderOf(d.Location)
 		ret = append(ret, status)
 	}
 
@@ -274,7 +441,9 @@ func (e *engineImpl) GetStatus(id string) (*persistence.DAGStatus, error) {
 		_, err = scheduler.NewExecutionGraph(d.Steps...)
 	}
 	status, _ := e.GetLatestStatus(d)
-	return persistence.NewDAGStatus(d, status, e.IsSuspended(d.Name), err), err
+	ret := persistence.NewDAGStatus(d, status, e.IsSuspended(d.Name), err)
+	ret.Folder = e.dataStoreFactory.NewDAGStore().FolderOf(d.Location)
+	return ret, err
 }
 
 func (e *engineImpl) ToggleSuspend(id string, suspend bool) error {
@@ -298,3 +467,66 @@ func (e *engineImpl) IsSuspended(id string) bool {
 	fs := e.dataStoreFactory.NewFlagStore()
 	return fs.IsSuspended(id)
 }
+
+func (e *engineImpl) StopAll() ([]string, error) {
+	// The schedules are halted first, so that no run starts while the
+	// running ones are stopped.
+	if err := e.SetHalted(true); err != nil {
+		return nil, err
+	}
+	statuses, _, err := e.GetAllStatus()
+	if err != nil {
+		return nil, err
+	}
+	var stopped []string
+	var errs []error
+	loaded := map[string]bool{}
+	for _, s := range statuses {
+		loaded[s.DAG.Location] = true
+		if s.Status == nil || s.Status.Status != scheduler.StatusRunning {
+			continue
+		}
+		if err := e.Stop(s.DAG); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.DAG.Name, err))
+			continue
+		}
+		stopped = append(stopped, s.DAG.Name)
+	}
+	// The DAGs whose files fail to load, e.g. edited during the run, are
+	// stopped through their sockets, which are addressed by their files.
+	files, err := e.dataStoreFactory.NewDAGStore().Files()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, file := range files {
+		if loaded[file] {
+			continue
+		}
+		d := &dag.DAG{Location: file}
+		status, err := e.GetCurrentStatus(d)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", file, err))
+			continue
+		}
+		if status.Status != scheduler.StatusRunning {
+			continue
+		}
+		if d.Name = status.Name; d.Name == "" {
+			d.Name = file
+		}
+		if err := e.Stop(d); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", d.Name, err))
+			continue
+		}
+		stopped = append(stopped, d.Name)
+	}
+	return stopped, errors.Join(errs...)
+}
+
+func (e *engineImpl) IsHalted() bool {
+	return e.dataStoreFactory.NewFlagStore().IsHalted()
+}
+
+func (e *engineImpl) SetHalted(halted bool) error {
+	return e.dataStoreFactory.NewFlagStore().SetHalted(halted)
+}
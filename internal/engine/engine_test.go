@@ -14,6 +14,7 @@ import (
 	"github.com/dagu-dev/dagu/internal/config"
 	"github.com/dagu-dev/dagu/internal/dag"
 	"github.com/dagu-dev/dagu/internal/engine"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
 	"github.com/dagu-dev/dagu/internal/persistence/model"
 	"github.com/dagu-dev/dagu/internal/scheduler"
 	"github.com/dagu-dev/dagu/internal/sock"
@@ -191,6 +192,26 @@ func TestStart(t *testing.T) {
 	require.Equal(t, scheduler.StatusError, status.Status)
 }
 
+func TestStartWithRequestId(t *testing.T) {
+	tmpDir, e, _ := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	file := testDAG("start.yaml")
+
+	d, err := e.GetStatus(file)
+	require.NoError(t, err)
+
+	// The caller finds the status of the run by the request ID it chose.
+	err = e.StartWithOptions(d.DAG, engine.RunOptions{RequestId: "req-1"})
+	require.Error(t, err)
+
+	status, err := e.GetStatusByRequestId(d.DAG, "req-1")
+	require.NoError(t, err)
+	require.Equal(t, "req-1", status.RequestId)
+	require.Equal(t, scheduler.StatusError, status.Status)
+}
+
 func TestStop(t *testing.T) {
 	tmpDir, e, _ := setupTest(t)
 	defer func() {
@@ -217,6 +238,73 @@ func TestStop(t *testing.T) {
 	}, time.Millisecond*1500, time.Millisecond*100)
 }
 
+func TestStopAll(t *testing.T) {
+	tmpDir, e, _ := setupTestTmpDir(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	id, err := e.CreateDAG("edited")
+	require.NoError(t, err)
+	require.NoError(t, e.UpdateDAG(id, "steps:\n  - name: \"1\"\n    command: sleep 10\n"))
+	d, err := e.GetStatus(id)
+	require.NoError(t, err)
+	e.StartAsync(d.DAG, "")
+	require.Eventually(t, func() bool {
+		st, _ := e.GetCurrentStatus(d.DAG)
+		return st.Status == scheduler.StatusRunning
+	}, time.Second*3, time.Millisecond*100)
+
+	// The run of the DAG whose file fails to load is stopped too.
+	require.NoError(t, os.WriteFile(d.DAG.Location, []byte("steps: [\n"), 0600))
+	stopped, err := e.StopAll()
+	require.NoError(t, err)
+	require.Equal(t, []string{"edited"}, stopped)
+	require.True(t, e.IsHalted())
+	require.Eventually(t, func() bool {
+		st, _ := e.GetLatestStatus(d.DAG)
+		return st.Status == scheduler.StatusCancel
+	}, time.Second*3, time.Millisecond*100)
+	require.NoError(t, e.SetHalted(false))
+}
+
+func TestMarkStep(t *testing.T) {
+	tmpDir, e, _ := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	file := testDAG("stop.yaml")
+
+	d, err := e.GetStatus(file)
+	require.NoError(t, err)
+
+	err = e.MarkStep(d.DAG, "1", scheduler.NodeStatusSuccess, "done")
+	require.Equal(t, dagerrors.CodeDAGNotRunning, dagerrors.CodeOf(err))
+
+	e.StartAsync(d.DAG, "")
+
+	require.Eventually(t, func() bool {
+		st, _ := e.GetCurrentStatus(d.DAG)
+		return st.Status == scheduler.StatusRunning
+	}, time.Millisecond*1500, time.Millisecond*100)
+
+	err = e.MarkStep(d.DAG, "1", scheduler.NodeStatusCancel, "done")
+	require.Equal(t, dagerrors.CodeInvalidArgument, dagerrors.CodeOf(err))
+	err = e.MarkStep(d.DAG, "2", scheduler.NodeStatusSuccess, "done")
+	require.Equal(t, dagerrors.CodeNotFound, dagerrors.CodeOf(err))
+	require.NoError(t, e.MarkStep(d.DAG, "1", scheduler.NodeStatusSuccess, "done"))
+
+	require.Eventually(t, func() bool {
+		st, _ := e.GetLatestStatus(d.DAG)
+		return st.Status == scheduler.StatusSuccess
+	}, time.Millisecond*1500, time.Millisecond*100)
+
+	st, err := e.GetLatestStatus(d.DAG)
+	require.NoError(t, err)
+	require.Equal(t, "done", st.Nodes[0].MarkedReason)
+}
+
 func TestRestart(t *testing.T) {
 	tmpDir, e, _ := setupTest(t)
 	defer func() {
@@ -377,6 +465,36 @@ func TestRenameDAG(t *testing.T) {
 	require.FileExists(t, loc2)
 }
 
+func TestMoveDAG(t *testing.T) {
+	tmpDir, e, ds := setupTestTmpDir(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	id, err := e.CreateDAG("etl")
+	require.NoError(t, err)
+	d, err := e.GetStatus(id)
+	require.NoError(t, err)
+	require.Equal(t, "", d.Folder)
+
+	hs := ds.NewHistoryStore()
+	require.NoError(t, hs.Open(d.DAG.Location, time.Now(), "test-move"))
+	require.NoError(t, hs.Write(testNewStatus(d.DAG, "test-move", scheduler.StatusSuccess, scheduler.NodeStatusSuccess)))
+	require.NoError(t, hs.Close())
+
+	// The history follows the DAG to its folder.
+	require.NoError(t, e.Rename(id, "team-a/etl"))
+	d, err = e.GetStatus("team-a/etl")
+	require.NoError(t, err)
+	require.Equal(t, "team-a", d.Folder)
+	require.Equal(t, path.Join(tmpDir, ".dagu", "dags", "team-a", "etl.yaml"), d.DAG.Location)
+	require.Len(t, e.GetRecentHistory(d.DAG, 10), 1)
+
+	folders, err := e.Folders()
+	require.NoError(t, err)
+	require.Equal(t, []string{"team-a"}, folders)
+}
+
 func TestLoadConfig(t *testing.T) {
 	tmpDir, e, _ := setupTest(t)
 	defer func() {
@@ -13,12 +13,14 @@ type factoryImpl struct {
 	dataStoreFactory persistence.DataStoreFactory
 	executable       string
 	workDir          string
+	namespace        string
 }
 
 func NewFactory(ds persistence.DataStoreFactory, cfg *config.Config) Factory {
 	impl := &factoryImpl{
 		dataStoreFactory: ds,
 		executable:       cfg.Executable,
+		namespace:        cfg.Namespace,
 	}
 	return impl
 }
@@ -28,5 +30,6 @@ func (f *factoryImpl) Create() Engine {
 		dataStoreFactory: f.dataStoreFactory,
 		executable:       f.executable,
 		workDir:          f.workDir,
+		namespace:        f.namespace,
 	}
 }
@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/constants"
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/logfile"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+)
+
+// FollowStepLog writes the log of the step of the run to w from the offset,
+// like tail -f, until the step finished and its log was written, or the
+// context is done. It waits for the log while the step has not started, and
+// returns the step, or nil if the run finished without it.
+func FollowStepLog(ctx context.Context, e Engine, d *dag.DAG, requestID, stepName string, offset int64, interval time.Duration, w io.Writer) (*model.Node, error) {
+	var node *model.Node
+	done := func() bool {
+		status, err := RunStatus(e, d, requestID)
+		if err != nil {
+			return false
+		}
+		if n := FindNode(status, stepName); n != nil {
+			node = n
+		}
+		return isFinished(status, node)
+	}
+	for {
+		finished := done()
+		if node != nil && node.Log != "" {
+			break
+		}
+		if finished {
+			return node, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+	if err := logfile.Follow(ctx, node.Log, offset, interval, done, w); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// RunStatus returns the status of the run, the current one from its agent
+// while it runs, since the history is not written when each step starts.
+func RunStatus(e Engine, d *dag.DAG, requestID string) (*model.Status, error) {
+	if status, _ := e.GetCurrentStatus(d); status != nil && status.RequestId == requestID {
+		return status, nil
+	}
+	return e.GetStatusByRequestId(d, requestID)
+}
+
+// isFinished returns true if the step, or the run without starting the
+// step, finished. The run is finished once it has the time it finished,
+// since its status is written as finished between its steps too.
+func isFinished(status *model.Status, node *model.Node) bool {
+	if status.FinishedAt != "" && status.Status != scheduler.StatusRunning {
+		return true
+	}
+	return node != nil && node.Status != scheduler.NodeStatusNone && node.Status != scheduler.NodeStatusRunning
+}
+
+// FindNode returns the step or the handler of the name of the run, or nil.
+func FindNode(status *model.Status, name string) *model.Node {
+	handlers := map[string]*model.Node{
+		constants.OnSuccess: status.OnSuccess,
+		constants.OnFailure: status.OnFailure,
+		constants.OnCancel:  status.OnCancel,
+		constants.OnTimeout: status.OnTimeout,
+		constants.OnExit:    status.OnExit,
+	}
+	for _, n := range status.Nodes {
+		if n.Name == name {
+			return n
+		}
+	}
+	return handlers[name]
+}
@@ -0,0 +1,151 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+)
+
+// Code is a stable, machine-readable identifier of an error.
+type Code string
+
+const (
+	CodeInternal        Code = "internal_error"
+	CodeInvalidArgument Code = "invalid_argument"
+	CodeInvalidDAG      Code = "invalid_dag"
+	CodeNotFound        Code = "not_found"
+	CodeAlreadyExists   Code = "already_exists"
+	CodeDAGRunning      Code = "dag_running"
+	CodeDAGNotRunning   Code = "dag_not_running"
+	CodeStepNotRunning  Code = "step_not_running"
+	// CodeConflict is a request that conflicts with the state of what it
+	// is about, e.g. the approval of a change that was already reviewed.
+	CodeConflict Code = "conflict"
+	CodeTimeout  Code = "timeout"
+	// CodePermissionDenied is a request that the access rules of the
+	// server do not allow.
+	CodePermissionDenied Code = "permission_denied"
+)
+
+// Category groups error codes by the kind of failure.
+type Category string
+
+const (
+	CategoryInternal   Category = "internal"
+	CategoryValidation Category = "validation"
+	CategoryNotFound   Category = "not_found"
+	CategoryConflict   Category = "conflict"
+	CategoryTimeout    Category = "timeout"
+	CategoryPermission Category = "permission"
+)
+
+var codeCategories = map[Code]Category{
+	CodeInternal:        CategoryInternal,
+	CodeInvalidArgument: CategoryValidation,
+	CodeInvalidDAG:      CategoryValidation,
+	CodeNotFound:        CategoryNotFound,
+	CodeAlreadyExists:   CategoryConflict,
+	CodeDAGRunning:      CategoryConflict,
+	CodeDAGNotRunning:   CategoryConflict,
+	CodeStepNotRunning:  CategoryConflict,
+	CodeConflict:        CategoryConflict,
+	CodeTimeout:         CategoryTimeout,
+
+	CodePermissionDenied: CategoryPermission,
+}
+
+// Category returns the category of the code.
+func (c Code) Category() Category {
+	if cat, ok := codeCategories[c]; ok {
+		return cat
+	}
+	return CategoryInternal
+}
+
+// ExitCode returns the process exit code used by the CLI for the category.
+func (c Category) ExitCode() int {
+	switch c {
+	case CategoryValidation:
+		return 2
+	case CategoryNotFound:
+		return 3
+	case CategoryConflict:
+		return 4
+	case CategoryTimeout:
+		return 5
+	default:
+		return 1
+	}
+}
+
+// HTTPStatus returns the HTTP status code used by the API for the category.
+func (c Category) HTTPStatus() int {
+	switch c {
+	case CategoryValidation:
+		return 400
+	case CategoryNotFound:
+		return 404
+	case CategoryConflict:
+		return 409
+	case CategoryTimeout:
+		return 504
+	case CategoryPermission:
+		return 403
+	default:
+		return 500
+	}
+}
+
+// CodedError is an error annotated with a Code.
+type CodedError struct {
+	Code Code
+	Err  error
+}
+
+func (e *CodedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CodedError) Unwrap() error {
+	return e.Err
+}
+
+// New returns a new error with the given code and message.
+func New(code Code, msg string) error {
+	return &CodedError{Code: code, Err: errors.New(msg)}
+}
+
+// WithCode annotates err with the given code. It returns nil if err is nil.
+func WithCode(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &CodedError{Code: code, Err: err}
+}
+
+// CodeOf returns the code of the error. Errors without an explicit code
+// are classified by well-known causes and default to CodeInternal.
+func CodeOf(err error) Code {
+	var coded *CodedError
+	var list *ErrorList
+	switch {
+	case errors.As(err, &coded):
+		return coded.Code
+	case errors.Is(err, context.DeadlineExceeded):
+		return CodeTimeout
+	case errors.Is(err, fs.ErrNotExist):
+		return CodeNotFound
+	case errors.As(err, &list):
+		return CodeInvalidDAG
+	default:
+		return CodeInternal
+	}
+}
+
+// ExitCode returns the CLI exit code for the error. It returns 0 if err is nil.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	return CodeOf(err).Category().ExitCode()
+}
@@ -0,0 +1,38 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeOf(t *testing.T) {
+	errRunning := New(CodeDAGRunning, "the DAG is running")
+	_, errNotExist := os.Stat("/this/file/does/not/exist")
+	list := &ErrorList{}
+	list.Add(errors.New("invalid step"))
+
+	for _, tc := range []struct {
+		err      error
+		code     Code
+		exitCode int
+	}{
+		{err: errRunning, code: CodeDAGRunning, exitCode: 4},
+		{err: fmt.Errorf("wrapped: %w", errRunning), code: CodeDAGRunning, exitCode: 4},
+		{err: WithCode(CodeInvalidArgument, errors.New("bad")), code: CodeInvalidArgument, exitCode: 2},
+		{err: errNotExist, code: CodeNotFound, exitCode: 3},
+		{err: fmt.Errorf("wait: %w", context.DeadlineExceeded), code: CodeTimeout, exitCode: 5},
+		{err: list, code: CodeInvalidDAG, exitCode: 2},
+		{err: errors.New("unknown"), code: CodeInternal, exitCode: 1},
+	} {
+		require.Equal(t, tc.code, CodeOf(tc.err))
+		require.Equal(t, tc.exitCode, ExitCode(tc.err))
+	}
+
+	require.Equal(t, 0, ExitCode(nil))
+	require.Nil(t, WithCode(CodeInternal, nil))
+}
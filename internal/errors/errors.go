@@ -20,6 +20,11 @@ func (e *ErrorList) Error() string {
 	return strings.Join(errStrings, "; ")
 }
 
+// Errors returns the errors of the list.
+func (e *ErrorList) Errors() []error {
+	return e.errors
+}
+
 func (e *ErrorList) HasErrors() bool {
 	return len(e.errors) > 0
 }
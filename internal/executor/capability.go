@@ -0,0 +1,155 @@
+package executor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// Capability is a platform feature that executors depend on.
+type Capability struct {
+	Name      string
+	Available bool
+	// Detail describes the detected feature, or how the executors degrade
+	// when it is not available.
+	Detail string
+}
+
+const (
+	CapabilityProcessGroup = "process-group"
+	CapabilityDockerSocket = "docker-socket"
+	CapabilityCgroup       = "cgroup"
+)
+
+// Platform returns the OS and architecture of the binary, and the C library
+// of the system if it is musl.
+func Platform() string {
+	p := fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH)
+	if matches, _ := filepath.Glob("/lib/ld-musl-*.so.1"); len(matches) > 0 {
+		p += " (musl)"
+	}
+	return p
+}
+
+const defaultDockerHost = "unix:///var/run/docker.sock"
+
+var (
+	capabilities     map[string]Capability
+	capabilitiesOnce sync.Once
+)
+
+// Capabilities returns the capabilities detected on the running platform.
+// Detection runs once and the result is cached.
+func Capabilities() []Capability {
+	detectCapabilities()
+	ret := make([]Capability, 0, len(capabilities))
+	for _, name := range []string{CapabilityProcessGroup, CapabilityDockerSocket, CapabilityCgroup} {
+		ret = append(ret, capabilities[name])
+	}
+	return ret
+}
+
+// HasCapability returns true if the capability is available.
+func HasCapability(name string) bool {
+	detectCapabilities()
+	return capabilities[name].Available
+}
+
+func getCapability(name string) Capability {
+	detectCapabilities()
+	return capabilities[name]
+}
+
+func detectCapabilities() {
+	capabilitiesOnce.Do(func() {
+		capabilities = map[string]Capability{}
+		for _, c := range []Capability{
+			detectProcessGroup(),
+			detectDockerSocket(),
+			detectCgroup(),
+		} {
+			capabilities[c.Name] = c
+		}
+	})
+}
+
+// probeProcessGroup returns an error if the steps cannot be run in their own
+// process groups and the groups cannot be signaled. It is replaced in the
+// tests.
+var probeProcessGroup = func() error {
+	cmd := exec.Command("sleep", "10")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+	return syscall.Kill(-cmd.Process.Pid, 0)
+}
+
+func detectProcessGroup() Capability {
+	c := Capability{Name: CapabilityProcessGroup}
+	if err := probeProcessGroup(); err != nil {
+		c.Detail = fmt.Sprintf("process groups are not supported (%v); only the step process is signaled, not its children", err)
+		return c
+	}
+	c.Available = true
+	c.Detail = "steps run in their own process group and signals are sent to the whole group"
+	return c
+}
+
+func detectDockerSocket() Capability {
+	c := Capability{Name: CapabilityDockerSocket}
+	host := os.Getenv("DOCKER_HOST")
+	if host == "" {
+		host = defaultDockerHost
+	}
+	path, ok := strings.CutPrefix(host, "unix://")
+	if !ok {
+		c.Available = true
+		c.Detail = fmt.Sprintf("using %s", host)
+		return c
+	}
+	if _, err := os.Stat(path); err != nil {
+		c.Detail = fmt.Sprintf("docker socket %s is not found; mount the socket or set DOCKER_HOST to use the docker executor", path)
+		return c
+	}
+	if err := unix.Access(path, unix.W_OK); err != nil {
+		c.Detail = fmt.Sprintf("docker socket %s is not writable (%v); add the user to the docker group to use the docker executor", path, err)
+		return c
+	}
+	c.Available = true
+	c.Detail = fmt.Sprintf("using %s", host)
+	return c
+}
+
+const cgroupRoot = "/sys/fs/cgroup"
+
+func detectCgroup() Capability {
+	c := Capability{Name: CapabilityCgroup}
+	if runtime.GOOS != "linux" {
+		c.Detail = fmt.Sprintf("cgroups are not available on %s", runtime.GOOS)
+		return c
+	}
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err == nil {
+		c.Available = true
+		c.Detail = "cgroup v2"
+		return c
+	}
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "memory")); err == nil {
+		c.Available = true
+		c.Detail = "cgroup v1"
+		return c
+	}
+	c.Detail = fmt.Sprintf("no cgroup hierarchy is mounted at %s", cgroupRoot)
+	return c
+}
@@ -0,0 +1,29 @@
+package executor
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectProcessGroup(t *testing.T) {
+	// The probe signals the group of a child started in its own group.
+	c := detectProcessGroup()
+	require.Equal(t, CapabilityProcessGroup, c.Name)
+	require.True(t, c.Available, c.Detail)
+
+	// The steps are degraded to signaling their processes only if the
+	// group of the child cannot be signaled, e.g. in a sandbox.
+	probe := probeProcessGroup
+	defer func() {
+		probeProcessGroup = probe
+	}()
+	probeProcessGroup = func() error {
+		return syscall.EPERM
+	}
+	c = detectProcessGroup()
+	require.False(t, c.Available)
+	require.Contains(t, c.Detail, "process groups are not supported (operation not permitted)")
+	require.Contains(t, c.Detail, "only the step process is signaled")
+}
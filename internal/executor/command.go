@@ -8,7 +8,6 @@ import (
 	"os"
 	"os/exec"
 	"sync"
-	"syscall"
 
 	"github.com/dagu-dev/dagu/internal/dag"
 )
@@ -36,13 +35,17 @@ func (e *CommandExecutor) SetStderr(out io.Writer) {
 	e.cmd.Stderr = out
 }
 
+func (e *CommandExecutor) SetStdin(in io.Reader) {
+	e.cmd.Stdin = in
+}
+
 func (e *CommandExecutor) Kill(sig os.Signal) error {
 	e.lock.Lock()
 	defer e.lock.Unlock()
 	if e.cmd == nil || e.cmd.Process == nil {
 		return nil
 	}
-	return syscall.Kill(-e.cmd.Process.Pid, sig.(syscall.Signal))
+	return killProcess(e.cmd, sig)
 }
 
 func CreateCommandExecutor(ctx context.Context, step dag.Step) (Executor, error) {
@@ -57,10 +60,7 @@ func CreateCommandExecutor(ctx context.Context, step dag.Step) (Executor, error)
 		cmd.Env = append(cmd.Env, value.(string))
 		return true
 	})
-	cmd.SysProcAttr = &syscall.SysProcAttr{
-		Setpgid: true,
-		Pgid:    0,
-	}
+	cmd.SysProcAttr = processGroupAttr()
 
 	return &CommandExecutor{
 		cmd: cmd,
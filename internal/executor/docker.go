@@ -29,7 +29,10 @@ type DockerExecutor struct {
 	cancel          func()
 }
 
-var errImageMustBeString = errors.New("image must be string")
+var (
+	errImageMustBeString = errors.New("image must be string")
+	errDockerUnavailable = errors.New("docker is not available")
+)
 
 func (e *DockerExecutor) SetStdout(out io.Writer) {
 	e.stdout = out
@@ -51,6 +54,10 @@ func (e *DockerExecutor) Run() error {
 	e.context = ctx
 	e.cancel = fn
 
+	if c := getCapability(CapabilityDockerSocket); !c.Available {
+		return fmt.Errorf("%w: %s", errDockerUnavailable, c.Detail)
+	}
+
 	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
 	if err != nil {
 		return err
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 
 	"github.com/dagu-dev/dagu/internal/dag"
 )
@@ -17,6 +18,21 @@ type Executor interface {
 	Run() error
 }
 
+// SubRunner is implemented by executors that run another DAG. The output of
+// the step is the outputs of the sub DAG instead of its standard output.
+type SubRunner interface {
+	// SubRunRequestID returns the request ID of the sub DAG run.
+	SubRunRequestID() string
+	// Outputs returns the outputs of the sub DAG run as a JSON object.
+	Outputs() string
+}
+
+// StdinReader is implemented by executors whose commands read the standard
+// input of the step.
+type StdinReader interface {
+	SetStdin(in io.Reader)
+}
+
 type Creator func(ctx context.Context, step dag.Step) (Executor, error)
 
 var (
@@ -36,6 +52,19 @@ func CreateExecutor(ctx context.Context, step dag.Step) (Executor, error) {
 	return nil, fmt.Errorf("%w: %s", errInvalidExecutor, step.ExecutorConfig)
 }
 
+// Names returns the types of the registered executors in alphabetical
+// order, without the empty type of the default executor.
+func Names() []string {
+	names := make([]string, 0, len(executors))
+	for name := range executors {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 func ExecutorIsValid(name string) bool {
 	_, ok := executors[name]
 	return ok
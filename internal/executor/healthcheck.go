@@ -0,0 +1,235 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/mitchellh/mapstructure"
+)
+
+// HealthcheckExecutor waits for a dependency to be healthy. It requests the
+// health endpoint of the dependency every interval and finishes once the
+// endpoint returned 2xx for HealthyFor without a failure in between.
+type HealthcheckExecutor struct {
+	stdout io.Writer
+	ctx    context.Context
+	cancel context.CancelFunc
+	client *http.Client
+	url    string
+	cfg    *HealthcheckConfig
+
+	interval      time.Duration
+	healthyFor    time.Duration
+	timeout       time.Duration
+	escalateAfter time.Duration
+	// escalate sends the escalation notification with the message.
+	escalate func(message string) error
+}
+
+type HealthcheckConfig struct {
+	// Interval is the interval of the requests. The default is 5 seconds.
+	Interval any `mapstructure:"interval"`
+	// HealthyFor is how long the endpoint must stay healthy. The step
+	// finishes on the first healthy response if it is empty.
+	HealthyFor any `mapstructure:"healthyFor"`
+	// Timeout is how long the step waits before it fails. It waits until the
+	// timeout of the step if it is empty.
+	Timeout any `mapstructure:"timeout"`
+	// RequestTimeout is the timeout of each request. The default is 5
+	// seconds.
+	RequestTimeout any               `mapstructure:"requestTimeout"`
+	Headers        map[string]string `mapstructure:"headers"`
+	// EscalateAfter is how long the endpoint is unhealthy before the
+	// escalation is sent once. There is no escalation if it is empty.
+	EscalateAfter any `mapstructure:"escalateAfter"`
+	// EscalateTo is the address the escalation is mailed to. The default is
+	// the address of the error mail of the DAG.
+	EscalateTo string `mapstructure:"escalateTo"`
+}
+
+const (
+	defaultHealthcheckInterval       = 5 * time.Second
+	defaultHealthcheckRequestTimeout = 5 * time.Second
+)
+
+var (
+	errUnhealthy             = errors.New("the dependency is not healthy")
+	errNoHealthcheckURL      = errors.New("the url of the health endpoint is required")
+	errNoEscalationRecipient = errors.New("escalateTo or the error mail of the DAG is required for the escalation")
+	errNoNotifier            = errors.New("the escalation cannot be sent without the notifications of the run")
+)
+
+func (e *HealthcheckExecutor) SetStdout(out io.Writer) {
+	e.stdout = out
+}
+
+func (e *HealthcheckExecutor) SetStderr(out io.Writer) {
+	e.stdout = out
+}
+
+func (e *HealthcheckExecutor) Kill(sig os.Signal) error {
+	e.cancel()
+	return nil
+}
+
+func (e *HealthcheckExecutor) Run() error {
+	defer e.cancel()
+	start := time.Now()
+	var healthySince time.Time
+	unhealthySince := start
+	escalated := false
+	for {
+		err := e.probe()
+		now := time.Now()
+		if err == nil {
+			if healthySince.IsZero() {
+				healthySince = now
+				e.logf("healthy")
+			}
+			if now.Sub(healthySince) >= e.healthyFor {
+				if e.healthyFor > 0 {
+					e.logf("healthy for %s", e.healthyFor)
+				}
+				return nil
+			}
+		} else {
+			e.logf("unhealthy: %s", err)
+			if !healthySince.IsZero() {
+				healthySince, unhealthySince = time.Time{}, now
+			}
+			if e.escalateAfter > 0 && !escalated && now.Sub(unhealthySince) >= e.escalateAfter {
+				escalated = true
+				msg := fmt.Sprintf("%s has been unhealthy for %s: %s", e.url, now.Sub(unhealthySince).Round(time.Second), err)
+				if err := e.escalate(msg); err != nil {
+					e.logf("failed to send the escalation: %s", err)
+				} else {
+					e.logf("sent the escalation")
+				}
+			}
+		}
+		if e.timeout > 0 && now.Sub(start) >= e.timeout {
+			return fmt.Errorf("%w within %s: %s", errUnhealthy, e.timeout, e.url)
+		}
+		select {
+		case <-e.ctx.Done():
+			return e.ctx.Err()
+		case <-time.After(e.interval):
+		}
+	}
+}
+
+// probe requests the health endpoint once. It returns an error if the
+// request failed or its status is not 2xx.
+func (e *HealthcheckExecutor) probe() error {
+	req, err := http.NewRequestWithContext(e.ctx, http.MethodGet, e.url, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range e.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%w: %s", errHttpStatusCode, resp.Status)
+	}
+	return nil
+}
+
+func (e *HealthcheckExecutor) logf(format string, args ...any) {
+	_, _ = fmt.Fprintf(e.stdout, "%s %s\n", time.Now().Format(time.RFC3339), fmt.Sprintf(format, args...))
+}
+
+func CreateHealthcheckExecutor(ctx context.Context, step dag.Step) (Executor, error) {
+	var cfg HealthcheckConfig
+	if err := decodeHealthcheckConfig(step.ExecutorConfig.Config, &cfg); err != nil {
+		return nil, err
+	}
+	url := os.ExpandEnv(step.Command)
+	if url == "" {
+		return nil, errNoHealthcheckURL
+	}
+	for k, v := range cfg.Headers {
+		cfg.Headers[k] = os.ExpandEnv(v)
+	}
+
+	exec := &HealthcheckExecutor{stdout: os.Stdout, url: url, cfg: &cfg}
+	var requestTimeout time.Duration
+	durations := []struct {
+		name string
+		v    any
+		dst  *time.Duration
+		def  time.Duration
+	}{
+		{"interval", cfg.Interval, &exec.interval, defaultHealthcheckInterval},
+		{"healthyFor", cfg.HealthyFor, &exec.healthyFor, 0},
+		{"timeout", cfg.Timeout, &exec.timeout, 0},
+		{"escalateAfter", cfg.EscalateAfter, &exec.escalateAfter, 0},
+		{"requestTimeout", cfg.RequestTimeout, &requestTimeout, defaultHealthcheckRequestTimeout},
+	}
+	for _, d := range durations {
+		v, err := dag.ParseDuration(d.v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", d.name, err)
+		}
+		if v == 0 {
+			v = d.def
+		}
+		*d.dst = v
+	}
+	exec.client = &http.Client{Timeout: requestTimeout}
+
+	if exec.escalateAfter > 0 {
+		escalate, err := notifyEscalation(ctx, os.ExpandEnv(cfg.EscalateTo))
+		if err != nil {
+			return nil, err
+		}
+		exec.escalate = escalate
+	}
+	exec.ctx, exec.cancel = context.WithCancel(ctx)
+	return exec, nil
+}
+
+// notifyEscalation returns the function that sends the escalation to the
+// address, or to the address of the error mail of the DAG, with the
+// notifier of the run.
+func notifyEscalation(ctx context.Context, to string) (func(message string) error, error) {
+	dagCtx, err := dag.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if dagCtx.Notifier == nil {
+		return nil, errNoNotifier
+	}
+	if to == "" && dagCtx.DAG.ErrorMail != nil {
+		to = dagCtx.DAG.ErrorMail.To
+	}
+	if to == "" {
+		return nil, errNoEscalationRecipient
+	}
+	return func(message string) error {
+		return dagCtx.Notifier.Notify(to, "a dependency is unhealthy", message)
+	}, nil
+}
+
+func decodeHealthcheckConfig(dat map[string]interface{}, cfg *HealthcheckConfig) error {
+	md, _ := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		ErrorUnused: false,
+		Result:      cfg,
+	})
+	return md.Decode(dat)
+}
+
+func init() {
+	Register("healthcheck", CreateHealthcheckExecutor)
+}
@@ -0,0 +1,161 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/stretchr/testify/require"
+)
+
+// healthcheckServer returns the server of a health endpoint whose status
+// for the n-th request, from 1, is the one of the function.
+func healthcheckServer(t *testing.T, status func(n int64) int) *httptest.Server {
+	t.Helper()
+	var requests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status(requests.Add(1)))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+type mockNotifier struct {
+	mu            sync.Mutex
+	to, subject   string
+	notifications []string
+}
+
+var _ dag.Notifier = (*mockNotifier)(nil)
+
+func (m *mockNotifier) Notify(to, subject, body string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.to, m.subject = to, subject
+	m.notifications = append(m.notifications, body)
+	return nil
+}
+
+func testHealthcheck(t *testing.T, ctx context.Context, url string, cfg map[string]any) (*HealthcheckExecutor, *bytes.Buffer) {
+	t.Helper()
+	exec, err := CreateHealthcheckExecutor(ctx, dag.Step{
+		Command:        url,
+		ExecutorConfig: dag.ExecutorConfig{Type: "healthcheck", Config: cfg},
+	})
+	require.NoError(t, err)
+	var out bytes.Buffer
+	exec.SetStdout(&out)
+	return exec.(*HealthcheckExecutor), &out
+}
+
+func TestHealthcheckHealthy(t *testing.T) {
+	srv := healthcheckServer(t, func(int64) int { return http.StatusOK })
+	exec, out := testHealthcheck(t, context.Background(), srv.URL, map[string]any{
+		"interval":   "10ms",
+		"healthyFor": "50ms",
+		"timeout":    "5s",
+	})
+
+	start := time.Now()
+	require.NoError(t, exec.Run())
+	require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	require.Contains(t, out.String(), "healthy for 50ms")
+}
+
+func TestHealthcheckFlap(t *testing.T) {
+	// The endpoint fails once after it was healthy for a while.
+	srv := healthcheckServer(t, func(n int64) int {
+		if n == 5 {
+			return http.StatusServiceUnavailable
+		}
+		return http.StatusOK
+	})
+	exec, out := testHealthcheck(t, context.Background(), srv.URL, map[string]any{
+		"interval":   "10ms",
+		"healthyFor": "100ms",
+		"timeout":    "5s",
+	})
+
+	start := time.Now()
+	require.NoError(t, exec.Run())
+	// The window starts again after the failure.
+	require.GreaterOrEqual(t, time.Since(start), 140*time.Millisecond)
+	require.Equal(t, 2, strings.Count(out.String(), " healthy\n"))
+	require.Contains(t, out.String(), "unhealthy: ")
+}
+
+func TestHealthcheckTimeout(t *testing.T) {
+	srv := healthcheckServer(t, func(int64) int { return http.StatusInternalServerError })
+	exec, _ := testHealthcheck(t, context.Background(), srv.URL, map[string]any{
+		"interval": "10ms",
+		"timeout":  "50ms",
+	})
+
+	start := time.Now()
+	require.ErrorIs(t, exec.Run(), errUnhealthy)
+	elapsed := time.Since(start)
+	require.GreaterOrEqual(t, elapsed, 50*time.Millisecond)
+	require.Less(t, elapsed, time.Second)
+}
+
+func TestHealthcheckEscalation(t *testing.T) {
+	srv := healthcheckServer(t, func(int64) int { return http.StatusInternalServerError })
+	d := &dag.DAG{Name: "test", ErrorMail: &dag.MailConfig{To: "oncall@example.com"}}
+	notifier := &mockNotifier{}
+	ctx := dag.WithNotifier(dag.NewContext(context.Background(), d, nil), notifier)
+	exec, out := testHealthcheck(t, ctx, srv.URL, map[string]any{
+		"interval":      "10ms",
+		"timeout":       "150ms",
+		"escalateAfter": "30ms",
+	})
+
+	require.ErrorIs(t, exec.Run(), errUnhealthy)
+	// The escalation is sent once, however long the endpoint stays
+	// unhealthy after it.
+	require.Len(t, notifier.notifications, 1)
+	require.Contains(t, notifier.notifications[0], srv.URL+" has been unhealthy for ")
+	require.Equal(t, "oncall@example.com", notifier.to)
+	require.Equal(t, "a dependency is unhealthy", notifier.subject)
+	require.Equal(t, 1, strings.Count(out.String(), "sent the escalation"))
+
+	// The escalation is sent to escalateTo, and the sender is replaceable.
+	var sent []string
+	exec, _ = testHealthcheck(t, ctx, srv.URL, map[string]any{
+		"interval":      "10ms",
+		"timeout":       "100ms",
+		"escalateAfter": "20ms",
+		"escalateTo":    "db@example.com",
+	})
+	exec.escalate = func(message string) error {
+		sent = append(sent, message)
+		return nil
+	}
+	require.ErrorIs(t, exec.Run(), errUnhealthy)
+	require.Len(t, sent, 1)
+}
+
+func TestHealthcheckEscalationConfig(t *testing.T) {
+	step := dag.Step{
+		Command: "http://localhost/health",
+		ExecutorConfig: dag.ExecutorConfig{Type: "healthcheck", Config: map[string]any{
+			"escalateAfter": "1m",
+		}},
+	}
+
+	// The escalation needs a recipient.
+	ctx := dag.WithNotifier(dag.NewContext(context.Background(), &dag.DAG{Name: "test"}, nil), &mockNotifier{})
+	_, err := CreateHealthcheckExecutor(ctx, step)
+	require.ErrorIs(t, err, errNoEscalationRecipient)
+
+	// The escalation needs the notifications of the run.
+	d := &dag.DAG{Name: "test", ErrorMail: &dag.MailConfig{To: "oncall@example.com"}}
+	_, err = CreateHealthcheckExecutor(dag.NewContext(context.Background(), d, nil), step)
+	require.ErrorIs(t, err, errNoNotifier)
+}
@@ -8,7 +8,6 @@ import (
 	"io"
 	"os"
 	"strings"
-	"time"
 
 	"github.com/dagu-dev/dagu/internal/dag"
 	"github.com/go-resty/resty/v2"
@@ -25,7 +24,8 @@ type HTTPExecutor struct {
 }
 
 type HTTPConfig struct {
-	Timeout     int               `json:"timeout"`
+	// Timeout is a duration string such as "30s" or a number of seconds.
+	Timeout     any               `json:"timeout"`
 	Headers     map[string]string `json:"headers"`
 	QueryParams map[string]string `json:"query"`
 	Body        string            `json:"body"`
@@ -88,10 +88,15 @@ func CreateHTTPExecutor(ctx context.Context, step dag.Step) (Executor, error) {
 		}
 	}
 
+	timeout, err := dag.ParseDuration(reqCfg.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("timeout: %w", err)
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	client := resty.New()
-	if reqCfg.Timeout > 0 {
-		client.SetTimeout(time.Second * time.Duration(reqCfg.Timeout))
+	if timeout > 0 {
+		client.SetTimeout(timeout)
 	}
 	req := client.R().SetContext(ctx)
 	if len(reqCfg.Headers) > 0 {
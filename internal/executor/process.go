@@ -0,0 +1,36 @@
+package executor
+
+import (
+	"errors"
+	"log"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// processGroupAttr returns the attributes to start a process in its own
+// process group, or nil if process groups are not available.
+func processGroupAttr() *syscall.SysProcAttr {
+	if !HasCapability(CapabilityProcessGroup) {
+		return nil
+	}
+	return &syscall.SysProcAttr{
+		Setpgid: true,
+		Pgid:    0,
+	}
+}
+
+// killProcess sends the signal to the process group of the command. It
+// falls back to signaling the process only if the command was not started
+// in its own group or the group cannot be signaled.
+func killProcess(cmd *exec.Cmd, sig os.Signal) error {
+	pid := cmd.Process.Pid
+	if s, ok := sig.(syscall.Signal); ok && cmd.SysProcAttr != nil && cmd.SysProcAttr.Setpgid {
+		err := syscall.Kill(-pid, s)
+		if !errors.Is(err, syscall.EPERM) {
+			return err
+		}
+		log.Printf("failed to signal the process group %d: %v; signaling the process only", pid, err)
+	}
+	return cmd.Process.Signal(sig)
+}
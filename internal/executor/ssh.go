@@ -26,6 +26,7 @@ type SSHExecutor struct {
 	config    *SSHConfig
 	sshConfig *ssh.ClientConfig
 	stdout    io.Writer
+	stdin     io.Reader
 	session   *ssh.Session
 }
 
@@ -39,6 +40,10 @@ func (e *SSHExecutor) SetStderr(out io.Writer) {
 	e.stdout = out
 }
 
+func (e *SSHExecutor) SetStdin(in io.Reader) {
+	e.stdin = in
+}
+
 func (e *SSHExecutor) Kill(sig os.Signal) error {
 	if e.session != nil {
 		return e.session.Close()
@@ -64,6 +69,7 @@ func (e *SSHExecutor) Run() error {
 	// the remote side using the Run method.
 	session.Stdout = e.stdout
 	session.Stderr = e.stdout
+	session.Stdin = e.stdin
 	command := strings.Join(append([]string{e.step.Command}, e.step.Args...), " ")
 	return session.Run(command)
 }
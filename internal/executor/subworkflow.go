@@ -3,19 +3,24 @@ package executor
 import (
 	"context"
 	"fmt"
-	"github.com/dagu-dev/dagu/internal/utils"
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"sync"
-	"syscall"
 
 	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/google/uuid"
 )
 
 type SubWorkflowExecutor struct {
-	cmd  *exec.Cmd
-	lock sync.Mutex
+	cmd         *exec.Cmd
+	lock        sync.Mutex
+	requestID   string
+	outputsFile string
+	outputs     string
 }
 
 func (e *SubWorkflowExecutor) Run() error {
@@ -25,7 +30,33 @@ func (e *SubWorkflowExecutor) Run() error {
 	if err != nil {
 		return err
 	}
-	return e.cmd.Wait()
+	err = e.cmd.Wait()
+	e.readOutputs()
+	return err
+}
+
+// readOutputs reads the outputs written by the sub DAG run when it finished.
+func (e *SubWorkflowExecutor) readOutputs() {
+	defer func() {
+		_ = os.Remove(e.outputsFile)
+	}()
+	b, err := os.ReadFile(e.outputsFile)
+	if err != nil {
+		return
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.outputs = strings.TrimSpace(string(b))
+}
+
+func (e *SubWorkflowExecutor) SubRunRequestID() string {
+	return e.requestID
+}
+
+func (e *SubWorkflowExecutor) Outputs() string {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.outputs
 }
 
 func (e *SubWorkflowExecutor) SetStdout(out io.Writer) {
@@ -42,7 +73,7 @@ func (e *SubWorkflowExecutor) Kill(sig os.Signal) error {
 	if e.cmd == nil || e.cmd.Process == nil {
 		return nil
 	}
-	return syscall.Kill(-e.cmd.Process.Pid, sig.(syscall.Signal))
+	return killProcess(e.cmd, sig)
 }
 
 func CreateSubWorkflowExecutor(ctx context.Context, step dag.Step) (Executor, error) {
@@ -63,9 +94,19 @@ func CreateSubWorkflowExecutor(ctx context.Context, step dag.Step) (Executor, er
 
 	params := os.ExpandEnv(step.SubWorkflow.Params)
 
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	requestID := id.String()
+	outputsFile := filepath.Join(os.TempDir(), fmt.Sprintf("dagu_%s.outputs.json", requestID))
+
 	args := []string{
 		"start",
-		fmt.Sprintf("--params=%q", params),
+		// The sub DAG removes the surrounding quotes.
+		fmt.Sprintf(`--params="%s"`, params),
+		fmt.Sprintf("--request-id=%s", requestID),
+		fmt.Sprintf("--outputs-file=%s", outputsFile),
 		d.Location,
 	}
 
@@ -80,13 +121,12 @@ func CreateSubWorkflowExecutor(ctx context.Context, step dag.Step) (Executor, er
 		cmd.Env = append(cmd.Env, value.(string))
 		return true
 	})
-	cmd.SysProcAttr = &syscall.SysProcAttr{
-		Setpgid: true,
-		Pgid:    0,
-	}
+	cmd.SysProcAttr = processGroupAttr()
 
 	return &SubWorkflowExecutor{
-		cmd: cmd,
+		cmd:         cmd,
+		requestID:   requestID,
+		outputsFile: outputsFile,
 	}, nil
 }
 
@@ -0,0 +1,391 @@
+// Package gc removes the files left behind by agents that did not exit
+// cleanly: the sockets of agents that are gone, the script and outputs files
+// of their steps, their temporary directories and files, the lock files of
+// the status files that are gone, and the artifacts of runs that are no
+// longer in the history.
+package gc
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/logger"
+	"github.com/dagu-dev/dagu/internal/logger/tag"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/jsondb"
+)
+
+const (
+	KindSocket   = "socket"
+	KindTemp     = "temp"
+	KindArtifact = "artifact"
+	KindLock     = "lock"
+
+	defaultSockDir = "/tmp"
+	dialTimeout    = time.Second
+)
+
+var (
+	// socketPattern matches the sockets of the agents. See dag.DAG.SockAddr.
+	socketPattern = "@dagu-*.sock"
+	// tempPatterns match the script and output files of the steps and the
+	// outputs files of the sub workflows.
+	tempPatterns = []string{"dagu_script-*", "dagu_output-*", "dagu_*.outputs.json"}
+	// tempDirPattern matches the temporary directories in the temporary
+	// directory. See utils.MustTempDir.
+	tempDirPattern = "dagu_*"
+	// dataTempPatterns match the temporary files in the data directory of
+	// the writes that were not renamed to their files, see
+	// utils.WriteFileAtomic, and of the checks of the history.
+	dataTempPatterns = []string{".*.tmp", ".check-*"}
+)
+
+// Config contains the configuration for a Collector.
+type Config struct {
+	DataStore persistence.DataStoreFactory
+	// SockDir is the directory of the sockets of the agents. The default
+	// is /tmp.
+	SockDir string
+	// TempDir is the directory of the temporary files. The default is the
+	// temporary directory of the system. The directories of the steps are
+	// also searched for script files.
+	TempDir string
+	// DataDir is the data directory, which is searched for temporary files
+	// and orphaned lock files. It is not searched if it is empty.
+	DataDir string
+	// MinAge is how long a temporary file or the artifacts of a run are kept
+	// before they are removed. Sockets are removed as soon as no agent
+	// listens on them.
+	MinAge time.Duration
+	// DryRun reports the files without removing them.
+	DryRun bool
+	// ReportFile is optional. If set, the report of each collection is
+	// written to it.
+	ReportFile string
+	// Interval is the interval of the collections run by Start.
+	Interval time.Duration
+	Logger   logger.Logger
+}
+
+// Collector removes orphaned files.
+type Collector struct {
+	*Config
+}
+
+func New(cfg *Config) *Collector {
+	return &Collector{Config: cfg}
+}
+
+// Start runs a collection every interval in the background until done is
+// closed.
+func (c *Collector) Start(done chan any) {
+	go func() {
+		ticker := time.NewTicker(c.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.runAndLog()
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+func (c *Collector) runAndLog() {
+	r, err := c.Run()
+	if err != nil {
+		c.Logger.Error("failed to collect orphaned files", tag.Error(err))
+		return
+	}
+	for _, e := range r.Errors {
+		c.Logger.Warn("failed to remove orphaned file", "error", e)
+	}
+	if len(r.Removed) > 0 {
+		c.Logger.Info("removed orphaned files", "count", len(r.Removed), "size", r.Size())
+	}
+}
+
+// Report is the result of a collection.
+type Report struct {
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	DryRun     bool      `json:"dryRun"`
+	Removed    []*Item   `json:"removed"`
+	// Errors are the files that could not be removed.
+	Errors []string `json:"errors"`
+}
+
+// Item is a removed file or directory.
+type Item struct {
+	Kind string `json:"kind"`
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// Size returns the total size of the removed files.
+func (r *Report) Size() int64 {
+	var n int64
+	for _, it := range r.Removed {
+		n += it.Size
+	}
+	return n
+}
+
+// Run removes the orphaned files and returns the report. The report is
+// also written to the report file if it is set.
+func (c *Collector) Run() (*Report, error) {
+	r := &Report{StartedAt: time.Now(), DryRun: c.DryRun, Removed: []*Item{}, Errors: []string{}}
+	ds := c.DataStore.NewDAGStore()
+	dags, errs, err := ds.List()
+	if err != nil {
+		return nil, err
+	}
+
+	c.collectSockets(r)
+
+	dirs := []string{c.TempDir}
+	if c.TempDir == "" {
+		dirs[0] = os.TempDir()
+	}
+	for _, d := range dags {
+		details, err := ds.GetDetails(d.Location)
+		if err != nil {
+			continue
+		}
+		for _, s := range details.Steps {
+			if filepath.IsAbs(s.Dir) && !strings.Contains(s.Dir, "$") {
+				dirs = append(dirs, s.Dir)
+			}
+		}
+	}
+	c.collectTemp(r, dirs)
+	c.collectTempDirs(r, dirs[0])
+	c.collectDataDir(r)
+
+	// The artifacts of the DAGs that cannot be read are not known to be
+	// orphaned, so only the runs of the DAGs that are read are collected.
+	if err := c.collectArtifacts(r, dags, len(errs) == 0); err != nil {
+		r.Errors = append(r.Errors, err.Error())
+	}
+
+	r.FinishedAt = time.Now()
+	if c.ReportFile != "" {
+		if err := writeReport(c.ReportFile, r); err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
+func (c *Collector) collectSockets(r *Report) {
+	dir := c.SockDir
+	if dir == "" {
+		dir = defaultSockDir
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, socketPattern))
+	for _, f := range files {
+		info, err := os.Lstat(f)
+		if err != nil || info.Mode()&os.ModeSocket == 0 {
+			continue
+		}
+		conn, err := net.DialTimeout("unix", f, dialTimeout)
+		if err == nil {
+			_ = conn.Close()
+			continue
+		}
+		c.remove(r, &Item{Kind: KindSocket, Path: f}, func() error {
+			return os.Remove(f)
+		})
+	}
+}
+
+func (c *Collector) collectTemp(r *Report, dirs []string) {
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		if seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		for _, pattern := range tempPatterns {
+			files, _ := filepath.Glob(filepath.Join(dir, pattern))
+			for _, f := range files {
+				info, err := os.Lstat(f)
+				if err != nil || !info.Mode().IsRegular() || !c.isOld(info.ModTime()) {
+					continue
+				}
+				c.remove(r, &Item{Kind: KindTemp, Path: f, Size: info.Size()}, func() error {
+					return os.Remove(f)
+				})
+			}
+		}
+	}
+}
+
+// collectTempDirs removes the temporary directories whose files are all
+// old.
+func (c *Collector) collectTempDirs(r *Report, dir string) {
+	dirs, _ := filepath.Glob(filepath.Join(dir, tempDirPattern))
+	for _, d := range dirs {
+		info, err := os.Lstat(d)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		modTime, size := info.ModTime(), int64(0)
+		_ = filepath.WalkDir(d, func(_ string, e os.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if info, err := e.Info(); err == nil {
+				if info.ModTime().After(modTime) {
+					modTime = info.ModTime()
+				}
+				if info.Mode().IsRegular() {
+					size += info.Size()
+				}
+			}
+			return nil
+		})
+		if !c.isOld(modTime) {
+			continue
+		}
+		d := d
+		c.remove(r, &Item{Kind: KindTemp, Path: d, Size: size}, func() error {
+			return os.RemoveAll(d)
+		})
+	}
+}
+
+// collectDataDir removes the temporary files and the orphaned lock files of
+// the status files in the data directory.
+func (c *Collector) collectDataDir(r *Report) {
+	if c.DataDir == "" {
+		return
+	}
+	_ = filepath.WalkDir(c.DataDir, func(f string, e os.DirEntry, err error) error {
+		if err != nil || !e.Type().IsRegular() {
+			return nil
+		}
+		info, err := e.Info()
+		if err != nil || !c.isOld(info.ModTime()) {
+			return nil
+		}
+		kind := ""
+		switch {
+		case isDataTemp(e.Name()):
+			kind = KindTemp
+		case jsondb.OrphanedLock(f):
+			kind = KindLock
+		default:
+			return nil
+		}
+		c.remove(r, &Item{Kind: kind, Path: f, Size: info.Size()}, func() error {
+			return os.Remove(f)
+		})
+		return nil
+	})
+}
+
+func isDataTemp(name string) bool {
+	for _, pattern := range dataTempPatterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// collectArtifacts removes the artifacts of the runs that are not in the
+// history, e.g. because the history was removed after the retention period,
+// and of the DAGs that no longer exist.
+func (c *Collector) collectArtifacts(r *Report, dags []*dag.DAG, allDAGs bool) error {
+	store := c.DataStore.NewArtifactStore()
+	runs, err := store.Runs()
+	if err != nil {
+		return err
+	}
+	byDir := make(map[string]string)
+	for _, d := range dags {
+		byDir[store.Dir(d.Name, "")] = d.Location
+	}
+	hs := c.DataStore.NewHistoryStore()
+	for _, run := range runs {
+		if !c.isOld(run.ModTime) {
+			continue
+		}
+		loc, ok := byDir[filepath.Dir(run.Dir)]
+		switch {
+		case !ok && !allDAGs:
+			continue
+		case ok:
+			_, err := hs.FindByRequestId(loc, run.RequestId)
+			if !errors.Is(err, persistence.ErrRequestIdNotFound) {
+				continue
+			}
+		}
+		run := run
+		c.remove(r, &Item{Kind: KindArtifact, Path: run.Dir, Size: run.Size}, func() error {
+			return store.Remove(run)
+		})
+	}
+	return nil
+}
+
+func (c *Collector) isOld(t time.Time) bool {
+	return time.Since(t) >= c.MinAge
+}
+
+func (c *Collector) remove(r *Report, it *Item, fn func() error) {
+	if !c.DryRun {
+		if err := fn(); err != nil {
+			r.Errors = append(r.Errors, err.Error())
+			return
+		}
+	}
+	r.Removed = append(r.Removed, it)
+}
+
+func writeReport(file string, r *Report) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
+
+// ReadReport reads the report of the last collection. It returns nil if no
+// collection has been run.
+func ReadReport(file string) (*Report, error) {
+	b, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	r := &Report{}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ReportFile returns the default location of the report in the data
+// directory.
+func ReportFile(dataDir string) string {
+	return filepath.Join(dataDir, "gc", "report.json")
+}
@@ -0,0 +1,160 @@
+package gc
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	tmpDir := utils.MustTempDir("test-gc")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	cfg := &config.Config{
+		DAGs:        filepath.Join(tmpDir, "dags"),
+		DataDir:     filepath.Join(tmpDir, "data"),
+		ArtifactDir: filepath.Join(tmpDir, "artifacts"),
+	}
+	df := client.NewDataStoreFactory(cfg)
+	sockDir := filepath.Join(tmpDir, "sock")
+	tempDir := filepath.Join(tmpDir, "temp")
+	for _, dir := range []string{sockDir, tempDir} {
+		require.NoError(t, os.MkdirAll(dir, 0755))
+	}
+	old := time.Now().Add(-time.Hour * 48)
+
+	// A socket an agent listens on and one left by a crashed agent.
+	live, err := net.Listen("unix", filepath.Join(sockDir, "@dagu-live-1.sock"))
+	require.NoError(t, err)
+	defer func() {
+		_ = live.Close()
+	}()
+	stale, err := net.Listen("unix", filepath.Join(sockDir, "@dagu-stale-1.sock"))
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	// Script files of a step that is running and of a crashed agent.
+	newScript := filepath.Join(tempDir, "dagu_script-new")
+	oldScript := filepath.Join(tempDir, "dagu_script-old")
+	for _, f := range []string{newScript, oldScript} {
+		require.NoError(t, os.WriteFile(f, []byte("echo 1"), 0600))
+	}
+	require.NoError(t, os.Chtimes(oldScript, old, old))
+
+	// The artifacts of a run in the history, of a run that is not in the
+	// history anymore, and of a DAG that was deleted.
+	spec := []byte("steps:\n  - name: step1\n    command: echo 1\n")
+	_, err = df.NewDAGStore().Create("etl", spec)
+	require.NoError(t, err)
+	d, err := df.NewDAGStore().GetMetadata("etl")
+	require.NoError(t, err)
+	hs := df.NewHistoryStore()
+	require.NoError(t, hs.Open(d.Location, time.Now(), "request-1"))
+	st := model.NewStatus(d, nil, scheduler.StatusSuccess, 10000, nil, nil)
+	st.RequestId = "request-1"
+	require.NoError(t, hs.Write(st))
+	require.NoError(t, hs.Close())
+
+	as := df.NewArtifactStore()
+	for _, run := range [][2]string{{"etl", "request-1"}, {"etl", "request-2"}, {"deleted", "request-3"}} {
+		dir := as.Dir(run[0], run[1])
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		f := filepath.Join(dir, "out.csv")
+		require.NoError(t, os.WriteFile(f, []byte("a,b"), 0600))
+		require.NoError(t, os.Chtimes(f, old, old))
+		require.NoError(t, os.Chtimes(dir, old, old))
+	}
+
+	// A temporary directory in use and one left by a crashed agent.
+	newTempDir := filepath.Join(tempDir, "dagu_new")
+	oldTempDir := filepath.Join(tempDir, "dagu_old")
+	for _, dir := range []string{newTempDir, oldTempDir} {
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		f := filepath.Join(dir, "data")
+		require.NoError(t, os.WriteFile(f, []byte("data"), 0600))
+		require.NoError(t, os.Chtimes(f, old, old))
+		require.NoError(t, os.Chtimes(dir, old, old))
+	}
+	require.NoError(t, os.Chtimes(filepath.Join(newTempDir, "data"), time.Now(), time.Now()))
+
+	// The temporary file of a write left in the data directory, the lock
+	// file of a status file that was compacted, and the one of the status
+	// file of the run in the history.
+	histDir := filepath.Dir(hs.ReadStatusRecent(d.Location, 1)[0].File)
+	dataTemp := filepath.Join(histDir, ".report.json.123.tmp")
+	orphanedLock := filepath.Join(histDir, "etl.20240101.00:00:00.000.request.dat.lock")
+	lock := hs.ReadStatusRecent(d.Location, 1)[0].File + ".lock"
+	for _, f := range []string{dataTemp, orphanedLock, lock} {
+		require.NoError(t, os.WriteFile(f, []byte("{}"), 0600))
+		require.NoError(t, os.Chtimes(f, old, old))
+	}
+
+	reportFile := ReportFile(cfg.DataDir)
+	c := New(&Config{
+		DataStore:  df,
+		SockDir:    sockDir,
+		TempDir:    tempDir,
+		DataDir:    cfg.DataDir,
+		MinAge:     time.Hour * 24,
+		DryRun:     true,
+		ReportFile: reportFile,
+	})
+
+	r, err := c.Run()
+	require.NoError(t, err)
+	require.Empty(t, r.Errors)
+	removed := map[string]string{}
+	for _, it := range r.Removed {
+		removed[it.Path] = it.Kind
+	}
+	require.Equal(t, map[string]string{
+		filepath.Join(sockDir, "@dagu-stale-1.sock"): KindSocket,
+		oldScript:                      KindTemp,
+		oldTempDir:                     KindTemp,
+		dataTemp:                       KindTemp,
+		orphanedLock:                   KindLock,
+		as.Dir("etl", "request-2"):     KindArtifact,
+		as.Dir("deleted", "request-3"): KindArtifact,
+	}, removed)
+	require.Equal(t, int64(len("echo 1")+len("data")+2*len("{}")+2*len("a,b")), r.Size())
+	require.FileExists(t, oldScript)
+
+	c.DryRun = false
+	_, err = c.Run()
+	require.NoError(t, err)
+	require.NoFileExists(t, filepath.Join(sockDir, "@dagu-stale-1.sock"))
+	require.FileExists(t, filepath.Join(sockDir, "@dagu-live-1.sock"))
+	require.NoFileExists(t, oldScript)
+	require.FileExists(t, newScript)
+	require.NoDirExists(t, oldTempDir)
+	require.DirExists(t, newTempDir)
+	require.NoFileExists(t, dataTemp)
+	require.NoFileExists(t, orphanedLock)
+	require.FileExists(t, lock)
+	require.DirExists(t, as.Dir("etl", "request-1"))
+	require.NoDirExists(t, as.Dir("etl", "request-2"))
+	require.NoDirExists(t, as.Dir("deleted", "request-3"))
+
+	saved, err := ReadReport(reportFile)
+	require.NoError(t, err)
+	require.False(t, saved.DryRun)
+	require.Len(t, saved.Removed, 7)
+}
+
+func TestReadReportNotFound(t *testing.T) {
+	r, err := ReadReport(filepath.Join(os.TempDir(), "no-such-dir", "report.json"))
+	require.NoError(t, err)
+	require.Nil(t, r)
+}
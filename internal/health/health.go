@@ -0,0 +1,223 @@
+// Package health checks that the services of dagu work for the liveness and
+// the readiness probes of the containers, e.g. of Kubernetes.
+//
+// The liveness check fails if the loop of the scheduler is stuck, which a
+// restart fixes. The readiness check also fails if the DAGs cannot be read
+// or the history cannot be written, so that no requests are sent to the
+// server until the volumes are back.
+package health
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/utils"
+)
+
+// The paths of the probes on the server.
+const (
+	LivenessPath  = "/healthz"
+	ReadinessPath = "/readyz"
+)
+
+// The statuses of the checks and of the reports.
+const (
+	StatusOK      = "ok"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+)
+
+// DefaultMaxTickAge is how old the last tick of the scheduler, which ticks
+// every minute, can be before its loop is stuck.
+const DefaultMaxTickAge = 3 * time.Minute
+
+var (
+	errStuck       = errors.New("the scheduler loop is not ticking")
+	errNotRunning  = errors.New("the scheduler is not running")
+	errInvalidBeat = errors.New("invalid heartbeat")
+	errDegraded    = errors.New("the DAGs directory of the scheduler is unavailable, and the scheduler schedules the DAGs it loaded last")
+)
+
+// HeartbeatFile returns the path of the file the scheduler records its ticks
+// in, in the data directory.
+func HeartbeatFile(dataDir string) string {
+	return filepath.Join(dataDir, "scheduler.heartbeat")
+}
+
+// degradedMark follows the time of the tick in the heartbeat while the
+// scheduler schedules the cached DAGs.
+const degradedMark = "degraded"
+
+// Heartbeat records the ticks of the loop of the scheduler in a file, so
+// that the server checks that it is ticking, whichever process it runs in.
+type Heartbeat struct {
+	File string
+}
+
+// Beat records a tick of the scheduler at the time, and whether it
+// schedules the cached DAGs because its DAGs directory is unavailable.
+func (h *Heartbeat) Beat(now time.Time, degraded bool) error {
+	if err := os.MkdirAll(filepath.Dir(h.File), 0755); err != nil {
+		return err
+	}
+	data := strconv.FormatInt(now.Unix(), 10)
+	if degraded {
+		data += " " + degradedMark
+	}
+	return utils.WriteFileAtomic(h.File, []byte(data), 0644)
+}
+
+// Stop removes the file once the scheduler stops, so that a scheduler that
+// is stopped is not reported as stuck.
+func (h *Heartbeat) Stop() error {
+	if err := os.Remove(h.File); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Target is the DAGs directory and the history of a namespace.
+type Target struct {
+	// Namespace is empty for the default namespace.
+	Namespace string
+	DAGsDir   string
+	History   persistence.HistoryStore
+}
+
+// Checker checks the scheduler and the stores of the namespaces.
+type Checker struct {
+	HeartbeatFile string
+	// MaxTickAge is DefaultMaxTickAge if it is zero.
+	MaxTickAge time.Duration
+	Targets    []Target
+}
+
+// Check is the result of a check.
+type Check struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report is the results of the checks of a probe. Its status is failed if a
+// check failed.
+type Report struct {
+	Status string  `json:"status"`
+	Checks []Check `json:"checks"`
+}
+
+// OK returns true if no check failed.
+func (r *Report) OK() bool {
+	return r.Status == StatusOK
+}
+
+func (r *Report) add(name string, err error) {
+	c := Check{Name: name, Status: StatusOK}
+	switch {
+	case errors.Is(err, errNotRunning):
+		c.Status, c.Error = StatusSkipped, err.Error()
+	case err != nil:
+		c.Status, c.Error = StatusFailed, err.Error()
+		r.Status = StatusFailed
+	}
+	r.Checks = append(r.Checks, c)
+}
+
+// Liveness checks that the scheduler loop is ticking if the scheduler runs.
+func (c *Checker) Liveness() *Report {
+	r := &Report{Status: StatusOK, Checks: []Check{}}
+	r.add("scheduler", c.checkScheduler(time.Now()))
+	return r
+}
+
+// Readiness checks the scheduler, that it reads its DAGs directory, that
+// the DAGs directories are readable and that the histories are writable.
+func (c *Checker) Readiness() *Report {
+	r := c.Liveness()
+	r.add("schedules", c.checkSchedules())
+	for _, t := range c.Targets {
+		suffix := ""
+		if t.Namespace != "" {
+			suffix = ":" + t.Namespace
+		}
+		r.add("dags"+suffix, checkDAGsDir(t.DAGsDir))
+		r.add("history"+suffix, t.History.Check())
+	}
+	return r
+}
+
+// checkScheduler checks the time of the last tick of the scheduler.
+func (c *Checker) checkScheduler(now time.Time) error {
+	last, _, err := c.readHeartbeat()
+	if err != nil {
+		return err
+	}
+	maxAge := c.MaxTickAge
+	if maxAge <= 0 {
+		maxAge = DefaultMaxTickAge
+	}
+	if now.Sub(last) > maxAge {
+		return fmt.Errorf("%w: the last tick was at %s", errStuck, last.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// checkSchedules checks that the scheduler schedules the DAGs of its DAGs
+// directory, and not the ones it cached before the directory became
+// unavailable. A restart does not fix it, so that the liveness does not
+// check it.
+func (c *Checker) checkSchedules() error {
+	_, degraded, err := c.readHeartbeat()
+	if err != nil {
+		return err
+	}
+	if degraded {
+		return errDegraded
+	}
+	return nil
+}
+
+// readHeartbeat returns the time of the last tick of the scheduler, and
+// whether it was degraded then.
+func (c *Checker) readHeartbeat() (time.Time, bool, error) {
+	data, err := os.ReadFile(c.HeartbeatFile)
+	if os.IsNotExist(err) {
+		// The scheduler is stopped or runs in another container that has
+		// not started yet.
+		return time.Time{}, false, errNotRunning
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 || len(fields) > 2 || len(fields) == 2 && fields[1] != degradedMark {
+		return time.Time{}, false, fmt.Errorf("%w: %s", errInvalidBeat, c.HeartbeatFile)
+	}
+	sec, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%w: %s", errInvalidBeat, c.HeartbeatFile)
+	}
+	return time.Unix(sec, 0), len(fields) == 2, nil
+}
+
+func checkDAGsDir(dir string) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	_, err = f.ReadDir(1)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,76 @@
+package health
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/persistence/jsondb"
+	"github.com/dagu-dev/dagu/internal/persistence/sqldb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiveness(t *testing.T) {
+	dir := t.TempDir()
+	hb := &Heartbeat{File: HeartbeatFile(filepath.Join(dir, "data"))}
+	c := &Checker{HeartbeatFile: hb.File, MaxTickAge: time.Minute}
+
+	// The scheduler has not started.
+	r := c.Liveness()
+	require.True(t, r.OK())
+	require.Equal(t, StatusSkipped, r.Checks[0].Status)
+
+	require.NoError(t, hb.Beat(time.Now(), false))
+	r = c.Liveness()
+	require.True(t, r.OK())
+	require.Equal(t, []Check{{Name: "scheduler", Status: StatusOK}}, r.Checks)
+
+	require.NoError(t, hb.Beat(time.Now().Add(-2*time.Minute), false))
+	r = c.Liveness()
+	require.False(t, r.OK())
+	require.Equal(t, StatusFailed, r.Status)
+	require.Contains(t, r.Checks[0].Error, "the scheduler loop is not ticking")
+
+	require.NoError(t, hb.Stop())
+	require.NoError(t, hb.Stop())
+	require.True(t, c.Liveness().OK())
+}
+
+func TestReadiness(t *testing.T) {
+	dir := t.TempDir()
+	dagsDir := filepath.Join(dir, "dags")
+	require.NoError(t, os.MkdirAll(dagsDir, 0755))
+
+	c := &Checker{
+		HeartbeatFile: HeartbeatFile(dir),
+		Targets: []Target{
+			{DAGsDir: dagsDir, History: jsondb.New(filepath.Join(dir, "history"), dagsDir)},
+			{Namespace: "staging", DAGsDir: dagsDir, History: sqldb.NewSQLite(filepath.Join(dir, "history.db"), dagsDir)},
+		},
+	}
+	r := c.Readiness()
+	require.True(t, r.OK(), r)
+	require.Len(t, r.Checks, 6)
+	require.Equal(t, Check{Name: "schedules", Status: StatusSkipped, Error: errNotRunning.Error()}, r.Checks[1])
+	require.Equal(t, "history:staging", r.Checks[5].Name)
+
+	// The scheduler schedules the cached DAGs, which a restart does not
+	// fix.
+	hb := &Heartbeat{File: c.HeartbeatFile}
+	require.NoError(t, hb.Beat(time.Now(), true))
+	require.True(t, c.Liveness().OK())
+	r = c.Readiness()
+	require.False(t, r.OK())
+	require.Equal(t, Check{Name: "schedules", Status: StatusFailed, Error: errDegraded.Error()}, r.Checks[1])
+	require.NoError(t, hb.Beat(time.Now(), false))
+	require.True(t, c.Readiness().OK())
+
+	// The DAGs directory is gone.
+	c.Targets[0].DAGsDir = filepath.Join(dir, "missing")
+	r = c.Readiness()
+	require.False(t, r.OK())
+	require.Equal(t, Check{Name: "dags", Status: StatusFailed, Error: r.Checks[2].Error}, r.Checks[2])
+	require.NotEmpty(t, r.Checks[2].Error)
+	require.Equal(t, StatusOK, r.Checks[3].Status)
+}
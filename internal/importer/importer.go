@@ -0,0 +1,120 @@
+// Package importer converts the jobs of the schedulers of the hosts, the
+// systemd timers and the tasks of the Windows Task Scheduler, to DAGs, so
+// that they are migrated to dagu without rewriting them by hand.
+//
+// The settings that have no equivalent in a DAG, e.g. a timer that runs
+// after the boot, are not converted and reported as warnings, which are also
+// written as comments at the top of the DAG file to review it.
+package importer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dagu-dev/dagu/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	errNoCommand  = errors.New("the job has no command")
+	errNoSchedule = errors.New("unsupported schedule")
+)
+
+// Result is a DAG converted from a job.
+type Result struct {
+	// Name is the name of the DAG, and of its file without the extension.
+	Name string
+	// Source is the file the job was read from.
+	Source string
+	DAG    *DAG
+	// Warnings are the settings of the job that are not converted or are
+	// converted approximately.
+	Warnings []string
+}
+
+// DAG is the definition of a converted DAG, with the fields in the order of
+// the DAG files.
+type DAG struct {
+	Description string              `yaml:"description,omitempty"`
+	Schedule    []string            `yaml:"schedule,omitempty"`
+	Env         []map[string]string `yaml:"env,omitempty"`
+	Dotenv      []string            `yaml:"dotenv,omitempty"`
+	Steps       []*Step             `yaml:"steps"`
+}
+
+// Step is a step of a converted DAG. The command is a string parsed as the
+// command of a shell, or the list of the program and its arguments.
+type Step struct {
+	Name       string      `yaml:"name"`
+	Command    any         `yaml:"command"`
+	Dir        string      `yaml:"dir,omitempty"`
+	Depends    []string    `yaml:"depends,omitempty"`
+	ContinueOn *ContinueOn `yaml:"continueOn,omitempty"`
+}
+
+// ContinueOn is the continueOn of a step.
+type ContinueOn struct {
+	Failure bool `yaml:"failure"`
+}
+
+func (r *Result) warnf(format string, args ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// addStep adds a step of the command that runs after the previous one. The
+// step is named after its program, e.g. backup.sh, with a suffix if another
+// step has the name.
+func (r *Result) addStep(program string, command any, dir string, ignoreFailure bool) {
+	base := program[strings.LastIndexAny(program, `/\`)+1:]
+	if base == "" {
+		base = "step"
+	}
+	name := base
+	for i := 2; r.hasStep(name); i++ {
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+	s := &Step{Name: name, Command: command, Dir: dir}
+	if n := len(r.DAG.Steps); n > 0 {
+		s.Depends = []string{r.DAG.Steps[n-1].Name}
+	}
+	if ignoreFailure {
+		s.ContinueOn = &ContinueOn{Failure: true}
+	}
+	r.DAG.Steps = append(r.DAG.Steps, s)
+}
+
+func (r *Result) hasStep(name string) bool {
+	for _, s := range r.DAG.Steps {
+		if s.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// YAML returns the DAG file of the result, with a comment of the source and
+// the warnings at the top.
+func (r *Result) YAML() ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Imported from %s.\n", r.Source)
+	for _, w := range r.Warnings {
+		fmt.Fprintf(&buf, "# WARNING: %s\n", strings.ReplaceAll(w, "\n", " "))
+	}
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(r.DAG); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// dagName returns the name of the DAG of the job, which is also the name of
+// its file.
+func dagName(name string) string {
+	return utils.ValidFilename(strings.TrimSpace(name), "_")
+}
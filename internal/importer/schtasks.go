@@ -0,0 +1,347 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+var errInvalidDuration = errors.New("invalid duration")
+
+// task is the XML of a task exported from the Task Scheduler, e.g. with
+// schtasks /query /xml /tn <name>.
+type task struct {
+	RegistrationInfo struct {
+		URI         string
+		Description string
+	}
+	Triggers struct {
+		CalendarTriggers []taskTrigger `xml:"CalendarTrigger"`
+		TimeTriggers     []taskTrigger `xml:"TimeTrigger"`
+		Others           []xmlElement  `xml:",any"`
+	}
+	Principals struct {
+		Principals []struct {
+			UserId string
+		} `xml:"Principal"`
+	}
+	Actions struct {
+		Execs []struct {
+			Command          string
+			Arguments        string
+			WorkingDirectory string
+		} `xml:"Exec"`
+		Others []xmlElement `xml:",any"`
+	}
+}
+
+type xmlElement struct {
+	XMLName xml.Name
+}
+
+type taskTrigger struct {
+	StartBoundary string
+	Enabled       string
+	Repetition    *struct {
+		Interval string
+		Duration string
+	}
+	ScheduleByDay *struct {
+		DaysInterval int
+	}
+	ScheduleByWeek *struct {
+		WeeksInterval int
+		DaysOfWeek    struct {
+			Days []xmlElement `xml:",any"`
+		}
+	}
+	ScheduleByMonth *struct {
+		DaysOfMonth struct {
+			Days []string `xml:"Day"`
+		}
+		Months struct {
+			Months []xmlElement `xml:",any"`
+		}
+	}
+}
+
+// systemAccounts are the accounts of the services of Windows, whose tasks
+// have no user to run as.
+var systemAccounts = map[string]bool{
+	"s-1-5-18": true, "system": true, "nt authority\\system": true,
+	"s-1-5-19": true, "local service": true, "nt authority\\localservice": true,
+	"s-1-5-20": true, "network service": true, "nt authority\\networkservice": true,
+}
+
+var taskMonths = map[string]int{
+	"January": 1, "February": 2, "March": 3, "April": 4, "May": 5, "June": 6,
+	"July": 7, "August": 8, "September": 9, "October": 10, "November": 11, "December": 12,
+}
+
+var taskWeekdays = map[string]int{
+	"Sunday": 0, "Monday": 1, "Tuesday": 2, "Wednesday": 3, "Thursday": 4, "Friday": 5, "Saturday": 6,
+}
+
+// TaskScheduler converts the XML export of a task of the Windows Task
+// Scheduler to a DAG. The name of the DAG is the name of the task.
+func TaskScheduler(file string) (*Result, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	t, err := parseTask(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	name := t.RegistrationInfo.URI[strings.LastIndex(t.RegistrationInfo.URI, `\`)+1:]
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	r := &Result{Name: dagName(name), Source: file, DAG: &DAG{}}
+	r.DAG.Description = strings.TrimSpace(t.RegistrationInfo.Description)
+	for _, trigger := range t.Triggers.CalendarTriggers {
+		r.convertTrigger(&trigger, false)
+	}
+	for _, trigger := range t.Triggers.TimeTriggers {
+		r.convertTrigger(&trigger, true)
+	}
+	for _, e := range t.Triggers.Others {
+		r.warnf("the %s is not converted: a DAG only has a schedule", e.XMLName.Local)
+	}
+	if len(r.DAG.Schedule) == 0 {
+		r.warnf("the task has no trigger that is converted: the DAG is run manually")
+	}
+	for _, p := range t.Principals.Principals {
+		if p.UserId != "" && !systemAccounts[strings.ToLower(p.UserId)] {
+			r.warnf("the task runs as %s: run dagu as the user", p.UserId)
+		}
+	}
+	for _, e := range t.Actions.Execs {
+		command := strings.Trim(strings.TrimSpace(e.Command), `"`)
+		if strings.Contains(command+e.Arguments+e.WorkingDirectory, "%") {
+			r.warnf("the environment variables of %s are not converted", command)
+		}
+		r.addStep(command, append([]string{command}, splitWindowsArgs(e.Arguments)...), e.WorkingDirectory, false)
+	}
+	for _, e := range t.Actions.Others {
+		r.warnf("the %s action is not converted", e.XMLName.Local)
+	}
+	if len(r.DAG.Steps) == 0 {
+		return nil, fmt.Errorf("%s: %w", file, errNoCommand)
+	}
+	return r, nil
+}
+
+// parseTask parses the XML of a task, which is in UTF-16 if it is exported
+// from the Task Scheduler.
+func parseTask(data []byte) (*task, error) {
+	r := transform.NewReader(bytes.NewReader(data), unicode.BOMOverride(unicode.UTF8.NewDecoder()))
+	dec := xml.NewDecoder(r)
+	// The document is decoded to UTF-8 whichever encoding it declares.
+	dec.CharsetReader = func(_ string, r io.Reader) (io.Reader, error) {
+		return r, nil
+	}
+	t := &task{}
+	if err := dec.Decode(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// convertTrigger adds the schedule of the trigger. A time trigger runs once
+// at its start boundary, which has no equivalent in a schedule, unless it
+// repeats.
+func (r *Result) convertTrigger(t *taskTrigger, once bool) {
+	if t.Enabled == "false" {
+		r.warnf("the disabled trigger starting at %s is not converted", t.StartBoundary)
+		return
+	}
+	start, zoned, err := parseStartBoundary(t.StartBoundary)
+	if err != nil {
+		r.warnf("the trigger starting at %q is not converted: %v", t.StartBoundary, err)
+		return
+	}
+	if zoned {
+		r.warnf("the time zone of the start boundary %s is not converted: the schedule is in the time zone of the scheduler", t.StartBoundary)
+	}
+	if start.Second() != 0 {
+		r.warnf("the seconds of the start boundary %s are not converted", t.StartBoundary)
+	}
+	minute, hour := strconv.Itoa(start.Minute()), strconv.Itoa(start.Hour())
+	dom, month, dow := "*", "*", "*"
+	switch {
+	case once:
+		if t.Repetition == nil {
+			r.warnf("the one-time trigger at %s is not converted", t.StartBoundary)
+			return
+		}
+	case t.ScheduleByDay != nil:
+		if n := t.ScheduleByDay.DaysInterval; n > 1 {
+			dom = fmt.Sprintf("*/%d", n)
+			r.warnf("the interval of %d days is converted approximately: the runs restart on the 1st of each month", n)
+		}
+	case t.ScheduleByWeek != nil:
+		var days []string
+		for _, d := range t.ScheduleByWeek.DaysOfWeek.Days {
+			if v, ok := taskWeekdays[d.XMLName.Local]; ok {
+				days = append(days, strconv.Itoa(v))
+			}
+		}
+		if len(days) > 0 {
+			dow = strings.Join(days, ",")
+		}
+		if n := t.ScheduleByWeek.WeeksInterval; n > 1 {
+			r.warnf("the interval of %d weeks is not converted: the DAG runs every week", n)
+		}
+	case t.ScheduleByMonth != nil:
+		var days, months []string
+		for _, d := range t.ScheduleByMonth.DaysOfMonth.Days {
+			if _, err := fieldValue(strings.TrimSpace(d), 1, 31); err != nil {
+				r.warnf("the day %s of the monthly trigger is not converted", d)
+				continue
+			}
+			days = append(days, strings.TrimSpace(d))
+		}
+		if len(days) == 0 {
+			r.warnf("the monthly trigger starting at %s has no day that is converted", t.StartBoundary)
+			return
+		}
+		for _, m := range t.ScheduleByMonth.Months.Months {
+			if v, ok := taskMonths[m.XMLName.Local]; ok {
+				months = append(months, strconv.Itoa(v))
+			}
+		}
+		dom = strings.Join(days, ",")
+		if len(months) > 0 && len(months) < 12 {
+			month = strings.Join(months, ",")
+		}
+	default:
+		r.warnf("the trigger starting at %s is not converted: only the daily, weekly and monthly triggers are", t.StartBoundary)
+		return
+	}
+	if t.Repetition != nil {
+		if minute, hour, err = repeat(start, t.Repetition.Interval, t.Repetition.Duration); err != nil {
+			r.warnf("the repetition of the trigger starting at %s is not converted: %v", t.StartBoundary, err)
+			if once {
+				return
+			}
+			minute, hour = strconv.Itoa(start.Minute()), strconv.Itoa(start.Hour())
+		}
+	}
+	r.DAG.Schedule = append(r.DAG.Schedule, strings.Join([]string{minute, hour, dom, month, dow}, " "))
+}
+
+// parseStartBoundary parses the start boundary of a trigger, which is in the
+// local time of the host unless it is zoned.
+func parseStartBoundary(s string) (t time.Time, zoned bool, err error) {
+	s = strings.TrimSpace(s)
+	if t, err = time.Parse("2006-01-02T15:04:05.999999999", s); err == nil {
+		return t, false, nil
+	}
+	if t, err = time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("invalid start boundary %q", s)
+}
+
+// repeat returns the minute and the hour fields of the repetition of a
+// trigger every interval for the duration from the start, all day if the
+// duration is empty or a day or longer. The intervals must divide an hour or
+// a day.
+func repeat(start time.Time, interval, duration string) (string, string, error) {
+	every, err := parseISODuration(interval)
+	if err != nil {
+		return "", "", err
+	}
+	hours := "*"
+	if duration != "" {
+		d, err := parseISODuration(duration)
+		if err != nil {
+			return "", "", err
+		}
+		if d < 24*time.Hour {
+			last := start.Add(d - time.Minute)
+			if last.Day() != start.Day() {
+				return "", "", fmt.Errorf("the repetition for %s passes midnight", duration)
+			}
+			hours = fmt.Sprintf("%d-%d", start.Hour(), last.Hour())
+		}
+	}
+	switch {
+	case every < time.Hour && time.Hour%every == 0 && every%time.Minute == 0:
+		m := int(every / time.Minute)
+		return fmt.Sprintf("%d/%d", start.Minute()%m, m), hours, nil
+	case every >= time.Hour && 24*time.Hour%every == 0 && every%time.Hour == 0 && hours == "*":
+		h := int(every / time.Hour)
+		return strconv.Itoa(start.Minute()), fmt.Sprintf("%d/%d", start.Hour()%h, h), nil
+	}
+	return "", "", fmt.Errorf("the interval %s does not divide an hour or a day", interval)
+}
+
+var reISODuration = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseISODuration parses the ISO 8601 durations of the Task Scheduler,
+// e.g. PT15M or P1D.
+func parseISODuration(s string) (time.Duration, error) {
+	m := reISODuration.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil || s == "P" || s == "PT" {
+		return 0, fmt.Errorf("%w: %q", errInvalidDuration, s)
+	}
+	var d time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if m[i+1] == "" {
+			continue
+		}
+		n, _ := strconv.Atoi(m[i+1])
+		d += time.Duration(n) * unit
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%w: %q", errInvalidDuration, s)
+	}
+	return d, nil
+}
+
+// splitWindowsArgs splits the arguments of a command line of Windows. The
+// arguments are separated by white spaces outside of the double quotes, and
+// \" is a double quote.
+func splitWindowsArgs(s string) []string {
+	var args []string
+	var cur strings.Builder
+	quoted, inArg := false, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && s[i+1] == '"':
+			cur.WriteByte('"')
+			inArg = true
+			i++
+		case c == '"':
+			quoted = !quoted
+			inArg = true
+		case (c == ' ' || c == '\t') && !quoted:
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteByte(c)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args
+}
@@ -0,0 +1,90 @@
+package importer
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskScheduler(t *testing.T) {
+	// The export is in UTF-16 as schtasks writes it.
+	r, err := TaskScheduler(filepath.Join("testdata", "sales_export.xml"))
+	require.NoError(t, err)
+
+	require.Equal(t, "SalesExport", r.Name)
+	require.Equal(t, "Exports the sales report", r.DAG.Description)
+	require.Equal(t, []string{
+		"15 6 * * 1,4",
+		"0/30 8-17 * * *",
+		"0 23 1,15 1,4,7,10 *",
+	}, r.DAG.Schedule)
+	require.Equal(t, []*Step{{
+		Name:    "export.exe",
+		Command: []string{`C:\Program Files\Reports\export.exe`, "--format", "csv", "--out", `D:\Reports\sales report.csv`},
+		Dir:     `D:\Reports`,
+	}}, r.DAG.Steps)
+
+	require.Len(t, r.Warnings, 2)
+	require.Contains(t, r.Warnings[0], "the LogonTrigger is not converted")
+	require.Contains(t, r.Warnings[1], `the task runs as CONTOSO\reports`)
+
+	data, err := r.YAML()
+	require.NoError(t, err)
+	d, err := (&dag.Loader{}).LoadData(data)
+	require.NoError(t, err)
+	require.Len(t, d.Schedule, 3)
+	require.Equal(t, `C:\Program Files\Reports\export.exe`, d.Steps[0].Command)
+}
+
+func TestRepeat(t *testing.T) {
+	start := time.Date(2024, 1, 10, 8, 5, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		interval, duration string
+		minute, hour       string
+		err                bool
+	}{
+		{interval: "PT15M", minute: "5/15", hour: "*"},
+		{interval: "PT10M", duration: "PT2H", minute: "5/10", hour: "8-10"},
+		{interval: "PT1H", duration: "P1D", minute: "5", hour: "0/1"},
+		{interval: "PT6H", minute: "5", hour: "2/6"},
+		{interval: "PT7M", err: true},
+		{interval: "PT5H", err: true},
+		{interval: "PT30M", duration: "PT20H", err: true},
+	} {
+		minute, hour, err := repeat(start, tt.interval, tt.duration)
+		if tt.err {
+			require.Error(t, err, tt.interval)
+			continue
+		}
+		require.NoError(t, err, tt.interval)
+		require.Equal(t, tt.minute, minute, tt.interval)
+		require.Equal(t, tt.hour, hour, tt.interval)
+	}
+}
+
+func TestParseISODuration(t *testing.T) {
+	for s, want := range map[string]time.Duration{
+		"PT15M":     15 * time.Minute,
+		"P1D":       24 * time.Hour,
+		"P1DT2H":    26 * time.Hour,
+		"PT1H30M":   90 * time.Minute,
+		"PT90S":     90 * time.Second,
+		"PT0H15M0S": 15 * time.Minute,
+	} {
+		d, err := parseISODuration(s)
+		require.NoError(t, err, s)
+		require.Equal(t, want, d, s)
+	}
+	for _, s := range []string{"", "P", "PT", "PT0M", "15M", "P1W"} {
+		_, err := parseISODuration(s)
+		require.ErrorIs(t, err, errInvalidDuration, s)
+	}
+}
+
+func TestSplitWindowsArgs(t *testing.T) {
+	require.Equal(t, []string{"-a", "b c", `say "hi"`, ""}, splitWindowsArgs(`-a "b c"  "say \"hi\"" ""`))
+	require.Nil(t, splitWindowsArgs("  "))
+}
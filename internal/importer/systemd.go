@@ -0,0 +1,370 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-shellwords"
+)
+
+// unit is a systemd unit file: the values of the keys of its sections.
+type unit map[string]map[string][]string
+
+func (u unit) get(section, key string) string {
+	values := u[section][key]
+	if len(values) == 0 {
+		return ""
+	}
+	return values[len(values)-1]
+}
+
+// parseUnit parses a unit file. An empty value resets the values of the key
+// before it, as in systemd.
+func parseUnit(data []byte) (unit, error) {
+	u := unit{}
+	section := ""
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	var line string
+	for n := 1; sc.Scan(); n++ {
+		text := strings.TrimSpace(sc.Text())
+		if cont, ok := strings.CutSuffix(text, `\`); ok {
+			line += strings.TrimSpace(cont) + " "
+			continue
+		}
+		line, text = "", line+text
+		switch {
+		case text == "", text[0] == '#', text[0] == ';':
+		case text[0] == '[' && text[len(text)-1] == ']':
+			section = text[1 : len(text)-1]
+		default:
+			key, value, ok := strings.Cut(text, "=")
+			if !ok || section == "" {
+				return nil, fmt.Errorf("line %d: invalid line: %s", n, text)
+			}
+			key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+			if u[section] == nil {
+				u[section] = map[string][]string{}
+			}
+			if value == "" {
+				u[section][key] = nil
+				continue
+			}
+			u[section][key] = append(u[section][key], value)
+		}
+	}
+	return u, sc.Err()
+}
+
+// Systemd converts the systemd timer and the service it activates, the
+// unit of its Unit key or the service of the same name in the same
+// directory, to a DAG.
+func Systemd(timerFile string) (*Result, error) {
+	data, err := os.ReadFile(timerFile)
+	if err != nil {
+		return nil, err
+	}
+	timer, err := parseUnit(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", timerFile, err)
+	}
+	name := strings.TrimSuffix(filepath.Base(timerFile), ".timer")
+	serviceName := timer.get("Timer", "Unit")
+	if serviceName == "" {
+		serviceName = name + ".service"
+	}
+	serviceFile := filepath.Join(filepath.Dir(timerFile), serviceName)
+	if data, err = os.ReadFile(serviceFile); err != nil {
+		return nil, err
+	}
+	service, err := parseUnit(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", serviceFile, err)
+	}
+
+	r := &Result{Name: dagName(name), Source: timerFile, DAG: &DAG{}}
+	r.DAG.Description = service.get("Unit", "Description")
+	if r.DAG.Description == "" {
+		r.DAG.Description = timer.get("Unit", "Description")
+	}
+	r.convertTimer(timer)
+	if err := r.convertService(service); err != nil {
+		return nil, fmt.Errorf("%s: %w", serviceFile, err)
+	}
+	return r, nil
+}
+
+// monotonicTimers are the keys of the timers relative to the boot or to the
+// last activation, which have no equivalent in a cron schedule.
+var monotonicTimers = []string{
+	"OnActiveSec", "OnBootSec", "OnStartupSec", "OnUnitActiveSec", "OnUnitInactiveSec",
+}
+
+func (r *Result) convertTimer(timer unit) {
+	for _, spec := range timer["Timer"]["OnCalendar"] {
+		c, err := calendarToCron(spec)
+		if err != nil {
+			r.warnf("OnCalendar=%s is not converted: %v", spec, err)
+			continue
+		}
+		r.DAG.Schedule = append(r.DAG.Schedule, c)
+	}
+	for _, key := range monotonicTimers {
+		for _, v := range timer["Timer"][key] {
+			r.warnf("%s=%s is not converted: a DAG has no schedule relative to the boot or to its runs", key, v)
+		}
+	}
+	if v := timer.get("Timer", "Persistent"); v == "true" || v == "yes" {
+		r.warnf("Persistent=%s is not converted: the runs missed while the scheduler is down are not run", v)
+	}
+	if v := timer.get("Timer", "RandomizedDelaySec"); v != "" {
+		r.warnf("RandomizedDelaySec=%s is not converted: the runs start on the schedule", v)
+	}
+	if len(r.DAG.Schedule) == 0 {
+		r.warnf("the timer has no schedule that is converted: the DAG is run manually")
+	}
+}
+
+func (r *Result) convertService(service unit) error {
+	s := service["Service"]
+	for _, kv := range s["Environment"] {
+		vars, err := shellwords.Parse(kv)
+		if err != nil {
+			return fmt.Errorf("invalid Environment=%s: %w", kv, err)
+		}
+		for _, v := range vars {
+			key, value, _ := strings.Cut(v, "=")
+			r.DAG.Env = append(r.DAG.Env, map[string]string{key: value})
+		}
+	}
+	for _, f := range s["EnvironmentFile"] {
+		r.DAG.Dotenv = append(r.DAG.Dotenv, strings.TrimPrefix(f, "-"))
+	}
+	dir := strings.TrimPrefix(service.get("Service", "WorkingDirectory"), "-")
+	if strings.HasPrefix(dir, "~") {
+		r.warnf("WorkingDirectory=%s is not converted: the home directory of the user is not known", dir)
+		dir = ""
+	}
+	user := service.get("Service", "User")
+	if user != "" && user != "root" {
+		r.warnf("User=%s is converted to sudo: the user that runs dagu must be allowed to run the commands as %s without a password", user, user)
+	}
+	if g := service.get("Service", "Group"); g != "" {
+		r.warnf("Group=%s is not converted: the commands run with the groups of the user", g)
+	}
+	for _, key := range []string{"ExecStartPre", "ExecStart", "ExecStartPost"} {
+		for _, line := range s[key] {
+			r.addExec(key, line, dir, user)
+		}
+	}
+	if len(r.DAG.Steps) == 0 {
+		return errNoCommand
+	}
+	return nil
+}
+
+// addExec adds the step of a command line of the service. The prefixes of
+// the special executables are removed: with -, the failure of the command is
+// ignored.
+func (r *Result) addExec(key, line, dir, user string) {
+	ignoreFailure := false
+	for len(line) > 0 && strings.ContainsRune("-@:+!", rune(line[0])) {
+		switch line[0] {
+		case '-':
+			ignoreFailure = true
+		default:
+			r.warnf("the %c prefix of %s=%s is not converted", line[0], key, line)
+		}
+		line = line[1:]
+	}
+	if strings.Contains(line, "%") {
+		r.warnf("the specifiers of %s=%s are not converted", key, line)
+	}
+	program, _, _ := strings.Cut(line, " ")
+	if user != "" && user != "root" {
+		line = fmt.Sprintf("sudo -u %s -- %s", user, line)
+	}
+	r.addStep(program, line, dir, ignoreFailure)
+}
+
+// calendarShorthands are the cron expressions of the shorthands of the
+// calendar events.
+var calendarShorthands = map[string]string{
+	"minutely":     "* * * * *",
+	"hourly":       "0 * * * *",
+	"daily":        "0 0 * * *",
+	"weekly":       "0 0 * * 1",
+	"monthly":      "0 0 1 * *",
+	"quarterly":    "0 0 1 1,4,7,10 *",
+	"semiannually": "0 0 1 1,7 *",
+	"yearly":       "0 0 1 1 *",
+	"annually":     "0 0 1 1 *",
+}
+
+// weekdays are the numbers of the days of the week in cron, with Sunday as
+// 7 to convert the ranges that end on Sunday.
+var weekdays = map[string]int{
+	"mon": 1, "monday": 1,
+	"tue": 2, "tuesday": 2,
+	"wed": 3, "wednesday": 3,
+	"thu": 4, "thursday": 4,
+	"fri": 5, "friday": 5,
+	"sat": 6, "saturday": 6,
+	"sun": 7, "sunday": 7,
+}
+
+// calendarToCron converts a calendar event of OnCalendar, of the form
+// [<weekdays>] [<year>-]<month>-<day> [<hour>:<minute>[:<second>]]
+// [<time zone>], to a cron expression. The events with years or seconds
+// other than 0 have no equivalent in cron.
+func calendarToCron(spec string) (string, error) {
+	fields := strings.Fields(spec)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("%w: %q", errNoSchedule, spec)
+	}
+	prefix := ""
+	if tz := fields[len(fields)-1]; len(fields) > 1 && !strings.Contains(tz, ":") && (strings.Contains(tz, "/") || tz == "UTC") {
+		prefix = "CRON_TZ=" + tz + " "
+		fields = fields[:len(fields)-1]
+	}
+	if len(fields) == 1 {
+		if c, ok := calendarShorthands[strings.ToLower(fields[0])]; ok {
+			return prefix + c, nil
+		}
+	}
+	dow := "*"
+	if days, err := convertWeekdays(fields[0]); err == nil {
+		dow = days
+		fields = fields[1:]
+	}
+	date, clock := "*-*-*", "00:00:00"
+	for _, f := range fields {
+		switch {
+		case strings.Contains(f, ":"):
+			clock = f
+		case strings.Contains(f, "-"):
+			date = f
+		default:
+			return "", fmt.Errorf("%w: %q", errNoSchedule, spec)
+		}
+	}
+	d := strings.Split(date, "-")
+	if len(d) == 2 {
+		d = append([]string{"*"}, d...)
+	}
+	if len(d) != 3 {
+		return "", fmt.Errorf("%w: date of %q", errNoSchedule, spec)
+	}
+	if d[0] != "*" {
+		return "", fmt.Errorf("%w: years of %q", errNoSchedule, spec)
+	}
+	t := strings.Split(clock, ":")
+	if len(t) == 3 {
+		if sec, err := strconv.Atoi(t[2]); err != nil || sec != 0 {
+			return "", fmt.Errorf("%w: seconds of %q", errNoSchedule, spec)
+		}
+		t = t[:2]
+	}
+	if len(t) != 2 {
+		return "", fmt.Errorf("%w: time of %q", errNoSchedule, spec)
+	}
+	var cron []string
+	for _, f := range []struct {
+		value    string
+		min, max int
+	}{{t[1], 0, 59}, {t[0], 0, 23}, {d[2], 1, 31}, {d[1], 1, 12}} {
+		v, err := calendarField(f.value, f.min, f.max)
+		if err != nil {
+			return "", fmt.Errorf("%w: %q: %v", errNoSchedule, spec, err)
+		}
+		cron = append(cron, v)
+	}
+	return prefix + strings.Join(append(cron, dow), " "), nil
+}
+
+// calendarField converts a component of a calendar event, a list of values,
+// ranges of the form a..b and repetitions of the form a/n, to a field of a
+// cron expression.
+func calendarField(s string, min, max int) (string, error) {
+	if s == "*" {
+		return s, nil
+	}
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		start, step, hasStep := strings.Cut(item, "/")
+		from, to, isRange := strings.Cut(start, "..")
+		var v string
+		if start == "*" {
+			v = "*"
+		} else {
+			a, err := fieldValue(from, min, max)
+			if err != nil {
+				return "", err
+			}
+			v = strconv.Itoa(a)
+			if isRange {
+				b, err := fieldValue(to, min, max)
+				if err != nil {
+					return "", err
+				}
+				v += "-" + strconv.Itoa(b)
+			}
+		}
+		if hasStep {
+			n, err := strconv.Atoi(step)
+			if err != nil || n < 1 {
+				return "", fmt.Errorf("invalid repetition %q", item)
+			}
+			v += "/" + strconv.Itoa(n)
+		}
+		items = append(items, v)
+	}
+	return strings.Join(items, ","), nil
+}
+
+func fieldValue(s string, min, max int) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < min || v > max {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	return v, nil
+}
+
+// convertWeekdays converts the weekdays of a calendar event, e.g.
+// Mon..Fri,Sun, to the day of the week field of cron.
+func convertWeekdays(s string) (string, error) {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		from, to, isRange := strings.Cut(item, "..")
+		a, ok := weekdays[strings.ToLower(from)]
+		if !ok {
+			return "", fmt.Errorf("invalid weekday %q", from)
+		}
+		b := a
+		if isRange {
+			if b, ok = weekdays[strings.ToLower(to)]; !ok || b < a {
+				return "", fmt.Errorf("invalid weekdays %q", item)
+			}
+		}
+		// Sunday is 0 in cron.
+		sunday := b == 7
+		if sunday {
+			b--
+		}
+		switch {
+		case a > b:
+		case a == b:
+			items = append(items, strconv.Itoa(a))
+		default:
+			items = append(items, fmt.Sprintf("%d-%d", a, b))
+		}
+		if sunday {
+			items = append(items, "0")
+		}
+	}
+	return strings.Join(items, ","), nil
+}
@@ -0,0 +1,90 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarToCron(t *testing.T) {
+	for _, tt := range []struct {
+		spec string
+		want string
+		err  bool
+	}{
+		{spec: "daily", want: "0 0 * * *"},
+		{spec: "hourly", want: "0 * * * *"},
+		{spec: "Mon..Fri *-*-* 09:30", want: "30 9 * * 1-5"},
+		{spec: "Sat..Sun 03:00", want: "0 3 * * 6,0"},
+		{spec: "Mon,Wed 12:00:00", want: "0 12 * * 1,3"},
+		{spec: "*-*-01 06:00", want: "0 6 1 * *"},
+		{spec: "*-01,07-01 00:00", want: "0 0 1 1,7 *"},
+		{spec: "*-*-* *:0/15", want: "0/15 * * * *"},
+		{spec: "*-*-* 08..18:00", want: "0 8-18 * * *"},
+		{spec: "Mon 04:00 Europe/Berlin", want: "CRON_TZ=Europe/Berlin 0 4 * * 1"},
+		{spec: "*-*-* 04:00:30", err: true},
+		{spec: "2024-*-* 04:00", err: true},
+		{spec: "*-*-* 25:00", err: true},
+		{spec: "Someday 04:00", err: true},
+	} {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := calendarToCron(tt.spec)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSystemd(t *testing.T) {
+	r, err := Systemd(filepath.Join("testdata", "backup.timer"))
+	require.NoError(t, err)
+
+	require.Equal(t, "backup", r.Name)
+	require.Equal(t, "Back up the database", r.DAG.Description)
+	require.Equal(t, []string{"30 2 * * 1-5", "CRON_TZ=Europe/Berlin 0 4 * * 6,0"}, r.DAG.Schedule)
+	require.Equal(t, []map[string]string{{"TARGET": "s3://backups/db"}, {"RETENTION": "7"}}, r.DAG.Env)
+	require.Equal(t, []string{"/etc/default/backup"}, r.DAG.Dotenv)
+
+	require.Len(t, r.DAG.Steps, 2)
+	require.Equal(t, &Step{
+		Name:       "mkdir",
+		Command:    "sudo -u backup -- /usr/bin/mkdir -p /srv/backup/tmp",
+		Dir:        "/srv/backup",
+		ContinueOn: &ContinueOn{Failure: true},
+	}, r.DAG.Steps[0])
+	require.Equal(t, &Step{
+		Name:    "backup.sh",
+		Command: "sudo -u backup -- /usr/local/bin/backup.sh --target ${TARGET} --retention ${RETENTION}",
+		Dir:     "/srv/backup",
+		Depends: []string{"mkdir"},
+	}, r.DAG.Steps[1])
+
+	require.Len(t, r.Warnings, 3)
+	require.Contains(t, r.Warnings[0], "OnBootSec=15min is not converted")
+	require.Contains(t, r.Warnings[1], "Persistent=true is not converted")
+	require.Contains(t, r.Warnings[2], "User=backup is converted to sudo")
+
+	// The DAG file is loaded as a DAG.
+	data, err := r.YAML()
+	require.NoError(t, err)
+	require.Contains(t, string(data), "# WARNING: OnBootSec=15min is not converted")
+	d, err := (&dag.Loader{}).LoadData(data)
+	require.NoError(t, err)
+	require.Len(t, d.Steps, 2)
+	require.Len(t, d.Schedule, 2)
+}
+
+func TestSystemdWithoutService(t *testing.T) {
+	dir := t.TempDir()
+	timer := filepath.Join(dir, "missing.timer")
+	require.NoError(t, os.WriteFile(timer, []byte("[Timer]\nOnCalendar=daily\n"), 0600))
+	_, err := Systemd(timer)
+	require.Error(t, err)
+}
@@ -0,0 +1,258 @@
+// Package ldap authenticates the users with a bind to an LDAP directory,
+// e.g. Active Directory, and finds their groups.
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+var (
+	// ErrInvalidCredentials is returned if the user is not found or the
+	// password is wrong.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrNotInGroup is returned if the user is in none of the required
+	// groups.
+	ErrNotInGroup = errors.New("the user is not in a required group")
+
+	errMissingConfig = errors.New("the URL, the base DN and the user filter of LDAP are required")
+	errInvalidURL    = errors.New("the URL of LDAP must be ldap://host[:port] or ldaps://host[:port]")
+	errInvalidFilter = errors.New("invalid LDAP filter")
+	errInvalidCACert = errors.New("no CA certificate in the file")
+	errServiceBind   = errors.New("bind of the service account failed")
+)
+
+const defaultTimeout = time.Second * 10
+
+// Config is the directory the users are authenticated with.
+type Config struct {
+	// URL is the URL of the server, ldap://host:389 or ldaps://host:636.
+	URL string
+	// StartTLS upgrades the connection of an ldap URL to TLS before any
+	// credentials are sent.
+	StartTLS bool
+	// CACertFile has the certificates of the CAs that the certificate of the
+	// server is verified with, in addition to the ones of the system.
+	CACertFile         string
+	InsecureSkipVerify bool
+	// BindDN and BindPassword are the credentials of the account that
+	// searches the users and their groups. The searches are anonymous if
+	// BindDN is empty.
+	BindDN       string
+	BindPassword string
+	// BaseDN is the base of the search of the users.
+	BaseDN string
+	// UserFilter finds the entry of a user, with {username} replaced by the
+	// name of the user, e.g. (uid={username}).
+	UserFilter string
+	// GroupBaseDN is the base of the search of the groups, BaseDN if it is
+	// empty.
+	GroupBaseDN string
+	// GroupFilter finds the groups of a user, with {dn} replaced by the DN
+	// of the user and {username} by its name, e.g. (member={dn}). The
+	// groups are not searched if it is empty.
+	GroupFilter string
+	// GroupAttribute is the attribute of the name of a group, e.g. cn.
+	GroupAttribute string
+	// RequiredGroups are the groups one of which the users must be in to
+	// log in, if there are any.
+	RequiredGroups []string
+	// Timeout is the timeout of an authentication.
+	Timeout time.Duration
+}
+
+// Authenticator authenticates the users with the directory of its
+// configuration.
+type Authenticator struct {
+	config    Config
+	url       string
+	ldaps     bool
+	tlsConfig *tls.Config
+}
+
+// New returns the authenticator of the configuration.
+func New(cfg Config) (*Authenticator, error) {
+	if cfg.URL == "" || cfg.BaseDN == "" || cfg.UserFilter == "" {
+		return nil, errMissingConfig
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") || u.Hostname() == "" {
+		return nil, fmt.Errorf("%w: %s", errInvalidURL, cfg.URL)
+	}
+	a := &Authenticator{config: cfg, url: u.Scheme + "://" + u.Host, ldaps: u.Scheme == "ldaps"}
+	if a.config.GroupBaseDN == "" {
+		a.config.GroupBaseDN = cfg.BaseDN
+	}
+	if a.config.GroupAttribute == "" {
+		a.config.GroupAttribute = "cn"
+	}
+	if a.config.Timeout <= 0 {
+		a.config.Timeout = defaultTimeout
+	}
+	for _, f := range []string{cfg.UserFilter, cfg.GroupFilter} {
+		if f == "" {
+			continue
+		}
+		if _, err := ldap.CompileFilter(expandFilter(f, "user", "cn=user")); err != nil {
+			return nil, fmt.Errorf("%w: %s: %s", errInvalidFilter, f, err)
+		}
+	}
+	a.tlsConfig = &tls.Config{
+		ServerName:         u.Hostname(),
+		InsecureSkipVerify: cfg.InsecureSkipVerify, // nolint:gosec
+	}
+	if cfg.CACertFile != "" {
+		pem, err := os.ReadFile(cfg.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: %s", errInvalidCACert, cfg.CACertFile)
+		}
+		a.tlsConfig.RootCAs = pool
+	}
+	return a, nil
+}
+
+// expandFilter replaces the placeholders of the filter with the escaped
+// name and DN of the user.
+func expandFilter(filter, username, dn string) string {
+	return strings.NewReplacer("{username}", ldap.EscapeFilter(username), "{dn}", ldap.EscapeFilter(dn)).Replace(filter)
+}
+
+// Authenticate binds as the user with the password, and returns the groups
+// of the user.
+func (a *Authenticator) Authenticate(ctx context.Context, username, password string) ([]string, error) {
+	// A bind without a password is an unauthenticated bind, which succeeds.
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+	ctx, cancel := context.WithTimeout(ctx, a.config.Timeout)
+	defer cancel()
+	c, err := a.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = c.Close()
+	}()
+	// The connection is closed if the request is canceled, which fails the
+	// operation in progress.
+	stop := context.AfterFunc(ctx, func() {
+		_ = c.Close()
+	})
+	defer stop()
+
+	if err := bindService(c, a.config); err != nil {
+		return nil, err
+	}
+	users, err := search(c, a.config.BaseDN, expandFilter(a.config.UserFilter, username, ""), []string{"1.1"})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+	dn := users[0].DN
+	if err := bind(c, dn, password); err != nil {
+		return nil, err
+	}
+
+	var groups []string
+	if a.config.GroupFilter != "" {
+		if err := bindService(c, a.config); err != nil {
+			return nil, err
+		}
+		entries, err := search(c, a.config.GroupBaseDN, expandFilter(a.config.GroupFilter, username, dn), []string{a.config.GroupAttribute})
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if v := e.GetEqualFoldAttributeValue(a.config.GroupAttribute); v != "" {
+				groups = append(groups, v)
+			}
+		}
+	}
+	if len(a.config.RequiredGroups) > 0 && !slices.ContainsFunc(groups, func(g string) bool {
+		return slices.Contains(a.config.RequiredGroups, g)
+	}) {
+		return nil, fmt.Errorf("%w: %s", ErrNotInGroup, username)
+	}
+	return groups, nil
+}
+
+// dial connects to the server, over TLS if the URL is ldaps or with
+// StartTLS. The operations time out after the timeout of the configuration.
+func (a *Authenticator) dial(ctx context.Context) (*ldap.Conn, error) {
+	d := &net.Dialer{Timeout: a.config.Timeout}
+	if deadline, ok := ctx.Deadline(); ok {
+		d.Deadline = deadline
+	}
+	c, err := ldap.DialURL(a.url, ldap.DialWithDialer(d), ldap.DialWithTLSConfig(a.tlsConfig))
+	if err != nil {
+		return nil, err
+	}
+	c.SetTimeout(a.config.Timeout)
+	if a.config.StartTLS && !a.ldaps {
+		if err := c.StartTLS(a.tlsConfig); err != nil {
+			_ = c.Close()
+			return nil, fmt.Errorf("StartTLS: %w", err)
+		}
+	}
+	return c, nil
+}
+
+func bindService(c *ldap.Conn, cfg Config) error {
+	if cfg.BindDN == "" {
+		return nil
+	}
+	if err := bind(c, cfg.BindDN, cfg.BindPassword); err != nil {
+		// A wrong password of the service account is not the fault of the
+		// user.
+		if errors.Is(err, ErrInvalidCredentials) {
+			return fmt.Errorf("%w: invalid credentials of %s", errServiceBind, cfg.BindDN)
+		}
+		return fmt.Errorf("%w: %s: %s", errServiceBind, cfg.BindDN, err)
+	}
+	return nil
+}
+
+// bind authenticates the connection as the DN with the password.
+func bind(c *ldap.Conn, dn, password string) error {
+	err := c.Bind(dn, password)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+		return ErrInvalidCredentials
+	}
+	return err
+}
+
+// search returns the entries under the base that match the filter, with
+// the attributes, and none if the base does not exist.
+func search(c *ldap.Conn, base, filter string, attrs []string) ([]*ldap.Entry, error) {
+	res, err := c.Search(ldap.NewSearchRequest(base,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter, attrs, nil,
+	))
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res.Entries, nil
+}
@@ -0,0 +1,284 @@
+package ldap
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/require"
+)
+
+type entry struct {
+	dn    string
+	attrs map[string][]string
+}
+
+// fakeServer is a directory that supports the operations of the client.
+type fakeServer struct {
+	listener  net.Listener
+	tlsConfig *tls.Config
+	passwords map[string]string
+	entries   []entry
+}
+
+func newFakeServer(t *testing.T, tlsConfig *tls.Config) *fakeServer {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+	s := &fakeServer{
+		listener:  l,
+		tlsConfig: tlsConfig,
+		passwords: map[string]string{
+			"cn=reader,dc=example,dc=com":           "reader-secret",
+			"uid=alice,ou=people,dc=example,dc=com": "alice-secret",
+			"uid=bob,ou=people,dc=example,dc=com":   "bob-secret",
+		},
+		entries: []entry{
+			{dn: "uid=alice,ou=people,dc=example,dc=com", attrs: map[string][]string{"uid": {"alice"}}},
+			{dn: "uid=bob,ou=people,dc=example,dc=com", attrs: map[string][]string{"uid": {"bob"}}},
+			{dn: "cn=data-eng,ou=groups,dc=example,dc=com", attrs: map[string][]string{
+				"cn":     {"data-eng"},
+				"member": {"uid=alice,ou=people,dc=example,dc=com"},
+			}},
+			{dn: "cn=finance,ou=groups,dc=example,dc=com", attrs: map[string][]string{
+				"cn":     {"finance"},
+				"member": {"uid=alice,ou=people,dc=example,dc=com", "uid=bob,ou=people,dc=example,dc=com"},
+			}},
+		},
+	}
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(c)
+		}
+	}()
+	return s
+}
+
+func (s *fakeServer) url(scheme string) string {
+	return scheme + "://" + s.listener.Addr().String()
+}
+
+func (s *fakeServer) serve(c net.Conn) {
+	defer func() { _ = c.Close() }()
+	var bound string
+	for {
+		msg, err := ber.ReadPacket(c)
+		if err != nil || len(msg.Children) < 2 {
+			return
+		}
+		id, op := msg.Children[0].Value, msg.Children[1]
+		reply := func(op *ber.Packet) {
+			m := ber.NewSequence("")
+			m.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+			m.AppendChild(op)
+			_, _ = c.Write(m.Bytes())
+		}
+		done := func(tag ber.Tag, code int64) {
+			op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+			op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, ""))
+			op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+			op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+			reply(op)
+		}
+		switch op.Tag {
+		case ldap.ApplicationExtendedRequest:
+			if s.tlsConfig == nil {
+				done(ldap.ApplicationExtendedResponse, ldap.LDAPResultProtocolError)
+				continue
+			}
+			done(ldap.ApplicationExtendedResponse, ldap.LDAPResultSuccess)
+			tc := tls.Server(c, s.tlsConfig)
+			if tc.Handshake() != nil {
+				return
+			}
+			c = tc
+		case ldap.ApplicationBindRequest:
+			dn, password := op.Children[1].Value.(string), op.Children[2].Data.String()
+			if pw, ok := s.passwords[dn]; !ok || pw != password {
+				done(ldap.ApplicationBindResponse, ldap.LDAPResultInvalidCredentials)
+				continue
+			}
+			bound = dn
+			done(ldap.ApplicationBindResponse, ldap.LDAPResultSuccess)
+		case ldap.ApplicationSearchRequest:
+			// Only the service account may search.
+			if bound != "cn=reader,dc=example,dc=com" {
+				done(ldap.ApplicationSearchResultDone, ldap.LDAPResultInsufficientAccessRights)
+				continue
+			}
+			base := op.Children[0].Value.(string)
+			for _, e := range s.entries {
+				if !strings.HasSuffix(e.dn, base) || !match(op.Children[6], e) {
+					continue
+				}
+				res := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+				res.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, ""))
+				attrs := ber.NewSequence("")
+				for _, a := range op.Children[7].Children {
+					name := a.Value.(string)
+					values := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+					for _, v := range e.attrs[name] {
+						values.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, ""))
+					}
+					attr := ber.NewSequence("")
+					attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+					attr.AppendChild(values)
+					attrs.AppendChild(attr)
+				}
+				res.AppendChild(attrs)
+				reply(res)
+			}
+			done(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess)
+		case ldap.ApplicationUnbindRequest:
+			return
+		}
+	}
+}
+
+// match evaluates the equality and the conjunction filters.
+func match(f *ber.Packet, e entry) bool {
+	switch f.Tag {
+	case ldap.FilterAnd:
+		for _, c := range f.Children {
+			if !match(c, e) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterEqualityMatch:
+		for _, v := range e.attrs[f.Children[0].Data.String()] {
+			if strings.EqualFold(v, f.Children[1].Data.String()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func testConfig(url string) Config {
+	return Config{
+		URL:          url,
+		BindDN:       "cn=reader,dc=example,dc=com",
+		BindPassword: "reader-secret",
+		BaseDN:       "ou=people,dc=example,dc=com",
+		UserFilter:   "(uid={username})",
+		GroupBaseDN:  "ou=groups,dc=example,dc=com",
+		GroupFilter:  "(member={dn})",
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	s := newFakeServer(t, nil)
+	a, err := New(testConfig(s.url("ldap")))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	groups, err := a.Authenticate(ctx, "alice", "alice-secret")
+	require.NoError(t, err)
+	require.Equal(t, []string{"data-eng", "finance"}, groups)
+
+	_, err = a.Authenticate(ctx, "alice", "wrong")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = a.Authenticate(ctx, "alice", "")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = a.Authenticate(ctx, "carol", "alice-secret")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+
+	// The name is escaped in the filter.
+	_, err = a.Authenticate(ctx, "*", "alice-secret")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+
+	cfg := testConfig(s.url("ldap"))
+	cfg.RequiredGroups = []string{"data-eng"}
+	a, err = New(cfg)
+	require.NoError(t, err)
+	_, err = a.Authenticate(ctx, "bob", "bob-secret")
+	require.ErrorIs(t, err, ErrNotInGroup)
+	_, err = a.Authenticate(ctx, "alice", "alice-secret")
+	require.NoError(t, err)
+
+	// The service account must be able to bind.
+	cfg.BindPassword = "wrong"
+	a, err = New(cfg)
+	require.NoError(t, err)
+	_, err = a.Authenticate(ctx, "alice", "alice-secret")
+	require.ErrorIs(t, err, errServiceBind)
+	require.NotErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestStartTLS(t *testing.T) {
+	certFile, tlsConfig := testCertificate(t)
+	s := newFakeServer(t, tlsConfig)
+	cfg := testConfig(s.url("ldap"))
+	cfg.StartTLS = true
+
+	// The certificate of the server must be trusted.
+	a, err := New(cfg)
+	require.NoError(t, err)
+	_, err = a.Authenticate(context.Background(), "alice", "alice-secret")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrInvalidCredentials)
+
+	cfg.CACertFile = certFile
+	a, err = New(cfg)
+	require.NoError(t, err)
+	groups, err := a.Authenticate(context.Background(), "alice", "alice-secret")
+	require.NoError(t, err)
+	require.Equal(t, []string{"data-eng", "finance"}, groups)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(Config{URL: "ldap://localhost"})
+	require.ErrorIs(t, err, errMissingConfig)
+	cfg := testConfig("http://localhost")
+	_, err = New(cfg)
+	require.ErrorIs(t, err, errInvalidURL)
+	cfg = testConfig("ldaps://localhost")
+	cfg.GroupFilter = "(member={dn}"
+	_, err = New(cfg)
+	require.ErrorIs(t, err, errInvalidFilter)
+
+	_, err = New(testConfig("ldaps://ldap.example.com"))
+	require.NoError(t, err)
+}
+
+// testCertificate returns the file of a self-signed certificate for
+// 127.0.0.1 and the configuration of a server with it.
+func testCertificate(t *testing.T) (string, *tls.Config) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	return file, &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+}
@@ -0,0 +1,229 @@
+package logfile
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// An encrypted log starts with the magic and the ID of its key, followed by
+// a record for each write: the length of the sealed contents, the nonce and
+// the contents sealed with AES-GCM. The records are appended like the
+// writes of a plain log, so that the log is read while it is written.
+const (
+	magic      = "DAGULOG1"
+	keyIDSize  = 8
+	headerSize = len(magic) + keyIDSize
+	// KeySize is the size of the keys of the logs, AES-256.
+	KeySize = 32
+	// maxRecordSize bounds the length of a record read from a log.
+	maxRecordSize = 1 << 26
+)
+
+var (
+	errInvalidKey    = errors.New("the key of the logs must be 32 bytes encoded in base64")
+	errUnknownKey    = errors.New("the log is encrypted with an unknown key")
+	errNotEncrypted  = errors.New("the log exists and is not encrypted with the key")
+	errInvalidRecord = errors.New("invalid record of an encrypted log")
+)
+
+var (
+	keysMu sync.RWMutex
+	// keys are the ciphers of the keys registered to read the logs by the
+	// IDs of the keys.
+	keys = map[string]cipher.AEAD{}
+)
+
+// LoadKey reads the key of the logs from the file, 32 bytes encoded in
+// base64, e.g. generated with openssl rand -base64 32.
+func LoadKey(file string) ([]byte, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil || len(key) != KeySize {
+		return nil, fmt.Errorf("%w: %s", errInvalidKey, file)
+	}
+	return key, nil
+}
+
+// RegisterKey registers the key, so that the logs encrypted with it are read
+// by Open, OpenFrom and Follow.
+func RegisterKey(key []byte) error {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+	keysMu.Lock()
+	defer keysMu.Unlock()
+	keys[keyID(key)] = aead
+	return nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, errInvalidKey
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// keyID identifies the key of a log without revealing it.
+func keyID(key []byte) string {
+	sum := sha256.Sum256(append([]byte(magic), key...))
+	return string(sum[:keyIDSize])
+}
+
+func header(key []byte) []byte {
+	return append([]byte(magic), keyID(key)...)
+}
+
+// aeadOf returns the cipher of the key of the header of a log, or nil if
+// the log is not encrypted.
+func aeadOf(head []byte) (cipher.AEAD, error) {
+	if len(head) < headerSize || string(head[:len(magic)]) != magic {
+		return nil, nil
+	}
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+	aead, ok := keys[string(head[len(magic):headerSize])]
+	if !ok {
+		return nil, errUnknownKey
+	}
+	return aead, nil
+}
+
+// seal returns the record of the contents.
+func seal(aead cipher.AEAD, p []byte) ([]byte, error) {
+	size := aead.NonceSize() + len(p) + aead.Overhead()
+	record := make([]byte, 4+aead.NonceSize(), 4+size)
+	binary.BigEndian.PutUint32(record, uint32(size-aead.NonceSize()))
+	if _, err := rand.Read(record[4:]); err != nil {
+		return nil, err
+	}
+	return aead.Seal(record, record[4:], p, nil), nil
+}
+
+// decrypt returns the reader of the contents of the log read from r, which
+// are decrypted if the log is encrypted. The record being written at the end
+// of the log, if any, is not read.
+func decrypt(r io.ReadCloser) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(headerSize)
+	aead, err := aeadOf(head)
+	if err != nil {
+		_ = r.Close()
+		return nil, err
+	}
+	if aead == nil {
+		return &reader{Reader: br, close: func() error { return nil }, file: r}, nil
+	}
+	_, _ = br.Discard(headerSize)
+	return &reader{Reader: &recordReader{r: br, aead: aead}, close: func() error { return nil }, file: r}, nil
+}
+
+type recordReader struct {
+	r    io.Reader
+	aead cipher.AEAD
+	buf  []byte
+}
+
+func (r *recordReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		record, err := readRecord(r.r, r.aead)
+		if err != nil {
+			return 0, err
+		}
+		r.buf = record
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// readRecord returns the contents of the next record. It returns io.EOF at
+// the end of the log, and at a record that is not complete yet.
+func readRecord(r io.Reader, aead cipher.AEAD) ([]byte, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return nil, endOfLog(err)
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n > maxRecordSize {
+		return nil, errInvalidRecord
+	}
+	b := make([]byte, aead.NonceSize()+int(n))
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, endOfLog(err)
+	}
+	contents, err := aead.Open(nil, b[:aead.NonceSize()], b[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidRecord, err)
+	}
+	return contents, nil
+}
+
+func endOfLog(err error) error {
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return io.EOF
+	}
+	return err
+}
+
+// recordFollower writes the contents of the complete records of an
+// encrypted log that is being written, from the position of the first
+// record it did not write, skipping the contents before the offset.
+type recordFollower struct {
+	f    *os.File
+	aead cipher.AEAD
+	pos  int64
+	skip int64
+}
+
+func (rf *recordFollower) copyTo(w io.Writer) error {
+	for {
+		size := make([]byte, 4)
+		if _, err := rf.f.ReadAt(size, rf.pos); err != nil {
+			return ignoreEOF(err)
+		}
+		n := int64(binary.BigEndian.Uint32(size))
+		if n > maxRecordSize {
+			return errInvalidRecord
+		}
+		r := io.NewSectionReader(rf.f, rf.pos, 4+int64(rf.aead.NonceSize())+n)
+		contents, err := readRecord(r, rf.aead)
+		if err != nil {
+			return ignoreEOF(err)
+		}
+		rf.pos += 4 + int64(rf.aead.NonceSize()) + n
+		if rf.skip >= int64(len(contents)) {
+			rf.skip -= int64(len(contents))
+			continue
+		}
+		if _, err := w.Write(contents[rf.skip:]); err != nil {
+			return err
+		}
+		rf.skip = 0
+	}
+}
+
+func ignoreEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
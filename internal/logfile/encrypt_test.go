@@ -0,0 +1,122 @@
+package logfile
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(t *testing.T) []byte {
+	t.Helper()
+	key := make([]byte, KeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return key
+}
+
+func TestLoadKey(t *testing.T) {
+	dir := t.TempDir()
+	key := testKey(t)
+	file := filepath.Join(dir, "key")
+	require.NoError(t, os.WriteFile(file, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600))
+	loaded, err := LoadKey(file)
+	require.NoError(t, err)
+	require.Equal(t, key, loaded)
+
+	require.NoError(t, os.WriteFile(file, []byte("c2hvcnQ="), 0600))
+	_, err = LoadKey(file)
+	require.ErrorIs(t, err, errInvalidKey)
+}
+
+func TestEncryptedLog(t *testing.T) {
+	tmpDir := utils.MustTempDir("test-logfile")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	key := testKey(t)
+
+	// The contents are not in the files of the log, the rotated ones
+	// included.
+	path := filepath.Join(tmpDir, "step.log")
+	w, err := NewEncryptedWriter(path, 60, 2, key)
+	require.NoError(t, err)
+	for _, line := range []string{"first secret\n", "second secret\n"} {
+		_, err := w.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	files := Files(path)
+	require.Len(t, files, 2)
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		require.NoError(t, err)
+		require.NotContains(t, string(b), "secret")
+	}
+
+	// The log is not read without its key.
+	_, err = ReadFile(path)
+	require.ErrorIs(t, err, errUnknownKey)
+	require.NoError(t, RegisterKey(key))
+	b, err := ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "second secret\n", string(b))
+
+	// An encrypted log is appended with its key only.
+	w, err = NewEncryptedWriter(path, 0, 0, key)
+	require.NoError(t, err)
+	_, err = w.Write([]byte("third secret\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	_, err = NewEncryptedWriter(path, 0, 0, testKey(t))
+	require.ErrorIs(t, err, errNotEncrypted)
+	plain := filepath.Join(tmpDir, "plain.log")
+	require.NoError(t, os.WriteFile(plain, []byte("plain\n"), 0600))
+	_, err = NewEncryptedWriter(plain, 0, 0, key)
+	require.ErrorIs(t, err, errNotEncrypted)
+
+	// The compressed log is decompressed and decrypted.
+	require.NoError(t, Compress(path, CompressionZstd))
+	b, err = ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "second secret\nthird secret\n", string(b))
+}
+
+func TestFollowEncrypted(t *testing.T) {
+	tmpDir := utils.MustTempDir("test-logfile")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	key := testKey(t)
+	require.NoError(t, RegisterKey(key))
+
+	path := filepath.Join(tmpDir, "step.log")
+	var finished atomic.Bool
+	var buf bytes.Buffer
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Follow(context.Background(), path, 2, time.Millisecond*10, finished.Load, &buf)
+	}()
+
+	// The records are followed from the offset of the contents across the
+	// rotations of the log.
+	w, err := NewEncryptedWriter(path, 60, 5, key)
+	require.NoError(t, err)
+	for _, line := range []string{"first\n", "second\n", "third\n"} {
+		_, err := w.Write([]byte(line))
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond * 30)
+	}
+	require.NoError(t, w.Close())
+	finished.Store(true)
+	require.NoError(t, <-errCh)
+	require.Equal(t, "rst\nsecond\nthird\n", buf.String())
+}
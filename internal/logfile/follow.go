@@ -0,0 +1,178 @@
+package logfile
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+)
+
+// Follow writes the contents of the log at path from the offset to w, and
+// then what is appended to it every interval like tail -f, until done
+// returns true or the context is done. The log is read to its end after done
+// returns true, so that nothing written before is missed. A rotated log is
+// read to its end before its new file is followed. Follow waits for the log
+// if it does not exist yet, e.g. while its step has not started. A log that
+// was compressed is complete, so it is written from the offset at once. The
+// offset is the one of the contents of an encrypted log.
+func Follow(ctx context.Context, path string, offset int64, interval time.Duration, done func() bool, w io.Writer) error {
+	var f *os.File
+	var src source
+	defer func() {
+		if f != nil {
+			_ = f.Close()
+		}
+	}()
+	for {
+		finished := done()
+		if f == nil {
+			var err error
+			f, err = os.Open(path)
+			switch {
+			case err == nil:
+				if src, err = newSource(f, offset); err != nil {
+					return err
+				}
+			case !os.IsNotExist(err):
+				return err
+			case Find(path) != path:
+				return copyFrom(path, offset, w)
+			}
+		}
+		if f != nil {
+			rotated, err := drain(f, src, path, w)
+			if err != nil {
+				return err
+			}
+			if rotated {
+				_ = f.Close()
+				f = nil
+				offset = 0
+				continue
+			}
+		}
+		if finished {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// source writes what was appended to the file of a log since the last
+// time.
+type source interface {
+	copyTo(w io.Writer) error
+}
+
+type plainSource struct {
+	f *os.File
+}
+
+func (s plainSource) copyTo(w io.Writer) error {
+	_, err := io.Copy(w, s.f)
+	return err
+}
+
+// newSource returns the source of the file of a log from the offset. The
+// records of an encrypted log are followed from its header. The source of a
+// log that may be the one of an encrypted log whose header is not written
+// yet waits for the header.
+func newSource(f *os.File, offset int64) (source, error) {
+	head := make([]byte, headerSize)
+	n, _ := f.ReadAt(head, 0)
+	if n < headerSize && isHeaderPrefix(head[:n]) {
+		return &pendingSource{f: f, offset: offset}, nil
+	}
+	aead, err := aeadOf(head[:n])
+	if err != nil {
+		return nil, err
+	}
+	if aead != nil {
+		return &recordFollower{f: f, aead: aead, pos: int64(headerSize), skip: offset}, nil
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return plainSource{f: f}, nil
+}
+
+// isHeaderPrefix returns true if b may be the start of the header of an
+// encrypted log.
+func isHeaderPrefix(b []byte) bool {
+	k := min(len(b), len(magic))
+	return string(b[:k]) == magic[:k]
+}
+
+// pendingSource is the source of a log whose header is not complete yet.
+type pendingSource struct {
+	f      *os.File
+	offset int64
+	src    source
+}
+
+func (s *pendingSource) copyTo(w io.Writer) error {
+	if s.src == nil {
+		src, err := newSource(s.f, s.offset)
+		if err != nil {
+			return err
+		}
+		if _, ok := src.(*pendingSource); ok {
+			return nil
+		}
+		s.src = src
+	}
+	return s.src.copyTo(w)
+}
+
+// drain writes the contents of f to its end to w. It returns true if the log
+// at path was rotated, i.e. it is no longer f.
+func drain(f *os.File, src source, path string, w io.Writer) (bool, error) {
+	if err := src.copyTo(w); err != nil {
+		return false, err
+	}
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) && Find(path) != path {
+		// The log was compressed, and f is complete.
+		return false, nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	if err == nil {
+		current, err := f.Stat()
+		if err != nil {
+			return false, err
+		}
+		if os.SameFile(info, current) {
+			return false, nil
+		}
+	}
+	// The log was rotated, and what was written to f before is read before
+	// its new file.
+	if err := src.copyTo(w); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func copyFrom(path string, offset int64, w io.Writer) error {
+	r, err := Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	if _, err := io.CopyN(io.Discard, r, offset); err != nil {
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+	_, err = io.Copy(w, r)
+	return err
+}
@@ -0,0 +1,55 @@
+package logfile
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFollow(t *testing.T) {
+	tmpDir := utils.MustTempDir("test-logfile")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	path := filepath.Join(tmpDir, "step.log")
+	var finished atomic.Bool
+	var buf bytes.Buffer
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Follow(context.Background(), path, 0, time.Millisecond*10, finished.Load, &buf)
+	}()
+
+	// The log is followed across its rotations.
+	w, err := NewWriter(path, 10, 5)
+	require.NoError(t, err)
+	lines := []string{"first\n", "second\n", "third\n", "fourth\n"}
+	for _, line := range lines {
+		_, err := w.Write([]byte(line))
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond * 30)
+	}
+	require.NoError(t, w.Close())
+	finished.Store(true)
+	require.NoError(t, <-errCh)
+	require.Equal(t, "first\nsecond\nthird\nfourth\n", buf.String())
+
+	// The compressed log is read from the offset.
+	require.NoError(t, Compress(path, CompressionGzip))
+	buf.Reset()
+	require.NoError(t, Follow(context.Background(), path, 3, time.Millisecond, finished.Load, &buf))
+	require.Equal(t, "rth\n", buf.String())
+
+	// The following stops with the context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = Follow(ctx, filepath.Join(tmpDir, "other.log"), 0, time.Millisecond, func() bool { return false }, &buf)
+	require.ErrorIs(t, err, context.Canceled)
+}
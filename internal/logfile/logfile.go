@@ -0,0 +1,335 @@
+// Package logfile writes the step logs with a size-based rotation, compresses
+// them after the steps finish and reads them whether they are compressed or
+// not.
+//
+// A log rotated at path keeps its previous contents in path.1, path.2, ...
+// from the latest. A compressed log has the extension of its compression
+// appended to the path of each of its files, e.g. path.gz and path.1.gz.
+//
+// The logs written with a key are encrypted, and they are read with the keys
+// registered with RegisterKey.
+package logfile
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// The compressions of the logs.
+const (
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+var errUnknownCompression = errors.New("unknown log compression")
+
+var exts = map[string]string{
+	CompressionGzip: ".gz",
+	CompressionZstd: ".zst",
+}
+
+// Find returns the path of the file of the log at path, the compressed one
+// if the log was compressed. It returns path if there is no file.
+func Find(path string) string {
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	for _, name := range Names(path)[1:] {
+		if _, err := os.Stat(name); err == nil {
+			return name
+		}
+	}
+	return path
+}
+
+// Names returns the names the file of the log at path may have: path, and
+// path with the extensions of the compressions.
+func Names(path string) []string {
+	return []string{path, path + exts[CompressionGzip], path + exts[CompressionZstd]}
+}
+
+// Files returns the paths of the existing files of the log at path,
+// including the rotated ones, from the latest.
+func Files(path string) []string {
+	var files []string
+	for i := 0; ; i++ {
+		f := Find(rotated(path, i))
+		if _, err := os.Stat(f); err != nil {
+			return files
+		}
+		files = append(files, f)
+	}
+}
+
+// Open opens the log at path for reading. The contents of a compressed log
+// are decompressed. The rotated files are not read.
+func Open(path string) (io.ReadCloser, error) {
+	file := Find(path)
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	return NewReader(f, file)
+}
+
+// OpenFrom opens the log of the name from another storage than the local
+// disk, e.g. an object storage, like Open. open opens a file of the storage
+// and returns an error wrapping fs.ErrNotExist if there is none.
+func OpenFrom(open func(name string) (io.ReadCloser, error), name string) (io.ReadCloser, error) {
+	for _, n := range Names(name) {
+		r, err := open(n)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return NewReader(r, n)
+	}
+	return nil, fmt.Errorf("%w: %s", fs.ErrNotExist, name)
+}
+
+// NewReader returns the reader of the contents of the file of a log read
+// from r, decompressed according to the extension of the name of the file,
+// and decrypted if the log is encrypted. Closing it closes r.
+func NewReader(r io.ReadCloser, name string) (io.ReadCloser, error) {
+	switch filepath.Ext(name) {
+	case exts[CompressionGzip]:
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			_ = r.Close()
+			return nil, err
+		}
+		return decrypt(&reader{Reader: zr, close: zr.Close, file: r})
+	case exts[CompressionZstd]:
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			_ = r.Close()
+			return nil, err
+		}
+		return decrypt(&reader{Reader: zr, close: func() error { zr.Close(); return nil }, file: r})
+	}
+	return decrypt(r)
+}
+
+// ReadFile reads the log at path like Open.
+func ReadFile(path string) ([]byte, error) {
+	r, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	return io.ReadAll(r)
+}
+
+type reader struct {
+	io.Reader
+	close func() error
+	file  io.Closer
+}
+
+func (r *reader) Close() error {
+	err := r.close()
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	return err
+}
+
+// Compress compresses the files of the log at path that are not compressed
+// yet and removes them. It does nothing if the compression is empty.
+func Compress(path, compression string) error {
+	if compression == "" {
+		return nil
+	}
+	if _, ok := exts[compression]; !ok {
+		return fmt.Errorf("%w: %s", errUnknownCompression, compression)
+	}
+	for i := 0; ; i++ {
+		file := rotated(path, i)
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			if Find(file) != file {
+				continue
+			}
+			return nil
+		}
+		if err := compress(file, compression); err != nil {
+			return fmt.Errorf("failed to compress %s: %w", file, err)
+		}
+	}
+}
+
+func compress(file, compression string) error {
+	src, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+	dst, err := os.OpenFile(file+exts[compression], os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	var w io.WriteCloser
+	if compression == CompressionZstd {
+		w, err = zstd.NewWriter(dst)
+	} else {
+		w = gzip.NewWriter(dst)
+	}
+	if err == nil {
+		_, err = io.Copy(w, src)
+		if cerr := w.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(dst.Name())
+		return err
+	}
+	return os.Remove(file)
+}
+
+func rotated(path string, i int) string {
+	if i == 0 {
+		return path
+	}
+	return path + "." + strconv.Itoa(i)
+}
+
+// Writer writes to the log at path. It rotates the log before a write would
+// make it larger than MaxBytes, keeping MaxFiles rotated files.
+type Writer struct {
+	path     string
+	maxBytes int64
+	maxFiles int
+	file     *os.File
+	size     int64
+	// key and aead are the key and the cipher of an encrypted log.
+	key  []byte
+	aead cipher.AEAD
+}
+
+// NewWriter opens the log at path for appending, or creates it. The log is
+// not rotated if maxBytes is zero. At least one rotated file is kept.
+func NewWriter(path string, maxBytes int64, maxFiles int) (*Writer, error) {
+	return NewEncryptedWriter(path, maxBytes, maxFiles, nil)
+}
+
+// NewEncryptedWriter opens the log at path like NewWriter, and encrypts what
+// is written to it with the key unless it is nil. An existing log must be
+// encrypted with the key.
+func NewEncryptedWriter(path string, maxBytes int64, maxFiles int, key []byte) (*Writer, error) {
+	w := &Writer{path: path, maxBytes: maxBytes, maxFiles: max(maxFiles, 1), key: key}
+	if key != nil {
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		w.aead = aead
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *Writer) open() error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0755)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	w.file, w.size = f, info.Size()
+	if w.aead == nil {
+		return nil
+	}
+	if w.size == 0 {
+		n, err := f.Write(header(w.key))
+		w.size += int64(n)
+		return err
+	}
+	head := make([]byte, headerSize)
+	if _, err := f.ReadAt(head, 0); err != nil || !bytes.Equal(head, header(w.key)) {
+		_ = f.Close()
+		return fmt.Errorf("%w: %s", errNotEncrypted, w.path)
+	}
+	return nil
+}
+
+// Name returns the path of the log.
+func (w *Writer) Name() string {
+	return w.path
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	b := p
+	if w.aead != nil {
+		record, err := seal(w.aead, p)
+		if err != nil {
+			return 0, err
+		}
+		b = record
+	}
+	if w.maxBytes > 0 && w.size > w.initialSize() && w.size+int64(len(b)) > w.maxBytes {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(b)
+	w.size += int64(n)
+	if w.aead != nil && err == nil {
+		// The contents of the record are written.
+		n = len(p)
+	}
+	return n, err
+}
+
+// initialSize is the size of an empty log, the header of an encrypted one.
+func (w *Writer) initialSize() int64 {
+	if w.aead != nil {
+		return int64(headerSize)
+	}
+	return 0
+}
+
+func (w *Writer) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	_ = os.Remove(rotated(w.path, w.maxFiles))
+	for i := w.maxFiles - 1; i >= 0; i-- {
+		if err := os.Rename(rotated(w.path, i), rotated(w.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return w.open()
+}
+
+// Sync commits the contents of the log to the storage.
+func (w *Writer) Sync() error {
+	return w.file.Sync()
+}
+
+func (w *Writer) Close() error {
+	return w.file.Close()
+}
@@ -0,0 +1,95 @@
+package logfile
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	tmpDir := utils.MustTempDir("test-logfile")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	path := filepath.Join(tmpDir, "step.log")
+	w, err := NewWriter(path, 10, 2)
+	require.NoError(t, err)
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := w.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	require.Equal(t, []string{path, path + ".1", path + ".2"}, Files(path))
+	for file, want := range map[string]string{path: "fourth\n", path + ".1": "third\n", path + ".2": "second\n"} {
+		b, err := os.ReadFile(file)
+		require.NoError(t, err)
+		require.Equal(t, want, string(b))
+	}
+}
+
+func TestCompress(t *testing.T) {
+	for _, compression := range []string{CompressionGzip, CompressionZstd} {
+		t.Run(compression, func(t *testing.T) {
+			tmpDir := utils.MustTempDir("test-logfile")
+			defer func() {
+				_ = os.RemoveAll(tmpDir)
+			}()
+
+			path := filepath.Join(tmpDir, "step.log")
+			data := strings.Repeat("line\n", 100)
+			require.NoError(t, os.WriteFile(path, []byte(data), 0600))
+			require.NoError(t, os.WriteFile(path+".1", []byte("rotated\n"), 0600))
+
+			require.NoError(t, Compress(path, compression))
+			ext := exts[compression]
+			require.NoFileExists(t, path)
+			require.Equal(t, path+ext, Find(path))
+			require.Equal(t, []string{path + ext, path + ".1" + ext}, Files(path))
+
+			b, err := ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, data, string(b))
+			b, err = ReadFile(path + ".1")
+			require.NoError(t, err)
+			require.Equal(t, "rotated\n", string(b))
+
+			// The files already compressed are kept.
+			require.NoError(t, Compress(path, compression))
+			require.Equal(t, []string{path + ext, path + ".1" + ext}, Files(path))
+		})
+	}
+
+	require.ErrorIs(t, Compress("step.log", "bzip2"), errUnknownCompression)
+}
+
+func TestOpenFrom(t *testing.T) {
+	tmpDir := utils.MustTempDir("test-logfile")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	path := filepath.Join(tmpDir, "step.log")
+	require.NoError(t, os.WriteFile(path, []byte("remote\n"), 0600))
+	require.NoError(t, Compress(path, CompressionZstd))
+	open := func(name string) (io.ReadCloser, error) {
+		return os.Open(filepath.Join(tmpDir, name))
+	}
+
+	r, err := OpenFrom(open, "step.log")
+	require.NoError(t, err)
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, "remote\n", string(b))
+
+	_, err = OpenFrom(open, "other.log")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
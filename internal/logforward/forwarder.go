@@ -0,0 +1,272 @@
+package logforward
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+)
+
+// Entry is a single log line with its labels.
+type Entry struct {
+	Time   time.Time
+	Line   string
+	Labels map[string]string
+}
+
+// Sink sends a batch of entries to an external log store.
+type Sink interface {
+	Send(ctx context.Context, entries []Entry) error
+	Close() error
+}
+
+const (
+	TypeSyslog        = "syslog"
+	TypeLoki          = "loki"
+	TypeElasticsearch = "elasticsearch"
+
+	defaultBatchSize     = 100
+	defaultFlushInterval = time.Second
+	sendTimeout          = time.Second * 10
+)
+
+var errUnknownType = errors.New("unknown log forwarding type")
+
+// Options configures the batching and rate limiting of a Forwarder.
+type Options struct {
+	// Labels are added to every entry.
+	Labels        map[string]string
+	BatchSize     int
+	FlushInterval time.Duration
+	// RateLimit is the maximum number of lines per second. Lines over the
+	// limit are dropped. Zero means no limit.
+	RateLimit int
+}
+
+// Forwarder batches log lines and ships them to a Sink in the background.
+type Forwarder struct {
+	sink          Sink
+	labels        map[string]string
+	batchSize     int
+	flushInterval time.Duration
+	limiter       *rateLimiter
+	entries       chan Entry
+	dropped       atomic.Int64
+	done          chan struct{}
+	wg            sync.WaitGroup
+	closeOnce     sync.Once
+}
+
+// NewFromConfig creates a Forwarder from the global configuration.
+func NewFromConfig(cfg *config.LogForward) (*Forwarder, error) {
+	var (
+		sink Sink
+		err  error
+	)
+	switch cfg.Type {
+	case TypeSyslog:
+		sink, err = NewSyslogSink(cfg.Network, cfg.Address, cfg.Tag)
+	case TypeLoki:
+		sink = NewLokiSink(cfg.URL)
+	case TypeElasticsearch:
+		sink = NewElasticsearchSink(cfg.URL, cfg.Index)
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownType, cfg.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return New(sink, Options{
+		Labels:        cfg.Labels,
+		BatchSize:     cfg.BatchSize,
+		FlushInterval: time.Second * time.Duration(cfg.FlushIntervalSec),
+		RateLimit:     cfg.RateLimit,
+	}), nil
+}
+
+// New creates a Forwarder and starts shipping entries to the sink.
+func New(sink Sink, opts Options) *Forwarder {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	flushInterval := opts.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = defaultFlushInterval
+	}
+	f := &Forwarder{
+		sink:          sink,
+		labels:        opts.Labels,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		entries:       make(chan Entry, batchSize*4),
+		done:          make(chan struct{}),
+	}
+	if opts.RateLimit > 0 {
+		f.limiter = newRateLimiter(opts.RateLimit)
+	}
+	f.wg.Add(1)
+	go f.loop()
+	return f
+}
+
+// Send queues a line for forwarding. It never blocks; the line is dropped
+// if the rate limit is exceeded or the queue is full.
+func (f *Forwarder) Send(line string, labels map[string]string) {
+	if f.limiter != nil && !f.limiter.allow() {
+		f.dropped.Add(1)
+		return
+	}
+	e := Entry{Time: time.Now(), Line: line, Labels: f.mergeLabels(labels)}
+	select {
+	case f.entries <- e:
+	default:
+		f.dropped.Add(1)
+	}
+}
+
+// Writer returns a writer that forwards each written line with the labels.
+func (f *Forwarder) Writer(labels map[string]string) io.WriteCloser {
+	return &lineWriter{forwarder: f, labels: labels}
+}
+
+// Close flushes the queued entries and closes the sink.
+func (f *Forwarder) Close() (err error) {
+	f.closeOnce.Do(func() {
+		close(f.done)
+		f.wg.Wait()
+		err = f.sink.Close()
+	})
+	return err
+}
+
+func (f *Forwarder) mergeLabels(labels map[string]string) map[string]string {
+	ret := make(map[string]string, len(f.labels)+len(labels))
+	for k, v := range f.labels {
+		ret[k] = v
+	}
+	for k, v := range labels {
+		ret[k] = v
+	}
+	return ret
+}
+
+func (f *Forwarder) loop() {
+	defer f.wg.Done()
+	ticker := time.NewTicker(f.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Entry, 0, f.batchSize)
+	flush := func() {
+		if n := f.dropped.Swap(0); n > 0 {
+			batch = append(batch, Entry{
+				Time:   time.Now(),
+				Line:   fmt.Sprintf("%d log lines were dropped by the log forwarder", n),
+				Labels: f.mergeLabels(nil),
+			})
+		}
+		if len(batch) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		defer cancel()
+		if err := f.sink.Send(ctx, batch); err != nil {
+			log.Printf("failed to forward %d log lines: %v", len(batch), err)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case e := <-f.entries:
+			batch = append(batch, e)
+			if len(batch) >= f.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-f.done:
+			for {
+				select {
+				case e := <-f.entries:
+					batch = append(batch, e)
+					if len(batch) >= f.batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// lineWriter splits the written data into lines and forwards them.
+type lineWriter struct {
+	forwarder *Forwarder
+	labels    map[string]string
+	mu        sync.Mutex
+	buf       []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.forwarder.Send(string(bytes.TrimRight(w.buf[:i], "\r")), w.labels)
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Close forwards the remaining data that does not end with a newline.
+func (w *lineWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) > 0 {
+		w.forwarder.Send(string(w.buf), w.labels)
+		w.buf = nil
+	}
+	return nil
+}
+
+// rateLimiter is a token bucket that allows up to rate events per second.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate int) *rateLimiter {
+	return &rateLimiter{rate: float64(rate), tokens: float64(rate), last: time.Now()}
+}
+
+func (r *rateLimiter) allow() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	r.tokens += now.Sub(r.last).Seconds() * r.rate
+	if r.tokens > r.rate {
+		r.tokens = r.rate
+	}
+	r.last = now
+	if r.tokens < 1 {
+		return false
+	}
+	r.tokens--
+	return true
+}
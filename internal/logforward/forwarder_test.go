@@ -0,0 +1,125 @@
+package logforward
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockSink struct {
+	mu      sync.Mutex
+	batches [][]Entry
+}
+
+func (s *mockSink) Send(_ context.Context, entries []Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, append([]Entry{}, entries...))
+	return nil
+}
+
+func (s *mockSink) Close() error {
+	return nil
+}
+
+func (s *mockSink) entries() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ret []Entry
+	for _, b := range s.batches {
+		ret = append(ret, b...)
+	}
+	return ret
+}
+
+func TestForwarder(t *testing.T) {
+	sink := &mockSink{}
+	f := New(sink, Options{
+		Labels:        map[string]string{"host": "test"},
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+	})
+
+	w := f.Writer(map[string]string{"dag": "d", "step": "s"})
+	_, err := w.Write([]byte("line1\nline2\nli"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("ne3"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	entries := sink.entries()
+	require.Len(t, entries, 3)
+	for i, line := range []string{"line1", "line2", "line3"} {
+		require.Equal(t, line, entries[i].Line)
+		require.Equal(t, map[string]string{"host": "test", "dag": "d", "step": "s"}, entries[i].Labels)
+	}
+	require.Len(t, sink.batches[0], 2)
+}
+
+func TestForwarderRateLimit(t *testing.T) {
+	sink := &mockSink{}
+	f := New(sink, Options{RateLimit: 2, FlushInterval: time.Hour})
+	for i := 0; i < 5; i++ {
+		f.Send("line", nil)
+	}
+	require.NoError(t, f.Close())
+
+	entries := sink.entries()
+	require.Len(t, entries, 3)
+	require.Equal(t, "3 log lines were dropped by the log forwarder", entries[2].Line)
+}
+
+func TestLokiSink(t *testing.T) {
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/loki/api/v1/push", r.URL.Path)
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	sink := NewLokiSink(srv.URL)
+	err := sink.Send(context.Background(), []Entry{
+		{Time: time.Unix(1, 0), Line: "a", Labels: map[string]string{"dag": "d"}},
+		{Time: time.Unix(2, 0), Line: "b", Labels: map[string]string{"dag": "d"}},
+	})
+	require.NoError(t, err)
+
+	var payload struct {
+		Streams []lokiStream `json:"streams"`
+	}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	require.Len(t, payload.Streams, 1)
+	require.Equal(t, map[string]string{"dag": "d"}, payload.Streams[0].Stream)
+	require.Equal(t, [][2]string{{"1000000000", "a"}, {"2000000000", "b"}}, payload.Streams[0].Values)
+}
+
+func TestElasticsearchSink(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/_bulk", r.URL.Path)
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer srv.Close()
+
+	sink := NewElasticsearchSink(srv.URL, "logs")
+	err := sink.Send(context.Background(), []Entry{
+		{Time: time.Unix(1, 0).UTC(), Line: "a", Labels: map[string]string{"dag": "d"}},
+	})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	require.Len(t, lines, 2)
+	require.JSONEq(t, `{"index":{"_index":"logs"}}`, lines[0])
+	require.JSONEq(t, `{"@timestamp":"1970-01-01T00:00:01Z","message":"a","dag":"d"}`, lines[1])
+}
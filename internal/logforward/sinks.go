@@ -0,0 +1,175 @@
+package logforward
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/syslog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SyslogSink writes entries to a local or remote syslog daemon.
+type SyslogSink struct {
+	writer *syslog.Writer
+}
+
+// NewSyslogSink connects to the syslog daemon. An empty network connects
+// to the local daemon.
+func NewSyslogSink(network, address, tag string) (*SyslogSink, error) {
+	if tag == "" {
+		tag = "dagu"
+	}
+	w, err := syslog.Dial(network, address, syslog.LOG_INFO|syslog.LOG_USER, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogSink{writer: w}, nil
+}
+
+func (s *SyslogSink) Send(_ context.Context, entries []Entry) error {
+	for _, e := range entries {
+		if err := s.writer.Info(formatLabels(e.Labels) + " " + e.Line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SyslogSink) Close() error {
+	return s.writer.Close()
+}
+
+// LokiSink pushes entries to the Loki push API.
+type LokiSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewLokiSink(url string) *LokiSink {
+	return &LokiSink{
+		url:    strings.TrimSuffix(url, "/") + "/loki/api/v1/push",
+		client: &http.Client{},
+	}
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+func (s *LokiSink) Send(ctx context.Context, entries []Entry) error {
+	streams := map[string]*lokiStream{}
+	var keys []string
+	for _, e := range entries {
+		key := formatLabels(e.Labels)
+		st, ok := streams[key]
+		if !ok {
+			st = &lokiStream{Stream: e.Labels}
+			streams[key] = st
+			keys = append(keys, key)
+		}
+		st.Values = append(st.Values, [2]string{strconv.FormatInt(e.Time.UnixNano(), 10), e.Line})
+	}
+	payload := struct {
+		Streams []*lokiStream `json:"streams"`
+	}{}
+	for _, k := range keys {
+		payload.Streams = append(payload.Streams, streams[k])
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return post(ctx, s.client, s.url, "application/json", body)
+}
+
+func (s *LokiSink) Close() error {
+	return nil
+}
+
+// ElasticsearchSink indexes entries with the Elasticsearch bulk API.
+type ElasticsearchSink struct {
+	url    string
+	index  string
+	client *http.Client
+}
+
+func NewElasticsearchSink(url, index string) *ElasticsearchSink {
+	if index == "" {
+		index = "dagu-logs"
+	}
+	return &ElasticsearchSink{
+		url:    strings.TrimSuffix(url, "/") + "/_bulk",
+		index:  index,
+		client: &http.Client{},
+	}
+}
+
+func (s *ElasticsearchSink) Send(ctx context.Context, entries []Entry) error {
+	var buf bytes.Buffer
+	action, err := json.Marshal(map[string]any{"index": map[string]string{"_index": s.index}})
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		doc := map[string]any{
+			"@timestamp": e.Time.Format(time.RFC3339Nano),
+			"message":    e.Line,
+		}
+		for k, v := range e.Labels {
+			doc[k] = v
+		}
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		buf.Write(action)
+		buf.WriteByte('\n')
+		buf.Write(b)
+		buf.WriteByte('\n')
+	}
+	return post(ctx, s.client, s.url, "application/x-ndjson", buf.Bytes())
+}
+
+func (s *ElasticsearchSink) Close() error {
+	return nil
+}
+
+func post(ctx context.Context, client *http.Client, url, contentType string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status %d from %s: %s", resp.StatusCode, url, msg)
+	}
+	return nil
+}
+
+func formatLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%q", k, labels[k]))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
@@ -2,14 +2,20 @@ package mailer
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/smtp"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/secret"
 )
 
 // Mailer is a mailer that sends emails.
@@ -19,35 +25,72 @@ type Mailer struct {
 
 // Config is a config for SMTP mailer.
 type Config struct {
-	Host     string
-	Port     string
+	Host string
+	Port string
+	// Username and Password may be secret references, which are read again
+	// for each mail so that the rotated credentials are used.
 	Username string
 	Password string
+	// Timeout is the timeout of an attempt to send a mail. Zero means no
+	// timeout.
+	Timeout time.Duration
+	// Retries is the number of times a mail is sent again after it failed.
+	Retries int
 }
 
 var (
 	replacer     = strings.NewReplacer("\r\n", "", "\r", "", "\n", "", "%0a", "", "%0d", "")
 	boundary     = "==simple-boundary-dagu-mailer"
 	errFileEmpty = errors.New("file is empty")
+
+	retryInterval = time.Second * 5
 )
 
 // SendMail sends an email.
 func (m *Mailer) SendMail(from string, to []string, subject, body string, attachments []string) error {
 	log.Printf("Sending an email to %s, subject is \"%s\"", strings.Join(to, ","), subject)
-	if m.Username == "" && m.Password == "" {
-		return m.sendWithNoAuth(from, to, subject, body, attachments)
+	username, err := secret.Current(context.Background(), m.Username)
+	if err != nil {
+		return err
+	}
+	password, err := secret.Current(context.Background(), m.Password)
+	if err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if username != "" || password != "" {
+		auth = smtp.PlainAuth("", username, password, m.Host)
+	}
+	for i := 0; i <= m.Retries; i++ {
+		if i > 0 {
+			log.Printf("failed to send an email: %v, retrying in %s", err, retryInterval)
+			time.Sleep(retryInterval)
+		}
+		if err = m.send(auth, from, to, subject, body, attachments); err == nil {
+			return nil
+		}
 	}
-	return m.sendWithAuth(from, to, subject, body, attachments)
+	return err
 }
 
-func (m *Mailer) sendWithNoAuth(from string, to []string, subject, body string, attachments []string) error {
-	c, err := smtp.Dial(m.Host + ":" + m.Port)
+func (m *Mailer) send(auth smtp.Auth, from string, to []string, subject, body string, attachments []string) error {
+	c, err := m.dial()
 	if err != nil {
 		return err
 	}
 	defer func() {
 		_ = c.Close()
 	}()
+	if auth != nil {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err = c.StartTLS(&tls.Config{ServerName: m.Host}); err != nil {
+				return err
+			}
+		}
+		if err = c.Auth(auth); err != nil {
+			return err
+		}
+	}
 	if err = c.Mail(replacer.Replace(from)); err != nil {
 		return err
 	}
@@ -74,13 +117,22 @@ func (m *Mailer) sendWithNoAuth(from string, to []string, subject, body string,
 	return c.Quit()
 }
 
-func (m *Mailer) sendWithAuth(from string, to []string, subject, body string, attachments []string) error {
-	auth := smtp.PlainAuth("", m.Username, m.Password, m.Host)
-	body = newlineToBrTag(body)
-	return smtp.SendMail(
-		m.Host+":"+m.Port, auth, from, to,
-		m.composeMail(to, from, subject, body, attachments),
-	)
+// dial connects to the SMTP server. The whole conversation with the server
+// must end within the timeout.
+func (m *Mailer) dial() (*smtp.Client, error) {
+	addr := m.Host + ":" + m.Port
+	if m.Timeout <= 0 {
+		return smtp.Dial(addr)
+	}
+	conn, err := net.DialTimeout("tcp", addr, m.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.SetDeadline(time.Now().Add(m.Timeout)); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return smtp.NewClient(conn, m.Host)
 }
 
 func (m *Mailer) composeHeader(to []string, from string, subject string) string {
@@ -0,0 +1,180 @@
+// Package oidc is the client of an OpenID Connect provider that the users
+// log in with the authorization code flow.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+var (
+	errMissingConfig  = errors.New("the issuer, the client ID and the redirect URL of OIDC are required")
+	errDiscovery      = errors.New("failed to discover the OIDC provider")
+	errTokenExchange  = errors.New("failed to exchange the code for the tokens")
+	errInvalidIDToken = errors.New("invalid ID token")
+)
+
+// Config is the client of dagu registered with the provider.
+type Config struct {
+	// Issuer is the URL of the provider, whose discovery document is at
+	// <issuer>/.well-known/openid-configuration.
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the URL of the callback of dagu, to which the provider
+	// redirects the users after they logged in.
+	RedirectURL string
+	// Scopes are requested in addition to openid.
+	Scopes []string
+}
+
+// Provider is the OpenID Connect provider of the configuration. Its
+// discovery document is fetched when it is first needed, and its keys when
+// an ID token is signed with an unknown key.
+type Provider struct {
+	config Config
+	client *http.Client
+
+	mu       sync.Mutex
+	oauth2   *oauth2.Config
+	verifier *gooidc.IDTokenVerifier
+}
+
+// New returns the provider of the configuration.
+func New(cfg Config) (*Provider, error) {
+	if cfg.Issuer == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+		return nil, errMissingConfig
+	}
+	return &Provider{config: cfg, client: &http.Client{Timeout: time.Second * 10}}, nil
+}
+
+// RandomValue returns a random value for the state, the nonce or the PKCE
+// verifier of a login.
+func RandomValue() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// AuthCodeURL returns the URL of the login at the provider, with the state
+// and the nonce of the login and the PKCE challenge of its verifier.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	cfg, _, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	return cfg.AuthCodeURL(state, gooidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)), nil
+}
+
+func (p *Provider) scopes() []string {
+	scopes := []string{gooidc.ScopeOpenID}
+	for _, s := range p.config.Scopes {
+		if !slices.Contains(scopes, s) {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+// Exchange exchanges the code of the callback of a login for the tokens,
+// and returns the claims of the ID token once it is verified.
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (Claims, error) {
+	cfg, _, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := cfg.Exchange(gooidc.ClientContext(ctx, p.client), code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errTokenExchange, err)
+	}
+	raw, _ := token.Extra("id_token").(string)
+	if raw == "" {
+		return nil, fmt.Errorf("%w: no ID token", errTokenExchange)
+	}
+	return p.verify(ctx, raw, nonce)
+}
+
+// Claims are the claims of an ID token.
+type Claims map[string]any
+
+// String returns the claim of the name if it is a string.
+func (c Claims) String(name string) string {
+	s, _ := c[name].(string)
+	return s
+}
+
+// Strings returns the claim of the name if it is a list of strings or a
+// string, e.g. the groups of the user.
+func (c Claims) Strings(name string) []string {
+	switch v := c[name].(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var ret []string
+		for _, s := range v {
+			if s, ok := s.(string); ok {
+				ret = append(ret, s)
+			}
+		}
+		return ret
+	}
+	return nil
+}
+
+// verify verifies the signature of the ID token with the keys of the
+// provider, and that it was issued by the provider for the client, has not
+// expired, and has the nonce of the login.
+func (p *Provider) verify(ctx context.Context, raw, nonce string) (Claims, error) {
+	_, verifier, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := verifier.Verify(gooidc.ClientContext(ctx, p.client), raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidIDToken, err)
+	}
+	if token.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", errInvalidIDToken)
+	}
+	var claims Claims
+	if err := token.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidIDToken, err)
+	}
+	return claims, nil
+}
+
+// discover returns the configuration of the client and the verifier of the
+// ID tokens of the provider, which are kept once its discovery document
+// was fetched.
+func (p *Provider) discover(ctx context.Context) (*oauth2.Config, *gooidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.oauth2 != nil {
+		return p.oauth2, p.verifier, nil
+	}
+	// The keys are fetched with the client later on, after the request
+	// that discovered the provider is done.
+	provider, err := gooidc.NewProvider(gooidc.ClientContext(ctx, p.client), p.config.Issuer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", errDiscovery, err)
+	}
+	p.oauth2 = &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.config.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       p.scopes(),
+	}
+	p.verifier = provider.Verifier(&gooidc.Config{ClientID: p.config.ClientID})
+	return p.oauth2, p.verifier, nil
+}
@@ -0,0 +1,157 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProvider is an OpenID Connect provider that issues the ID token of
+// its claims for the code "code" of the verifier.
+type fakeProvider struct {
+	*httptest.Server
+	key      *rsa.PrivateKey
+	verifier string
+	claims   map[string]any
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	f := &fakeProvider{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 f.URL,
+			"authorization_endpoint": f.URL + "/authorize",
+			"token_endpoint":         f.URL + "/token",
+			"jwks_uri":               f.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "k1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		id, secret, _ := r.BasicAuth()
+		challenge := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+		if id != "dagu" || secret != "secret" || r.FormValue("code") != "code" ||
+			base64.RawURLEncoding.EncodeToString(challenge[:]) != f.verifier {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     f.sign(t, f.claims),
+		})
+	})
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeProvider) sign(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "k1"})
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, f.key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestProvider(t *testing.T) {
+	_, err := New(Config{Issuer: "https://idp.example.com"})
+	require.ErrorIs(t, err, errMissingConfig)
+
+	f := newFakeProvider(t)
+	p, err := New(Config{
+		Issuer:       f.URL,
+		ClientID:     "dagu",
+		ClientSecret: "secret",
+		RedirectURL:  "https://dagu.example.com/oidc/callback",
+		Scopes:       []string{"profile", "groups"},
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	state, nonce, verifier := RandomValue(), RandomValue(), RandomValue()
+	authURL, err := p.AuthCodeURL(ctx, state, nonce, verifier)
+	require.NoError(t, err)
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	require.Equal(t, f.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	q := u.Query()
+	require.Equal(t, "openid profile groups", q.Get("scope"))
+	require.Equal(t, state, q.Get("state"))
+	require.Equal(t, nonce, q.Get("nonce"))
+	require.Equal(t, "S256", q.Get("code_challenge_method"))
+	f.verifier = q.Get("code_challenge")
+
+	valid := func() map[string]any {
+		return map[string]any{
+			"iss":                f.URL,
+			"aud":                "dagu",
+			"exp":                time.Now().Add(time.Hour).Unix(),
+			"nonce":              nonce,
+			"sub":                "1234",
+			"preferred_username": "alice",
+			"groups":             []string{"data-eng", "finance"},
+		}
+	}
+	f.claims = valid()
+	claims, err := p.Exchange(ctx, "code", verifier, nonce)
+	require.NoError(t, err)
+	require.Equal(t, "alice", claims.String("preferred_username"))
+	require.Equal(t, []string{"data-eng", "finance"}, claims.Strings("groups"))
+
+	// The code is exchanged with the verifier of the login only.
+	_, err = p.Exchange(ctx, "code", RandomValue(), nonce)
+	require.ErrorIs(t, err, errTokenExchange)
+	_, err = p.Exchange(ctx, "code", verifier, RandomValue())
+	require.ErrorIs(t, err, errInvalidIDToken)
+
+	for name, change := range map[string]func(c map[string]any){
+		"other issuer":   func(c map[string]any) { c["iss"] = "https://other.example.com" },
+		"other audience": func(c map[string]any) { c["aud"] = []string{"other"} },
+		"expired":        func(c map[string]any) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := valid()
+			change(c)
+			f.claims = c
+			_, err := p.Exchange(ctx, "code", verifier, nonce)
+			require.ErrorIs(t, err, errInvalidIDToken)
+		})
+	}
+
+	// The signature is verified with the keys of the provider.
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	forged := &fakeProvider{key: other}
+	_, err = p.verify(ctx, forged.sign(t, valid()), nonce)
+	require.ErrorIs(t, err, errInvalidIDToken)
+}
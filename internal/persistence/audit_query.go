@@ -0,0 +1,40 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+)
+
+// AuditQuery filters the entries of the audit log. The zero value selects
+// all of them.
+type AuditQuery struct {
+	DAG    string
+	Action string
+	// Actor selects the entries of the user or of the token of the name.
+	Actor string
+	// Since and Until select the entries of the time range, if they are
+	// not zero.
+	Since time.Time
+	Until time.Time
+	// Limit is the maximum number of the entries, the latest ones. Zero is
+	// no limit.
+	Limit int
+}
+
+// Match returns true if the query selects the entry.
+func (q *AuditQuery) Match(e *model.AuditEntry) bool {
+	switch {
+	case q.DAG != "" && e.DAG != q.DAG:
+		return false
+	case q.Action != "" && e.Action != q.Action:
+		return false
+	case q.Actor != "" && e.Actor != q.Actor && e.Token != q.Actor:
+		return false
+	case !q.Since.IsZero() && e.Time.Before(q.Since):
+		return false
+	case !q.Until.IsZero() && !e.Time.Before(q.Until):
+		return false
+	}
+	return true
+}
@@ -1,13 +1,19 @@
 package client
 
 import (
+	"log"
 	"os"
+	"path"
 
 	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/logfile"
 	"github.com/dagu-dev/dagu/internal/persistence"
 	"github.com/dagu-dev/dagu/internal/persistence/jsondb"
 	"github.com/dagu-dev/dagu/internal/persistence/local"
 	"github.com/dagu-dev/dagu/internal/persistence/local/storage"
+	"github.com/dagu-dev/dagu/internal/persistence/s3"
+	"github.com/dagu-dev/dagu/internal/persistence/sqldb"
+	"github.com/dagu-dev/dagu/internal/scheduler"
 )
 
 type dataStoreFactoryImpl struct {
@@ -23,9 +29,32 @@ func NewDataStoreFactory(cfg *config.Config) persistence.DataStoreFactory {
 		cfg: cfg,
 	}
 	_ = ds.InitDagDir()
+	initNamespace(cfg)
 	return ds
 }
 
+// initNamespace registers the key of the logs of the configuration, so that
+// its logs are read, and creates the directories of its namespace, which
+// only the user of the processes reads.
+func initNamespace(cfg *config.Config) {
+	if cfg.EncryptionKeyFile != "" {
+		key, err := logfile.LoadKey(cfg.EncryptionKeyFile)
+		if err != nil {
+			log.Printf("failed to load the key of the logs: %v", err)
+		} else {
+			_ = logfile.RegisterKey(key)
+		}
+	}
+	if cfg.Namespace == "" {
+		return
+	}
+	for _, dir := range []string{cfg.DataDir, cfg.LogDir} {
+		if dir != "" {
+			_ = os.MkdirAll(dir, 0700)
+		}
+	}
+}
+
 func (f *dataStoreFactoryImpl) InitDagDir() error {
 	_, err := os.Stat(f.cfg.DAGs)
 	if os.IsNotExist(err) {
@@ -38,13 +67,43 @@ func (f *dataStoreFactoryImpl) InitDagDir() error {
 }
 
 func (f *dataStoreFactoryImpl) NewHistoryStore() persistence.HistoryStore {
-	// TODO: Add support for other data stores (e.g. sqlite, postgres, etc.)
 	if f.historyStore == nil {
-		f.historyStore = jsondb.New(f.cfg.DataDir, f.cfg.DAGs)
+		if b := f.cfg.HistoryBackend; b != nil && (b.Type == "sqlite" || b.Type == "postgres") {
+			f.historyStore = NewSQLHistoryStore(f.cfg)
+		} else {
+			f.historyStore = jsondb.New(f.cfg.DataDir, f.cfg.DAGs)
+		}
 	}
 	return f.historyStore
 }
 
+// NewSQLHistoryStore returns the store of the database of the history
+// backend. It is the PostgreSQL database if the type of the backend is
+// postgres, and the SQLite database otherwise, by default history.db in the
+// data directory, or the SQLite databases of the DAGs in the history
+// directory of the data directory if the history is sharded.
+func NewSQLHistoryStore(cfg *config.Config) *sqldb.Store {
+	b := cfg.HistoryBackend
+	if b == nil {
+		b = &config.HistoryBackend{}
+	}
+	if b.Type == "postgres" {
+		return sqldb.NewPostgres(b.URL, cfg.DAGs)
+	}
+	if b.Sharded {
+		dir := b.Path
+		if dir == "" {
+			dir = path.Join(cfg.DataDir, "history")
+		}
+		return sqldb.NewShardedSQLite(dir, cfg.DAGs)
+	}
+	file := b.Path
+	if file == "" {
+		file = path.Join(cfg.DataDir, "history.db")
+	}
+	return sqldb.NewSQLite(file, cfg.DAGs)
+}
+
 func (f *dataStoreFactoryImpl) NewDAGStore() persistence.DAGStore {
 	if f.dagStore == nil {
 		f.dagStore = local.NewDAGStore(f.cfg.DAGs)
@@ -56,3 +115,72 @@ func (f *dataStoreFactoryImpl) NewFlagStore() persistence.FlagStore {
 	s := storage.NewStorage(f.cfg.SuspendFlagsDir)
 	return local.NewFlagStore(s)
 }
+
+func (f *dataStoreFactoryImpl) NewAlertStore() persistence.AlertStore {
+	s := storage.NewStorage(path.Join(f.cfg.DataDir, "alerts"))
+	return local.NewAlertStore(s)
+}
+
+func (f *dataStoreFactoryImpl) NewArtifactStore() persistence.ArtifactStore {
+	dir := f.cfg.ArtifactDir
+	if dir == "" {
+		dir = path.Join(f.cfg.DataDir, "artifacts")
+	}
+	store := local.NewArtifactStore(dir)
+	if b := f.cfg.ArtifactBackend; b != nil && b.Type == "s3" {
+		return s3.NewArtifactStore(store, b.Bucket, b.Prefix)
+	}
+	return store
+}
+
+func (f *dataStoreFactoryImpl) NewStepCache(name string) scheduler.StepCache {
+	return local.NewStepCache(path.Join(f.cfg.DataDir, "step-cache"), name)
+}
+
+func (f *dataStoreFactoryImpl) NewAuditStore() persistence.AuditStore {
+	return local.NewAuditStore(path.Join(f.cfg.DataDir, "audit", "audit.jsonl"))
+}
+
+func (f *dataStoreFactoryImpl) NewTokenStore() persistence.TokenStore {
+	return local.NewTokenStore(path.Join(f.cfg.DataDir, "tokens"))
+}
+
+func (f *dataStoreFactoryImpl) NewChangeStore() persistence.ChangeStore {
+	return local.NewChangeStore(path.Join(f.cfg.DataDir, "changes"))
+}
+
+func (f *dataStoreFactoryImpl) NewArchiveStore() persistence.ArchiveStore {
+	b := f.cfg.ArchiveBackend
+	if b == nil {
+		return nil
+	}
+	switch b.Type {
+	case "dir":
+		return local.NewArchiveStore(b.Dir)
+	case "s3":
+		return s3.NewArchiveStore(b.Bucket, b.Prefix)
+	}
+	return nil
+}
+
+// NewTriggerQueue returns the queue of the SQLite database of the trigger
+// queue, by default triggers.db in the data directory.
+func (f *dataStoreFactoryImpl) NewTriggerQueue() persistence.TriggerQueue {
+	q := f.cfg.TriggerQueue
+	if q == nil || q.Type != "sqlite" {
+		return nil
+	}
+	file := q.Path
+	if file == "" {
+		file = path.Join(f.cfg.DataDir, "triggers.db")
+	}
+	return sqldb.NewQueue(file)
+}
+
+func (f *dataStoreFactoryImpl) NewLogStore() persistence.LogStore {
+	b := f.cfg.LogBackend
+	if b == nil || (b.Type != "s3" && b.Type != "gcs") {
+		return nil
+	}
+	return s3.NewLogStore(b.Bucket, b.Prefix, b.Type == "gcs")
+}
@@ -0,0 +1,127 @@
+package persistence
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/utils"
+)
+
+// The orders of the DAGs of a DAGQuery.
+const (
+	SortByName    = "name"
+	SortByLastRun = "lastRun"
+	SortByStatus  = "status"
+)
+
+var (
+	errInvalidSort = dagerrors.New(dagerrors.CodeInvalidArgument, "invalid sort")
+	errInvalidPage = dagerrors.New(dagerrors.CodeInvalidArgument, "offset and limit must not be negative")
+)
+
+// DAGQuery filters, sorts and paginates the statuses of the DAGs. The zero
+// value selects all the DAGs in the order of their names.
+type DAGQuery struct {
+	// Tag selects the DAGs with the tag.
+	Tag string
+	// Status selects the DAGs whose latest runs have the status, e.g.
+	// running or failed.
+	Status string
+	// Scheduled selects the DAGs with schedules if it is true, and the ones
+	// without them if it is false.
+	Scheduled *bool
+	// Folder selects the DAGs in the folder, and not in its subfolders, if
+	// it is set. The empty folder is the DAGs directory itself.
+	Folder *string
+	// Sort is name, lastRun or status, which is in the order of not
+	// started, running, failed, canceled, finished, expired and lost. The
+	// DAGs of the same order are sorted by their names.
+	Sort string
+	Desc bool
+	// Offset is the number of the DAGs to skip, and Limit the maximum
+	// number of the DAGs. Zero is no limit.
+	Offset int
+	Limit  int
+}
+
+// Apply returns the page of the DAGs of the query and the number of the DAGs
+// that match its filters.
+func (q *DAGQuery) Apply(dags []*DAGStatus) ([]*DAGStatus, int, error) {
+	if q.Offset < 0 || q.Limit < 0 {
+		return nil, 0, errInvalidPage
+	}
+	less, err := q.less()
+	if err != nil {
+		return nil, 0, err
+	}
+	var ret []*DAGStatus
+	for _, d := range dags {
+		if q.match(d) {
+			ret = append(ret, d)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		if c := less(ret[i], ret[j]); c != 0 {
+			return (c < 0) != q.Desc
+		}
+		return (ret[i].DAG.Name < ret[j].DAG.Name) != q.Desc
+	})
+	total := len(ret)
+	ret = ret[min(q.Offset, total):]
+	if q.Limit > 0 && q.Limit < len(ret) {
+		ret = ret[:q.Limit]
+	}
+	return ret, total, nil
+}
+
+func (q *DAGQuery) match(d *DAGStatus) bool {
+	switch {
+	case q.Tag != "" && !d.DAG.HasTag(q.Tag):
+		return false
+	case q.Status != "" && (d.Status == nil || d.Status.Status.String() != q.Status):
+		return false
+	case q.Scheduled != nil && (len(d.DAG.Schedule) > 0) != *q.Scheduled:
+		return false
+	case q.Folder != nil && d.Folder != *q.Folder:
+		return false
+	}
+	return true
+}
+
+// less returns the comparison of the order of the query, which is zero for
+// the DAGs of the same order.
+func (q *DAGQuery) less() (func(a, b *DAGStatus) int, error) {
+	switch q.Sort {
+	case "", SortByName:
+		return func(_, _ *DAGStatus) int { return 0 }, nil
+	case SortByLastRun:
+		// The DAGs that never ran are the oldest.
+		return func(a, b *DAGStatus) int {
+			return lastRun(a).Compare(lastRun(b))
+		}, nil
+	case SortByStatus:
+		return func(a, b *DAGStatus) int {
+			return int(status(a)) - int(status(b))
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errInvalidSort, q.Sort)
+	}
+}
+
+func lastRun(d *DAGStatus) time.Time {
+	if d.Status == nil {
+		return time.Time{}
+	}
+	t, _ := utils.ParseTime(d.Status.StartedAt)
+	return t
+}
+
+func status(d *DAGStatus) scheduler.Status {
+	if d.Status == nil {
+		return scheduler.StatusNone
+	}
+	return d.Status.Status
+}
@@ -0,0 +1,73 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGQuery(t *testing.T) {
+	newStatus := func(name string, tags []string, scheduled bool, st scheduler.Status, startedAt string) *DAGStatus {
+		d := &dag.DAG{Name: name, Location: name + ".yaml", Tags: tags}
+		if scheduled {
+			d.Schedule = []*dag.Schedule{{Expression: "0 * * * *"}}
+		}
+		s := model.NewStatusDefault(d)
+		s.Status = st
+		s.StartedAt = startedAt
+		return NewDAGStatus(d, s, false, nil)
+	}
+	dags := []*DAGStatus{
+		newStatus("etl", []string{"daily"}, true, scheduler.StatusSuccess, "2024-01-01 10:00:00"),
+		newStatus("backup", []string{"daily", "ops"}, true, scheduler.StatusError, "2024-01-02 10:00:00"),
+		newStatus("adhoc", nil, false, scheduler.StatusNone, "-"),
+		newStatus("report", []string{"daily"}, false, scheduler.StatusRunning, "2024-01-03 10:00:00"),
+	}
+	dags[1].Folder = "ops"
+	names := func(dags []*DAGStatus) []string {
+		var ret []string
+		for _, d := range dags {
+			ret = append(ret, d.DAG.Name)
+		}
+		return ret
+	}
+	scheduled, unscheduled := true, false
+	top, ops := "", "ops"
+
+	for _, tc := range []struct {
+		name  string
+		query DAGQuery
+		want  []string
+		total int
+	}{
+		{"all", DAGQuery{}, []string{"adhoc", "backup", "etl", "report"}, 4},
+		{"tag", DAGQuery{Tag: "daily"}, []string{"backup", "etl", "report"}, 3},
+		{"status", DAGQuery{Status: "failed"}, []string{"backup"}, 1},
+		{"not started", DAGQuery{Status: "not started"}, []string{"adhoc"}, 1},
+		{"scheduled", DAGQuery{Scheduled: &scheduled}, []string{"backup", "etl"}, 2},
+		{"unscheduled", DAGQuery{Scheduled: &unscheduled}, []string{"adhoc", "report"}, 2},
+		{"last run", DAGQuery{Sort: SortByLastRun, Desc: true}, []string{"report", "backup", "etl", "adhoc"}, 4},
+		{"status order", DAGQuery{Sort: SortByStatus}, []string{"adhoc", "report", "backup", "etl"}, 4},
+		{"name desc", DAGQuery{Desc: true}, []string{"report", "etl", "backup", "adhoc"}, 4},
+		{"top folder", DAGQuery{Folder: &top}, []string{"adhoc", "etl", "report"}, 3},
+		{"folder", DAGQuery{Folder: &ops}, []string{"backup"}, 1},
+		{"page", DAGQuery{Tag: "daily", Offset: 1, Limit: 1}, []string{"etl"}, 3},
+		{"last page", DAGQuery{Offset: 3, Limit: 2}, []string{"report"}, 4},
+		{"past the end", DAGQuery{Offset: 10}, nil, 4},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			page, total, err := tc.query.Apply(dags)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, names(page))
+			require.Equal(t, tc.total, total)
+		})
+	}
+
+	_, _, err := (&DAGQuery{Sort: "size"}).Apply(dags)
+	require.ErrorIs(t, err, errInvalidSort)
+	_, _, err = (&DAGQuery{Offset: -1}).Apply(dags)
+	require.ErrorIs(t, err, errInvalidPage)
+}
@@ -1,19 +1,25 @@
 package persistence
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"path/filepath"
 	"time"
 
 	"github.com/dagu-dev/dagu/internal/dag"
-	"github.com/dagu-dev/dagu/internal/grep"
 	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
 )
 
 var (
 	ErrRequestIdNotFound = fmt.Errorf("request id not found")
 	ErrNoStatusDataToday = fmt.Errorf("no status data today")
 	ErrNoStatusData      = fmt.Errorf("no status data")
+	ErrTokenNotFound     = fmt.Errorf("token not found")
+	ErrTokenExists       = fmt.Errorf("token already exists")
+	ErrInvalidTokenName  = fmt.Errorf("the name of a token must consist of letters, digits, '-', '_' and '.'")
+	ErrChangeNotFound    = fmt.Errorf("change not found")
 )
 
 type (
@@ -21,6 +27,19 @@ type (
 		NewHistoryStore() HistoryStore
 		NewDAGStore() DAGStore
 		NewFlagStore() FlagStore
+		NewAlertStore() AlertStore
+		NewArtifactStore() ArtifactStore
+		NewStepCache(name string) scheduler.StepCache
+		NewAuditStore() AuditStore
+		// NewArchiveStore returns nil if the archive is not configured.
+		NewArchiveStore() ArchiveStore
+		// NewLogStore returns nil if the log backend is not configured.
+		NewLogStore() LogStore
+		NewTokenStore() TokenStore
+		NewChangeStore() ChangeStore
+		// NewTriggerQueue returns nil if the trigger queue is not
+		// configured.
+		NewTriggerQueue() TriggerQueue
 	}
 
 	HistoryStore interface {
@@ -29,36 +48,178 @@ type (
 		Close() error
 		Update(dagFile, requestId string, st *model.Status) error
 		ReadStatusRecent(dagFile string, n int) []*model.StatusFile
+		// ReadStatusAll returns the statuses of all the runs, the latest
+		// first.
+		ReadStatusAll(dagFile string) []*model.StatusFile
 		ReadStatusToday(dagFile string) (*model.Status, error)
 		FindByRequestId(dagFile string, requestId string) (*model.StatusFile, error)
 		RemoveAll(dagFile string) error
 		RemoveOld(dagFile string, retentionDays int) error
+		// RemoveRun removes the status of the run of the request ID.
+		RemoveRun(dagFile, requestId string) error
 		Rename(oldName, newName string) error
+		// Check returns an error if the statuses cannot be written, e.g.
+		// because the volume of the history is read-only.
+		Check() error
 	}
 
 	DAGStore interface {
 		Create(name string, spec []byte) (string, error)
 		Delete(name string) error
 		List() (ret []*dag.DAG, errs []string, err error)
+		// Files returns the DAG files of the DAGs directory and its
+		// folders, the ones that fail to load included.
+		Files() ([]string, error)
 		GetMetadata(name string) (*dag.DAG, error)
 		GetDetails(name string) (*dag.DAG, error)
-		Grep(pattern string) (ret []*GrepResult, errs []string, err error)
 		Load(name string) (*dag.DAG, error)
 		Rename(oldName, newName string) error
 		GetSpec(name string) (string, error)
 		UpdateSpec(name string, spec []byte) error
 		FindByName(name string) (*dag.DAG, error)
+		// Folders returns the folders of the DAGs directory, e.g. team-a/etl.
+		Folders() ([]string, error)
+		// FolderOf returns the folder of the DAG file, empty in the DAGs
+		// directory itself.
+		FolderOf(location string) string
+		CreateFolder(folder string) error
+		// DeleteFolder deletes the folder, which must be empty.
+		DeleteFolder(folder string) error
 	}
 
 	FlagStore interface {
 		ToggleSuspend(id string, suspend bool) error
 		IsSuspended(id string) bool
+		// SetHalted pauses or resumes the schedules of all the DAGs, e.g.
+		// during an incident.
+		SetHalted(halted bool) error
+		IsHalted() bool
 	}
 
-	GrepResult struct {
-		Name    string
-		DAG     *dag.DAG
-		Matches []*grep.Match
+	// AlertStore keeps the notification state of the DAGs between runs.
+	AlertStore interface {
+		// Get returns nil if there is no state for the DAG.
+		Get(id string) (*model.Alert, error)
+		Save(id string, alert *model.Alert) error
+		Delete(id string) error
+	}
+
+	// ArtifactStore keeps the files published by the runs of the DAGs.
+	ArtifactStore interface {
+		// Dir returns the directory the run publishes its artifacts to.
+		Dir(name, requestId string) string
+		// Open opens the artifact at the path relative to the artifacts of
+		// the run.
+		Open(name, requestId, path string) (io.ReadCloser, error)
+		// Publish stores the artifacts at the paths relative to the
+		// directory of the run in the backend of the store, if any.
+		Publish(ctx context.Context, name, requestId string, paths []string) error
+		// Runs returns the runs that have artifacts.
+		Runs() ([]*ArtifactRun, error)
+		// Remove removes the artifacts of the run.
+		Remove(run *ArtifactRun) error
+		// List returns the artifacts of the run sorted by their paths.
+		List(ctx context.Context, name, requestId string) ([]*Artifact, error)
+		// Delete removes the artifact at the path relative to the artifacts
+		// of the run.
+		Delete(ctx context.Context, name, requestId, path string) error
+		// URL returns a pre-signed URL that downloads the artifact at the
+		// path without other credentials until it expires.
+		URL(ctx context.Context, name, requestId, path string, expires time.Duration) (string, error)
+	}
+
+	// AuditStore records the actions of the operators.
+	AuditStore interface {
+		Append(entry *model.AuditEntry) error
+		// List returns the entries of the query, the latest first.
+		List(q *AuditQuery) ([]*model.AuditEntry, error)
+	}
+
+	// TokenStore keeps the API tokens created with the API or the CLI.
+	TokenStore interface {
+		// Create returns ErrTokenExists if there is a token of the name.
+		Create(token *model.APIToken) error
+		// List returns the tokens sorted by their names.
+		List() ([]*model.APIToken, error)
+		// Delete returns ErrTokenNotFound if there is no token of the name.
+		Delete(name string) error
+	}
+
+	// ChangeStore keeps the changes of the DAGs of a namespace under
+	// change control, the pending ones and the reviewed ones.
+	ChangeStore interface {
+		Create(change *model.Change) error
+		// Get returns ErrChangeNotFound if there is no change of the ID.
+		Get(id string) (*model.Change, error)
+		// Update replaces the change of the ID of the change.
+		Update(change *model.Change) error
+		// List returns the changes, the latest first.
+		List() ([]*model.Change, error)
+	}
+
+	// ArchiveStore keeps the files of the runs moved to the cold tier.
+	ArchiveStore interface {
+		// Put stores the file of the run under the name.
+		Put(ctx context.Context, name, requestId, file string, r io.Reader, size int64) error
+		// Open opens the file of the run. It returns an error wrapping
+		// fs.ErrNotExist if the file is not in the archive.
+		Open(ctx context.Context, name, requestId, file string) (io.ReadCloser, error)
+		// Location returns where the files of the run are stored.
+		Location(name, requestId string) string
+	}
+
+	// LogStore keeps the step logs the agents upload when the steps
+	// finish, e.g. from ephemeral machines whose local logs are lost.
+	LogStore interface {
+		// Put stores the log file of the run under the name.
+		Put(ctx context.Context, name, requestId, file string, r io.Reader, size int64) error
+		// Open opens the log file of the run. It returns an error wrapping
+		// fs.ErrNotExist if the file is not in the store.
+		Open(ctx context.Context, name, requestId, file string) (io.ReadCloser, error)
+		// Location returns where the log files of the run are stored.
+		Location(name, requestId string) string
+	}
+
+	// TriggerQueue keeps the starts of the runs the server accepted until
+	// the scheduler starts them, so that they are not lost while the
+	// scheduler is down or restarting.
+	TriggerQueue interface {
+		// Enqueue adds the trigger to the queue and sets its ID.
+		Enqueue(t *model.Trigger) error
+		// Claim claims the oldest triggers, at most n, that are not claimed
+		// or whose claims are older than the lease, so that the schedulers
+		// sharing the queue do not start the same runs.
+		Claim(n int, lease time.Duration) ([]*model.Trigger, error)
+		// Done removes the trigger whose run was started.
+		Done(id int64) error
+		// Release releases the claim of the trigger that did not start its
+		// run because of the error, so that it is claimed again.
+		Release(id int64, reason error) error
+		// List returns the triggers in the queue, the oldest first.
+		List() ([]*model.Trigger, error)
+	}
+
+	// Artifact is a file published by a run.
+	Artifact struct {
+		// Path is relative to the artifacts of the run, separated by
+		// slashes.
+		Path    string
+		Size    int64
+		ModTime time.Time
+		// SHA256 is the digest of the contents in hex. It is empty for the
+		// artifacts that are only in the backend, which have their ETag.
+		SHA256 string
+		ETag   string
+	}
+
+	// ArtifactRun is the directory of the artifacts of a run.
+	ArtifactRun struct {
+		// Dir is the directory of the run. It is a subdirectory of the
+		// directory returned by Dir with an empty request ID.
+		Dir       string
+		RequestId string
+		ModTime   time.Time
+		Size      int64
 	}
 
 	DAGStatus struct {
@@ -69,6 +230,10 @@ type (
 		Suspended bool
 		Error     error
 		ErrorT    *string
+
+		// Folder is the folder of the DAG in the DAGs directory, e.g.
+		// team-a/etl, empty in the directory itself.
+		Folder string
 	}
 )
 
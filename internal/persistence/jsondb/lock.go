@@ -0,0 +1,192 @@
+package jsondb
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// lockSuffix is the suffix of the lock file of a status file.
+	lockSuffix = ".lock"
+	// lockHeartbeat is how often the writer of a run renews its lock.
+	lockHeartbeat = time.Second * 10
+	// lockStaleAfter is how long a lock stays valid without being renewed.
+	lockStaleAfter = time.Minute
+)
+
+var (
+	errStatusLocked = dagerrors.New(dagerrors.CodeDAGRunning, "the status file is locked by another process")
+	errLockLost     = errors.New("the lock of the status file was taken over")
+)
+
+// statusLock is the lock of a status file held by its writer. The lock file
+// has a fencing token, which is incremented each time the lock is taken, so
+// that a writer whose lock was broken as stale, e.g. a process that was
+// stopped for a while, finds out before it writes that another process took
+// the file over, instead of writing over its status.
+type statusLock struct {
+	file  string
+	token int64
+}
+
+// lockState is the content of a lock file. It is released if it has no
+// process, and stale if its process is gone, or it was not renewed for
+// lockStaleAfter, e.g. the process runs on another host.
+type lockState struct {
+	Token    int64
+	Pid      int
+	Hostname string
+	// Start is the start time of the process returned by
+	// utils.ProcessStart, so that the lock of a process that exited is
+	// stale even if another process reused its pid.
+	Start     uint64
+	RenewedAt time.Time
+}
+
+func (s *lockState) stale(now time.Time) bool {
+	if s.Pid == 0 {
+		return true
+	}
+	if hostname, _ := os.Hostname(); s.Hostname == hostname && !utils.ProcessAlive(s.Pid, s.Start) {
+		return true
+	}
+	return now.Sub(s.RenewedAt) > lockStaleAfter
+}
+
+// lockStatus takes the lock of the status file. The lock of another process
+// is broken if it is stale.
+func lockStatus(target string) (*statusLock, error) {
+	l := &statusLock{file: target + lockSuffix}
+	err := withFileLock(l.file, func(f *os.File, current *lockState) error {
+		now := utils.Now()
+		next := &lockState{Token: 1, Pid: os.Getpid(), Start: utils.ProcessStart(os.Getpid()), RenewedAt: now}
+		next.Hostname, _ = os.Hostname()
+		if current != nil {
+			if !current.stale(now) {
+				return errStatusLocked
+			}
+			next.Token = current.Token + 1
+		}
+		l.token = next.Token
+		return writeLockState(f, next)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// fenced runs fn while the lock file is locked if the lock is still held,
+// so that the lock cannot be broken while fn writes.
+func (l *statusLock) fenced(fn func() error) error {
+	return withFileLock(l.file, func(_ *os.File, current *lockState) error {
+		if current == nil || current.Token != l.token || current.Pid == 0 {
+			return errLockLost
+		}
+		return fn()
+	})
+}
+
+// renew keeps the lock from becoming stale.
+func (l *statusLock) renew() error {
+	return withFileLock(l.file, func(f *os.File, current *lockState) error {
+		if current == nil || current.Token != l.token || current.Pid == 0 {
+			return errLockLost
+		}
+		current.RenewedAt = utils.Now()
+		return writeLockState(f, current)
+	})
+}
+
+// release gives up the lock if it is still held. The lock file is kept with
+// its token, so that the token of the next writer is greater.
+func (l *statusLock) release() error {
+	return withFileLock(l.file, func(f *os.File, current *lockState) error {
+		if current == nil || current.Token != l.token {
+			return nil
+		}
+		current.Pid = 0
+		return writeLockState(f, current)
+	})
+}
+
+// withFileLock opens the lock file and holds an exclusive lock on it while
+// fn is running, so that reading and updating the lock is atomic.
+func withFileLock(file string, fn func(f *os.File, current *lockState) error) error {
+	f, err := os.OpenFile(file, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	if err := unix.Flock(int(f.Fd()), unix.LOCK_EX); err != nil {
+		return err
+	}
+	defer func() {
+		_ = unix.Flock(int(f.Fd()), unix.LOCK_UN)
+	}()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	var current *lockState
+	if len(data) > 0 {
+		current = &lockState{}
+		if err := json.Unmarshal(data, current); err != nil {
+			// A corrupted lock file is treated as a released lock.
+			current = &lockState{}
+		}
+	}
+	return fn(f, current)
+}
+
+func writeLockState(f *os.File, s *lockState) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := f.WriteAt(data, 0); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// OrphanedLock reports whether the file is the lock file of a status file
+// that no longer exists, e.g. the original of a compacted status file or the
+// status file of a run removed by a process that crashed, and that no writer
+// holds.
+func OrphanedLock(file string) bool {
+	target, ok := strings.CutSuffix(file, lockSuffix)
+	if !ok || !strings.HasSuffix(target, ".dat") {
+		return false
+	}
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		return false
+	}
+	orphaned := false
+	_ = withFileLock(file, func(_ *os.File, current *lockState) error {
+		orphaned = current == nil || current.stale(utils.Now())
+		return nil
+	})
+	return orphaned
+}
+
+// removeLock removes the lock file of the status file, once the status file
+// is gone so that there is no next writer.
+func removeLock(target string) {
+	if err := os.Remove(target + lockSuffix); err != nil && !os.IsNotExist(err) {
+		utils.LogErr("remove lock", err)
+	}
+}
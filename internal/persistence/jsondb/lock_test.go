@@ -0,0 +1,116 @@
+package jsondb
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusLock(t *testing.T) {
+	tmpDir, db := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	d := &dag.DAG{Name: "test_status_lock", Location: "test_status_lock.yaml"}
+	status := model.NewStatus(d, nil, scheduler.StatusRunning, 10000, nil, nil)
+	status.RequestId = "request-id-1"
+
+	w, file, err := db.newWriter(d.Location, time.Now(), status.RequestId)
+	require.NoError(t, err)
+	require.NoError(t, w.open())
+	require.NoError(t, w.write(status))
+
+	// The lock of a live process is not broken.
+	other := &writer{target: file}
+	require.ErrorIs(t, other.open(), errStatusLocked)
+
+	// The lock of a process that is gone is broken, and the process does
+	// not write after it is back.
+	writeLock(t, file, &lockState{Token: w.lock.token, Pid: 1 << 30, Hostname: hostname(t), RenewedAt: time.Now()})
+	require.NoError(t, other.open())
+	require.Equal(t, w.lock.token+1, other.lock.token)
+	status.Status = scheduler.StatusError
+	require.NoError(t, other.write(status))
+	require.NoError(t, other.close())
+	status.Status = scheduler.StatusSuccess
+	require.ErrorIs(t, w.write(status), errLockLost)
+	got, err := ParseFile(file)
+	require.NoError(t, err)
+	require.Equal(t, scheduler.StatusError, got.Status)
+
+	// So is the lock of a process whose pid was reused by another one.
+	writeLock(t, file, &lockState{Token: 3, Pid: os.Getpid(), Hostname: hostname(t), Start: 1, RenewedAt: time.Now()})
+	require.NoError(t, db.Update(d.Location, status.RequestId, status))
+
+	// So is the lock of another host that was not renewed.
+	writeLock(t, file, &lockState{Token: 5, Pid: os.Getpid(), Hostname: "other", RenewedAt: time.Now().Add(-lockStaleAfter * 2)})
+	require.NoError(t, db.Update(d.Location, status.RequestId, status))
+	writeLock(t, file, &lockState{Token: 7, Pid: os.Getpid(), Hostname: "other", RenewedAt: time.Now()})
+	require.ErrorIs(t, db.Update(d.Location, status.RequestId, status), errStatusLocked)
+	require.NoError(t, w.close())
+}
+
+func TestCloseRemovesLock(t *testing.T) {
+	tmpDir, db := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	d := &dag.DAG{Name: "test_close_lock", Location: "test_close_lock.yaml"}
+	status := model.NewStatus(d, nil, scheduler.StatusSuccess, 10000, nil, nil)
+	status.RequestId = "request-id-1"
+
+	require.NoError(t, db.Open(d.Location, time.Now(), status.RequestId))
+	file := db.writer.target
+	require.FileExists(t, file+lockSuffix)
+	require.NoError(t, db.Write(status))
+	require.NoError(t, db.Close())
+	require.NoFileExists(t, file)
+	require.NoFileExists(t, file+lockSuffix)
+}
+
+func TestOrphanedLock(t *testing.T) {
+	tmpDir, db := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	d := &dag.DAG{Name: "test_orphaned_lock", Location: "test_orphaned_lock.yaml"}
+	status := model.NewStatus(d, nil, scheduler.StatusRunning, 10000, nil, nil)
+	status.RequestId = "request-id-1"
+	w, file, err := db.newWriter(d.Location, time.Now(), status.RequestId)
+	require.NoError(t, err)
+	require.NoError(t, w.open())
+	require.NoError(t, w.write(status))
+
+	// The lock of a status file is not orphaned, nor the lock of a writer
+	// whose status file is not written yet.
+	require.False(t, OrphanedLock(file+lockSuffix))
+	require.NoError(t, os.Rename(file, file+".bak"))
+	require.False(t, OrphanedLock(file+lockSuffix))
+
+	// The released lock of a status file that is gone is orphaned.
+	require.NoError(t, w.close())
+	require.True(t, OrphanedLock(file+lockSuffix))
+	require.False(t, OrphanedLock(file+".bak"))
+}
+
+func writeLock(t *testing.T, file string, s *lockState) {
+	t.Helper()
+	require.NoError(t, withFileLock(file+lockSuffix, func(f *os.File, _ *lockState) error {
+		return writeLockState(f, s)
+	}))
+}
+
+func hostname(t *testing.T) string {
+	t.Helper()
+	h, err := os.Hostname()
+	require.NoError(t, err)
+	return h
+}
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/dagu-dev/dagu/internal/config"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
 	"github.com/dagu-dev/dagu/internal/persistence"
 	"github.com/dagu-dev/dagu/internal/persistence/model"
 
@@ -40,9 +41,11 @@ type Store struct {
 }
 
 var (
-	errRequestIdNotFound  = errors.New("requestId not found")
+	errRequestIdNotFound  = dagerrors.New(dagerrors.CodeNotFound, "requestId not found")
 	errCreateNewDirectory = errors.New("failed to create new directory")
 	errDAGFileEmpty       = errors.New("dagFile is empty")
+	errStoreNotOpen       = errors.New("status store is not open")
+	errNoTimestamp        = errors.New("no timestamp in the status file name")
 )
 
 const (
@@ -66,15 +69,20 @@ func (store *Store) Update(dagFile, requestId string, s *model.Status) error {
 	if err != nil {
 		return err
 	}
-	w := &writer{target: f.File}
-	if err := w.open(); err != nil {
-		return err
+	defer store.cache.Invalidate(f.File)
+	if !strings.HasSuffix(f.File, compactedSuffix) {
+		// The file of a run that is not compacted may still be appended
+		// to by its agent, so it is not replaced.
+		w := &writer{target: f.File}
+		if err := w.open(); err != nil {
+			return err
+		}
+		defer func() {
+			_ = w.close()
+		}()
+		return w.write(s)
 	}
-	defer func() {
-		store.cache.Invalidate(f.File)
-		_ = w.close()
-	}()
-	return w.write(s)
+	return utils.WriteFileAtomic(f.File, []byte(statusLine(s)), 0644)
 }
 
 func (store *Store) Open(dagFile string, t time.Time, requestId string) error {
@@ -90,22 +98,33 @@ func (store *Store) Open(dagFile string, t time.Time, requestId string) error {
 }
 
 func (store *Store) Write(s *model.Status) error {
-	return store.writer.write(s)
+	w := store.writer
+	if w == nil {
+		return errStoreNotOpen
+	}
+	return w.write(s)
 }
 
 func (store *Store) Close() error {
 	if store.writer == nil {
 		return nil
 	}
+	w := store.writer
 	defer func() {
-		_ = store.writer.close()
+		_ = w.close()
 		store.writer = nil
 	}()
-	if err := store.Compact(store.writer.dagFile, store.writer.target); err != nil {
+	// The file is not compacted if the run was taken over, e.g. marked as
+	// lost while its process was stopped.
+	if err := w.lock.fenced(func() error {
+		return store.Compact(w.dagFile, w.target)
+	}); err != nil {
 		return err
 	}
-	store.cache.Invalidate(store.writer.target)
-	return store.writer.close()
+	store.cache.Invalidate(w.target)
+	err := w.close()
+	removeLock(w.target)
+	return err
 }
 
 func ParseFile(file string) (*model.Status, error) {
@@ -125,6 +144,9 @@ func ParseFile(file string) (*model.Status, error) {
 			if ret == nil {
 				return nil, err
 			}
+			if ret.NewerSchema() {
+				log.Printf("%s was written by a newer version of dagu (schema version %d)", file, ret.SchemaVersion)
+			}
 			return ret, nil
 		} else if err != nil {
 			return nil, err
@@ -170,11 +192,38 @@ func (store *Store) ReadStatusRecent(dagFile string, n int) []*model.StatusFile
 	return ret
 }
 
+// ReadStatusAll returns the statuses of all the runs, the latest first.
+func (store *Store) ReadStatusAll(dagFile string) []*model.StatusFile {
+	matches, _ := filepath.Glob(store.pattern(dagFile) + "*.dat")
+	var ret []*model.StatusFile
+	for _, file := range filterLatest(matches, len(matches)) {
+		status, err := ParseFile(file)
+		if err != nil {
+			log.Printf("parsing failed %s : %s", file, err)
+			continue
+		}
+		ret = append(ret, &model.StatusFile{
+			File:   file,
+			Status: status,
+		})
+	}
+	return ret
+}
+
+// StartedAt returns the time the run of the status file was opened at.
+func StartedAt(file string) (time.Time, error) {
+	ts := rStartedAt.FindString(filepath.Base(file))
+	if ts == "" {
+		return time.Time{}, fmt.Errorf("%w: %s", errNoTimestamp, file)
+	}
+	return time.ParseInLocation(startedAtLayout, ts, time.Local)
+}
+
 // ReadStatusToday returns a list of status files.
 func (store *Store) ReadStatusToday(dagFile string) (*model.Status, error) {
 	// TODO: let's fix below not to use config here
 	readLatestStatus := config.Get().LatestStatusToday
-	file, err := store.latestToday(dagFile, time.Now(), readLatestStatus)
+	file, err := store.latestToday(dagFile, utils.Now(), readLatestStatus)
 	if err != nil {
 		return nil, err
 	}
@@ -228,6 +277,7 @@ func (store *Store) RemoveOld(dagFile string, retentionDays int) error {
 			if err == nil {
 				if info.ModTime().Before(ot) {
 					lastErr = os.Remove(m)
+					removeLock(m)
 				}
 			}
 		}
@@ -235,6 +285,34 @@ func (store *Store) RemoveOld(dagFile string, retentionDays int) error {
 	return lastErr
 }
 
+// RemoveRun removes the status file of the run of the request ID.
+func (store *Store) RemoveRun(dagFile, requestId string) error {
+	if requestId == "" {
+		return errRequestIdNotFound
+	}
+	pattern := fmt.Sprintf("%s.*.%s*.dat", store.pattern(dagFile), utils.TruncString(requestId, 8))
+	matches, _ := filepath.Glob(pattern)
+	removed := false
+	for _, f := range matches {
+		status, err := ParseFile(f)
+		if err != nil || status.RequestId != requestId {
+			continue
+		}
+		if err := os.Remove(f); err != nil {
+			return err
+		}
+		removeLock(f)
+		removed = true
+	}
+	if !removed {
+		return fmt.Errorf("%w : %s", persistence.ErrRequestIdNotFound, requestId)
+	}
+	return nil
+}
+
+// compactedSuffix is the suffix of the name of a compacted status file.
+const compactedSuffix = "_c.dat"
+
 // Compact creates a new file with only the latest data and removes old data.
 func (store *Store) Compact(_, original string) error {
 	status, err := ParseFile(original)
@@ -242,25 +320,17 @@ func (store *Store) Compact(_, original string) error {
 		return err
 	}
 
-	newFile := fmt.Sprintf("%s_c.dat",
-		strings.TrimSuffix(filepath.Base(original), path.Ext(original)))
+	newFile := strings.TrimSuffix(filepath.Base(original), path.Ext(original)) + compactedSuffix
 	f := path.Join(filepath.Dir(original), newFile)
-	w := &writer{target: f}
-	if err := w.open(); err != nil {
+	// The compacted file is complete once it has its name, so the status
+	// is in the original or in the compacted file after a crash.
+	if err := utils.WriteFileAtomic(f, []byte(statusLine(status)), 0644); err != nil {
 		return err
 	}
-	defer func() {
-		_ = w.close()
-	}()
-
-	if err := w.write(status); err != nil {
-		if err := os.Remove(f); err != nil {
-			log.Printf("failed to remove %s : %s", f, err.Error())
-		}
+	if err := os.Remove(original); err != nil {
 		return err
 	}
-
-	return os.Remove(original)
+	return utils.SyncDir(filepath.Dir(original))
 }
 
 func (store *Store) normalizeInternalName(name string) string {
@@ -275,6 +345,20 @@ func (store *Store) exists(file string) bool {
 	return !os.IsNotExist(err)
 }
 
+// Check creates and removes a file in the directory of the history to check
+// that the statuses can be written.
+func (store *Store) Check() error {
+	if err := os.MkdirAll(store.dir, 0755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(store.dir, ".check-*")
+	if err != nil {
+		return err
+	}
+	_ = f.Close()
+	return os.Remove(f.Name())
+}
+
 func (store *Store) Rename(oldName, newName string) error {
 	// This is needed to ensure backward compatibility.
 	on := store.normalizeInternalName(oldName)
@@ -301,6 +385,9 @@ func (store *Store) Rename(oldName, newName string) error {
 		base := path.Base(m)
 		f := strings.Replace(base, oldPattern, newPattern, 1)
 		_ = os.Rename(m, path.Join(newDir, f))
+		if store.exists(m + lockSuffix) {
+			_ = os.Rename(m+lockSuffix, path.Join(newDir, f)+lockSuffix)
+		}
 	}
 	if files, _ := os.ReadDir(oldDir); len(files) == 0 {
 		_ = os.Remove(oldDir)
@@ -319,7 +406,7 @@ func (store *Store) newFile(dagFile string, t time.Time, requestId string) (stri
 	if dagFile == "" {
 		return "", errDAGFileEmpty
 	}
-	fileName := fmt.Sprintf("%s.%s.%s.dat", store.pattern(dagFile), t.Format("20060102.15:04:05.000"), utils.TruncString(requestId, 8))
+	fileName := fmt.Sprintf("%s.%s.%s.dat", store.pattern(dagFile), t.Format(startedAtLayout), utils.TruncString(requestId, 8))
 	return fileName, nil
 }
 
@@ -358,7 +445,12 @@ func (store *Store) latest(pattern string, n int) []string {
 	return ret
 }
 
-var rTimestamp = regexp.MustCompile(`2\d{7}.\d{2}:\d{2}:\d{2}`)
+var (
+	rTimestamp = regexp.MustCompile(`2\d{7}.\d{2}:\d{2}:\d{2}`)
+	rStartedAt = regexp.MustCompile(`2\d{7}\.\d{2}:\d{2}:\d{2}\.\d{3}`)
+)
+
+const startedAtLayout = "20060102.15:04:05.000"
 
 func filterLatest(files []string, n int) []string {
 	if len(files) == 0 {
@@ -281,6 +281,7 @@ func TestCompactFile(t *testing.T) {
 		{model.NewStatus(
 			d, nil, scheduler.StatusSuccess, 10000, nil, nil)},
 	} {
+		data.Status.RequestId = requestId
 		require.NoError(t, dw.write(data.Status))
 	}
 
@@ -306,6 +307,16 @@ func TestCompactFile(t *testing.T) {
 	require.Regexp(t, `test_compact_file.*_c.dat`, s2.File)
 	require.Equal(t, s.Status, s2.Status)
 
+	// The compacted file is replaced by an update instead of appended to.
+	s2.Status.Status = scheduler.StatusError
+	require.NoError(t, db2.Update(d.Location, requestId, s2.Status))
+	b, err := os.ReadFile(s2.File)
+	require.NoError(t, err)
+	require.Equal(t, 1, strings.Count(string(b), "\n"))
+	s3, err := db2.FindByRequestId(d.Location, requestId)
+	require.NoError(t, err)
+	require.Equal(t, scheduler.StatusError, s3.Status.Status)
+
 	err = db2.Compact(d.Location, "Invalid_file_name.dat")
 	require.Error(t, err)
 }
@@ -378,6 +389,15 @@ func TestTimestamp(t *testing.T) {
 	}
 }
 
+func TestStartedAt(t *testing.T) {
+	ts, err := StartedAt("/tmp/test_started_at.20200101.10:00:00.123.abc_c.dat")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2020, 1, 1, 10, 0, 0, 123000000, time.Local), ts)
+
+	_, err = StartedAt("/tmp/test_started_at.dat")
+	require.ErrorIs(t, err, errNoTimestamp)
+}
+
 func TestReadLine(t *testing.T) {
 	tmpDir := utils.MustTempDir("test_read_line")
 	defer func() {
@@ -421,3 +441,21 @@ func TestReadLine(t *testing.T) {
 	_, err = readLineFrom(f, offset)
 	require.Equal(t, io.EOF, err)
 }
+
+func TestParseFileOfOlderVersion(t *testing.T) {
+	// The run was started before an upgrade, so the file has the statuses
+	// of the older version.
+	file := filepath.Join(t.TempDir(), "legacy.dat")
+	lines := `{"RequestId":"request-id-1","Name":"legacy","Status":1,"Pid":1234,"Nodes":[{"Step":{"Name":"step1"},"Status":1}]}
+{"RequestId":"request-id-1","Name":"legacy","Status":4,"Nodes":[{"Step":{"Name":"step1"},"Status":4}]}
+`
+	require.NoError(t, os.WriteFile(file, []byte(lines), 0600))
+
+	st, err := ParseFile(file)
+	require.NoError(t, err)
+	require.Equal(t, model.SchemaVersion, st.SchemaVersion)
+	require.Equal(t, scheduler.StatusSuccess, st.Status)
+	require.Equal(t, scheduler.StatusSuccess.String(), st.StatusText)
+	require.Equal(t, model.PidNotRunning, st.Pid)
+	require.Equal(t, scheduler.NodeStatusSuccess.String(), st.Nodes[0].StatusText)
+}
@@ -6,6 +6,7 @@ import (
 	"path"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/dagu-dev/dagu/internal/persistence/model"
 
@@ -20,28 +21,68 @@ type writer struct {
 	file    *os.File
 	mu      sync.Mutex
 	closed  bool
+
+	lock *statusLock
+	stop chan struct{}
 }
 
-// Open opens the writer.
+// Open takes the lock of the status file and opens the writer. The lock is
+// renewed until the writer is closed.
 func (w *writer) open() (err error) {
 	_ = os.MkdirAll(path.Dir(w.target), 0755)
+	if w.lock, err = lockStatus(w.target); err != nil {
+		return err
+	}
 	w.file, err = utils.OpenOrCreateFile(w.target)
-	if err == nil {
-		w.writer = bufio.NewWriter(w.file)
+	if err != nil {
+		utils.LogErr("release lock", w.lock.release())
+		return err
 	}
-	return
+	w.writer = bufio.NewWriter(w.file)
+	w.stop = make(chan struct{})
+	go w.renewLock()
+	return nil
 }
 
-// Writer appends the status to the local file.
+func (w *writer) renewLock() {
+	ticker := time.NewTicker(lockHeartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if err := w.lock.renew(); err != nil {
+				utils.LogErr("renew lock of "+w.target, err)
+				return
+			}
+		}
+	}
+}
+
+// Writer appends the status to the local file and syncs it, so that the
+// status is not lost in a crash. A line torn by a crash is skipped when the
+// file is read, and the previous status is read instead. Nothing is written
+// if the lock of the file was taken over.
 func (w *writer) write(st *model.Status) error {
 	w.mu.Lock()
 	defer w.mu.Unlock()
+	return w.lock.fenced(func() error {
+		_, err := w.writer.WriteString(statusLine(st))
+		utils.LogErr("write status", err)
+		if err := w.writer.Flush(); err != nil {
+			return err
+		}
+		return w.file.Sync()
+	})
+}
+
+// statusLine returns the status as a line of a status file.
+func statusLine(st *model.Status) string {
 	jsonb, _ := st.ToJson()
 	str := strings.ReplaceAll(string(jsonb), "\n", " ")
 	str = strings.ReplaceAll(str, "\r", " ")
-	_, err := w.writer.WriteString(str + "\n")
-	utils.LogErr("write status", err)
-	return w.writer.Flush()
+	return str + "\n"
 }
 
 // Close closes the writer.
@@ -49,10 +90,12 @@ func (w *writer) close() (err error) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 	if !w.closed {
+		close(w.stop)
 		err = w.writer.Flush()
 		utils.LogErr("flush file", err)
 		utils.LogErr("file sync", w.file.Sync())
 		utils.LogErr("file close", w.file.Close())
+		utils.LogErr("release lock", w.lock.release())
 		w.closed = true
 	}
 	return err
@@ -0,0 +1,56 @@
+package local
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/local/storage"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+)
+
+type alertStoreImpl struct {
+	storage *storage.Storage
+}
+
+func NewAlertStore(s *storage.Storage) persistence.AlertStore {
+	return &alertStoreImpl{
+		storage: s,
+	}
+}
+
+func (a alertStoreImpl) Get(id string) (*model.Alert, error) {
+	b, err := a.storage.Read(alertFileName(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	alert := &model.Alert{}
+	if err := json.Unmarshal(b, alert); err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
+
+func (a alertStoreImpl) Save(id string, alert *model.Alert) error {
+	b, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	return a.storage.Write(alertFileName(id), b)
+}
+
+func (a alertStoreImpl) Delete(id string) error {
+	if !a.storage.Exists(alertFileName(id)) {
+		return nil
+	}
+	return a.storage.Delete(alertFileName(id))
+}
+
+func alertFileName(id string) string {
+	return fmt.Sprintf("%s.alert.json", normalizeFilename(id, "-"))
+}
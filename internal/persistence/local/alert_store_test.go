@@ -0,0 +1,44 @@
+package local
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/persistence/local/storage"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertStore(t *testing.T) {
+	tmpDir := utils.MustTempDir("test-alert-store")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	as := NewAlertStore(storage.NewStorage(tmpDir))
+
+	alert, err := as.Get("test")
+	require.NoError(t, err)
+	require.Nil(t, alert)
+
+	now := time.Now().Round(time.Second)
+	err = as.Save("test", &model.Alert{FirstFailureAt: now, NotifiedAt: now, Failures: 3, Suppressed: 2})
+	require.NoError(t, err)
+
+	alert, err = as.Get("test")
+	require.NoError(t, err)
+	require.Equal(t, 3, alert.Failures)
+	require.Equal(t, 2, alert.Suppressed)
+	require.True(t, now.Equal(alert.NotifiedAt))
+
+	require.NoError(t, as.Delete("test"))
+	alert, err = as.Get("test")
+	require.NoError(t, err)
+	require.Nil(t, alert)
+
+	// Deleting a missing state is not an error.
+	require.NoError(t, as.Delete("test"))
+}
@@ -0,0 +1,53 @@
+package local
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/utils"
+)
+
+type archiveStoreImpl struct {
+	dir string
+}
+
+// NewArchiveStore returns a store that keeps the files of the runs in the
+// directory as <DAG name>/<request ID>/<file>.
+func NewArchiveStore(dir string) persistence.ArchiveStore {
+	return &archiveStoreImpl{dir: dir}
+}
+
+func (s *archiveStoreImpl) Location(name, requestId string) string {
+	return filepath.Join(s.dir, utils.ValidFilename(name, "_"), utils.ValidFilename(requestId, "_"))
+}
+
+func (s *archiveStoreImpl) Put(_ context.Context, name, requestId, file string, r io.Reader, _ int64) error {
+	dir := s.Location(name, requestId)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	// The file is written under a temporary name so that an interrupted
+	// copy is not taken for the archived file.
+	dst := filepath.Join(dir, filepath.Base(file))
+	tmp := dst + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
+
+func (s *archiveStoreImpl) Open(_ context.Context, name, requestId, file string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(s.Location(name, requestId), filepath.Base(file)))
+}
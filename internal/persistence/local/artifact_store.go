@@ -0,0 +1,191 @@
+package local
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/persistence"
+)
+
+var (
+	errInvalidArtifactPath = dagerrors.New(dagerrors.CodeInvalidArgument, "invalid artifact path")
+	errPresignNotSupported = dagerrors.New(dagerrors.CodeInvalidArgument, "the artifacts have no pre-signed URLs without the s3 backend")
+)
+
+type artifactStoreImpl struct {
+	dir string
+}
+
+// NewArtifactStore returns a store that keeps the artifacts of each run in
+// <dir>/<DAG name>/<request ID>.
+func NewArtifactStore(dir string) persistence.ArtifactStore {
+	return &artifactStoreImpl{dir: dir}
+}
+
+func (a *artifactStoreImpl) Dir(name, requestId string) string {
+	return filepath.Join(a.dir, normalizeFilename(name, "-"), normalizeFilename(requestId, "-"))
+}
+
+func (a *artifactStoreImpl) Open(name, requestId, path string) (io.ReadCloser, error) {
+	file, err := a.file(name, requestId, path)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(file)
+}
+
+// file returns the file of the artifact at the path. The path must be in the
+// directory of the run.
+func (a *artifactStoreImpl) file(name, requestId, path string) (string, error) {
+	p := filepath.Clean(filepath.FromSlash(path))
+	if p == "." || filepath.IsAbs(p) || p == ".." || strings.HasPrefix(p, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s", errInvalidArtifactPath, path)
+	}
+	return filepath.Join(a.Dir(name, requestId), p), nil
+}
+
+// Publish does nothing since the directory of the run is the store.
+func (a *artifactStoreImpl) Publish(_ context.Context, _, _ string, _ []string) error {
+	return nil
+}
+
+func (a *artifactStoreImpl) Runs() ([]*persistence.ArtifactRun, error) {
+	dagDirs, err := os.ReadDir(a.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var ret []*persistence.ArtifactRun
+	for _, d := range dagDirs {
+		if !d.IsDir() {
+			continue
+		}
+		runDirs, err := os.ReadDir(filepath.Join(a.dir, d.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range runDirs {
+			if !r.IsDir() {
+				continue
+			}
+			dir := filepath.Join(a.dir, d.Name(), r.Name())
+			run := &persistence.ArtifactRun{Dir: dir, RequestId: r.Name()}
+			// The run is as old as its most recently modified file.
+			err := filepath.WalkDir(dir, func(_ string, e fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				info, err := e.Info()
+				if err != nil {
+					return err
+				}
+				if !e.IsDir() {
+					run.Size += info.Size()
+				}
+				if info.ModTime().After(run.ModTime) {
+					run.ModTime = info.ModTime()
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, run)
+		}
+	}
+	return ret, nil
+}
+
+func (a *artifactStoreImpl) Remove(run *persistence.ArtifactRun) error {
+	rel, err := filepath.Rel(a.dir, run.Dir)
+	if err != nil || len(strings.Split(rel, string(filepath.Separator))) != 2 || strings.HasPrefix(rel, "..") {
+		return fmt.Errorf("%w: %s", errInvalidArtifactPath, run.Dir)
+	}
+	if err := os.RemoveAll(run.Dir); err != nil {
+		return err
+	}
+	// The directory of the DAG is removed with its last run.
+	_ = os.Remove(filepath.Dir(run.Dir))
+	return nil
+}
+
+// List returns no artifacts if the run has none.
+func (a *artifactStoreImpl) List(_ context.Context, name, requestId string) ([]*persistence.Artifact, error) {
+	dir := a.Dir(name, requestId)
+	ret := []*persistence.Artifact{}
+	err := filepath.WalkDir(dir, func(p string, e fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !e.Type().IsRegular() {
+			return nil
+		}
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		sum, err := sha256File(p)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, p)
+		ret = append(ret, &persistence.Artifact{
+			Path:    filepath.ToSlash(rel),
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+			SHA256:  sum,
+		})
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return ret, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Path < ret[j].Path
+	})
+	return ret, nil
+}
+
+func (a *artifactStoreImpl) Delete(_ context.Context, name, requestId, path string) error {
+	file, err := a.file(name, requestId, path)
+	if err != nil {
+		return err
+	}
+	return os.Remove(file)
+}
+
+// URL returns an error since the artifacts are served by the server.
+func (a *artifactStoreImpl) URL(_ context.Context, _, _, _ string, _ time.Duration) (string, error) {
+	return "", errPresignNotSupported
+}
+
+func sha256File(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
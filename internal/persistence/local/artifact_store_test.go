@@ -0,0 +1,103 @@
+package local
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactStore(t *testing.T) {
+	tmpDir := utils.MustTempDir("test-artifact-store")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	as := NewArtifactStore(tmpDir)
+	dir := as.Dir("etl", "request-1")
+	require.Equal(t, filepath.Join(tmpDir, "etl", "request-1"), dir)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "out"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "out", "report.csv"), []byte("a,b"), 0600))
+
+	r, err := as.Open("etl", "request-1", "out/report.csv")
+	require.NoError(t, err)
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, "a,b", string(b))
+
+	_, err = as.Open("etl", "request-2", "out/report.csv")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	for _, p := range []string{"../request-2/report.csv", "/etc/passwd"} {
+		_, err = as.Open("etl", "request-1", p)
+		require.ErrorIs(t, err, errInvalidArtifactPath)
+	}
+
+	// The artifacts are listed with their digests and deleted.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "summary.txt"), []byte("ok"), 0600))
+	ctx := context.Background()
+	artifacts, err := as.List(ctx, "etl", "request-1")
+	require.NoError(t, err)
+	require.Len(t, artifacts, 2)
+	require.Equal(t, "out/report.csv", artifacts[0].Path)
+	require.Equal(t, int64(3), artifacts[0].Size)
+	require.Equal(t, "1eb7c54d52831bbfe8942af0b1c56b7409523a59ed6ca99c1174fef7eb32c1b5", artifacts[0].SHA256)
+	require.Equal(t, "summary.txt", artifacts[1].Path)
+
+	require.NoError(t, as.Delete(ctx, "etl", "request-1", "out/report.csv"))
+	require.ErrorIs(t, as.Delete(ctx, "etl", "request-1", "out/report.csv"), fs.ErrNotExist)
+	require.ErrorIs(t, as.Delete(ctx, "etl", "request-1", "../request-2"), errInvalidArtifactPath)
+	artifacts, err = as.List(ctx, "etl", "request-1")
+	require.NoError(t, err)
+	require.Len(t, artifacts, 1)
+
+	artifacts, err = as.List(ctx, "etl", "request-2")
+	require.NoError(t, err)
+	require.Empty(t, artifacts)
+
+	_, err = as.URL(ctx, "etl", "request-1", "summary.txt", time.Hour)
+	require.ErrorIs(t, err, errPresignNotSupported)
+}
+
+func TestArtifactStoreRuns(t *testing.T) {
+	tmpDir := utils.MustTempDir("test-artifact-store-runs")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	as := NewArtifactStore(tmpDir)
+	runs, err := as.Runs()
+	require.NoError(t, err)
+	require.Empty(t, runs)
+
+	for _, id := range []string{"request-1", "request-2"} {
+		dir := as.Dir("etl", id)
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "report.csv"), []byte("a,b"), 0600))
+	}
+
+	runs, err = as.Runs()
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	require.Equal(t, as.Dir("etl", "request-1"), runs[0].Dir)
+	require.Equal(t, "request-1", runs[0].RequestId)
+	require.Equal(t, int64(3), runs[0].Size)
+	require.False(t, runs[0].ModTime.IsZero())
+
+	require.NoError(t, as.Remove(runs[0]))
+	require.NoDirExists(t, runs[0].Dir)
+	require.NoError(t, as.Remove(runs[1]))
+	require.NoDirExists(t, filepath.Join(tmpDir, "etl"))
+
+	err = as.Remove(&persistence.ArtifactRun{Dir: tmpDir})
+	require.ErrorIs(t, err, errInvalidArtifactPath)
+}
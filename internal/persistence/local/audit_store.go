@@ -0,0 +1,87 @@
+package local
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+)
+
+type auditStoreImpl struct {
+	file string
+	mu   sync.Mutex
+}
+
+// NewAuditStore returns a store that appends the entries to the file as
+// JSON lines.
+func NewAuditStore(file string) persistence.AuditStore {
+	return &auditStoreImpl{file: file}
+}
+
+func (s *auditStoreImpl) Append(entry *model.AuditEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(s.file), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// maxAuditEntrySize is the maximum size of a line of the file.
+const maxAuditEntrySize = 1 << 20
+
+func (s *auditStoreImpl) List(q *persistence.AuditQuery) ([]*model.AuditEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.Open(s.file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	var entries []*model.AuditEntry
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), maxAuditEntrySize)
+	for sc.Scan() {
+		e := &model.AuditEntry{}
+		// A line that was not written completely, e.g. when the disk was
+		// full, is skipped.
+		if err := json.Unmarshal(sc.Bytes(), e); err != nil {
+			continue
+		}
+		if q.Match(e) {
+			entries = append(entries, e)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if q.Limit > 0 && len(entries) > q.Limit {
+		entries = entries[len(entries)-q.Limit:]
+	}
+	slices.Reverse(entries)
+	return entries, nil
+}
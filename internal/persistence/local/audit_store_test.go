@@ -0,0 +1,84 @@
+package local
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditStore(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "audit", "audit.jsonl")
+	s := NewAuditStore(file)
+
+	entries := []*model.AuditEntry{
+		{Time: time.Now().Round(0), Action: "mark-success", DAG: "etl", RequestId: "request-1", Step: "load", Reason: "loaded by hand"},
+		{Time: time.Now().Round(0), Actor: "admin", Action: "mark-failed", DAG: "etl", RequestId: "request-2", Step: "load", Reason: "bad data"},
+	}
+	for _, e := range entries {
+		require.NoError(t, s.Append(e))
+	}
+
+	f, err := os.Open(file)
+	require.NoError(t, err)
+	defer func() {
+		_ = f.Close()
+	}()
+	var got []*model.AuditEntry
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		e := &model.AuditEntry{}
+		require.NoError(t, json.Unmarshal(sc.Bytes(), e))
+		got = append(got, e)
+	}
+	require.Len(t, got, 2)
+	require.Equal(t, "admin", got[1].Actor)
+	require.Equal(t, "loaded by hand", got[0].Reason)
+	require.True(t, entries[0].Time.Equal(got[0].Time))
+}
+
+func TestAuditStoreList(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "audit", "audit.jsonl")
+	s := NewAuditStore(file)
+
+	entries, err := s.List(&persistence.AuditQuery{})
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	for i, e := range []*model.AuditEntry{
+		{Action: "start", DAG: "etl", Actor: "alice"},
+		{Action: "stop", DAG: "etl", Token: "ci"},
+		{Action: "delete", DAG: "report", Actor: "alice"},
+		{Action: "start", DAG: "report", Token: "ci"},
+	} {
+		e.Time = start.Add(time.Duration(i) * time.Hour)
+		require.NoError(t, s.Append(e))
+	}
+
+	actions := func(q *persistence.AuditQuery) []string {
+		entries, err := s.List(q)
+		require.NoError(t, err)
+		var ret []string
+		for _, e := range entries {
+			ret = append(ret, e.Action+" "+e.DAG)
+		}
+		return ret
+	}
+	require.Equal(t, []string{"start report", "delete report", "stop etl", "start etl"}, actions(&persistence.AuditQuery{}))
+	require.Equal(t, []string{"stop etl", "start etl"}, actions(&persistence.AuditQuery{DAG: "etl"}))
+	require.Equal(t, []string{"start report", "start etl"}, actions(&persistence.AuditQuery{Action: "start"}))
+	require.Equal(t, []string{"delete report", "start etl"}, actions(&persistence.AuditQuery{Actor: "alice"}))
+	require.Equal(t, []string{"start report", "stop etl"}, actions(&persistence.AuditQuery{Actor: "ci"}))
+	require.Equal(t, []string{"delete report", "stop etl"}, actions(&persistence.AuditQuery{
+		Since: start.Add(time.Hour),
+		Until: start.Add(3 * time.Hour),
+	}))
+	require.Equal(t, []string{"start report", "delete report"}, actions(&persistence.AuditQuery{Limit: 2}))
+}
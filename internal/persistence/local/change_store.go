@@ -0,0 +1,110 @@
+package local
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+)
+
+const changeFileSuffix = ".change.json"
+
+var changeIDRegex = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+type changeStoreImpl struct {
+	dir string
+}
+
+// NewChangeStore returns a store that keeps each change in a JSON file of
+// its ID in the directory.
+func NewChangeStore(dir string) persistence.ChangeStore {
+	return &changeStoreImpl{dir: dir}
+}
+
+func (s *changeStoreImpl) Create(change *model.Change) error {
+	if !changeIDRegex.MatchString(change.ID) {
+		return fmt.Errorf("invalid change ID: %s", change.ID)
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return err
+	}
+	return s.write(change, os.O_CREATE|os.O_EXCL|os.O_WRONLY)
+}
+
+func (s *changeStoreImpl) Get(id string) (*model.Change, error) {
+	if !changeIDRegex.MatchString(id) {
+		return nil, fmt.Errorf("%w: %s", persistence.ErrChangeNotFound, id)
+	}
+	b, err := os.ReadFile(s.file(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", persistence.ErrChangeNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	change := &model.Change{}
+	if err := json.Unmarshal(b, change); err != nil {
+		return nil, fmt.Errorf("%s: %w", id, err)
+	}
+	return change, nil
+}
+
+func (s *changeStoreImpl) Update(change *model.Change) error {
+	if _, err := s.Get(change.ID); err != nil {
+		return err
+	}
+	return s.write(change, os.O_TRUNC|os.O_WRONLY)
+}
+
+func (s *changeStoreImpl) List() ([]*model.Change, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var changes []*model.Change
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), changeFileSuffix) {
+			continue
+		}
+		change, err := s.Get(strings.TrimSuffix(e.Name(), changeFileSuffix))
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].CreatedAt.After(changes[j].CreatedAt)
+	})
+	return changes, nil
+}
+
+func (s *changeStoreImpl) write(change *model.Change, flag int) error {
+	b, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.file(change.ID), flag, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (s *changeStoreImpl) file(id string) string {
+	return filepath.Join(s.dir, id+changeFileSuffix)
+}
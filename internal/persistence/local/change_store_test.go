@@ -0,0 +1,55 @@
+package local
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeStore(t *testing.T) {
+	cs := NewChangeStore(filepath.Join(t.TempDir(), "changes"))
+
+	changes, err := cs.List()
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	first, err := model.NewChange("etl", "alice", "steps:\n  - name: a\n    command: echo 1\n", "steps:\n  - name: a\n    command: echo 2\n")
+	require.NoError(t, err)
+	require.Equal(t, model.ChangePending, first.Status)
+	require.Contains(t, first.Diff, "--- etl (current)\n+++ etl (change)\n")
+	require.Contains(t, first.Diff, "-    command: echo 1\n+    command: echo 2\n")
+	require.NoError(t, cs.Create(first))
+
+	second, err := model.NewChange("report", "bob", "", "steps: []\n")
+	require.NoError(t, err)
+	second.CreatedAt = first.CreatedAt.Add(time.Second)
+	require.NoError(t, cs.Create(second))
+	require.Error(t, cs.Create(second))
+
+	// The latest change is first.
+	changes, err = cs.List()
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	require.Equal(t, second.ID, changes[0].ID)
+	require.Equal(t, first.Spec, changes[1].Spec)
+
+	first.Status = model.ChangeApproved
+	first.Reviewer = "bob"
+	require.NoError(t, cs.Update(first))
+	got, err := cs.Get(first.ID)
+	require.NoError(t, err)
+	require.Equal(t, model.ChangeApproved, got.Status)
+	require.Equal(t, "bob", got.Reviewer)
+
+	// The unknown changes are not found, and their IDs are safe as file
+	// names.
+	_, err = cs.Get("unknown")
+	require.ErrorIs(t, err, persistence.ErrChangeNotFound)
+	_, err = cs.Get("../tokens/ci")
+	require.ErrorIs(t, err, persistence.ErrChangeNotFound)
+	require.ErrorIs(t, cs.Update(&model.Change{ID: "unknown"}), persistence.ErrChangeNotFound)
+}
@@ -3,16 +3,18 @@ package local
 import (
 	"errors"
 	"fmt"
-	"github.com/dagu-dev/dagu/internal/persistence/filecache"
+	"io/fs"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/dagu-dev/dagu/internal/dag"
-	"github.com/dagu-dev/dagu/internal/grep"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
 	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/filecache"
 	"github.com/dagu-dev/dagu/internal/utils"
 )
 
@@ -31,16 +33,21 @@ func NewDAGStore(dir string) persistence.DAGStore {
 }
 
 var (
-	errInvalidName           = errors.New("invalid name")
+	errInvalidName           = dagerrors.New(dagerrors.CodeInvalidArgument, "invalid name")
 	errFailedToReadDAGFile   = errors.New("failed to read DAG file")
-	errDOGFileNotExist       = errors.New("the DAG file does not exist")
+	errDOGFileNotExist       = dagerrors.New(dagerrors.CodeNotFound, "the DAG file does not exist")
 	errFailedToUpdateDAGFile = errors.New("failed to update DAG file")
 	errFailedToCreateDAGFile = errors.New("failed to create DAG file")
 	errFailedToCreateDAGsDir = errors.New("failed to create DAGs directory")
 	errFailedToDeleteDAGFile = errors.New("failed to delete DAG file")
-	errDAGFileAlreadyExists  = errors.New("the DAG file already exists")
-	errInvalidNewName        = errors.New("invalid new name")
-	errInvalidOldName        = errors.New("invalid old name")
+	errDAGFileAlreadyExists  = dagerrors.New(dagerrors.CodeAlreadyExists, "the DAG file already exists")
+	errInvalidNewName        = dagerrors.New(dagerrors.CodeInvalidArgument, "invalid new name")
+	errInvalidOldName        = dagerrors.New(dagerrors.CodeInvalidArgument, "invalid old name")
+	errInvalidFolder         = dagerrors.New(dagerrors.CodeInvalidArgument, "invalid folder")
+	errFolderNotExist        = dagerrors.New(dagerrors.CodeNotFound, "the folder does not exist")
+	errFolderAlreadyExists   = dagerrors.New(dagerrors.CodeAlreadyExists, "the folder already exists")
+	errFolderNotEmpty        = dagerrors.New(dagerrors.CodeInvalidArgument, "the folder is not empty")
+	errDuplicateName         = dagerrors.New(dagerrors.CodeAlreadyExists, "a DAG of the same name is in another folder")
 )
 
 func (d *dagStoreImpl) GetMetadata(name string) (*dag.DAG, error) {
@@ -80,16 +87,16 @@ func (d *dagStoreImpl) GetSpec(name string) (string, error) {
 }
 
 func (d *dagStoreImpl) UpdateSpec(name string, spec []byte) error {
+	loc, err := d.fileLocation(name)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errInvalidName, name)
+	}
 	// validation
 	cl := dag.Loader{}
-	_, err := cl.LoadData(spec)
+	_, err = cl.LoadDataAt(spec, loc)
 	if err != nil {
 		return err
 	}
-	loc, err := d.fileLocation(name)
-	if err != nil {
-		return fmt.Errorf("%w: %s", errInvalidName, name)
-	}
 	if !exists(loc) {
 		return fmt.Errorf("%w: %s", errDOGFileNotExist, loc)
 	}
@@ -112,6 +119,12 @@ func (d *dagStoreImpl) Create(name string, spec []byte) (string, error) {
 	if exists(loc) {
 		return "", fmt.Errorf("%w: %s", errDAGFileAlreadyExists, loc)
 	}
+	if other, ok := d.sameName(loc, ""); ok {
+		return "", fmt.Errorf("%w: %s", errDuplicateName, other)
+	}
+	if err := os.MkdirAll(filepath.Dir(loc), 0755); err != nil {
+		return "", fmt.Errorf("%w: %s", errFailedToCreateDAGFile, err)
+	}
 	return name, os.WriteFile(loc, spec, 0644)
 }
 
@@ -133,15 +146,47 @@ func exists(file string) bool {
 	return !os.IsNotExist(err)
 }
 
+// fileLocation returns the file of the DAG of the name, which is the path of
+// the file without the extension in the DAGs directory, e.g. team-a/etl, or
+// the path of a DAG file.
 func (d *dagStoreImpl) fileLocation(name string) (string, error) {
-	if strings.Contains(name, "/") {
+	if filepath.IsAbs(name) || (strings.Contains(name, "/") && checkExtension(name) && exists(name)) {
 		// this is for backward compatibility
 		return name, nil
 	}
+	if !validFolder(path.Dir(name)) || path.Base(name) == "." {
+		return "", fmt.Errorf("%w: %s", errInvalidName, name)
+	}
 	loc := path.Join(d.dir, name)
 	return d.normalizeFilename(loc)
 }
 
+// validFolder returns true if the folder is a relative path in the DAGs
+// directory, the directory itself being ".", without hidden directories.
+func validFolder(folder string) bool {
+	if folder == "." {
+		return true
+	}
+	if folder == "" || path.IsAbs(folder) || path.Clean(folder) != folder {
+		return false
+	}
+	for _, s := range strings.Split(folder, "/") {
+		if strings.HasPrefix(s, ".") {
+			return false
+		}
+	}
+	return true
+}
+
+// folderLocation returns the directory of the folder in the DAGs directory.
+func (d *dagStoreImpl) folderLocation(folder string) (string, error) {
+	folder = strings.Trim(folder, "/")
+	if folder == "" || folder == "." || !validFolder(folder) {
+		return "", fmt.Errorf("%w: %s", errInvalidFolder, folder)
+	}
+	return filepath.Join(d.dir, filepath.FromSlash(folder)), nil
+}
+
 func (d *dagStoreImpl) normalizeFilename(file string) (string, error) {
 	a := strings.TrimSuffix(file, ".yaml")
 	a = strings.TrimSuffix(a, ".yml")
@@ -162,77 +207,173 @@ func (d *dagStoreImpl) List() (ret []*dag.DAG, errs []string, err error) {
 		errs = append(errs, err.Error())
 		return
 	}
-	fis, err := os.ReadDir(d.dir)
+	files, err := d.files()
 	if err != nil {
 		errs = append(errs, err.Error())
 		return
 	}
-	for _, fi := range fis {
-		if checkExtension(fi.Name()) {
-			dat, err := d.GetMetadata(fi.Name())
-			if err == nil {
-				ret = append(ret, dat)
-			} else {
-				errs = append(errs, fmt.Sprintf("reading %s failed: %s", fi.Name(), err))
-			}
+	names := map[string]string{}
+	for _, file := range files {
+		if other, ok := names[dagName(file)]; ok {
+			errs = append(errs, fmt.Sprintf("%s and %s: %s", other, file, errDuplicateName))
+		}
+		names[dagName(file)] = file
+		dat, err := d.GetMetadata(path.Join(d.dir, file))
+		if err == nil {
+			ret = append(ret, dat)
+		} else {
+			errs = append(errs, fmt.Sprintf("reading %s failed: %s", file, err))
 		}
 	}
 	return ret, errs, nil
 }
 
-var extensions = []string{".yaml", ".yml"}
+func (d *dagStoreImpl) Files() ([]string, error) {
+	files, err := d.files()
+	if err != nil {
+		return nil, err
+	}
+	for i, file := range files {
+		files[i] = path.Join(d.dir, file)
+	}
+	return files, nil
+}
 
-func checkExtension(file string) bool {
-	ext := filepath.Ext(file)
-	for _, e := range extensions {
-		if e == ext {
-			return true
+// sameName returns the file of another DAG in the DAGs directory whose name
+// is the one of the file of loc, except the file of except. The names of the
+// DAGs are unique across the folders, since the history, the suspend flags
+// and the sockets of the DAGs are keyed by their names.
+func (d *dagStoreImpl) sameName(loc, except string) (string, bool) {
+	files, err := d.files()
+	if err != nil {
+		return "", false
+	}
+	name := dagName(loc)
+	for _, file := range files {
+		other := filepath.Join(d.dir, filepath.FromSlash(file))
+		if other != filepath.Clean(loc) && other != filepath.Clean(except) && dagName(file) == name {
+			return file, true
 		}
 	}
-	return false
+	return "", false
 }
 
-func (d *dagStoreImpl) Grep(pattern string) (ret []*persistence.GrepResult, errs []string, err error) {
-	if err = d.ensureDirExist(); err != nil {
-		errs = append(errs, fmt.Sprintf("failed to create DAGs directory %s", d.dir))
-		return
+// dagName returns the name of the DAG of the file, the name of the file
+// without the extension.
+func dagName(file string) string {
+	base := path.Base(filepath.ToSlash(file))
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+// files returns the DAG files in the DAGs directory and its folders, as the
+// paths in the directory, e.g. team-a/etl.yaml. The hidden directories are
+// skipped.
+func (d *dagStoreImpl) files() ([]string, error) {
+	var files []string
+	err := d.walk(func(rel string, entry fs.DirEntry) {
+		if !entry.IsDir() && checkExtension(entry.Name()) {
+			files = append(files, rel)
+		}
+	})
+	return files, err
+}
+
+func (d *dagStoreImpl) walk(fn func(rel string, entry fs.DirEntry)) error {
+	return filepath.WalkDir(d.dir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if p == d.dir {
+				return err
+			}
+			// The folders that cannot be read are skipped.
+			return nil
+		}
+		if p == d.dir {
+			return nil
+		}
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), ".") {
+			return filepath.SkipDir
+		}
+		rel, err := filepath.Rel(d.dir, p)
+		if err != nil {
+			return err
+		}
+		fn(filepath.ToSlash(rel), entry)
+		return nil
+	})
+}
+
+// Folders returns the folders in the DAGs directory, e.g. team-a and
+// team-a/etl, sorted by their paths.
+func (d *dagStoreImpl) Folders() ([]string, error) {
+	if err := d.ensureDirExist(); err != nil {
+		return nil, fmt.Errorf("%w: %s", errFailedToCreateDAGsDir, d.dir)
 	}
+	folders := []string{}
+	err := d.walk(func(rel string, entry fs.DirEntry) {
+		if entry.IsDir() {
+			folders = append(folders, rel)
+		}
+	})
+	sort.Strings(folders)
+	return folders, err
+}
 
-	fis, err := os.ReadDir(d.dir)
-	dl := &dag.Loader{}
-	opts := &grep.Options{
-		IsRegexp: true,
-		Before:   2,
-		After:    2,
+// FolderOf returns the folder of the DAG file, which is empty if the file is
+// in the DAGs directory itself or is not in it.
+func (d *dagStoreImpl) FolderOf(location string) string {
+	rel, err := filepath.Rel(d.dir, filepath.Dir(location))
+	if err != nil {
+		return ""
 	}
+	rel = filepath.ToSlash(rel)
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, "../") {
+		return ""
+	}
+	return rel
+}
 
-	utils.LogErr("read DAGs directory", err)
-	for _, fi := range fis {
-		if utils.MatchExtension(fi.Name(), dag.EXTENSIONS) {
-			file := filepath.Join(d.dir, fi.Name())
-			dat, err := os.ReadFile(file)
-			if err != nil {
-				utils.LogErr("read DAG file", err)
-				continue
-			}
-			m, err := grep.Grep(dat, fmt.Sprintf("(?i)%s", pattern), opts)
-			if err != nil {
-				errs = append(errs, fmt.Sprintf("grep %s failed: %s", fi.Name(), err))
-				continue
-			}
-			d, err := dl.LoadMetadata(file)
-			if err != nil {
-				errs = append(errs, fmt.Sprintf("check %s failed: %s", fi.Name(), err))
-				continue
-			}
-			ret = append(ret, &persistence.GrepResult{
-				Name:    strings.TrimSuffix(fi.Name(), path.Ext(fi.Name())),
-				DAG:     d,
-				Matches: m,
-			})
+// CreateFolder creates the folder and its parents in the DAGs directory.
+func (d *dagStoreImpl) CreateFolder(folder string) error {
+	dir, err := d.folderLocation(folder)
+	if err != nil {
+		return err
+	}
+	if exists(dir) {
+		return fmt.Errorf("%w: %s", errFolderAlreadyExists, folder)
+	}
+	return os.MkdirAll(dir, 0755)
+}
+
+// DeleteFolder deletes the folder, which must have no DAG file and no other
+// folder.
+func (d *dagStoreImpl) DeleteFolder(folder string) error {
+	dir, err := d.folderLocation(folder)
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%w: %s", errFolderNotExist, folder)
+	}
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("%w: %s", errFolderNotEmpty, folder)
+	}
+	return os.Remove(dir)
+}
+
+var extensions = []string{".yaml", ".yml"}
+
+func checkExtension(file string) bool {
+	ext := filepath.Ext(file)
+	for _, e := range extensions {
+		if e == ext {
+			return true
 		}
 	}
-	return ret, errs, nil
+	return false
 }
 
 func (d *dagStoreImpl) Load(name string) (*dag.DAG, error) {
@@ -249,7 +390,24 @@ func (d *dagStoreImpl) Rename(oldDAGPath, newDAGPath string) error {
 	if err != nil {
 		return fmt.Errorf("%w: %s", errInvalidNewName, newDAGPath)
 	}
-	return os.Rename(oldLoc, newLoc)
+	if !exists(oldLoc) {
+		return fmt.Errorf("%w: %s", errDOGFileNotExist, oldLoc)
+	}
+	if exists(newLoc) {
+		return fmt.Errorf("%w: %s", errDAGFileAlreadyExists, newLoc)
+	}
+	if other, ok := d.sameName(newLoc, oldLoc); ok {
+		return fmt.Errorf("%w: %s", errDuplicateName, other)
+	}
+	// The DAG is moved to the folder of the new name, which is created.
+	if err := os.MkdirAll(filepath.Dir(newLoc), 0755); err != nil {
+		return fmt.Errorf("%w: %s", errInvalidNewName, err)
+	}
+	if err := os.Rename(oldLoc, newLoc); err != nil {
+		return err
+	}
+	d.metaCache.Invalidate(oldLoc)
+	return nil
 }
 
 func (d *dagStoreImpl) FindByName(name string) (*dag.DAG, error) {
@@ -0,0 +1,67 @@
+package local
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGStoreFolders(t *testing.T) {
+	tmpDir := utils.MustTempDir("test-dag-store")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	ds := NewDAGStore(tmpDir)
+	spec := []byte("steps:\n  - name: step1\n    command: echo 1\n")
+	for _, name := range []string{"top", "team-a/etl"} {
+		_, err := ds.Create(name, spec)
+		require.NoError(t, err)
+	}
+	require.NoError(t, ds.CreateFolder("team-b/reports"))
+	require.NoError(t, os.MkdirAll(path.Join(tmpDir, ".git"), 0755))
+
+	folders, err := ds.Folders()
+	require.NoError(t, err)
+	require.Equal(t, []string{"team-a", "team-b", "team-b/reports"}, folders)
+
+	dags, errs, err := ds.List()
+	require.NoError(t, err)
+	require.Empty(t, errs)
+	require.Len(t, dags, 2)
+	require.Equal(t, path.Join(tmpDir, "team-a", "etl.yaml"), dags[0].Location)
+	require.Equal(t, "team-a", ds.FolderOf(dags[0].Location))
+	require.Equal(t, "", ds.FolderOf(dags[1].Location))
+
+	// The DAG is moved to the folder of the new name.
+	require.NoError(t, ds.Rename("team-a/etl", "team-b/reports/etl"))
+	require.FileExists(t, path.Join(tmpDir, "team-b", "reports", "etl.yaml"))
+	require.ErrorIs(t, ds.Rename("top", "team-b/reports/etl"), errDAGFileAlreadyExists)
+
+	require.ErrorIs(t, ds.CreateFolder("team-b"), errFolderAlreadyExists)
+	require.ErrorIs(t, ds.DeleteFolder("team-b"), errFolderNotEmpty)
+	require.ErrorIs(t, ds.DeleteFolder("team-c"), errFolderNotExist)
+	require.NoError(t, ds.DeleteFolder("team-a"))
+
+	// The names of the DAGs are unique across the folders.
+	_, err = ds.Create("team-b/top", spec)
+	require.ErrorIs(t, err, errDuplicateName)
+	require.ErrorIs(t, ds.Rename("top", "team-c/etl"), errDuplicateName)
+	require.NoError(t, ds.Rename("team-b/reports/etl", "etl"))
+	require.NoError(t, os.MkdirAll(path.Join(tmpDir, "team-c"), 0755))
+	require.NoError(t, os.WriteFile(path.Join(tmpDir, "team-c", "top.yaml"), spec, 0600))
+	_, errs, err = ds.List()
+	require.NoError(t, err)
+	require.Equal(t, []string{"team-c/top.yaml and top.yaml: " + errDuplicateName.Error()}, errs)
+
+	for _, name := range []string{"../etl", "team-a/../../etl", ".git/etl"} {
+		_, err := ds.Create(name, spec)
+		require.Error(t, err, name)
+	}
+	for _, folder := range []string{"..", "team-a/..", ".git", "a//b"} {
+		require.ErrorIs(t, ds.CreateFolder(folder), errInvalidFolder, folder)
+	}
+}
@@ -32,6 +32,23 @@ func (f flagStoreImpl) IsSuspended(id string) bool {
 	return f.storage.Exists(fileName(id))
 }
 
+// haltFile is the flag of the halt of the schedules. It has no .suspend
+// extension, so it is not the flag of a DAG.
+const haltFile = "halt"
+
+func (f flagStoreImpl) SetHalted(halted bool) error {
+	if halted {
+		return f.storage.Create(haltFile)
+	} else if f.IsHalted() {
+		return f.storage.Delete(haltFile)
+	}
+	return nil
+}
+
+func (f flagStoreImpl) IsHalted() bool {
+	return f.storage.Exists(haltFile)
+}
+
 func fileName(id string) string {
 	return fmt.Sprintf("%s.suspend", normalizeFilename(id, "-"))
 }
@@ -24,4 +24,13 @@ func TestFlagStore(t *testing.T) {
 	require.NoError(t, err)
 
 	require.True(t, fs.IsSuspended("test"))
+
+	// The halt is not the suspension of a DAG.
+	require.False(t, fs.IsHalted())
+	require.NoError(t, fs.SetHalted(true))
+	require.True(t, fs.IsHalted())
+	require.False(t, fs.IsSuspended("halt"))
+	require.NoError(t, fs.SetHalted(false))
+	require.False(t, fs.IsHalted())
+	require.NoError(t, fs.SetHalted(false))
 }
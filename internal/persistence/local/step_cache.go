@@ -0,0 +1,61 @@
+package local
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/dagu-dev/dagu/internal/scheduler"
+)
+
+type stepCacheImpl struct {
+	dir string
+}
+
+// NewStepCache returns a cache that keeps the results of the steps of a DAG
+// in <dir>/<DAG name>/<key>.json.
+func NewStepCache(dir, name string) scheduler.StepCache {
+	return &stepCacheImpl{dir: filepath.Join(dir, normalizeFilename(name, "-"))}
+}
+
+func (c *stepCacheImpl) Get(key string) (*scheduler.CachedResult, error) {
+	b, err := os.ReadFile(c.file(key))
+	if err != nil {
+		return nil, err
+	}
+	r := &scheduler.CachedResult{}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (c *stepCacheImpl) Put(key string, r *scheduler.CachedResult) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return err
+	}
+	// The result is renamed into place so that a concurrent run does not
+	// read a partial file.
+	tmp, err := os.CreateTemp(c.dir, "."+key+"-")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.file(key))
+}
+
+func (c *stepCacheImpl) file(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
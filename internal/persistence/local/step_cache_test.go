@@ -0,0 +1,30 @@
+package local
+
+import (
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	c := NewStepCache(tmpDir, "etl job")
+
+	_, err := c.Get("abc")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	out := `{"n":1}`
+	r := &scheduler.CachedResult{RequestId: "request-1", CachedAt: time.Now().Round(0), Output: "1", StepOutput: &out}
+	require.NoError(t, c.Put("abc", r))
+	require.FileExists(t, filepath.Join(tmpDir, "etl-job", "abc.json"))
+
+	got, err := c.Get("abc")
+	require.NoError(t, err)
+	require.Equal(t, r.RequestId, got.RequestId)
+	require.True(t, r.CachedAt.Equal(got.CachedAt))
+	require.Equal(t, out, *got.StepOutput)
+}
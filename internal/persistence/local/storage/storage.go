@@ -24,6 +24,16 @@ func (s *Storage) Create(file string) error {
 	return os.WriteFile(path.Join(s.Dir, file), []byte{}, 0644)
 }
 
+// Write writes the data to the given file.
+func (s *Storage) Write(file string, data []byte) error {
+	return os.WriteFile(path.Join(s.Dir, file), data, 0644)
+}
+
+// Read returns the contents of the given file.
+func (s *Storage) Read(file string) ([]byte, error) {
+	return os.ReadFile(path.Join(s.Dir, file))
+}
+
 // Exists returns true if the given file exists.
 func (s *Storage) Exists(file string) bool {
 	_, err := os.Stat(path.Join(s.Dir, file))
@@ -0,0 +1,99 @@
+package local
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+)
+
+const tokenFileSuffix = ".token.json"
+
+var tokenNameRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+type tokenStoreImpl struct {
+	dir string
+}
+
+// NewTokenStore returns a store that keeps each token in a JSON file of its
+// name in the directory.
+func NewTokenStore(dir string) persistence.TokenStore {
+	return &tokenStoreImpl{dir: dir}
+}
+
+func (s *tokenStoreImpl) Create(token *model.APIToken) error {
+	if !tokenNameRegex.MatchString(token.Name) {
+		return fmt.Errorf("%w: %s", persistence.ErrInvalidTokenName, token.Name)
+	}
+	b, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.file(token.Name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("%w: %s", persistence.ErrTokenExists, token.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (s *tokenStoreImpl) List() ([]*model.APIToken, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var tokens []*model.APIToken
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), tokenFileSuffix) {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(s.dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		token := &model.APIToken{}
+		if err := json.Unmarshal(b, token); err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].Name < tokens[j].Name
+	})
+	return tokens, nil
+}
+
+func (s *tokenStoreImpl) Delete(name string) error {
+	if !tokenNameRegex.MatchString(name) {
+		return fmt.Errorf("%w: %s", persistence.ErrTokenNotFound, name)
+	}
+	err := os.Remove(s.file(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %s", persistence.ErrTokenNotFound, name)
+	}
+	return err
+}
+
+func (s *tokenStoreImpl) file(name string) string {
+	return filepath.Join(s.dir, name+tokenFileSuffix)
+}
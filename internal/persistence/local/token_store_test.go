@@ -0,0 +1,50 @@
+package local
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenStore(t *testing.T) {
+	ts := NewTokenStore(filepath.Join(t.TempDir(), "tokens"))
+
+	tokens, err := ts.List()
+	require.NoError(t, err)
+	require.Empty(t, tokens)
+
+	ci, secret, err := model.NewAPIToken("ci", []string{"trigger"}, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, model.HashAPIToken(secret), ci.Hash)
+	require.False(t, ci.Expired(time.Now()))
+	require.True(t, ci.Expired(ci.ExpiresAt))
+	require.NoError(t, ts.Create(ci))
+
+	grafana, _, err := model.NewAPIToken("grafana", []string{"read"}, time.Time{})
+	require.NoError(t, err)
+	require.False(t, grafana.Expired(time.Now().Add(time.Hour*24*365)))
+	require.NoError(t, ts.Create(grafana))
+
+	// The names are unique and safe as file names.
+	require.ErrorIs(t, ts.Create(ci), persistence.ErrTokenExists)
+	require.ErrorIs(t, ts.Create(&model.APIToken{Name: "../ci"}), persistence.ErrInvalidTokenName)
+
+	tokens, err = ts.List()
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+	require.Equal(t, "ci", tokens[0].Name)
+	require.Equal(t, ci.Hash, tokens[0].Hash)
+	require.Equal(t, []string{"trigger"}, tokens[0].Scopes)
+	require.True(t, ci.ExpiresAt.Equal(tokens[0].ExpiresAt))
+	require.True(t, tokens[1].ExpiresAt.IsZero())
+
+	require.NoError(t, ts.Delete("ci"))
+	require.ErrorIs(t, ts.Delete("ci"), persistence.ErrTokenNotFound)
+	tokens, err = ts.List()
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// Alert is the state of the failure notifications of a DAG since it last
+// succeeded.
+type Alert struct {
+	// FirstFailureAt is the time of the first failure since the DAG last
+	// succeeded.
+	FirstFailureAt time.Time `json:"FirstFailureAt"`
+	// NotifiedAt is the time the last notification was sent.
+	NotifiedAt time.Time `json:"NotifiedAt"`
+	// Failures is the number of failures since the DAG last succeeded.
+	Failures int `json:"Failures"`
+	// Suppressed is the number of failures since the last notification.
+	Suppressed int `json:"Suppressed"`
+}
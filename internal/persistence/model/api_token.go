@@ -0,0 +1,52 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// apiTokenPrefix is the prefix of the secrets of the API tokens, which
+// tells them apart from the other credentials, e.g. in a secret scanner.
+const apiTokenPrefix = "dagu_"
+
+// APIToken is an API token created with the API or the CLI. Only the hash of
+// its secret is stored, so the secret is shown once when it is created.
+type APIToken struct {
+	Name string `json:"Name"`
+	// Hash is the hex SHA-256 hash of the secret of the token.
+	Hash      string    `json:"Hash"`
+	Scopes    []string  `json:"Scopes"`
+	CreatedAt time.Time `json:"CreatedAt"`
+	// ExpiresAt is zero if the token does not expire.
+	ExpiresAt time.Time `json:"ExpiresAt"`
+}
+
+// NewAPIToken returns a token of the name with the scopes and a new secret,
+// which expires at expiresAt unless it is zero. It returns the secret too.
+func NewAPIToken(name string, scopes []string, expiresAt time.Time) (*APIToken, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", err
+	}
+	secret := apiTokenPrefix + hex.EncodeToString(b)
+	return &APIToken{
+		Name:      name,
+		Hash:      HashAPIToken(secret),
+		Scopes:    scopes,
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}, secret, nil
+}
+
+// HashAPIToken returns the hash of the secret of a token.
+func HashAPIToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Expired returns true if the token expired at the time.
+func (t *APIToken) Expired(now time.Time) bool {
+	return !t.ExpiresAt.IsZero() && !now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// AuditEntry is an action an operator took on a DAG or a run.
+type AuditEntry struct {
+	Time time.Time `json:"Time"`
+	// Actor is the user who took the action. It is empty if the server
+	// does not authenticate the users.
+	Actor string `json:"Actor,omitempty"`
+	// Token is the name of the API token the action was taken with. The
+	// actor is the user the token impersonated, if any.
+	Token     string `json:"Token,omitempty"`
+	Action    string `json:"Action"`
+	DAG       string `json:"DAG"`
+	RequestId string `json:"RequestId,omitempty"`
+	Step      string `json:"Step,omitempty"`
+	Reason    string `json:"Reason,omitempty"`
+
+	// Detail is about the action, e.g. the new name of a renamed DAG.
+	Detail string `json:"Detail,omitempty"`
+	// SourceIP is the address of the client of the request.
+	SourceIP string `json:"SourceIP,omitempty"`
+}
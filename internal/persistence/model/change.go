@@ -0,0 +1,68 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// The statuses of a change of a DAG under change control.
+const (
+	ChangePending  = "pending"
+	ChangeApproved = "approved"
+	ChangeRejected = "rejected"
+)
+
+// Change is an edit of the definition of a DAG of a namespace under change
+// control. The definition is not saved until a user other than its author
+// approves the change, so the scheduler and the runs use the approved
+// definition until then.
+type Change struct {
+	ID string `json:"Id"`
+	// DAG is the name of the DAG, as the API identifies it.
+	DAG       string    `json:"DAG"`
+	Author    string    `json:"Author"`
+	CreatedAt time.Time `json:"CreatedAt"`
+	// Base is the definition the change was made to, and Spec the
+	// definition of the change. Diff is the unified diff between them.
+	Base string `json:"Base"`
+	Spec string `json:"Spec"`
+	Diff string `json:"Diff"`
+
+	Status string `json:"Status"`
+	// Reviewer is the user who approved or rejected the change, with the
+	// reason of the rejection, if any.
+	Reviewer   string    `json:"Reviewer,omitempty"`
+	ReviewedAt time.Time `json:"ReviewedAt,omitempty"`
+	Reason     string    `json:"Reason,omitempty"`
+}
+
+// NewChange returns the pending change of the definition of the DAG from
+// base to spec, with its diff.
+func NewChange(name, author, base, spec string) (*Change, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(base),
+		B:        difflib.SplitLines(spec),
+		FromFile: name + " (current)",
+		ToFile:   name + " (change)",
+		Context:  3,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Change{
+		ID:        id.String(),
+		DAG:       name,
+		Author:    author,
+		CreatedAt: time.Now(),
+		Base:      base,
+		Spec:      spec,
+		Diff:      diff,
+		Status:    ChangePending,
+	}, nil
+}
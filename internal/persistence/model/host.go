@@ -0,0 +1,81 @@
+package model
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/constants"
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/utils"
+)
+
+// toolVersionTimeout is the maximum time to wait for a version command.
+const toolVersionTimeout = 5 * time.Second
+
+// Host describes the environment a run was executed in, so that runs with
+// different results can be compared.
+type Host struct {
+	Hostname     string        `json:"Hostname"`
+	OS           string        `json:"OS"`
+	Arch         string        `json:"Arch"`
+	DaguVersion  string        `json:"DaguVersion"`
+	ToolVersions []*ToolResult `json:"ToolVersions,omitempty"`
+	// ProcessStart is the start time of the process of the run returned by
+	// utils.ProcessStart, which tells the process from another one that
+	// reused its pid.
+	ProcessStart uint64 `json:"ProcessStart,omitempty"`
+}
+
+// ToolResult is the version of a tool declared by the DAG.
+type ToolResult struct {
+	Name    string `json:"Name"`
+	Version string `json:"Version"`
+	Error   string `json:"Error,omitempty"`
+}
+
+// NewHost describes the current host and runs the version commands of the
+// tools of the DAG.
+func NewHost(ctx context.Context, d *dag.DAG) *Host {
+	hostname, _ := os.Hostname()
+	h := &Host{
+		Hostname:    hostname,
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		DaguVersion: constants.Version,
+		// The run is executed by the current process.
+		ProcessStart: utils.ProcessStart(os.Getpid()),
+	}
+	for _, t := range d.ToolVersions {
+		version, err := toolVersion(ctx, t.Command)
+		r := &ToolResult{Name: t.Name, Version: version}
+		if err != nil {
+			r.Error = err.Error()
+		}
+		h.ToolVersions = append(h.ToolVersions, r)
+	}
+	return h
+}
+
+// toolVersion returns the first line of the output of the command. Some
+// tools print their version to stderr, so both are read.
+func toolVersion(ctx context.Context, command string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, toolVersionTimeout)
+	defer cancel()
+	program, args := utils.SplitCommand(command, false)
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, program, args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	for _, line := range strings.Split(out.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line, err
+		}
+	}
+	return "", err
+}
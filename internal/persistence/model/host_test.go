@@ -0,0 +1,35 @@
+package model
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/constants"
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHost(t *testing.T) {
+	h := NewHost(context.Background(), &dag.DAG{
+		ToolVersions: []dag.ToolVersion{
+			{Name: "echo", Command: "echo v1.2.3"},
+			{Name: "stderr", Command: "sh -c 'echo v4 >&2'"},
+			{Name: "missing", Command: "no-such-tool-xyz --version"},
+		},
+	})
+
+	hostname, _ := os.Hostname()
+	require.Equal(t, hostname, h.Hostname)
+	require.Equal(t, runtime.GOOS, h.OS)
+	require.Equal(t, runtime.GOARCH, h.Arch)
+	require.Equal(t, constants.Version, h.DaguVersion)
+
+	require.Len(t, h.ToolVersions, 3)
+	require.Equal(t, &ToolResult{Name: "echo", Version: "v1.2.3"}, h.ToolVersions[0])
+	require.Equal(t, &ToolResult{Name: "stderr", Version: "v4"}, h.ToolVersions[1])
+	require.Equal(t, "missing", h.ToolVersions[2].Name)
+	require.Empty(t, h.ToolVersions[2].Version)
+	require.NotEmpty(t, h.ToolVersions[2].Error)
+}
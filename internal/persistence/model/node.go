@@ -14,42 +14,51 @@ var (
 )
 
 type Node struct {
-	dag.Step   `json:"Step"`
-	Log        string               `json:"Log"`
-	StartedAt  string               `json:"StartedAt"`
-	FinishedAt string               `json:"FinishedAt"`
-	Status     scheduler.NodeStatus `json:"Status"`
-	RetryCount int                  `json:"RetryCount"`
-	DoneCount  int                  `json:"DoneCount"`
-	Error      string               `json:"Error"`
-	StatusText string               `json:"StatusText"`
+	dag.Step        `json:"Step"`
+	Log             string               `json:"Log"`
+	StartedAt       string               `json:"StartedAt"`
+	FinishedAt      string               `json:"FinishedAt"`
+	Status          scheduler.NodeStatus `json:"Status"`
+	RetryCount      int                  `json:"RetryCount"`
+	DoneCount       int                  `json:"DoneCount"`
+	Error           string               `json:"Error"`
+	StatusText      string               `json:"StatusText"`
+	SubRunRequestId string               `json:"SubRunRequestId,omitempty"`
+	MarkedReason    string               `json:"MarkedReason,omitempty"`
+	Preview         string               `json:"Preview,omitempty"`
 }
 
 func (n *Node) ToNode() *scheduler.Node {
 	startedAt, _ := utils.ParseTime(n.StartedAt)
 	finishedAt, _ := utils.ParseTime(n.FinishedAt)
 	return scheduler.NewNode(n.Step, scheduler.NodeState{
-		Status:     n.Status,
-		Log:        n.Log,
-		StartedAt:  startedAt,
-		FinishedAt: finishedAt,
-		RetryCount: n.RetryCount,
-		DoneCount:  n.DoneCount,
-		Error:      errFromText(n.Error),
+		Status:          n.Status,
+		Log:             n.Log,
+		StartedAt:       startedAt,
+		FinishedAt:      finishedAt,
+		RetryCount:      n.RetryCount,
+		DoneCount:       n.DoneCount,
+		Error:           errFromText(n.Error),
+		SubRunRequestId: n.SubRunRequestId,
+		MarkedReason:    n.MarkedReason,
+		Preview:         n.Preview,
 	})
 }
 
 func FromNode(n scheduler.NodeState, step dag.Step) *Node {
 	return &Node{
-		Step:       step,
-		Log:        n.Log,
-		StartedAt:  utils.FormatTime(n.StartedAt),
-		FinishedAt: utils.FormatTime(n.FinishedAt),
-		Status:     n.Status,
-		StatusText: n.Status.String(),
-		RetryCount: n.RetryCount,
-		DoneCount:  n.DoneCount,
-		Error:      errText(n.Error),
+		Step:            step,
+		Log:             n.Log,
+		StartedAt:       utils.FormatTime(n.StartedAt),
+		FinishedAt:      utils.FormatTime(n.FinishedAt),
+		Status:          n.Status,
+		StatusText:      n.Status.String(),
+		RetryCount:      n.RetryCount,
+		DoneCount:       n.DoneCount,
+		Error:           errText(n.Error),
+		SubRunRequestId: n.SubRunRequestId,
+		MarkedReason:    n.MarkedReason,
+		Preview:         n.Preview,
 	}
 }
 
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dagu-dev/dagu/internal/scheduler"
+)
+
+// SchemaVersion is the version of the format of the statuses written by
+// this version of dagu. It is increased when the format changes in a way
+// that needs a migration of the statuses of the previous versions.
+//
+// The statuses are decoded with all their versions, because the history
+// files and the running executions may have been written by an older
+// binary, e.g. after an upgrade while some DAGs were running.
+const SchemaVersion = 1
+
+// migrations upgrade the raw status of the version at the index to the
+// next version.
+var migrations = []func(raw map[string]any){
+	migrateV0,
+}
+
+// migrateV0 upgrades the statuses written before the format was versioned.
+// Some of them have no status texts, and no pid when they were not
+// running.
+func migrateV0(raw map[string]any) {
+	if _, ok := raw["Pid"]; !ok {
+		raw["Pid"] = int(PidNotRunning)
+	}
+	if text, _ := raw["StatusText"].(string); text == "" {
+		code, _ := raw["Status"].(float64)
+		raw["StatusText"] = scheduler.Status(code).String()
+	}
+	nodes, _ := raw["Nodes"].([]any)
+	for _, key := range []string{"OnExit", "OnSuccess", "OnFailure", "OnCancel"} {
+		nodes = append(nodes, raw[key])
+	}
+	for _, n := range nodes {
+		node, ok := n.(map[string]any)
+		if !ok {
+			continue
+		}
+		if text, _ := node["StatusText"].(string); text == "" {
+			code, _ := node["Status"].(float64)
+			node["StatusText"] = scheduler.NodeStatus(code).String()
+		}
+	}
+}
+
+// migrate decodes the status of an older version and upgrades it to the
+// current version.
+func migrate(data []byte, version int) (*Status, error) {
+	raw := map[string]any{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	for v := max(version, 0); v < SchemaVersion; v++ {
+		migrations[v](raw)
+	}
+	raw["SchemaVersion"] = SchemaVersion
+	migrated, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate status: %w", err)
+	}
+	status := &Status{}
+	if err := json.Unmarshal(migrated, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// NewerSchema reports whether the status was written by a newer version of
+// dagu. Its fields unknown to this version are lost.
+func (st *Status) NewerSchema() bool {
+	return st.SchemaVersion > SchemaVersion
+}
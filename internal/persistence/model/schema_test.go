@@ -0,0 +1,71 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStatusSchemaCompatibility decodes the statuses written by the
+// versions of dagu in testdata.
+func TestStatusSchemaCompatibility(t *testing.T) {
+	read := func(t *testing.T, name string) *Status {
+		data, err := os.ReadFile(filepath.Join("testdata", name))
+		require.NoError(t, err)
+		st, err := StatusFromJson(string(data))
+		require.NoError(t, err)
+		return st
+	}
+
+	t.Run("Legacy", func(t *testing.T) {
+		st := read(t, "status_v0.json")
+		require.Equal(t, SchemaVersion, st.SchemaVersion)
+		require.Equal(t, "legacy", st.Name)
+		require.Equal(t, scheduler.StatusSuccess, st.Status)
+		require.Equal(t, "finished", st.StatusText)
+		require.Equal(t, PidNotRunning, st.Pid)
+		require.False(t, st.Pid.IsRunning())
+		require.Len(t, st.Nodes, 2)
+		require.Equal(t, "extract", st.Nodes[0].Name)
+		require.Equal(t, "finished", st.Nodes[0].StatusText)
+		require.Equal(t, []string{"extract"}, st.Nodes[1].Depends)
+		require.Equal(t, "not started", st.Nodes[1].StatusText)
+		require.Equal(t, "finished", st.OnExit.StatusText)
+		require.Nil(t, st.OnFailure)
+	})
+
+	t.Run("Current", func(t *testing.T) {
+		st := read(t, "status_v1.json")
+		require.Equal(t, SchemaVersion, st.SchemaVersion)
+		require.Equal(t, scheduler.StatusRunning, st.Status)
+		require.Equal(t, Pid(1234), st.Pid)
+		require.Equal(t, 5, st.Priority)
+		require.Equal(t, scheduler.NodeStatusRunning, st.Nodes[0].Status)
+	})
+
+	t.Run("Newer", func(t *testing.T) {
+		st := read(t, "status_newer.json")
+		require.True(t, st.NewerSchema())
+		require.Equal(t, "newer", st.Name)
+		require.Equal(t, scheduler.StatusSuccess, st.Status)
+		require.Len(t, st.Nodes, 1)
+		require.Equal(t, "extract", st.Nodes[0].Name)
+		require.Equal(t, 1, st.Nodes[0].DoneCount)
+	})
+}
+
+func TestStatusSchemaVersion(t *testing.T) {
+	st := NewStatus(&dag.DAG{Name: "test"}, nil, scheduler.StatusNone, int(PidNotRunning), nil, nil)
+	require.Equal(t, SchemaVersion, st.SchemaVersion)
+	require.False(t, st.NewerSchema())
+
+	js, err := st.ToJson()
+	require.NoError(t, err)
+	decoded, err := StatusFromJson(string(js))
+	require.NoError(t, err)
+	require.Equal(t, SchemaVersion, decoded.SchemaVersion)
+}
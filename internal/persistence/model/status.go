@@ -9,6 +9,7 @@ import (
 
 	"github.com/dagu-dev/dagu/internal/dag"
 	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/secret"
 	"github.com/dagu-dev/dagu/internal/utils"
 )
 
@@ -20,6 +21,10 @@ type Pid int
 
 const PidNotRunning Pid = -1
 
+// LaneManual is the lane of the runs started by hand that are queued ahead
+// of the scheduled runs.
+const LaneManual = "manual"
+
 func (p Pid) String() string {
 	if p == PidNotRunning {
 		return ""
@@ -42,11 +47,79 @@ type Status struct {
 	OnSuccess  *Node            `json:"OnSuccess"`
 	OnFailure  *Node            `json:"OnFailure"`
 	OnCancel   *Node            `json:"OnCancel"`
+	OnTimeout  *Node            `json:"OnTimeout,omitempty"`
 	StartedAt  string           `json:"StartedAt"`
 	FinishedAt string           `json:"FinishedAt"`
 	Log        string           `json:"Log"`
 	Params     string           `json:"Params"`
-	mu         sync.RWMutex
+	// Schedule is the cron expression of the schedule entry that started
+	// the run. It is empty for runs started manually.
+	Schedule string `json:"Schedule,omitempty"`
+	// RestartedFrom is the request ID of the run this run restarted, by the
+	// restart command or by a start entry after a stop entry stopped it.
+	RestartedFrom string `json:"RestartedFrom,omitempty"`
+	// RestartedAs is the request ID of the run that restarted this run.
+	RestartedAs string `json:"RestartedAs,omitempty"`
+	// StoppedBy is the cron expression of the schedule entry that stopped
+	// the run.
+	StoppedBy string `json:"StoppedBy,omitempty"`
+	// Initiator is who started the run through the API.
+	Initiator *Initiator `json:"Initiator,omitempty"`
+	// Archive is where the log files of the run were moved to when it was
+	// archived. It is nil if the run is not archived.
+	Archive *Archive `json:"Archive,omitempty"`
+	// Host is the host and the tool versions the run was executed with.
+	Host *Host `json:"Host,omitempty"`
+	// LogFormat is the format the step logs of the run are written in.
+	LogFormat string `json:"LogFormat,omitempty"`
+	// Priority is the priority of the run in the queue of its pool, and
+	// QueuePosition is its position in the queue while it waits for a slot.
+	Priority      int `json:"Priority,omitempty"`
+	QueuePosition int `json:"QueuePosition,omitempty"`
+	// Lane is manual if the run was started by hand and queued ahead of the
+	// scheduled runs, and Overtook are the request IDs of the scheduled
+	// runs queued before it that it took the slot of its pool ahead of.
+	Lane     string   `json:"Lane,omitempty"`
+	Overtook []string `json:"Overtook,omitempty"`
+	// SchemaVersion is the version of the format of the status.
+	SchemaVersion int `json:"SchemaVersion,omitempty"`
+	mu            sync.RWMutex
+}
+
+// Initiator is the name of the API token a run was started with and the
+// user it was started by, or on behalf of if the token impersonated the
+// user.
+type Initiator struct {
+	Token string `json:"Token,omitempty"`
+	User  string `json:"User,omitempty"`
+}
+
+// Archive is the location of the archive of a run in the cold tier, e.g.
+// s3://bucket/prefix/etl/<request ID>, and the time it was archived at.
+type Archive struct {
+	Location   string `json:"Location"`
+	ArchivedAt string `json:"ArchivedAt"`
+}
+
+// LogPaths returns the paths of the logs of the run: the scheduler log and
+// the logs of the steps and the handlers.
+func (st *Status) LogPaths() []string {
+	paths := []string{st.Log}
+	nodes := []*Node{st.OnExit, st.OnSuccess, st.OnFailure, st.OnCancel, st.OnTimeout}
+	for _, n := range append(nodes, st.Nodes...) {
+		if n != nil {
+			paths = append(paths, n.Log)
+		}
+	}
+	var ret []string
+	seen := make(map[string]bool)
+	for _, p := range paths {
+		if p != "" && !seen[p] {
+			seen[p] = true
+			ret = append(ret, p)
+		}
+	}
+	return ret
 }
 
 type StatusFile struct {
@@ -54,12 +127,18 @@ type StatusFile struct {
 	Status *Status
 }
 
+// StatusFromJson decodes the status and migrates it from the version it
+// was written with. The statuses of newer versions are decoded as they are,
+// without the fields this version does not know.
 func StatusFromJson(s string) (*Status, error) {
 	status := &Status{}
 	err := json.Unmarshal([]byte(s), status)
 	if err != nil {
 		return nil, err
 	}
+	if status.SchemaVersion < SchemaVersion {
+		return migrate([]byte(s), status.SchemaVersion)
+	}
 	return status, err
 }
 
@@ -83,24 +162,29 @@ func NewStatus(
 	pid int,
 	startTime, endTime *time.Time,
 ) *Status {
-	var onExit, onSuccess, onFailure, onCancel *Node
+	var onExit, onSuccess, onFailure, onCancel, onTimeout *Node
 	onExit = nodeOrNil(d.HandlerOn.Exit)
 	onSuccess = nodeOrNil(d.HandlerOn.Success)
 	onFailure = nodeOrNil(d.HandlerOn.Failure)
 	onCancel = nodeOrNil(d.HandlerOn.Cancel)
+	onTimeout = nodeOrNil(d.HandlerOn.Timeout)
 	return &Status{
-		Name:       d.Name,
-		Status:     status,
-		StatusText: status.String(),
-		Pid:        Pid(pid),
-		Nodes:      nodesOrSteps(nodes, d.Steps),
-		OnExit:     onExit,
-		OnSuccess:  onSuccess,
-		OnFailure:  onFailure,
-		OnCancel:   onCancel,
-		StartedAt:  formatTime(startTime),
-		FinishedAt: formatTime(endTime),
-		Params:     strings.Join(d.Params, " "),
+		Name:          d.Name,
+		Status:        status,
+		StatusText:    status.String(),
+		Pid:           Pid(pid),
+		Nodes:         nodesOrSteps(nodes, d.Steps),
+		OnExit:        onExit,
+		OnSuccess:     onSuccess,
+		OnFailure:     onFailure,
+		OnCancel:      onCancel,
+		OnTimeout:     onTimeout,
+		StartedAt:     formatTime(startTime),
+		FinishedAt:    formatTime(endTime),
+		Params:        strings.Join(d.Params, " "),
+		LogFormat:     d.LogFormat,
+		Priority:      d.Priority,
+		SchemaVersion: SchemaVersion,
 	}
 }
 
@@ -139,5 +223,6 @@ func (st *Status) ToJson() ([]byte, error) {
 	if err != nil {
 		return []byte{}, err
 	}
-	return js, nil
+	// The command arguments and outputs may contain resolved secrets.
+	return []byte(secret.Mask(string(js))), nil
 }
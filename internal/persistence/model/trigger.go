@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// Trigger is a start of a run of a DAG the server accepted, kept in the
+// trigger queue until the scheduler starts the run.
+type Trigger struct {
+	// ID is the position of the trigger in the queue.
+	ID int64 `json:"Id"`
+	// DAG is the name of the DAG, as the API identifies it.
+	DAG       string     `json:"DAG"`
+	RequestId string     `json:"RequestId"`
+	Params    string     `json:"Params,omitempty"`
+	Initiator *Initiator `json:"Initiator,omitempty"`
+	Faults    []string   `json:"Faults,omitempty"`
+
+	ReceivedAt time.Time `json:"ReceivedAt"`
+	// Attempts is the number of the times the scheduler claimed the
+	// trigger, and Error the reason the last attempt did not start the run.
+	Attempts int    `json:"Attempts"`
+	Error    string `json:"Error,omitempty"`
+}
@@ -0,0 +1,217 @@
+package s3
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/aws"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/persistence"
+)
+
+const service = "s3"
+
+var (
+	errBucketRequired      = errors.New("the bucket of the artifacts is not configured")
+	errInvalidArtifactPath = dagerrors.New(dagerrors.CodeInvalidArgument, "invalid artifact path")
+)
+
+type artifactStoreImpl struct {
+	persistence.ArtifactStore
+	bucket string
+	prefix string
+	// send sends the requests to S3 and presign signs their URLs. They are
+	// replaced in tests.
+	send    func(ctx context.Context, method, service, path string, body io.Reader, size int64) (*http.Response, error)
+	presign func(ctx context.Context, method, service, path string, expires time.Duration) (string, error)
+}
+
+// NewArtifactStore returns a store that publishes the artifacts of the
+// local store to the bucket as <prefix>/<DAG name>/<request ID>/<path>.
+// The artifacts that are no longer in the local store, e.g. after they are
+// garbage collected, are read from the bucket.
+func NewArtifactStore(local persistence.ArtifactStore, bucket, prefix string) persistence.ArtifactStore {
+	return &artifactStoreImpl{
+		ArtifactStore: local,
+		bucket:        bucket,
+		prefix:        strings.Trim(prefix, "/"),
+		send:          aws.Send,
+		presign:       aws.Presign,
+	}
+}
+
+func (a *artifactStoreImpl) Open(name, requestId, p string) (io.ReadCloser, error) {
+	r, err := a.ArtifactStore.Open(name, requestId, p)
+	if !errors.Is(err, fs.ErrNotExist) {
+		return r, err
+	}
+	if a.bucket == "" {
+		return nil, errBucketRequired
+	}
+	key := a.key(name, requestId, p)
+	resp, err := a.send(context.Background(), http.MethodGet, service, a.objectPath(key), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%w: s3://%s/%s", fs.ErrNotExist, a.bucket, key)
+	}
+	return resp.Body, nil
+}
+
+func (a *artifactStoreImpl) Publish(ctx context.Context, name, requestId string, paths []string) error {
+	if a.bucket == "" {
+		return errBucketRequired
+	}
+	dir := a.Dir(name, requestId)
+	for _, p := range paths {
+		if err := a.put(ctx, filepath.Join(dir, filepath.FromSlash(p)), a.key(name, requestId, p)); err != nil {
+			return fmt.Errorf("failed to publish %s: %w", p, err)
+		}
+	}
+	return nil
+}
+
+func (a *artifactStoreImpl) put(ctx context.Context, file, key string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	resp, err := a.send(ctx, http.MethodPut, service, a.objectPath(key), f, info.Size())
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("bucket %s is not found", a.bucket)
+	}
+	return nil
+}
+
+// List lists the artifacts of the run in the bucket if they are no longer in
+// the local store.
+func (a *artifactStoreImpl) List(ctx context.Context, name, requestId string) ([]*persistence.Artifact, error) {
+	ret, err := a.ArtifactStore.List(ctx, name, requestId)
+	if err != nil || len(ret) > 0 {
+		return ret, err
+	}
+	if a.bucket == "" {
+		return nil, errBucketRequired
+	}
+	prefix := a.key(name, requestId, "") + "/"
+	var token string
+	for {
+		q := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			q.Set("continuation-token", token)
+		}
+		result, err := a.list(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range result.Contents {
+			ret = append(ret, &persistence.Artifact{
+				Path:    strings.TrimPrefix(c.Key, prefix),
+				Size:    c.Size,
+				ModTime: c.LastModified,
+				ETag:    strings.Trim(c.ETag, `"`),
+			})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return ret, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// listResult is the response of ListObjectsV2.
+type listResult struct {
+	IsTruncated           bool
+	NextContinuationToken string
+	Contents              []struct {
+		Key          string
+		Size         int64
+		ETag         string
+		LastModified time.Time
+	}
+}
+
+func (a *artifactStoreImpl) list(ctx context.Context, q url.Values) (*listResult, error) {
+	resp, err := a.send(ctx, http.MethodGet, service, "/"+a.bucket+"?"+q.Encode(), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("bucket %s is not found", a.bucket)
+	}
+	result := &listResult{}
+	if err := xml.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Delete removes the artifact from the local store and the bucket.
+func (a *artifactStoreImpl) Delete(ctx context.Context, name, requestId, p string) error {
+	if err := a.ArtifactStore.Delete(ctx, name, requestId, p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if a.bucket == "" {
+		return errBucketRequired
+	}
+	resp, err := a.send(ctx, http.MethodDelete, service, a.objectPath(a.key(name, requestId, p)), nil, 0)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// URL returns the URL of the object of the artifact in the bucket signed
+// with the configured credentials.
+func (a *artifactStoreImpl) URL(ctx context.Context, name, requestId, p string, expires time.Duration) (string, error) {
+	if a.bucket == "" {
+		return "", errBucketRequired
+	}
+	if c := path.Clean(p); c == "." || c == ".." || strings.HasPrefix(c, "../") || path.IsAbs(c) {
+		return "", fmt.Errorf("%w: %s", errInvalidArtifactPath, p)
+	}
+	return a.presign(ctx, http.MethodGet, service, a.objectPath(a.key(name, requestId, p)), expires)
+}
+
+func (a *artifactStoreImpl) key(name, requestId, p string) string {
+	return path.Join(a.prefix, name, requestId, p)
+}
+
+// objectPath returns the path of the object in the path-style URL of the
+// bucket. The segments of the key are escaped as Signature Version 4
+// requires.
+func (a *artifactStoreImpl) objectPath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, s := range segments {
+		segments[i] = strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+	}
+	return "/" + a.bucket + "/" + strings.Join(segments, "/")
+}
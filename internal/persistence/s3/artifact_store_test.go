@@ -0,0 +1,113 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/persistence/local"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactStore(t *testing.T) {
+	tmpDir := t.TempDir()
+	objects := map[string]string{}
+	as := NewArtifactStore(local.NewArtifactStore(tmpDir), "artifacts", "/dagu/").(*artifactStoreImpl)
+	as.send = func(_ context.Context, method, svc, p string, body io.Reader, _ int64) (*http.Response, error) {
+		require.Equal(t, "s3", svc)
+		resp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}
+		switch method {
+		case http.MethodPut:
+			b, err := io.ReadAll(body)
+			require.NoError(t, err)
+			objects[p] = string(b)
+		case http.MethodGet:
+			if strings.HasPrefix(p, "/artifacts?") {
+				resp.Body = io.NopCloser(strings.NewReader(listObjects(t, p, objects)))
+				break
+			}
+			v, ok := objects[p]
+			if !ok {
+				resp.StatusCode = http.StatusNotFound
+			}
+			resp.Body = io.NopCloser(strings.NewReader(v))
+		case http.MethodDelete:
+			delete(objects, p)
+			resp.StatusCode = http.StatusNoContent
+		}
+		return resp, nil
+	}
+	as.presign = func(_ context.Context, method, svc, p string, expires time.Duration) (string, error) {
+		return fmt.Sprintf("https://s3.example.com%s?X-Amz-Expires=%d", p, int(expires.Seconds())), nil
+	}
+
+	dir := as.Dir("etl", "request-1")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "out"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "out", "report 1.csv"), []byte("a,b"), 0600))
+	require.NoError(t, as.Publish(context.Background(), "etl", "request-1", []string{"out/report 1.csv"}))
+	require.Equal(t, map[string]string{"/artifacts/dagu/etl/request-1/out/report%201.csv": "a,b"}, objects)
+
+	// The artifacts removed from the local store are read from the bucket.
+	require.NoError(t, os.RemoveAll(dir))
+	r, err := as.Open("etl", "request-1", "out/report 1.csv")
+	require.NoError(t, err)
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, "a,b", string(b))
+
+	_, err = as.Open("etl", "request-2", "out/report 1.csv")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	// The artifacts removed from the local store are listed from the bucket.
+	ctx := context.Background()
+	artifacts, err := as.List(ctx, "etl", "request-1")
+	require.NoError(t, err)
+	require.Len(t, artifacts, 1)
+	require.Equal(t, "out/report 1.csv", artifacts[0].Path)
+	require.Equal(t, int64(3), artifacts[0].Size)
+	require.Equal(t, "etag", artifacts[0].ETag)
+
+	u, err := as.URL(ctx, "etl", "request-1", "out/report 1.csv", time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, "https://s3.example.com/artifacts/dagu/etl/request-1/out/report%201.csv?X-Amz-Expires=3600", u)
+	_, err = as.URL(ctx, "etl", "request-1", "../request-2/a.csv", time.Hour)
+	require.ErrorIs(t, err, errInvalidArtifactPath)
+
+	require.NoError(t, as.Delete(ctx, "etl", "request-1", "out/report 1.csv"))
+	require.Empty(t, objects)
+	artifacts, err = as.List(ctx, "etl", "request-1")
+	require.NoError(t, err)
+	require.Empty(t, artifacts)
+
+	_, err = NewArtifactStore(local.NewArtifactStore(tmpDir), "", "").Open("etl", "request-1", "a.csv")
+	require.ErrorIs(t, err, errBucketRequired)
+}
+
+// listObjects returns the response of ListObjectsV2 of the objects with the
+// prefix of the query of the path.
+func listObjects(t *testing.T, p string, objects map[string]string) string {
+	u, err := url.Parse(p)
+	require.NoError(t, err)
+	require.Equal(t, "2", u.Query().Get("list-type"))
+	prefix := u.Query().Get("prefix")
+	var sb strings.Builder
+	sb.WriteString("<ListBucketResult><IsTruncated>false</IsTruncated>")
+	for k, v := range objects {
+		key, err := url.PathUnescape(strings.TrimPrefix(k, "/artifacts/"))
+		require.NoError(t, err)
+		if strings.HasPrefix(key, prefix) {
+			fmt.Fprintf(&sb, "<Contents><Key>%s</Key><Size>%d</Size><ETag>&quot;etag&quot;</ETag><LastModified>2024-01-01T10:00:00.000Z</LastModified></Contents>", key, len(v))
+		}
+	}
+	sb.WriteString("</ListBucketResult>")
+	return sb.String()
+}
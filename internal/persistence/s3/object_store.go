@@ -0,0 +1,90 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/dagu-dev/dagu/internal/aws"
+	"github.com/dagu-dev/dagu/internal/persistence"
+)
+
+var (
+	errArchiveBucketRequired = errors.New("the bucket of the archive is not configured")
+	errLogBucketRequired     = errors.New("the bucket of the logs is not configured")
+)
+
+// objectStoreImpl keeps the files of the runs in the bucket as
+// <prefix>/<DAG name>/<request ID>/<file>.
+type objectStoreImpl struct {
+	// objects builds the keys and the paths of the objects like the
+	// artifact store.
+	objects           *artifactStoreImpl
+	errBucketRequired error
+	// send sends the requests to S3. It is replaced in tests.
+	send func(ctx context.Context, method, service, path string, body io.Reader, size int64) (*http.Response, error)
+}
+
+// NewArchiveStore returns a store that keeps the files of the archived runs
+// in the bucket as <prefix>/<DAG name>/<request ID>/<file>.
+func NewArchiveStore(bucket, prefix string) persistence.ArchiveStore {
+	return &objectStoreImpl{
+		objects:           &artifactStoreImpl{bucket: bucket, prefix: strings.Trim(prefix, "/")},
+		errBucketRequired: errArchiveBucketRequired,
+		send:              aws.Send,
+	}
+}
+
+// NewLogStore returns a store that keeps the step logs of the runs in the
+// bucket like NewArchiveStore. The bucket is in Google Cloud Storage if gcs
+// is true.
+func NewLogStore(bucket, prefix string, gcs bool) persistence.LogStore {
+	send := aws.Send
+	if gcs {
+		send = aws.SendGCS
+	}
+	return &objectStoreImpl{
+		objects:           &artifactStoreImpl{bucket: bucket, prefix: strings.Trim(prefix, "/")},
+		errBucketRequired: errLogBucketRequired,
+		send:              send,
+	}
+}
+
+func (s *objectStoreImpl) Location(name, requestId string) string {
+	return fmt.Sprintf("s3://%s/%s", s.objects.bucket, s.objects.key(name, requestId, ""))
+}
+
+func (s *objectStoreImpl) Put(ctx context.Context, name, requestId, file string, r io.Reader, size int64) error {
+	if s.objects.bucket == "" {
+		return s.errBucketRequired
+	}
+	resp, err := s.send(ctx, http.MethodPut, service, s.objects.objectPath(s.objects.key(name, requestId, file)), r, size)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("bucket %s is not found", s.objects.bucket)
+	}
+	return nil
+}
+
+func (s *objectStoreImpl) Open(ctx context.Context, name, requestId, file string) (io.ReadCloser, error) {
+	if s.objects.bucket == "" {
+		return nil, s.errBucketRequired
+	}
+	key := s.objects.key(name, requestId, file)
+	resp, err := s.send(ctx, http.MethodGet, service, s.objects.objectPath(key), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%w: s3://%s/%s", fs.ErrNotExist, s.objects.bucket, key)
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,54 @@
+package s3
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectStore(t *testing.T) {
+	objects := map[string]string{}
+	as := NewArchiveStore("archive", "/dagu/").(*objectStoreImpl)
+	as.send = func(_ context.Context, method, svc, p string, body io.Reader, _ int64) (*http.Response, error) {
+		require.Equal(t, "s3", svc)
+		resp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}
+		switch method {
+		case http.MethodPut:
+			b, err := io.ReadAll(body)
+			require.NoError(t, err)
+			objects[p] = string(b)
+		case http.MethodGet:
+			v, ok := objects[p]
+			if !ok {
+				resp.StatusCode = http.StatusNotFound
+			}
+			resp.Body = io.NopCloser(strings.NewReader(v))
+		}
+		return resp, nil
+	}
+
+	ctx := context.Background()
+	require.Equal(t, "s3://archive/dagu/etl/request-1", as.Location("etl", "request-1"))
+	require.NoError(t, as.Put(ctx, "etl", "request-1", "step 1.log.gz", strings.NewReader("log"), 3))
+	require.Equal(t, map[string]string{"/archive/dagu/etl/request-1/step%201.log.gz": "log"}, objects)
+
+	r, err := as.Open(ctx, "etl", "request-1", "step 1.log.gz")
+	require.NoError(t, err)
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, "log", string(b))
+
+	_, err = as.Open(ctx, "etl", "request-1", "step 1.log")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = NewArchiveStore("", "").Open(ctx, "etl", "request-1", "step 1.log")
+	require.ErrorIs(t, err, errArchiveBucketRequired)
+	_, err = NewLogStore("", "", false).Open(ctx, "etl", "request-1", "step 1.log")
+	require.ErrorIs(t, err, errLogBucketRequired)
+}
@@ -0,0 +1,133 @@
+package sqldb
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	// The drivers of the dialects. The SQLite driver does not need cgo.
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+)
+
+// dialect is what differs between the databases.
+type dialect struct {
+	driver string
+	schema string
+	// numbered is true if the placeholders are $1, $2, ... instead of ?.
+	numbered bool
+}
+
+// busyTimeout is how long the writers of a SQLite database wait for each
+// other.
+const busyTimeout = time.Second * 10
+
+var sqliteDialect = dialect{
+	driver: "sqlite",
+	schema: `
+CREATE TABLE IF NOT EXISTS history (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	dag        TEXT NOT NULL,
+	request_id TEXT NOT NULL,
+	started_at INTEGER NOT NULL,
+	updated_at INTEGER NOT NULL,
+	status     TEXT NOT NULL,
+	UNIQUE (dag, request_id)
+);
+CREATE INDEX IF NOT EXISTS history_dag_started_at ON history (dag, started_at);
+`,
+}
+
+var postgresDialect = dialect{
+	driver: "postgres",
+	schema: `
+CREATE TABLE IF NOT EXISTS history (
+	id         BIGSERIAL PRIMARY KEY,
+	dag        TEXT NOT NULL,
+	request_id TEXT NOT NULL,
+	started_at BIGINT NOT NULL,
+	updated_at BIGINT NOT NULL,
+	status     TEXT NOT NULL,
+	UNIQUE (dag, request_id)
+);
+CREATE INDEX IF NOT EXISTS history_dag_started_at ON history (dag, started_at);
+`,
+	numbered: true,
+}
+
+// NewSQLite returns the store of the SQLite database file. The database is
+// created when it is used for the first time. It is shared by the agents
+// and the server, so it is opened in the WAL mode. dagsDir is used to find
+// the history of the DAGs renamed by name like the file store.
+func NewSQLite(file, dagsDir string) *Store {
+	return &Store{
+		dialect: sqliteDialect,
+		dsn:     sqliteDSN(file),
+		name:    file,
+		dagsDir: dagsDir,
+		setup: func() error {
+			return os.MkdirAll(filepath.Dir(file), 0755)
+		},
+	}
+}
+
+// NewShardedSQLite returns the store of the SQLite databases of the DAGs in
+// the directory, a database per DAG, so that the runs of a DAG with a large
+// history, e.g. while they are pruned, do not block the writes of the runs
+// of the others.
+func NewShardedSQLite(dir, dagsDir string) *Store {
+	return &Store{
+		dialect:  sqliteDialect,
+		name:     dir,
+		dagsDir:  dagsDir,
+		shardDir: dir,
+	}
+}
+
+func sqliteDSN(file string) string {
+	return fmt.Sprintf("file:%s?_pragma=busy_timeout(%d)&_pragma=journal_mode(WAL)", file, busyTimeout.Milliseconds())
+}
+
+// NewPostgres returns the store of the PostgreSQL database of the URL, e.g.
+// postgres://dagu:secret@db:5432/dagu. The table is created when the
+// database is used for the first time.
+func NewPostgres(dsn, dagsDir string) *Store {
+	return &Store{
+		dialect: postgresDialect,
+		dsn:     dsn,
+		name:    redact(dsn),
+		dagsDir: dagsDir,
+	}
+}
+
+// rebind replaces the ? placeholders of the statement with the ones of the
+// dialect.
+func (d dialect) rebind(stmt string) string {
+	if !d.numbered {
+		return stmt
+	}
+	var b strings.Builder
+	n := 0
+	for _, c := range stmt {
+		if c == '?' {
+			n++
+			fmt.Fprintf(&b, "$%d", n)
+			continue
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// redact removes the password from the URL of the database, so that it is
+// not logged.
+func redact(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err != nil || u.User == nil {
+		return dsn
+	}
+	return u.Redacted()
+}
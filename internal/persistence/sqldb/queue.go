@@ -0,0 +1,188 @@
+package sqldb
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+)
+
+const queueSchema = `
+CREATE TABLE IF NOT EXISTS triggers (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	request_id  TEXT NOT NULL UNIQUE,
+	trigger     TEXT NOT NULL,
+	received_at INTEGER NOT NULL,
+	claimed_at  INTEGER NOT NULL DEFAULT 0,
+	attempts    INTEGER NOT NULL DEFAULT 0,
+	error       TEXT NOT NULL DEFAULT ''
+);
+`
+
+// Queue is the trigger queue in a SQLite database, one row per trigger. It
+// is shared by the server, which adds the triggers, and the schedulers,
+// which claim them with a single statement each, so that two schedulers do
+// not claim the same trigger.
+type Queue struct {
+	file string
+
+	once sync.Once
+	db   *sql.DB
+	err  error
+}
+
+var _ persistence.TriggerQueue = (*Queue)(nil)
+
+// NewQueue returns the trigger queue of the SQLite database file. The
+// database is created when it is used for the first time.
+func NewQueue(file string) *Queue {
+	return &Queue{file: file}
+}
+
+func (q *Queue) open() (*sql.DB, error) {
+	q.once.Do(func() {
+		if err := os.MkdirAll(filepath.Dir(q.file), 0755); err != nil {
+			q.err = err
+			return
+		}
+		db, err := sql.Open(sqliteDialect.driver, sqliteDSN(q.file))
+		if err != nil {
+			q.err = err
+			return
+		}
+		if _, err := db.Exec(queueSchema); err != nil {
+			_ = db.Close()
+			q.err = fmt.Errorf("failed to create the trigger table in %s: %w", q.file, err)
+			return
+		}
+		q.db = db
+	})
+	return q.db, q.err
+}
+
+func (q *Queue) close() {
+	if q.db != nil {
+		_ = q.db.Close()
+	}
+}
+
+// Enqueue adds the trigger to the queue. The triggers of the same request
+// ID are added once.
+func (q *Queue) Enqueue(t *model.Trigger) error {
+	db, err := q.open()
+	if err != nil {
+		return err
+	}
+	if t.ReceivedAt.IsZero() {
+		t.ReceivedAt = time.Now()
+	}
+	b, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	res, err := db.Exec(
+		`INSERT INTO triggers (request_id, trigger, received_at) VALUES (?, ?, ?)`,
+		t.RequestId, string(b), t.ReceivedAt.UnixMilli(),
+	)
+	if err != nil {
+		return err
+	}
+	t.ID, err = res.LastInsertId()
+	return err
+}
+
+// Claim claims the oldest triggers that are not claimed or whose claims
+// expired, e.g. because the scheduler that claimed them stopped before it
+// started their runs.
+func (q *Queue) Claim(n int, lease time.Duration) ([]*model.Trigger, error) {
+	db, err := q.open()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	rows, err := db.Query(`
+UPDATE triggers SET claimed_at = ?, attempts = attempts + 1
+WHERE id IN (SELECT id FROM triggers WHERE claimed_at <= ? ORDER BY id LIMIT ?)
+RETURNING id, trigger, attempts, error`,
+		now.UnixMilli(), now.Add(-lease).UnixMilli(), n,
+	)
+	if err != nil {
+		return nil, err
+	}
+	triggers, err := scanTriggers(rows)
+	if err != nil {
+		return nil, err
+	}
+	// The rows are returned in no particular order.
+	sort.Slice(triggers, func(i, j int) bool {
+		return triggers[i].ID < triggers[j].ID
+	})
+	return triggers, nil
+}
+
+// Done removes the trigger.
+func (q *Queue) Done(id int64) error {
+	db, err := q.open()
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`DELETE FROM triggers WHERE id = ?`, id)
+	return err
+}
+
+// Release releases the claim of the trigger and records the error.
+func (q *Queue) Release(id int64, reason error) error {
+	db, err := q.open()
+	if err != nil {
+		return err
+	}
+	var msg string
+	if reason != nil {
+		msg = reason.Error()
+	}
+	_, err = db.Exec(`UPDATE triggers SET claimed_at = 0, error = ? WHERE id = ?`, msg, id)
+	return err
+}
+
+// List returns the triggers in the queue, the claimed ones included.
+func (q *Queue) List() ([]*model.Trigger, error) {
+	db, err := q.open()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(`SELECT id, trigger, attempts, error FROM triggers ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	return scanTriggers(rows)
+}
+
+func scanTriggers(rows *sql.Rows) ([]*model.Trigger, error) {
+	defer rows.Close()
+	triggers := []*model.Trigger{}
+	for rows.Next() {
+		var (
+			id       int64
+			b        string
+			attempts int
+			reason   string
+		)
+		if err := rows.Scan(&id, &b, &attempts, &reason); err != nil {
+			return nil, err
+		}
+		t := &model.Trigger{}
+		if err := json.Unmarshal([]byte(b), t); err != nil {
+			return nil, err
+		}
+		t.ID, t.Attempts, t.Error = id, attempts, reason
+		triggers = append(triggers, t)
+	}
+	return triggers, rows.Err()
+}
@@ -0,0 +1,66 @@
+package sqldb
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueue(t *testing.T) {
+	q := NewQueue(filepath.Join(t.TempDir(), "data", "triggers.db"))
+	t.Cleanup(q.close)
+
+	first := &model.Trigger{DAG: "etl", RequestId: "first", Params: "x=1", Initiator: &model.Initiator{User: "alice"}}
+	require.NoError(t, q.Enqueue(first))
+	require.NoError(t, q.Enqueue(&model.Trigger{DAG: "report", RequestId: "second"}))
+	// A request ID is queued once.
+	require.Error(t, q.Enqueue(&model.Trigger{DAG: "etl", RequestId: "first"}))
+
+	triggers, err := q.List()
+	require.NoError(t, err)
+	require.Len(t, triggers, 2)
+	require.Equal(t, first.ID, triggers[0].ID)
+	require.Equal(t, "x=1", triggers[0].Params)
+	require.Equal(t, "alice", triggers[0].Initiator.User)
+	require.False(t, triggers[0].ReceivedAt.IsZero())
+
+	// The claimed triggers are not claimed again until the lease expires
+	// or they are released.
+	claimed, err := q.Claim(1, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, "first", claimed[0].RequestId)
+	require.Equal(t, 1, claimed[0].Attempts)
+
+	claimed, err = q.Claim(10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, "second", claimed[0].RequestId)
+
+	claimed, err = q.Claim(10, time.Minute)
+	require.NoError(t, err)
+	require.Empty(t, claimed)
+
+	require.NoError(t, q.Release(first.ID, errors.New("the DAG is running")))
+	require.NoError(t, q.Done(triggers[1].ID))
+
+	claimed, err = q.Claim(10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, "first", claimed[0].RequestId)
+	require.Equal(t, 2, claimed[0].Attempts)
+	require.Equal(t, "the DAG is running", claimed[0].Error)
+
+	// The claim of a scheduler that stopped expires.
+	claimed, err = q.Claim(10, 0)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	triggers, err = q.List()
+	require.NoError(t, err)
+	require.Len(t, triggers, 1)
+}
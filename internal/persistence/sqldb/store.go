@@ -0,0 +1,418 @@
+package sqldb
+
+import (
+	"crypto/md5"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/utils"
+)
+
+// Store keeps the statuses of the runs in a SQL database, one row per run,
+// so that the history of the DAGs with many runs is read with a query
+// instead of a file per run. The database is SQLite or PostgreSQL.
+type Store struct {
+	dialect
+	dsn string
+	// name is the database in the logs and the File of the statuses.
+	name    string
+	dagsDir string
+	// setup prepares the database before it is opened, if not nil.
+	setup func() error
+
+	// shardDir is the directory of the SQLite databases of the DAGs if the
+	// history is sharded, a database per DAG, so that the writes and the
+	// pruning of the runs of a DAG do not wait for the lock of the others.
+	shardDir string
+
+	mu  sync.Mutex
+	dbs map[string]*database
+
+	// The run the Write calls write to, set by Open.
+	current *run
+}
+
+// database is a database of the store, the only one unless the history is
+// sharded.
+type database struct {
+	name string
+	once sync.Once
+	db   *sql.DB
+	err  error
+}
+
+type run struct {
+	dagFile   string
+	requestId string
+	startedAt time.Time
+}
+
+var (
+	errRequestIdNotFound = dagerrors.New(dagerrors.CodeNotFound, "requestId not found")
+	errDAGFileEmpty      = errors.New("dagFile is empty")
+	errStoreNotOpen      = errors.New("status store is not open")
+)
+
+var _ persistence.HistoryStore = (*Store)(nil)
+
+// open returns the database of the history of the DAG, and opens it the
+// first time.
+func (store *Store) open(dagFile string) (*database, error) {
+	name, dsn, setup := store.name, store.dsn, store.setup
+	if store.shardDir != "" {
+		name = store.shardFile(dagFile)
+		dsn = sqliteDSN(name)
+		setup = func() error {
+			return os.MkdirAll(store.shardDir, 0755)
+		}
+	}
+	store.mu.Lock()
+	d, ok := store.dbs[name]
+	if !ok {
+		if store.dbs == nil {
+			store.dbs = make(map[string]*database)
+		}
+		d = &database{name: name}
+		store.dbs[name] = d
+	}
+	store.mu.Unlock()
+
+	d.once.Do(func() {
+		if setup != nil {
+			if err := setup(); err != nil {
+				d.err = err
+				return
+			}
+		}
+		db, err := sql.Open(store.driver, dsn)
+		if err != nil {
+			d.err = err
+			return
+		}
+		if store.shardDir != "" {
+			// A shard is idle most of the time, and there may be many.
+			db.SetMaxIdleConns(1)
+		}
+		if _, err := db.Exec(store.schema); err != nil {
+			_ = db.Close()
+			d.err = fmt.Errorf("failed to create the history table in %s: %w", name, err)
+			return
+		}
+		d.db = db
+	})
+	return d, d.err
+}
+
+// shardFile returns the database of the DAG in the directory of the shards,
+// named like the directory of its status files in the file store.
+func (store *Store) shardFile(dagFile string) string {
+	h := md5.Sum([]byte(dagFile))
+	prefix := strings.TrimSuffix(filepath.Base(dagFile), filepath.Ext(dagFile))
+	return filepath.Join(store.shardDir, fmt.Sprintf("%s-%s.db", prefix, hex.EncodeToString(h[:])))
+}
+
+// hasHistory returns false if the history is sharded and the DAG has no
+// database yet, so that it is not created to be read.
+func (store *Store) hasHistory(dagFile string) bool {
+	if store.shardDir == "" {
+		return true
+	}
+	_, err := os.Stat(store.shardFile(dagFile))
+	return !os.IsNotExist(err)
+}
+
+// close closes the databases that were opened.
+func (store *Store) close() {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	for _, d := range store.dbs {
+		if d.db != nil {
+			_ = d.db.Close()
+		}
+	}
+	store.dbs = nil
+}
+
+// Name returns the database file, the directory of the databases if the
+// history is sharded, or the URL of the database without its password.
+func (store *Store) Name() string {
+	return store.name
+}
+
+// exec runs the statement with the placeholders of the dialect in the
+// database of the history of the DAG.
+func (store *Store) exec(dagFile, stmt string, args ...any) (sql.Result, error) {
+	d, err := store.open(dagFile)
+	if err != nil {
+		return nil, err
+	}
+	return d.db.Exec(store.rebind(stmt), args...)
+}
+
+// Open starts the run the statuses written with Write belong to.
+func (store *Store) Open(dagFile string, t time.Time, requestId string) error {
+	if dagFile == "" {
+		return errDAGFileEmpty
+	}
+	if _, err := store.open(dagFile); err != nil {
+		return err
+	}
+	store.current = &run{dagFile: dagFile, requestId: requestId, startedAt: t}
+	return nil
+}
+
+// Write replaces the status of the current run.
+func (store *Store) Write(st *model.Status) error {
+	r := store.current
+	if r == nil {
+		return errStoreNotOpen
+	}
+	return store.put(r.dagFile, r.requestId, r.startedAt, st)
+}
+
+// Close ends the current run.
+func (store *Store) Close() error {
+	store.current = nil
+	return nil
+}
+
+// Import writes the status of a run that started at the time, e.g. a run of
+// the file store. The status of a run that is already in the database is
+// replaced.
+func (store *Store) Import(dagFile string, startedAt time.Time, st *model.Status) error {
+	return store.put(dagFile, st.RequestId, startedAt, st)
+}
+
+func (store *Store) put(dagFile, requestId string, startedAt time.Time, st *model.Status) error {
+	data, err := st.ToJson()
+	if err != nil {
+		return err
+	}
+	_, err = store.exec(dagFile, `INSERT INTO history (dag, request_id, started_at, updated_at, status)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (dag, request_id) DO UPDATE SET updated_at = excluded.updated_at, status = excluded.status`,
+		dagFile, requestId, startedAt.UnixNano(), time.Now().UnixNano(), string(data))
+	return err
+}
+
+// Update replaces the status of the run of the request ID.
+func (store *Store) Update(dagFile, requestId string, st *model.Status) error {
+	if !store.hasHistory(dagFile) {
+		return fmt.Errorf("%w : %s", persistence.ErrRequestIdNotFound, requestId)
+	}
+	data, err := st.ToJson()
+	if err != nil {
+		return err
+	}
+	res, err := store.exec(dagFile, `UPDATE history SET updated_at = ?, status = ? WHERE dag = ? AND request_id = ?`,
+		time.Now().UnixNano(), string(data), dagFile, requestId)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w : %s", persistence.ErrRequestIdNotFound, requestId)
+	}
+	return nil
+}
+
+// ReadStatusRecent returns the statuses of the last n runs, the latest
+// first.
+func (store *Store) ReadStatusRecent(dagFile string, n int) []*model.StatusFile {
+	ret, err := store.query(dagFile, `WHERE dag = ? ORDER BY started_at DESC, id DESC LIMIT ?`, dagFile, n)
+	if err != nil {
+		log.Printf("failed to read the history of %s: %v", dagFile, err)
+	}
+	return ret
+}
+
+// ReadStatusAll returns the statuses of all the runs, the latest first.
+func (store *Store) ReadStatusAll(dagFile string) []*model.StatusFile {
+	ret, err := store.query(dagFile, `WHERE dag = ? ORDER BY started_at DESC, id DESC`, dagFile)
+	if err != nil {
+		log.Printf("failed to read the history of %s: %v", dagFile, err)
+	}
+	return ret
+}
+
+// ReadStatusToday returns the status of the latest run. Only the runs
+// started today are read if LatestStatusToday is set.
+func (store *Store) ReadStatusToday(dagFile string) (*model.Status, error) {
+	// The file store reads the configuration here too.
+	var since time.Time
+	if config.Get().LatestStatusToday {
+		y, m, d := utils.Now().Date()
+		since = time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	}
+	ret, err := store.query(dagFile, `WHERE dag = ? AND started_at >= ? ORDER BY started_at DESC, id DESC LIMIT 1`,
+		dagFile, since.UnixNano())
+	if err != nil {
+		return nil, err
+	}
+	if len(ret) == 0 {
+		return nil, persistence.ErrNoStatusDataToday
+	}
+	return ret[0].Status, nil
+}
+
+// FindByRequestId returns the status of the run of the request ID.
+func (store *Store) FindByRequestId(dagFile string, requestId string) (*model.StatusFile, error) {
+	if requestId == "" {
+		return nil, errRequestIdNotFound
+	}
+	ret, err := store.query(dagFile, `WHERE dag = ? AND request_id = ?`, dagFile, requestId)
+	if err != nil {
+		return nil, err
+	}
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("%w : %s", persistence.ErrRequestIdNotFound, requestId)
+	}
+	return ret[0], nil
+}
+
+// query returns the statuses of the rows selected by the clause in the
+// database of the history of the DAG.
+func (store *Store) query(dagFile, clause string, args ...any) ([]*model.StatusFile, error) {
+	if !store.hasHistory(dagFile) {
+		return nil, nil
+	}
+	d, err := store.open(dagFile)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := d.db.Query(store.rebind(`SELECT status FROM history `+clause), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	var ret []*model.StatusFile
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		status, err := model.StatusFromJson(data)
+		if err != nil {
+			log.Printf("failed to parse a status of %s: %v", d.name, err)
+			continue
+		}
+		if status.NewerSchema() {
+			log.Printf("a status of %s was written by a newer version of dagu (schema version %d)", d.name, status.SchemaVersion)
+		}
+		ret = append(ret, &model.StatusFile{File: d.name, Status: status})
+	}
+	return ret, rows.Err()
+}
+
+// RemoveAll removes the history of the DAG.
+func (store *Store) RemoveAll(dagFile string) error {
+	return store.RemoveOld(dagFile, 0)
+}
+
+// RemoveOld removes the runs that were last updated more than retentionDays
+// ago.
+func (store *Store) RemoveOld(dagFile string, retentionDays int) error {
+	if retentionDays < 0 || !store.hasHistory(dagFile) {
+		return nil
+	}
+	ot := time.Now().AddDate(0, 0, -1*retentionDays)
+	_, err := store.exec(dagFile, `DELETE FROM history WHERE dag = ? AND updated_at < ?`, dagFile, ot.UnixNano())
+	return err
+}
+
+// RemoveRun removes the run of the request ID.
+func (store *Store) RemoveRun(dagFile, requestId string) error {
+	if !store.hasHistory(dagFile) {
+		return fmt.Errorf("%w : %s", persistence.ErrRequestIdNotFound, requestId)
+	}
+	res, err := store.exec(dagFile, `DELETE FROM history WHERE dag = ? AND request_id = ?`, dagFile, requestId)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w : %s", persistence.ErrRequestIdNotFound, requestId)
+	}
+	return nil
+}
+
+// Rename moves the history of the DAG to its new name. The runs are copied
+// to the database of the new name if the history is sharded.
+func (store *Store) Rename(oldName, newName string) error {
+	on, nn := store.normalizeInternalName(oldName), store.normalizeInternalName(newName)
+	if store.shardDir == "" {
+		_, err := store.exec(on, `UPDATE history SET dag = ? WHERE dag = ?`, nn, on)
+		return err
+	}
+	if !store.hasHistory(on) {
+		return nil
+	}
+	from, err := store.open(on)
+	if err != nil {
+		return err
+	}
+	rows, err := from.db.Query(store.rebind(`SELECT request_id, started_at, updated_at, status FROM history WHERE dag = ?`), on)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		var requestId, status string
+		var startedAt, updatedAt int64
+		if err := rows.Scan(&requestId, &startedAt, &updatedAt, &status); err != nil {
+			return err
+		}
+		if _, err := store.exec(nn, `INSERT INTO history (dag, request_id, started_at, updated_at, status)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (dag, request_id) DO UPDATE SET updated_at = excluded.updated_at, status = excluded.status`,
+			nn, requestId, startedAt, updatedAt, status); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	_, err = store.exec(on, `DELETE FROM history WHERE dag = ?`, on)
+	return err
+}
+
+// Check deletes no row, which takes the write lock of the database, to check
+// that the history can be written. The directory of the shards is checked
+// like the one of the file store if the history is sharded.
+func (store *Store) Check() error {
+	if store.shardDir != "" {
+		if err := os.MkdirAll(store.shardDir, 0755); err != nil {
+			return err
+		}
+		f, err := os.CreateTemp(store.shardDir, ".check-*")
+		if err != nil {
+			return err
+		}
+		_ = f.Close()
+		return os.Remove(f.Name())
+	}
+	_, err := store.exec("", `DELETE FROM history WHERE 1 = 0`)
+	return err
+}
+
+func (store *Store) normalizeInternalName(name string) string {
+	a := strings.TrimSuffix(name, ".yaml")
+	a = strings.TrimSuffix(a, ".yml")
+	a = path.Join(store.dagsDir, a)
+	return fmt.Sprintf("%s.yaml", a)
+}
@@ -0,0 +1,162 @@
+package sqldb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T) *Store {
+	t.Helper()
+	dir := t.TempDir()
+	store := NewSQLite(filepath.Join(dir, "history.db"), dir)
+	t.Cleanup(store.close)
+	return store
+}
+
+func writeStatus(t *testing.T, store *Store, d *dag.DAG, requestId string, ts time.Time, st scheduler.Status) {
+	t.Helper()
+	status := model.NewStatus(d, nil, st, 10000, nil, nil)
+	status.RequestId = requestId
+	require.NoError(t, store.Open(d.Location, ts, requestId))
+	require.NoError(t, store.Write(status))
+	require.NoError(t, store.Close())
+}
+
+func TestWriteAndRead(t *testing.T) {
+	testWriteAndRead(t, setupTest(t))
+}
+
+// TestPostgres runs the tests of the store with the PostgreSQL database of
+// DAGU_TEST_POSTGRES_URL. Its history table is emptied.
+func TestPostgres(t *testing.T) {
+	dsn := os.Getenv("DAGU_TEST_POSTGRES_URL")
+	if dsn == "" {
+		t.Skip("DAGU_TEST_POSTGRES_URL is not set")
+	}
+	store := NewPostgres(dsn, t.TempDir())
+	defer store.close()
+	_, err := store.exec("", `DELETE FROM history`)
+	require.NoError(t, err)
+	testWriteAndRead(t, store)
+}
+
+func TestRebind(t *testing.T) {
+	stmt := `UPDATE history SET status = ? WHERE dag = ? AND request_id = ?`
+	require.Equal(t, stmt, sqliteDialect.rebind(stmt))
+	require.Equal(t, `UPDATE history SET status = $1 WHERE dag = $2 AND request_id = $3`, postgresDialect.rebind(stmt))
+	require.Equal(t, "postgres://dagu:xxxxx@db:5432/dagu", redact("postgres://dagu:secret@db:5432/dagu"))
+}
+
+func testWriteAndRead(t *testing.T, store *Store) {
+	t.Helper()
+	d := &dag.DAG{Name: "test_write_and_read", Location: "test_write_and_read.yaml"}
+
+	writeStatus(t, store, d, "request-id-1", time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local), scheduler.StatusSuccess)
+	writeStatus(t, store, d, "request-id-3", time.Date(2022, 1, 3, 0, 0, 0, 0, time.Local), scheduler.StatusError)
+	writeStatus(t, store, d, "request-id-2", time.Date(2022, 1, 2, 0, 0, 0, 0, time.Local), scheduler.StatusSuccess)
+
+	ret := store.ReadStatusRecent(d.Location, 2)
+	require.Len(t, ret, 2)
+	require.Equal(t, "request-id-3", ret[0].Status.RequestId)
+	require.Equal(t, "request-id-2", ret[1].Status.RequestId)
+
+	latest, err := store.ReadStatusToday(d.Location)
+	require.NoError(t, err)
+	require.Equal(t, "request-id-3", latest.RequestId)
+
+	found, err := store.FindByRequestId(d.Location, "request-id-1")
+	require.NoError(t, err)
+	require.Equal(t, scheduler.StatusSuccess, found.Status.Status)
+
+	_, err = store.FindByRequestId(d.Location, "request-id-4")
+	require.ErrorIs(t, err, persistence.ErrRequestIdNotFound)
+
+	// The status of a run is replaced by the next writes.
+	found.Status.Status = scheduler.StatusCancel
+	require.NoError(t, store.Update(d.Location, "request-id-1", found.Status))
+	found, err = store.FindByRequestId(d.Location, "request-id-1")
+	require.NoError(t, err)
+	require.Equal(t, scheduler.StatusCancel, found.Status.Status)
+
+	err = store.Update(d.Location, "request-id-4", found.Status)
+	require.ErrorIs(t, err, persistence.ErrRequestIdNotFound)
+
+	_, err = store.ReadStatusToday("other.yaml")
+	require.ErrorIs(t, err, persistence.ErrNoStatusDataToday)
+}
+
+func TestWriteWithoutOpen(t *testing.T) {
+	store := setupTest(t)
+	d := &dag.DAG{Name: "test_write_without_open", Location: "test_write_without_open.yaml"}
+	require.ErrorIs(t, store.Write(model.NewStatusDefault(d)), errStoreNotOpen)
+	require.ErrorIs(t, store.Open("", time.Now(), "request-id-1"), errDAGFileEmpty)
+}
+
+func TestRemoveAndRename(t *testing.T) {
+	store := setupTest(t)
+	d := &dag.DAG{Name: "old", Location: filepath.Join(store.dagsDir, "old.yaml")}
+	writeStatus(t, store, d, "request-id-1", time.Now(), scheduler.StatusSuccess)
+	writeStatus(t, store, d, "request-id-2", time.Now(), scheduler.StatusSuccess)
+
+	require.NoError(t, store.RemoveOld(d.Location, 1))
+	require.Len(t, store.ReadStatusRecent(d.Location, 10), 2)
+
+	require.NoError(t, store.Rename("old", "new"))
+	require.Empty(t, store.ReadStatusRecent(d.Location, 10))
+	newLocation := filepath.Join(store.dagsDir, "new.yaml")
+	require.Len(t, store.ReadStatusRecent(newLocation, 10), 2)
+
+	require.NoError(t, store.RemoveAll(newLocation))
+	require.Empty(t, store.ReadStatusRecent(newLocation, 10))
+}
+
+func TestSharded(t *testing.T) {
+	dir := t.TempDir()
+	store := NewShardedSQLite(filepath.Join(dir, "history"), dir)
+	t.Cleanup(store.close)
+	require.NoError(t, store.Check())
+	testWriteAndRead(t, store)
+
+	// Each DAG has its own database, which is created by its first run.
+	d := &dag.DAG{Name: "old", Location: filepath.Join(dir, "old.yaml")}
+	require.Empty(t, store.ReadStatusRecent(d.Location, 10))
+	require.NoFileExists(t, store.shardFile(d.Location))
+	writeStatus(t, store, d, "request-id-1", time.Now(), scheduler.StatusSuccess)
+	writeStatus(t, store, d, "request-id-2", time.Now(), scheduler.StatusSuccess)
+	require.FileExists(t, store.shardFile(d.Location))
+	require.NotEqual(t, store.shardFile("test_write_and_read.yaml"), store.shardFile(d.Location))
+	ret := store.ReadStatusRecent(d.Location, 10)
+	require.Len(t, ret, 2)
+	require.Equal(t, store.shardFile(d.Location), ret[0].File)
+
+	// The runs are copied to the database of the new name.
+	require.NoError(t, store.Rename("old", "new"))
+	require.Empty(t, store.ReadStatusRecent(d.Location, 10))
+	newLocation := filepath.Join(dir, "new.yaml")
+	require.Len(t, store.ReadStatusRecent(newLocation, 10), 2)
+
+	require.NoError(t, store.RemoveRun(newLocation, "request-id-1"))
+	require.Len(t, store.ReadStatusRecent(newLocation, 10), 1)
+	require.ErrorIs(t, store.RemoveRun("other.yaml", "request-id-1"), persistence.ErrRequestIdNotFound)
+}
+
+func TestImport(t *testing.T) {
+	store := setupTest(t)
+	d := &dag.DAG{Name: "test_import", Location: "test_import.yaml"}
+	status := model.NewStatus(d, nil, scheduler.StatusSuccess, 10000, nil, nil)
+	status.RequestId = "request-id-1"
+
+	// A run imported twice is not duplicated.
+	ts := time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local)
+	require.NoError(t, store.Import(d.Location, ts, status))
+	require.NoError(t, store.Import(d.Location, ts, status))
+	require.Len(t, store.ReadStatusRecent(d.Location, 10), 1)
+}
@@ -0,0 +1,320 @@
+package pool
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/utils"
+	"golang.org/x/sys/unix"
+)
+
+var (
+	errUnknownPool     = errors.New("unknown concurrency pool")
+	errInvalidPoolSize = errors.New("size of a concurrency pool must be positive")
+	errEmptyKey        = errors.New("concurrency key is empty")
+)
+
+const (
+	defaultPollInterval = time.Second
+	queueDir            = "queue"
+	keysDir             = ".keys"
+)
+
+// Pools limits the number of executions in each concurrency pool across all
+// the runs of all the DAGs. A pool of size N has N slot files in its
+// directory, and an execution holds a lock on one of them while it runs.
+// The locks are released by the system when a process exits, so a run that
+// crashed does not keep its slot.
+//
+// The executions waiting for a slot are queued with a ticket file in the
+// queue directory of the pool, and get the slots in the order of their
+// lanes, of their priorities, and then of the times they were queued at.
+type Pools struct {
+	dir          string
+	sizes        map[string]int
+	pollInterval time.Duration
+}
+
+// New returns the pools with the sizes by name. The names are not case
+// sensitive.
+func New(dir string, sizes map[string]int) *Pools {
+	p := &Pools{dir: dir, sizes: make(map[string]int), pollInterval: defaultPollInterval}
+	for name, size := range sizes {
+		p.sizes[strings.ToLower(name)] = size
+	}
+	return p
+}
+
+// The lanes of the runs in the queues.
+const (
+	LaneScheduled = 0
+	LaneManual    = 1
+)
+
+// Entry is an execution in the queues of the pools.
+type Entry struct {
+	// Lane is the lane of the execution. The executions of a higher lane
+	// take the free slots before the ones of the lower lanes, whatever
+	// their priorities, e.g. the manual runs before the scheduled ones.
+	Lane int
+	// Priority is the priority of the execution in its lane. The
+	// executions with a higher priority take the free slots first.
+	Priority int
+	// RequestId is the request ID of the run of the execution.
+	RequestId string
+	// Overtook is called, if it is not nil, with the request IDs of the
+	// executions of the lower lanes that were queued before the execution
+	// when it takes a slot ahead of them.
+	Overtook func(requestIds []string)
+}
+
+// Acquire waits until a slot of the pool is free and takes it. The slot is
+// held until release is called. The executions of a higher lane, and then
+// with a higher priority, take the free slots first. If queued is not nil,
+// it is called with the position of the execution in the queue when it
+// changes, starting at 1.
+func (p *Pools) Acquire(ctx context.Context, name string, e Entry, queued func(position int)) (release func(), err error) {
+	size, ok := p.sizes[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnknownPool, name)
+	}
+	if size <= 0 {
+		return nil, fmt.Errorf("%w: %s: %d", errInvalidPoolSize, name, size)
+	}
+	return p.acquire(ctx, filepath.Join(p.dir, utils.ValidFilename(strings.ToLower(name), "_")), size, e, queued)
+}
+
+// AcquireKey waits until no other execution holds the concurrency key and
+// takes it until release is called, like the slot of a pool of size 1. The
+// keys are not configured, and are case sensitive.
+func (p *Pools) AcquireKey(ctx context.Context, key string, e Entry, queued func(position int)) (release func(), err error) {
+	if key == "" {
+		return nil, errEmptyKey
+	}
+	// The hash keeps the keys apart that have the same valid file name.
+	sum := sha256.Sum256([]byte(key))
+	name := fmt.Sprintf("%s-%x", utils.ValidFilename(key, "_"), sum[:4])
+	return p.acquire(ctx, filepath.Join(p.dir, keysDir, name), 1, e, queued)
+}
+
+func (p *Pools) acquire(ctx context.Context, dir string, size int, e Entry, queued func(position int)) (func(), error) {
+	t, err := enqueue(filepath.Join(dir, queueDir), e)
+	if err != nil {
+		return nil, err
+	}
+	defer t.remove()
+
+	last := 0
+	for {
+		pos, overtaken, err := t.position()
+		if err != nil {
+			return nil, err
+		}
+		if pos != last && queued != nil {
+			queued(pos)
+		}
+		last = pos
+		release, err := takeSlot(dir, size, pos)
+		if err != nil {
+			return nil, err
+		}
+		if release != nil {
+			if len(overtaken) > 0 && e.Overtook != nil {
+				e.Overtook(overtaken)
+			}
+			return release, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(p.pollInterval):
+		}
+	}
+}
+
+// takeSlot takes a free slot of the pool for the execution at the position
+// in the queue, only if there are more free slots than the executions ahead
+// of it, so that they take the slots first. It returns nil otherwise.
+func takeSlot(dir string, size, pos int) (func(), error) {
+	var free []func()
+	for i := 0; i < size; i++ {
+		release, err := tryLock(filepath.Join(dir, fmt.Sprintf("%d.lock", i)))
+		if err != nil {
+			for _, r := range free {
+				r()
+			}
+			return nil, err
+		}
+		if release != nil {
+			free = append(free, release)
+		}
+	}
+	if pos-1 < len(free) {
+		for _, r := range free[1:] {
+			r()
+		}
+		return free[0], nil
+	}
+	for _, r := range free {
+		r()
+	}
+	return nil, nil
+}
+
+// ticket is the entry of an execution in the queue of a pool. Its file is
+// locked while the execution waits.
+type ticket struct {
+	dir  string
+	file *os.File
+	ticketData
+}
+
+type ticketData struct {
+	Lane      int       `json:"Lane,omitempty"`
+	Priority  int       `json:"Priority"`
+	RequestId string    `json:"RequestId,omitempty"`
+	QueuedAt  time.Time `json:"QueuedAt"`
+}
+
+// enqueue writes and locks the ticket before it is moved to the queue, so
+// that the other executions do not take it for the ticket of a process that
+// exited.
+func enqueue(dir string, e Entry) (*ticket, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.CreateTemp(filepath.Dir(dir), ".ticket-")
+	if err != nil {
+		return nil, err
+	}
+	t := &ticket{dir: dir, file: f, ticketData: ticketData{
+		Lane:      e.Lane,
+		Priority:  e.Priority,
+		RequestId: e.RequestId,
+		QueuedAt:  time.Now(),
+	}}
+	if err := unix.Flock(int(f.Fd()), unix.LOCK_EX); err != nil {
+		t.close()
+		return nil, err
+	}
+	data, err := json.Marshal(t.ticketData)
+	if err == nil {
+		_, err = f.Write(data)
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), filepath.Join(dir, filepath.Base(f.Name())))
+	}
+	if err != nil {
+		t.close()
+		_ = os.Remove(f.Name())
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *ticket) name() string {
+	return filepath.Base(t.file.Name())
+}
+
+// position returns 1 plus the number of the tickets ahead of the ticket,
+// and the request IDs of the tickets of the lower lanes that were queued
+// before it. The tickets of the processes that exited are removed.
+func (t *ticket) position() (int, []string, error) {
+	entries, err := os.ReadDir(t.dir)
+	if err != nil {
+		return 0, nil, err
+	}
+	var queue []ticketData
+	var names []string
+	for _, e := range entries {
+		if e.Name() == t.name() {
+			continue
+		}
+		file := filepath.Join(t.dir, e.Name())
+		if removeStale(file) {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var td ticketData
+		if err := json.Unmarshal(data, &td); err != nil {
+			continue
+		}
+		queue = append(queue, td)
+		names = append(names, e.Name())
+	}
+	pos := 1
+	var overtaken []string
+	for i, td := range queue {
+		if ahead(td, names[i], t.ticketData, t.name()) {
+			pos++
+		} else if td.Lane < t.Lane && td.QueuedAt.Before(t.QueuedAt) {
+			overtaken = append(overtaken, td.RequestId)
+		}
+	}
+	return pos, overtaken, nil
+}
+
+// ahead reports whether the ticket a is ahead of the ticket b.
+func ahead(a ticketData, aName string, b ticketData, bName string) bool {
+	if a.Lane != b.Lane {
+		return a.Lane > b.Lane
+	}
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	if !a.QueuedAt.Equal(b.QueuedAt) {
+		return a.QueuedAt.Before(b.QueuedAt)
+	}
+	return aName < bName
+}
+
+// removeStale removes the ticket if it is not locked by its process.
+func removeStale(file string) bool {
+	release, err := tryLock(file)
+	if err != nil || release == nil {
+		return false
+	}
+	_ = os.Remove(file)
+	release()
+	return true
+}
+
+func (t *ticket) remove() {
+	_ = os.Remove(filepath.Join(t.dir, t.name()))
+	t.close()
+}
+
+func (t *ticket) close() {
+	_ = unix.Flock(int(t.file.Fd()), unix.LOCK_UN)
+	_ = t.file.Close()
+}
+
+// tryLock locks the file. It returns nil if the file is locked by another
+// execution.
+func tryLock(file string) (func(), error) {
+	f, err := os.OpenFile(file, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := unix.Flock(int(f.Fd()), unix.LOCK_EX|unix.LOCK_NB); err != nil {
+		_ = f.Close()
+		if errors.Is(err, unix.EWOULDBLOCK) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return func() {
+		_ = unix.Flock(int(f.Fd()), unix.LOCK_UN)
+		_ = f.Close()
+	}, nil
+}
@@ -0,0 +1,178 @@
+package pool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquire(t *testing.T) {
+	p := New(t.TempDir(), map[string]int{"Database": 2})
+	p.pollInterval = time.Millisecond * 10
+	ctx := context.Background()
+
+	release1, err := p.Acquire(ctx, "database", Entry{}, nil)
+	require.NoError(t, err)
+	release2, err := p.Acquire(ctx, "DATABASE", Entry{}, nil)
+	require.NoError(t, err)
+
+	// The pool is full until a slot is released.
+	timeout, cancel := context.WithTimeout(ctx, time.Millisecond*50)
+	defer cancel()
+	_, err = p.Acquire(timeout, "database", Entry{}, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	acquired := make(chan func())
+	go func() {
+		release, err := p.Acquire(ctx, "database", Entry{}, nil)
+		require.NoError(t, err)
+		acquired <- release
+	}()
+	release1()
+	release3 := <-acquired
+	release2()
+	release3()
+}
+
+func TestAcquirePriority(t *testing.T) {
+	p := New(t.TempDir(), map[string]int{"gpu": 1})
+	p.pollInterval = time.Millisecond * 10
+	ctx := context.Background()
+
+	release, err := p.Acquire(ctx, "gpu", Entry{}, nil)
+	require.NoError(t, err)
+
+	order := make(chan string, 2)
+	low := make(chan int, 10)
+	high := make(chan int, 10)
+	wait := func(name string, priority int, positions chan int) {
+		release, err := p.Acquire(ctx, "gpu", Entry{Priority: priority}, func(pos int) { positions <- pos })
+		require.NoError(t, err)
+		order <- name
+		time.Sleep(time.Millisecond * 20)
+		release()
+	}
+	go wait("low", 0, low)
+	require.Equal(t, 1, <-low)
+	go wait("high", 10, high)
+	require.Equal(t, 1, <-high)
+	// The execution with the lower priority moves back in the queue.
+	require.Equal(t, 2, <-low)
+
+	release()
+	require.Equal(t, "high", <-order)
+	require.Equal(t, "low", <-order)
+}
+
+func TestAcquirePriorityFullPool(t *testing.T) {
+	p := New(t.TempDir(), map[string]int{"gpu": 2})
+	p.pollInterval = time.Millisecond * 10
+	ctx := context.Background()
+
+	release1, err := p.Acquire(ctx, "gpu", Entry{}, nil)
+	require.NoError(t, err)
+	release2, err := p.Acquire(ctx, "gpu", Entry{}, nil)
+	require.NoError(t, err)
+
+	order := make(chan string, 2)
+	releases := make(chan func(), 2)
+	low := make(chan int, 10)
+	high := make(chan int, 10)
+	wait := func(name string, priority int, positions chan int) {
+		release, err := p.Acquire(ctx, "gpu", Entry{Priority: priority}, func(pos int) { positions <- pos })
+		require.NoError(t, err)
+		order <- name
+		releases <- release
+	}
+	go wait("low", 0, low)
+	require.Equal(t, 1, <-low)
+	go wait("high", 10, high)
+	require.Equal(t, 1, <-high)
+	require.Equal(t, 2, <-low)
+
+	// The slot that is released is taken by the execution with the higher
+	// priority, though the position of the other one is within the size of
+	// the pool.
+	release1()
+	require.Equal(t, "high", <-order)
+	time.Sleep(time.Millisecond * 50)
+	require.Empty(t, order)
+
+	release2()
+	require.Equal(t, "low", <-order)
+	(<-releases)()
+	(<-releases)()
+}
+
+func TestAcquireLane(t *testing.T) {
+	p := New(t.TempDir(), map[string]int{"gpu": 1})
+	p.pollInterval = time.Millisecond * 10
+	ctx := context.Background()
+
+	release, err := p.Acquire(ctx, "gpu", Entry{}, nil)
+	require.NoError(t, err)
+
+	scheduled := make(chan int, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		release, err := p.Acquire(ctx, "gpu", Entry{Priority: 10, RequestId: "scheduled"}, func(pos int) { scheduled <- pos })
+		require.NoError(t, err)
+		release()
+	}()
+	require.Equal(t, 1, <-scheduled)
+
+	// The manual run takes the slot ahead of the scheduled run of a higher
+	// priority, and records it.
+	acquired := make(chan []string, 1)
+	go func() {
+		var overtaken []string
+		release, err := p.Acquire(ctx, "gpu", Entry{Lane: 1, RequestId: "manual", Overtook: func(ids []string) { overtaken = ids }}, nil)
+		require.NoError(t, err)
+		acquired <- overtaken
+		release()
+	}()
+	require.Equal(t, 2, <-scheduled)
+	release()
+	require.Equal(t, []string{"scheduled"}, <-acquired)
+	<-done
+}
+
+func TestAcquireKey(t *testing.T) {
+	p := New(t.TempDir(), nil)
+	p.pollInterval = time.Millisecond * 10
+	ctx := context.Background()
+
+	release1, err := p.AcquireKey(ctx, "etl/customer-1", Entry{}, nil)
+	require.NoError(t, err)
+	// The other keys are not held, even with the same valid file name.
+	release2, err := p.AcquireKey(ctx, "etl/customer-2", Entry{}, nil)
+	require.NoError(t, err)
+	release3, err := p.AcquireKey(ctx, "etl_customer-1", Entry{}, nil)
+	require.NoError(t, err)
+
+	timeout, cancel := context.WithTimeout(ctx, time.Millisecond*50)
+	defer cancel()
+	_, err = p.AcquireKey(timeout, "etl/customer-1", Entry{}, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release1()
+	release4, err := p.AcquireKey(ctx, "etl/customer-1", Entry{}, nil)
+	require.NoError(t, err)
+	release2()
+	release3()
+	release4()
+
+	_, err = p.AcquireKey(ctx, "", Entry{}, nil)
+	require.ErrorIs(t, err, errEmptyKey)
+}
+
+func TestAcquireErrors(t *testing.T) {
+	p := New(t.TempDir(), map[string]int{"closed": 0})
+	_, err := p.Acquire(context.Background(), "missing", Entry{}, nil)
+	require.ErrorIs(t, err, errUnknownPool)
+	_, err = p.Acquire(context.Background(), "closed", Entry{}, nil)
+	require.ErrorIs(t, err, errInvalidPoolSize)
+}
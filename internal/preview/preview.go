@@ -0,0 +1,145 @@
+// Package preview renders the artifacts of the runs for the browser, so that
+// the screenshots and the reports the steps save are reviewed from the run
+// page instead of being downloaded. The HTML reports are sanitized, since an
+// artifact is written by the commands of a DAG and not trusted.
+package preview
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+)
+
+// The types of the artifacts, which the clients use as hints of how to show
+// them.
+const (
+	TypeImage = "image"
+	TypeHTML  = "html"
+	TypeJSON  = "json"
+	TypeCSV   = "csv"
+	// TypeFile is the type of the artifacts without a preview.
+	TypeFile = "file"
+)
+
+const (
+	// MaxSize is the size of the largest artifact that is previewed.
+	MaxSize = 10 << 20
+	// maxCSVRows is the number of the rows of a CSV artifact in its
+	// preview, the header included.
+	maxCSVRows = 1000
+)
+
+var (
+	ErrNoPreview = dagerrors.New(dagerrors.CodeInvalidArgument, "the artifact has no preview")
+	ErrTooLarge  = dagerrors.New(dagerrors.CodeInvalidArgument, "the artifact is too large to preview")
+)
+
+// extensions are the types of the artifacts by the extensions of their
+// files. SVG images are not previewed, since they can have scripts.
+var extensions = map[string]string{
+	".png":  TypeImage,
+	".jpg":  TypeImage,
+	".jpeg": TypeImage,
+	".gif":  TypeImage,
+	".webp": TypeImage,
+	".html": TypeHTML,
+	".htm":  TypeHTML,
+	".json": TypeJSON,
+	".csv":  TypeCSV,
+}
+
+var imageContentTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+}
+
+// TypeOf returns the type of the artifact at the path.
+func TypeOf(p string) string {
+	if t, ok := extensions[strings.ToLower(path.Ext(p))]; ok {
+		return t
+	}
+	return TypeFile
+}
+
+// CSV is the preview of a CSV artifact.
+type CSV struct {
+	Header []string
+	Rows   [][]string
+	// Truncated is true if the artifact has more rows than the preview.
+	Truncated bool
+}
+
+// Render writes the preview of the artifact at the path, whose contents are
+// read from r, and returns its content type. An image is written as it is,
+// an HTML document without its scripts and the other active contents, a
+// JSON document indented, and a CSV table as a JSON object of its rows.
+func Render(w io.Writer, p string, r io.Reader) (string, error) {
+	typ := TypeOf(p)
+	if typ == TypeFile {
+		return "", fmt.Errorf("%w: %s", ErrNoPreview, p)
+	}
+	b, err := io.ReadAll(io.LimitReader(r, MaxSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(b) > MaxSize {
+		return "", fmt.Errorf("%w: %s", ErrTooLarge, p)
+	}
+
+	switch typ {
+	case TypeImage:
+		_, err = w.Write(b)
+		return imageContentTypes[strings.ToLower(path.Ext(p))], err
+	case TypeHTML:
+		return "text/html; charset=utf-8", sanitize(w, b)
+	case TypeJSON:
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, b, "", "  "); err != nil {
+			// An invalid document is shown as the text it is.
+			_, err = w.Write(b)
+			return "text/plain; charset=utf-8", err
+		}
+		_, err = buf.WriteTo(w)
+		return "application/json", err
+	default:
+		table, err := readCSV(b)
+		if err != nil {
+			return "", err
+		}
+		return "application/json", json.NewEncoder(w).Encode(table)
+	}
+}
+
+func readCSV(b []byte) (*CSV, error) {
+	cr := csv.NewReader(bytes.NewReader(b))
+	cr.FieldsPerRecord = -1
+	table := &CSV{Rows: [][]string{}}
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return table, nil
+		}
+		if err != nil {
+			return nil, dagerrors.WithCode(dagerrors.CodeInvalidArgument, err)
+		}
+		switch {
+		case table.Header == nil:
+			table.Header = row
+		case len(table.Rows)+1 >= maxCSVRows:
+			table.Truncated = true
+			return table, nil
+		default:
+			table.Rows = append(table.Rows, row)
+		}
+	}
+}
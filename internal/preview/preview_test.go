@@ -0,0 +1,88 @@
+package preview
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeOf(t *testing.T) {
+	for p, typ := range map[string]string{
+		"screenshots/login.PNG": TypeImage,
+		"report/index.html":     TypeHTML,
+		"result.json":           TypeJSON,
+		"export.csv":            TypeCSV,
+		"chart.svg":             TypeFile,
+		"dist/app.tar.gz":       TypeFile,
+	} {
+		require.Equal(t, typ, TypeOf(p), p)
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Run("Image", func(t *testing.T) {
+		var buf bytes.Buffer
+		contentType, err := Render(&buf, "shot.jpg", strings.NewReader("\xff\xd8\xff"))
+		require.NoError(t, err)
+		require.Equal(t, "image/jpeg", contentType)
+		require.Equal(t, "\xff\xd8\xff", buf.String())
+	})
+	t.Run("HTML", func(t *testing.T) {
+		var buf bytes.Buffer
+		contentType, err := Render(&buf, "index.html", strings.NewReader(`<html><head>
+<script>alert(1)</script><meta http-equiv="refresh" content="0;url=https://example.com">
+</head><body onload="steal()">
+<h1 class="title">Report</h1>
+<a href=" java&#x09;script:alert(1)">x</a><a href="details.html">details</a>
+<img src="data:image/png;base64,iVBOR" onerror="steal()"><img src="data:text/html,<script>">
+<iframe src="https://example.com"></iframe><svg><script>alert(1)</script></svg>
+</body></html>`))
+		require.NoError(t, err)
+		require.Equal(t, "text/html; charset=utf-8", contentType)
+		out := buf.String()
+		require.Contains(t, out, `<h1 class="title">Report</h1>`)
+		require.Contains(t, out, `<a href="details.html">details</a>`)
+		require.Contains(t, out, `<img src="data:image/png;base64,iVBOR"/>`)
+		for _, s := range []string{"script", "onload", "onerror", "refresh", "iframe", "svg", "data:text"} {
+			require.NotContains(t, out, s)
+		}
+	})
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		contentType, err := Render(&buf, "result.json", strings.NewReader(`{"passed":3,"failed":[]}`))
+		require.NoError(t, err)
+		require.Equal(t, "application/json", contentType)
+		require.Equal(t, "{\n  \"passed\": 3,\n  \"failed\": []\n}", buf.String())
+
+		// An invalid document is shown as text.
+		buf.Reset()
+		contentType, err = Render(&buf, "result.json", strings.NewReader(`{"passed":`))
+		require.NoError(t, err)
+		require.Equal(t, "text/plain; charset=utf-8", contentType)
+	})
+	t.Run("CSV", func(t *testing.T) {
+		var rows []string
+		for i := 0; i < maxCSVRows+10; i++ {
+			rows = append(rows, fmt.Sprintf("%d,row %d", i, i))
+		}
+		var buf bytes.Buffer
+		_, err := Render(&buf, "export.csv", strings.NewReader("id,name\n"+strings.Join(rows, "\n")))
+		require.NoError(t, err)
+		var table CSV
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &table))
+		require.Equal(t, []string{"id", "name"}, table.Header)
+		require.Len(t, table.Rows, maxCSVRows-1)
+		require.Equal(t, []string{"0", "row 0"}, table.Rows[0])
+		require.True(t, table.Truncated)
+	})
+	t.Run("NoPreview", func(t *testing.T) {
+		_, err := Render(&bytes.Buffer{}, "app.tar.gz", strings.NewReader(""))
+		require.ErrorIs(t, err, ErrNoPreview)
+		_, err = Render(&bytes.Buffer{}, "big.json", strings.NewReader(strings.Repeat(" ", MaxSize+1)))
+		require.ErrorIs(t, err, ErrTooLarge)
+	})
+}
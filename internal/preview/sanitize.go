@@ -0,0 +1,110 @@
+package preview
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// droppedElements are the elements removed from the HTML artifacts with
+// their contents: the scripts, the embedded documents and plugins, and the
+// elements that navigate or submit the page or change its base URL.
+var droppedElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Noscript: true,
+	atom.Iframe:   true,
+	atom.Frame:    true,
+	atom.Frameset: true,
+	atom.Object:   true,
+	atom.Embed:    true,
+	atom.Applet:   true,
+	atom.Base:     true,
+	atom.Meta:     true,
+	atom.Link:     true,
+	atom.Form:     true,
+	atom.Template: true,
+}
+
+// urlAttributes are the attributes whose URLs are removed if they run
+// scripts.
+var urlAttributes = map[string]bool{
+	"href":       true,
+	"src":        true,
+	"action":     true,
+	"formaction": true,
+	"xlink:href": true,
+	"background": true,
+	"poster":     true,
+	"srcset":     true,
+}
+
+// sanitize writes the HTML document without its scripts, its event handlers
+// and the URLs that run scripts. The images embedded as data URLs, e.g. the
+// screenshots of a test report, are kept.
+func sanitize(w io.Writer, b []byte) error {
+	doc, err := html.Parse(bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	sanitizeNode(doc)
+	return html.Render(w, doc)
+}
+
+// sanitizeNode removes the dropped elements of the children of the node.
+// The SVG and MathML elements are removed too, since their scripts and
+// their links are not checked.
+func sanitizeNode(n *html.Node) {
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		if c.Type == html.ElementNode && (droppedElements[c.DataAtom] || c.Namespace != "") {
+			n.RemoveChild(c)
+		} else {
+			if c.Type == html.ElementNode {
+				c.Attr = sanitizeAttrs(c.Attr)
+			}
+			sanitizeNode(c)
+		}
+		c = next
+	}
+}
+
+func sanitizeAttrs(attrs []html.Attribute) []html.Attribute {
+	var kept []html.Attribute
+	for _, a := range attrs {
+		key := strings.ToLower(a.Key)
+		if a.Namespace != "" {
+			key = a.Namespace + ":" + key
+		}
+		switch {
+		case strings.HasPrefix(key, "on"):
+			continue
+		case urlAttributes[key] && !safeURL(a.Val):
+			continue
+		}
+		kept = append(kept, a)
+	}
+	return kept
+}
+
+// safeURL returns false for the URLs of the javascript and vbscript schemes
+// and the data URLs other than the images.
+func safeURL(v string) bool {
+	// The browsers ignore the whitespaces and the control characters in the
+	// schemes.
+	s := strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(v))
+	switch {
+	case strings.HasPrefix(s, "javascript:"), strings.HasPrefix(s, "vbscript:"):
+		return false
+	case strings.HasPrefix(s, "data:"):
+		return strings.HasPrefix(s, "data:image/") && !strings.HasPrefix(s, "data:image/svg")
+	}
+	return true
+}
@@ -0,0 +1,283 @@
+// Package prune removes the runs of the DAGs from the history with their log
+// files when they are older, more or larger than the retention of the DAG
+// allows, and the log files that are older than the retention.
+package prune
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/logfile"
+	"github.com/dagu-dev/dagu/internal/logger"
+	"github.com/dagu-dev/dagu/internal/logger/tag"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/utils"
+)
+
+// The reasons a run is removed for.
+const (
+	ReasonAge  = "age"
+	ReasonRuns = "runs"
+	ReasonSize = "size"
+)
+
+// Config contains the configuration for a Pruner.
+type Config struct {
+	DataStore persistence.DataStoreFactory
+	// DryRun reports the runs and the files without removing them.
+	DryRun bool
+	// Interval is the interval of the pruning run by Start.
+	Interval time.Duration
+	// Workers is the number of the DAGs pruned at the same time, so that a
+	// DAG with a large history does not delay the others. It is one if it
+	// is zero.
+	Workers int
+	Logger  logger.Logger
+}
+
+// Pruner enforces the retention of the history of the DAGs:
+// histRetentionDays, histRetentionRuns and histRetentionBytes. The runs
+// that are running are kept.
+type Pruner struct {
+	*Config
+}
+
+func New(cfg *Config) *Pruner {
+	return &Pruner{Config: cfg}
+}
+
+// Start prunes the history every interval in the background until done is
+// closed.
+func (p *Pruner) Start(done chan any) {
+	go func() {
+		ticker := time.NewTicker(p.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.runAndLog()
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+func (p *Pruner) runAndLog() {
+	r, err := p.Run()
+	if err != nil {
+		p.Logger.Error("failed to prune the history", tag.Error(err))
+		return
+	}
+	for _, e := range r.Errors {
+		p.Logger.Warn("failed to prune the history", "error", e)
+	}
+	if len(r.Runs) > 0 || len(r.Logs) > 0 {
+		p.Logger.Info("pruned the history", "runs", len(r.Runs), "logs", len(r.Logs), "size", r.Size())
+	}
+}
+
+// Report is the result of a pruning.
+type Report struct {
+	DryRun bool   `json:"dryRun"`
+	Runs   []*Run `json:"runs"`
+	// Logs are the log files older than the retention of no run in the
+	// history.
+	Logs []*File `json:"logs"`
+	// Errors are the runs and the files that could not be removed.
+	Errors []string `json:"errors"`
+}
+
+// Run is a run removed from the history.
+type Run struct {
+	DAG       string  `json:"dag"`
+	RequestId string  `json:"requestId"`
+	Reason    string  `json:"reason"`
+	Logs      []*File `json:"logs"`
+}
+
+// File is a removed log file.
+type File struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// Size returns the total size of the removed log files.
+func (r *Report) Size() int64 {
+	var n int64
+	for _, run := range r.Runs {
+		n += size(run.Logs)
+	}
+	return n + size(r.Logs)
+}
+
+func size(files []*File) int64 {
+	var n int64
+	for _, f := range files {
+		n += f.Size
+	}
+	return n
+}
+
+// Run prunes the history of the DAGs of the names, or of all the DAGs if
+// there is none, and returns the report.
+func (p *Pruner) Run(names ...string) (*Report, error) {
+	r := &Report{DryRun: p.DryRun, Runs: []*Run{}, Logs: []*File{}, Errors: []string{}}
+	ds := p.DataStore.NewDAGStore()
+	if len(names) == 0 {
+		dags, errs, err := ds.List()
+		if err != nil {
+			return nil, err
+		}
+		r.Errors = append(r.Errors, errs...)
+		for _, d := range dags {
+			names = append(names, d.Location)
+		}
+	}
+	hs := p.DataStore.NewHistoryStore()
+	// Each DAG is pruned into its own report, and the reports are merged in
+	// the order of the names.
+	reports := make([]*Report, len(names))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < max(p.Workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				reports[i] = &Report{}
+				d, err := ds.GetDetails(names[i])
+				if err != nil {
+					reports[i].Errors = append(reports[i].Errors, err.Error())
+					continue
+				}
+				p.prune(reports[i], hs, d)
+			}
+		}()
+	}
+	for i := range names {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	for _, dr := range reports {
+		r.Runs = append(r.Runs, dr.Runs...)
+		r.Logs = append(r.Logs, dr.Logs...)
+		r.Errors = append(r.Errors, dr.Errors...)
+	}
+	return r, nil
+}
+
+func (p *Pruner) prune(r *Report, hs persistence.HistoryStore, d *dag.DAG) {
+	var cutoff time.Time
+	if d.HistRetentionDays >= 0 {
+		cutoff = time.Now().AddDate(0, 0, -d.HistRetentionDays)
+	}
+	kept := 0
+	var total int64
+	// known are the log files of the runs in the history.
+	known := make(map[string]bool)
+	for _, st := range hs.ReadStatusAll(d.Location) {
+		logs := logFiles(st.Status)
+		for _, f := range logs {
+			known[f.Path] = true
+		}
+		reason := ""
+		switch {
+		case st.Status.Status == scheduler.StatusRunning:
+		case d.HistRetentionRuns > 0 && kept >= d.HistRetentionRuns:
+			reason = ReasonRuns
+		// The latest run is kept even if its logs are larger.
+		case d.HistRetentionBytes > 0 && kept > 0 && total+size(logs) > d.HistRetentionBytes:
+			reason = ReasonSize
+		case isBefore(startedAt(st.Status), cutoff):
+			reason = ReasonAge
+		}
+		if reason == "" {
+			kept++
+			total += size(logs)
+			continue
+		}
+		run := &Run{DAG: d.Name, RequestId: st.Status.RequestId, Reason: reason, Logs: logs}
+		if !p.DryRun {
+			if err := hs.RemoveRun(d.Location, run.RequestId); err != nil {
+				r.Errors = append(r.Errors, err.Error())
+				continue
+			}
+			run.Logs = p.removeFiles(r, logs)
+		}
+		r.Runs = append(r.Runs, run)
+	}
+	// The log directory is not known if it refers to a variable.
+	if !cutoff.IsZero() && filepath.IsAbs(d.LogDir) && !strings.Contains(d.LogDir, "$") {
+		p.pruneLogs(r, filepath.Join(d.LogDir, utils.ValidFilename(d.Name, "_")), cutoff, known)
+	}
+}
+
+// pruneLogs removes the log files of the runs that are no longer in the
+// history, e.g. because they were removed by the agent after the retention
+// period.
+func (p *Pruner) pruneLogs(r *Report, dir string, cutoff time.Time, known map[string]bool) {
+	entries, _ := os.ReadDir(dir)
+	var files []*File
+	for _, e := range entries {
+		file := filepath.Join(dir, e.Name())
+		info, err := e.Info()
+		if err != nil || known[file] || !info.Mode().IsRegular() || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		files = append(files, &File{Path: file, Size: info.Size()})
+	}
+	if !p.DryRun {
+		files = p.removeFiles(r, files)
+	}
+	r.Logs = append(r.Logs, files...)
+}
+
+// removeFiles removes the files and returns the ones that were removed.
+func (p *Pruner) removeFiles(r *Report, files []*File) []*File {
+	var removed []*File
+	for _, f := range files {
+		if err := os.Remove(f.Path); err != nil && !os.IsNotExist(err) {
+			r.Errors = append(r.Errors, err.Error())
+			continue
+		}
+		removed = append(removed, f)
+	}
+	return removed
+}
+
+// logFiles returns the log files of the run that exist, including the
+// rotated and the compressed ones.
+func logFiles(st *model.Status) []*File {
+	var files []*File
+	for _, path := range st.LogPaths() {
+		for _, f := range logfile.Files(path) {
+			if info, err := os.Stat(f); err == nil && info.Mode().IsRegular() {
+				files = append(files, &File{Path: f, Size: info.Size()})
+			}
+		}
+	}
+	return files
+}
+
+func isBefore(t, cutoff time.Time) bool {
+	return !t.IsZero() && t.Before(cutoff)
+}
+
+// startedAt returns the time the run started, or finished if it never
+// started. It is zero if the run has neither.
+func startedAt(st *model.Status) time.Time {
+	for _, s := range []string{st.StartedAt, st.FinishedAt} {
+		if t, err := utils.ParseTime(s); err == nil && !t.IsZero() {
+			return t
+		}
+	}
+	return time.Time{}
+}
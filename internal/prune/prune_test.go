@@ -0,0 +1,186 @@
+package prune
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruner(t *testing.T) {
+	tmpDir := utils.MustTempDir("test-prune")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	cfg := &config.Config{
+		DAGs:    filepath.Join(tmpDir, "dags"),
+		DataDir: filepath.Join(tmpDir, "data"),
+	}
+	df := client.NewDataStoreFactory(cfg)
+	logDir := filepath.Join(tmpDir, "logs")
+	spec := fmt.Sprintf("logDir: %s\nhistRetentionDays: 7\nhistRetentionRuns: 3\nhistRetentionBytes: 35\nsteps:\n  - name: step1\n    command: echo 1\n", logDir)
+	_, err := df.NewDAGStore().Create("etl", []byte(spec))
+	require.NoError(t, err)
+	d, err := df.NewDAGStore().GetDetails("etl")
+	require.NoError(t, err)
+	dir := filepath.Join(logDir, "etl")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+
+	// The runs from the latest: one that is running, one with a log that is
+	// too large to be kept with the logs of the later runs, and one more
+	// than histRetentionRuns.
+	hs := df.NewHistoryStore()
+	now := time.Now()
+	runs := []struct {
+		requestId string
+		startedAt time.Time
+		status    scheduler.Status
+	}{
+		{"running", now, scheduler.StatusRunning},
+		{"latest", now.Add(-time.Hour), scheduler.StatusSuccess},
+		{"large", now.Add(-time.Hour * 2), scheduler.StatusError},
+		{"second", now.Add(-time.Hour * 3), scheduler.StatusSuccess},
+		{"old", now.AddDate(0, 0, -8), scheduler.StatusSuccess},
+	}
+	logs := map[string]string{}
+	for _, run := range runs {
+		logs[run.requestId] = filepath.Join(dir, run.requestId+".log")
+		data := []byte("0123456789")
+		if run.requestId == "large" {
+			data = bytes.Repeat(data, 3)
+		}
+		require.NoError(t, os.WriteFile(logs[run.requestId], data, 0600))
+		require.NoError(t, hs.Open(d.Location, run.startedAt, run.requestId))
+		st := model.NewStatus(d, nil, run.status, 10000, model.Time(run.startedAt), nil)
+		st.RequestId = run.requestId
+		st.Log = logs[run.requestId]
+		require.NoError(t, hs.Write(st))
+		require.NoError(t, hs.Close())
+	}
+	orphan := filepath.Join(dir, "orphan.log")
+	require.NoError(t, os.WriteFile(orphan, []byte("0123456789"), 0600))
+	old := now.AddDate(0, 0, -10)
+	require.NoError(t, os.Chtimes(orphan, old, old))
+
+	p := New(&Config{DataStore: df, DryRun: true})
+	r, err := p.Run()
+	require.NoError(t, err)
+	require.Empty(t, r.Errors)
+	reasons := map[string]string{}
+	for _, run := range r.Runs {
+		reasons[run.RequestId] = run.Reason
+	}
+	require.Equal(t, map[string]string{"large": ReasonSize, "old": ReasonRuns}, reasons)
+	require.Len(t, r.Logs, 1)
+	require.Equal(t, orphan, r.Logs[0].Path)
+	require.Equal(t, int64(50), r.Size())
+	require.FileExists(t, logs["old"])
+
+	p.DryRun = false
+	_, err = p.Run("etl")
+	require.NoError(t, err)
+	var kept []string
+	for _, st := range hs.ReadStatusAll(d.Location) {
+		kept = append(kept, st.Status.RequestId)
+	}
+	require.Equal(t, []string{"running", "latest", "second"}, kept)
+	require.NoFileExists(t, logs["large"])
+	require.NoFileExists(t, logs["old"])
+	require.NoFileExists(t, orphan)
+	require.FileExists(t, logs["second"])
+}
+
+func TestPrunerAge(t *testing.T) {
+	tmpDir := utils.MustTempDir("test-prune")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	cfg := &config.Config{
+		DAGs:    filepath.Join(tmpDir, "dags"),
+		DataDir: filepath.Join(tmpDir, "data"),
+	}
+	df := client.NewDataStoreFactory(cfg)
+	spec := fmt.Sprintf("logDir: %s\nhistRetentionDays: 7\nsteps:\n  - name: step1\n    command: echo 1\n", filepath.Join(tmpDir, "logs"))
+	_, err := df.NewDAGStore().Create("etl", []byte(spec))
+	require.NoError(t, err)
+	d, err := df.NewDAGStore().GetDetails("etl")
+	require.NoError(t, err)
+
+	hs := df.NewHistoryStore()
+	for i, startedAt := range []time.Time{time.Now(), time.Now().AddDate(0, 0, -8)} {
+		requestId := fmt.Sprintf("request-%d", i)
+		require.NoError(t, hs.Open(d.Location, startedAt, requestId))
+		st := model.NewStatus(d, nil, scheduler.StatusSuccess, 10000, model.Time(startedAt), nil)
+		st.RequestId = requestId
+		require.NoError(t, hs.Write(st))
+		require.NoError(t, hs.Close())
+	}
+
+	r, err := New(&Config{DataStore: df}).Run()
+	require.NoError(t, err)
+	require.Len(t, r.Runs, 1)
+	require.Equal(t, "request-1", r.Runs[0].RequestId)
+	require.Equal(t, ReasonAge, r.Runs[0].Reason)
+	require.Len(t, hs.ReadStatusAll(d.Location), 1)
+}
+
+func TestPrunerWorkers(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		DAGs:           filepath.Join(tmpDir, "dags"),
+		DataDir:        filepath.Join(tmpDir, "data"),
+		HistoryBackend: &config.HistoryBackend{Type: "sqlite", Sharded: true},
+	}
+	df := client.NewDataStoreFactory(cfg)
+	hs := df.NewHistoryStore()
+	var names []string
+	for i := 0; i < 6; i++ {
+		name := fmt.Sprintf("dag%d", i)
+		names = append(names, name)
+		_, err := df.NewDAGStore().Create(name, []byte("histRetentionRuns: 1\nsteps:\n  - name: step1\n    command: echo 1\n"))
+		require.NoError(t, err)
+		d, err := df.NewDAGStore().GetDetails(name)
+		require.NoError(t, err)
+		for j := 0; j < 3; j++ {
+			requestId := fmt.Sprintf("%s-%d", name, j)
+			startedAt := time.Now().Add(time.Duration(j) * time.Minute)
+			require.NoError(t, hs.Open(d.Location, startedAt, requestId))
+			st := model.NewStatus(d, nil, scheduler.StatusSuccess, 10000, model.Time(startedAt), nil)
+			st.RequestId = requestId
+			require.NoError(t, hs.Write(st))
+			require.NoError(t, hs.Close())
+		}
+	}
+
+	// The DAGs are pruned at the same time, and reported in their order.
+	r, err := New(&Config{DataStore: df, Workers: 3}).Run(names...)
+	require.NoError(t, err)
+	require.Empty(t, r.Errors)
+	var removed []string
+	for _, run := range r.Runs {
+		removed = append(removed, run.RequestId)
+	}
+	var want []string
+	for _, name := range names {
+		want = append(want, name+"-1", name+"-0")
+	}
+	require.Equal(t, want, removed)
+	for _, name := range names {
+		d, err := df.NewDAGStore().GetDetails(name)
+		require.NoError(t, err)
+		ret := hs.ReadStatusAll(d.Location)
+		require.Len(t, ret, 1)
+		require.Equal(t, name+"-2", ret[0].Status.RequestId)
+	}
+}
@@ -0,0 +1,234 @@
+// Package recovery finds the runs of the DAGs whose statuses are running
+// while their agents are gone, e.g. after a crash of the host, and marks
+// them as lost so that they do not stay running forever.
+package recovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/constants"
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/logger"
+	"github.com/dagu-dev/dagu/internal/logger/tag"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/sock"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"golang.org/x/sys/unix"
+)
+
+var errAgentGone = errors.New("the agent of the run is gone")
+
+// Config contains the configuration for a Recoverer.
+type Config struct {
+	DataStore persistence.DataStoreFactory
+	// FailureHandler runs the failure handlers of the DAGs of the lost runs.
+	FailureHandler bool
+	// LockFile is the file locked while the runs are recovered, so that the
+	// scheduler and the server started together do not recover them twice.
+	LockFile string
+	Logger   logger.Logger
+}
+
+// Recoverer marks the runs whose agents are gone as lost. A run is lost if
+// the socket of its DAG does not serve it and its process does not exist.
+// The runs started on other hosts are kept, since their agents cannot be
+// checked.
+type Recoverer struct {
+	*Config
+}
+
+func New(cfg *Config) *Recoverer {
+	return &Recoverer{Config: cfg}
+}
+
+// LockFile returns the path of the lock file of the recovery in the data
+// directory.
+func LockFile(dataDir string) string {
+	return filepath.Join(dataDir, "recovery.lock")
+}
+
+// Start recovers the lost runs once in the background.
+func (r *Recoverer) Start(_ chan any) {
+	go r.runAndLog()
+}
+
+func (r *Recoverer) runAndLog() {
+	rep, err := r.Run()
+	if err != nil {
+		r.Logger.Error("failed to recover the lost runs", tag.Error(err))
+		return
+	}
+	for _, e := range rep.Errors {
+		r.Logger.Warn("failed to recover the lost run", "error", e)
+	}
+	for _, run := range rep.Runs {
+		r.Logger.Warn("marked the run as lost", "dag", run.DAG, "requestId", run.RequestId, "pid", run.Pid)
+	}
+}
+
+// Report is the result of a recovery.
+type Report struct {
+	Runs []*Run `json:"runs"`
+	// Errors are the runs that could not be marked as lost.
+	Errors []string `json:"errors"`
+}
+
+// Run is a run marked as lost.
+type Run struct {
+	DAG       string `json:"dag"`
+	RequestId string `json:"requestId"`
+	Pid       int    `json:"pid"`
+	// FailureHandler is the status of the failure handler if it was run.
+	FailureHandler string `json:"failureHandler,omitempty"`
+}
+
+// Run marks the lost runs of the DAGs of the names, or of all the DAGs if
+// there is none, and returns the report. It does nothing if another process
+// is recovering the runs.
+func (r *Recoverer) Run(names ...string) (*Report, error) {
+	rep := &Report{Runs: []*Run{}, Errors: []string{}}
+	if r.LockFile != "" {
+		unlock, err := tryLock(r.LockFile)
+		if err != nil {
+			return nil, err
+		}
+		if unlock == nil {
+			return rep, nil
+		}
+		defer unlock()
+	}
+	ds := r.DataStore.NewDAGStore()
+	if len(names) == 0 {
+		dags, errs, err := ds.List()
+		if err != nil {
+			return nil, err
+		}
+		rep.Errors = append(rep.Errors, errs...)
+		for _, d := range dags {
+			names = append(names, d.Location)
+		}
+	}
+	hostname, _ := os.Hostname()
+	for _, name := range names {
+		d, err := ds.GetDetails(name)
+		if err != nil {
+			rep.Errors = append(rep.Errors, err.Error())
+			continue
+		}
+		r.recover(rep, d, hostname)
+	}
+	return rep, nil
+}
+
+func (r *Recoverer) recover(rep *Report, d *dag.DAG, hostname string) {
+	hs := r.DataStore.NewHistoryStore()
+	for _, sf := range hs.ReadStatusAll(d.Location) {
+		st := sf.Status
+		if st.Status != scheduler.StatusRunning || (st.Host != nil && st.Host.Hostname != hostname) || isAlive(d, st) {
+			continue
+		}
+		run := &Run{DAG: d.Name, RequestId: st.RequestId, Pid: int(st.Pid)}
+		markLost(st)
+		if r.FailureHandler && d.HandlerOn.Failure != nil {
+			node := r.runFailureHandler(d, st)
+			run.FailureHandler = node.StatusText
+		}
+		if err := hs.Update(d.Location, st.RequestId, st); err != nil {
+			rep.Errors = append(rep.Errors, fmt.Sprintf("%s of %s: %s", st.RequestId, d.Name, err))
+			continue
+		}
+		rep.Runs = append(rep.Runs, run)
+	}
+}
+
+// isAlive returns true if the socket of the DAG serves the run, or if the
+// process of the run exists, e.g. while the agent starts its socket. The
+// process is the one of the run if it has the start time of the host of the
+// status, which the runs recorded before it was added do not have.
+func isAlive(d *dag.DAG, st *model.Status) bool {
+	client := sock.Client{Addr: d.SockAddr()}
+	if res, err := client.Request("GET", "/status"); err == nil {
+		if current, _ := model.StatusFromJson(res); current != nil && current.RequestId == st.RequestId {
+			return true
+		}
+	}
+	if !st.Pid.IsRunning() {
+		return false
+	}
+	var start uint64
+	if st.Host != nil {
+		start = st.Host.ProcessStart
+	}
+	return utils.ProcessAlive(int(st.Pid), start)
+}
+
+// markLost sets the status of the run and of its running steps to lost.
+func markLost(st *model.Status) {
+	now := utils.FormatTime(time.Now())
+	st.Status = scheduler.StatusLost
+	st.StatusText = st.Status.String()
+	st.Pid = model.PidNotRunning
+	if st.FinishedAt == "" {
+		st.FinishedAt = now
+	}
+	for _, n := range st.Nodes {
+		if n.Status != scheduler.NodeStatusRunning {
+			continue
+		}
+		n.Status = scheduler.NodeStatusError
+		n.StatusText = n.Status.String()
+		n.Error = errAgentGone.Error()
+		n.FinishedAt = now
+	}
+}
+
+// runFailureHandler runs the failure handler of the DAG for the lost run
+// and records it in the status.
+func (r *Recoverer) runFailureHandler(d *dag.DAG, st *model.Status) *model.Node {
+	sc := &scheduler.Scheduler{Config: &scheduler.Config{
+		LogDir:    filepath.Join(d.LogDir, utils.ValidFilename(d.Name, "_")),
+		DAGName:   d.Name,
+		RequestId: st.RequestId,
+		OnFailure: d.HandlerOn.Failure,
+	}}
+	node, err := sc.RunHandler(context.Background(), constants.OnFailure, scheduler.StatusLost)
+	if err != nil {
+		st.OnFailure = model.NewNode(*d.HandlerOn.Failure)
+		st.OnFailure.Status = scheduler.NodeStatusError
+		st.OnFailure.StatusText = st.OnFailure.Status.String()
+		st.OnFailure.Error = err.Error()
+		return st.OnFailure
+	}
+	st.OnFailure = model.FromNode(node.State(), node.Step())
+	return st.OnFailure
+}
+
+// tryLock locks the file. It returns nil if the file is locked by another
+// process.
+func tryLock(file string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(file, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := unix.Flock(int(f.Fd()), unix.LOCK_EX|unix.LOCK_NB); err != nil {
+		_ = f.Close()
+		if errors.Is(err, unix.EWOULDBLOCK) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return func() {
+		_ = unix.Flock(int(f.Fd()), unix.LOCK_UN)
+		_ = f.Close()
+	}, nil
+}
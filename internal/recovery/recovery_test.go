@@ -0,0 +1,109 @@
+package recovery
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverer(t *testing.T) {
+	tmpDir := utils.MustTempDir("test-recovery")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	cfg := &config.Config{
+		DAGs:    filepath.Join(tmpDir, "dags"),
+		DataDir: filepath.Join(tmpDir, "data"),
+	}
+	df := client.NewDataStoreFactory(cfg)
+	handled := filepath.Join(tmpDir, "handled")
+	spec := fmt.Sprintf("logDir: %s\nhandlerOn:\n  failure:\n    command: touch %s\nsteps:\n  - name: step1\n    command: sleep 60\n", filepath.Join(tmpDir, "logs"), handled)
+	_, err := df.NewDAGStore().Create("etl", []byte(spec))
+	require.NoError(t, err)
+	d, err := df.NewDAGStore().GetDetails("etl")
+	require.NoError(t, err)
+
+	// A process that exited.
+	gone, err := os.StartProcess("/bin/true", []string{"true"}, &os.ProcAttr{})
+	require.NoError(t, err)
+	_, err = gone.Wait()
+	require.NoError(t, err)
+
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+	hs := df.NewHistoryStore()
+	now := time.Now()
+	runs := []struct {
+		requestId string
+		status    scheduler.Status
+		pid       int
+		hostname  string
+	}{
+		{"finished", scheduler.StatusSuccess, gone.Pid, ""},
+		{"alive", scheduler.StatusRunning, os.Getpid(), ""},
+		{"other-host", scheduler.StatusRunning, gone.Pid, "other-host"},
+		{"lost", scheduler.StatusRunning, gone.Pid, ""},
+		// The pid of the run was reused by another process.
+		{"reused", scheduler.StatusRunning, os.Getpid(), hostname},
+	}
+	for i, run := range runs {
+		startedAt := now.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, hs.Open(d.Location, startedAt, run.requestId))
+		st := model.NewStatus(d, nil, run.status, run.pid, model.Time(startedAt), nil)
+		st.RequestId = run.requestId
+		st.Nodes[0].Status = scheduler.NodeStatusRunning
+		if run.hostname == hostname {
+			st.Host = &model.Host{Hostname: hostname, ProcessStart: 1}
+		} else if run.hostname != "" {
+			st.Host = &model.Host{Hostname: run.hostname}
+		}
+		require.NoError(t, hs.Write(st))
+		require.NoError(t, hs.Close())
+	}
+
+	r := New(&Config{DataStore: df, FailureHandler: true, LockFile: LockFile(cfg.DataDir)})
+	rep, err := r.Run()
+	require.NoError(t, err)
+	require.Empty(t, rep.Errors)
+	require.Len(t, rep.Runs, 2)
+	require.ElementsMatch(t, []string{"lost", "reused"}, []string{rep.Runs[0].RequestId, rep.Runs[1].RequestId})
+	require.Equal(t, scheduler.NodeStatusSuccess.String(), rep.Runs[0].FailureHandler)
+	require.FileExists(t, handled)
+
+	sf, err := hs.FindByRequestId(d.Location, "lost")
+	require.NoError(t, err)
+	require.Equal(t, scheduler.StatusLost, sf.Status.Status)
+	require.Equal(t, model.PidNotRunning, sf.Status.Pid)
+	require.NotEmpty(t, sf.Status.FinishedAt)
+	require.Equal(t, scheduler.NodeStatusError, sf.Status.Nodes[0].Status)
+	require.Equal(t, errAgentGone.Error(), sf.Status.Nodes[0].Error)
+	require.Equal(t, scheduler.NodeStatusSuccess, sf.Status.OnFailure.Status)
+	for _, id := range []string{"alive", "other-host"} {
+		sf, err := hs.FindByRequestId(d.Location, id)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.StatusRunning, sf.Status.Status)
+	}
+
+	// The lost runs are not marked again.
+	rep, err = r.Run("etl")
+	require.NoError(t, err)
+	require.Empty(t, rep.Runs)
+
+	// Another process is recovering the runs.
+	unlock, err := tryLock(LockFile(cfg.DataDir))
+	require.NoError(t, err)
+	defer unlock()
+	rep, err = r.Run()
+	require.NoError(t, err)
+	require.Empty(t, rep.Runs)
+}
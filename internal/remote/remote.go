@@ -0,0 +1,346 @@
+// Package remote is the client of the REST API of a dagu instance, which the
+// CLI commands use instead of the local files when the profile has the URL
+// of the API.
+package remote
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+)
+
+// Client requests the API of an instance with its token.
+type Client struct {
+	baseURL   string
+	token     string
+	namespace string
+	http      *http.Client
+}
+
+// New returns the client of the API of the configuration, or nil if it has
+// no API URL.
+func New(cfg *config.Config) *Client {
+	if cfg.APIURL == "" {
+		return nil
+	}
+	return &Client{
+		baseURL:   strings.TrimSuffix(cfg.APIURL, "/"),
+		token:     cfg.AuthToken,
+		namespace: cfg.Namespace,
+		http:      http.DefaultClient,
+	}
+}
+
+// streamRetryInterval is the interval at which a log stream that was cut
+// before its end is requested again.
+const streamRetryInterval = time.Second
+
+var errLogStream = errors.New("failed to stream the log")
+
+// Status is the status of a run of a DAG.
+type Status struct {
+	RequestId  string  `json:"RequestId"`
+	Pid        int     `json:"Pid"`
+	StatusText string  `json:"StatusText"`
+	Nodes      []*Node `json:"Nodes,omitempty"`
+	OnSuccess  *Node   `json:"OnSuccess,omitempty"`
+	OnFailure  *Node   `json:"OnFailure,omitempty"`
+	OnCancel   *Node   `json:"OnCancel,omitempty"`
+	OnExit     *Node   `json:"OnExit,omitempty"`
+}
+
+// Node is the status of a step of a run.
+type Node struct {
+	Step struct {
+		Name string `json:"Name"`
+	} `json:"Step"`
+	StatusText string `json:"StatusText"`
+}
+
+// StepNames returns the names of the steps and the handlers of the run.
+func (s *Status) StepNames() []string {
+	var names []string
+	for _, n := range s.nodes() {
+		names = append(names, n.Step.Name)
+	}
+	return names
+}
+
+// Node returns the status of the step or the handler of the name, or nil if
+// the run has none.
+func (s *Status) Node(name string) *Node {
+	for _, n := range s.nodes() {
+		if n.Step.Name == name {
+			return n
+		}
+	}
+	return nil
+}
+
+func (s *Status) nodes() []*Node {
+	var nodes []*Node
+	for _, n := range append(s.Nodes, s.OnSuccess, s.OnFailure, s.OnCancel, s.OnExit) {
+		if n != nil {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+type actionRequest struct {
+	Action string `json:"action"`
+	Params string `json:"params,omitempty"`
+}
+
+type actionResponse struct {
+	RequestId string `json:"RequestId"`
+	Queued    bool   `json:"Queued"`
+}
+
+type detailsResponse struct {
+	DAG struct {
+		Status *Status `json:"Status"`
+	} `json:"DAG"`
+	StepLog *struct {
+		Content string `json:"Content"`
+	} `json:"StepLog"`
+}
+
+type apiError struct {
+	Code            string `json:"code"`
+	DetailedMessage string `json:"detailedMessage"`
+}
+
+// Start starts the run of the DAG with the parameters and returns its
+// request ID, and whether the run was added to the trigger queue of the
+// instance.
+func (c *Client) Start(ctx context.Context, dagID, params string) (string, bool, error) {
+	resp := &actionResponse{}
+	if err := c.do(ctx, http.MethodPost, dagPath(dagID), &actionRequest{Action: "start", Params: params}, resp); err != nil {
+		return "", false, err
+	}
+	return resp.RequestId, resp.Queued, nil
+}
+
+// Stop stops the running DAG.
+func (c *Client) Stop(ctx context.Context, dagID string) error {
+	return c.do(ctx, http.MethodPost, dagPath(dagID), &actionRequest{Action: "stop"}, nil)
+}
+
+// Status returns the status of the latest run of the DAG.
+func (c *Client) Status(ctx context.Context, dagID string) (*Status, error) {
+	return c.RunStatus(ctx, dagID, "")
+}
+
+// RunStatus returns the status of the run of the request ID, or of the
+// latest run if it is empty.
+func (c *Client) RunStatus(ctx context.Context, dagID, requestID string) (*Status, error) {
+	resp := &detailsResponse{}
+	q := url.Values{}
+	if requestID != "" {
+		q.Set("requestId", requestID)
+	}
+	if err := c.do(ctx, http.MethodGet, withQuery(dagPath(dagID), q), nil, resp); err != nil {
+		return nil, err
+	}
+	if resp.DAG.Status == nil {
+		return &Status{}, nil
+	}
+	return resp.DAG.Status, nil
+}
+
+// StepLog returns the log of the step of the run of the request ID.
+func (c *Client) StepLog(ctx context.Context, dagID, requestID, step string) (string, error) {
+	resp := &detailsResponse{}
+	q := url.Values{"tab": {"log"}, "step": {step}, "requestId": {requestID}}
+	if err := c.do(ctx, http.MethodGet, withQuery(dagPath(dagID), q), nil, resp); err != nil {
+		return "", err
+	}
+	if resp.StepLog == nil {
+		return "", nil
+	}
+	return resp.StepLog.Content, nil
+}
+
+// FollowStepLog writes the lines of the log of the step of the run of the
+// request ID to w as they are written, from the log stream of the API, until
+// the step finishes. It returns the status of the step then, or an empty
+// status if the step is not run. A stream that is cut, e.g. by a proxy, is
+// requested again from its last event.
+func (c *Client) FollowStepLog(ctx context.Context, dagID, requestID, step string, w io.Writer) (string, error) {
+	p := fmt.Sprintf("%s/runs/%s/steps/%s/log/stream", dagPath(dagID), url.PathEscape(requestID), url.PathEscape(step))
+	lastID := ""
+	for {
+		req, err := c.NewRequest(ctx, http.MethodGet, p, nil)
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Accept", "text/event-stream")
+		if lastID != "" {
+			req.Header.Set("Last-Event-ID", lastID)
+		}
+		resp, err := c.Do(req)
+		if err != nil {
+			return "", err
+		}
+		status, end, err := readEvents(resp.Body, w, &lastID)
+		_ = resp.Body.Close()
+		if end || errors.Is(err, errLogStream) {
+			return status, err
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(streamRetryInterval):
+		}
+	}
+}
+
+// readEvents writes the data of the message events of the stream to w, and
+// keeps the id of the last one. It returns whether the stream ended with an
+// end event, and its status.
+func readEvents(r io.Reader, w io.Writer, lastID *string) (string, bool, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	var event, id string
+	var data []string
+	for sc.Scan() {
+		line := sc.Text()
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event = value
+		case "id":
+			id = value
+		case "data":
+			data = append(data, value)
+		case "":
+			if line != "" {
+				// A comment.
+				continue
+			}
+			switch event {
+			case "end":
+				return strings.Join(data, "\n"), true, nil
+			case "error":
+				return "", true, fmt.Errorf("%w: %s", errLogStream, strings.Join(data, " "))
+			case "", "message":
+				if len(data) > 0 {
+					if _, err := io.WriteString(w, strings.Join(data, "\n")+"\n"); err != nil {
+						return "", false, fmt.Errorf("%w: %w", errLogStream, err)
+					}
+				}
+				if id != "" {
+					*lastID = id
+				}
+			}
+			event, id, data = "", "", nil
+		}
+	}
+	return "", false, sc.Err()
+}
+
+func withQuery(p string, q url.Values) string {
+	if len(q) == 0 {
+		return p
+	}
+	return p + "?" + q.Encode()
+}
+
+// dagPath returns the path of the DAG in the API, whose slashes are escaped,
+// e.g. /dags/team-a%2Fetl.
+func dagPath(dagID string) string {
+	return "/dags/" + url.PathEscape(dagID)
+}
+
+// URL returns the URL of the path of the API in the namespace of the
+// client.
+func (c *Client) URL(p string) string {
+	if c.namespace == "" || c.namespace == config.DefaultNamespace {
+		return c.baseURL + p
+	}
+	return c.baseURL + "/namespaces/" + url.PathEscape(c.namespace) + p
+}
+
+// NewRequest returns the request of the path of the API with the token of
+// the client.
+func (c *Client) NewRequest(ctx context.Context, method, p string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.URL(p), body)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return req, nil
+}
+
+// Do sends the request. The error of a response that is not successful
+// has the code of the error of the API, so that the commands exit with the
+// same codes as the local ones.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	apiErr := &apiError{}
+	if err := json.NewDecoder(resp.Body).Decode(apiErr); err != nil || apiErr.Code == "" {
+		// The responses of the authentication have no code.
+		code := dagerrors.CodeInternal
+		switch resp.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			code = dagerrors.CodePermissionDenied
+		case http.StatusNotFound:
+			code = dagerrors.CodeNotFound
+		}
+		return nil, dagerrors.WithCode(code, fmt.Errorf("%s %s: %s", req.Method, req.URL, resp.Status))
+	}
+	return nil, dagerrors.New(dagerrors.Code(apiErr.Code), apiErr.DetailedMessage)
+}
+
+func (c *Client) do(ctx context.Context, method, p string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := c.NewRequest(ctx, method, p, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,86 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("Authorization") != "Bearer token":
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		case r.URL.RawPath == "/api/v1/dags/team-a%2Fetl":
+			_, _ = w.Write([]byte(`{"DAG": {"Status": {"RequestId": "1", "Pid": 42, "StatusText": "running"}}}`))
+		default:
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"code": "dag_not_running", "detailedMessage": "the DAG is not running"}`))
+		}
+	}))
+	defer srv.Close()
+
+	require.Nil(t, New(&config.Config{}))
+	c := New(&config.Config{APIURL: srv.URL + "/api/v1/", AuthToken: "token"})
+	require.Equal(t, srv.URL+"/api/v1/namespaces/staging/dags", (&Client{baseURL: c.baseURL, namespace: "staging"}).URL("/dags"))
+	require.Equal(t, srv.URL+"/api/v1/dags", (&Client{baseURL: c.baseURL, namespace: "default"}).URL("/dags"))
+
+	ctx := context.Background()
+	status, err := c.Status(ctx, "team-a/etl")
+	require.NoError(t, err)
+	require.Equal(t, &Status{RequestId: "1", Pid: 42, StatusText: "running"}, status)
+
+	// The errors have the codes of the API, and of the statuses of the
+	// responses without one.
+	err = c.Stop(ctx, "report")
+	require.ErrorContains(t, err, "the DAG is not running")
+	require.Equal(t, dagerrors.CodeDAGNotRunning, dagerrors.CodeOf(err))
+	c.token = "wrong"
+	_, err = c.Status(ctx, "team-a/etl")
+	require.Equal(t, dagerrors.CodePermissionDenied, dagerrors.CodeOf(err))
+}
+
+func TestFollowStepLog(t *testing.T) {
+	var lastIDs []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/dags/etl/runs/req-1/steps/extract/log/stream", r.URL.Path)
+		lastID := r.Header.Get("Last-Event-ID")
+		lastIDs = append(lastIDs, lastID)
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch lastID {
+		case "":
+			// The stream is cut before its end.
+			_, _ = w.Write([]byte("id: 6\ndata: first\n\n: keep-alive\n\nid: 13\ndata: second\n\n"))
+		case "13":
+			_, _ = w.Write([]byte("id: 19\ndata: third\n\nevent: end\ndata: finished\n\n"))
+		}
+	}))
+	defer srv.Close()
+
+	c := New(&config.Config{APIURL: srv.URL})
+	var buf bytes.Buffer
+	status, err := c.FollowStepLog(context.Background(), "etl", "req-1", "extract", &buf)
+	require.NoError(t, err)
+	require.Equal(t, "finished", status)
+	require.Equal(t, "first\nsecond\nthird\n", buf.String())
+	require.Equal(t, []string{"", "13"}, lastIDs)
+}
+
+func TestFollowStepLogError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("event: error\ndata: the log is encrypted with an unknown key\n\n"))
+	}))
+	defer srv.Close()
+
+	c := New(&config.Config{APIURL: srv.URL})
+	_, err := c.FollowStepLog(context.Background(), "etl", "req-1", "extract", io.Discard)
+	require.ErrorIs(t, err, errLogStream)
+	require.ErrorContains(t, err, "unknown key")
+}
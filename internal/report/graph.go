@@ -0,0 +1,101 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+)
+
+// The formats of the exported graphs.
+const (
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+	FormatSVG     = "svg"
+)
+
+var errInvalidFormat = dagerrors.New(dagerrors.CodeInvalidArgument, "invalid graph format")
+
+// ContentTypes are the media types of the formats of the graphs.
+var ContentTypes = map[string]string{
+	FormatDOT:     "text/vnd.graphviz; charset=utf-8",
+	FormatMermaid: "text/plain; charset=utf-8",
+	FormatSVG:     "image/svg+xml",
+}
+
+// Graph writes the dependency graph of the steps of the DAG in the format,
+// to embed it in documents and dashboards. The steps are colored by their
+// statuses in the run if it is not nil, like in the UI.
+func Graph(w io.Writer, format string, d *dag.DAG, st *model.Status) error {
+	nodes := model.FromSteps(d.Steps)
+	if st != nil {
+		nodes = st.Nodes
+	}
+	switch format {
+	case FormatDOT:
+		return writeDOT(w, d.Name, nodes)
+	case FormatMermaid:
+		return writeMermaid(w, nodes)
+	case FormatSVG:
+		return tmpl.ExecuteTemplate(w, "graph", buildGraph(nodes))
+	default:
+		return fmt.Errorf("%w: %s", errInvalidFormat, format)
+	}
+}
+
+func writeDOT(w io.Writer, name string, nodes []*model.Node) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(name))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\"];\n")
+	names := map[string]bool{}
+	for _, n := range nodes {
+		names[n.Name] = true
+		colors := nodeColors[n.Status]
+		fmt.Fprintf(&b, "  %s [fillcolor=%s, fontcolor=%s, tooltip=%s];\n",
+			dotQuote(n.Name), dotQuote(colors[0]), dotQuote(colors[1]), dotQuote(n.Name+": "+n.Status.String()))
+	}
+	for _, n := range nodes {
+		for _, dep := range n.Depends {
+			if names[dep] {
+				fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote(dep), dotQuote(n.Name))
+			}
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// writeMermaid writes the graph as a Mermaid flowchart. The IDs of the
+// nodes are their indexes, since the names of the steps may have any
+// character.
+func writeMermaid(w io.Writer, nodes []*model.Node) error {
+	ids := map[string]string{}
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	for i, n := range nodes {
+		ids[n.Name] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[n.Name], strings.ReplaceAll(n.Name, `"`, "#quot;"))
+	}
+	for _, n := range nodes {
+		for _, dep := range n.Depends {
+			if id, ok := ids[dep]; ok {
+				fmt.Fprintf(&b, "  %s --> %s\n", id, ids[n.Name])
+			}
+		}
+	}
+	for _, n := range nodes {
+		colors := nodeColors[n.Status]
+		fmt.Fprintf(&b, "  style %s fill:%s,color:%s\n", ids[n.Name], colors[0], colors[1])
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
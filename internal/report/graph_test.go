@@ -0,0 +1,52 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraph(t *testing.T) {
+	d := &dag.DAG{Name: "etl", Steps: []dag.Step{
+		{Name: "extract"},
+		{Name: `load "all"`, Depends: []string{"extract"}},
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, Graph(&buf, FormatDOT, d, nil))
+	require.Equal(t, `digraph "etl" {
+  rankdir=LR;
+  node [shape=box, style="rounded,filled"];
+  "extract" [fillcolor="lightblue", fontcolor="black", tooltip="extract: not started"];
+  "load \"all\"" [fillcolor="lightblue", fontcolor="black", tooltip="load \"all\": not started"];
+  "extract" -> "load \"all\"";
+}
+`, buf.String())
+
+	// The steps are colored by their statuses in the run.
+	st := &model.Status{Nodes: model.FromSteps(d.Steps)}
+	st.Nodes[0].Status = scheduler.NodeStatusSuccess
+	st.Nodes[1].Status = scheduler.NodeStatusError
+	buf.Reset()
+	require.NoError(t, Graph(&buf, FormatMermaid, d, st))
+	require.Equal(t, `graph LR
+  n0["extract"]
+  n1["load #quot;all#quot;"]
+  n0 --> n1
+  style n0 fill:green,color:white
+  style n1 fill:red,color:white
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, Graph(&buf, FormatSVG, d, st))
+	svg := buf.String()
+	require.True(t, bytes.HasPrefix(buf.Bytes(), []byte(`<svg xmlns="http://www.w3.org/2000/svg"`)))
+	require.Contains(t, svg, "extract: finished")
+	require.Contains(t, svg, `fill="red"`)
+
+	require.ErrorIs(t, Graph(&buf, "png", d, nil), errInvalidFormat)
+}
@@ -0,0 +1,237 @@
+package report
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"html/template"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/logfile"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/utils"
+)
+
+const (
+	// logExcerptLines is the number of the last lines of the log of each
+	// step in the report.
+	logExcerptLines = 20
+	// logExcerptBytes limits how much of the end of a log is read.
+	logExcerptBytes = 64 * 1024
+
+	boxWidth  = 160
+	boxHeight = 40
+	colGap    = 60
+	rowGap    = 20
+	margin    = 10
+)
+
+var (
+	//go:embed templates/report.html
+	reportTemplate string
+	//go:embed templates/graph.svg
+	graphTemplate string
+)
+
+var tmpl = template.Must(template.Must(template.New("report").Parse(reportTemplate)).Parse(graphTemplate))
+
+// nodeColors are the colors of the statuses of the steps, as in the UI.
+var nodeColors = map[scheduler.NodeStatus][2]string{
+	scheduler.NodeStatusNone:    {"lightblue", "black"},
+	scheduler.NodeStatusRunning: {"lime", "black"},
+	scheduler.NodeStatusError:   {"red", "white"},
+	scheduler.NodeStatusCancel:  {"pink", "black"},
+	scheduler.NodeStatusSuccess: {"green", "white"},
+	scheduler.NodeStatusSkipped: {"gray", "white"},
+	scheduler.NodeStatusTimeout: {"orangered", "white"},
+}
+
+type reportData struct {
+	DAG        *dag.DAG
+	Status     *model.Status
+	Duration   string
+	Graph      graph
+	Nodes      []nodeData
+	Handlers   []nodeData
+	ReportedAt string
+}
+
+type nodeData struct {
+	*model.Node
+	Duration   string
+	Command    string
+	Background string
+	Color      string
+	Log        string
+}
+
+type graph struct {
+	Width  int
+	Height int
+	Boxes  []box
+	Edges  []edge
+}
+
+type box struct {
+	X, Y, W, H int
+	Name       string
+	StatusText string
+	Background string
+	Color      string
+}
+
+type edge struct {
+	X1, Y1, X2, Y2 int
+}
+
+// Render writes a self-contained HTML report of the run of the DAG with
+// the graph of the steps, their durations and the ends of their logs.
+func Render(w io.Writer, d *dag.DAG, st *model.Status) error {
+	data := &reportData{
+		DAG:        d,
+		Status:     st,
+		Duration:   duration(st.StartedAt, st.FinishedAt),
+		Graph:      buildGraph(st.Nodes),
+		ReportedAt: utils.FormatTime(time.Now()),
+	}
+	for _, n := range st.Nodes {
+		data.Nodes = append(data.Nodes, newNodeData(n))
+	}
+	for _, n := range []*model.Node{st.OnSuccess, st.OnFailure, st.OnCancel, st.OnTimeout, st.OnExit} {
+		if n != nil && n.Status != scheduler.NodeStatusNone {
+			data.Handlers = append(data.Handlers, newNodeData(n))
+		}
+	}
+	return tmpl.Execute(w, data)
+}
+
+func newNodeData(n *model.Node) nodeData {
+	colors := nodeColors[n.Status]
+	command := n.CmdWithArgs
+	if command == "" {
+		command = strings.TrimSpace(n.Command + " " + strings.Join(n.Args, " "))
+	}
+	return nodeData{
+		Node:       n,
+		Duration:   duration(n.StartedAt, n.FinishedAt),
+		Command:    command,
+		Background: colors[0],
+		Color:      colors[1],
+		Log:        logExcerpt(n.Log),
+	}
+}
+
+func duration(startedAt, finishedAt string) string {
+	start, err := utils.ParseTime(startedAt)
+	if err != nil || start.IsZero() {
+		return "-"
+	}
+	end, err := utils.ParseTime(finishedAt)
+	if err != nil || end.IsZero() {
+		return "-"
+	}
+	return utils.FormatDuration(end.Sub(start).Round(time.Millisecond), "0s")
+}
+
+// buildGraph lays out the steps in columns by their depth in the graph.
+func buildGraph(nodes []*model.Node) graph {
+	depth := map[string]int{}
+	var depthOf func(n *model.Node, seen map[string]bool) int
+	byName := map[string]*model.Node{}
+	for _, n := range nodes {
+		byName[n.Name] = n
+	}
+	depthOf = func(n *model.Node, seen map[string]bool) int {
+		if d, ok := depth[n.Name]; ok {
+			return d
+		}
+		seen[n.Name] = true
+		d := 0
+		for _, dep := range n.Depends {
+			if parent, ok := byName[dep]; ok && !seen[dep] {
+				d = max(d, depthOf(parent, seen)+1)
+			}
+		}
+		depth[n.Name] = d
+		return d
+	}
+
+	var g graph
+	rows := map[int]int{}
+	pos := map[string]box{}
+	for _, n := range nodes {
+		col := depthOf(n, map[string]bool{})
+		colors := nodeColors[n.Status]
+		b := box{
+			X:          margin + col*(boxWidth+colGap),
+			Y:          margin + rows[col]*(boxHeight+rowGap),
+			W:          boxWidth,
+			H:          boxHeight,
+			Name:       n.Name,
+			StatusText: n.Status.String(),
+			Background: colors[0],
+			Color:      colors[1],
+		}
+		rows[col]++
+		pos[n.Name] = b
+		g.Boxes = append(g.Boxes, b)
+		g.Width = max(g.Width, b.X+boxWidth+margin)
+		g.Height = max(g.Height, b.Y+boxHeight+margin)
+	}
+	for _, n := range nodes {
+		to := pos[n.Name]
+		for _, dep := range n.Depends {
+			if from, ok := pos[dep]; ok {
+				g.Edges = append(g.Edges, edge{
+					X1: from.X + boxWidth, Y1: from.Y + boxHeight/2,
+					X2: to.X, Y2: to.Y + boxHeight/2,
+				})
+			}
+		}
+	}
+	return g
+}
+
+// logExcerpt returns the last lines of the log file.
+func logExcerpt(file string) string {
+	if file == "" {
+		return ""
+	}
+	r, err := logfile.Open(file)
+	if err != nil {
+		return ""
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	// A compressed log cannot be seeked. It is read and cut to the end.
+	if f, ok := r.(*os.File); ok {
+		if info, err := f.Stat(); err == nil && info.Size() > logExcerptBytes {
+			if _, err := f.Seek(-logExcerptBytes, io.SeekEnd); err != nil {
+				return ""
+			}
+		}
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return ""
+	}
+	if len(b) > logExcerptBytes {
+		b = b[len(b)-logExcerptBytes:]
+	}
+	var lines []string
+	s := bufio.NewScanner(bytes.NewReader(b))
+	s.Buffer(make([]byte, 0, 64*1024), logExcerptBytes)
+	for s.Scan() {
+		lines = append(lines, s.Text())
+	}
+	if len(lines) > logExcerptLines {
+		lines = lines[len(lines)-logExcerptLines:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,72 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "extract.log")
+	var lines []string
+	for i := 0; i < 30; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	lines[29] = "<done>"
+	require.NoError(t, os.WriteFile(logFile, []byte(strings.Join(lines, "\n")+"\n"), 0600))
+
+	d := &dag.DAG{Name: "etl", Description: "nightly ETL"}
+	st := &model.Status{
+		RequestId:  "request-1",
+		Name:       "etl",
+		StatusText: scheduler.StatusError.String(),
+		StartedAt:  "2024-01-02 03:04:05",
+		FinishedAt: "2024-01-02 03:05:05",
+		Params:     "DATE=2024-01-01",
+		Nodes: []*model.Node{
+			{Step: dag.Step{Name: "extract", CmdWithArgs: "./extract.sh"}, Status: scheduler.NodeStatusSuccess,
+				StartedAt: "2024-01-02 03:04:05", FinishedAt: "2024-01-02 03:04:35", Log: logFile},
+			{Step: dag.Step{Name: "load", Depends: []string{"extract"}}, Status: scheduler.NodeStatusError,
+				Error: "exit status 1"},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, d, st))
+	html := buf.String()
+	for _, s := range []string{"nightly ETL", "request-1", "1m0s", "30s", "DATE=2024-01-01", "./extract.sh", "exit status 1", "&lt;done&gt;"} {
+		require.Contains(t, html, s)
+	}
+	// Only the last lines of the log are included.
+	require.NotContains(t, html, "line 9\n")
+	require.Contains(t, html, "line 10\n")
+}
+
+func TestBuildGraph(t *testing.T) {
+	g := buildGraph([]*model.Node{
+		{Step: dag.Step{Name: "a"}},
+		{Step: dag.Step{Name: "b", Depends: []string{"a"}}},
+		{Step: dag.Step{Name: "c", Depends: []string{"a"}}},
+		{Step: dag.Step{Name: "d", Depends: []string{"b", "c"}}},
+	})
+	var pos []int
+	for _, b := range g.Boxes {
+		pos = append(pos, b.X, b.Y)
+	}
+	col, row := boxWidth+colGap, boxHeight+rowGap
+	require.Equal(t, []int{
+		margin, margin,
+		margin + col, margin,
+		margin + col, margin + row,
+		margin + 2*col, margin,
+	}, pos)
+	require.Len(t, g.Edges, 4)
+	require.Equal(t, margin+2*col+boxWidth+margin, g.Width)
+}
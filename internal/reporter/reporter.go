@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/logfile"
+	"github.com/dagu-dev/dagu/internal/persistence"
 	"github.com/dagu-dev/dagu/internal/persistence/model"
 	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/utils"
 	"github.com/jedib0t/go-pretty/v6/table"
 )
 
@@ -21,6 +25,9 @@ type Reporter struct {
 // Config is the configuration for the reporter.
 type Config struct {
 	Mailer Mailer
+	// AlertStore keeps the notification state of DAGs with an alert
+	// policy. The alert policy is ignored if it is nil.
+	AlertStore persistence.AlertStore
 }
 
 // Mailer is a mailer interface.
@@ -34,7 +41,7 @@ func (rp *Reporter) ReportStep(d *dag.DAG, status *model.Status, node *scheduler
 	if st != scheduler.NodeStatusNone {
 		log.Printf("%s %s", node.Step().Name, status.StatusText)
 	}
-	if st == scheduler.NodeStatusError && node.Step().MailOnError {
+	if (st == scheduler.NodeStatusError || st == scheduler.NodeStatusTimeout) && node.Step().MailOnError {
 		return rp.Mailer.SendMail(
 			d.ErrorMail.From,
 			[]string{d.ErrorMail.To},
@@ -60,15 +67,26 @@ func (rp *Reporter) ReportSummary(status *model.Status, err error) {
 
 // SendMail is a function that sends a report mail.
 func (rp *Reporter) SendMail(d *dag.DAG, status *model.Status, err error) error {
-	if err != nil || status.Status == scheduler.StatusError {
+	// An expired run did not fail, so it neither is alerted nor resolves
+	// an alert.
+	if status.Status == scheduler.StatusExpired {
+		if d.MailOn != nil && d.MailOn.Expired {
+			return rp.sendErrorMail(d, status, "")
+		}
+		return nil
+	}
+	failed := err != nil || status.Status == scheduler.StatusError
+	if d.AlertPolicy != nil && rp.AlertStore != nil {
+		if failed {
+			return rp.sendFailureAlert(d, status)
+		}
+		if status.Status == scheduler.StatusSuccess {
+			utils.LogErr("send resolved mail", rp.sendResolvedAlert(d, status))
+		}
+	}
+	if failed {
 		if d.MailOn != nil && d.MailOn.Failure {
-			return rp.Mailer.SendMail(
-				d.ErrorMail.From,
-				[]string{d.ErrorMail.To},
-				fmt.Sprintf("%s %s (%s)", d.ErrorMail.Prefix, d.Name, status.Status),
-				renderHTML(status.Nodes),
-				addAttachmentList(d.ErrorMail.AttachLogs, status.Nodes),
-			)
+			return rp.sendErrorMail(d, status, "")
 		}
 	} else if status.Status == scheduler.StatusSuccess {
 		if d.MailOn != nil && d.MailOn.Success {
@@ -84,6 +102,94 @@ func (rp *Reporter) SendMail(d *dag.DAG, status *model.Status, err error) error
 	return nil
 }
 
+// SendNotification mails the notification of a step of the DAG to the
+// address, from the address of the error mail of the DAG.
+func (rp *Reporter) SendNotification(d *dag.DAG, to, subject, body string) error {
+	var from, prefix string
+	if d.ErrorMail != nil {
+		from, prefix = d.ErrorMail.From, d.ErrorMail.Prefix
+	}
+	return rp.Mailer.SendMail(
+		from,
+		[]string{to},
+		fmt.Sprintf("%s %s (%s)", prefix, d.Name, subject),
+		body,
+		nil,
+	)
+}
+
+func (rp *Reporter) sendErrorMail(d *dag.DAG, status *model.Status, summary string) error {
+	subject := fmt.Sprintf("%s %s (%s)", d.ErrorMail.Prefix, d.Name, status.Status)
+	body := renderHTML(status.Nodes)
+	if summary != "" {
+		subject = fmt.Sprintf("%s %s (%s, %s)", d.ErrorMail.Prefix, d.Name, status.Status, summary)
+		body = fmt.Sprintf("<p>%s</p>%s", summary, body)
+	}
+	return rp.Mailer.SendMail(
+		d.ErrorMail.From,
+		[]string{d.ErrorMail.To},
+		subject,
+		body,
+		addAttachmentList(d.ErrorMail.AttachLogs, status.Nodes),
+	)
+}
+
+// sendFailureAlert sends the failure mail unless a failure of the DAG was
+// already notified within the window of the alert policy. The failures
+// suppressed in the meantime are counted in the next mail.
+func (rp *Reporter) sendFailureAlert(d *dag.DAG, status *model.Status) error {
+	alert, err := rp.AlertStore.Get(d.Name)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if alert == nil {
+		alert = &model.Alert{FirstFailureAt: now}
+	}
+	alert.Failures++
+	alert.Suppressed++
+	if alert.NotifiedAt.IsZero() || now.Sub(alert.NotifiedAt) >= d.AlertPolicy.Window {
+		if d.MailOn != nil && d.MailOn.Failure {
+			var summary string
+			if alert.Suppressed > 1 {
+				summary = fmt.Sprintf("%d failures since %s", alert.Suppressed, utils.FormatTime(alert.NotifiedAt))
+			}
+			if err := rp.sendErrorMail(d, status, summary); err != nil {
+				return err
+			}
+		}
+		alert.NotifiedAt = now
+		alert.Suppressed = 0
+	} else {
+		log.Printf("failure notification of %s suppressed (%d since %s)",
+			d.Name, alert.Suppressed, utils.FormatTime(alert.NotifiedAt))
+	}
+	return rp.AlertStore.Save(d.Name, alert)
+}
+
+// sendResolvedAlert clears the failure state of the DAG and sends a
+// resolved mail if the alert policy asks for it.
+func (rp *Reporter) sendResolvedAlert(d *dag.DAG, status *model.Status) error {
+	alert, err := rp.AlertStore.Get(d.Name)
+	if err != nil || alert == nil {
+		return err
+	}
+	if err := rp.AlertStore.Delete(d.Name); err != nil {
+		return err
+	}
+	if !d.AlertPolicy.NotifyResolved {
+		return nil
+	}
+	summary := fmt.Sprintf("%d failures since %s", alert.Failures, utils.FormatTime(alert.FirstFailureAt))
+	return rp.Mailer.SendMail(
+		d.ErrorMail.From,
+		[]string{d.ErrorMail.To},
+		fmt.Sprintf("%s %s (resolved after %s)", d.ErrorMail.Prefix, d.Name, summary),
+		fmt.Sprintf("<p>Resolved after %s.</p>%s", summary, renderHTML(status.Nodes)),
+		nil,
+	)
+}
+
 func renderSummary(status *model.Status, err error) string {
 	t := table.NewWriter()
 	var errText = ""
@@ -146,7 +252,7 @@ func renderHTML(nodes []*model.Node) string {
 	`)
 	addStatusFunc := func(status scheduler.NodeStatus) {
 		style := ""
-		if status == scheduler.NodeStatusError {
+		if status == scheduler.NodeStatusError || status == scheduler.NodeStatusTimeout {
 			style = "color: #D01117;font-weight:bold;"
 		}
 
@@ -170,7 +276,8 @@ func renderHTML(nodes []*model.Node) string {
 func addAttachmentList(trigger bool, nodes []*model.Node) (attachments []string) {
 	if trigger {
 		for _, n := range nodes {
-			attachments = append(attachments, n.Log)
+			// The log is attached as it is, compressed or not.
+			attachments = append(attachments, logfile.Find(n.Log))
 		}
 	}
 	return
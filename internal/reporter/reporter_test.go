@@ -28,6 +28,9 @@ func TestReporter(t *testing.T) {
 		"create node list":    testRenderTable,
 		"report summary":      testReportSummary,
 		"report step":         testReportStep,
+		"alert policy":        testAlertPolicy,
+		"expired mail":        testExpiredMail,
+		"notification mail":   testNotificationMail,
 	} {
 		t.Run(scenario, func(t *testing.T) {
 
@@ -94,6 +97,18 @@ func testErrorMail(t *testing.T, rp *Reporter, d *dag.DAG, nodes []*model.Node)
 	require.Equal(t, 1, mock.count)
 }
 
+func testNotificationMail(t *testing.T, rp *Reporter, d *dag.DAG, _ []*model.Node) {
+	require.NoError(t, rp.SendNotification(d, "oncall@mailer.com", "a dependency is unhealthy", "down"))
+
+	mock, ok := rp.Mailer.(*mockMailer)
+	require.True(t, ok)
+	require.Equal(t, "from@mailer.com", mock.from)
+	require.Equal(t, []string{"oncall@mailer.com"}, mock.to)
+	require.Equal(t, "Error:  test DAG (a dependency is unhealthy)", mock.subject)
+	require.Equal(t, "down", mock.body)
+	require.Equal(t, 1, mock.count)
+}
+
 func testNoErrorMail(t *testing.T, rp *Reporter, d *dag.DAG, nodes []*model.Node) {
 	d.MailOn.Failure = false
 	d.MailOn.Success = true
@@ -109,6 +124,21 @@ func testNoErrorMail(t *testing.T, rp *Reporter, d *dag.DAG, nodes []*model.Node
 	require.Equal(t, 0, mock.count)
 }
 
+func testExpiredMail(t *testing.T, rp *Reporter, d *dag.DAG, nodes []*model.Node) {
+	st := &model.Status{Status: scheduler.StatusExpired, Nodes: nodes}
+	mock, ok := rp.Mailer.(*mockMailer)
+	require.True(t, ok)
+
+	// An expired run is not a failure.
+	require.NoError(t, rp.SendMail(d, st, fmt.Errorf("expired")))
+	require.Equal(t, 0, mock.count)
+
+	d.MailOn.Expired = true
+	require.NoError(t, rp.SendMail(d, st, fmt.Errorf("expired")))
+	require.Equal(t, 1, mock.count)
+	require.Contains(t, mock.subject, "expired")
+}
+
 func testSuccessMail(t *testing.T, rp *Reporter, d *dag.DAG, nodes []*model.Node) {
 	d.MailOn.Failure = true
 	d.MailOn.Success = true
@@ -126,6 +156,44 @@ func testSuccessMail(t *testing.T, rp *Reporter, d *dag.DAG, nodes []*model.Node
 	require.Equal(t, 1, mock.count)
 }
 
+func testAlertPolicy(t *testing.T, rp *Reporter, d *dag.DAG, nodes []*model.Node) {
+	d.MailOn.Failure = true
+	d.MailOn.Success = false
+	d.AlertPolicy = &dag.AlertPolicy{Window: time.Hour, NotifyResolved: true}
+	store := &mockAlertStore{alerts: map[string]*model.Alert{}}
+	rp.AlertStore = store
+	mock, ok := rp.Mailer.(*mockMailer)
+	require.True(t, ok)
+
+	failed := &model.Status{Status: scheduler.StatusError, Nodes: nodes}
+
+	// The first failure is notified.
+	require.NoError(t, rp.SendMail(d, failed, nil))
+	require.Equal(t, 1, mock.count)
+
+	// The failures within the window are suppressed.
+	require.NoError(t, rp.SendMail(d, failed, nil))
+	require.Equal(t, 1, mock.count)
+	require.Equal(t, 1, store.alerts[d.Name].Suppressed)
+
+	// The next failure after the window reports the suppressed count.
+	store.alerts[d.Name].NotifiedAt = time.Now().Add(-time.Hour)
+	require.NoError(t, rp.SendMail(d, failed, nil))
+	require.Equal(t, 2, mock.count)
+	require.Contains(t, mock.subject, "2 failures since")
+	require.Equal(t, 0, store.alerts[d.Name].Suppressed)
+
+	// The recovery is notified once.
+	succeeded := &model.Status{Status: scheduler.StatusSuccess, Nodes: nodes}
+	require.NoError(t, rp.SendMail(d, succeeded, nil))
+	require.Equal(t, 3, mock.count)
+	require.Contains(t, mock.subject, "resolved after 3 failures")
+	require.Empty(t, store.alerts)
+
+	require.NoError(t, rp.SendMail(d, succeeded, nil))
+	require.Equal(t, 3, mock.count)
+}
+
 func testReportSummary(t *testing.T, rp *Reporter, d *dag.DAG, nodes []*model.Node) {
 	origStdout := os.Stdout
 	r, w, err := os.Pipe()
@@ -227,3 +295,21 @@ func (m *mockMailer) SendMail(from string, to []string, subject, body string, _
 	m.body = body
 	return nil
 }
+
+type mockAlertStore struct {
+	alerts map[string]*model.Alert
+}
+
+func (m *mockAlertStore) Get(id string) (*model.Alert, error) {
+	return m.alerts[id], nil
+}
+
+func (m *mockAlertStore) Save(id string, alert *model.Alert) error {
+	m.alerts[id] = alert
+	return nil
+}
+
+func (m *mockAlertStore) Delete(id string) error {
+	delete(m.alerts, id)
+	return nil
+}
@@ -0,0 +1,176 @@
+// Package runner runs a DAG in the current process without the history
+// store, the socket server or the scheduler process. It is used to run a
+// DAG as the entrypoint of a CI job or a container.
+package runner
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/dagu-dev/dagu/internal/agent"
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/secret"
+)
+
+// Config contains the configuration for a Runner.
+type Config struct {
+	DAG *dag.DAG
+
+	// Steps limits the run to the given steps.
+	Steps []string
+	// LogDir is the directory of the step logs. The log directory of the
+	// DAG is used if it is empty.
+	LogDir string
+	// Finder finds the DAGs run by sub workflow steps. Sub workflow steps
+	// fail if it is nil.
+	Finder dag.DAGFinder
+	// DataStoreFactory provides the pools, the step cache and the artifacts
+	// of the run. The one of the configuration is used if it is nil.
+	DataStoreFactory persistence.DataStoreFactory
+	// StepOutput is optional. If set, the output of the steps is also
+	// written to it, each line prefixed with the step name.
+	StepOutput io.Writer
+}
+
+// Runner runs a DAG in-process.
+type Runner struct {
+	*Config
+
+	// agent sets up the run like the runs of the agents, with the
+	// templates, the pools, the step cache and the guards of the steps.
+	agent     *agent.Agent
+	host      *model.Host
+	scheduler *scheduler.Scheduler
+	graph     *scheduler.ExecutionGraph
+	lock      sync.RWMutex
+}
+
+func New(config *Config) *Runner {
+	return &Runner{Config: config}
+}
+
+// Run runs the DAG and returns its final status. The error is the error of
+// the last failed step if the DAG did not succeed.
+func (r *Runner) Run(ctx context.Context) (*model.Status, error) {
+	if err := r.setup(); err != nil {
+		return nil, err
+	}
+	if err := r.DAG.LoadDotenv(); err != nil {
+		return nil, err
+	}
+	if err := secret.ResolveEnv(ctx); err != nil {
+		return nil, err
+	}
+	host := model.NewHost(ctx, r.DAG)
+	r.lock.Lock()
+	r.host = host
+	r.lock.Unlock()
+	if len(r.DAG.Preconditions) > 0 {
+		if err := dag.EvalConditions(r.DAG.Preconditions); err != nil {
+			r.scheduler.Cancel(r.graph)
+			return r.Status(), err
+		}
+	}
+	ctx = dag.NewContext(ctx, r.DAG, r.Finder)
+	err := r.scheduler.Schedule(ctx, r.graph, nil)
+	return r.Status(), err
+}
+
+// Signal sends the signal to the running steps.
+func (r *Runner) Signal(sig os.Signal) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if r.scheduler == nil {
+		return
+	}
+	log.Printf("Sending %s signal to running child processes.", sig)
+	r.scheduler.Signal(r.graph, sig, nil, false)
+}
+
+func (r *Runner) setup() (err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	ds := r.DataStoreFactory
+	if ds == nil {
+		ds = client.NewDataStoreFactory(config.Get())
+	}
+	r.agent = agent.New(&agent.Config{DAG: r.DAG, Steps: r.Steps, LogDir: r.LogDir}, nil, ds)
+	if r.scheduler, r.graph, err = r.agent.Setup(); err != nil {
+		return err
+	}
+	if r.StepOutput != nil {
+		r.scheduler.LogForwarder = &stepOutput{w: r.StepOutput}
+	}
+	return nil
+}
+
+// Status returns the current status of the run.
+func (r *Runner) Status() *model.Status {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	status := r.agent.Status()
+	// The run has no log of an agent.
+	status.Log = ""
+	status.Host = r.host
+	return status
+}
+
+// stepOutput writes the output of the steps to a single writer.
+type stepOutput struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+func (o *stepOutput) Writer(labels map[string]string) io.WriteCloser {
+	return &lineWriter{out: o, prefix: []byte("[" + labels["step"] + "] ")}
+}
+
+// lineWriter writes complete lines so that the output of parallel steps
+// does not get interleaved within a line.
+type lineWriter struct {
+	out    *stepOutput
+	prefix []byte
+	buf    []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := w.writeLine(w.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+func (w *lineWriter) Close() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.writeLine(append(w.buf, '\n'))
+	w.buf = nil
+	return err
+}
+
+func (w *lineWriter) writeLine(line []byte) error {
+	w.out.mu.Lock()
+	defer w.out.mu.Unlock()
+	_, err := w.out.w.Write(append(append([]byte{}, w.prefix...), line...))
+	return err
+}
@@ -0,0 +1,88 @@
+package runner_test
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/runner"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+func loadDAG(t *testing.T, data string) *dag.DAG {
+	t.Helper()
+	d, err := (&dag.Loader{}).LoadData([]byte(data))
+	require.NoError(t, err)
+	return d
+}
+
+func TestRun(t *testing.T) {
+	d := loadDAG(t, `name: test
+steps:
+  - name: "1"
+    command: echo hello
+  - name: "2"
+    command: echo world
+    depends: ["1"]
+handlerOn:
+  exit:
+    command: echo bye
+`)
+	var out bytes.Buffer
+	r := runner.New(&runner.Config{DAG: d, LogDir: t.TempDir(), StepOutput: &out})
+	status, err := r.Run(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, scheduler.StatusSuccess, status.Status)
+	require.NotEmpty(t, status.RequestId)
+	require.Len(t, status.Nodes, 2)
+	require.Equal(t, scheduler.NodeStatusSuccess, status.OnExit.Status)
+	require.Contains(t, out.String(), "[1] hello\n")
+	require.Contains(t, out.String(), "[2] world\n")
+}
+
+func TestRunSelectedSteps(t *testing.T) {
+	d := loadDAG(t, `name: test
+steps:
+  - name: "1"
+    command: "false"
+  - name: "2"
+    command: "true"
+`)
+	r := runner.New(&runner.Config{DAG: d, LogDir: t.TempDir(), Steps: []string{"2"}})
+	status, err := r.Run(context.Background())
+	require.NoError(t, err)
+	require.Len(t, status.Nodes, 1)
+
+	r = runner.New(&runner.Config{DAG: d, LogDir: t.TempDir()})
+	status, err = r.Run(context.Background())
+	require.Error(t, err)
+	require.Equal(t, scheduler.StatusError, status.Status)
+}
+
+func TestRunSetup(t *testing.T) {
+	tmpDir := t.TempDir()
+	d := loadDAG(t, `name: setup
+steps:
+  - name: "1"
+    command: echo {{ .Name }} {{ .RequestId }}
+`)
+	var out bytes.Buffer
+	r := runner.New(&runner.Config{
+		DAG:              d,
+		LogDir:           tmpDir,
+		DataStoreFactory: client.NewDataStoreFactory(&config.Config{DataDir: path.Join(tmpDir, "data")}),
+		StepOutput:       &out,
+	})
+	status, err := r.Run(context.Background())
+	require.NoError(t, err)
+
+	// The templates are rendered like the ones of the runs of the agents.
+	require.Equal(t, "[1] setup "+status.RequestId+"\n", out.String())
+	require.Empty(t, status.Log)
+}
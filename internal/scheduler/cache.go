@@ -0,0 +1,126 @@
+package scheduler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// CachedResult is the result of a successful run of a step with a cache
+// policy.
+type CachedResult struct {
+	RequestId string    `json:"RequestId"`
+	CachedAt  time.Time `json:"CachedAt"`
+	// Output is the captured output of the step.
+	Output string `json:"Output,omitempty"`
+	// StepOutput is the structured output of the step, if any.
+	StepOutput *string `json:"StepOutput,omitempty"`
+}
+
+// StepCache stores the results of the steps by cache key.
+type StepCache interface {
+	// Get returns the result of the key. The error is fs.ErrNotExist if
+	// there is none.
+	Get(key string) (*CachedResult, error)
+	Put(key string, r *CachedResult) error
+}
+
+// cacheKey returns the hash of what the result of the step depends on: the
+// command and the script with the variables expanded, the parameters of
+// the run, the key of the cache policy and the content of the inputs.
+func (sc *Scheduler) cacheKey(n *Node) (string, error) {
+	step := n.step
+	h := sha256.New()
+	write := func(values ...string) {
+		for _, v := range values {
+			_, _ = io.WriteString(h, v)
+			_, _ = h.Write([]byte{0})
+		}
+	}
+	write(step.Name, step.Dir, os.ExpandEnv(step.CmdWithArgs), os.ExpandEnv(step.Command))
+	for _, arg := range step.Args {
+		write(os.ExpandEnv(arg))
+	}
+	write(n.expandOutputRefs(step.Script), sc.Params, os.ExpandEnv(step.Cache.Key))
+	for _, in := range step.Inputs {
+		f, err := os.Open(inputPath(&step, in))
+		if err != nil {
+			return "", err
+		}
+		write(in.Ref)
+		_, err = io.Copy(h, f)
+		_ = f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// restoreCache restores the result of the node from the cache. It returns
+// false if the node has to run.
+func (sc *Scheduler) restoreCache(n *Node) bool {
+	key, err := sc.cacheKey(n)
+	if err != nil {
+		log.Printf("%s: failed to compute the cache key: %s", n.step.Name, err)
+		return false
+	}
+	n.cacheKey = key
+	if sc.NoCache {
+		return false
+	}
+	r, err := sc.StepCache.Get(key)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Printf("%s: failed to read the cache: %s", n.step.Name, err)
+		}
+		return false
+	}
+	if maxAge := n.step.Cache.MaxAge; maxAge > 0 && time.Since(r.CachedAt) > maxAge {
+		return false
+	}
+	log.Printf("%s: reusing the result of %s cached at %s", n.step.Name, r.RequestId, r.CachedAt.Format(time.RFC3339))
+	if n.logWriter != nil {
+		_, _ = fmt.Fprintf(n.logStream(streamStdout), "reused the result of %s cached at %s\n", r.RequestId, r.CachedAt.Format(time.RFC3339))
+	}
+	if n.step.Output != "" || n.step.Generator {
+		n.setOutput(r.Output)
+	}
+	if r.StepOutput != nil {
+		n.setStepOutput(*r.StepOutput, true)
+	}
+	return true
+}
+
+// saveCache saves the result of the node that ran successfully.
+func (sc *Scheduler) saveCache(n *Node) {
+	if n.cacheKey == "" {
+		return
+	}
+	r := &CachedResult{RequestId: sc.RequestId, CachedAt: time.Now()}
+	if n.step.Output != "" && n.step.OutputVariables != nil {
+		if v, ok := n.step.OutputVariables.Load(n.step.Output); ok {
+			r.Output = strings.TrimPrefix(v.(string), n.step.Output+"=")
+		}
+	} else if n.step.Generator {
+		n.mu.RLock()
+		r.Output = n.genOutput
+		n.mu.RUnlock()
+	}
+	if n.step.StepOutputs != nil {
+		if v, ok := n.step.StepOutputs.Load(n.step.Name); ok {
+			out := v.(string)
+			r.StepOutput = &out
+		}
+	}
+	if err := sc.StepCache.Put(n.cacheKey, r); err != nil {
+		log.Printf("%s: failed to save the cache: %s", n.step.Name, err)
+	}
+}
@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+)
+
+// errExpectedFiles is the error of a step whose command exited 0 without
+// the files it is expected to write.
+var errExpectedFiles = errors.New("expected files are missing")
+
+// checkExpectedFiles checks the files the step must have written once its
+// command exited 0 at the start time. It returns all the problems, so that
+// the operators see every missing output at once.
+func (n *Node) checkExpectedFiles(started time.Time) error {
+	var problems []string
+	for _, e := range n.step.ExpectedFiles {
+		problems = append(problems, n.checkExpectedFile(e, started)...)
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", errExpectedFiles, strings.Join(problems, "; "))
+}
+
+func (n *Node) checkExpectedFile(e dag.ExpectedFile, started time.Time) []string {
+	pattern := os.ExpandEnv(e.Path)
+	if !filepath.IsAbs(pattern) && n.step.Dir != "" {
+		pattern = filepath.Join(n.step.Dir, pattern)
+	}
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return []string{fmt.Sprintf("%s: %s", pattern, err)}
+	}
+	var problems []string
+	count := 0
+	for _, m := range matches {
+		fi, err := os.Stat(m)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", m, err))
+			continue
+		}
+		if fi.IsDir() {
+			continue
+		}
+		count++
+		if fi.Size() < e.MinSize {
+			problems = append(problems, fmt.Sprintf("%s is %d bytes, less than the minSize of %d bytes", m, fi.Size(), e.MinSize))
+		}
+		// The modification times of some file systems are in seconds.
+		if e.Fresh && fi.ModTime().Before(started.Truncate(time.Second)) {
+			problems = append(problems, fmt.Sprintf("%s was not modified by the step, it was last modified at %s", m, fi.ModTime().Format(time.RFC3339)))
+		}
+	}
+	minCount := max(e.MinCount, 1)
+	switch {
+	case count == 0:
+		problems = append(problems, fmt.Sprintf("%s does not exist", pattern))
+	case count < minCount:
+		problems = append(problems, fmt.Sprintf("%s matched %d files, less than the minCount of %d", pattern, count, minCount))
+	}
+	return problems
+}
@@ -0,0 +1,182 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// The kinds of the faults injected into the steps of a run.
+const (
+	FaultFail  = "fail"
+	FaultDelay = "delay"
+	FaultKill  = "kill"
+)
+
+var (
+	errInvalidFault  = errors.New("invalid fault")
+	errFaultCanceled = errors.New("the run was canceled during the injected delay")
+)
+
+// Fault is a failure injected into a step of a run, so that its retry
+// policy, the handlers and the notifications are tested before they are
+// relied on. A fail fault fails the step without running its command, a
+// delay fault delays its command and a kill fault kills the agent when the
+// step starts, as if the host went down.
+type Fault struct {
+	Kind string
+	Step string
+	// ExitCode is the exit code of the failure of a fail fault, 1 by
+	// default, which the exit codes of the retry policy are matched with.
+	ExitCode int
+	// Attempts is the number of the first attempts of the step a fail
+	// fault fails, all of them if it is zero.
+	Attempts int
+	// Delay is how long a delay fault delays the command of the step.
+	Delay time.Duration
+}
+
+// ParseFault parses a fault of the form fail=<step>[:<exit code>[:<attempts>]],
+// delay=<step>:<duration> or kill=<step>.
+func ParseFault(s string) (Fault, error) {
+	kind, rest, ok := strings.Cut(s, "=")
+	if !ok || rest == "" {
+		return Fault{}, fmt.Errorf("%w: %s", errInvalidFault, s)
+	}
+	f := Fault{Kind: kind}
+	parts := strings.Split(rest, ":")
+	f.Step = parts[0]
+	args := parts[1:]
+	var err error
+	switch kind {
+	case FaultFail:
+		if len(args) > 2 {
+			return Fault{}, fmt.Errorf("%w: %s", errInvalidFault, s)
+		}
+		f.ExitCode = 1
+		if len(args) > 0 {
+			if f.ExitCode, err = strconv.Atoi(args[0]); err != nil || f.ExitCode < 1 || f.ExitCode > 255 {
+				return Fault{}, fmt.Errorf("%w: exit code of %s", errInvalidFault, s)
+			}
+		}
+		if len(args) > 1 {
+			if f.Attempts, err = strconv.Atoi(args[1]); err != nil || f.Attempts < 1 {
+				return Fault{}, fmt.Errorf("%w: attempts of %s", errInvalidFault, s)
+			}
+		}
+	case FaultDelay:
+		if len(args) != 1 {
+			return Fault{}, fmt.Errorf("%w: %s", errInvalidFault, s)
+		}
+		if f.Delay, err = time.ParseDuration(args[0]); err != nil || f.Delay <= 0 {
+			return Fault{}, fmt.Errorf("%w: delay of %s", errInvalidFault, s)
+		}
+	case FaultKill:
+		if len(args) > 0 {
+			return Fault{}, fmt.Errorf("%w: %s", errInvalidFault, s)
+		}
+	default:
+		return Fault{}, fmt.Errorf("%w: unknown kind %q", errInvalidFault, kind)
+	}
+	return f, nil
+}
+
+// ParseFaults parses the faults of the specs.
+func ParseFaults(specs []string) ([]Fault, error) {
+	var faults []Fault
+	for _, s := range specs {
+		f, err := ParseFault(s)
+		if err != nil {
+			return nil, err
+		}
+		faults = append(faults, f)
+	}
+	return faults, nil
+}
+
+// faultError is the error of a step failed by a fail fault. It has the exit
+// code of the fault, as the error of a command that exited with it.
+type faultError struct {
+	code int
+}
+
+func (e *faultError) Error() string {
+	return fmt.Sprintf("injected failure: exit status %d", e.code)
+}
+
+func (e *faultError) ExitCode() int {
+	return e.code
+}
+
+// killAgent kills the process of the agent. It is replaced in the tests.
+var killAgent = func() {
+	_ = syscall.Kill(os.Getpid(), syscall.SIGKILL)
+}
+
+// injectFaults applies the faults of the step of the node before its
+// command runs. It returns the error of the step if a fault fails it. The
+// delay of a handler does not end when the run is canceled, as the handler
+// itself.
+func (sc *Scheduler) injectFaults(ctx context.Context, node *Node, handler bool) error {
+	for _, f := range sc.Faults {
+		if f.Step != node.step.Name {
+			continue
+		}
+		switch f.Kind {
+		case FaultKill:
+			log.Printf("injected fault: killing the agent at \"%s\"", node.step.Name)
+			killAgent()
+		case FaultDelay:
+			log.Printf("injected fault: delaying \"%s\" by %s", node.step.Name, f.Delay)
+			if err := sc.delayNode(ctx, node, f.Delay, !handler); err != nil {
+				node.SetError(err)
+				return err
+			}
+		case FaultFail:
+			if f.Attempts > 0 && node.getRetryCount() >= f.Attempts {
+				continue
+			}
+			log.Printf("injected fault: failing \"%s\" with exit code %d", node.step.Name, f.ExitCode)
+			err := &faultError{code: f.ExitCode}
+			node.SetError(err)
+			return err
+		}
+	}
+	return nil
+}
+
+// delayNode waits for the delay before the command of the node runs. The
+// step times out if its timeout is shorter than the delay, and the wait
+// ends when the run is canceled if it is cancelable.
+func (sc *Scheduler) delayNode(ctx context.Context, node *Node, delay time.Duration, cancelable bool) error {
+	timeout := node.step.Timeout
+	timedOut := timeout > 0 && timeout < delay
+	if timedOut {
+		delay = timeout
+	}
+	deadline := time.NewTimer(delay)
+	defer deadline.Stop()
+	ticker := time.NewTicker(sc.pause)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-deadline.C:
+			if timedOut {
+				return fmt.Errorf("%w after %s", errStepTimeout, timeout)
+			}
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if cancelable && sc.isCanceled() {
+				return errFaultCanceled
+			}
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dagu-dev/dagu/internal/executor"
+	"github.com/dagu-dev/dagu/internal/utils"
+)
+
+const (
+	foreachItemEnv  = "ITEM"
+	foreachIndexEnv = "ITEM_INDEX"
+)
+
+var errForeachFailed = errors.New("foreach instances failed")
+
+// foreachItems returns the items the step is expanded over. Items given as
+// an expression are expanded with the environment and parsed either as a
+// JSON array or as whitespace-separated values.
+func foreachItems(n *Node) ([]string, error) {
+	f := n.step.Foreach
+	if f.ItemsExpr == "" {
+		return f.Items, nil
+	}
+	expr := strings.TrimSpace(os.ExpandEnv(f.ItemsExpr))
+	if strings.HasPrefix(expr, "[") {
+		var vals []any
+		if err := json.Unmarshal([]byte(expr), &vals); err != nil {
+			return nil, fmt.Errorf("failed to parse foreach items %q: %w", expr, err)
+		}
+		items := make([]string, 0, len(vals))
+		for _, v := range vals {
+			if s, ok := v.(string); ok {
+				items = append(items, s)
+				continue
+			}
+			b, _ := json.Marshal(v)
+			items = append(items, string(b))
+		}
+		return items, nil
+	}
+	return strings.Fields(expr), nil
+}
+
+// executeForeach runs one instance of the step per item with at most
+// MaxParallel instances running at the same time. The step fails if any
+// of the instances fails.
+func (n *Node) executeForeach(ctx context.Context) error {
+	items, err := foreachItems(n)
+	if err != nil {
+		n.SetError(err)
+		return err
+	}
+
+	n.mu.Lock()
+	ctx, fn := context.WithCancel(ctx)
+	n.cancelFunc = fn
+	n.foreachCmds = nil
+	n.mu.Unlock()
+
+	maxParallel := n.step.Foreach.MaxParallel
+	if maxParallel <= 0 || maxParallel > len(items) {
+		maxParallel = len(items)
+	}
+	sem := make(chan struct{}, maxParallel)
+
+	var writers []io.Writer
+	if n.logWriter != nil {
+		writers = append(writers, n.mask(n.logStream(streamStdout)))
+	}
+	if n.stdoutWriter != nil {
+		writers = append(writers, n.mask(n.stdoutWriter))
+	}
+	logOut := &syncWriter{w: io.MultiWriter(writers...)}
+	outputs := make([]string, len(items))
+	errs := make([]error, len(items))
+
+	var wg sync.WaitGroup
+	for i, item := range items {
+		wg.Add(1)
+		go func(i int, item string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if ctx.Err() != nil {
+				errs[i] = ctx.Err()
+				return
+			}
+			outputs[i], errs[i] = n.runForeachInstance(ctx, i, item, logOut)
+		}(i, item)
+	}
+	wg.Wait()
+
+	var failed []string
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", items[i], err))
+		}
+	}
+	_, _ = fmt.Fprintf(logOut, "foreach: %d succeeded, %d failed\n", len(items)-len(failed), len(failed))
+	n.closeMasks()
+
+	if n.step.Output != "" {
+		b, _ := json.Marshal(outputs)
+		ret := string(b)
+		_ = os.Setenv(n.step.Output, ret)
+		n.step.OutputVariables.Store(n.step.Output, fmt.Sprintf("%s=%s", n.step.Output, ret))
+	}
+
+	if len(failed) > 0 {
+		err := fmt.Errorf("%w: %s", errForeachFailed, strings.Join(failed, "; "))
+		n.SetError(err)
+		return err
+	}
+	n.SetError(nil)
+	return nil
+}
+
+func (n *Node) runForeachInstance(ctx context.Context, idx int, item string, logOut io.Writer) (string, error) {
+	step := n.Step()
+	index := strconv.Itoa(idx)
+	expand := func(s string) string {
+		return os.Expand(s, func(key string) string {
+			switch key {
+			case foreachItemEnv:
+				return item
+			case foreachIndexEnv:
+				return index
+			default:
+				return "${" + key + "}"
+			}
+		})
+	}
+	step.Variables = append(append([]string{}, step.Variables...),
+		fmt.Sprintf("%s=%s", foreachItemEnv, item),
+		fmt.Sprintf("%s=%s", foreachIndexEnv, index),
+	)
+	if step.CmdWithArgs != "" {
+		step.Command, step.Args = utils.SplitCommand(expand(step.CmdWithArgs), true)
+	} else {
+		args := make([]string, len(step.Args))
+		for i, arg := range step.Args {
+			args[i] = os.ExpandEnv(expand(arg))
+		}
+		step.Args = args
+	}
+	if n.scriptFile != nil {
+		step.Args = append(step.Args, n.scriptFile.Name())
+	}
+
+	step, err := prepareStep(ctx, step)
+	if err != nil {
+		return "", err
+	}
+	cmd, err := executor.CreateExecutor(ctx, step)
+	if err != nil {
+		return "", err
+	}
+	stdin, err := setStdin(cmd, step)
+	if err != nil {
+		return "", err
+	}
+	if stdin != nil {
+		defer func() {
+			utils.LogErr("close stdin", stdin.Close())
+		}()
+	}
+	n.mu.Lock()
+	n.foreachCmds = append(n.foreachCmds, cmd)
+	n.mu.Unlock()
+
+	var buf bytes.Buffer
+	out := &prefixWriter{prefix: fmt.Sprintf("[%s] ", item), w: logOut}
+	var stdout io.Writer = out
+	if step.Output != "" {
+		stdout = io.MultiWriter(out, &buf)
+	}
+	cmd.SetStdout(stdout)
+	cmd.SetStderr(out)
+	err = cmd.Run()
+	_ = out.Close()
+	return strings.TrimSpace(buf.String()), err
+}
+
+// syncWriter serializes writes from parallel instances.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
+
+// prefixWriter writes complete lines with a prefix so that the output of
+// parallel instances does not get interleaved within a line.
+type prefixWriter struct {
+	prefix string
+	w      io.Writer
+	mu     sync.Mutex
+	buf    []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if _, err := w.w.Write(append([]byte(w.prefix), w.buf[:i+1]...)); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+func (w *prefixWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) == 0 {
+		return nil
+	}
+	_, err := w.w.Write(append([]byte(w.prefix), append(w.buf, '\n')...))
+	w.buf = nil
+	return err
+}
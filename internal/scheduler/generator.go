@@ -0,0 +1,28 @@
+package scheduler
+
+import (
+	"log"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+)
+
+// generateSteps expands the output of a generator node into steps and adds
+// them to the graph.
+func (sc *Scheduler) generateSteps(g *ExecutionGraph, node *Node) error {
+	node.mu.RLock()
+	output := node.genOutput
+	node.mu.RUnlock()
+
+	steps, err := dag.BuildGeneratedSteps(node.step.Variables, output)
+	if err != nil {
+		return err
+	}
+	if len(steps) == 0 {
+		return nil
+	}
+	if err := g.addGeneratedSteps(node, steps); err != nil {
+		return err
+	}
+	log.Printf("%s generated %d steps", node.step.Name, len(steps))
+	return nil
+}
@@ -8,7 +8,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dagu-dev/dagu/internal/clock"
 	"github.com/dagu-dev/dagu/internal/dag"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
 	"github.com/dagu-dev/dagu/internal/utils"
 )
 
@@ -17,22 +19,27 @@ type ExecutionGraph struct {
 	startedAt       time.Time
 	finishedAt      time.Time
 	outputVariables *utils.SyncMap
+	stepOutputs     *utils.SyncMap
 	dict            map[int]*Node
 	nodes           []*Node
 	from            map[int][]int
 	to              map[int][]int
 	mu              sync.RWMutex
+	// clock is the clock the execution started with.
+	clock clock.Clock
 }
 
 var (
 	errCycleDetected = errors.New("cycle detected")
 	errStepNotFound  = errors.New("step not found")
+	errDuplicateStep = errors.New("duplicate step name")
 )
 
 // NewExecutionGraph creates a new execution graph with the given steps.
 func NewExecutionGraph(steps ...dag.Step) (*ExecutionGraph, error) {
 	graph := &ExecutionGraph{
 		outputVariables: &utils.SyncMap{},
+		stepOutputs:     &utils.SyncMap{},
 		dict:            make(map[int]*Node),
 		from:            make(map[int][]int),
 		to:              make(map[int][]int),
@@ -40,13 +47,15 @@ func NewExecutionGraph(steps ...dag.Step) (*ExecutionGraph, error) {
 	}
 	for _, step := range steps {
 		step.OutputVariables = graph.outputVariables
+		step.StepOutputs = graph.stepOutputs
 		node := &Node{step: step}
 		node.init()
 		graph.dict[node.id] = node
 		graph.nodes = append(graph.nodes, node)
 	}
+	// The steps of a DAG whose graph cannot be set up are not valid.
 	if err := graph.setup(); err != nil {
-		return nil, err
+		return nil, dagerrors.WithCode(dagerrors.CodeInvalidDAG, err)
 	}
 	return graph, nil
 }
@@ -55,6 +64,7 @@ func NewExecutionGraph(steps ...dag.Step) (*ExecutionGraph, error) {
 func NewExecutionGraphForRetry(nodes ...*Node) (*ExecutionGraph, error) {
 	graph := &ExecutionGraph{
 		outputVariables: &utils.SyncMap{},
+		stepOutputs:     &utils.SyncMap{},
 		dict:            make(map[int]*Node),
 		from:            make(map[int][]int),
 		to:              make(map[int][]int),
@@ -80,7 +90,14 @@ func NewExecutionGraphForRetry(nodes ...*Node) (*ExecutionGraph, error) {
 				return true
 			})
 		}
+		if node.step.StepOutputs != nil {
+			node.step.StepOutputs.Range(func(key, value any) bool {
+				graph.stepOutputs.Store(key, value)
+				return true
+			})
+		}
 		node.step.OutputVariables = graph.outputVariables
+		node.step.StepOutputs = graph.stepOutputs
 		node.init()
 		graph.dict[node.id] = node
 		graph.nodes = append(graph.nodes, node)
@@ -99,7 +116,7 @@ func (g *ExecutionGraph) Duration() time.Duration {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 	if g.finishedAt.IsZero() {
-		return time.Since(g.startedAt)
+		return clock.OrDefault(g.clock).Now().Sub(g.startedAt)
 	}
 	return g.finishedAt.Sub(g.startedAt)
 }
@@ -125,7 +142,7 @@ func (g *ExecutionGraph) StartAt() time.Time {
 func (g *ExecutionGraph) IsRunning() bool {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
-	for _, node := range g.Nodes() {
+	for _, node := range g.nodes {
 		if node.State().Status == NodeStatusRunning {
 			return true
 		}
@@ -142,24 +159,97 @@ func (g *ExecutionGraph) FinishAt() time.Time {
 func (g *ExecutionGraph) Finish() {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	g.finishedAt = time.Now()
+	g.finishedAt = clock.OrDefault(g.clock).Now()
 }
 
-func (g *ExecutionGraph) Start() {
+// Start records the start of the execution with the clock, the clock of
+// the process if it is nil.
+func (g *ExecutionGraph) Start(c clock.Clock) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	g.startedAt = time.Now()
+	g.clock = c
+	g.startedAt = clock.OrDefault(c).Now()
 }
 
 // Nodes returns the nodes of the execution graph.
 func (g *ExecutionGraph) Nodes() []*Node {
-	return g.nodes
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return append([]*Node{}, g.nodes...)
 }
 
 func (g *ExecutionGraph) node(id int) *Node {
 	return g.dict[id]
 }
 
+// upstream returns the nodes the given node depends on.
+func (g *ExecutionGraph) upstream(node *Node) []*Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	var ret []*Node
+	for _, id := range g.to[node.id] {
+		ret = append(ret, g.node(id))
+	}
+	return ret
+}
+
+// addGeneratedSteps adds the steps generated by the parent node to the
+// graph. Generated steps without dependencies depend on the parent, and the
+// steps that depended on the parent also wait for all the generated steps.
+func (g *ExecutionGraph) addGeneratedSteps(parent *Node, steps []dag.Step) error {
+	parentName := parent.step.Name
+	for i := range steps {
+		var deps []string
+		for _, dep := range steps[i].Depends {
+			if dep != parentName {
+				deps = append(deps, dep)
+			}
+		}
+		steps[i].Depends = deps
+	}
+	// Build the generated steps as a separate graph first to validate the
+	// dependencies between them.
+	sub, err := NewExecutionGraph(steps...)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, node := range sub.nodes {
+		if _, err := g.findStep(node.step.Name); err == nil {
+			return fmt.Errorf("%w: %s", errDuplicateStep, node.step.Name)
+		}
+	}
+
+	children := g.from[parent.id]
+	for _, node := range sub.nodes {
+		node.step.OutputVariables = g.outputVariables
+		node.step.StepOutputs = g.stepOutputs
+		g.dict[node.id] = node
+		g.nodes = append(g.nodes, node)
+		for _, id := range sub.to[node.id] {
+			g.addEdge(sub.dict[id], node)
+		}
+		if len(node.step.Depends) == 0 {
+			node.step.Depends = []string{parentName}
+			g.addEdge(parent, node)
+		}
+		if len(sub.from[node.id]) > 0 {
+			continue
+		}
+		for _, id := range children {
+			child := g.dict[id]
+			child.mu.Lock()
+			child.step.Depends = append(child.step.Depends, node.step.Name)
+			child.mu.Unlock()
+			g.addEdge(node, child)
+		}
+	}
+	return nil
+}
+
 func (g *ExecutionGraph) setupRetry() error {
 	dict := map[int]NodeStatus{}
 	retry := map[int]bool{}
@@ -176,7 +266,7 @@ func (g *ExecutionGraph) setupRetry() error {
 	for len(frontier) > 0 {
 		var next []int
 		for _, u := range frontier {
-			if retry[u] || dict[u] == NodeStatusError || dict[u] == NodeStatusCancel {
+			if retry[u] || dict[u] == NodeStatusError || dict[u] == NodeStatusTimeout || dict[u] == NodeStatusCancel {
 				log.Printf("clear node state: %s", g.dict[u].step.Name)
 				g.dict[u].clearState()
 				retry[u] = true
@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+)
+
+// errGuardExceeded is the error of a step that is not run because the
+// expression of its guard is false, e.g. when a budget is exhausted.
+var errGuardExceeded = errors.New("guard exceeded")
+
+// checkGuard checks the guard of the node before it starts. It returns
+// false if the node is skipped or failed instead. A guard that cannot be
+// checked fails the node regardless of the policy, so that a step is not
+// run when its budget is unknown.
+func (sc *Scheduler) checkGuard(ctx context.Context, n *Node) bool {
+	g := n.step.Guard
+	log.Printf("checking guard of \"%s\"", n.step.Name)
+	value, ok, err := g.Check(ctx)
+	if err != nil {
+		log.Printf("%s", err.Error())
+		sc.lastError = err
+		n.setErr(err)
+		return false
+	}
+	if ok {
+		return true
+	}
+	err = fmt.Errorf("%w: %s is false with %s=%s", errGuardExceeded, g.Expr, dag.GuardVariable, value)
+	log.Printf("%s: %s", n.step.Name, err.Error())
+	if g.OnExceeded == dag.GuardSkip {
+		n.setStatus(NodeStatusSkipped)
+		n.SetError(err)
+		return false
+	}
+	sc.lastError = err
+	n.setErr(err)
+	return false
+}
@@ -0,0 +1,141 @@
+package scheduler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+)
+
+// Streams of the lines of the step logs.
+const (
+	streamStdout = "stdout"
+	streamStderr = "stderr"
+)
+
+// LogRecord is a line of a step log in the JSON format.
+type LogRecord struct {
+	Time      time.Time `json:"time"`
+	Stream    string    `json:"stream"`
+	DAG       string    `json:"dag"`
+	Step      string    `json:"step"`
+	RequestId string    `json:"requestId"`
+	Message   string    `json:"message"`
+}
+
+// PlainLog renders a step log in the JSON format as plain text, one message
+// per line. The lines that are not records are kept as they are.
+func PlainLog(data []byte) []byte {
+	var b bytes.Buffer
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		var r LogRecord
+		if err := json.Unmarshal(line, &r); err != nil || r.Stream == "" {
+			b.Write(line)
+			continue
+		}
+		b.WriteString(r.Message)
+		b.WriteByte('\n')
+	}
+	return b.Bytes()
+}
+
+func (sc *Scheduler) setupLogFormat(node *Node) {
+	if sc.LogFormat != dag.LogFormatJSON {
+		return
+	}
+	node.logRecord = &LogRecord{DAG: sc.DAGName, Step: node.step.Name, RequestId: sc.RequestId}
+}
+
+// jsonLog writes the lines written to its streams as records to the log of
+// a node.
+type jsonLog struct {
+	mu      sync.Mutex
+	w       *bufio.Writer
+	record  LogRecord
+	streams map[string]*jsonLogStream
+}
+
+func newJSONLog(w *bufio.Writer, record LogRecord) *jsonLog {
+	return &jsonLog{w: w, record: record, streams: make(map[string]*jsonLogStream)}
+}
+
+func (l *jsonLog) stream(name string) io.Writer {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s, ok := l.streams[name]
+	if !ok {
+		s = &jsonLogStream{log: l, name: name}
+		l.streams[name] = s
+	}
+	return s
+}
+
+// close writes the last lines of the streams that do not end with a
+// newline.
+func (l *jsonLog) close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var lastErr error
+	for _, s := range l.streams {
+		if len(s.buf) == 0 {
+			continue
+		}
+		if err := l.write(s.name, s.buf); err != nil {
+			lastErr = err
+		}
+		s.buf = nil
+	}
+	return lastErr
+}
+
+func (l *jsonLog) write(stream string, line []byte) error {
+	r := l.record
+	r.Time = time.Now()
+	r.Stream = stream
+	r.Message = strings.TrimSuffix(string(line), "\r")
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	_, err = l.w.Write(append(b, '\n'))
+	return err
+}
+
+type jsonLogStream struct {
+	log  *jsonLog
+	name string
+	// buf is the line that is not terminated yet.
+	buf []byte
+}
+
+func (s *jsonLogStream) Write(p []byte) (int, error) {
+	s.log.mu.Lock()
+	defer s.log.mu.Unlock()
+	s.buf = append(s.buf, p...)
+	for {
+		i := bytes.IndexByte(s.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := s.log.write(s.name, s.buf[:i]); err != nil {
+			return 0, err
+		}
+		s.buf = append(s.buf[:0], s.buf[i+1:]...)
+	}
+	return len(p), nil
+}
+
+// Flush flushes the log so that the records are not delayed.
+func (s *jsonLogStream) Flush() error {
+	s.log.mu.Lock()
+	defer s.log.mu.Unlock()
+	return s.log.w.Flush()
+}
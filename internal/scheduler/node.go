@@ -9,12 +9,16 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/dagu-dev/dagu/internal/clock"
 	"github.com/dagu-dev/dagu/internal/dag"
 	"github.com/dagu-dev/dagu/internal/executor"
+	"github.com/dagu-dev/dagu/internal/logfile"
+	"github.com/dagu-dev/dagu/internal/secret"
 	"github.com/dagu-dev/dagu/internal/utils"
 	"golang.org/x/sys/unix"
 )
@@ -28,8 +32,12 @@ const (
 	NodeStatusCancel
 	NodeStatusSuccess
 	NodeStatusSkipped
+	NodeStatusTimeout
 )
 
+// errStepTimeout is the error of a step that ran longer than its timeout.
+var errStepTimeout = fmt.Errorf("step timed out")
+
 func (s NodeStatus) String() string {
 	switch s {
 	case NodeStatusRunning:
@@ -42,6 +50,8 @@ func (s NodeStatus) String() string {
 		return "finished"
 	case NodeStatusSkipped:
 		return "skipped"
+	case NodeStatusTimeout:
+		return "timed out"
 	case NodeStatusNone:
 		fallthrough
 	default:
@@ -58,21 +68,55 @@ type Node struct {
 	step dag.Step
 	NodeState
 
-	id           int
-	mu           sync.RWMutex
-	logLock      sync.Mutex
-	cmd          executor.Executor
-	cancelFunc   func()
-	logFile      *os.File
-	logWriter    *bufio.Writer
-	stdoutFile   *os.File
-	stdoutWriter *bufio.Writer
-	stderrFile   *os.File
-	stderrWriter *bufio.Writer
-	outputWriter *os.File
-	outputReader *os.File
-	scriptFile   *os.File
-	done         bool
+	id            int
+	mu            sync.RWMutex
+	logLock       sync.Mutex
+	cmd           executor.Executor
+	cancelFunc    func()
+	logFile       *logfile.Writer
+	logWriter     *bufio.Writer
+	stdoutFile    *os.File
+	stdoutWriter  *bufio.Writer
+	stderrFile    *os.File
+	stderrWriter  *bufio.Writer
+	outputWriter  *os.File
+	outputReader  *os.File
+	scriptFile    *os.File
+	forwardWriter io.WriteCloser
+	foreachCmds   []executor.Executor
+	genOutput     string
+	done          bool
+	timedOut      bool
+	// outputFile is the file the step writes its structured output to.
+	outputFile string
+	// outputRefs are the values of the references to the outputs of other
+	// steps.
+	outputRefs map[string]string
+	// cacheKey is the cache key of the step with a cache policy.
+	cacheKey string
+	// marked is the status an operator marked the running node with.
+	marked NodeStatus
+	// restart is true if the running node is stopped to run it again.
+	restart bool
+	// logRecord is the record the lines of the log are written with if the
+	// log is in the JSON format.
+	logRecord *LogRecord
+	// stdinFile is the file the stdin of the command is read from.
+	stdinFile io.Closer
+	jsonLog   *jsonLog
+	// logRotation is the rotation of the log of the node, if any.
+	logRotation *dag.LogRotation
+	// logKey is the key the log of the node is encrypted with, if any.
+	logKey []byte
+	// traceparent is the trace context of the span of the current attempt
+	// of the node, if the run is traced.
+	traceparent string
+	// clock is the time of the node and of its timeout, the clock of the
+	// process if it is nil.
+	clock clock.Clock
+	// masks are the writers masking the secrets in the output of the
+	// current attempt, which hold back the possible starts of the values.
+	masks []io.Closer
 }
 
 // NodeState is the state of a node.
@@ -85,12 +129,24 @@ type NodeState struct {
 	RetriedAt  time.Time
 	DoneCount  int
 	Error      error
+	// SubRunRequestId is the request ID of the sub DAG run of the node.
+	SubRunRequestId string
+	// MarkedReason is the reason given by the operator who marked the
+	// status of the node manually.
+	MarkedReason string
+	// Preview is the command of the node with the variables expanded and
+	// the secret values masked.
+	Preview string
 }
 
 func (n *Node) finish() {
 	n.mu.Lock()
 	defer n.mu.Unlock()
-	n.FinishedAt = time.Now()
+	n.FinishedAt = n.now()
+}
+
+func (n *Node) now() time.Time {
+	return clock.OrDefault(n.clock).Now()
 }
 
 func (n *Node) SetError(err error) {
@@ -107,24 +163,112 @@ func (n *Node) State() NodeState {
 
 // Execute runs the command synchronously and returns error if any.
 func (n *Node) Execute(ctx context.Context) error {
+	if n.step.Timeout > 0 {
+		stop := n.watchTimeout()
+		defer stop()
+	}
+	if n.step.Foreach != nil {
+		return n.timeoutError(n.executeForeach(ctx))
+	}
+	defer n.closeStdin()
 	cmd, err := n.setupExec(ctx)
 	if err != nil {
 		return err
 	}
-	n.SetError(cmd.Run())
-	if n.outputReader != nil && n.step.Output != "" {
+	started := time.Now()
+	n.SetError(n.timeoutError(cmd.Run()))
+	n.closeMasks()
+	if r, ok := cmd.(executor.SubRunner); ok {
+		n.setOutput(r.Outputs())
+		n.setStepOutput(r.Outputs(), true)
+	} else if n.outputReader != nil {
 		utils.LogErr("close pipe writer", n.outputWriter.Close())
 		var buf bytes.Buffer
 		// TODO: Error handling
 		_, _ = io.Copy(&buf, n.outputReader)
-		ret := strings.TrimSpace(buf.String())
-		_ = os.Setenv(n.step.Output, ret)
-		n.step.OutputVariables.Store(n.step.Output, fmt.Sprintf("%s=%s", n.step.Output, ret))
+		n.setOutput(strings.TrimSpace(buf.String()))
+		n.setStepOutput(strings.TrimSpace(buf.String()), n.step.Output != "")
+	} else {
+		n.setStepOutput("", false)
+	}
+	if n.Error == nil && len(n.step.ExpectedFiles) > 0 {
+		n.SetError(n.checkExpectedFiles(started))
 	}
 
 	return n.Error
 }
 
+// watchTimeout sends the stop signal to the command of the node when it runs
+// longer than the timeout of the step, and kills it if it is still running
+// after the grace period. The returned function stops watching.
+func (n *Node) watchTimeout() func() {
+	n.mu.Lock()
+	n.timedOut = false
+	n.mu.Unlock()
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-done:
+			return
+		case <-clock.OrDefault(n.clock).After(n.step.Timeout):
+		}
+		n.mu.Lock()
+		n.timedOut = true
+		n.mu.Unlock()
+		sig := os.Signal(unix.SIGTERM)
+		if n.step.SignalOnStop != "" {
+			sig = unix.SignalNum(n.step.SignalOnStop)
+		}
+		log.Printf("%s timed out after %s, sending %s signal", n.step.Name, n.step.Timeout, sig)
+		n.kill(sig)
+		grace := time.NewTimer(n.step.KillGracePeriod)
+		defer grace.Stop()
+		select {
+		case <-done:
+		case <-grace.C:
+			log.Printf("killing %s after the grace period of %s", n.step.Name, n.step.KillGracePeriod)
+			n.kill(unix.SIGKILL)
+		}
+	}()
+	return func() {
+		close(done)
+	}
+}
+
+// timeoutError returns the timeout error instead of the error of the
+// killed command if the node timed out.
+func (n *Node) timeoutError(err error) error {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if n.timedOut {
+		return fmt.Errorf("%w after %s", errStepTimeout, n.step.Timeout)
+	}
+	return err
+}
+
+func (n *Node) kill(sig os.Signal) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if n.cmd != nil {
+		utils.LogErr("sending signal", n.cmd.Kill(sig))
+	}
+	for _, cmd := range n.foreachCmds {
+		utils.LogErr("sending signal", cmd.Kill(sig))
+	}
+}
+
+func (n *Node) setOutput(ret string) {
+	if n.step.Output != "" {
+		_ = os.Setenv(n.step.Output, ret)
+		n.step.OutputVariables.Store(n.step.Output, fmt.Sprintf("%s=%s", n.step.Output, ret))
+	}
+	if n.step.Generator {
+		n.mu.Lock()
+		n.genOutput = ret
+		n.mu.Unlock()
+	}
+}
+
 func (n *Node) setupExec(ctx context.Context) (executor.Executor, error) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
@@ -143,41 +287,85 @@ func (n *Node) setupExec(ctx context.Context) (executor.Executor, error) {
 		n.step.Args = append(args, n.scriptFile.Name())
 	}
 
-	cmd, err := executor.CreateExecutor(ctx, n.step)
+	step, err := prepareStep(ctx, n.step)
+	if err != nil {
+		return nil, err
+	}
+	n.Preview = previewCommand(step.Command, step.Args)
+	if n.outputFile != "" {
+		step.Variables = append(append([]string{}, step.Variables...), fmt.Sprintf("%s=%s", envOutputFile, n.outputFile))
+	}
+	if n.traceparent != "" {
+		step.Variables = append(append([]string{}, step.Variables...), fmt.Sprintf("%s=%s", envTraceparent, n.traceparent))
+	}
+	cmd, err := executor.CreateExecutor(ctx, step)
 	if err != nil {
 		return nil, err
 	}
 	n.cmd = cmd
+	if n.stdinFile, err = setStdin(cmd, step); err != nil {
+		return nil, err
+	}
+
+	// The output of a sub DAG run is read from the executor.
+	r, isSubRun := cmd.(executor.SubRunner)
+	if isSubRun {
+		n.SubRunRequestId = r.SubRunRequestID()
+	}
 
-	var stdout io.Writer
+	// others are the writers of the stdout other than the log. The stderr
+	// is written to them too if the step has no stderr file.
+	var stdout, stderr, others []io.Writer
 
+	// Secrets are masked in the logs but not in the output of the step.
 	if n.logWriter != nil {
-		stdout = n.logWriter
-		cmd.SetStderr(stdout)
+		stdout = append(stdout, n.mask(n.logStream(streamStdout)))
+		stderr = append(stderr, n.mask(n.logStream(streamStderr)))
 	}
 
 	if n.stdoutWriter != nil {
-		stdout = io.MultiWriter(n.logWriter, n.stdoutWriter)
+		others = append(others, n.mask(n.stdoutWriter))
 	}
 
-	if n.step.Output != "" {
+	if (n.step.Output != "" || n.step.Generator) && !isSubRun {
 		var err error
 		if n.outputReader, n.outputWriter, err = os.Pipe(); err != nil {
 			return nil, err
 		}
-		stdout = io.MultiWriter(stdout, n.outputWriter)
+		others = append(others, n.outputWriter)
 	}
 
-	cmd.SetStdout(stdout)
+	cmd.SetStdout(io.MultiWriter(append(stdout, others...)...))
 	if n.stderrWriter != nil {
-		cmd.SetStderr(n.stderrWriter)
+		cmd.SetStderr(n.mask(n.stderrWriter))
 	} else {
-		cmd.SetStderr(stdout)
+		cmd.SetStderr(io.MultiWriter(append(stderr, others...)...))
 	}
 
 	return cmd, nil
 }
 
+// prepareStep returns the step to create the executor with. The variables of
+// its dotenv files are added, which take precedence over the environment
+// variables of the DAG, and the secret references are resolved. The
+// resolved values are not kept in the node so that they are not written to
+// the status.
+func prepareStep(ctx context.Context, step dag.Step) (dag.Step, error) {
+	if len(step.Dotenv) > 0 {
+		vars, err := dag.LoadDotenv(step.Dotenv, step.Dir)
+		if err != nil {
+			return step, err
+		}
+		step.Variables = append(append([]string{}, step.Variables...), vars...)
+	}
+	vars, err := secret.ResolveVars(ctx, step.Variables)
+	if err != nil {
+		return step, err
+	}
+	step.Variables = vars
+	return step, nil
+}
+
 func (n *Node) Step() dag.Step {
 	n.mu.RLock()
 	defer n.mu.RUnlock()
@@ -218,6 +406,14 @@ func (n *Node) setStatus(status NodeStatus) {
 	n.Status = status
 }
 
+// selectPlatform selects the command of the step for the platform it runs
+// on.
+func (n *Node) selectPlatform() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.step.SelectPlatform(runtime.GOOS, runtime.GOARCH)
+}
+
 func (n *Node) setErr(err error) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
@@ -237,6 +433,15 @@ func (n *Node) signal(sig os.Signal, allowOverride bool) {
 		log.Printf("Sending %s signal to %s", sigsig, n.step.Name)
 		utils.LogErr("sending signal", n.cmd.Kill(sigsig))
 	}
+	if status == NodeStatusRunning {
+		for _, cmd := range n.foreachCmds {
+			sigsig := sig
+			if allowOverride && n.step.SignalOnStop != "" {
+				sigsig = unix.SignalNum(n.step.SignalOnStop)
+			}
+			utils.LogErr("sending signal", cmd.Kill(sigsig))
+		}
+	}
 	if status == NodeStatusRunning {
 		n.Status = NodeStatusCancel
 	}
@@ -259,12 +464,13 @@ func (n *Node) setup(logDir string, requestId string) error {
 	n.mu.Lock()
 	defer n.mu.Unlock()
 
-	n.StartedAt = time.Now()
+	n.StartedAt = n.now()
 	n.Log = filepath.Join(logDir, fmt.Sprintf("%s.%s.%s.log",
 		utils.ValidFilename(n.step.Name, "_"),
 		n.StartedAt.Format("20060102.15:04:05.000"),
 		utils.TruncString(requestId, 8),
 	))
+	n.outputFile = outputFileName(requestId, n.id)
 	for _, fn := range []func() error{
 		n.setupLog,
 		n.setupStdout,
@@ -285,7 +491,7 @@ func (n *Node) setupScript() (err error) {
 			return fmt.Errorf("directory %q does not exist", n.step.Dir)
 		}
 		n.scriptFile, _ = os.CreateTemp(n.step.Dir, "dagu_script-")
-		if _, err = n.scriptFile.WriteString(n.step.Script); err != nil {
+		if _, err = n.scriptFile.WriteString(n.expandOutputRefs(n.step.Script)); err != nil {
 			return
 		}
 		defer func() {
@@ -336,16 +542,54 @@ func (n *Node) setupLog() error {
 	}
 	n.logLock.Lock()
 	defer n.logLock.Unlock()
+	var maxBytes int64
+	var maxFiles int
+	if n.logRotation != nil {
+		maxBytes, maxFiles = n.logRotation.MaxBytes, n.logRotation.MaxFiles
+	}
 	var err error
-	n.logFile, err = utils.OpenOrCreateFile(n.Log)
+	n.logFile, err = logfile.NewEncryptedWriter(n.Log, maxBytes, maxFiles, n.logKey)
 	if err != nil {
 		n.Error = err
 		return err
 	}
-	n.logWriter = bufio.NewWriter(n.logFile)
+	if n.forwardWriter != nil {
+		n.logWriter = bufio.NewWriter(io.MultiWriter(n.logFile, n.forwardWriter))
+	} else {
+		n.logWriter = bufio.NewWriter(n.logFile)
+	}
+	if n.logRecord != nil {
+		n.jsonLog = newJSONLog(n.logWriter, *n.logRecord)
+	}
 	return nil
 }
 
+// mask returns the writer masking the secrets written to w, which is closed
+// by closeMasks.
+func (n *Node) mask(w io.Writer) io.Writer {
+	m := secret.NewMaskWriter(w)
+	n.masks = append(n.masks, m)
+	return m
+}
+
+// closeMasks writes the output held back by the writers masking the
+// secrets.
+func (n *Node) closeMasks() {
+	for _, m := range n.masks {
+		utils.LogErr("close mask writer", m.Close())
+	}
+	n.masks = nil
+}
+
+// logStream returns the writer of the stream to the log of the node. The
+// lines are written as records if the log is in the JSON format.
+func (n *Node) logStream(stream string) io.Writer {
+	if n.jsonLog != nil {
+		return n.jsonLog.stream(stream)
+	}
+	return n.logWriter
+}
+
 func (n *Node) teardown() error {
 	if n.done {
 		return nil
@@ -353,14 +597,25 @@ func (n *Node) teardown() error {
 	n.logLock.Lock()
 	n.done = true
 	var lastErr error
-	for _, w := range []*bufio.Writer{n.logWriter, n.stdoutWriter} {
+	if n.jsonLog != nil {
+		if err := n.jsonLog.close(); err != nil {
+			lastErr = err
+		}
+	}
+	for _, w := range []*bufio.Writer{n.logWriter, n.stdoutWriter, n.stderrWriter} {
 		if w != nil {
 			if err := w.Flush(); err != nil {
 				lastErr = err
 			}
 		}
 	}
-	for _, f := range []*os.File{n.logFile, n.stdoutFile} {
+	if n.logFile != nil {
+		if err := n.logFile.Sync(); err != nil {
+			lastErr = err
+		}
+		_ = n.logFile.Close()
+	}
+	for _, f := range []*os.File{n.stdoutFile, n.stderrFile} {
 		if f != nil {
 			if err := f.Sync(); err != nil {
 				lastErr = err
@@ -368,11 +623,17 @@ func (n *Node) teardown() error {
 			_ = f.Close()
 		}
 	}
+	if n.forwardWriter != nil {
+		_ = n.forwardWriter.Close()
+	}
 	n.logLock.Unlock()
 
 	if n.scriptFile != nil {
 		_ = os.Remove(n.scriptFile.Name())
 	}
+	if n.outputFile != "" {
+		_ = os.Remove(n.outputFile)
+	}
 	if lastErr != nil {
 		n.Error = lastErr
 	}
@@ -0,0 +1,108 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/dagu-dev/dagu/internal/utils"
+)
+
+// envOutputFile is the environment variable of the file a step writes its
+// structured output to.
+const envOutputFile = "DAG_OUTPUT_FILE"
+
+var (
+	errOutputNotFound = fmt.Errorf("step output not found")
+
+	// outputRefRegex matches the references to the outputs of the steps,
+	// e.g. ${steps.fetch.output.items[0].id}.
+	outputRefRegex = regexp.MustCompile(`\$\{(steps\.([^.{}]+)\.output((?:\.[^.\[\]{}]+|\[\d+\])*))\}`)
+)
+
+// resolveOutputRefs evaluates the references to the outputs of other steps
+// in the step and sets them as environment variables named after the
+// reference, so that they are expanded like any other variable. The script
+// of the step is not expanded, so its references are replaced when the
+// script file is written.
+func (n *Node) resolveOutputRefs() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	step := n.step
+	step.OutputVariables = nil
+	step.StepOutputs = nil
+	b, err := json.Marshal(step)
+	if err != nil {
+		return err
+	}
+	refs := make(map[string]string)
+	for _, m := range outputRefRegex.FindAllStringSubmatch(string(b), -1) {
+		if _, ok := refs[m[1]]; ok {
+			continue
+		}
+		v, err := n.outputValue(m[2], m[3])
+		if err != nil {
+			return err
+		}
+		refs[m[1]] = v
+		if err := os.Setenv(m[1], v); err != nil {
+			return err
+		}
+	}
+	n.outputRefs = refs
+	return nil
+}
+
+func (n *Node) outputValue(name, path string) (string, error) {
+	if n.step.StepOutputs == nil {
+		return "", fmt.Errorf("%w: %s", errOutputNotFound, name)
+	}
+	v, ok := n.step.StepOutputs.Load(name)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", errOutputNotFound, name)
+	}
+	if path == "" {
+		return v.(string), nil
+	}
+	ret, err := utils.JSONPath(v.(string), path)
+	if err != nil {
+		return "", fmt.Errorf("steps.%s.output%s: %w", name, path, err)
+	}
+	return ret, nil
+}
+
+// expandOutputRefs replaces the references in s with the values resolved
+// by resolveOutputRefs.
+func (n *Node) expandOutputRefs(s string) string {
+	if len(n.outputRefs) == 0 {
+		return s
+	}
+	return outputRefRegex.ReplaceAllStringFunc(s, func(ref string) string {
+		if v, ok := n.outputRefs[ref[2:len(ref)-1]]; ok {
+			return v
+		}
+		return ref
+	})
+}
+
+// setStepOutput stores the structured output of the step for the steps that
+// refer to it. It is the content of the output file if the step wrote it, and
+// the captured output of the step otherwise.
+func (n *Node) setStepOutput(out string, ok bool) {
+	if n.outputFile != "" {
+		if b, err := os.ReadFile(n.outputFile); err == nil {
+			out, ok = strings.TrimSpace(string(b)), true
+			utils.LogErr("remove output file", os.Remove(n.outputFile))
+		}
+	}
+	if ok && n.step.StepOutputs != nil {
+		n.step.StepOutputs.Store(n.step.Name, out)
+	}
+}
+
+func outputFileName(requestId string, id int) string {
+	return filepath.Join(os.TempDir(), fmt.Sprintf("dagu_output-%s-%d.json", utils.TruncString(requestId, 8), id))
+}
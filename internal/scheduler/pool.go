@@ -0,0 +1,122 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/pool"
+)
+
+var (
+	errNoPools      = errors.New("concurrency pools are not supported in this run")
+	errQueueExpired = errors.New("run waited longer than its queue TTL")
+)
+
+// QueuePosition returns the position of the run in the queue of its pool,
+// or 0 if it does not wait for a slot.
+func (sc *Scheduler) QueuePosition() int {
+	return int(sc.queuePosition.Load())
+}
+
+func (sc *Scheduler) setQueuePosition(position int) {
+	sc.queuePosition.Store(int32(position))
+}
+
+// Overtook returns the request IDs of the runs of the lower lanes, e.g. the
+// scheduled runs, that were queued before the run and that it took a slot
+// of its pool ahead of.
+func (sc *Scheduler) Overtook() []string {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.overtook
+}
+
+func (sc *Scheduler) setOvertook(requestIds []string) {
+	log.Printf("took a slot of pool %s ahead of the runs %s", sc.Pool, strings.Join(requestIds, ", "))
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.overtook = requestIds
+}
+
+// entry returns the entry of the run in the queues of the pools.
+func (sc *Scheduler) entry(overtook func([]string)) pool.Entry {
+	return pool.Entry{Lane: sc.Lane, Priority: sc.Priority, RequestId: sc.RequestId, Overtook: overtook}
+}
+
+// acquireRunPool waits for a slot of the pool of the run. The run expires
+// if it waits longer than QueueTTL.
+func (sc *Scheduler) acquireRunPool(ctx context.Context) (func(), error) {
+	if sc.QueueTTL <= 0 {
+		return sc.acquirePool(ctx, sc.Pool, sc.setQueuePosition, sc.setOvertook)
+	}
+	ctx, cancel := context.WithTimeoutCause(ctx, sc.QueueTTL, errQueueExpired)
+	defer cancel()
+	release, err := sc.acquirePool(ctx, sc.Pool, sc.setQueuePosition, sc.setOvertook)
+	if err != nil && errors.Is(context.Cause(ctx), errQueueExpired) {
+		sc.expired.Store(true)
+		err = fmt.Errorf("%w: %s", errQueueExpired, sc.QueueTTL)
+		log.Print(err)
+	}
+	return release, err
+}
+
+// acquirePool waits for a slot of the pool. The wait ends when the run is
+// canceled.
+func (sc *Scheduler) acquirePool(ctx context.Context, name string, queued func(int), overtook func([]string)) (func(), error) {
+	if sc.Pools == nil {
+		return nil, fmt.Errorf("%w: %s", errNoPools, name)
+	}
+	return sc.wait(ctx, "a slot of pool "+name, queued, func(ctx context.Context) (func(), error) {
+		return sc.Pools.Acquire(ctx, name, sc.entry(overtook), queued)
+	})
+}
+
+// acquireKey waits until the steps of the other runs of the DAG with the
+// concurrency key finish. The key is expanded with the variables of the
+// run, so that the steps of the runs for different partitions, e.g.
+// customers, run in parallel.
+func (sc *Scheduler) acquireKey(ctx context.Context, key string) (func(), error) {
+	key = os.ExpandEnv(key)
+	if sc.Pools == nil {
+		return nil, fmt.Errorf("%w: concurrency key %s", errNoPools, key)
+	}
+	return sc.wait(ctx, "concurrency key "+key, nil, func(ctx context.Context) (func(), error) {
+		return sc.Pools.AcquireKey(ctx, sc.DAGName+"/"+key, sc.entry(nil), nil)
+	})
+}
+
+// wait waits for acquire to return until the run is canceled.
+func (sc *Scheduler) wait(ctx context.Context, what string, queued func(int), acquire func(ctx context.Context) (func(), error)) (func(), error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		t := time.NewTicker(sc.pause)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				if sc.isCanceled() {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	log.Printf("waiting for %s", what)
+	release, err := acquire(ctx)
+	if queued != nil {
+		queued(0)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire %s: %w", what, err)
+	}
+	log.Printf("acquired %s", what)
+	return release, nil
+}
@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"os"
+	"strings"
+
+	"github.com/dagu-dev/dagu/internal/secret"
+	"github.com/dagu-dev/dagu/internal/utils"
+)
+
+// scriptArg is the argument of the script file of a step in the preview of
+// its command before the file is created.
+const scriptArg = "<script>"
+
+// previewCommand returns the command and its arguments as a line of a shell,
+// with the resolved secret values masked, so that the operators see what
+// runs without the credentials.
+func previewCommand(command string, args []string) string {
+	parts := make([]string, 0, len(args)+1)
+	parts = append(parts, quoteArg(command))
+	for _, a := range args {
+		parts = append(parts, quoteArg(a))
+	}
+	return secret.Mask(strings.Join(parts, " "))
+}
+
+// quoteArg quotes the argument for a shell if it has white spaces or the
+// characters the shell interprets.
+func quoteArg(s string) string {
+	if s == "" {
+		return "''"
+	}
+	if !strings.ContainsAny(s, " \t\n'\"\\$`|&;<>()*?[]{}~#!") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// plannedPreview returns the preview of the command of the node before it
+// runs. The variables are expanded with the environment of the run as the
+// command is when the node runs, but the command substitutions are not run
+// and the unknown variables, e.g. the outputs of the steps that have not run
+// yet, are kept.
+func (n *Node) plannedPreview() string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	command, args := n.step.Command, n.step.Args
+	if n.step.CmdWithArgs != "" {
+		command, args = utils.SplitCommand(n.expandOutputRefs(n.step.CmdWithArgs), false)
+		for i := range args {
+			args[i] = os.Expand(args[i], func(key string) string {
+				if v, ok := os.LookupEnv(key); ok {
+					return v
+				}
+				return "${" + key + "}"
+			})
+		}
+	}
+	if n.step.Script != "" {
+		args = append(append([]string{}, args...), scriptArg)
+	}
+	return previewCommand(command, args)
+}
+
+// setPlannedPreviews sets the previews of the nodes that have not run, so
+// that the run shows what its steps will run before they start.
+func setPlannedPreviews(g *ExecutionGraph) {
+	for _, node := range g.Nodes() {
+		if node.State().Status != NodeStatusNone {
+			continue
+		}
+		preview := node.plannedPreview()
+		node.mu.Lock()
+		node.Preview = preview
+		node.mu.Unlock()
+	}
+}
@@ -2,15 +2,23 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/dagu-dev/dagu/internal/clock"
 	"github.com/dagu-dev/dagu/internal/config"
 	"github.com/dagu-dev/dagu/internal/constants"
 	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/logfile"
+	"github.com/dagu-dev/dagu/internal/pool"
+	"github.com/dagu-dev/dagu/internal/utils"
 )
 
 type Status int
@@ -21,11 +29,32 @@ const (
 	StatusError
 	StatusCancel
 	StatusSuccess
+	// StatusExpired is the status of a run canceled because it waited for a
+	// slot of its pool longer than its queue TTL.
+	StatusExpired
+	// StatusLost is the status of a run whose status was running when its
+	// agent was already gone, e.g. after a crash of the host.
+	StatusLost
+)
+
+// The environment variables of the handler steps with the run they handle.
+const (
+	envDAGName      = "DAG_NAME"
+	envDAGRequestId = "DAG_REQUEST_ID"
+	envDAGStatus    = "DAG_STATUS"
 )
 
 var (
 	errUpstreamFailed  = fmt.Errorf("upstream failed")
 	errUpstreamSkipped = fmt.Errorf("upstream skipped")
+	// errBranchNotTaken is the error of a step skipped because its if
+	// expression is false, and of the steps that depend only on such steps.
+	errBranchNotTaken  = fmt.Errorf("branch not taken")
+	errNoInputFetcher  = fmt.Errorf("inputs are not supported in this run")
+	errNoArtifactSaver = fmt.Errorf("artifacts are not supported in this run")
+	// errRepeatUntilNotMet is the error of a step whose until condition is
+	// still false after the limit of runs.
+	errRepeatUntilNotMet = fmt.Errorf("repeat condition was not met")
 )
 
 func (s Status) String() string {
@@ -38,6 +67,10 @@ func (s Status) String() string {
 		return "canceled"
 	case StatusSuccess:
 		return "finished"
+	case StatusExpired:
+		return "expired"
+	case StatusLost:
+		return "lost"
 	case StatusNone:
 		fallthrough
 	default:
@@ -54,6 +87,14 @@ type Scheduler struct {
 	pause     time.Duration
 	lastError error
 	handlers  map[string]*Node
+	// queuePosition is the position of the run in the queue of its pool
+	// while it waits for a slot.
+	queuePosition atomic.Int32
+	// expired is set if the run waited longer than QueueTTL.
+	expired atomic.Bool
+	// overtook are the request IDs of the runs of the lower lanes that the
+	// run took a slot of its pool ahead of.
+	overtook []string
 }
 
 type Config struct {
@@ -65,7 +106,114 @@ type Config struct {
 	OnSuccess     *dag.Step
 	OnFailure     *dag.Step
 	OnCancel      *dag.Step
-	RequestId     string
+	OnTimeout     *dag.Step
+	// HandlerTimeout is the timeout of the handler steps that have none, so
+	// that a hanging handler does not keep a finished run from exiting.
+	// Zero means no timeout.
+	HandlerTimeout time.Duration
+	RequestId      string
+	// DAGName and LogFormat are the name of the DAG and the format of the
+	// step logs. The lines are written as JSON records with the name if the
+	// format is json.
+	DAGName   string
+	LogFormat string
+	// LogCompression is the compression of the step logs when the steps
+	// finish, and LogRotation the rotation of the logs of the steps that
+	// have none.
+	LogCompression string
+	LogRotation    *dag.LogRotation
+	// LogKey is the key the logs of the steps are encrypted with. They are
+	// not encrypted if it is nil.
+	LogKey []byte
+	// LogUploader is optional. If set, the log files of the steps are
+	// uploaded when the steps finish, after they are compressed.
+	LogUploader LogUploader
+	// LogForwarder is optional. If set, step logs are also forwarded with
+	// LogLabels and the step name as labels.
+	LogForwarder LogForwarder
+	LogLabels    map[string]string
+	// InputFetcher fetches the input artifacts of the steps. Steps with
+	// inputs fail if it is nil.
+	InputFetcher InputFetcher
+	// ArtifactSaver saves the artifacts of the steps. Steps with artifacts
+	// fail if it is nil.
+	ArtifactSaver ArtifactSaver
+	// StepCache stores the results of the steps with a cache policy. The
+	// steps always run if it is nil. With NoCache, they run and replace
+	// their cached results. Params is part of their cache keys.
+	StepCache StepCache
+	NoCache   bool
+	Params    string
+	// Pool is the concurrency pool the run takes a slot of before its
+	// steps start. Pools limits the runs and the steps in the pools, and
+	// the steps with the same concurrency key. The run or the steps in a
+	// pool or with a key fail if it is nil.
+	Pool  string
+	Pools Pools
+	// Priority is the priority of the run in the queues of the pools, and
+	// Lane its lane, whose runs take the free slots before the ones of the
+	// lower lanes, e.g. the manual runs before the scheduled ones.
+	Priority int
+	Lane     int
+	// QueueTTL is how long the run waits for a slot of its pool before it
+	// expires. Zero means no limit.
+	QueueTTL time.Duration
+	// Faults are the failures injected into the steps of the run.
+	Faults []Fault
+	// Tracer is optional. If set, the attempts of the steps are traced.
+	Tracer Tracer
+	// Clock is the time of the run and of the waits of its steps, their
+	// delays, retries, repeats and timeouts. The clock of the process is
+	// used if it is nil.
+	Clock clock.Clock
+}
+
+// LogForwarder forwards logs to an external log store.
+type LogForwarder interface {
+	Writer(labels map[string]string) io.WriteCloser
+}
+
+// envTraceparent is the environment variable of the trace context of the
+// span of a step.
+const envTraceparent = "TRACEPARENT"
+
+// Tracer traces the attempts of the steps.
+type Tracer interface {
+	// StartStep starts the span of an attempt of the step. It returns the
+	// W3C traceparent of the span, which the command of the step gets in
+	// TRACEPARENT, and the function that ends the span with the error of
+	// the attempt.
+	StartStep(step dag.Step, retryCount int) (traceparent string, end func(err error))
+}
+
+// LogUploader uploads the log files of the steps to a remote storage.
+type LogUploader interface {
+	Upload(ctx context.Context, file string) error
+}
+
+// Pools limits the number of executions in the concurrency pools across all
+// the runs.
+type Pools interface {
+	// Acquire waits until a slot of the pool is free and takes it until
+	// release is called. The executions of a higher lane, and then with a
+	// higher priority, take the free slots first. queued is called with
+	// the position in the queue when it changes if it is not nil.
+	Acquire(ctx context.Context, name string, e pool.Entry, queued func(position int)) (release func(), err error)
+	// AcquireKey waits until no other execution holds the concurrency key
+	// and takes it until release is called.
+	AcquireKey(ctx context.Context, key string, e pool.Entry, queued func(position int)) (release func(), err error)
+}
+
+// InputFetcher fetches the artifacts of other DAGs that the steps need.
+type InputFetcher interface {
+	// Fetch copies the artifact of the input to dst.
+	Fetch(ctx context.Context, input dag.Input, dst string) error
+}
+
+// ArtifactSaver saves the files of the steps to the artifacts of the run.
+type ArtifactSaver interface {
+	// Save copies the files matching the patterns relative to dir.
+	Save(ctx context.Context, dir string, patterns []string) error
 }
 
 // Schedule runs the graph of steps.
@@ -74,12 +222,21 @@ func (sc *Scheduler) Schedule(ctx context.Context, g *ExecutionGraph, done chan
 	if err := sc.setup(); err != nil {
 		return err
 	}
-	g.Start()
+	g.Start(sc.Clock)
 	defer g.Finish()
+	setPlannedPreviews(g)
+
+	var poolErr error
+	var release func()
+	if sc.Pool != "" && !sc.Dry {
+		if release, poolErr = sc.acquireRunPool(ctx); poolErr != nil {
+			sc.lastError = poolErr
+		}
+	}
 
 	var wg = sync.WaitGroup{}
 
-	for !sc.isFinished(g) {
+	for poolErr == nil && !sc.isFinished(g) {
 		if sc.isCanceled() {
 			break
 		}
@@ -94,6 +251,36 @@ func (sc *Scheduler) Schedule(ctx context.Context, g *ExecutionGraph, done chan
 			if sc.MaxActiveRuns > 0 && sc.runningCount(g) >= sc.MaxActiveRuns {
 				continue NodesIteration
 			}
+			if err := node.selectPlatform(); err != nil {
+				log.Printf("%s", err.Error())
+				sc.lastError = err
+				node.setErr(err)
+				continue NodesIteration
+			}
+			if !sc.Dry {
+				if err := node.resolveOutputRefs(); err != nil {
+					log.Printf("%s", err.Error())
+					sc.lastError = err
+					node.setErr(err)
+					continue NodesIteration
+				}
+			}
+			if node.step.If != "" {
+				log.Printf("evaluating if of \"%s\"", node.step.Name)
+				ok, err := dag.EvalExpr(node.step.If)
+				if err != nil {
+					log.Printf("%s", err.Error())
+					sc.lastError = err
+					node.setErr(err)
+					continue NodesIteration
+				}
+				if !ok {
+					log.Printf("skip \"%s\": %s is false", node.step.Name, node.step.If)
+					node.setStatus(NodeStatusSkipped)
+					node.SetError(fmt.Errorf("%w: %s is false", errBranchNotTaken, node.step.If))
+					continue NodesIteration
+				}
+			}
 			// Check preconditions
 			if len(node.step.Preconditions) > 0 {
 				log.Printf("checking pre conditions for \"%s\"", node.step.Name)
@@ -104,6 +291,9 @@ func (sc *Scheduler) Schedule(ctx context.Context, g *ExecutionGraph, done chan
 					continue NodesIteration
 				}
 			}
+			if node.step.Guard != nil && !sc.checkGuard(ctx, node) {
+				continue NodesIteration
+			}
 			wg.Add(1)
 
 			log.Printf("start running: %s", node.step.Name)
@@ -115,18 +305,61 @@ func (sc *Scheduler) Schedule(ctx context.Context, g *ExecutionGraph, done chan
 				}()
 
 				setupSucceed := true
-				if err := sc.setupNode(node); err != nil {
-					setupSucceed = false
-					sc.lastError = err
-					node.setErr(err)
+				if node.step.Pool != "" && !sc.Dry {
+					release, err := sc.acquirePool(ctx, node.step.Pool, nil, nil)
+					if err != nil {
+						setupSucceed = false
+						// A step canceled while it waits is already
+						// marked as canceled.
+						if !sc.isCanceled() {
+							sc.lastError = err
+							node.setErr(err)
+						}
+					} else {
+						defer release()
+					}
+				}
+				if setupSucceed && node.step.ConcurrencyKey != "" && !sc.Dry {
+					release, err := sc.acquireKey(ctx, node.step.ConcurrencyKey)
+					if err != nil {
+						setupSucceed = false
+						if !sc.isCanceled() {
+							sc.lastError = err
+							node.setErr(err)
+						}
+					} else {
+						defer release()
+					}
+				}
+				if setupSucceed {
+					if err := sc.setupNode(ctx, node); err != nil {
+						setupSucceed = false
+						sc.lastError = err
+						node.setErr(err)
+					}
 				}
 				defer func() {
 					_ = sc.teardownNode(node)
 				}()
 
+				cached := false
+				if setupSucceed && node.step.Cache != nil && sc.StepCache != nil && !sc.Dry {
+					cached = sc.restoreCache(node)
+				}
+
 			ExecRepeat:
-				for setupSucceed && !sc.isCanceled() {
+				for setupSucceed && !cached && !sc.isCanceled() {
 					execErr := sc.execNode(ctx, node)
+					// A step marked by an operator finishes with the status
+					// regardless of the result of the command.
+					if sc.takeOver(node) {
+						break ExecRepeat
+					}
+					// A step restarted by an operator runs its command
+					// again, whatever the result of the stopped one.
+					if node.takeRestart() {
+						continue ExecRepeat
+					}
 					if execErr != nil {
 						status := node.State().Status
 						switch {
@@ -134,18 +367,26 @@ func (sc *Scheduler) Schedule(ctx context.Context, g *ExecutionGraph, done chan
 							// do nothing
 						case sc.isCanceled():
 							sc.lastError = execErr
-						case node.step.RetryPolicy != nil && node.step.RetryPolicy.Limit > node.getRetryCount():
+						case node.step.RetryPolicy != nil && node.step.RetryPolicy.Limit > node.getRetryCount() &&
+							node.step.RetryPolicy.ShouldRetry(execErr):
 							// retry
 							log.Printf("%s failed but scheduled for retry", node.step.Name)
 							node.incRetryCount()
-							log.Printf("sleep %s for retry", node.step.RetryPolicy.Interval)
-							time.Sleep(node.step.RetryPolicy.Interval)
-							node.setRetriedAt(time.Now())
+							interval := node.step.RetryPolicy.IntervalAt(node.getRetryCount())
+							log.Printf("sleep %s for retry", interval)
+							sc.clock().Sleep(interval)
+							if sc.takeOver(node) {
+								break ExecRepeat
+							}
+							node.setRetriedAt(sc.clock().Now())
 							node.setStatus(NodeStatusNone)
 						default:
 							// finish the node
 							node.setStatus(NodeStatusError)
 							node.setErr(execErr)
+							if errors.Is(execErr, errStepTimeout) {
+								node.setStatus(NodeStatusTimeout)
+							}
 							sc.lastError = execErr
 						}
 					}
@@ -155,8 +396,17 @@ func (sc *Scheduler) Schedule(ctx context.Context, g *ExecutionGraph, done chan
 					if node.step.RepeatPolicy.Repeat {
 						if execErr == nil || node.step.ContinueOn.Failure {
 							if !sc.isCanceled() {
-								time.Sleep(node.step.RepeatPolicy.Interval)
-								continue ExecRepeat
+								repeat, err := shouldRepeat(node)
+								if err != nil {
+									sc.lastError = err
+									node.setErr(err)
+								} else if repeat {
+									sc.clock().Sleep(node.step.RepeatPolicy.IntervalAt(node.getDoneCount()))
+									if sc.takeOver(node) {
+										break ExecRepeat
+									}
+									continue ExecRepeat
+								}
 							}
 						}
 					}
@@ -167,7 +417,23 @@ func (sc *Scheduler) Schedule(ctx context.Context, g *ExecutionGraph, done chan
 					break ExecRepeat
 				}
 				// finish the node
+				if node.State().Status == NodeStatusRunning && node.step.Generator && !sc.Dry {
+					if err := sc.generateSteps(g, node); err != nil {
+						sc.lastError = err
+						node.setErr(err)
+					}
+				}
+				// A cached step does not save its artifacts again.
+				if node.State().Status == NodeStatusRunning && len(node.step.Artifacts) > 0 && !cached && !sc.Dry {
+					if err := sc.saveArtifacts(ctx, node); err != nil {
+						sc.lastError = err
+						node.setErr(err)
+					}
+				}
 				if node.State().Status == NodeStatusRunning {
+					if node.step.Cache != nil && sc.StepCache != nil && !cached && !sc.Dry {
+						sc.saveCache(node)
+					}
 					node.setStatus(NodeStatusSuccess)
 				}
 				if err := sc.teardownNode(node); err != nil {
@@ -178,19 +444,28 @@ func (sc *Scheduler) Schedule(ctx context.Context, g *ExecutionGraph, done chan
 					done <- node
 				}
 			}(node)
-			time.Sleep(sc.Delay)
+			sc.clock().Sleep(sc.Delay)
 		}
 		time.Sleep(sc.pause)
 	}
 	wg.Wait()
+	// The handlers do not hold the slot of the run in its pool.
+	if release != nil {
+		release()
+	}
 
 	var handlers []string
-	switch sc.Status(g) {
+	status := sc.Status(g)
+	switch status {
 	case StatusSuccess:
 		handlers = append(handlers, constants.OnSuccess)
 	case StatusError:
-		handlers = append(handlers, constants.OnFailure)
-	case StatusCancel:
+		if sc.handlers[constants.OnTimeout] != nil && isTimedOut(g) {
+			handlers = append(handlers, constants.OnTimeout)
+		} else {
+			handlers = append(handlers, constants.OnFailure)
+		}
+	case StatusCancel, StatusExpired:
 		handlers = append(handlers, constants.OnCancel)
 	}
 	handlers = append(handlers, constants.OnExit)
@@ -198,6 +473,8 @@ func (sc *Scheduler) Schedule(ctx context.Context, g *ExecutionGraph, done chan
 		if n := sc.handlers[h]; n != nil {
 			log.Printf("%s started", n.step.Name)
 			n.step.OutputVariables = g.outputVariables
+			n.step.StepOutputs = g.stepOutputs
+			n.step.Variables = sc.handlerVariables(n.step.Variables, status)
 			if err := sc.runHandlerNode(ctx, n); err != nil {
 				sc.lastError = err
 			}
@@ -209,27 +486,163 @@ func (sc *Scheduler) Schedule(ctx context.Context, g *ExecutionGraph, done chan
 	return sc.lastError
 }
 
-func (sc *Scheduler) setupNode(node *Node) error {
+// shouldRepeat reports whether the node with a repeat policy runs again. It
+// returns an error if the until condition is not met within the limit.
+func shouldRepeat(node *Node) (bool, error) {
+	p := node.step.RepeatPolicy
+	if p.Until != "" {
+		ok, err := dag.EvalExpr(p.Until)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			log.Printf("%s: %s is true", node.step.Name, p.Until)
+			return false, nil
+		}
+	}
+	if p.Limit > 0 && node.getDoneCount() >= p.Limit {
+		if p.Until != "" {
+			return false, fmt.Errorf("%w: %s after %d runs", errRepeatUntilNotMet, p.Until, p.Limit)
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+func (sc *Scheduler) setupNode(ctx context.Context, node *Node) error {
 	if !sc.Dry {
-		return node.setup(sc.LogDir, sc.RequestId)
+		sc.setupLogForward(node)
+		sc.setupLogFormat(node)
+		node.logRotation = node.step.LogRotation
+		if node.logRotation == nil {
+			node.logRotation = sc.LogRotation
+		}
+		node.logKey = sc.LogKey
+		node.clock = sc.Clock
+		if err := node.setup(sc.LogDir, sc.RequestId); err != nil {
+			return err
+		}
+		return sc.fetchInputs(ctx, node)
+	}
+	return nil
+}
+
+func (sc *Scheduler) fetchInputs(ctx context.Context, node *Node) error {
+	if len(node.step.Inputs) == 0 {
+		return nil
+	}
+	if sc.InputFetcher == nil {
+		return errNoInputFetcher
+	}
+	for _, in := range node.step.Inputs {
+		dst := inputPath(&node.step, in)
+		log.Printf("fetching input %s of \"%s\" to %s", in.Ref, node.step.Name, dst)
+		if err := sc.InputFetcher.Fetch(ctx, in, dst); err != nil {
+			return fmt.Errorf("failed to fetch input %s: %w", in.Ref, err)
+		}
 	}
 	return nil
 }
 
+// inputPath returns the path the input of the step is fetched to.
+func inputPath(step *dag.Step, in dag.Input) string {
+	if !filepath.IsAbs(in.Path) && step.Dir != "" {
+		return filepath.Join(step.Dir, in.Path)
+	}
+	return in.Path
+}
+
+func (sc *Scheduler) saveArtifacts(ctx context.Context, node *Node) error {
+	if sc.ArtifactSaver == nil {
+		return errNoArtifactSaver
+	}
+	log.Printf("saving artifacts of \"%s\"", node.step.Name)
+	if err := sc.ArtifactSaver.Save(ctx, node.step.Dir, node.step.Artifacts); err != nil {
+		return fmt.Errorf("failed to save artifacts: %w", err)
+	}
+	return nil
+}
+
+func (sc *Scheduler) setupLogForward(node *Node) {
+	if sc.LogForwarder == nil {
+		return
+	}
+	labels := map[string]string{"step": node.step.Name}
+	for k, v := range sc.LogLabels {
+		labels[k] = v
+	}
+	node.forwardWriter = sc.LogForwarder.Writer(labels)
+}
+
 func (sc *Scheduler) teardownNode(node *Node) error {
 	if !sc.Dry {
-		return node.teardown()
+		// The log is uploaded once although the node is torn down again
+		// when the step returns.
+		if node.done {
+			return nil
+		}
+		if err := node.teardown(); err != nil {
+			return err
+		}
+		sc.compressLog(node)
+		sc.uploadLog(node)
 	}
 	return nil
 }
 
+// compressLog compresses the log of the finished node. The log is still
+// read by the API and the reports after it is compressed, and it is kept
+// as it is if it cannot be compressed.
+func (sc *Scheduler) compressLog(node *Node) {
+	file := node.State().Log
+	if sc.LogCompression == "" || file == "" {
+		return
+	}
+	if err := logfile.Compress(file, sc.LogCompression); err != nil {
+		log.Printf("failed to compress the log of \"%s\": %v", node.step.Name, err)
+	}
+}
+
+// uploadLog uploads the log files of the finished node, including the
+// rotated ones. The run does not fail if they cannot be uploaded.
+func (sc *Scheduler) uploadLog(node *Node) {
+	file := node.State().Log
+	if sc.LogUploader == nil || file == "" {
+		return
+	}
+	for _, f := range logfile.Files(file) {
+		if err := sc.LogUploader.Upload(context.Background(), f); err != nil {
+			log.Printf("failed to upload the log of \"%s\": %v", node.step.Name, err)
+		}
+	}
+}
+
 func (sc *Scheduler) execNode(ctx context.Context, n *Node) error {
 	if !sc.Dry {
-		return n.Execute(ctx)
+		end := sc.traceNode(n)
+		err := sc.injectFaults(ctx, n, false)
+		if err == nil {
+			err = n.Execute(ctx)
+		}
+		end(err)
+		return err
 	}
 	return nil
 }
 
+// traceNode starts the span of the attempt of the node if the run is
+// traced, and returns the function that ends it.
+func (sc *Scheduler) traceNode(n *Node) func(error) {
+	if sc.Tracer == nil {
+		return func(error) {}
+	}
+	traceparent, end := sc.Tracer.StartStep(n.Step(), n.getRetryCount())
+	n.mu.Lock()
+	n.traceparent = traceparent
+	n.mu.Unlock()
+	return end
+}
+
 // Signal sends a signal to the scheduler.
 // for a node with repeat policy, it does not stop the node and
 // wait to finish current run.
@@ -255,6 +668,18 @@ func (sc *Scheduler) Signal(g *ExecutionGraph, sig os.Signal, done chan bool, al
 	}
 }
 
+// SignalSteps sends a signal to the nodes of the given steps. Other nodes
+// keep running.
+func (sc *Scheduler) SignalSteps(g *ExecutionGraph, sig os.Signal, steps []string) {
+	for _, node := range g.Nodes() {
+		for _, name := range steps {
+			if node.step.Name == name {
+				node.signal(sig, true)
+			}
+		}
+	}
+}
+
 // Cancel sends -1 signal to all nodes.
 func (sc *Scheduler) Cancel(g *ExecutionGraph) {
 	sc.setCanceled()
@@ -268,10 +693,14 @@ func (sc *Scheduler) Status(g *ExecutionGraph) Status {
 	if sc.isCanceled() && !sc.isSucceed(g) {
 		return StatusCancel
 	}
+	if sc.expired.Load() {
+		return StatusExpired
+	}
 	if !g.IsStarted() {
 		return StatusNone
 	}
-	if g.IsRunning() {
+	// A run that waits for a slot of its pool is running.
+	if g.IsRunning() || sc.QueuePosition() > 0 {
 		return StatusRunning
 	}
 	if sc.isError() {
@@ -286,6 +715,35 @@ func (sc *Scheduler) isError() bool {
 	return sc.lastError != nil
 }
 
+// RunHandler runs the handler of the name for the run of the status without
+// running the steps, e.g. the failure handler of a lost run. It returns nil
+// if there is no such handler.
+func (sc *Scheduler) RunHandler(ctx context.Context, name string, status Status) (*Node, error) {
+	if err := sc.setup(); err != nil {
+		return nil, err
+	}
+	node := sc.HandlerNode(name)
+	if node == nil {
+		return nil, nil
+	}
+	node.step.OutputVariables = &utils.SyncMap{}
+	node.step.StepOutputs = &utils.SyncMap{}
+	node.step.Variables = sc.handlerVariables(node.step.Variables, status)
+	_ = sc.runHandlerNode(ctx, node)
+	return node, nil
+}
+
+// handlerVariables returns the variables of a handler step with the ones of
+// the run it handles, so that a handler shared by the DAGs, e.g. one of the
+// base config, tells the runs apart.
+func (sc *Scheduler) handlerVariables(vars []string, status Status) []string {
+	return append(append([]string{}, vars...),
+		fmt.Sprintf("%s=%s", envDAGName, sc.DAGName),
+		fmt.Sprintf("%s=%s", envDAGRequestId, sc.RequestId),
+		fmt.Sprintf("%s=%s", envDAGStatus, status),
+	)
+}
+
 // HandlerNode returns the handler node with the given name.
 func (sc *Scheduler) HandlerNode(name string) *Node {
 	if v, ok := sc.handlers[name]; ok {
@@ -301,21 +759,31 @@ func (sc *Scheduler) isCanceled() bool {
 	return sc.canceled == 1
 }
 
+// isReady reports whether the upstream steps of the node are done. A step
+// that joins branches runs if at least one of its upstream branches was
+// taken; it is skipped only if none of them was.
 func isReady(g *ExecutionGraph, node *Node) bool {
 	ready := true
-	for _, dep := range g.to[node.id] {
-		n := g.node(dep)
+	taken, notTaken := 0, 0
+	for _, n := range g.upstream(node) {
 		switch n.State().Status {
 		case NodeStatusSuccess:
+			taken++
 			continue
-		case NodeStatusError:
+		case NodeStatusError, NodeStatusTimeout:
 			if !n.step.ContinueOn.Failure {
 				ready = false
 				node.setStatus(NodeStatusCancel)
 				node.SetError(errUpstreamFailed)
 			}
+			taken++
 		case NodeStatusSkipped:
-			if !n.step.ContinueOn.Skipped {
+			switch {
+			case n.step.ContinueOn.Skipped:
+				taken++
+			case errors.Is(n.State().Error, errBranchNotTaken):
+				notTaken++
+			default:
 				ready = false
 				node.setStatus(NodeStatusSkipped)
 				node.SetError(errUpstreamSkipped)
@@ -329,17 +797,33 @@ func isReady(g *ExecutionGraph, node *Node) bool {
 			ready = false
 		}
 	}
+	if ready && notTaken > 0 && taken == 0 {
+		ready = false
+		node.setStatus(NodeStatusSkipped)
+		node.SetError(fmt.Errorf("%w: no upstream branch was taken", errBranchNotTaken))
+	}
 	return ready
 }
 
 func (sc *Scheduler) runHandlerNode(ctx context.Context, node *Node) error {
 	defer func() {
-		node.FinishedAt = time.Now()
+		node.FinishedAt = node.now()
 	}()
 
 	node.setStatus(NodeStatusRunning)
 
 	if !sc.Dry {
+		if err := node.selectPlatform(); err != nil {
+			node.setErr(err)
+			return nil
+		}
+		if err := node.resolveOutputRefs(); err != nil {
+			node.setErr(err)
+			return nil
+		}
+		sc.setupLogForward(node)
+		sc.setupLogFormat(node)
+		node.clock = sc.Clock
 		err := node.setup(sc.LogDir, sc.RequestId)
 		if err != nil {
 			node.setStatus(NodeStatusError)
@@ -348,11 +832,14 @@ func (sc *Scheduler) runHandlerNode(ctx context.Context, node *Node) error {
 		defer func() {
 			_ = node.teardown()
 		}()
-		err = node.Execute(ctx)
-		if err != nil {
-			node.setStatus(NodeStatusError)
-		} else {
+		err = sc.execHandler(ctx, node)
+		switch {
+		case err == nil:
 			node.setStatus(NodeStatusSuccess)
+		case errors.Is(err, errStepTimeout):
+			node.setStatus(NodeStatusTimeout)
+		default:
+			node.setStatus(NodeStatusError)
 		}
 	} else {
 		node.setStatus(NodeStatusSuccess)
@@ -361,6 +848,36 @@ func (sc *Scheduler) runHandlerNode(ctx context.Context, node *Node) error {
 	return nil
 }
 
+// execHandler runs the handler with its retry policy. The handler is not
+// stopped when the context of the run is canceled, e.g. onCancel, but only
+// after its timeout, or HandlerTimeout if it has none.
+func (sc *Scheduler) execHandler(ctx context.Context, node *Node) error {
+	ctx = context.WithoutCancel(ctx)
+	if node.step.Timeout == 0 {
+		node.step.Timeout = sc.HandlerTimeout
+	}
+	for {
+		end := sc.traceNode(node)
+		err := sc.injectFaults(ctx, node, true)
+		if err == nil {
+			err = node.Execute(ctx)
+		}
+		end(err)
+		p := node.step.RetryPolicy
+		if err == nil || p == nil || p.Limit <= node.getRetryCount() || !p.ShouldRetry(err) {
+			return err
+		}
+		log.Printf("%s failed but scheduled for retry", node.step.Name)
+		node.incRetryCount()
+		sc.clock().Sleep(p.IntervalAt(node.getRetryCount()))
+		node.setRetriedAt(sc.clock().Now())
+	}
+}
+
+func (sc *Scheduler) clock() clock.Clock {
+	return clock.OrDefault(sc.Clock)
+}
+
 func (sc *Scheduler) setup() (err error) {
 	sc.pause = time.Millisecond * 100
 	if sc.LogDir == "" {
@@ -384,6 +901,9 @@ func (sc *Scheduler) setup() (err error) {
 	if sc.OnCancel != nil {
 		sc.handlers[constants.OnCancel] = &Node{step: *sc.OnCancel}
 	}
+	if sc.OnTimeout != nil {
+		sc.handlers[constants.OnTimeout] = &Node{step: *sc.OnTimeout}
+	}
 	return
 }
 
@@ -412,6 +932,16 @@ func (sc *Scheduler) isFinished(g *ExecutionGraph) bool {
 	return true
 }
 
+// isTimedOut reports whether a step of the run timed out.
+func isTimedOut(g *ExecutionGraph) bool {
+	for _, node := range g.Nodes() {
+		if node.State().Status == NodeStatusTimeout {
+			return true
+		}
+	}
+	return false
+}
+
 func (sc *Scheduler) isSucceed(g *ExecutionGraph) bool {
 	sc.mu.RLock()
 	defer sc.mu.RUnlock()
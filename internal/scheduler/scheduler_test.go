@@ -1,17 +1,33 @@
 package scheduler
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
+	"runtime"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
 
+	"github.com/dagu-dev/dagu/internal/clock"
 	"github.com/dagu-dev/dagu/internal/config"
 	"github.com/dagu-dev/dagu/internal/constants"
 	"github.com/dagu-dev/dagu/internal/dag"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/executor"
+	"github.com/dagu-dev/dagu/internal/logfile"
+	"github.com/dagu-dev/dagu/internal/pool"
+	"github.com/dagu-dev/dagu/internal/secret"
 	"github.com/dagu-dev/dagu/internal/utils"
 	"github.com/stretchr/testify/require"
 )
@@ -145,6 +161,54 @@ func TestSchedulerAllowSkipped(t *testing.T) {
 	require.Equal(t, NodeStatusSuccess, nodes[2].State().Status)
 }
 
+func TestSchedulerBranch(t *testing.T) {
+	s1 := step("1", "echo prod")
+	s1.Output = "BRANCH_ENV"
+	dev := step("dev", testCommand, "1")
+	dev.If = `$BRANCH_ENV == "dev"`
+	prod := step("prod", testCommand, "1")
+	prod.If = `$BRANCH_ENV == "prod"`
+
+	g, sc, err := testSchedule(t,
+		s1,
+		dev,
+		step("after dev", testCommand, "dev"),
+		prod,
+		step("join", testCommand, "after dev", "prod"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, sc.Status(g), StatusSuccess)
+
+	nodes := g.Nodes()
+	require.Equal(t, NodeStatusSuccess, nodes[0].State().Status)
+	require.Equal(t, NodeStatusSkipped, nodes[1].State().Status)
+	require.ErrorIs(t, nodes[1].State().Error, errBranchNotTaken)
+	require.Equal(t, NodeStatusSkipped, nodes[2].State().Status)
+	require.ErrorIs(t, nodes[2].State().Error, errBranchNotTaken)
+	require.Equal(t, NodeStatusSuccess, nodes[3].State().Status)
+	require.Equal(t, NodeStatusSuccess, nodes[4].State().Status)
+}
+
+func TestSchedulerNoBranchTaken(t *testing.T) {
+	a := step("a", testCommand)
+	a.If = "false"
+	b := step("b", testCommand)
+	b.If = "0"
+	invalid := step("invalid", testCommand)
+	invalid.If = "`false`"
+
+	g, sc, err := testSchedule(t, a, b, step("join", testCommand, "a", "b"), invalid)
+	require.Error(t, err)
+	require.Equal(t, sc.Status(g), StatusError)
+
+	nodes := g.Nodes()
+	require.Equal(t, NodeStatusSkipped, nodes[0].State().Status)
+	require.Equal(t, NodeStatusSkipped, nodes[1].State().Status)
+	require.Equal(t, NodeStatusSkipped, nodes[2].State().Status)
+	require.ErrorIs(t, nodes[2].State().Error, errBranchNotTaken)
+	require.Equal(t, NodeStatusError, nodes[3].State().Status)
+}
+
 func TestSchedulerCancel(t *testing.T) {
 
 	g, _ := NewExecutionGraph(
@@ -171,6 +235,38 @@ func TestSchedulerCancel(t *testing.T) {
 	require.Equal(t, NodeStatusNone, nodes[2].State().Status)
 }
 
+func TestSchedulerMarkStep(t *testing.T) {
+	mark := func(g *ExecutionGraph, sc *Scheduler, status NodeStatus) {
+		t.Helper()
+		require.Eventually(t, func() bool {
+			return g.Nodes()[0].State().Status == NodeStatusRunning
+		}, time.Second, time.Millisecond*10)
+		require.NoError(t, sc.MarkStep(g, "1", status, "confirmed by the vendor"))
+	}
+
+	g, sc := newTestSchedule(t, &Config{}, step("1", "sleep 1000"), step("2", testCommand, "1"))
+	go mark(g, sc, NodeStatusSuccess)
+	require.NoError(t, sc.Schedule(context.Background(), g, nil))
+	require.Equal(t, StatusSuccess, sc.Status(g))
+	nodes := g.Nodes()
+	require.Equal(t, NodeStatusSuccess, nodes[0].State().Status)
+	require.Equal(t, "confirmed by the vendor", nodes[0].State().MarkedReason)
+	require.Equal(t, NodeStatusSuccess, nodes[1].State().Status)
+
+	g, sc = newTestSchedule(t, &Config{}, step("1", "sleep 1000"), step("2", testCommand, "1"))
+	go mark(g, sc, NodeStatusError)
+	require.ErrorIs(t, sc.Schedule(context.Background(), g, nil), errMarkedFailed)
+	require.Equal(t, StatusError, sc.Status(g))
+	nodes = g.Nodes()
+	require.Equal(t, NodeStatusError, nodes[0].State().Status)
+	require.Equal(t, NodeStatusCancel, nodes[1].State().Status)
+
+	require.ErrorIs(t, sc.MarkStep(g, "1", NodeStatusSuccess, "done"), errStepNotRunning)
+	require.Equal(t, dagerrors.CodeStepNotRunning, dagerrors.CodeOf(sc.MarkStep(g, "1", NodeStatusSuccess, "done")))
+	require.ErrorIs(t, sc.MarkStep(g, "3", NodeStatusSuccess, "done"), errStepNotFound)
+	require.ErrorIs(t, sc.MarkStep(g, "1", NodeStatusCancel, "done"), errInvalidMark)
+}
+
 func TestSchedulerRetryFail(t *testing.T) {
 	cmd := path.Join(utils.MustGetwd(), "testdata/testfile.sh")
 	g, sc, err := testSchedule(t,
@@ -208,6 +304,124 @@ func TestSchedulerRetryFail(t *testing.T) {
 	require.Equal(t, nodes[1].State().RetryCount, 1)
 }
 
+func TestSchedulerRetryExitCodes(t *testing.T) {
+	g, sc, err := testSchedule(t,
+		dag.Step{
+			Name:        "1",
+			Command:     "sh",
+			Args:        []string{"-c", "exit 3"},
+			ContinueOn:  dag.ContinueOn{Failure: true},
+			RetryPolicy: &dag.RetryPolicy{Limit: 2, ExitCodes: []int{3}, Backoff: 2, Interval: time.Millisecond},
+		},
+		dag.Step{
+			Name:        "2",
+			Command:     "sh",
+			Args:        []string{"-c", "exit 1"},
+			RetryPolicy: &dag.RetryPolicy{Limit: 2, ExitCodes: []int{3}},
+			Depends:     []string{"1"},
+		},
+	)
+	require.Error(t, err)
+	require.Equal(t, sc.Status(g), StatusError)
+
+	nodes := g.Nodes()
+	require.Equal(t, NodeStatusError, nodes[0].State().Status)
+	require.Equal(t, 2, nodes[0].State().RetryCount)
+	require.Equal(t, NodeStatusError, nodes[1].State().Status)
+	require.Equal(t, 0, nodes[1].State().RetryCount)
+}
+
+func TestSchedulerStepTimeout(t *testing.T) {
+	start := time.Now()
+	g, sc, err := testSchedule(t,
+		dag.Step{
+			Name:            "1",
+			Command:         "sleep",
+			Args:            []string{"5"},
+			Timeout:         time.Millisecond * 100,
+			KillGracePeriod: time.Second * 5,
+		},
+		dag.Step{
+			// The stop signal is ignored, so the step is killed after the
+			// grace period.
+			Name:            "2",
+			Command:         "sh",
+			Args:            []string{"-c", "trap '' TERM; sleep 5"},
+			Timeout:         time.Millisecond * 100,
+			KillGracePeriod: time.Millisecond * 200,
+		},
+		step("3", testCommand, "1"),
+	)
+	require.ErrorIs(t, err, errStepTimeout)
+	require.Less(t, time.Since(start), time.Second*3)
+	require.Equal(t, sc.Status(g), StatusError)
+
+	nodes := g.Nodes()
+	require.Equal(t, NodeStatusTimeout, nodes[0].State().Status)
+	require.ErrorIs(t, nodes[0].State().Error, errStepTimeout)
+	require.Equal(t, "timed out", nodes[0].State().Status.String())
+	require.Equal(t, NodeStatusTimeout, nodes[1].State().Status)
+	require.Equal(t, NodeStatusCancel, nodes[2].State().Status)
+}
+
+func TestSchedulerStepTimeoutNotReached(t *testing.T) {
+	g, sc, err := testSchedule(t,
+		dag.Step{
+			Name:    "1",
+			Command: testCommand,
+			Timeout: time.Second * 5,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, sc.Status(g), StatusSuccess)
+	require.Equal(t, NodeStatusSuccess, g.Nodes()[0].State().Status)
+}
+
+func TestSchedulerClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("RetryInterval", func(t *testing.T) {
+		c := clock.NewFake(start)
+		c.Auto = true
+		g, sc := newTestSchedule(t, &Config{MaxActiveRuns: 2, Clock: c},
+			dag.Step{
+				Name:        "1",
+				Command:     "sh",
+				Args:        []string{"-c", "exit 1"},
+				RetryPolicy: &dag.RetryPolicy{Limit: 1, Interval: time.Hour},
+			},
+		)
+		now := time.Now()
+		require.Error(t, sc.Schedule(context.Background(), g, nil))
+		require.Less(t, time.Since(now), time.Second*3)
+
+		node := g.Nodes()[0].State()
+		require.Equal(t, 1, node.RetryCount)
+		require.Equal(t, start.Add(time.Hour), node.RetriedAt)
+	})
+
+	t.Run("StepTimeout", func(t *testing.T) {
+		c := clock.NewFake(start)
+		g, sc := newTestSchedule(t, &Config{MaxActiveRuns: 2, Clock: c},
+			dag.Step{
+				Name:            "1",
+				Command:         "sleep",
+				Args:            []string{"5"},
+				Timeout:         time.Hour,
+				KillGracePeriod: time.Second * 5,
+			},
+		)
+		done := make(chan error)
+		go func() { done <- sc.Schedule(context.Background(), g, nil) }()
+
+		require.Eventually(t, func() bool { return c.Waiters() == 1 }, time.Second*2, time.Millisecond*10)
+		c.Advance(time.Hour)
+		require.ErrorIs(t, <-done, errStepTimeout)
+		require.Equal(t, NodeStatusTimeout, g.Nodes()[0].State().Status)
+		require.Equal(t, start.Add(time.Hour), g.Nodes()[0].State().FinishedAt)
+	})
+}
+
 func TestSchedulerRetrySuccess(t *testing.T) {
 	cmd := path.Join(utils.MustGetwd(), "testdata/testfile.sh")
 	tmpDir, err := os.MkdirTemp("", "scheduler_test")
@@ -471,6 +685,87 @@ func TestSchedulerOnFailure(t *testing.T) {
 	require.Equal(t, NodeStatusNone, sc.HandlerNode(constants.OnCancel).State().Status)
 }
 
+func TestSchedulerOnTimeout(t *testing.T) {
+	onFailure := step("onFailure", testCommand)
+	onTimeout := step("onTimeout", testCommand)
+	timeoutStep := dag.Step{
+		Name:            "1",
+		Command:         "sleep",
+		Args:            []string{"5"},
+		Timeout:         time.Millisecond * 100,
+		KillGracePeriod: time.Second,
+	}
+	g, sc := newTestSchedule(t,
+		&Config{OnFailure: &onFailure, OnTimeout: &onTimeout},
+		timeoutStep,
+	)
+	err := sc.Schedule(context.Background(), g, nil)
+	require.ErrorIs(t, err, errStepTimeout)
+	require.Equal(t, StatusError, sc.Status(g))
+	require.Equal(t, NodeStatusSuccess, sc.HandlerNode(constants.OnTimeout).State().Status)
+	require.Equal(t, NodeStatusNone, sc.HandlerNode(constants.OnFailure).State().Status)
+
+	// onFailure runs when the run fails for another reason.
+	g, sc = newTestSchedule(t,
+		&Config{OnFailure: &onFailure, OnTimeout: &onTimeout},
+		step("1", testCommandFail),
+	)
+	err = sc.Schedule(context.Background(), g, nil)
+	require.Error(t, err)
+	require.Equal(t, NodeStatusNone, sc.HandlerNode(constants.OnTimeout).State().Status)
+	require.Equal(t, NodeStatusSuccess, sc.HandlerNode(constants.OnFailure).State().Status)
+
+	// onFailure runs for a timeout without onTimeout.
+	g, sc = newTestSchedule(t, &Config{OnFailure: &onFailure}, timeoutStep)
+	err = sc.Schedule(context.Background(), g, nil)
+	require.ErrorIs(t, err, errStepTimeout)
+	require.Equal(t, NodeStatusSuccess, sc.HandlerNode(constants.OnFailure).State().Status)
+}
+
+func TestSchedulerHandlerTimeout(t *testing.T) {
+	onExit := step("onExit", "sleep 5")
+	onExit.KillGracePeriod = time.Second
+	g, sc := newTestSchedule(t,
+		&Config{OnExit: &onExit, HandlerTimeout: time.Millisecond * 100},
+		step("1", testCommand),
+	)
+	started := time.Now()
+	err := sc.Schedule(context.Background(), g, nil)
+	require.NoError(t, err)
+	require.Less(t, time.Since(started), time.Second*3)
+	require.Equal(t, StatusSuccess, sc.Status(g))
+	require.Equal(t, NodeStatusTimeout, sc.HandlerNode(constants.OnExit).State().Status)
+}
+
+func TestSchedulerHandlerRetry(t *testing.T) {
+	onFailure := step("onFailure", testCommandFail)
+	onFailure.RetryPolicy = &dag.RetryPolicy{Limit: 2}
+	g, sc := newTestSchedule(t,
+		&Config{OnFailure: &onFailure},
+		step("1", testCommandFail),
+	)
+	err := sc.Schedule(context.Background(), g, nil)
+	require.Error(t, err)
+	node := sc.HandlerNode(constants.OnFailure)
+	require.Equal(t, NodeStatusError, node.State().Status)
+	require.Equal(t, 2, node.State().RetryCount)
+}
+
+func TestSchedulerHandlerVariables(t *testing.T) {
+	out := path.Join(t.TempDir(), "handled")
+	onFailure := step("onFailure", "sh")
+	onFailure.Script = fmt.Sprintf("echo $DAG_NAME $DAG_REQUEST_ID $DAG_STATUS > %s", out)
+	g, sc := newTestSchedule(t,
+		&Config{OnFailure: &onFailure, DAGName: "etl", RequestId: "request-1"},
+		step("1", testCommandFail),
+	)
+	err := sc.Schedule(context.Background(), g, nil)
+	require.Error(t, err)
+	b, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, "etl request-1 failed\n", string(b))
+}
+
 func TestRepeat(t *testing.T) {
 	g, _ := NewExecutionGraph(
 		dag.Step{
@@ -523,6 +818,55 @@ func TestRepeatFail(t *testing.T) {
 	require.Equal(t, nodes[0].DoneCount, 1)
 }
 
+func TestRepeatUntil(t *testing.T) {
+	file := path.Join(t.TempDir(), "count")
+	g, _ := NewExecutionGraph(
+		dag.Step{
+			Name:    "1",
+			Command: "sh",
+			Args:    []string{"-c", fmt.Sprintf("echo x >> %s; wc -l < %s", file, file)},
+			Output:  "REPEAT_COUNT",
+			RepeatPolicy: dag.RepeatPolicy{
+				Repeat:   true,
+				Interval: time.Millisecond * 10,
+				Until:    "$REPEAT_COUNT >= 3",
+				Backoff:  2,
+			},
+		},
+	)
+	sc := &Scheduler{Config: &Config{}}
+	err := sc.Schedule(context.Background(), g, nil)
+	require.NoError(t, err)
+
+	nodes := g.Nodes()
+	require.Equal(t, sc.Status(g), StatusSuccess)
+	require.Equal(t, NodeStatusSuccess, nodes[0].State().Status)
+	require.Equal(t, 3, nodes[0].State().DoneCount)
+}
+
+func TestRepeatUntilLimit(t *testing.T) {
+	g, _ := NewExecutionGraph(
+		dag.Step{
+			Name:    "1",
+			Command: testCommand,
+			RepeatPolicy: dag.RepeatPolicy{
+				Repeat:   true,
+				Interval: time.Millisecond * 10,
+				Until:    `"$REPEAT_NEVER_SET" == done`,
+				Limit:    2,
+			},
+		},
+	)
+	sc := &Scheduler{Config: &Config{}}
+	err := sc.Schedule(context.Background(), g, nil)
+	require.ErrorIs(t, err, errRepeatUntilNotMet)
+
+	nodes := g.Nodes()
+	require.Equal(t, sc.Status(g), StatusError)
+	require.Equal(t, NodeStatusError, nodes[0].State().Status)
+	require.Equal(t, 2, nodes[0].State().DoneCount)
+}
+
 func TestStopRepetitiveTaskGracefully(t *testing.T) {
 	g, _ := NewExecutionGraph(
 		dag.Step{
@@ -644,6 +988,472 @@ func TestTakeOutputFromPrevStep(t *testing.T) {
 	require.Equal(t, "take-output", os.ExpandEnv("$TOOK_PREV_OUT"))
 }
 
+func TestStepStdin(t *testing.T) {
+	file := path.Join(t.TempDir(), "input.txt")
+	require.NoError(t, os.WriteFile(file, []byte("from-file"), 0600))
+	t.Setenv("STDIN_PARAM", "from-param")
+
+	s1 := step("1", "echo from-output")
+	s1.Output = "STDIN_PREV_OUT"
+	steps := []dag.Step{s1}
+	for name, stdin := range map[string]*dag.Stdin{
+		"VALUE":  {Value: "value of ${STDIN_PREV_OUT}"},
+		"FILE":   {File: file},
+		"PARAM":  {Param: "STDIN_PARAM"},
+		"OUTPUT": {Output: "STDIN_PREV_OUT"},
+	} {
+		s := step(name, "cat", "1")
+		s.Stdin = stdin
+		s.Output = "STDIN_" + name
+		steps = append(steps, s)
+	}
+
+	g, sc := newTestSchedule(t, &Config{}, steps...)
+	err := sc.Schedule(context.Background(), g, nil)
+	require.NoError(t, err)
+	require.Equal(t, "value of from-output", os.Getenv("STDIN_VALUE"))
+	require.Equal(t, "from-file", os.Getenv("STDIN_FILE"))
+	require.Equal(t, "from-param", os.Getenv("STDIN_PARAM"))
+	require.Equal(t, "from-output", os.Getenv("STDIN_OUTPUT"))
+
+	// The step fails if its source is not set.
+	s := step("1", "cat")
+	s.Stdin = &dag.Stdin{Output: "STDIN_UNKNOWN"}
+	g, sc = newTestSchedule(t, &Config{}, s)
+	require.Error(t, sc.Schedule(context.Background(), g, nil))
+	require.ErrorIs(t, g.Nodes()[0].State().Error, errStdinOutputNotSet)
+}
+
+func TestStepOutputRefs(t *testing.T) {
+	fetch := dag.Step{
+		Name:    "fetch",
+		Command: "sh",
+		Args:    []string{"-c", `echo '{"items": [{"id": "a1"}, {"id": "a2"}]}' > $DAG_OUTPUT_FILE`},
+	}
+	count := dag.Step{
+		Name:    "count",
+		Command: "sh",
+		Args:    []string{"-c", `echo '{"n": 2}'`},
+		Output:  "COUNT_JSON",
+	}
+	use := dag.Step{
+		Name:        "use",
+		CmdWithArgs: "echo ${steps.fetch.output.items[1].id}-${steps.count.output.n}",
+		Output:      "OUTPUT_REFS",
+		Depends:     []string{"fetch", "count"},
+	}
+	script := dag.Step{
+		Name:    "script",
+		Command: "sh",
+		Script:  "echo '${steps.fetch.output.items[0]}'",
+		Output:  "OUTPUT_REFS_SCRIPT",
+		Depends: []string{"fetch"},
+	}
+	g, sc, err := testSchedule(t, fetch, count, use, script)
+	require.NoError(t, err)
+	require.Equal(t, StatusSuccess, sc.Status(g))
+	require.Equal(t, "a2-2", os.Getenv("OUTPUT_REFS"))
+	require.Equal(t, `{"id":"a1"}`, os.Getenv("OUTPUT_REFS_SCRIPT"))
+}
+
+func TestStepOutputRefNotFound(t *testing.T) {
+	missing := dag.Step{
+		Name:        "2",
+		CmdWithArgs: "echo ${steps.1.output.id}",
+		Depends:     []string{"1"},
+	}
+	g, sc, err := testSchedule(t, step("1", testCommand), missing)
+	require.ErrorIs(t, err, errOutputNotFound)
+	require.Equal(t, StatusError, sc.Status(g))
+	require.Equal(t, NodeStatusError, g.Nodes()[1].State().Status)
+}
+
+func TestStepPlatforms(t *testing.T) {
+	s1 := step("1", "echo default")
+	s1.Output = "PLATFORM_OUT"
+	s1.Platforms = map[string]dag.PlatformCommand{
+		runtime.GOOS: {Command: "echo", Args: []string{"os"}},
+		"plan9":      {Command: "echo", Args: []string{"plan9"}},
+	}
+	s2 := dag.Step{
+		Name:      "2",
+		Platforms: map[string]dag.PlatformCommand{"plan9": {Command: "true"}},
+	}
+	g, sc, err := testSchedule(t, s1, s2)
+	require.Error(t, err)
+	require.Equal(t, "os", os.Getenv("PLATFORM_OUT"))
+	require.Equal(t, NodeStatusError, g.Nodes()[1].State().Status)
+	require.Contains(t, g.Nodes()[1].State().Error.Error(), "the step has no command for the platform")
+	require.Equal(t, StatusError, sc.Status(g))
+}
+
+type testStepCache map[string]*CachedResult
+
+func (c testStepCache) Get(key string) (*CachedResult, error) {
+	r, ok := c[key]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return r, nil
+}
+
+func (c testStepCache) Put(key string, r *CachedResult) error {
+	c[key] = r
+	return nil
+}
+
+func TestStepCache(t *testing.T) {
+	counter := path.Join(t.TempDir(), "counter")
+	s1 := dag.Step{
+		Name:    "1",
+		Command: "sh",
+		Args:    []string{"-c", "echo x >> " + counter + "; echo cached"},
+		Output:  "CACHE_OUT",
+		Cache:   &dag.CachePolicy{},
+	}
+	cache := testStepCache{}
+	run := func(cfg *Config, s dag.Step) *ExecutionGraph {
+		t.Helper()
+		_ = os.Unsetenv("CACHE_OUT")
+		cfg.StepCache = cache
+		g, sc := newTestSchedule(t, cfg, s)
+		require.NoError(t, sc.Schedule(context.Background(), g, nil))
+		require.Equal(t, StatusSuccess, sc.Status(g))
+		require.Equal(t, "cached", os.Getenv("CACHE_OUT"))
+		return g
+	}
+	runs := func() int {
+		b, err := os.ReadFile(counter)
+		require.NoError(t, err)
+		return len(b) / 2
+	}
+
+	run(&Config{RequestId: "1"}, s1)
+	run(&Config{RequestId: "2"}, s1)
+	require.Equal(t, 1, runs())
+	require.Len(t, cache, 1)
+
+	// NoCache runs the step and replaces the result.
+	run(&Config{RequestId: "3", NoCache: true}, s1)
+	require.Equal(t, 2, runs())
+	for _, r := range cache {
+		require.Equal(t, "3", r.RequestId)
+	}
+
+	// Other parameters make another key.
+	run(&Config{RequestId: "4", Params: "DATE=2024-01-01"}, s1)
+	require.Equal(t, 3, runs())
+
+	// The result expires after maxAge.
+	for _, r := range cache {
+		r.CachedAt = time.Now().Add(-time.Hour)
+	}
+	s1.Cache = &dag.CachePolicy{MaxAge: time.Minute}
+	run(&Config{RequestId: "5"}, s1)
+	require.Equal(t, 4, runs())
+}
+
+func TestStepGuard(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"credits": {"remaining": 40}}`))
+	}))
+	defer srv.Close()
+	headers := map[string]string{"Authorization": "Bearer secret"}
+
+	s1 := step("1", "true")
+	s1.Guard = &dag.Guard{HTTP: srv.URL, Headers: headers, Path: "credits.remaining", Expr: "$GUARD_VALUE > 10", OnExceeded: dag.GuardFail}
+	s2 := step("2", "true")
+	s2.Guard = &dag.Guard{HTTP: srv.URL, Headers: headers, Path: "credits.remaining", Expr: "$GUARD_VALUE > 100", OnExceeded: dag.GuardSkip}
+	s3 := step("3", "true")
+	s3.Guard = &dag.Guard{Command: "echo 1500", Expr: "$GUARD_VALUE < 1000", OnExceeded: dag.GuardFail}
+	s4 := step("4", "true")
+	s4.Guard = &dag.Guard{HTTP: srv.URL, Expr: "$GUARD_VALUE", OnExceeded: dag.GuardSkip}
+
+	g, _, err := testSchedule(t, s1, s2, s3, s4)
+	require.Error(t, err)
+	nodes := g.Nodes()
+	require.Equal(t, NodeStatusSuccess, nodes[0].State().Status)
+	require.Equal(t, NodeStatusSkipped, nodes[1].State().Status)
+	require.ErrorIs(t, nodes[1].State().Error, errGuardExceeded)
+	require.Contains(t, nodes[1].State().Error.Error(), "GUARD_VALUE=40")
+	require.Equal(t, NodeStatusError, nodes[2].State().Status)
+	require.ErrorIs(t, nodes[2].State().Error, errGuardExceeded)
+	// A guard that cannot be checked fails the step even if it would be
+	// skipped.
+	require.Equal(t, NodeStatusError, nodes[3].State().Status)
+	require.Contains(t, nodes[3].State().Error.Error(), "401")
+}
+
+func TestExpectedFiles(t *testing.T) {
+	dir := t.TempDir()
+	old := path.Join(dir, "old.csv")
+	require.NoError(t, os.WriteFile(old, []byte("a,b\n"), 0600))
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(old, past, past))
+
+	// The command writes the files.
+	s1 := step("1", "sh -c 'printf 12345 > out-1.csv; printf 1 > out-2.csv'")
+	s1.Dir = dir
+	s1.ExpectedFiles = []dag.ExpectedFile{{Path: "out-*.csv", MinCount: 2}}
+	// The command exits 0 without writing the files.
+	s2 := step("2", "true")
+	s2.Dir = dir
+	s2.ExpectedFiles = []dag.ExpectedFile{
+		{Path: "missing.csv", MinCount: 1},
+		{Path: "out-*.csv", MinSize: 3, MinCount: 3},
+		{Path: old, MinCount: 1, Fresh: true},
+	}
+	s2.Depends = []string{"1"}
+	// The files are not checked if the command fails.
+	s3 := step("3", "false")
+	s3.Dir = dir
+	s3.ExpectedFiles = []dag.ExpectedFile{{Path: "missing.csv", MinCount: 1}}
+
+	g, _, err := testSchedule(t, s1, s2, s3)
+	require.Error(t, err)
+	nodes := g.Nodes()
+	require.Equal(t, NodeStatusSuccess, nodes[0].State().Status)
+
+	require.Equal(t, NodeStatusError, nodes[1].State().Status)
+	require.ErrorIs(t, nodes[1].State().Error, errExpectedFiles)
+	msg := nodes[1].State().Error.Error()
+	require.Contains(t, msg, path.Join(dir, "missing.csv")+" does not exist")
+	require.Contains(t, msg, path.Join(dir, "out-2.csv")+" is 1 bytes, less than the minSize of 3 bytes")
+	require.Contains(t, msg, "out-*.csv matched 2 files, less than the minCount of 3")
+	require.Contains(t, msg, old+" was not modified by the step")
+	require.NotContains(t, msg, "out-1.csv")
+
+	require.Equal(t, NodeStatusError, nodes[2].State().Status)
+	require.NotErrorIs(t, nodes[2].State().Error, errExpectedFiles)
+}
+
+func TestJSONLogFormat(t *testing.T) {
+	s1 := dag.Step{
+		Name:    "1",
+		Command: "sh",
+		Args:    []string{"-c", "echo out; echo err >&2; printf last"},
+		Output:  "JSON_LOG_OUT",
+	}
+	g, sc := newTestSchedule(t, &Config{RequestId: "req-1", DAGName: "report", LogFormat: dag.LogFormatJSON}, s1)
+	require.NoError(t, sc.Schedule(context.Background(), g, nil))
+
+	b, err := os.ReadFile(g.Nodes()[0].State().Log)
+	require.NoError(t, err)
+	var records []LogRecord
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		var r LogRecord
+		require.NoError(t, json.Unmarshal([]byte(line), &r), line)
+		require.False(t, r.Time.IsZero())
+		r.Time = time.Time{}
+		records = append(records, r)
+	}
+	record := LogRecord{DAG: "report", Step: "1", RequestId: "req-1"}
+	withLine := func(stream, msg string) LogRecord {
+		r := record
+		r.Stream, r.Message = stream, msg
+		return r
+	}
+	require.ElementsMatch(t, []LogRecord{
+		withLine(streamStdout, "out"),
+		withLine(streamStderr, "err"),
+		withLine(streamStdout, "last"),
+	}, records)
+	// The output of the step is not wrapped.
+	require.Contains(t, os.Getenv("JSON_LOG_OUT"), "out\n")
+
+	plain := PlainLog(append(b, []byte("not a record\n")...))
+	require.ElementsMatch(t, []string{"out", "err", "last", "not a record"}, strings.Split(strings.TrimSpace(string(plain)), "\n"))
+}
+
+func TestLogCompression(t *testing.T) {
+	s1 := dag.Step{
+		Name:        "1",
+		Command:     "seq",
+		Args:        []string{"1", "2000"},
+		LogRotation: &dag.LogRotation{MaxBytes: 4096, MaxFiles: 5},
+	}
+	g, sc := newTestSchedule(t, &Config{LogCompression: logfile.CompressionGzip}, s1)
+	require.NoError(t, sc.Schedule(context.Background(), g, nil))
+
+	file := g.Nodes()[0].State().Log
+	files := logfile.Files(file)
+	require.Greater(t, len(files), 1)
+	for _, f := range files {
+		require.True(t, strings.HasSuffix(f, ".gz"), f)
+	}
+	b, err := logfile.ReadFile(file)
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(string(b), "2000\n"))
+}
+
+func TestLogKey(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, logfile.KeySize)
+	require.NoError(t, logfile.RegisterKey(key))
+	s1 := dag.Step{Name: "1", Command: "echo", Args: []string{"secret"}}
+	g, sc := newTestSchedule(t, &Config{LogKey: key}, s1)
+	require.NoError(t, sc.Schedule(context.Background(), g, nil))
+
+	// The log of the step is encrypted, and read with the key.
+	file := g.Nodes()[0].State().Log
+	raw, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.NotContains(t, string(raw), "secret")
+	b, err := logfile.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, "secret\n", string(b))
+}
+
+type testLogUploader struct {
+	files []string
+}
+
+func (u *testLogUploader) Upload(_ context.Context, file string) error {
+	u.files = append(u.files, file)
+	return nil
+}
+
+func TestLogUploader(t *testing.T) {
+	s1 := dag.Step{Name: "1", Command: "true"}
+	s2 := dag.Step{Name: "2", Command: "true", Depends: []string{"1"}}
+	u := &testLogUploader{}
+	g, sc := newTestSchedule(t, &Config{LogCompression: logfile.CompressionGzip, LogUploader: u}, s1, s2)
+	require.NoError(t, sc.Schedule(context.Background(), g, nil))
+
+	var want []string
+	for _, n := range g.Nodes() {
+		want = append(want, n.State().Log+".gz")
+	}
+	require.Equal(t, want, u.files)
+}
+
+// testPools are pools of size 1 that count the slots taken.
+type testPools struct {
+	slots map[string]chan struct{}
+	taken atomic.Int32
+	mu    sync.Mutex
+	keys  []string
+}
+
+// AcquireKey takes the slot of the pool of the key, which is created when it
+// is first acquired.
+func (p *testPools) AcquireKey(ctx context.Context, key string, e pool.Entry, queued func(int)) (func(), error) {
+	p.mu.Lock()
+	if _, ok := p.slots[key]; !ok {
+		p.slots[key] = make(chan struct{}, 1)
+	}
+	p.keys = append(p.keys, key)
+	p.mu.Unlock()
+	return p.Acquire(ctx, key, e, queued)
+}
+
+func (p *testPools) Acquire(ctx context.Context, name string, _ pool.Entry, queued func(int)) (func(), error) {
+	p.mu.Lock()
+	slot, ok := p.slots[name]
+	p.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown pool %s", name)
+	}
+	if queued != nil {
+		queued(1)
+	}
+	select {
+	case slot <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	p.taken.Add(1)
+	return func() { <-slot }, nil
+}
+
+func TestConcurrencyPools(t *testing.T) {
+	counter := path.Join(t.TempDir(), "counter")
+	// Each step fails if another one runs at the same time.
+	script := fmt.Sprintf("mkdir %s && sleep 0.2 && rmdir %s", counter, counter)
+	var steps []dag.Step
+	for _, name := range []string{"1", "2", "3"} {
+		s := dag.Step{Name: name, Command: "sh", Args: []string{"-c", script}, Pool: "database"}
+		steps = append(steps, s)
+	}
+	pools := &testPools{slots: map[string]chan struct{}{"database": make(chan struct{}, 1), "dags": make(chan struct{}, 1)}}
+	g, sc := newTestSchedule(t, &Config{MaxActiveRuns: 3, Pool: "dags", Pools: pools}, steps...)
+	require.NoError(t, sc.Schedule(context.Background(), g, nil))
+	require.Equal(t, StatusSuccess, sc.Status(g))
+	require.Equal(t, int32(4), pools.taken.Load())
+	// The slot of the run is released.
+	require.Empty(t, pools.slots["dags"])
+
+	// The run waits in the queue while the slot of the pool is taken.
+	pools.slots["dags"] <- struct{}{}
+	g, sc = newTestSchedule(t, &Config{Pool: "dags", Pools: pools}, step("1", "true"))
+	errc := make(chan error)
+	go func() {
+		errc <- sc.Schedule(context.Background(), g, nil)
+	}()
+	require.Eventually(t, func() bool { return sc.QueuePosition() == 1 }, time.Second, time.Millisecond*10)
+	require.Equal(t, StatusRunning, sc.Status(g))
+	<-pools.slots["dags"]
+	require.NoError(t, <-errc)
+	require.Equal(t, 0, sc.QueuePosition())
+
+	g, sc = newTestSchedule(t, &Config{}, step("1", "true"))
+	g.Nodes()[0].step.Pool = "database"
+	require.Error(t, sc.Schedule(context.Background(), g, nil))
+	require.ErrorIs(t, g.Nodes()[0].State().Error, errNoPools)
+
+	g, sc = newTestSchedule(t, &Config{Pool: "missing", Pools: pools}, step("1", "true"))
+	require.ErrorContains(t, sc.Schedule(context.Background(), g, nil), "unknown pool missing")
+	require.Equal(t, StatusError, sc.Status(g))
+	require.Equal(t, NodeStatusNone, g.Nodes()[0].State().Status)
+}
+
+func TestConcurrencyKeys(t *testing.T) {
+	t.Setenv("CUSTOMER_ID", "customer-1")
+	counter := path.Join(t.TempDir(), "counter")
+	// Each step fails if the step of the other run runs at the same time.
+	script := fmt.Sprintf("mkdir %s && sleep 0.2 && rmdir %s", counter, counter)
+	pools := &testPools{slots: map[string]chan struct{}{}}
+	errc := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		s := dag.Step{Name: "1", Command: "sh", Args: []string{"-c", script}, ConcurrencyKey: "${CUSTOMER_ID}"}
+		g, sc := newTestSchedule(t, &Config{DAGName: "etl", Pools: pools}, s)
+		go func() {
+			errc <- sc.Schedule(context.Background(), g, nil)
+		}()
+	}
+	require.NoError(t, <-errc)
+	require.NoError(t, <-errc)
+	require.Equal(t, []string{"etl/customer-1", "etl/customer-1"}, pools.keys)
+
+	s := step("1", "true")
+	s.ConcurrencyKey = "${CUSTOMER_ID}"
+	g, sc := newTestSchedule(t, &Config{}, s)
+	require.Error(t, sc.Schedule(context.Background(), g, nil))
+	require.ErrorIs(t, g.Nodes()[0].State().Error, errNoPools)
+}
+
+func TestQueueTTL(t *testing.T) {
+	pools := &testPools{slots: map[string]chan struct{}{"dags": make(chan struct{}, 1)}}
+	pools.slots["dags"] <- struct{}{}
+	onCancel := step("onCancel", "true")
+	g, sc := newTestSchedule(t, &Config{Pool: "dags", Pools: pools, QueueTTL: time.Millisecond * 100, OnCancel: &onCancel}, step("1", "true"))
+	err := sc.Schedule(context.Background(), g, nil)
+	require.ErrorIs(t, err, errQueueExpired)
+	require.Equal(t, StatusExpired, sc.Status(g))
+	require.Equal(t, NodeStatusNone, g.Nodes()[0].State().Status)
+	require.Equal(t, NodeStatusSuccess, sc.HandlerNode(constants.OnCancel).State().Status)
+	require.Equal(t, 0, sc.QueuePosition())
+
+	// The run starts if it gets a slot in time.
+	<-pools.slots["dags"]
+	g, sc = newTestSchedule(t, &Config{Pool: "dags", Pools: pools, QueueTTL: time.Second}, step("1", "true"))
+	require.NoError(t, sc.Schedule(context.Background(), g, nil))
+	require.Equal(t, StatusSuccess, sc.Status(g))
+}
+
 func step(name, command string, depends ...string) dag.Step {
 	cmd, args := utils.SplitCommand(command, false)
 	return dag.Step{
@@ -671,3 +1481,287 @@ func newTestSchedule(t *testing.T, cfg *Config, steps ...dag.Step) (
 	require.NoError(t, err)
 	return g, &Scheduler{Config: cfg}
 }
+
+func TestForeach(t *testing.T) {
+	s1 := step("1", "echo ${ITEM}")
+	s1.Foreach = &dag.Foreach{Items: []string{"a", "b", "c"}, MaxParallel: 2}
+	s1.Output = "FOREACH_OUT"
+
+	g, sc := newTestSchedule(t, &Config{}, s1)
+	err := sc.Schedule(context.Background(), g, nil)
+	require.NoError(t, err)
+
+	nodes := g.Nodes()
+	require.Equal(t, NodeStatusSuccess, nodes[0].State().Status)
+	require.Equal(t, `["a","b","c"]`, os.Getenv("FOREACH_OUT"))
+}
+
+func TestForeachItemsFromPrevStep(t *testing.T) {
+	s1 := step("1", `echo "x y"`)
+	s1.Output = "FOREACH_ITEMS"
+
+	s2 := step("2", "echo ${ITEM_INDEX}", "1")
+	s2.Foreach = &dag.Foreach{ItemsExpr: "$FOREACH_ITEMS"}
+	s2.Output = "FOREACH_INDEXES"
+
+	g, sc := newTestSchedule(t, &Config{}, s1, s2)
+	err := sc.Schedule(context.Background(), g, nil)
+	require.NoError(t, err)
+
+	nodes := g.Nodes()
+	require.Equal(t, NodeStatusSuccess, nodes[1].State().Status)
+	require.Equal(t, `["0","1"]`, os.Getenv("FOREACH_INDEXES"))
+}
+
+func TestForeachFail(t *testing.T) {
+	s1 := step("1", "test ${ITEM} != b")
+	s1.Foreach = &dag.Foreach{Items: []string{"a", "b", "c"}}
+
+	g, sc := newTestSchedule(t, &Config{}, s1)
+	err := sc.Schedule(context.Background(), g, nil)
+	require.Error(t, err)
+
+	nodes := g.Nodes()
+	require.Equal(t, NodeStatusError, nodes[0].State().Status)
+	require.ErrorIs(t, nodes[0].State().Error, errForeachFailed)
+}
+
+func TestGenerator(t *testing.T) {
+	s1 := step("1", "sh")
+	s1.Script = `echo '[{"name":"gen 1","command":"echo 1"},{"name":"gen 2","command":"echo 2","depends":["gen 1"]}]'`
+	s1.Generator = true
+
+	s2 := step("2", "echo done", "1")
+
+	g, sc := newTestSchedule(t, &Config{}, s1, s2)
+	err := sc.Schedule(context.Background(), g, nil)
+	require.NoError(t, err)
+
+	nodes := g.Nodes()
+	require.Len(t, nodes, 4)
+	for _, n := range nodes {
+		require.Equal(t, NodeStatusSuccess, n.State().Status)
+	}
+	require.Equal(t, []string{"1"}, nodes[2].step.Depends)
+	require.Equal(t, []string{"1", "gen 2"}, nodes[1].step.Depends)
+	require.True(t, nodes[3].FinishedAt.Before(nodes[1].StartedAt) ||
+		nodes[3].FinishedAt.Equal(nodes[1].StartedAt))
+}
+
+func TestGeneratorItems(t *testing.T) {
+	s1 := step("1", "sh")
+	s1.Script = `echo '["a","b"]'`
+	s1.Generator = true
+	s1.Output = "GENERATED_ITEMS"
+
+	s2 := step("2", "echo ${ITEM}", "1")
+	s2.Foreach = &dag.Foreach{ItemsExpr: "$GENERATED_ITEMS"}
+	s2.Output = "GENERATED_OUT"
+
+	g, sc := newTestSchedule(t, &Config{}, s1, s2)
+	err := sc.Schedule(context.Background(), g, nil)
+	require.NoError(t, err)
+
+	require.Len(t, g.Nodes(), 2)
+	require.Equal(t, `["a","b"]`, os.Getenv("GENERATED_OUT"))
+}
+
+func TestGeneratorInvalidOutput(t *testing.T) {
+	s1 := step("1", "echo invalid")
+	s1.Generator = true
+
+	s2 := step("2", "echo done", "1")
+
+	g, sc := newTestSchedule(t, &Config{}, s1, s2)
+	err := sc.Schedule(context.Background(), g, nil)
+	require.Error(t, err)
+
+	nodes := g.Nodes()
+	require.Equal(t, NodeStatusError, nodes[0].State().Status)
+	require.Equal(t, NodeStatusCancel, nodes[1].State().Status)
+}
+
+type testSubRunExecutor struct {
+	stdout io.Writer
+}
+
+func (e *testSubRunExecutor) SetStdout(out io.Writer) { e.stdout = out }
+func (e *testSubRunExecutor) SetStderr(io.Writer)     {}
+func (e *testSubRunExecutor) Kill(os.Signal) error    { return nil }
+func (e *testSubRunExecutor) SubRunRequestID() string { return "sub-run" }
+func (e *testSubRunExecutor) Outputs() string         { return `{"RESULT":"ok"}` }
+
+func (e *testSubRunExecutor) Run() error {
+	_, err := io.WriteString(e.stdout, "log of the sub DAG\n")
+	return err
+}
+
+func TestSubRunOutput(t *testing.T) {
+	executor.Register("test-subrun", func(context.Context, dag.Step) (executor.Executor, error) {
+		return &testSubRunExecutor{}, nil
+	})
+
+	s1 := step("1", "run sub")
+	s1.ExecutorConfig.Type = "test-subrun"
+	s1.Output = "SUB_OUT"
+
+	g, sc := newTestSchedule(t, &Config{}, s1)
+	err := sc.Schedule(context.Background(), g, nil)
+	require.NoError(t, err)
+
+	nodes := g.Nodes()
+	require.Equal(t, NodeStatusSuccess, nodes[0].State().Status)
+	require.Equal(t, "sub-run", nodes[0].State().SubRunRequestId)
+	require.Equal(t, `{"RESULT":"ok"}`, os.ExpandEnv("$SUB_OUT"))
+}
+
+func TestStepDotenv(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(path.Join(dir, ".env"), []byte("DOTENV_VALUE=from-file\nDOTENV_OVERRIDE=from-file\n"), 0600)
+	require.NoError(t, err)
+
+	s1 := step("1", "sh")
+	s1.Script = `echo $DOTENV_VALUE $DOTENV_OVERRIDE $DOTENV_DAG`
+	s1.Output = "DOTENV_OUT"
+	s1.Dir = dir
+	s1.Variables = []string{"DOTENV_OVERRIDE=from-dag", "DOTENV_DAG=from-dag"}
+	s1.Dotenv = []dag.Dotenv{{File: ".env"}, {File: ".env.missing", Missing: dag.DotenvMissingIgnore}}
+
+	s2 := step("2", "true", "1")
+	s2.Dotenv = []dag.Dotenv{{File: path.Join(dir, ".env.missing")}}
+
+	g, sc := newTestSchedule(t, &Config{}, s1, s2)
+	err = sc.Schedule(context.Background(), g, nil)
+	require.Error(t, err)
+
+	nodes := g.Nodes()
+	require.Equal(t, NodeStatusSuccess, nodes[0].State().Status)
+	require.Equal(t, NodeStatusError, nodes[1].State().Status)
+	require.Equal(t, "from-file from-file from-dag", os.ExpandEnv("$DOTENV_OUT"))
+}
+
+func TestParseFault(t *testing.T) {
+	f, err := ParseFault("fail=extract")
+	require.NoError(t, err)
+	require.Equal(t, Fault{Kind: FaultFail, Step: "extract", ExitCode: 1}, f)
+	f, err = ParseFault("fail=extract:75:2")
+	require.NoError(t, err)
+	require.Equal(t, Fault{Kind: FaultFail, Step: "extract", ExitCode: 75, Attempts: 2}, f)
+	f, err = ParseFault("delay=load:1m30s")
+	require.NoError(t, err)
+	require.Equal(t, Fault{Kind: FaultDelay, Step: "load", Delay: 90 * time.Second}, f)
+	f, err = ParseFault("kill=report")
+	require.NoError(t, err)
+	require.Equal(t, Fault{Kind: FaultKill, Step: "report"}, f)
+
+	for _, s := range []string{"", "fail", "fail=", "fail=a:0", "fail=a:1:x", "delay=a", "delay=a:soon", "kill=a:1", "crash=a"} {
+		_, err := ParseFault(s)
+		require.ErrorIs(t, err, errInvalidFault, s)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	onFailure := step("onFailure", testCommand)
+	g, sc := newTestSchedule(t,
+		&Config{
+			MaxActiveRuns: 2,
+			OnFailure:     &onFailure,
+			Faults: []Fault{
+				{Kind: FaultFail, Step: "1", ExitCode: 75, Attempts: 1},
+				{Kind: FaultFail, Step: "2", ExitCode: 1},
+				{Kind: FaultFail, Step: "onFailure", ExitCode: 1},
+			},
+		},
+		dag.Step{
+			Name:        "1",
+			Command:     testCommand,
+			RetryPolicy: &dag.RetryPolicy{Limit: 1, ExitCodes: []int{75}},
+		},
+		dag.Step{
+			Name:        "2",
+			Command:     testCommand,
+			RetryPolicy: &dag.RetryPolicy{Limit: 2, ExitCodes: []int{75}},
+			Depends:     []string{"1"},
+		},
+	)
+	require.Error(t, sc.Schedule(context.Background(), g, nil))
+
+	// The first attempt of 1 fails with the exit code of the retry policy,
+	// and the retry succeeds.
+	nodes := g.Nodes()
+	require.Equal(t, NodeStatusSuccess, nodes[0].State().Status)
+	require.Equal(t, 1, nodes[0].State().RetryCount)
+	require.Equal(t, NodeStatusError, nodes[1].State().Status)
+	require.Equal(t, 0, nodes[1].State().RetryCount)
+	require.Equal(t, "injected failure: exit status 1", nodes[1].State().Error.Error())
+	require.Equal(t, NodeStatusError, sc.HandlerNode(constants.OnFailure).State().Status)
+}
+
+func TestFaultDelay(t *testing.T) {
+	g, sc := newTestSchedule(t,
+		&Config{
+			MaxActiveRuns: 2,
+			Faults: []Fault{
+				{Kind: FaultDelay, Step: "1", Delay: 200 * time.Millisecond},
+				{Kind: FaultDelay, Step: "2", Delay: time.Minute},
+			},
+		},
+		step("1", testCommand),
+		dag.Step{Name: "2", Command: testCommand, Timeout: 100 * time.Millisecond, Depends: []string{"1"}},
+	)
+	start := time.Now()
+	err := sc.Schedule(context.Background(), g, nil)
+	require.ErrorIs(t, err, errStepTimeout)
+	require.Less(t, time.Since(start), 10*time.Second)
+
+	nodes := g.Nodes()
+	require.Equal(t, NodeStatusSuccess, nodes[0].State().Status)
+	require.GreaterOrEqual(t, nodes[0].State().FinishedAt.Sub(nodes[0].State().StartedAt), 200*time.Millisecond)
+	require.Equal(t, NodeStatusTimeout, nodes[1].State().Status)
+}
+
+func TestFaultKill(t *testing.T) {
+	var killed atomic.Bool
+	origKillAgent := killAgent
+	killAgent = func() { killed.Store(true) }
+	defer func() {
+		killAgent = origKillAgent
+	}()
+	_, _, err := testSchedule(t, step("1", testCommand))
+	require.NoError(t, err)
+	require.False(t, killed.Load())
+
+	g, sc := newTestSchedule(t,
+		&Config{Faults: []Fault{{Kind: FaultKill, Step: "2"}}},
+		step("1", testCommand),
+		step("2", testCommand, "1"),
+	)
+	require.NoError(t, sc.Schedule(context.Background(), g, nil))
+	require.True(t, killed.Load())
+}
+
+type testSecretProvider string
+
+func (p testSecretProvider) Get(_ context.Context, _, _ string) (string, error) {
+	return string(p), nil
+}
+
+func TestStepPreview(t *testing.T) {
+	secret.Register("test-preview", testSecretProvider("s3cr3t-token"))
+	token, err := secret.Resolve(context.Background(), "secret://test-preview/token")
+	require.NoError(t, err)
+	t.Setenv("PREVIEW_TOKEN", token)
+	t.Setenv("PREVIEW_NAME", "world")
+
+	run := dag.Step{Name: "run", CmdWithArgs: `echo "hello $PREVIEW_NAME" $PREVIEW_TOKEN`}
+	planned := dag.Step{Name: "planned", CmdWithArgs: "echo $PREVIEW_NAME ${PREVIEW_UNKNOWN} `date`", Depends: []string{"run"}}
+	g, sc := newTestSchedule(t, &Config{}, run, planned)
+	setPlannedPreviews(g)
+	require.Equal(t, "echo 'hello world' *****", g.Nodes()[0].State().Preview)
+	require.Equal(t, "echo world '${PREVIEW_UNKNOWN}' '`date`'", g.Nodes()[1].State().Preview)
+
+	// The previews of the nodes that ran are the commands they ran.
+	require.NoError(t, sc.Schedule(context.Background(), g, nil))
+	require.Equal(t, "echo 'hello world' *****", g.Nodes()[0].State().Preview)
+	require.NotContains(t, g.Nodes()[1].State().Preview, "`date`")
+}
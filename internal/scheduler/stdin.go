@@ -0,0 +1,106 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/executor"
+	"github.com/dagu-dev/dagu/internal/utils"
+)
+
+var (
+	errStdinNotSupported = errors.New("the executor of the step does not read stdin")
+	errStdinParamNotSet  = errors.New("the parameter of stdin is not set")
+	errStdinOutputNotSet = errors.New("the output of stdin is not set")
+)
+
+// setStdin sets the stdin of the step to the command. It returns the file
+// the input is read from, which is closed after the command, or nil.
+func setStdin(cmd executor.Executor, step dag.Step) (io.Closer, error) {
+	in := step.Stdin
+	if in == nil {
+		return nil, nil
+	}
+	r, ok := cmd.(executor.StdinReader)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errStdinNotSupported, step.ExecutorConfig.Type)
+	}
+	lookup := stdinLookup(step)
+	expand := func(s string) string {
+		return os.Expand(s, func(key string) string {
+			v, _ := lookup(key)
+			return v
+		})
+	}
+	switch {
+	case in.File != "":
+		file := expand(in.File)
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(step.Dir, file)
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		r.SetStdin(f)
+		return f, nil
+	case in.Param != "":
+		v, ok := os.LookupEnv(in.Param)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errStdinParamNotSet, in.Param)
+		}
+		r.SetStdin(strings.NewReader(v))
+	case in.Output != "":
+		v, ok := outputVariable(step, in.Output)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errStdinOutputNotSet, in.Output)
+		}
+		r.SetStdin(strings.NewReader(v))
+	default:
+		r.SetStdin(strings.NewReader(expand(in.Value)))
+	}
+	return nil, nil
+}
+
+// stdinLookup returns the function that looks up the variables the stdin of
+// the step is expanded with: the ones of the step, the outputs of the
+// previous steps and the environment, e.g. the parameters of the run.
+func stdinLookup(step dag.Step) func(key string) (string, bool) {
+	return func(key string) (string, bool) {
+		for i := len(step.Variables) - 1; i >= 0; i-- {
+			if k, v, ok := strings.Cut(step.Variables[i], "="); ok && k == key {
+				return v, true
+			}
+		}
+		if v, ok := outputVariable(step, key); ok {
+			return v, true
+		}
+		return os.LookupEnv(key)
+	}
+}
+
+func outputVariable(step dag.Step, name string) (string, bool) {
+	if step.OutputVariables == nil {
+		return "", false
+	}
+	v, ok := step.OutputVariables.Load(name)
+	if !ok {
+		return "", false
+	}
+	_, value, _ := strings.Cut(v.(string), "=")
+	return value, true
+}
+
+func (n *Node) closeStdin() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.stdinFile != nil {
+		utils.LogErr("close stdin", n.stdinFile.Close())
+		n.stdinFile = nil
+	}
+}
@@ -0,0 +1,126 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"golang.org/x/sys/unix"
+)
+
+var (
+	errStepNotRunning = dagerrors.New(dagerrors.CodeStepNotRunning, "step is not running")
+	errInvalidMark    = errors.New("a step can only be marked as success or failed")
+	errMarkedFailed   = errors.New("marked as failed")
+)
+
+// MarkStep takes over a running step on behalf of an operator, e.g. when an
+// external system confirmed that the work of a stuck step is done. The
+// command of the step is stopped and the step finishes with the status, so
+// that the steps that depend on it proceed as if it finished by itself.
+func (sc *Scheduler) MarkStep(g *ExecutionGraph, name string, status NodeStatus, reason string) error {
+	if status != NodeStatusSuccess && status != NodeStatusError {
+		return dagerrors.WithCode(dagerrors.CodeInvalidArgument, errInvalidMark)
+	}
+	for _, node := range g.Nodes() {
+		if node.step.Name == name {
+			return node.mark(status, reason)
+		}
+	}
+	return dagerrors.WithCode(dagerrors.CodeNotFound, fmt.Errorf("%w: %s", errStepNotFound, name))
+}
+
+func (n *Node) mark(status NodeStatus, reason string) error {
+	n.mu.Lock()
+	if n.Status != NodeStatusRunning {
+		n.mu.Unlock()
+		return fmt.Errorf("%w: %s is %s", errStepNotRunning, n.step.Name, n.Status)
+	}
+	log.Printf("%s is marked as %s: %s", n.step.Name, status, reason)
+	n.marked = status
+	n.MarkedReason = reason
+	n.mu.Unlock()
+	sig := os.Signal(unix.SIGTERM)
+	if n.step.SignalOnStop != "" {
+		sig = unix.SignalNum(n.step.SignalOnStop)
+	}
+	n.kill(sig)
+	return nil
+}
+
+// RestartSteps stops the commands of the running steps and runs them again
+// in the same run, e.g. for a restart of only the server step of a DAG, so
+// that the other steps of the run keep running. No step is restarted if one
+// of them is not running.
+func (sc *Scheduler) RestartSteps(g *ExecutionGraph, names []string) error {
+	var nodes []*Node
+	for _, name := range names {
+		i := slices.IndexFunc(g.Nodes(), func(n *Node) bool { return n.step.Name == name })
+		if i < 0 {
+			return dagerrors.WithCode(dagerrors.CodeNotFound, fmt.Errorf("%w: %s", errStepNotFound, name))
+		}
+		n := g.Nodes()[i]
+		if status := n.State().Status; status != NodeStatusRunning {
+			return fmt.Errorf("%w: %s is %s", errStepNotRunning, name, status)
+		}
+		nodes = append(nodes, n)
+	}
+	for _, n := range nodes {
+		if err := n.restartCmd(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *Node) restartCmd() error {
+	n.mu.Lock()
+	if n.Status != NodeStatusRunning {
+		n.mu.Unlock()
+		return fmt.Errorf("%w: %s is %s", errStepNotRunning, n.step.Name, n.Status)
+	}
+	log.Printf("%s is restarted", n.step.Name)
+	n.restart = true
+	n.mu.Unlock()
+	sig := os.Signal(unix.SIGTERM)
+	if n.step.SignalOnStop != "" {
+		sig = unix.SignalNum(n.step.SignalOnStop)
+	}
+	n.kill(sig)
+	return nil
+}
+
+// takeRestart returns true if the node was stopped to run it again, and
+// resets it to run its command again.
+func (n *Node) takeRestart() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.restart {
+		return false
+	}
+	n.restart = false
+	n.Status = NodeStatusRunning
+	n.Error = nil
+	return true
+}
+
+// takeOver finishes the node with the status it is marked with, if any.
+func (sc *Scheduler) takeOver(n *Node) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	switch n.marked {
+	case NodeStatusSuccess:
+		n.Status = NodeStatusSuccess
+		n.Error = nil
+	case NodeStatusError:
+		n.Status = NodeStatusError
+		n.Error = fmt.Errorf("%w: %s", errMarkedFailed, n.MarkedReason)
+		sc.lastError = n.Error
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,537 @@
+// Package search is the full-text index of the DAGs of a directory: their
+// names, descriptions, tags and step commands, and the logs of the steps of
+// their recent runs.
+//
+// The index is incremental: a DAG file is indexed again only when its
+// modification time or its size changed, and so is a log file, so that a
+// search does not read all the files again.
+package search
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/grep"
+	"github.com/dagu-dev/dagu/internal/logfile"
+	"github.com/dagu-dev/dagu/internal/logger"
+	"github.com/dagu-dev/dagu/internal/logger/tag"
+	"github.com/dagu-dev/dagu/internal/persistence"
+)
+
+// The fields of a DAG that are matched.
+const (
+	FieldName        = "name"
+	FieldTag         = "tag"
+	FieldDescription = "description"
+	FieldCommand     = "command"
+	FieldLog         = "log"
+)
+
+// field is a set of the fields of a DAG as bits, whose order is the weight
+// of the fields in the scores.
+type field uint8
+
+const (
+	fieldLog field = 1 << iota
+	fieldCommand
+	fieldDescription
+	fieldTag
+	fieldName
+)
+
+var fieldNames = []struct {
+	field field
+	name  string
+}{
+	{fieldName, FieldName},
+	{fieldTag, FieldTag},
+	{fieldDescription, FieldDescription},
+	{fieldCommand, FieldCommand},
+	{fieldLog, FieldLog},
+}
+
+const (
+	// refreshInterval is the interval of the refreshes of the index in the
+	// background, so that the logs of the new runs are indexed before they
+	// are searched.
+	refreshInterval = time.Minute
+	// maxLogSize is the size of the end of a log file that is indexed.
+	maxLogSize = 1 << 20
+	// maxLogMatches is the number of the matched lines returned of a log.
+	maxLogMatches = 5
+	// contextLines are the lines before and after a matched line.
+	contextLines = 2
+)
+
+type Config struct {
+	DAGStore     persistence.DAGStore
+	HistoryStore persistence.HistoryStore
+	// LogRuns is the number of the recent runs of each DAG whose logs are
+	// indexed. The logs are not indexed if it is zero.
+	LogRuns int
+	Logger  logger.Logger
+}
+
+// Index is the index of the DAGs of the store. It is safe for concurrent
+// use.
+type Index struct {
+	*Config
+
+	mu   sync.Mutex
+	dags map[string]*dagDoc
+	logs map[string]*logDoc
+	// terms are the fields of the DAGs, and logTerms the logs, that each
+	// term is in.
+	terms    map[string]map[*dagDoc]field
+	logTerms map[string]map[*logDoc]bool
+}
+
+// dagDoc is an indexed DAG file.
+type dagDoc struct {
+	location string
+	name     string
+	dag      *dag.DAG
+	spec     []byte
+	modTime  time.Time
+	size     int64
+	terms    []string
+}
+
+// logDoc is an indexed log file of a step.
+type logDoc struct {
+	file      string
+	location  string
+	step      string
+	requestId string
+	modTime   time.Time
+	size      int64
+	terms     []string
+}
+
+// Result is a DAG that matched a query.
+type Result struct {
+	// Name is the name of the DAG in the DAGs directory, e.g. team-a/etl.
+	Name string
+	DAG  *dag.DAG
+	// Fields are the fields of the DAG that matched, the most relevant
+	// first.
+	Fields []string
+	Score  int
+	// Matches are the lines of the definition that matched.
+	Matches []*grep.Match
+	// Logs are the logs of the recent runs that matched, the latest run
+	// first.
+	Logs []*LogResult
+}
+
+// LogResult is the log of a step that matched a query.
+type LogResult struct {
+	RequestId string
+	Step      string
+	Matches   []*grep.Match
+}
+
+func New(cfg *Config) *Index {
+	return &Index{
+		Config:   cfg,
+		dags:     map[string]*dagDoc{},
+		logs:     map[string]*logDoc{},
+		terms:    map[string]map[*dagDoc]field{},
+		logTerms: map[string]map[*logDoc]bool{},
+	}
+}
+
+// Start builds the index in the background and refreshes it until the
+// context is done.
+func (idx *Index) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(refreshInterval)
+		defer ticker.Stop()
+		for {
+			if _, err := idx.Refresh(true); err != nil {
+				idx.Logger.Warn("failed to refresh the search index", tag.Error(err))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Refresh indexes the DAG files that changed since the last refresh and
+// removes the ones that were deleted, and the logs too if logs is true.
+// The DAGs that cannot be read are returned as the errors.
+func (idx *Index) Refresh(logs bool) ([]string, error) {
+	dags, errs, err := idx.DAGStore.List()
+	if err != nil {
+		return nil, err
+	}
+	if errs == nil {
+		errs = []string{}
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	seen := map[string]bool{}
+	for _, d := range dags {
+		seen[d.Location] = true
+		if err := idx.indexDAG(d.Location); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	for loc, doc := range idx.dags {
+		if !seen[loc] {
+			idx.removeDAG(doc)
+		}
+	}
+	if logs && idx.LogRuns > 0 {
+		idx.refreshLogs()
+	}
+	return errs, nil
+}
+
+func (idx *Index) indexDAG(location string) error {
+	info, err := os.Stat(location)
+	if err != nil {
+		return err
+	}
+	old := idx.dags[location]
+	if old != nil && old.modTime.Equal(info.ModTime()) && old.size == info.Size() {
+		return nil
+	}
+	d, err := idx.DAGStore.GetDetails(location)
+	if err != nil {
+		return err
+	}
+	spec, err := os.ReadFile(location)
+	if err != nil {
+		return err
+	}
+	if old != nil {
+		idx.removeDAG(old)
+	}
+	base := filepath.Base(location)
+	doc := &dagDoc{
+		location: location,
+		name:     path.Join(idx.DAGStore.FolderOf(location), strings.TrimSuffix(base, filepath.Ext(base))),
+		dag:      d,
+		spec:     spec,
+		modTime:  info.ModTime(),
+		size:     info.Size(),
+	}
+	fields := map[string]field{}
+	add := func(f field, s string) {
+		for _, t := range tokenize(s) {
+			fields[t] |= f
+		}
+	}
+	add(fieldName, doc.name)
+	add(fieldName, d.Name)
+	for _, t := range d.Tags {
+		add(fieldTag, t)
+	}
+	add(fieldDescription, d.Description)
+	for _, s := range allSteps(d) {
+		add(fieldCommand, s.CmdWithArgs)
+		add(fieldCommand, s.Script)
+	}
+	for t, f := range fields {
+		if idx.terms[t] == nil {
+			idx.terms[t] = map[*dagDoc]field{}
+		}
+		idx.terms[t][doc] = f
+		doc.terms = append(doc.terms, t)
+	}
+	idx.dags[location] = doc
+	return nil
+}
+
+// allSteps returns the steps of the DAG and of its handlers.
+func allSteps(d *dag.DAG) []dag.Step {
+	steps := append([]dag.Step{}, d.Steps...)
+	for _, s := range []*dag.Step{
+		d.HandlerOn.Success, d.HandlerOn.Failure, d.HandlerOn.Cancel, d.HandlerOn.Timeout, d.HandlerOn.Exit,
+	} {
+		if s != nil {
+			steps = append(steps, *s)
+		}
+	}
+	return steps
+}
+
+func (idx *Index) removeDAG(doc *dagDoc) {
+	for _, t := range doc.terms {
+		delete(idx.terms[t], doc)
+		if len(idx.terms[t]) == 0 {
+			delete(idx.terms, t)
+		}
+	}
+	delete(idx.dags, doc.location)
+}
+
+// refreshLogs indexes the logs of the steps of the recent runs of the DAGs
+// that changed, and removes the ones of the older runs.
+func (idx *Index) refreshLogs() {
+	seen := map[string]bool{}
+	for loc := range idx.dags {
+		for _, st := range idx.HistoryStore.ReadStatusRecent(loc, idx.LogRuns) {
+			for _, n := range st.Status.Nodes {
+				if n.Log == "" || seen[n.Log] {
+					continue
+				}
+				seen[n.Log] = true
+				doc := &logDoc{file: n.Log, location: loc, step: n.Step.Name, requestId: st.Status.RequestId}
+				if err := idx.indexLog(doc); err != nil && !os.IsNotExist(err) {
+					idx.Logger.Warn("failed to index the log", "file", n.Log, tag.Error(err))
+				}
+			}
+		}
+	}
+	for file, doc := range idx.logs {
+		if !seen[file] {
+			idx.removeLog(doc)
+		}
+	}
+}
+
+func (idx *Index) indexLog(doc *logDoc) error {
+	info, err := os.Stat(logfile.Find(doc.file))
+	if err != nil {
+		return err
+	}
+	old := idx.logs[doc.file]
+	if old != nil && old.modTime.Equal(info.ModTime()) && old.size == info.Size() {
+		return nil
+	}
+	data, err := readTail(doc.file)
+	if err != nil {
+		return err
+	}
+	if old != nil {
+		idx.removeLog(old)
+	}
+	doc.modTime, doc.size = info.ModTime(), info.Size()
+	for _, t := range tokenize(string(data)) {
+		if idx.logTerms[t] == nil {
+			idx.logTerms[t] = map[*logDoc]bool{}
+		}
+		if !idx.logTerms[t][doc] {
+			idx.logTerms[t][doc] = true
+			doc.terms = append(doc.terms, t)
+		}
+	}
+	idx.logs[doc.file] = doc
+	return nil
+}
+
+// readTail reads the last maxLogSize bytes of the log at file, which is
+// decompressed if it was compressed.
+func readTail(file string) ([]byte, error) {
+	r, err := logfile.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	if f, ok := r.(*os.File); ok {
+		if _, err := f.Seek(-maxLogSize, io.SeekEnd); err != nil {
+			if _, err := f.Seek(0, io.SeekStart); err != nil {
+				return nil, err
+			}
+		}
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxLogSize {
+		data = data[len(data)-maxLogSize:]
+	}
+	return data, nil
+}
+
+func (idx *Index) removeLog(doc *logDoc) {
+	for _, t := range doc.terms {
+		delete(idx.logTerms[t], doc)
+		if len(idx.logTerms[t]) == 0 {
+			delete(idx.logTerms, t)
+		}
+	}
+	delete(idx.logs, doc.file)
+}
+
+// Search returns the DAGs that have all the words of the query, each as
+// the prefix of a word of a field of the DAG or of one of its logs if logs
+// is true, the most relevant first. The index is refreshed first.
+func (idx *Index) Search(query string, logs bool) ([]*Result, []string, error) {
+	errs, err := idx.Refresh(logs)
+	if err != nil {
+		return nil, nil, err
+	}
+	terms := tokenize(query)
+	if len(terms) == 0 {
+		return []*Result{}, errs, nil
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	results := map[*dagDoc]*Result{}
+	result := func(doc *dagDoc) *Result {
+		if results[doc] == nil {
+			results[doc] = &Result{Name: doc.name, DAG: doc.dag}
+		}
+		return results[doc]
+	}
+	for doc, f := range idx.matchDAGs(terms) {
+		r := result(doc)
+		for _, n := range fieldNames {
+			if f&n.field != 0 {
+				r.Fields = append(r.Fields, n.name)
+			}
+		}
+		r.Score = int(f)
+		r.Matches = grepLines(doc.spec, terms, 0)
+	}
+	if logs && idx.LogRuns > 0 {
+		for _, doc := range idx.matchLogs(terms) {
+			d := idx.dags[doc.location]
+			if d == nil {
+				continue
+			}
+			data, err := readTail(doc.file)
+			if err != nil {
+				continue
+			}
+			r := result(d)
+			if len(r.Logs) == 0 {
+				r.Fields = append(r.Fields, FieldLog)
+				r.Score += int(fieldLog)
+			}
+			r.Logs = append(r.Logs, &LogResult{
+				RequestId: doc.requestId,
+				Step:      doc.step,
+				Matches:   grepLines(data, terms, maxLogMatches),
+			})
+		}
+	}
+
+	ret := make([]*Result, 0, len(results))
+	for _, r := range results {
+		ret = append(ret, r)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Score != ret[j].Score {
+			return ret[i].Score > ret[j].Score
+		}
+		return ret[i].Name < ret[j].Name
+	})
+	return ret, errs, nil
+}
+
+// matchDAGs returns the DAGs that have all the terms, with the fields that
+// have the most relevant match of each term.
+func (idx *Index) matchDAGs(terms []string) map[*dagDoc]field {
+	var ret map[*dagDoc]field
+	for i, term := range terms {
+		matched := map[*dagDoc]field{}
+		for t, docs := range idx.terms {
+			if !strings.HasPrefix(t, term) {
+				continue
+			}
+			for doc, f := range docs {
+				if i == 0 || ret[doc] != 0 {
+					matched[doc] |= f
+				}
+			}
+		}
+		if i > 0 {
+			for doc, f := range matched {
+				matched[doc] = f | ret[doc]
+			}
+		}
+		ret = matched
+	}
+	return ret
+}
+
+// matchLogs returns the logs that have all the terms, the ones of the
+// latest runs first.
+func (idx *Index) matchLogs(terms []string) []*logDoc {
+	var ret map[*logDoc]bool
+	for i, term := range terms {
+		matched := map[*logDoc]bool{}
+		for t, docs := range idx.logTerms {
+			if !strings.HasPrefix(t, term) {
+				continue
+			}
+			for doc := range docs {
+				if i == 0 || ret[doc] {
+					matched[doc] = true
+				}
+			}
+		}
+		ret = matched
+	}
+	docs := make([]*logDoc, 0, len(ret))
+	for doc := range ret {
+		docs = append(docs, doc)
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		if !docs[i].modTime.Equal(docs[j].modTime) {
+			return docs[i].modTime.After(docs[j].modTime)
+		}
+		return docs[i].file < docs[j].file
+	})
+	return docs
+}
+
+// grepLines returns the lines of the data that have a word starting with
+// one of the terms, with the lines around them, at most limit of them if
+// limit is positive.
+func grepLines(data []byte, terms []string, limit int) []*grep.Match {
+	m, err := grep.Grep(data, strings.Join(terms, " "), &grep.Options{
+		Before:  contextLines,
+		After:   contextLines,
+		Matcher: termMatcher(terms),
+	})
+	if err != nil {
+		return []*grep.Match{}
+	}
+	if limit > 0 && len(m) > limit {
+		m = m[:limit]
+	}
+	return m
+}
+
+type termMatcher []string
+
+func (m termMatcher) Match(line string) bool {
+	for _, t := range tokenize(line) {
+		for _, term := range m {
+			if strings.HasPrefix(t, term) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// tokenize returns the words of the text in lower case. The words are the
+// sequences of the letters and the digits.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
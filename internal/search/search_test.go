@@ -0,0 +1,102 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/persistence/jsondb"
+	"github.com/dagu-dev/dagu/internal/persistence/local"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	dir := t.TempDir()
+	dagsDir := filepath.Join(dir, "dags")
+	require.NoError(t, os.MkdirAll(filepath.Join(dagsDir, "team-a"), 0755))
+	write := func(name, spec string) string {
+		file := filepath.Join(dagsDir, name)
+		require.NoError(t, os.WriteFile(file, []byte(spec), 0644))
+		return file
+	}
+	write("backup.yaml", "description: Nightly database backup\ntags: ops\nsteps:\n  - name: dump\n    command: pg_dump app\n")
+	etl := write("team-a/etl.yaml", "description: Load the orders\nsteps:\n  - name: extract\n    command: python extract.py --backup\n")
+	write("report.yaml", "steps:\n  - name: send\n    command: mail -s report\n")
+
+	history := jsondb.New(filepath.Join(dir, "history"), dagsDir)
+	idx := New(&Config{
+		DAGStore:     local.NewDAGStore(dagsDir),
+		HistoryStore: history,
+		LogRuns:      1,
+	})
+	names := func(results []*Result) []string {
+		var ret []string
+		for _, r := range results {
+			ret = append(ret, r.Name)
+		}
+		return ret
+	}
+
+	// The name ranks above the command.
+	ret, errs, err := idx.Search("backup", false)
+	require.NoError(t, err)
+	require.Empty(t, errs)
+	require.Equal(t, []string{"backup", "team-a/etl"}, names(ret))
+	require.Equal(t, []string{FieldName, FieldDescription}, ret[0].Fields)
+	require.Equal(t, []string{FieldCommand}, ret[1].Fields)
+	require.Equal(t, 4, ret[1].Matches[0].LineNumber)
+
+	// All the words must match, as prefixes.
+	ret, _, err = idx.Search("Nightly DATA", false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"backup"}, names(ret))
+	ret, _, err = idx.Search("nightly orders", false)
+	require.NoError(t, err)
+	require.Empty(t, ret)
+	ret, _, err = idx.Search("ops", false)
+	require.NoError(t, err)
+	require.Equal(t, []string{FieldTag}, ret[0].Fields)
+
+	// The changed and the deleted files are indexed again.
+	time.Sleep(10 * time.Millisecond)
+	write("report.yaml", "description: Weekly orders report\nsteps:\n  - name: send\n    command: mail\n")
+	require.NoError(t, os.Remove(filepath.Join(dagsDir, "backup.yaml")))
+	ret, _, err = idx.Search("orders", false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"report", "team-a/etl"}, names(ret))
+	ret, _, err = idx.Search("nightly", false)
+	require.NoError(t, err)
+	require.Empty(t, ret)
+
+	// The logs of the recent runs are searched if they are asked for.
+	logFile := filepath.Join(dir, "extract.log")
+	require.NoError(t, os.WriteFile(logFile, []byte("start\nconnection refused by db01\ndone\n"), 0644))
+	d := &dag.DAG{Name: "etl", Location: etl}
+	st := model.NewStatusDefault(d)
+	st.RequestId = "req-1"
+	st.Nodes = []*model.Node{{Step: dag.Step{Name: "extract"}, Log: logFile}}
+	require.NoError(t, history.Open(etl, time.Now(), st.RequestId))
+	require.NoError(t, history.Write(st))
+	require.NoError(t, history.Close())
+
+	ret, _, err = idx.Search("refused", false)
+	require.NoError(t, err)
+	require.Empty(t, ret)
+	ret, _, err = idx.Search("connection refused", true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"team-a/etl"}, names(ret))
+	require.Equal(t, []string{FieldLog}, ret[0].Fields)
+	require.Len(t, ret[0].Logs, 1)
+	require.Equal(t, "req-1", ret[0].Logs[0].RequestId)
+	require.Equal(t, "extract", ret[0].Logs[0].Step)
+	require.Equal(t, 2, ret[0].Logs[0].Matches[0].LineNumber)
+
+	// The logs are not indexed without runs to index.
+	idx.LogRuns = 0
+	ret, _, err = idx.Search("refused", true)
+	require.NoError(t, err)
+	require.Empty(t, ret)
+}
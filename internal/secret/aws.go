@@ -0,0 +1,81 @@
+package secret
+
+import (
+	"context"
+	"strings"
+
+	sdkaws "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/dagu-dev/dagu/internal/aws"
+	"github.com/dagu-dev/dagu/internal/dag"
+)
+
+// secretsManagerProvider reads secrets from AWS Secrets Manager. The path is
+// the name or the ARN of the secret. With a key, the secret must be a JSON
+// object and the value of the key is returned.
+type secretsManagerProvider struct {
+	client *aws.Client
+}
+
+func (p *secretsManagerProvider) Get(ctx context.Context, path, key string) (string, error) {
+	cfg, err := p.client.Config(ctx)
+	if err != nil {
+		return "", err
+	}
+	// The region of an ARN takes precedence over the configured region.
+	if arn := strings.Split(path, ":"); len(arn) > 3 && arn[0] == "arn" && arn[3] != "" {
+		cfg.Region = arn[3]
+	}
+	if cfg.Region == "" {
+		return "", aws.ErrRegion
+	}
+	resp, err := secretsmanager.NewFromConfig(cfg).GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: &path,
+	})
+	if err != nil {
+		return "", err
+	}
+	value := sdkaws.ToString(resp.SecretString)
+	if value == "" {
+		value = string(resp.SecretBinary)
+	}
+	return jsonKey(path, value, key)
+}
+
+// ssmProvider reads parameters from the SSM Parameter Store. The path is
+// the name of the parameter, e.g. /prod/db/password. SecureString
+// parameters are decrypted. With a key, the parameter must be a JSON object
+// and the value of the key is returned.
+type ssmProvider struct {
+	client *aws.Client
+}
+
+func (p *ssmProvider) Get(ctx context.Context, path, key string) (string, error) {
+	cfg, err := p.client.Config(ctx)
+	if err != nil {
+		return "", err
+	}
+	if cfg.Region == "" {
+		return "", aws.ErrRegion
+	}
+	resp, err := ssm.NewFromConfig(cfg).GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           &path,
+		WithDecryption: sdkaws.Bool(true),
+	})
+	if err != nil {
+		return "", err
+	}
+	return jsonKey(path, sdkaws.ToString(resp.Parameter.Value), key)
+}
+
+func init() {
+	RegisterScheme("aws-sm", &secretsManagerProvider{client: aws.Default})
+	ssm := &ssmProvider{client: aws.Default}
+	RegisterScheme("ssm", ssm)
+	// The parameters of the DAGs can also take their defaults from the
+	// Parameter Store. The values are not masked since they are not secrets.
+	dag.RegisterParamFetcher("ssm", dag.ParamFetcherFunc(func(ctx context.Context, ref string) (string, error) {
+		return ssm.Get(ctx, strings.TrimPrefix(ref, "ssm://"), "")
+	}))
+}
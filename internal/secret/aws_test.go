@@ -0,0 +1,121 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/dagu-dev/dagu/internal/aws"
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func testAWSServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var in map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		switch r.Header.Get("X-Amz-Target") {
+		case "secretsmanager.GetSecretValue":
+			require.Contains(t, auth, "/secretsmanager/aws4_request")
+			switch in["SecretId"] {
+			case "prod/db":
+				require.Contains(t, auth, "/us-east-1/")
+				_, _ = w.Write([]byte(`{"SecretString":"{\"password\":\"db-pass\",\"port\":5432}"}`))
+			case "arn:aws:secretsmanager:eu-west-1:123456789012:secret:token":
+				require.Contains(t, auth, "/eu-west-1/")
+				_, _ = w.Write([]byte(`{"SecretString":"api-token"}`))
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"__type":"ResourceNotFoundException","message":"not found"}`))
+			}
+		case "AmazonSSM.GetParameter":
+			require.Contains(t, auth, "/ssm/aws4_request")
+			require.Equal(t, true, in["WithDecryption"])
+			switch in["Name"] {
+			case "/prod/db/password":
+				_, _ = w.Write([]byte(`{"Parameter":{"Name":"/prod/db/password","Value":"ssm-pass"}}`))
+			case "/prod/db":
+				_, _ = w.Write([]byte(`{"Parameter":{"Name":"/prod/db","Value":"{\"user\":\"app\",\"port\":5432}"}}`))
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+}
+
+func TestAWSProviders(t *testing.T) {
+	srv := testAWSServer(t)
+	defer srv.Close()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "SECRET")
+
+	ctx := context.Background()
+	client := aws.New(func() *config.AWS {
+		return &config.AWS{Region: "us-east-1", Endpoint: srv.URL}
+	})
+	sm := &secretsManagerProvider{client: client}
+
+	v, err := sm.Get(ctx, "prod/db", "password")
+	require.NoError(t, err)
+	require.Equal(t, "db-pass", v)
+
+	v, err = sm.Get(ctx, "prod/db", "port")
+	require.NoError(t, err)
+	require.Equal(t, "5432", v)
+
+	v, err = sm.Get(ctx, "arn:aws:secretsmanager:eu-west-1:123456789012:secret:token", "")
+	require.NoError(t, err)
+	require.Equal(t, "api-token", v)
+
+	_, err = sm.Get(ctx, "prod/db", "unknown")
+	require.ErrorIs(t, err, errKeyNotFound)
+
+	_, err = sm.Get(ctx, "unknown", "")
+	var notFound *types.ResourceNotFoundException
+	require.ErrorAs(t, err, &notFound)
+
+	ssm := &ssmProvider{client: client}
+	v, err = ssm.Get(ctx, "/prod/db/password", "")
+	require.NoError(t, err)
+	require.Equal(t, "ssm-pass", v)
+
+	// The key is the one of the JSON object of the parameter.
+	v, err = ssm.Get(ctx, "/prod/db", "user")
+	require.NoError(t, err)
+	require.Equal(t, "app", v)
+	v, err = ssm.Get(ctx, "/prod/db", "port")
+	require.NoError(t, err)
+	require.Equal(t, "5432", v)
+	_, err = ssm.Get(ctx, "/prod/db", "password")
+	require.ErrorIs(t, err, errKeyNotFound)
+	_, err = ssm.Get(ctx, "/prod/db/password", "user")
+	require.ErrorContains(t, err, "not a JSON object")
+}
+
+func TestAWSRefs(t *testing.T) {
+	ref, err := ParseRef("aws-sm://prod/db#password")
+	require.NoError(t, err)
+	require.Equal(t, Ref{Provider: "aws-sm", Path: "prod/db", Key: "password"}, ref)
+	require.Equal(t, "aws-sm://prod/db#password", ref.String())
+
+	ref, err = ParseRef("ssm:///prod/db/password")
+	require.NoError(t, err)
+	require.Equal(t, Ref{Provider: "ssm", Path: "/prod/db/password"}, ref)
+
+	require.False(t, IsRef("https://example.com"))
+	_, err = ParseRef("ssm://")
+	require.ErrorIs(t, err, errInvalidRef)
+}
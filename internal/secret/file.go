@@ -0,0 +1,26 @@
+package secret
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+// fileProvider reads secrets from files, e.g. the ones of the Docker and the
+// Kubernetes secrets mounted in the container. The path is absolute, e.g.
+// secret://file/run/secrets/smtp for /run/secrets/smtp, and the key is a
+// field of the JSON object of the file. The trailing newline of a file is
+// not part of the secret.
+type fileProvider struct{}
+
+func (fileProvider) Get(_ context.Context, path, key string) (string, error) {
+	b, err := os.ReadFile("/" + strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return "", err
+	}
+	return jsonKey(path, strings.TrimRight(string(b), "\r\n"), key)
+}
+
+func init() {
+	Register("file", fileProvider{})
+}
@@ -0,0 +1,386 @@
+// Package secret resolves references to secrets stored in external secret
+// managers and masks the resolved values in the status and the logs.
+//
+// A reference has the form secret://<provider>/<path>#<key>, e.g.
+// secret://vault/secret/data/app#password. Providers registered with a
+// scheme are also referred to as <scheme>://<path>#<key>, e.g.
+// aws-sm://prod/db#password.
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	refPrefix = "secret://"
+	// minMaskLength is the minimum length of a secret value to be masked.
+	// Shorter values would mask too much of the unrelated output.
+	minMaskLength = 4
+	maskedValue   = "*****"
+)
+
+var (
+	errInvalidRef      = errors.New("invalid secret reference")
+	errUnknownProvider = errors.New("unknown secret provider")
+	errKeyNotFound     = errors.New("key not found in secret")
+)
+
+// Provider reads secrets from a secret manager.
+type Provider interface {
+	// Get returns the value of the key of the secret at the path. If the
+	// key is empty, the whole secret is returned.
+	Get(ctx context.Context, path, key string) (string, error)
+}
+
+// Ref is a reference to a secret.
+type Ref struct {
+	Provider string
+	Path     string
+	Key      string
+}
+
+func (r Ref) String() string {
+	s := refPrefix + r.Provider + "/" + r.Path
+	if schemes[r.Provider] {
+		s = r.Provider + "://" + r.Path
+	}
+	if r.Key != "" {
+		s += "#" + r.Key
+	}
+	return s
+}
+
+var (
+	providers = make(map[string]Provider)
+	// schemes are the providers that can be referred to by their own URL
+	// scheme.
+	schemes = make(map[string]bool)
+	// resolved caches the resolved values by reference so that each secret
+	// is read once per process and can be masked.
+	resolved   = make(map[string]string)
+	resolvedMu sync.RWMutex
+	// retired are the values of the secrets that were read again and
+	// changed, which are still masked.
+	retired = make(map[string]bool)
+)
+
+// Register registers the provider with the name used in the references.
+func Register(name string, p Provider) {
+	providers[name] = p
+}
+
+// RegisterScheme registers the provider with the URL scheme used in the
+// references.
+func RegisterScheme(scheme string, p Provider) {
+	providers[scheme] = p
+	schemes[scheme] = true
+}
+
+// IsRef reports whether the value is a secret reference.
+func IsRef(value string) bool {
+	if strings.HasPrefix(value, refPrefix) {
+		return true
+	}
+	scheme, _, ok := strings.Cut(value, "://")
+	return ok && schemes[scheme]
+}
+
+// ParseRef parses a secret reference.
+func ParseRef(value string) (Ref, error) {
+	if !IsRef(value) {
+		return Ref{}, fmt.Errorf("%w: %s", errInvalidRef, value)
+	}
+	rest, key, _ := strings.Cut(value, "#")
+	var provider, path string
+	if strings.HasPrefix(rest, refPrefix) {
+		provider, path, _ = strings.Cut(strings.TrimPrefix(rest, refPrefix), "/")
+	} else {
+		provider, path, _ = strings.Cut(rest, "://")
+	}
+	if provider == "" || path == "" {
+		return Ref{}, fmt.Errorf("%w: %s", errInvalidRef, value)
+	}
+	return Ref{Provider: provider, Path: path, Key: key}, nil
+}
+
+// jsonKey returns the value of the secret, or the value of the key of the
+// secret if there is a key, in which case the secret must be a JSON object.
+func jsonKey(path, value, key string) (string, error) {
+	if key == "" {
+		return value, nil
+	}
+	var data map[string]any
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return "", fmt.Errorf("secret %s is not a JSON object: %w", path, err)
+	}
+	v, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", errKeyNotFound, key)
+	}
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// Resolve returns the value of the secret reference.
+func Resolve(ctx context.Context, value string) (string, error) {
+	resolvedMu.RLock()
+	v, ok := resolved[value]
+	resolvedMu.RUnlock()
+	if ok {
+		return v, nil
+	}
+	ref, err := ParseRef(value)
+	if err != nil {
+		return "", err
+	}
+	p, ok := providers[ref.Provider]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", errUnknownProvider, ref.Provider)
+	}
+	v, err = p.Get(ctx, ref.Path, ref.Key)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	resolvedMu.Lock()
+	resolved[value] = v
+	resolvedMu.Unlock()
+	return v, nil
+}
+
+// Current returns the current value of a credential, which is the value
+// itself unless it is a secret reference. Unlike Resolve, the secret is read
+// again each time, so that a credential rotated in its secret manager or its
+// file is used without restarting the process. The value read last is
+// returned if the secret cannot be read anymore.
+func Current(ctx context.Context, value string) (string, error) {
+	if !IsRef(value) {
+		return value, nil
+	}
+	ref, err := ParseRef(value)
+	if err != nil {
+		return "", err
+	}
+	p, ok := providers[ref.Provider]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", errUnknownProvider, ref.Provider)
+	}
+	v, err := p.Get(ctx, ref.Path, ref.Key)
+	resolvedMu.Lock()
+	defer resolvedMu.Unlock()
+	last, ok := resolved[value]
+	if err != nil {
+		if ok {
+			log.Printf("failed to read %s again, using its last value: %v", ref, err)
+			return last, nil
+		}
+		return "", fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	if ok && last != v {
+		retired[last] = true
+	}
+	resolved[value] = v
+	return v, nil
+}
+
+// Refresh forgets the resolved secrets, so that Resolve reads them again.
+// Their values are still masked.
+func Refresh() {
+	resolvedMu.Lock()
+	defer resolvedMu.Unlock()
+	for _, v := range resolved {
+		retired[v] = true
+	}
+	resolved = make(map[string]string)
+}
+
+// ResolveVars returns the KEY=VALUE pairs with the secret references
+// replaced by their values.
+func ResolveVars(ctx context.Context, vars []string) ([]string, error) {
+	var ret []string
+	for i, kv := range vars {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || !IsRef(value) {
+			continue
+		}
+		v, err := Resolve(ctx, value)
+		if err != nil {
+			return nil, err
+		}
+		if ret == nil {
+			ret = append([]string{}, vars...)
+		}
+		ret[i] = key + "=" + v
+	}
+	if ret == nil {
+		return vars, nil
+	}
+	return ret, nil
+}
+
+// ResolveEnv replaces the secret references in the environment of the
+// process by their values.
+func ResolveEnv(ctx context.Context) error {
+	vars, err := ResolveVars(ctx, os.Environ())
+	if err != nil {
+		return err
+	}
+	for _, kv := range vars {
+		key, value, _ := strings.Cut(kv, "=")
+		if os.Getenv(key) != value {
+			if err := os.Setenv(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Mask replaces the resolved secret values in s.
+func Mask(s string) string {
+	r := replacer()
+	if r == nil {
+		return s
+	}
+	return r.Replace(s)
+}
+
+func replacer() *strings.Replacer {
+	oldnew := maskPairs()
+	if len(oldnew) == 0 {
+		return nil
+	}
+	return strings.NewReplacer(oldnew...)
+}
+
+// maskPairs returns the pairs of the values to mask and of the mask, for
+// strings.NewReplacer.
+func maskPairs() []string {
+	resolvedMu.RLock()
+	defer resolvedMu.RUnlock()
+	var oldnew []string
+	values := make([]string, 0, len(resolved)+len(retired))
+	for _, v := range resolved {
+		values = append(values, v)
+	}
+	for v := range retired {
+		values = append(values, v)
+	}
+	for _, v := range values {
+		if len(v) < minMaskLength {
+			continue
+		}
+		oldnew = append(oldnew, v, maskedValue)
+		// Values are also masked in JSON documents such as the status.
+		if b, err := json.Marshal(v); err == nil {
+			if escaped := string(b[1 : len(b)-1]); escaped != v {
+				oldnew = append(oldnew, escaped, maskedValue)
+			}
+		}
+	}
+	return oldnew
+}
+
+// NewMaskWriter returns a writer that masks the resolved secret values in
+// the written contents. The end of a write that may be the start of a value
+// is held back until the next write, so that the values split across writes
+// are masked too, and Close writes what is held back. If w is buffered, it
+// is flushed after each write so that the output is not delayed.
+func NewMaskWriter(w io.Writer) io.WriteCloser {
+	return &maskWriter{w: w}
+}
+
+type maskWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+	// held is the end of the contents written last that is not masked and
+	// written yet.
+	held []byte
+}
+
+type flusher interface {
+	Flush() error
+}
+
+func (m *maskWriter) Write(p []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	oldnew := maskPairs()
+	s := string(m.held) + string(p)
+	cut := safeCut(s, oldnew)
+	m.held = []byte(s[cut:])
+	if err := m.write(s[:cut], oldnew); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close writes the contents held back. It does not close the underlying
+// writer.
+func (m *maskWriter) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := string(m.held)
+	m.held = nil
+	if s == "" {
+		return nil
+	}
+	return m.write(s, maskPairs())
+}
+
+func (m *maskWriter) write(s string, oldnew []string) error {
+	if len(oldnew) > 0 {
+		s = strings.NewReplacer(oldnew...).Replace(s)
+	}
+	if _, err := io.WriteString(m.w, s); err != nil {
+		return err
+	}
+	if f, ok := m.w.(flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// safeCut returns the length of the start of s that is masked and written
+// at once: s without its longest end that is the start of a value, and
+// without the value that overlaps that end, if any.
+func safeCut(s string, oldnew []string) int {
+	cut := len(s)
+	for i := 0; i < len(oldnew); i += 2 {
+		v := oldnew[i]
+		for k := min(len(v)-1, len(s)); k > len(s)-cut; k-- {
+			if strings.HasSuffix(s, v[:k]) {
+				cut = len(s) - k
+				break
+			}
+		}
+	}
+	for moved := true; moved; {
+		moved = false
+		for i := 0; i < len(oldnew); i += 2 {
+			v := oldnew[i]
+			// A value found in the window starts before the cut and ends
+			// after it.
+			from := max(0, cut-len(v)+1)
+			to := min(len(s), cut+len(v)-1)
+			if from >= to {
+				continue
+			}
+			if j := strings.Index(s[from:to], v); j >= 0 {
+				cut = from + j
+				moved = true
+			}
+		}
+	}
+	return cut
+}
@@ -0,0 +1,141 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testProvider map[string]string
+
+func (p testProvider) Get(_ context.Context, path, key string) (string, error) {
+	v, ok := p[path+"#"+key]
+	if !ok {
+		return "", errKeyNotFound
+	}
+	return v, nil
+}
+
+func TestParseRef(t *testing.T) {
+	ref, err := ParseRef("secret://vault/secret/data/app#password")
+	require.NoError(t, err)
+	require.Equal(t, Ref{Provider: "vault", Path: "secret/data/app", Key: "password"}, ref)
+	require.Equal(t, "secret://vault/secret/data/app#password", ref.String())
+
+	for _, v := range []string{"vault/secret", "secret://vault", "secret:///path"} {
+		_, err := ParseRef(v)
+		require.ErrorIs(t, err, errInvalidRef)
+	}
+}
+
+func TestResolveVars(t *testing.T) {
+	Register("test", testProvider{"app#password": "s3cr3t-value", "app#user": "admin"})
+
+	vars, err := ResolveVars(context.Background(), []string{
+		"PLAIN=value",
+		"PASSWORD=secret://test/app#password",
+		"USER=secret://test/app#user",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"PLAIN=value", "PASSWORD=s3cr3t-value", "USER=admin"}, vars)
+
+	_, err = ResolveVars(context.Background(), []string{"X=secret://test/app#unknown"})
+	require.Error(t, err)
+	_, err = ResolveVars(context.Background(), []string{"X=secret://unknown/app#key"})
+	require.ErrorIs(t, err, errUnknownProvider)
+
+	_ = os.Setenv("SECRET_TEST_PASSWORD", "secret://test/app#password")
+	require.NoError(t, ResolveEnv(context.Background()))
+	require.Equal(t, "s3cr3t-value", os.Getenv("SECRET_TEST_PASSWORD"))
+}
+
+func TestMask(t *testing.T) {
+	Register("test-mask", testProvider{"app#json": `p"w\d`, "app#short": "abc"})
+	_, err := ResolveVars(context.Background(), []string{
+		"A=secret://test-mask/app#json",
+		"B=secret://test-mask/app#short",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "value is ***** abc", Mask(`value is p"w\d abc`))
+	require.Equal(t, `{"Args":["*****"]}`, Mask(`{"Args":["p\"w\\d"]}`))
+
+	var buf bytes.Buffer
+	w := NewMaskWriter(&buf)
+	n, err := w.Write([]byte("echo p\"w\\d\n"))
+	require.NoError(t, err)
+	require.Equal(t, 11, n)
+	require.Equal(t, "echo *****\n", buf.String())
+
+	// A value split across writes is masked, and the end of a write that
+	// may be the start of a value is written by the next write or by Close.
+	buf.Reset()
+	for _, p := range []string{"echo p", "\"w", "\\d\n", "p\"w"} {
+		_, err := w.Write([]byte(p))
+		require.NoError(t, err)
+	}
+	require.Equal(t, "echo *****\n", buf.String())
+	require.NoError(t, w.Close())
+	require.Equal(t, "echo *****\np\"w", buf.String())
+}
+
+func TestCurrent(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "smtp")
+	require.NoError(t, os.WriteFile(file, []byte("first-password\n"), 0600))
+	ref := "secret://file" + file
+	ctx := context.Background()
+
+	v, err := Current(ctx, "plain")
+	require.NoError(t, err)
+	require.Equal(t, "plain", v)
+
+	v, err = Current(ctx, ref)
+	require.NoError(t, err)
+	require.Equal(t, "first-password", v)
+
+	// The rotated secret is read again, and both values are masked.
+	require.NoError(t, os.WriteFile(file, []byte("second-password"), 0600))
+	v, err = Current(ctx, ref)
+	require.NoError(t, err)
+	require.Equal(t, "second-password", v)
+	require.Equal(t, "***** *****", Mask("first-password second-password"))
+
+	// The last value is used if the secret cannot be read anymore.
+	require.NoError(t, os.Remove(file))
+	v, err = Current(ctx, ref)
+	require.NoError(t, err)
+	require.Equal(t, "second-password", v)
+	_, err = Current(ctx, "secret://file"+file+"-unknown")
+	require.Error(t, err)
+
+	// A field of a JSON file.
+	require.NoError(t, os.WriteFile(file, []byte(`{"token": "xoxb-token"}`), 0600))
+	v, err = Current(ctx, ref+"#token")
+	require.NoError(t, err)
+	require.Equal(t, "xoxb-token", v)
+}
+
+func TestRefresh(t *testing.T) {
+	p := testProvider{"app#token": "old-token"}
+	Register("test-refresh", p)
+	ctx := context.Background()
+
+	v, err := Resolve(ctx, "secret://test-refresh/app#token")
+	require.NoError(t, err)
+	require.Equal(t, "old-token", v)
+
+	p["app#token"] = "new-token"
+	v, err = Resolve(ctx, "secret://test-refresh/app#token")
+	require.NoError(t, err)
+	require.Equal(t, "old-token", v)
+
+	Refresh()
+	v, err = Resolve(ctx, "secret://test-refresh/app#token")
+	require.NoError(t, err)
+	require.Equal(t, "new-token", v)
+	require.Equal(t, "***** *****", Mask("old-token new-token"))
+}
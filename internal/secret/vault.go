@@ -0,0 +1,176 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+)
+
+const (
+	vaultAuthToken      = "token"
+	vaultAuthAppRole    = "approle"
+	vaultAuthKubernetes = "kubernetes"
+
+	defaultK8sTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+var (
+	errVaultNotConfigured  = errors.New("vault address is not configured")
+	errVaultAuthMethod     = errors.New("unknown vault auth method")
+	errVaultSecretNotFound = errors.New("secret not found")
+	errVaultRequestFailed  = errors.New("vault request failed")
+)
+
+// vaultProvider reads secrets from HashiCorp Vault. Both versions of the KV
+// secrets engine are supported; for version 2 the path includes "data",
+// e.g. secret/data/app.
+type vaultProvider struct {
+	// config returns the configuration. It is read when the first secret
+	// is resolved.
+	config func() *config.Vault
+	client *http.Client
+
+	mu    sync.Mutex
+	token string
+}
+
+func newVaultProvider(cfg func() *config.Vault) *vaultProvider {
+	return &vaultProvider{
+		config: cfg,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (p *vaultProvider) Get(ctx context.Context, path, key string) (string, error) {
+	cfg := p.config()
+	if cfg == nil || cfg.Address == "" {
+		return "", errVaultNotConfigured
+	}
+	token, err := p.login(ctx, cfg)
+	if err != nil {
+		return "", err
+	}
+	var resp struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := p.do(ctx, cfg, http.MethodGet, path, token, nil, &resp); err != nil {
+		return "", err
+	}
+	data := resp.Data
+	// KV version 2 nests the secret in data.data along with its metadata.
+	if nested, ok := data["data"].(map[string]any); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+	if key == "" {
+		b, err := json.Marshal(data)
+		return string(b), err
+	}
+	v, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", errKeyNotFound, key)
+	}
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// login returns the token to read secrets with, logging in with the auth
+// method the first time.
+func (p *vaultProvider) login(ctx context.Context, cfg *config.Vault) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token != "" {
+		return p.token, nil
+	}
+	method := cfg.AuthMethod
+	if method == "" {
+		method = vaultAuthToken
+	}
+	mount := cfg.AuthMount
+	if mount == "" {
+		mount = method
+	}
+	var body map[string]string
+	switch method {
+	case vaultAuthToken:
+		p.token = cfg.Token
+		return p.token, nil
+	case vaultAuthAppRole:
+		body = map[string]string{"role_id": cfg.RoleID, "secret_id": cfg.SecretID}
+	case vaultAuthKubernetes:
+		file := cfg.TokenFile
+		if file == "" {
+			file = defaultK8sTokenFile
+		}
+		jwt, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read service account token: %w", err)
+		}
+		body = map[string]string{"role": cfg.Role, "jwt": strings.TrimSpace(string(jwt))}
+	default:
+		return "", fmt.Errorf("%w: %s", errVaultAuthMethod, method)
+	}
+	var resp struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	if err := p.do(ctx, cfg, http.MethodPost, "auth/"+mount+"/login", "", body, &resp); err != nil {
+		return "", fmt.Errorf("failed to login to vault: %w", err)
+	}
+	p.token = resp.Auth.ClientToken
+	return p.token, nil
+}
+
+func (p *vaultProvider) do(ctx context.Context, cfg *config.Vault, method, path, token string, body, out any) error {
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			return err
+		}
+	}
+	url := strings.TrimSuffix(cfg.Address, "/") + "/v1/" + strings.TrimPrefix(path, "/")
+	req, err := http.NewRequestWithContext(ctx, method, url, &buf)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", cfg.Namespace)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%w: %s", errVaultSecretNotFound, path)
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("%w: %s", errVaultRequestFailed, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func init() {
+	Register("vault", newVaultProvider(func() *config.Vault {
+		return config.Get().Vault
+	}))
+}
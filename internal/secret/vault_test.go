@@ -0,0 +1,105 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func testVaultServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/approle/login", "/v1/auth/k8s/login":
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			if body["secret_id"] != "sid" && body["jwt"] != "sa-token" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(`{"auth":{"client_token":"login-token"}}`))
+			return
+		}
+		if token := r.Header.Get("X-Vault-Token"); token != "root" && token != "login-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/app":
+			_, _ = w.Write([]byte(`{"data":{"data":{"password":"v2-pass","port":5432},"metadata":{"version":1}}}`))
+		case "/v1/kv/app":
+			_, _ = w.Write([]byte(`{"data":{"password":"v1-pass"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestVaultProvider(t *testing.T) {
+	srv := testVaultServer(t)
+	defer srv.Close()
+
+	ctx := context.Background()
+	p := newVaultProvider(func() *config.Vault {
+		return &config.Vault{Address: srv.URL, Token: "root"}
+	})
+
+	v, err := p.Get(ctx, "secret/data/app", "password")
+	require.NoError(t, err)
+	require.Equal(t, "v2-pass", v)
+
+	v, err = p.Get(ctx, "secret/data/app", "port")
+	require.NoError(t, err)
+	require.Equal(t, "5432", v)
+
+	v, err = p.Get(ctx, "kv/app", "password")
+	require.NoError(t, err)
+	require.Equal(t, "v1-pass", v)
+
+	v, err = p.Get(ctx, "kv/app", "")
+	require.NoError(t, err)
+	require.Equal(t, `{"password":"v1-pass"}`, v)
+
+	_, err = p.Get(ctx, "kv/app", "unknown")
+	require.ErrorIs(t, err, errKeyNotFound)
+
+	_, err = p.Get(ctx, "kv/unknown", "password")
+	require.ErrorIs(t, err, errVaultSecretNotFound)
+}
+
+func TestVaultAuthMethods(t *testing.T) {
+	srv := testVaultServer(t)
+	defer srv.Close()
+
+	ctx := context.Background()
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("sa-token\n"), 0600))
+
+	for _, cfg := range []*config.Vault{
+		{Address: srv.URL, AuthMethod: "approle", RoleID: "rid", SecretID: "sid"},
+		{Address: srv.URL, AuthMethod: "kubernetes", AuthMount: "k8s", Role: "dagu", TokenFile: tokenFile},
+	} {
+		cfg := cfg
+		p := newVaultProvider(func() *config.Vault { return cfg })
+		v, err := p.Get(ctx, "kv/app", "password")
+		require.NoError(t, err)
+		require.Equal(t, "v1-pass", v)
+	}
+
+	p := newVaultProvider(func() *config.Vault {
+		return &config.Vault{Address: srv.URL, AuthMethod: "approle", SecretID: "wrong"}
+	})
+	_, err := p.Get(ctx, "kv/app", "password")
+	require.ErrorIs(t, err, errVaultRequestFailed)
+
+	p = newVaultProvider(func() *config.Vault { return nil })
+	_, err = p.Get(ctx, "kv/app", "password")
+	require.ErrorIs(t, err, errVaultNotConfigured)
+}
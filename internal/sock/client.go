@@ -6,6 +6,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -16,6 +17,16 @@ var (
 	timeout = time.Millisecond * 3000
 )
 
+// ResponseError is the error of a request that the server rejected.
+type ResponseError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("%d %s", e.StatusCode, e.Message)
+}
+
 // Client is a unix socket client that can send requests
 // to the frontend over HTTP.
 type Client struct {
@@ -51,6 +62,9 @@ func (cl *Client) Request(method, url string) (string, error) {
 	if err != nil {
 		return "", procError("read response body", err)
 	}
+	if response.StatusCode >= http.StatusBadRequest {
+		return "", &ResponseError{StatusCode: response.StatusCode, Message: strings.TrimSpace(string(body))}
+	}
 
 	return string(body), nil
 }
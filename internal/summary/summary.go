@@ -0,0 +1,235 @@
+// Package summary writes a single record of each finished run, with its
+// status, the durations and the retries of its steps and the digests of its
+// outputs, to a sink of the configuration, so that the external systems
+// consume the outcomes of the runs without polling the API.
+package summary
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/dagu-dev/dagu/internal/webhook"
+)
+
+// The sinks of the summaries.
+const (
+	SinkStdout  = "stdout"
+	SinkFile    = "file"
+	SinkWebhook = "webhook"
+)
+
+// Event is the kind of the event of the summaries posted to a webhook.
+const Event = "run.summary"
+
+var (
+	errUnknownSink = errors.New("unknown run summary sink")
+	errNoPath      = errors.New("the file sink of the run summaries requires a path")
+	errNoURL       = errors.New("the webhook sink of the run summaries requires a url")
+)
+
+// Summary is the summary of a finished run.
+type Summary struct {
+	DAG        string `json:"dag"`
+	RequestId  string `json:"requestId"`
+	Namespace  string `json:"namespace,omitempty"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	StartedAt  string `json:"startedAt,omitempty"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+	// Retries is the number of the retries of all the steps.
+	Retries int    `json:"retries"`
+	Steps   []Step `json:"steps"`
+}
+
+// Step is the summary of a step of the run, or of a handler.
+type Step struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+	Retries    int    `json:"retries"`
+	// Outputs are the SHA-256 digests of the values of the output variables
+	// by their names, e.g. sha256:9f86d08..., so that the consumers detect
+	// the changes of the outputs without receiving their values.
+	Outputs map[string]string `json:"outputs,omitempty"`
+}
+
+// New returns the summary of the finished run of the status.
+func New(status *model.Status, namespace string, err error) *Summary {
+	s := &Summary{
+		DAG:        status.Name,
+		RequestId:  status.RequestId,
+		Namespace:  namespace,
+		Status:     status.StatusText,
+		StartedAt:  status.StartedAt,
+		FinishedAt: status.FinishedAt,
+		DurationMs: durationMs(status.StartedAt, status.FinishedAt),
+		Steps:      []Step{},
+	}
+	if err != nil {
+		s.Error = err.Error()
+	}
+	nodes := append([]*model.Node{}, status.Nodes...)
+	// The handlers are summarized if they ran.
+	for _, n := range []*model.Node{status.OnExit, status.OnSuccess, status.OnFailure, status.OnCancel, status.OnTimeout} {
+		if n != nil && n.Status != scheduler.NodeStatusNone {
+			nodes = append(nodes, n)
+		}
+	}
+	for _, n := range nodes {
+		step := Step{
+			Name:       n.Step.Name,
+			Status:     n.StatusText,
+			Error:      n.Error,
+			DurationMs: durationMs(n.StartedAt, n.FinishedAt),
+			Retries:    n.RetryCount,
+			Outputs:    digests(n),
+		}
+		s.Retries += n.RetryCount
+		s.Steps = append(s.Steps, step)
+	}
+	return s
+}
+
+func durationMs(startedAt, finishedAt string) int64 {
+	start, err := utils.ParseTime(startedAt)
+	if err != nil || start.IsZero() {
+		return 0
+	}
+	end, err := utils.ParseTime(finishedAt)
+	if err != nil || end.Before(start) {
+		return 0
+	}
+	return end.Sub(start).Milliseconds()
+}
+
+func digests(n *model.Node) map[string]string {
+	if n.Step.OutputVariables == nil {
+		return nil
+	}
+	outputs := map[string]string{}
+	n.Step.OutputVariables.Range(func(_, value any) bool {
+		if k, v, ok := strings.Cut(value.(string), "="); ok {
+			sum := sha256.Sum256([]byte(v))
+			outputs[k] = "sha256:" + hex.EncodeToString(sum[:])
+		}
+		return true
+	})
+	return outputs
+}
+
+// Writer writes the summaries to a sink.
+type Writer struct {
+	Sink string
+	// Stdout is where the stdout sink writes the summaries, os.Stdout if it
+	// is nil.
+	Stdout io.Writer
+	// Path is the file of the file sink, to which the summaries are
+	// appended as JSON lines.
+	Path string
+	// Webhook is the webhook of the webhook sink, and Sender posts the
+	// summaries to it.
+	Webhook *webhook.Webhook
+	Sender  *webhook.Sender
+}
+
+// fileMu serializes the appends of the summaries to the files in the
+// process.
+var fileMu sync.Mutex
+
+// Validate returns an error if the writer is not valid.
+func (w *Writer) Validate() error {
+	switch w.Sink {
+	case SinkStdout:
+	case SinkFile:
+		if w.Path == "" {
+			return errNoPath
+		}
+	case SinkWebhook:
+		if w.Webhook == nil || w.Webhook.URL == "" {
+			return errNoURL
+		}
+	default:
+		return fmt.Errorf("%w: %s", errUnknownSink, w.Sink)
+	}
+	return nil
+}
+
+// Write writes the summary as a single line of JSON, or posts it to the
+// webhook.
+func (w *Writer) Write(s *Summary) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	switch w.Sink {
+	case SinkWebhook:
+		return w.Sender.Deliver(w.Webhook, Event, b)
+	case SinkFile:
+		return appendLine(w.Path, b)
+	default:
+		out := w.Stdout
+		if out == nil {
+			out = os.Stdout
+		}
+		_, err = out.Write(append(b, '\n'))
+		return err
+	}
+}
+
+// appendLine appends the line to the file in a single write, so that the
+// summaries of the runs of other processes are not interleaved with it.
+func appendLine(path string, b []byte) error {
+	fileMu.Lock()
+	defer fileMu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// NewWriter returns the writer of the sink of the configuration, or nil if
+// there is none. An invalid sink is logged, so that it does not fail the
+// runs.
+func NewWriter(cfg *config.Config) *Writer {
+	c := cfg.RunSummary
+	if c == nil || c.Sink == "" {
+		return nil
+	}
+	w := &Writer{Sink: c.Sink, Path: c.Path}
+	if c.Sink == SinkWebhook && c.URL != "" {
+		w.Webhook = &webhook.Webhook{Name: "run summary", URL: c.URL, Secret: c.Secret, Headers: c.Headers}
+		w.Sender = &webhook.Sender{
+			Timeout: time.Second * time.Duration(cfg.NotificationTimeoutSec),
+			Retries: cfg.NotificationRetries,
+		}
+	}
+	if err := w.Validate(); err != nil {
+		log.Printf("invalid run summary sink: %v", err)
+		return nil
+	}
+	return w
+}
@@ -0,0 +1,86 @@
+package summary
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/dagu-dev/dagu/internal/webhook"
+	"github.com/stretchr/testify/require"
+)
+
+func testStatus() *model.Status {
+	outputs := &utils.SyncMap{}
+	outputs.Store("OUT", "OUT=secret value")
+	return &model.Status{
+		RequestId:  "req",
+		Name:       "etl",
+		Status:     scheduler.StatusSuccess,
+		StatusText: scheduler.StatusSuccess.String(),
+		StartedAt:  "2024-03-01 10:00:00",
+		FinishedAt: "2024-03-01 10:01:30",
+		Nodes: []*model.Node{{
+			Step:       dag.Step{Name: "extract", OutputVariables: outputs},
+			Status:     scheduler.NodeStatusSuccess,
+			StatusText: scheduler.NodeStatusSuccess.String(),
+			StartedAt:  "2024-03-01 10:00:00",
+			FinishedAt: "2024-03-01 10:00:10",
+			RetryCount: 2,
+		}},
+		OnExit: &model.Node{Step: dag.Step{Name: "onExit"}, Status: scheduler.NodeStatusNone},
+	}
+}
+
+func TestNew(t *testing.T) {
+	s := New(testStatus(), "staging", nil)
+	require.Equal(t, "etl", s.DAG)
+	require.Equal(t, "staging", s.Namespace)
+	require.Equal(t, int64(90000), s.DurationMs)
+	require.Equal(t, 2, s.Retries)
+	// The handler that did not run is not summarized.
+	require.Len(t, s.Steps, 1)
+	require.Equal(t, int64(10000), s.Steps[0].DurationMs)
+	sum := sha256.Sum256([]byte("secret value"))
+	require.Equal(t, map[string]string{"OUT": "sha256:" + hex.EncodeToString(sum[:])}, s.Steps[0].Outputs)
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	w := &Writer{Sink: SinkStdout, Stdout: &buf}
+	require.NoError(t, w.Write(New(testStatus(), "", nil)))
+	require.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("\n")))
+	s := &Summary{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), s))
+	require.Equal(t, "req", s.RequestId)
+
+	var event string
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		event = r.Header.Get(webhook.HeaderEvent)
+		body, _ = io.ReadAll(r.Body)
+		require.Equal(t, webhook.Sign("secret", r.Header.Get(webhook.HeaderTimestamp), body), r.Header.Get(webhook.HeaderSignature))
+	}))
+	defer srv.Close()
+	w = NewWriter(&config.Config{RunSummary: &config.RunSummary{Sink: SinkWebhook, URL: srv.URL, Secret: "secret"}})
+	require.NotNil(t, w)
+	require.NoError(t, w.Write(New(testStatus(), "", nil)))
+	require.Equal(t, Event, event)
+	require.Contains(t, string(body), `"dag":"etl"`)
+}
+
+func TestNewWriter(t *testing.T) {
+	require.Nil(t, NewWriter(&config.Config{}))
+	require.Nil(t, NewWriter(&config.Config{RunSummary: &config.RunSummary{Sink: "kafka"}}))
+	require.Nil(t, NewWriter(&config.Config{RunSummary: &config.RunSummary{Sink: SinkFile}}))
+	require.NotNil(t, NewWriter(&config.Config{RunSummary: &config.RunSummary{Sink: SinkStdout}}))
+}
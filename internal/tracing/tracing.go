@@ -0,0 +1,163 @@
+// Package tracing exports the runs of the DAGs and the attempts of their
+// steps as OpenTelemetry spans with the OTLP/HTTP protocol, so that a run
+// shows up as a trace alongside the services it calls.
+//
+// The trace context is propagated in the W3C traceparent format. A run joins
+// the trace of the TRACEPARENT variable of its environment, e.g. of the step
+// that started it as a sub DAG run, and each step gets the traceparent of its
+// span in TRACEPARENT.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// EnvTraceparent is the variable the trace context is passed in to the
+// commands.
+const EnvTraceparent = "TRACEPARENT"
+
+const defaultServiceName = "dagu"
+
+// traceparentKey is the key of the traceparent of the W3C propagator.
+const traceparentKey = "traceparent"
+
+// Tracer records the spans and exports them to the collector.
+type Tracer struct {
+	provider *sdktrace.TracerProvider
+	tracer   trace.Tracer
+}
+
+// New returns the tracer of the configuration, or nil if the runs are not
+// traced. The OTEL_EXPORTER_OTLP_* variables of the environment configure
+// the exporter, e.g. the headers of OTEL_EXPORTER_OTLP_HEADERS if the
+// configuration has none, and OTEL_TRACES_SAMPLER the sampling of the new
+// traces.
+func New(cfg *config.Config) *Tracer {
+	if cfg.Tracing == nil || cfg.Tracing.Endpoint == "" {
+		return nil
+	}
+	return newTracer(cfg.Tracing)
+}
+
+func newTracer(cfg *config.Tracing, opts ...otlptracehttp.Option) *Tracer {
+	opts = append([]otlptracehttp.Option{
+		otlptracehttp.WithEndpointURL(strings.TrimSuffix(cfg.Endpoint, "/") + "/v1/traces"),
+	}, opts...)
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+	}
+	// The client only connects when the spans are exported.
+	exporter, _ := otlptracehttp.New(context.Background(), opts...)
+	name := cfg.ServiceName
+	if name == "" {
+		name = defaultServiceName
+	}
+	host, _ := os.Hostname()
+	res, _ := resource.Merge(resource.Environment(), resource.NewSchemaless(
+		attribute.String("service.name", name),
+		attribute.String("host.name", host),
+	))
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	return &Tracer{provider: provider, tracer: provider.Tracer(defaultServiceName)}
+}
+
+// Span is an operation of a trace, e.g. a run or an attempt of a step.
+type Span struct {
+	ctx  context.Context
+	span trace.Span
+}
+
+// Start starts a span of the trace of the parent, or of a new trace if the
+// parent is nil.
+func (t *Tracer) Start(name string, parent *Span) *Span {
+	ctx := context.Background()
+	if parent != nil {
+		ctx = parent.ctx
+	}
+	return t.start(ctx, name)
+}
+
+// StartRemote starts a span of the trace of the W3C traceparent, e.g. of the
+// environment, or of a new trace if it is not valid. The span is sampled
+// like its parent.
+func (t *Tracer) StartRemote(name, traceparent string) *Span {
+	ctx := propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier{
+		traceparentKey: strings.TrimSpace(traceparent),
+	})
+	return t.start(ctx, name)
+}
+
+func (t *Tracer) start(ctx context.Context, name string) *Span {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindInternal))
+	return &Span{ctx: ctx, span: span}
+}
+
+// Traceparent returns the W3C traceparent of the span, which the spans of
+// the commands are children of.
+func (s *Span) Traceparent() string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(s.ctx, carrier)
+	return carrier[traceparentKey]
+}
+
+// TraceID returns the hex ID of the trace of the span.
+func (s *Span) TraceID() string {
+	return s.span.SpanContext().TraceID().String()
+}
+
+// SetAttr sets an attribute of the span. The value is a string, a bool, an
+// integer or a float.
+func (s *Span) SetAttr(key string, value any) {
+	var kv attribute.KeyValue
+	switch v := value.(type) {
+	case bool:
+		kv = attribute.Bool(key, v)
+	case int:
+		kv = attribute.Int(key, v)
+	case int64:
+		kv = attribute.Int64(key, v)
+	case float64:
+		kv = attribute.Float64(key, v)
+	default:
+		kv = attribute.String(key, fmt.Sprint(v))
+	}
+	s.span.SetAttributes(kv)
+}
+
+// End ends the span with the error of the operation, if any. The span is
+// exported in the background, or with the next Flush of the tracer.
+func (s *Span) End(err error) {
+	if err != nil {
+		s.span.SetStatus(codes.Error, err.Error())
+	} else {
+		s.span.SetStatus(codes.Ok, "")
+	}
+	s.span.End()
+}
+
+// Flush exports the ended spans. The exports that fail with a status the
+// collector may recover from, e.g. 503, are retried until the context is
+// done.
+func (t *Tracer) Flush(ctx context.Context) error {
+	return t.provider.ForceFlush(ctx)
+}
+
+// Shutdown exports the ended spans and stops the tracer.
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	return t.provider.Shutdown(ctx)
+}
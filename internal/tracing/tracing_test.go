@@ -0,0 +1,102 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSpans(t *testing.T) {
+	tr := newTracer(&config.Tracing{Endpoint: "http://localhost:4318"})
+	defer func() {
+		_ = tr.Shutdown(context.Background())
+	}()
+	parent := tr.StartRemote("run", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", parent.TraceID())
+	child := tr.Start("step", parent)
+	require.Equal(t, parent.TraceID(), child.TraceID())
+	require.Equal(t, "00-"+child.TraceID()+"-"+child.span.SpanContext().SpanID().String()+"-01", child.Traceparent())
+
+	// The spans of a trace that is not sampled are not sampled.
+	unsampled := tr.StartRemote("run", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	require.False(t, unsampled.span.SpanContext().IsSampled())
+	require.True(t, strings.HasSuffix(tr.Start("step", unsampled).Traceparent(), "-00"))
+
+	// A span of a new trace has no parent.
+	require.NotEqual(t, parent.TraceID(), tr.StartRemote("run", "invalid").TraceID())
+}
+
+func TestFlush(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "X-Api-Key=key")
+	var (
+		mu       sync.Mutex
+		requests int
+		spans    []*tracepb.Span
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/traces", r.URL.Path)
+		require.Equal(t, "key", r.Header.Get("X-Api-Key"))
+		mu.Lock()
+		defer mu.Unlock()
+		// The collector is unavailable at first.
+		if requests++; requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req := &coltracepb.ExportTraceServiceRequest{}
+		require.NoError(t, proto.Unmarshal(body, req))
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				spans = append(spans, ss.Spans...)
+			}
+		}
+	}))
+	defer srv.Close()
+
+	tr := newTracer(&config.Tracing{Endpoint: srv.URL + "/"}, otlptracehttp.WithRetry(otlptracehttp.RetryConfig{
+		Enabled:         true,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		MaxElapsedTime:  time.Second,
+	}))
+	run := tr.Start("run", nil)
+	step := tr.Start("step", run)
+	step.SetAttr("process.exit_code", 1)
+	step.SetAttr("dagu.step.executor", "command")
+	step.End(errors.New("exit status 1"))
+	run.End(nil)
+	require.NoError(t, tr.Shutdown(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 2, requests)
+	require.Len(t, spans, 2)
+	s := spans[0]
+	require.Equal(t, "step", s.Name)
+	require.Equal(t, run.TraceID(), hex.EncodeToString(s.TraceId))
+	require.Equal(t, run.span.SpanContext().SpanID().String(), hex.EncodeToString(s.ParentSpanId))
+	require.Equal(t, tracepb.Status_STATUS_CODE_ERROR, s.Status.Code)
+	require.Equal(t, "exit status 1", s.Status.Message)
+	require.Equal(t, "process.exit_code", s.Attributes[0].Key)
+	require.Equal(t, int64(1), s.Attributes[0].Value.GetIntValue())
+	require.Empty(t, spans[1].ParentSpanId)
+
+	// A tracer without an endpoint does not trace.
+	require.Nil(t, New(&config.Config{}))
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// ProcessStart returns the start time of the process in clock ticks after
+// the boot of the host, read from /proc/<pid>/stat, or 0 if it is not
+// known, e.g. on the systems other than Linux.
+func ProcessStart(pid int) uint64 {
+	b, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0
+	}
+	// The fields follow the name of the command in parentheses, which may
+	// have spaces. The start time is the 22nd field, the state the 3rd.
+	i := bytes.LastIndexByte(b, ')')
+	if i < 0 {
+		return 0
+	}
+	fields := strings.Fields(string(b[i+1:]))
+	if len(fields) < 20 {
+		return 0
+	}
+	start, err := strconv.ParseUint(fields[19], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return start
+}
+
+// ProcessAlive returns true if the process exists, the process of another
+// user included, and it started at the start time returned by
+// ProcessStart, so that a process that reused the pid of one that exited is
+// not taken for it. The start time is not compared if it is 0.
+func ProcessAlive(pid int, start uint64) bool {
+	if err := unix.Kill(pid, 0); err != nil && !errors.Is(err, unix.EPERM) {
+		return false
+	}
+	if start == 0 {
+		return true
+	}
+	current := ProcessStart(pid)
+	return current == 0 || current == start
+}
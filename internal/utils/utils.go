@@ -9,10 +9,12 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/dagu-dev/dagu/internal/clock"
 	"github.com/dagu-dev/dagu/internal/constants"
 	"github.com/mattn/go-shellwords"
 )
@@ -20,6 +22,8 @@ import (
 var (
 	ErrUnexpectedEOF         = errors.New("unexpected end of input after escape character")
 	ErrUnknownEscapeSequence = errors.New("unknown escape sequence")
+	ErrInvalidJSON           = errors.New("invalid JSON")
+	ErrJSONPathNotFound      = errors.New("path not found in JSON")
 )
 
 // MustGetUserHomeDir returns current working directory.
@@ -175,6 +179,49 @@ func CreateFile(file string) (*os.File, error) {
 	return outfile, nil
 }
 
+// WriteFileAtomic writes the data to the file through a temporary file in
+// the same directory that is synced and renamed to the file, so that the
+// file has either its old or its new contents after a crash, never a part
+// of them.
+func WriteFileAtomic(file string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(file)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(file)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Chmod(perm)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), file)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return SyncDir(dir)
+}
+
+// SyncDir commits the entries of the directory, e.g. a renamed file, to the
+// storage.
+func SyncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = d.Close()
+	}()
+	return d.Sync()
+}
+
 // https://github.com/sindresorhus/filename-reserved-regex/blob/master/index.js
 var (
 	filenameReservedRegex             = regexp.MustCompile(`[<>:"/\\|?*\x00-\x1F]`)
@@ -275,11 +322,13 @@ func SetFixedTime(t time.Time) {
 	fixedTime = t
 }
 
+// Now returns the fixed time if it is set, or the time of the clock of the
+// process.
 func Now() time.Time {
 	lock.RLock()
 	defer lock.RUnlock()
 	if fixedTime.IsZero() {
-		return time.Now()
+		return clock.Default().Now()
 	}
 	return fixedTime
 }
@@ -314,7 +363,9 @@ type Parameter struct {
 }
 
 func ParseParams(input string, executeCommandSubstitution bool) ([]Parameter, error) {
-	paramRegex := regexp.MustCompile(`(?:([^\s=]+)=)?("(?:\\"|[^"])*"|` + "`(" + `?:\\"|[^"]*)` + "`" + `|[^"\s]+)`)
+	// A template such as {{ .LogicalDate | date "2006-01-02" }} is kept in
+	// one parameter even if it is not quoted.
+	paramRegex := regexp.MustCompile(`(?:([^\s=]+)=)?("(?:\\"|[^"])*"|` + "`(" + `?:\\"|[^"]*)` + "`" + `|(?:\{\{.*?\}\}|[^"\s])+)`)
 	matches := paramRegex.FindAllStringSubmatch(input, -1)
 
 	params := []Parameter{}
@@ -420,3 +471,52 @@ func UnescapeArg(input string) (string, error) {
 
 	return escaped.String(), nil
 }
+
+var jsonPathRegex = regexp.MustCompile(`^(?:\.?[^.\[\]]+|\[\d+\])`)
+
+// JSONPath returns the value at the path in the JSON document. The path is
+// a list of keys separated by dots and indexes in brackets, e.g.
+// items[0].id. Strings are returned unquoted, and objects and arrays as
+// JSON.
+func JSONPath(doc, path string) (string, error) {
+	dec := json.NewDecoder(strings.NewReader(doc))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidJSON, err)
+	}
+	notFound := fmt.Errorf("%w: %s", ErrJSONPathNotFound, path)
+	for rest := path; rest != ""; {
+		tok := jsonPathRegex.FindString(rest)
+		if tok == "" {
+			return "", notFound
+		}
+		rest = rest[len(tok):]
+		if strings.HasPrefix(tok, "[") {
+			a, ok := v.([]any)
+			i, _ := strconv.Atoi(tok[1 : len(tok)-1])
+			if !ok || i >= len(a) {
+				return "", notFound
+			}
+			v = a[i]
+			continue
+		}
+		m, ok := v.(map[string]any)
+		if !ok {
+			return "", notFound
+		}
+		if v, ok = m[strings.TrimPrefix(tok, ".")]; !ok {
+			return "", notFound
+		}
+	}
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number, bool:
+		return fmt.Sprint(v), nil
+	}
+	b, err := json.Marshal(v)
+	return string(b), err
+}
@@ -94,6 +94,27 @@ func TestOpenFile(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	file := path.Join(dir, "status.dat")
+	require.NoError(t, os.WriteFile(file, []byte("old"), 0600))
+
+	require.NoError(t, utils.WriteFileAtomic(file, []byte("new"), 0644))
+	b, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, "new", string(b))
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0644), info.Mode().Perm())
+
+	// No temporary file is left.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	require.Error(t, utils.WriteFileAtomic(path.Join(dir, "missing", "status.dat"), []byte("new"), 0644))
+}
+
 func TestOpenOrCreateFile(t *testing.T) {
 	tmp, err := os.MkdirTemp("", "open_or_create")
 	require.NoError(t, err)
@@ -214,4 +235,45 @@ func TestParseParams(t *testing.T) {
 	require.Equal(t, 1, len(ret))
 	require.Equal(t, ret[0].Name, "QUESTION")
 	require.Equal(t, ret[0].Value, "what is your favorite activity?")
+
+	ret, err = utils.ParseParams(`DATE={{ .LogicalDate | date "2006-01-02" }} x`, true)
+	require.NoError(t, err)
+	require.Equal(t, []utils.Parameter{
+		{Name: "DATE", Value: `{{ .LogicalDate | date "2006-01-02" }}`},
+		{Value: "x"},
+	}, ret)
+}
+
+func TestJSONPath(t *testing.T) {
+	doc := `{"items": [{"id": "a1", "n": 1.5}, {"id": "a2", "ok": true}], "meta": {"next": null}}`
+	for path, want := range map[string]string{
+		"items[0].id": "a1",
+		".items[0].n": "1.5",
+		"items[1].ok": "true",
+		"items[1]":    `{"id":"a2","ok":true}`,
+		"meta.next":   "",
+		"meta":        `{"next":null}`,
+	} {
+		got, err := utils.JSONPath(doc, path)
+		require.NoError(t, err, path)
+		require.Equal(t, want, got, path)
+	}
+	for _, path := range []string{"items[2]", "items.id", "meta.next.x", "missing"} {
+		_, err := utils.JSONPath(doc, path)
+		require.ErrorIs(t, err, utils.ErrJSONPathNotFound, path)
+	}
+	_, err := utils.JSONPath("not json", "a")
+	require.ErrorIs(t, err, utils.ErrInvalidJSON)
+}
+
+func TestProcessAlive(t *testing.T) {
+	start := utils.ProcessStart(os.Getpid())
+	require.NotZero(t, start)
+	require.True(t, utils.ProcessAlive(os.Getpid(), start))
+	require.True(t, utils.ProcessAlive(os.Getpid(), 0))
+	// The process that has the pid is not the one that started then.
+	require.False(t, utils.ProcessAlive(os.Getpid(), start+1))
+	require.False(t, utils.ProcessAlive(1<<30, 0))
+	// The process of another user exists.
+	require.True(t, utils.ProcessAlive(1, utils.ProcessStart(1)))
 }
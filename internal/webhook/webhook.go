@@ -0,0 +1,312 @@
+// Package webhook posts the events of the runs of the DAGs, e.g. a run that
+// failed, to the webhooks of the configuration, so that the external
+// systems react to them without polling the API.
+//
+// The body of a request is the event in JSON, or the template of the
+// webhook rendered with the event. A webhook with a secret signs it: the
+// X-Dagu-Signature header is sha256= followed by the hex HMAC-SHA256 of the
+// X-Dagu-Timestamp header, a dot and the body, with the secret as the key.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/secret"
+	"github.com/google/uuid"
+)
+
+// The kinds of the events.
+const (
+	EventRunStarted   = "run.started"
+	EventRunSucceeded = "run.succeeded"
+	EventRunFailed    = "run.failed"
+	EventRunCancelled = "run.cancelled"
+	EventStepFailed   = "step.failed"
+	// EventSLAMissed is sent when a run is still running after the SLA of
+	// its DAG.
+	EventSLAMissed = "sla.missed"
+)
+
+// Events are the kinds of the events the webhooks subscribe to.
+var Events = []string{
+	EventRunStarted, EventRunSucceeded, EventRunFailed, EventRunCancelled, EventStepFailed, EventSLAMissed,
+}
+
+// The headers of the requests.
+const (
+	HeaderEvent     = "X-Dagu-Event"
+	HeaderDelivery  = "X-Dagu-Delivery"
+	HeaderTimestamp = "X-Dagu-Timestamp"
+	HeaderSignature = "X-Dagu-Signature"
+)
+
+var (
+	errUnknownEvent     = errors.New("unknown webhook event")
+	errNoURL            = errors.New("a webhook requires a url")
+	errUnexpectedStatus = errors.New("unexpected status of the webhook")
+
+	// retryInterval is the wait before the first retry of a request. It
+	// doubles for each of the next ones.
+	retryInterval = time.Second
+)
+
+// Event is an event of a run.
+type Event struct {
+	Event     string    `json:"event"`
+	Time      time.Time `json:"time"`
+	DAG       string    `json:"dag"`
+	RequestId string    `json:"requestId"`
+	Namespace string    `json:"namespace,omitempty"`
+	// Status is the status of the run, e.g. failed.
+	Status string `json:"status"`
+	// Step is the step of a step event.
+	Step       string `json:"step,omitempty"`
+	Error      string `json:"error,omitempty"`
+	StartedAt  string `json:"startedAt,omitempty"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+}
+
+// Webhook is a URL the events are posted to.
+type Webhook struct {
+	Name string
+	URL  string
+	// Secret signs the requests if it is not empty. It and the values of
+	// the headers may be secret references, which are read again for each
+	// event so that the rotated credentials are used.
+	Secret string
+	// Events are the kinds of the events posted to the webhook, all of them
+	// if it is empty.
+	Events []string
+	// Template is the text/template of the body, rendered with the event.
+	// The json function encodes a value in JSON, e.g. {{json .Error}}. The
+	// body is the event in JSON if it is empty.
+	Template string
+	// Headers are added to the requests, e.g. the Content-Type of the
+	// template, application/json by default.
+	Headers map[string]string
+
+	tmpl *template.Template
+}
+
+var funcs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// Validate returns an error if the webhook is not valid, and parses its
+// template.
+func (w *Webhook) Validate() error {
+	if w.URL == "" {
+		return errNoURL
+	}
+	for _, e := range w.Events {
+		if !slices.Contains(Events, e) {
+			return fmt.Errorf("%w: %s", errUnknownEvent, e)
+		}
+	}
+	if w.Template != "" {
+		tmpl, err := template.New(w.Name).Funcs(funcs).Option("missingkey=error").Parse(w.Template)
+		if err != nil {
+			return fmt.Errorf("template of the webhook %s: %w", w.Name, err)
+		}
+		w.tmpl = tmpl
+	}
+	return nil
+}
+
+func (w *Webhook) subscribes(event string) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, event)
+}
+
+func (w *Webhook) body(e *Event) ([]byte, error) {
+	if w.tmpl == nil {
+		return json.Marshal(e)
+	}
+	var buf bytes.Buffer
+	if err := w.tmpl.Execute(&buf, e); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// withCredentials returns a copy of the webhook with the current values of
+// the secret references of its secret and its headers.
+func (w *Webhook) withCredentials() (*Webhook, error) {
+	ctx := context.Background()
+	ret := *w
+	var err error
+	if ret.Secret, err = secret.Current(ctx, w.Secret); err != nil {
+		return nil, err
+	}
+	if len(w.Headers) > 0 {
+		ret.Headers = make(map[string]string, len(w.Headers))
+		for k, v := range w.Headers {
+			if ret.Headers[k], err = secret.Current(ctx, v); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &ret, nil
+}
+
+// Sign returns the signature of the body sent at the timestamp with the
+// secret.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sender posts the events to the webhooks.
+type Sender struct {
+	Webhooks []*Webhook
+	// Timeout is the timeout of an attempt to post an event. Zero means no
+	// timeout.
+	Timeout time.Duration
+	// Retries is the number of times an event is posted again after it
+	// failed, with a network error or a status of 429 or 5xx.
+	Retries int
+	Client  *http.Client
+}
+
+// Send posts the event to the webhooks that subscribe to it. The errors of
+// the webhooks are joined.
+func (s *Sender) Send(e *Event) error {
+	var errs []error
+	for _, w := range s.Webhooks {
+		if !w.subscribes(e.Event) {
+			continue
+		}
+		if err := s.post(w, e); err != nil {
+			errs = append(errs, fmt.Errorf("webhook %s: %w", w.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Sender) post(w *Webhook, e *Event) error {
+	body, err := w.body(e)
+	if err != nil {
+		return err
+	}
+	return s.Deliver(w, e.Event, body)
+}
+
+// Deliver posts the body to the webhook as the event of the kind, with the
+// headers and the signature of the webhook, and retries it as the events
+// are retried. The template and the events of the webhook are not used.
+func (s *Sender) Deliver(w *Webhook, event string, body []byte) error {
+	w, err := w.withCredentials()
+	if err != nil {
+		return err
+	}
+	delivery := uuid.NewString()
+	interval := retryInterval
+	for i := 0; ; i++ {
+		retry, err := s.attempt(w, event, delivery, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || i >= s.Retries {
+			return err
+		}
+		log.Printf("failed to post the %s event to the webhook %s: %v, retrying in %s", event, w.Name, err, interval)
+		time.Sleep(interval)
+		interval *= 2
+	}
+}
+
+// attempt posts the body to the webhook. It returns whether the request
+// may succeed if it is sent again.
+func (s *Sender) attempt(w *Webhook, event, delivery string, body []byte) (bool, error) {
+	ctx := context.Background()
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.Headers {
+		req.Header.Set(k, v)
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(HeaderEvent, event)
+	req.Header.Set(HeaderDelivery, delivery)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	if w.Secret != "" {
+		req.Header.Set(HeaderSignature, Sign(w.Secret, timestamp, body))
+	}
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("%w: %s", errUnexpectedStatus, resp.Status)
+}
+
+// New returns the sender of the webhooks of the configuration, or nil if
+// there are none. The invalid webhooks are logged and skipped, so that they
+// do not fail the runs.
+func New(cfg *config.Config) *Sender {
+	var hooks []*Webhook
+	for i, c := range cfg.Webhooks {
+		w := &Webhook{
+			Name:     c.Name,
+			URL:      c.URL,
+			Secret:   c.Secret,
+			Events:   c.Events,
+			Template: c.Template,
+			Headers:  c.Headers,
+		}
+		if w.Name == "" {
+			w.Name = strconv.Itoa(i)
+		}
+		if err := w.Validate(); err != nil {
+			log.Printf("invalid webhook %s: %v", w.Name, err)
+			continue
+		}
+		hooks = append(hooks, w)
+	}
+	if len(hooks) == 0 {
+		return nil
+	}
+	return &Sender{
+		Webhooks: hooks,
+		Timeout:  time.Second * time.Duration(cfg.NotificationTimeoutSec),
+		Retries:  cfg.NotificationRetries,
+	}
+}
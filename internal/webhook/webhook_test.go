@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend(t *testing.T) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, Sign("secret", r.Header.Get(HeaderTimestamp), body), r.Header.Get(HeaderSignature))
+		require.Equal(t, "text/plain", r.Header.Get("Content-Type"))
+		require.NotEmpty(t, r.Header.Get(HeaderDelivery))
+		bodies = append(bodies, r.Header.Get(HeaderEvent)+" "+string(body))
+	}))
+	defer srv.Close()
+
+	w := &Webhook{
+		Name:     "chat",
+		URL:      srv.URL,
+		Secret:   "secret",
+		Events:   []string{EventRunFailed},
+		Template: `{{.DAG}} {{.Status}}: {{json .Error}}`,
+		Headers:  map[string]string{"Content-Type": "text/plain"},
+	}
+	require.NoError(t, w.Validate())
+	s := &Sender{Webhooks: []*Webhook{w}}
+
+	require.NoError(t, s.Send(&Event{Event: EventRunStarted, DAG: "etl", Status: "running"}))
+	require.NoError(t, s.Send(&Event{Event: EventRunFailed, DAG: "etl", Status: "failed", Error: `exit "1"`}))
+	require.Equal(t, []string{`run.failed etl failed: "exit \"1\""`}, bodies)
+}
+
+func TestSendRotatedSecret(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(file, []byte("first"), 0600))
+	var signatures []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		timestamp := r.Header.Get(HeaderTimestamp)
+		switch r.Header.Get(HeaderSignature) {
+		case Sign("first", timestamp, body):
+			signatures = append(signatures, "first")
+		case Sign("second", timestamp, body):
+			signatures = append(signatures, "second")
+		}
+	}))
+	defer srv.Close()
+
+	w := &Webhook{Name: "ops", URL: srv.URL, Secret: "secret://file" + file}
+	require.NoError(t, w.Validate())
+	s := &Sender{Webhooks: []*Webhook{w}}
+
+	require.NoError(t, s.Send(&Event{Event: EventRunFailed, DAG: "etl"}))
+	require.NoError(t, os.WriteFile(file, []byte("second"), 0600))
+	require.NoError(t, s.Send(&Event{Event: EventRunFailed, DAG: "etl"}))
+	require.Equal(t, []string{"first", "second"}, signatures)
+}
+
+func TestSendRetries(t *testing.T) {
+	retryInterval = time.Millisecond
+	var attempts atomic.Int32
+	status := http.StatusServiceUnavailable
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(status)
+		}
+	}))
+	defer srv.Close()
+
+	w := &Webhook{Name: "ops", URL: srv.URL}
+	require.NoError(t, w.Validate())
+	s := &Sender{Webhooks: []*Webhook{w}, Retries: 2}
+	require.NoError(t, s.Send(&Event{Event: EventStepFailed}))
+	require.Equal(t, int32(3), attempts.Load())
+
+	// The client errors are not retried.
+	attempts.Store(0)
+	status = http.StatusBadRequest
+	require.ErrorIs(t, s.Send(&Event{Event: EventStepFailed}), errUnexpectedStatus)
+	require.Equal(t, int32(1), attempts.Load())
+}
+
+func TestValidate(t *testing.T) {
+	require.ErrorIs(t, (&Webhook{}).Validate(), errNoURL)
+	require.ErrorIs(t, (&Webhook{URL: "http://x", Events: []string{"run.done"}}).Validate(), errUnknownEvent)
+	require.Error(t, (&Webhook{URL: "http://x", Template: "{{.DAG"}).Validate())
+}
@@ -0,0 +1,10 @@
+// Package schemas embeds the JSON Schemas of the files of dagu, so that they
+// can be published by the binary.
+package schemas
+
+import _ "embed"
+
+// DAG is the JSON Schema of the DAG files.
+//
+//go:embed dag.schema.json
+var DAG []byte
@@ -3,9 +3,16 @@ package frontend
 import (
 	"context"
 	"embed"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/health"
 	"github.com/dagu-dev/dagu/internal/logger"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/recovery"
 	"github.com/dagu-dev/dagu/service/frontend/handlers"
 	"github.com/dagu-dev/dagu/service/frontend/server"
 	"go.uber.org/fx"
@@ -19,15 +26,39 @@ var (
 var Module = fx.Options(
 	fx.Provide(
 		fx.Annotate(handlers.NewDAG, fx.ResultTags(`group:"handlers"`))),
+	fx.Provide(
+		fx.Annotate(handlers.NewGC, fx.ResultTags(`group:"handlers"`))),
+	fx.Provide(
+		fx.Annotate(handlers.NewMeta, fx.ResultTags(`group:"handlers"`))),
+	fx.Provide(
+		fx.Annotate(handlers.NewDrift, fx.ResultTags(`group:"handlers"`))),
+	fx.Provide(
+		fx.Annotate(handlers.NewOpenAPI, fx.ResultTags(`group:"handlers"`))),
+	fx.Provide(
+		fx.Annotate(handlers.NewArtifact, fx.ResultTags(`group:"handlers"`))),
+	fx.Provide(
+		fx.Annotate(handlers.NewLogStream, fx.ResultTags(`group:"handlers"`))),
+	fx.Provide(
+		fx.Annotate(handlers.NewToken, fx.ResultTags(`group:"handlers"`))),
+	fx.Provide(
+		fx.Annotate(handlers.NewValidate, fx.ResultTags(`group:"handlers"`))),
+	fx.Provide(
+		fx.Annotate(handlers.NewAudit, fx.ResultTags(`group:"handlers"`))),
+	fx.Provide(
+		fx.Annotate(handlers.NewTrigger, fx.ResultTags(`group:"handlers"`))),
+	fx.Provide(handlers.NewNamespaces),
 	fx.Provide(New),
 )
 
 type Params struct {
 	fx.In
 
-	Config   *config.Config
-	Logger   logger.Logger
-	Handlers []server.New `group:"handlers"`
+	Config    *config.Config
+	Logger    logger.Logger
+	DataStore persistence.DataStoreFactory
+	Handlers  []server.New `group:"handlers"`
+
+	Namespaces handlers.Namespaces
 }
 
 func LifetimeHooks(lc fx.Lifecycle, srv *server.Server) {
@@ -52,6 +83,14 @@ func New(params Params) *server.Server {
 		Logger:   params.Logger,
 		Handlers: params.Handlers,
 		AssetsFS: assetsFS,
+
+		TokenStore: params.DataStore.NewTokenStore(),
+		OIDC:       params.Config.OIDC,
+		LDAP:       params.Config.LDAP,
+	}
+
+	for _, ns := range params.Config.Namespaces {
+		serverParams.Namespaces = append(serverParams.Namespaces, ns.Name)
 	}
 
 	if params.Config.IsAuthToken {
@@ -60,6 +99,27 @@ func New(params Params) *server.Server {
 		}
 	}
 
+	for _, t := range params.Config.APITokens {
+		serverParams.APITokens = append(serverParams.APITokens, server.APIToken{
+			Name:   t.Name,
+			Token:  t.Token,
+			Scopes: t.Scopes,
+		})
+	}
+
+	for _, r := range params.Config.AccessRules {
+		serverParams.AccessRules = append(serverParams.AccessRules, server.AccessRule{
+			User:     r.User,
+			Token:    r.Token,
+			Role:     r.Role,
+			Prefixes: r.Prefixes,
+			Tags:     r.Tags,
+			Group:    r.Group,
+
+			Namespaces: r.Namespaces,
+		})
+	}
+
 	if params.Config.IsBasicAuth {
 		serverParams.BasicAuth = &server.BasicAuth{
 			Username: params.Config.BasicAuthUsername,
@@ -67,5 +127,61 @@ func New(params Params) *server.Server {
 		}
 	}
 
+	serverParams.Health = newHealthChecker(params)
+
+	for _, ns := range params.Namespaces {
+		ns.Search.Start(context.Background())
+	}
+
+	logBanner(params.Logger, params.Config)
+	if params.Config.RecoverLostRuns {
+		// The lost runs of each namespace are in its own history.
+		for _, ns := range params.Namespaces {
+			recovery.New(&recovery.Config{
+				DataStore:      ns.DataStore,
+				FailureHandler: ns.Config.LostRunFailureHandler,
+				LockFile:       recovery.LockFile(ns.Config.DataDir),
+				Logger:         params.Logger,
+			}).Start(nil)
+		}
+	}
 	return server.NewServer(serverParams)
 }
+
+// logBanner logs the version and the capabilities of the server that the
+// meta endpoint returns, so that they are in the log of each start.
+func logBanner(l logger.Logger, cfg *config.Config) {
+	m := handlers.Meta(cfg)
+	l.Info("starting dagu",
+		"version", *m.Version,
+		"api", *m.APIVersion,
+		"address", net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		"auth", strings.Join(m.AuthModes, ","),
+		"history", *m.Storage.History,
+		"artifacts", *m.Storage.Artifacts,
+		"logs", *m.Storage.Logs,
+		"features", strings.Join(m.Features, ","),
+		"executors", strings.Join(m.Executors, ","),
+	)
+}
+
+// newHealthChecker returns the checks of the probes: the scheduler of the
+// data directory of the server, and the DAGs and the history of each
+// namespace.
+func newHealthChecker(params Params) *health.Checker {
+	c := &health.Checker{HeartbeatFile: health.HeartbeatFile(params.Config.DataDir)}
+	names := make([]string, 0, len(params.Namespaces))
+	for name := range params.Namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ns := params.Namespaces[name]
+		t := health.Target{DAGsDir: ns.Config.DAGs, History: ns.DataStore.NewHistoryStore()}
+		if ns.Config != params.Config {
+			t.Namespace = name
+		}
+		c.Targets = append(c.Targets, t)
+	}
+	return c
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/service/frontend/handlers/response"
+	pkgmiddleware "github.com/dagu-dev/dagu/service/frontend/middleware"
+)
+
+var errPermissionDenied = dagerrors.New(dagerrors.CodePermissionDenied, "the access rules do not allow the request")
+
+// accessOf returns the access of the request, nil if it is not restricted.
+func accessOf(r *http.Request) *pkgmiddleware.Access {
+	if r == nil {
+		return nil
+	}
+	return pkgmiddleware.AccessFrom(r.Context())
+}
+
+// authorizeDAG returns an error if the access rules of the request do not
+// allow the role on the DAG of the status.
+func authorizeDAG(r *http.Request, s *persistence.DAGStatus, role string) *response.CodedError {
+	if allows(accessOf(r), s, role) {
+		return nil
+	}
+	return response.NewError(fmt.Errorf("%w: %s of %s", errPermissionDenied, role, dagID(s)))
+}
+
+// allows returns true if the access allows the role on the DAG of the
+// status.
+func allows(access *pkgmiddleware.Access, s *persistence.DAGStatus, role string) bool {
+	return access.AllowsName(dagID(s), s.DAG.Tags, role)
+}
+
+// dagID returns the ID of the DAG of the status the access rules match: the
+// path of its file in the DAGs directory without the extension, e.g.
+// team-a/etl.
+func dagID(s *persistence.DAGStatus) string {
+	d := s.DAG
+	if d.Location == "" {
+		return d.Name
+	}
+	return path.Join(s.Folder, strings.TrimSuffix(filepath.Base(d.Location), filepath.Ext(d.Location)))
+}
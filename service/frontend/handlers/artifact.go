@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/engine"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/preview"
+	"github.com/dagu-dev/dagu/service/frontend/handlers/response"
+	pkgmiddleware "github.com/dagu-dev/dagu/service/frontend/middleware"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+	"github.com/dagu-dev/dagu/service/frontend/server"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+const (
+	defaultArtifactURLExpiry = 15 * time.Minute
+	// maxArtifactURLExpiry is the longest validity of a URL signed with
+	// Signature Version 4.
+	maxArtifactURLExpiry = 7 * 24 * time.Hour
+	// previewCSP is the content security policy of the previews of the
+	// artifacts. The sandbox gives them an origin of their own, and they
+	// only load their styles and the images embedded in them.
+	previewCSP = "sandbox; default-src 'none'; img-src data:; style-src 'unsafe-inline'"
+)
+
+var errInvalidExpiry = dagerrors.New(dagerrors.CodeInvalidArgument, "expiresIn must be between 1 and 604800 seconds")
+
+type ArtifactHandler struct {
+	namespaces map[string]*ArtifactHandler
+
+	engineFactory engine.Factory
+	artifactStore persistence.ArtifactStore
+}
+
+func NewArtifact(namespaces Namespaces) server.New {
+	return &ArtifactHandler{
+		namespaces: byNamespace(namespaces, func(ns *Namespace) *ArtifactHandler {
+			return &ArtifactHandler{
+				engineFactory: ns.EngineFactory,
+				artifactStore: ns.DataStore.NewArtifactStore(),
+			}
+		}),
+	}
+}
+
+func (h *ArtifactHandler) Configure(api *operations.DaguAPI) {
+	api.ListArtifactsHandler = operations.ListArtifactsHandlerFunc(
+		func(params operations.ListArtifactsParams) middleware.Responder {
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).List(params)
+			if err != nil {
+				return operations.NewListArtifactsDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewListArtifactsOK().WithPayload(resp)
+		})
+
+	api.DownloadArtifactHandler = operations.DownloadArtifactHandlerFunc(
+		func(params operations.DownloadArtifactParams) middleware.Responder {
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).Download(params)
+			if err != nil {
+				return operations.NewDownloadArtifactDefault(err.Code).WithPayload(err.APIError)
+			}
+			return resp
+		})
+
+	api.PreviewArtifactHandler = operations.PreviewArtifactHandlerFunc(
+		func(params operations.PreviewArtifactParams) middleware.Responder {
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).Preview(params)
+			if err != nil {
+				return operations.NewPreviewArtifactDefault(err.Code).WithPayload(err.APIError)
+			}
+			return resp
+		})
+
+	api.DeleteArtifactHandler = operations.DeleteArtifactHandlerFunc(
+		func(params operations.DeleteArtifactParams) middleware.Responder {
+			if err := ofRequest(h.namespaces, params.HTTPRequest).Delete(params); err != nil {
+				return operations.NewDeleteArtifactDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewDeleteArtifactOK()
+		})
+
+	api.GetArtifactURLHandler = operations.GetArtifactURLHandlerFunc(
+		func(params operations.GetArtifactURLParams) middleware.Responder {
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).GetURL(params)
+			if err != nil {
+				return operations.NewGetArtifactURLDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewGetArtifactURLOK().WithPayload(resp)
+		})
+}
+
+func (h *ArtifactHandler) List(params operations.ListArtifactsParams) (*models.ListArtifactsResponse, *response.CodedError) {
+	d, cerr := h.run(params.HTTPRequest, params.DagID, params.RequestID, pkgmiddleware.RoleViewer)
+	if cerr != nil {
+		return nil, cerr
+	}
+	artifacts, err := h.artifactStore.List(params.HTTPRequest.Context(), d.Name, params.RequestID)
+	if err != nil {
+		return nil, response.NewError(err)
+	}
+	return response.ToListArtifactsResponse(artifacts), nil
+}
+
+// Download streams the artifact with the name of its file as the name of the
+// download.
+func (h *ArtifactHandler) Download(params operations.DownloadArtifactParams) (middleware.Responder, *response.CodedError) {
+	d, cerr := h.run(params.HTTPRequest, params.DagID, params.RequestID, pkgmiddleware.RoleViewer)
+	if cerr != nil {
+		return nil, cerr
+	}
+	r, err := h.artifactStore.Open(d.Name, params.RequestID, params.Path)
+	if err != nil {
+		return nil, response.NewError(err)
+	}
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(params.Path)})
+	return middleware.ResponderFunc(func(w http.ResponseWriter, p runtime.Producer) {
+		w.Header().Set("Content-Disposition", disposition)
+		operations.NewDownloadArtifactOK().WithPayload(r).WriteResponse(w, p)
+	}), nil
+}
+
+// Preview returns the preview of the artifact to show it in the browser.
+// The preview is isolated from the pages of the server by the content
+// security policy, which also blocks the scripts and the requests to other
+// sites that the sanitization missed.
+func (h *ArtifactHandler) Preview(params operations.PreviewArtifactParams) (middleware.Responder, *response.CodedError) {
+	d, cerr := h.run(params.HTTPRequest, params.DagID, params.RequestID, pkgmiddleware.RoleViewer)
+	if cerr != nil {
+		return nil, cerr
+	}
+	if preview.TypeOf(params.Path) == preview.TypeFile {
+		return nil, response.NewError(fmt.Errorf("%w: %s", preview.ErrNoPreview, params.Path))
+	}
+	r, err := h.artifactStore.Open(d.Name, params.RequestID, params.Path)
+	if err != nil {
+		return nil, response.NewError(err)
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	var buf bytes.Buffer
+	contentType, err := preview.Render(&buf, params.Path, r)
+	if err != nil {
+		return nil, response.NewError(err)
+	}
+	disposition := mime.FormatMediaType("inline", map[string]string{"filename": path.Base(params.Path)})
+	return middleware.ResponderFunc(func(w http.ResponseWriter, _ runtime.Producer) {
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", disposition)
+		w.Header().Set("Content-Security-Policy", previewCSP)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(http.StatusOK)
+		_, _ = buf.WriteTo(w)
+	}), nil
+}
+
+func (h *ArtifactHandler) Delete(params operations.DeleteArtifactParams) *response.CodedError {
+	d, cerr := h.run(params.HTTPRequest, params.DagID, params.RequestID, pkgmiddleware.RoleOperator)
+	if cerr != nil {
+		return cerr
+	}
+	if err := h.artifactStore.Delete(params.HTTPRequest.Context(), d.Name, params.RequestID, params.Path); err != nil {
+		return response.NewError(err)
+	}
+	return nil
+}
+
+func (h *ArtifactHandler) GetURL(params operations.GetArtifactURLParams) (*models.ArtifactURLResponse, *response.CodedError) {
+	expires := defaultArtifactURLExpiry
+	if params.ExpiresIn != nil {
+		expires = time.Duration(*params.ExpiresIn) * time.Second
+		if expires <= 0 || expires > maxArtifactURLExpiry {
+			return nil, response.NewBadRequestError(errInvalidExpiry)
+		}
+	}
+	d, cerr := h.run(params.HTTPRequest, params.DagID, params.RequestID, pkgmiddleware.RoleViewer)
+	if cerr != nil {
+		return nil, cerr
+	}
+	now := time.Now()
+	url, err := h.artifactStore.URL(params.HTTPRequest.Context(), d.Name, params.RequestID, params.Path, expires)
+	if err != nil {
+		return nil, response.NewError(err)
+	}
+	return response.ToArtifactURLResponse(url, now.Add(expires)), nil
+}
+
+// run returns the DAG of the ID if it has the run of the request ID, and
+// the access rules of the request allow the role on it.
+func (h *ArtifactHandler) run(r *http.Request, dagID, requestID, role string) (*dag.DAG, *response.CodedError) {
+	e := h.engineFactory.Create()
+	dagStatus, err := e.GetStatus(dagID)
+	if dagStatus == nil {
+		return nil, response.NewNotFoundError(err)
+	}
+	if cerr := authorizeDAG(r, dagStatus, role); cerr != nil {
+		return nil, cerr
+	}
+	if _, err := e.GetStatusByRequestId(dagStatus.DAG, requestID); err != nil {
+		return nil, response.NewNotFoundError(fmt.Errorf("run %s of %s: %w", requestID, dagID, err))
+	}
+	return dagStatus.DAG, nil
+}
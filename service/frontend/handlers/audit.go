@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/service/frontend/handlers/response"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+	"github.com/dagu-dev/dagu/service/frontend/server"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/samber/lo"
+)
+
+// defaultAuditLimit is the number of the entries of the audit log returned
+// without a limit.
+const defaultAuditLimit = 100
+
+type AuditHandler struct {
+	namespaces map[string]*AuditHandler
+
+	auditStore persistence.AuditStore
+}
+
+func NewAudit(namespaces Namespaces) server.New {
+	return &AuditHandler{
+		namespaces: byNamespace(namespaces, func(ns *Namespace) *AuditHandler {
+			return &AuditHandler{
+				auditStore: ns.DataStore.NewAuditStore(),
+			}
+		}),
+	}
+}
+
+func (h *AuditHandler) Configure(api *operations.DaguAPI) {
+	api.ListAuditEntriesHandler = operations.ListAuditEntriesHandlerFunc(
+		func(params operations.ListAuditEntriesParams) middleware.Responder {
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).List(params)
+			if err != nil {
+				return operations.NewListAuditEntriesDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewListAuditEntriesOK().WithPayload(resp)
+		})
+}
+
+func (h *AuditHandler) List(params operations.ListAuditEntriesParams) (*models.ListAuditEntriesResponse, *response.CodedError) {
+	q := &persistence.AuditQuery{
+		DAG:    lo.FromPtr(params.Dag),
+		Action: lo.FromPtr(params.Action),
+		Actor:  lo.FromPtr(params.Actor),
+		Limit:  int(lo.FromPtr(params.Limit)),
+	}
+	if q.Limit < 0 {
+		return nil, response.NewBadRequestError(fmt.Errorf("limit must not be negative: %w", errInvalidArgs))
+	}
+	if q.Limit == 0 {
+		q.Limit = defaultAuditLimit
+	}
+	var err error
+	if q.Since, err = parseQueryTime(params.Since); err != nil {
+		return nil, response.NewBadRequestError(fmt.Errorf("%w: since: %s", errInvalidArgs, err))
+	}
+	if q.Until, err = parseQueryTime(params.Until); err != nil {
+		return nil, response.NewBadRequestError(fmt.Errorf("%w: until: %s", errInvalidArgs, err))
+	}
+	entries, err := h.auditStore.List(q)
+	if err != nil {
+		return nil, response.NewInternalError(err)
+	}
+	return response.ToListAuditEntriesResponse(entries), nil
+}
+
+// parseQueryTime parses the time of a query parameter in RFC 3339. It
+// returns the zero time if the parameter is not set.
+func parseQueryTime(s *string) (time.Time, error) {
+	if lo.FromPtr(s) == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, *s)
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/service/frontend/handlers/response"
+	pkgmiddleware "github.com/dagu-dev/dagu/service/frontend/middleware"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+	"github.com/go-openapi/swag"
+	"github.com/samber/lo"
+)
+
+var errNoRuns = dagerrors.New(dagerrors.CodeNotFound, "the DAG has no runs")
+
+// bulkItem is a DAG of a bulk action, and the run of the DAG to retry.
+type bulkItem struct {
+	name      string
+	requestID string
+}
+
+// PostBulkAction performs the action on the DAGs of the names and of the
+// tag, and on the runs, one after the other like PostAction does on each of
+// them. A DAG of both the names and the tag is acted on once.
+func (h *DAGHandler) PostBulkAction(params operations.PostBulkActionParams) (*models.PostBulkActionResponse, *response.CodedError) {
+	action := *params.Body.Action
+	if len(params.Body.Runs) > 0 && action != "retry" {
+		return nil, response.NewBadRequestError(fmt.Errorf("runs are only retried: %w", errInvalidArgs))
+	}
+
+	var items []bulkItem
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			items = append(items, bulkItem{name: name})
+		}
+	}
+	for _, name := range params.Body.Names {
+		add(name)
+	}
+	if tag := params.Body.Tag; tag != "" {
+		dags, _, err := h.engineFactory.Create().GetAllStatus()
+		if err != nil {
+			return nil, response.NewInternalError(err)
+		}
+		if access := accessOf(params.HTTPRequest); access != nil {
+			dags = lo.Filter(dags, func(d *persistence.DAGStatus, _ int) bool {
+				return allows(access, d, pkgmiddleware.RoleViewer)
+			})
+		}
+		dags, _, err = (&persistence.DAGQuery{Tag: tag}).Apply(dags)
+		if err != nil {
+			return nil, response.NewBadRequestError(err)
+		}
+		for _, d := range dags {
+			add(dagIDOf(d))
+		}
+	}
+	for _, run := range params.Body.Runs {
+		items = append(items, bulkItem{name: *run.Name, requestID: *run.RequestID})
+	}
+	if len(items) == 0 {
+		return nil, response.NewBadRequestError(fmt.Errorf("names, tag or runs is required: %w", errInvalidArgs))
+	}
+
+	ret := &models.PostBulkActionResponse{Results: []*models.BulkActionResult{}}
+	for _, item := range items {
+		ret.Results = append(ret.Results, h.bulkAction(params, item))
+	}
+	return ret, nil
+}
+
+// bulkAction performs the action of the bulk request on the DAG of the item
+// and returns its result.
+func (h *DAGHandler) bulkAction(params operations.PostBulkActionParams, item bulkItem) *models.BulkActionResult {
+	body := operations.PostDagActionBody{
+		Action:    params.Body.Action,
+		Params:    params.Body.Params,
+		RequestID: item.requestID,
+	}
+	result := &models.BulkActionResult{Name: swag.String(item.name)}
+	switch *params.Body.Action {
+	case "suspend":
+		body.Value = "true"
+	case "resume":
+		body.Action = swag.String("suspend")
+		body.Value = "false"
+	case "retry":
+		// The DAGs that are not found are reported by PostAction.
+		if d, err := h.engineFactory.Create().GetStatus(item.name); err == nil && body.RequestID == "" {
+			if d.Status.RequestId == "" {
+				result.Ok = swag.Bool(false)
+				result.Error = response.NewError(fmt.Errorf("%w: %s", errNoRuns, item.name)).APIError
+				return result
+			}
+			body.RequestID = d.Status.RequestId
+		}
+	}
+	result.RequestID = body.RequestID
+
+	_, cerr := h.PostAction(operations.PostDagActionParams{
+		HTTPRequest: params.HTTPRequest,
+		DagID:       item.name,
+		Body:        body,
+	})
+	result.Ok = swag.Bool(cerr == nil)
+	if cerr != nil {
+		result.Error = cerr.APIError
+	}
+	return result
+}
+
+// dagIDOf returns the ID of the DAG of the status in the API, which is the
+// path of its file in the DAGs directory without the extension, e.g.
+// team-a/etl.
+func dagIDOf(d *persistence.DAGStatus) string {
+	return path.Join(d.Folder, strings.TrimSuffix(d.File, filepath.Ext(d.File)))
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/engine"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkActionTag(t *testing.T) {
+	tmpDir := utils.MustTempDir("dagu_test")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	dagsDir := path.Join(tmpDir, "dags")
+	require.NoError(t, os.MkdirAll(path.Join(dagsDir, "team-a"), 0755))
+	for file, spec := range map[string]string{
+		"report.yaml":     "tags: finance\nsteps:\n  - name: render\n    command: echo 1\n",
+		"team-a/etl.yaml": "tags: finance\nsteps:\n  - name: extract\n    command: echo 1\n",
+		"team-a/ops.yml":  "steps:\n  - name: check\n    command: echo 1\n",
+	} {
+		require.NoError(t, os.WriteFile(path.Join(dagsDir, file), []byte(spec), 0600))
+	}
+	ds := client.NewDataStoreFactory(&config.Config{
+		DataDir: path.Join(tmpDir, "data"),
+		DAGs:    dagsDir,
+	})
+	e := engine.NewFactory(ds, &config.Config{})
+	h := &DAGHandler{engineFactory: e, auditStore: ds.NewAuditStore()}
+
+	// The DAGs of the tag are acted on by their IDs, the ones in the
+	// folders included.
+	r, err := http.NewRequest("POST", "/api/v1/bulk", nil)
+	require.NoError(t, err)
+	resp, cerr := h.PostBulkAction(operations.PostBulkActionParams{
+		HTTPRequest: r,
+		Body:        operations.PostBulkActionBody{Action: lo.ToPtr("suspend"), Tag: "finance"},
+	})
+	require.Nil(t, cerr)
+	require.ElementsMatch(t, []string{"report", "team-a/etl"}, lo.Map(resp.Results, func(r *models.BulkActionResult, _ int) string {
+		require.True(t, *r.Ok, r.Error)
+		return *r.Name
+	}))
+	require.True(t, e.Create().IsSuspended("etl"))
+	require.False(t, e.Create().IsSuspended("ops"))
+}
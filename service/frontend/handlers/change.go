@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	domain "github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/service/frontend/handlers/response"
+	pkgmiddleware "github.com/dagu-dev/dagu/service/frontend/middleware"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+	"github.com/samber/lo"
+)
+
+var (
+	errNoChangeControl  = dagerrors.New(dagerrors.CodeNotFound, "the namespace is not under change control")
+	errAnonymousChange  = dagerrors.New(dagerrors.CodePermissionDenied, "change control requires the users to be authenticated")
+	errSelfApproval     = dagerrors.New(dagerrors.CodePermissionDenied, "a change is approved by a user other than its author")
+	errChangeReviewed   = dagerrors.New(dagerrors.CodeConflict, "the change was already reviewed")
+	errChangeOutdated   = dagerrors.New(dagerrors.CodeConflict, "the DAG was changed since the change was made")
+	errInvalidChangeAct = dagerrors.New(dagerrors.CodeInvalidArgument, "the action must be approve or reject")
+)
+
+// reviewMu serializes the reviews, so that two changes of the same
+// definition are not both approved.
+var reviewMu sync.Mutex
+
+// propose records the definition of a save as a pending change of the DAG,
+// which is saved once another user approves it. The DAG keeps its
+// definition until then.
+func (h *DAGHandler) propose(params operations.PostDagActionParams, d *persistence.DAGStatus) (*models.PostDagActionResponse, *response.CodedError) {
+	author := reviewerOf(params.HTTPRequest)
+	if author == "" {
+		return nil, response.NewError(errAnonymousChange)
+	}
+	e := h.engineFactory.Create()
+	base, err := e.GetDAGSpec(params.DagID)
+	if err != nil {
+		return nil, response.NewNotFoundError(err)
+	}
+	// The change is validated like the save, so that it can be saved once
+	// it is approved.
+	loc := filepath.Join(h.dagsDir, params.DagID+".yaml")
+	if d != nil && d.DAG != nil && d.DAG.Location != "" {
+		loc = d.DAG.Location
+	}
+	if _, err := (&dag.Loader{}).LoadDataAt([]byte(params.Body.Value), loc); err != nil {
+		return nil, response.NewBadRequestError(err)
+	}
+	change, err := domain.NewChange(params.DagID, author, base, params.Body.Value)
+	if err != nil {
+		return nil, response.NewInternalError(err)
+	}
+	if err := h.changeStore.Create(change); err != nil {
+		return nil, response.NewInternalError(err)
+	}
+	h.audit(params.HTTPRequest, &domain.AuditEntry{Action: "propose", DAG: params.DagID, Detail: change.ID})
+	return &models.PostDagActionResponse{ChangeID: change.ID}, nil
+}
+
+// ListChanges returns the changes of the DAGs the access rules of the
+// request allow the viewer role on.
+func (h *DAGHandler) ListChanges(params operations.ListChangesParams) (*models.ListChangesResponse, *response.CodedError) {
+	if !h.changeControl {
+		return nil, response.NewError(errNoChangeControl)
+	}
+	changes, err := h.changeStore.List()
+	if err != nil {
+		return nil, response.NewInternalError(err)
+	}
+	access := accessOf(params.HTTPRequest)
+	e := h.engineFactory.Create()
+	changes = lo.Filter(changes, func(c *domain.Change, _ int) bool {
+		if params.Dag != nil && c.DAG != *params.Dag || params.Status != nil && c.Status != *params.Status {
+			return false
+		}
+		return access == nil || access.AllowsName(c.DAG, tagsOf(e.GetStatus(c.DAG)), pkgmiddleware.RoleViewer)
+	})
+	return response.ToListChangesResponse(changes), nil
+}
+
+// ReviewChange approves or rejects the pending change. The approval saves
+// the definition of the change, which must be a change of the current
+// definition of the DAG.
+func (h *DAGHandler) ReviewChange(params operations.ReviewChangeParams) (*models.Change, *response.CodedError) {
+	if !h.changeControl {
+		return nil, response.NewError(errNoChangeControl)
+	}
+	reviewMu.Lock()
+	defer reviewMu.Unlock()
+	change, err := h.changeStore.Get(params.ChangeID)
+	if errors.Is(err, persistence.ErrChangeNotFound) {
+		return nil, response.NewNotFoundError(err)
+	}
+	if err != nil {
+		return nil, response.NewInternalError(err)
+	}
+	e := h.engineFactory.Create()
+	tags := tagsOf(e.GetStatus(change.DAG))
+	if !accessOf(params.HTTPRequest).AllowsName(change.DAG, tags, pkgmiddleware.RoleAdmin) {
+		return nil, response.NewError(fmt.Errorf("%w: %s of %s", errPermissionDenied, pkgmiddleware.RoleAdmin, change.DAG))
+	}
+	reviewer := reviewerOf(params.HTTPRequest)
+	if reviewer == "" {
+		return nil, response.NewError(errAnonymousChange)
+	}
+	if change.Status != domain.ChangePending {
+		return nil, response.NewError(fmt.Errorf("%w: %s", errChangeReviewed, change.Status))
+	}
+
+	switch lo.FromPtr(params.Body.Action) {
+	case models.ReviewChangeRequestActionApprove:
+		if reviewer == change.Author {
+			return nil, response.NewError(errSelfApproval)
+		}
+		current, err := e.GetDAGSpec(change.DAG)
+		if err != nil {
+			return nil, response.NewNotFoundError(err)
+		}
+		if current != change.Base {
+			return nil, response.NewError(errChangeOutdated)
+		}
+		if err := e.UpdateDAG(change.DAG, change.Spec); err != nil {
+			return nil, response.NewError(err)
+		}
+		change.Status = domain.ChangeApproved
+	case models.ReviewChangeRequestActionReject:
+		change.Status = domain.ChangeRejected
+		change.Reason = params.Body.Reason
+	default:
+		return nil, response.NewBadRequestError(errInvalidChangeAct)
+	}
+	change.Reviewer, change.ReviewedAt = reviewer, time.Now()
+	if err := h.changeStore.Update(change); err != nil {
+		return nil, response.NewInternalError(err)
+	}
+	h.audit(params.HTTPRequest, &domain.AuditEntry{
+		Action: lo.FromPtr(params.Body.Action),
+		DAG:    change.DAG,
+		Reason: change.Reason,
+		Detail: change.ID,
+	})
+	return response.ToChange(change), nil
+}
+
+// reviewerOf returns who makes or reviews a change with the request: the
+// user, or the API token without one. It is empty if the server does not
+// authenticate the requests.
+func reviewerOf(r *http.Request) string {
+	if r == nil {
+		return ""
+	}
+	identity := pkgmiddleware.IdentityFrom(r.Context())
+	if identity.User != "" {
+		return identity.User
+	}
+	return identity.Token
+}
+
+// tagsOf returns the tags of the DAG of the status, if it can be loaded.
+func tagsOf(d *persistence.DAGStatus, _ error) []string {
+	if d == nil || d.DAG == nil {
+		return nil
+	}
+	return d.DAG.Tags
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/engine"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	domain "github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/utils"
+	pkgmiddleware "github.com/dagu-dev/dagu/service/frontend/middleware"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeControl(t *testing.T) {
+	tmpDir := utils.MustTempDir("dagu_test")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	dagsDir := path.Join(tmpDir, "dags")
+	require.NoError(t, os.MkdirAll(dagsDir, 0755))
+	const current = "schedule: \"0 1 * * *\"\nsteps:\n  - name: extract\n    command: echo 1\n"
+	const edited = "schedule: \"0 2 * * *\"\nsteps:\n  - name: extract\n    command: echo 1\n"
+	spec := path.Join(dagsDir, "etl.yaml")
+	require.NoError(t, os.WriteFile(spec, []byte(current), 0600))
+	ds := client.NewDataStoreFactory(&config.Config{
+		DataDir: path.Join(tmpDir, "data"),
+		DAGs:    dagsDir,
+	})
+	h := &DAGHandler{
+		engineFactory: engine.NewFactory(ds, &config.Config{}),
+		auditStore:    ds.NewAuditStore(),
+		changeStore:   ds.NewChangeStore(),
+		changeControl: true,
+		dagsDir:       dagsDir,
+	}
+
+	request := func(user string) *http.Request {
+		r, err := http.NewRequest("POST", "/api/v1/dags/etl", nil)
+		require.NoError(t, err)
+		if user == "" {
+			return r
+		}
+		return r.WithContext(pkgmiddleware.WithIdentity(r.Context(), pkgmiddleware.Identity{User: user}))
+	}
+	save := func(r *http.Request, value string) (*models.PostDagActionResponse, int) {
+		resp, cerr := h.PostAction(operations.PostDagActionParams{
+			HTTPRequest: r,
+			DagID:       "etl",
+			Body:        operations.PostDagActionBody{Action: lo.ToPtr("save"), Value: value},
+		})
+		if cerr != nil {
+			return nil, cerr.Code
+		}
+		return resp, http.StatusOK
+	}
+	review := func(r *http.Request, id, action string) (*models.Change, int) {
+		resp, cerr := h.ReviewChange(operations.ReviewChangeParams{
+			HTTPRequest: r,
+			ChangeID:    id,
+			Body:        &models.ReviewChangeRequest{Action: lo.ToPtr(action), Reason: "not now"},
+		})
+		if cerr != nil {
+			return nil, cerr.Code
+		}
+		return resp, http.StatusOK
+	}
+	definition := func() string {
+		b, err := os.ReadFile(spec)
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	// The save is a pending change, with the diff, and the DAG keeps its
+	// definition.
+	resp, code := save(request("alice"), edited)
+	require.Equal(t, http.StatusOK, code)
+	require.NotEmpty(t, resp.ChangeID)
+	require.Equal(t, current, definition())
+	changes, cerr := h.ListChanges(operations.ListChangesParams{HTTPRequest: request("bob"), Status: lo.ToPtr(domain.ChangePending)})
+	require.Nil(t, cerr)
+	require.Len(t, changes.Changes, 1)
+	require.Equal(t, "alice", *changes.Changes[0].Author)
+	require.Contains(t, *changes.Changes[0].Diff, "-schedule: \"0 1 * * *\"\n+schedule: \"0 2 * * *\"\n")
+
+	// The changes need an author, and a valid definition.
+	_, code = save(request(""), edited)
+	require.Equal(t, http.StatusForbidden, code)
+	_, code = save(request("alice"), "steps: [\n")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	// The author does not approve the change, and the access rules of the
+	// reviewer must allow the admin role on the DAG.
+	_, code = review(request("alice"), resp.ChangeID, "approve")
+	require.Equal(t, http.StatusForbidden, code)
+	viewer := request("carol")
+	viewer = viewer.WithContext(pkgmiddleware.WithAccess(viewer.Context(), []pkgmiddleware.AccessRule{
+		{User: "carol", Role: pkgmiddleware.RoleViewer},
+	}))
+	_, code = review(viewer, resp.ChangeID, "approve")
+	require.Equal(t, http.StatusForbidden, code)
+	require.Equal(t, current, definition())
+
+	// Another user approves the change, which saves the definition once.
+	change, code := review(request("bob"), resp.ChangeID, "approve")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, domain.ChangeApproved, *change.Status)
+	require.Equal(t, "bob", change.Reviewer)
+	require.Equal(t, edited, definition())
+	_, code = review(request("bob"), resp.ChangeID, "reject")
+	require.Equal(t, http.StatusConflict, code)
+
+	// A change of the definition that was replaced since is not approved,
+	// and it is rejected with the reason.
+	stale, code := save(request("alice"), current)
+	require.Equal(t, http.StatusOK, code)
+	require.NoError(t, os.WriteFile(spec, []byte(current), 0600))
+	_, code = review(request("bob"), stale.ChangeID, "approve")
+	require.Equal(t, http.StatusConflict, code)
+	change, code = review(request("bob"), stale.ChangeID, "reject")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, domain.ChangeRejected, *change.Status)
+	require.Equal(t, "not now", change.Reason)
+	_, code = review(request("bob"), "unknown", "approve")
+	require.Equal(t, http.StatusNotFound, code)
+
+	// Without change control, the save saves the definition.
+	h.changeControl = false
+	resp, code = save(request(""), edited)
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, resp.ChangeID)
+	require.Equal(t, edited, definition())
+	_, cerr = h.ListChanges(operations.ListChangesParams{HTTPRequest: request("bob")})
+	require.NotNil(t, cerr)
+	require.Equal(t, http.StatusNotFound, cerr.Code)
+}
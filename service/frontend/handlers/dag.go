@@ -1,26 +1,39 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"os"
+	"io/fs"
+	"net"
+	"net/http"
+	"path"
+	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/dagu-dev/dagu/internal/archive"
 	"github.com/dagu-dev/dagu/internal/config"
 	"github.com/dagu-dev/dagu/internal/constants"
 	"github.com/dagu-dev/dagu/internal/dag"
 	"github.com/dagu-dev/dagu/internal/engine"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/logfile"
 	"github.com/dagu-dev/dagu/internal/persistence"
 	"github.com/dagu-dev/dagu/internal/persistence/jsondb"
 	domain "github.com/dagu-dev/dagu/internal/persistence/model"
 	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/internal/search"
+	"github.com/dagu-dev/dagu/internal/utils"
 	"github.com/dagu-dev/dagu/service/frontend/handlers/response"
+	pkgmiddleware "github.com/dagu-dev/dagu/service/frontend/middleware"
 	"github.com/dagu-dev/dagu/service/frontend/models"
 	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
 	"github.com/dagu-dev/dagu/service/frontend/server"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
+	"github.com/google/uuid"
 	"github.com/samber/lo"
 	"golang.org/x/text/encoding"
 	"golang.org/x/text/encoding/japanese"
@@ -34,29 +47,73 @@ const (
 	dagTabTypeHistory      = "history"
 	dagTabTypeStepLog      = "log"
 	dagTabTypeSchedulerLog = "scheduler-log"
+
+	// defaultHistoryLimit is the number of the runs of the history tab.
+	defaultHistoryLimit = 30
+
+	// defaultWaitTimeout is the time a start waits for the run to finish
+	// if the request has no timeout.
+	defaultWaitTimeout = 5 * time.Minute
 )
 
 var (
-	errInvalidArgs        = errors.New("invalid argument")
+	errInvalidArgs        = dagerrors.New(dagerrors.CodeInvalidArgument, "invalid argument")
 	ErrFailedToReadStatus = errors.New("failed to read status")
-	ErrStepNotFound       = errors.New("step was not found")
+	ErrStepNotFound       = dagerrors.New(dagerrors.CodeNotFound, "step was not found")
+	ErrDAGNotFound        = dagerrors.New(dagerrors.CodeNotFound, "DAG was not found")
 	ErrReadingLastStatus  = errors.New("error reading the last status")
+	errDAGRunning         = dagerrors.New(dagerrors.CodeDAGRunning, "the DAG is still running")
+	errDAGNotRunning      = dagerrors.New(dagerrors.CodeDAGNotRunning, "the DAG is not running")
+	errChaosNotAllowed    = dagerrors.New(dagerrors.CodePermissionDenied, "the server does not allow chaos")
 )
 
 type DAGHandler struct {
+	// namespaces are the handlers of the namespaces. The requests are
+	// served by the one of their namespace.
+	namespaces map[string]*DAGHandler
+
 	engineFactory engine.Factory
+	auditStore    persistence.AuditStore
+	// archiveStore and logStore are nil if the archive and the log
+	// backend are not configured.
+	archiveStore persistence.ArchiveStore
+	logStore     persistence.LogStore
+	// triggerQueue is nil if the trigger queue is not configured. The runs
+	// started without waiting are added to it for the scheduler otherwise.
+	triggerQueue persistence.TriggerQueue
+	// allowChaos allows the runs to inject faults into their steps.
+	allowChaos bool
+	search     *search.Index
+	// changeControl saves the edits of the DAGs once they are approved, and
+	// keeps them in changeStore until then.
+	changeControl bool
+	changeStore   persistence.ChangeStore
+	dagsDir       string
 }
 
-func NewDAG(engineFactory engine.Factory) server.New {
+func NewDAG(namespaces Namespaces) server.New {
 	return &DAGHandler{
-		engineFactory: engineFactory,
+		namespaces: byNamespace(namespaces, func(ns *Namespace) *DAGHandler {
+			return &DAGHandler{
+				engineFactory: ns.EngineFactory,
+				auditStore:    ns.DataStore.NewAuditStore(),
+				archiveStore:  ns.DataStore.NewArchiveStore(),
+				logStore:      ns.DataStore.NewLogStore(),
+				triggerQueue:  ns.DataStore.NewTriggerQueue(),
+				allowChaos:    ns.Config.AllowChaos,
+				search:        ns.Search,
+				changeControl: ns.Config.ChangeControl,
+				changeStore:   ns.DataStore.NewChangeStore(),
+				dagsDir:       ns.Config.DAGs,
+			}
+		}),
 	}
 }
 
 func (h *DAGHandler) Configure(api *operations.DaguAPI) {
 	api.ListDagsHandler = operations.ListDagsHandlerFunc(
 		func(params operations.ListDagsParams) middleware.Responder {
-			resp, err := h.GetList(params)
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).GetList(params)
 			if err != nil {
 				return operations.NewListDagsDefault(err.Code).WithPayload(err.APIError)
 			}
@@ -65,7 +122,7 @@ func (h *DAGHandler) Configure(api *operations.DaguAPI) {
 
 	api.GetDagDetailsHandler = operations.GetDagDetailsHandlerFunc(
 		func(params operations.GetDagDetailsParams) middleware.Responder {
-			resp, err := h.GetDetail(params)
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).GetDetail(params)
 			if err != nil {
 				return operations.NewGetDagDetailsDefault(err.Code).WithPayload(err.APIError)
 			}
@@ -74,16 +131,70 @@ func (h *DAGHandler) Configure(api *operations.DaguAPI) {
 
 	api.PostDagActionHandler = operations.PostDagActionHandlerFunc(
 		func(params operations.PostDagActionParams) middleware.Responder {
-			resp, err := h.PostAction(params)
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).PostAction(params)
 			if err != nil {
 				return operations.NewPostDagActionDefault(err.Code).WithPayload(err.APIError)
 			}
 			return operations.NewPostDagActionOK().WithPayload(resp)
 		})
 
+	api.PostBulkActionHandler = operations.PostBulkActionHandlerFunc(
+		func(params operations.PostBulkActionParams) middleware.Responder {
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).PostBulkAction(params)
+			if err != nil {
+				return operations.NewPostBulkActionDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewPostBulkActionOK().WithPayload(resp)
+		})
+
+	api.GetDagGraphHandler = operations.GetDagGraphHandlerFunc(
+		func(params operations.GetDagGraphParams) middleware.Responder {
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).GetGraph(params)
+			if err != nil {
+				return operations.NewGetDagGraphDefault(err.Code).WithPayload(err.APIError)
+			}
+			return resp
+		})
+
+	api.GetHaltHandler = operations.GetHaltHandlerFunc(
+		func(params operations.GetHaltParams) middleware.Responder {
+			resp, err := h.GetHalt(params)
+			if err != nil {
+				return operations.NewGetHaltDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewGetHaltOK().WithPayload(resp)
+		})
+
+	api.PostHaltHandler = operations.PostHaltHandlerFunc(
+		func(params operations.PostHaltParams) middleware.Responder {
+			resp, err := h.PostHalt(params)
+			if err != nil {
+				return operations.NewPostHaltDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewPostHaltOK().WithPayload(resp)
+		})
+
+	api.DeleteHaltHandler = operations.DeleteHaltHandlerFunc(
+		func(params operations.DeleteHaltParams) middleware.Responder {
+			resp, err := h.DeleteHalt(params)
+			if err != nil {
+				return operations.NewDeleteHaltDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewDeleteHaltOK().WithPayload(resp)
+		})
+
+	api.GetDagStatusesHandler = operations.GetDagStatusesHandlerFunc(
+		func(params operations.GetDagStatusesParams) middleware.Responder {
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).GetStatuses(params)
+			if err != nil {
+				return operations.NewGetDagStatusesDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewGetDagStatusesOK().WithPayload(resp)
+		})
+
 	api.CreateDagHandler = operations.CreateDagHandlerFunc(
 		func(params operations.CreateDagParams) middleware.Responder {
-			resp, err := h.Create(params)
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).Create(params)
 			if err != nil {
 				return operations.NewCreateDagDefault(err.Code).WithPayload(err.APIError)
 			}
@@ -92,16 +203,59 @@ func (h *DAGHandler) Configure(api *operations.DaguAPI) {
 
 	api.DeleteDagHandler = operations.DeleteDagHandlerFunc(
 		func(params operations.DeleteDagParams) middleware.Responder {
-			err := h.Delete(params)
+			err := ofRequest(h.namespaces, params.HTTPRequest).Delete(params)
 			if err != nil {
 				return operations.NewDeleteDagDefault(err.Code).WithPayload(err.APIError)
 			}
 			return operations.NewDeleteDagOK()
 		})
 
+	api.ListFoldersHandler = operations.ListFoldersHandlerFunc(
+		func(params operations.ListFoldersParams) middleware.Responder {
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).ListFolders(params)
+			if err != nil {
+				return operations.NewListFoldersDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewListFoldersOK().WithPayload(resp)
+		})
+
+	api.CreateFolderHandler = operations.CreateFolderHandlerFunc(
+		func(params operations.CreateFolderParams) middleware.Responder {
+			if err := ofRequest(h.namespaces, params.HTTPRequest).CreateFolder(params); err != nil {
+				return operations.NewCreateFolderDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewCreateFolderOK()
+		})
+
+	api.DeleteFolderHandler = operations.DeleteFolderHandlerFunc(
+		func(params operations.DeleteFolderParams) middleware.Responder {
+			if err := ofRequest(h.namespaces, params.HTTPRequest).DeleteFolder(params); err != nil {
+				return operations.NewDeleteFolderDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewDeleteFolderOK()
+		})
+
+	api.ListChangesHandler = operations.ListChangesHandlerFunc(
+		func(params operations.ListChangesParams) middleware.Responder {
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).ListChanges(params)
+			if err != nil {
+				return operations.NewListChangesDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewListChangesOK().WithPayload(resp)
+		})
+
+	api.ReviewChangeHandler = operations.ReviewChangeHandlerFunc(
+		func(params operations.ReviewChangeParams) middleware.Responder {
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).ReviewChange(params)
+			if err != nil {
+				return operations.NewReviewChangeDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewReviewChangeOK().WithPayload(resp)
+		})
+
 	api.SearchDagsHandler = operations.SearchDagsHandlerFunc(
 		func(params operations.SearchDagsParams) middleware.Responder {
-			resp, err := h.Search(params)
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).Search(params)
 			if err != nil {
 				return operations.NewSearchDagsDefault(err.Code).WithPayload(err.APIError)
 			}
@@ -113,11 +267,15 @@ func (h *DAGHandler) Create(params operations.CreateDagParams) (*models.CreateDa
 	switch lo.FromPtr(params.Body.Action) {
 	case "new":
 		name := *params.Body.Value
+		if !accessOf(params.HTTPRequest).AllowsName(name, nil, pkgmiddleware.RoleAdmin) {
+			return nil, response.NewError(fmt.Errorf("%w: %s of %s", errPermissionDenied, pkgmiddleware.RoleAdmin, name))
+		}
 		e := h.engineFactory.Create()
 		id, err := e.CreateDAG(name)
 		if err != nil {
-			return nil, response.NewInternalError(err)
+			return nil, response.NewError(err)
 		}
+		h.audit(params.HTTPRequest, &domain.AuditEntry{Action: "create", DAG: id})
 		return &models.CreateDagResponse{DagID: swag.String(id)}, nil
 	default:
 		return nil, response.NewBadRequestError(errInvalidArgs)
@@ -127,20 +285,52 @@ func (h *DAGHandler) Delete(params operations.DeleteDagParams) *response.CodedEr
 	e := h.engineFactory.Create()
 	dagStatus, err := e.GetStatus(params.DagID)
 	if err != nil {
-		return response.NewNotFoundError(err)
+		return response.NewError(err)
+	}
+	if cerr := authorizeDAG(params.HTTPRequest, dagStatus, pkgmiddleware.RoleAdmin); cerr != nil {
+		return cerr
 	}
 	if err := e.DeleteDAG(params.DagID, dagStatus.DAG.Location); err != nil {
 		return response.NewInternalError(err)
 	}
+	h.audit(params.HTTPRequest, &domain.AuditEntry{Action: "delete", DAG: params.DagID})
 	return nil
 }
 
-func (h *DAGHandler) GetList(_ operations.ListDagsParams) (*models.ListDagsResponse, *response.CodedError) {
+func (h *DAGHandler) GetList(params operations.ListDagsParams) (*models.ListDagsResponse, *response.CodedError) {
 	e := h.engineFactory.Create()
 	dags, errs, err := e.GetAllStatus()
 	if err != nil {
 		return nil, response.NewInternalError(err)
 	}
+	if access := accessOf(params.HTTPRequest); access != nil {
+		dags = lo.Filter(dags, func(d *persistence.DAGStatus, _ int) bool {
+			return allows(access, d, pkgmiddleware.RoleViewer)
+		})
+	}
+	query := &persistence.DAGQuery{
+		Tag:       lo.FromPtr(params.Tag),
+		Status:    lo.FromPtr(params.Status),
+		Scheduled: params.Scheduled,
+		Sort:      lo.FromPtr(params.Sort),
+		Desc:      lo.FromPtr(params.Order) == "desc",
+		Offset:    int(lo.FromPtr(params.Offset)),
+		Limit:     int(lo.FromPtr(params.Limit)),
+	}
+	var folders []string
+	if params.Folder != nil {
+		folder := strings.Trim(*params.Folder, "/")
+		query.Folder = &folder
+		all, err := e.Folders()
+		if err != nil {
+			return nil, response.NewInternalError(err)
+		}
+		folders = subfolders(all, folder)
+	}
+	page, total, err := query.Apply(dags)
+	if err != nil {
+		return nil, response.NewBadRequestError(err)
+	}
 
 	// TODO: remove this if it's not needed
 	_, _, hasErr := lo.FindIndexOf(dags, func(d *persistence.DAGStatus) bool {
@@ -151,7 +341,77 @@ func (h *DAGHandler) GetList(_ operations.ListDagsParams) (*models.ListDagsRespo
 		hasErr = true
 	}
 
-	return response.ToListDagResponse(dags, errs, hasErr), nil
+	resp := response.ToListDagResponse(page, total, errs, hasErr)
+	resp.Folders = folders
+	return resp, nil
+}
+
+// GetStatuses returns the latest statuses of the DAGs of the names, in
+// their order, or of the tag. The errors are the ones of these DAGs only,
+// and the names that are not the ones of DAGs the request is allowed to
+// view are reported as not found.
+func (h *DAGHandler) GetStatuses(params operations.GetDagStatusesParams) (*models.GetDagStatusesResponse, *response.CodedError) {
+	var names []string
+	for _, name := range strings.Split(lo.FromPtr(params.Names), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	tag := lo.FromPtr(params.Tag)
+	if len(names) == 0 && tag == "" {
+		return nil, response.NewBadRequestError(fmt.Errorf("names or tag is required: %w", errInvalidArgs))
+	}
+	e := h.engineFactory.Create()
+	// The errors of the list are the ones of all the DAGs, so only the
+	// ones of the DAGs of the response are reported.
+	dags, _, err := e.GetAllStatus()
+	if err != nil {
+		return nil, response.NewInternalError(err)
+	}
+	access := accessOf(params.HTTPRequest)
+	if access != nil {
+		dags = lo.Filter(dags, func(d *persistence.DAGStatus, _ int) bool {
+			return allows(access, d, pkgmiddleware.RoleViewer)
+		})
+	}
+	dags, _, err = (&persistence.DAGQuery{Tag: tag}).Apply(dags)
+	if err != nil {
+		return nil, response.NewBadRequestError(err)
+	}
+	var errs []string
+	if len(names) > 0 {
+		byName := make(map[string]*persistence.DAGStatus, len(dags))
+		for _, d := range dags {
+			byName[d.DAG.Name] = d
+		}
+		dags = nil
+		for _, name := range names {
+			d, ok := byName[name]
+			if !ok {
+				errs = append(errs, loadError(e, access, name))
+				continue
+			}
+			dags = append(dags, d)
+		}
+	}
+	for _, d := range dags {
+		if d.Error != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", d.DAG.Name, d.Error))
+		}
+	}
+	return response.ToGetDagStatusesResponse(dags, errs), nil
+}
+
+// loadError returns the error of the DAG of the name that is not in the
+// list, which is the error of loading its file if it cannot be loaded and
+// the access allows the name, or not found otherwise.
+func loadError(e engine.Engine, access *pkgmiddleware.Access, name string) string {
+	if filepath.IsLocal(name) && access.AllowsName(name, nil, pkgmiddleware.RoleViewer) {
+		if _, err := e.GetStatus(name); err != nil && dagerrors.CodeOf(err) != dagerrors.CodeNotFound {
+			return fmt.Sprintf("%s: %s", name, err)
+		}
+	}
+	return fmt.Sprintf("%s: %s", ErrDAGNotFound, name)
 }
 
 func (h *DAGHandler) GetDetail(params operations.GetDagDetailsParams) (*models.GetDagDetailsResponse, *response.CodedError) {
@@ -165,12 +425,25 @@ func (h *DAGHandler) GetDetail(params operations.GetDagDetailsParams) (*models.G
 
 	logFile := params.File
 	stepName := params.Step
+	requestID := lo.FromPtr(params.RequestID)
 
 	e := h.engineFactory.Create()
 	dagStatus, err := e.GetStatus(dagID)
 	if dagStatus == nil {
 		return nil, response.NewNotFoundError(err)
 	}
+	if cerr := authorizeDAG(params.HTTPRequest, dagStatus, pkgmiddleware.RoleViewer); cerr != nil {
+		return nil, cerr
+	}
+
+	// A specific run is requested, e.g. the sub DAG run of a step.
+	if requestID != "" {
+		status, err := e.GetStatusByRequestId(dagStatus.DAG, requestID)
+		if err != nil {
+			return nil, response.NewNotFoundError(err)
+		}
+		dagStatus.Status = status
+	}
 
 	resp := response.ToGetDagDetailResponse(
 		dagStatus,
@@ -191,12 +464,23 @@ func (h *DAGHandler) GetDetail(params operations.GetDagDetailsParams) (*models.G
 		resp.Definition = lo.ToPtr(dagContent)
 
 	case dagTabTypeHistory:
+		limit, offset := int(lo.FromPtr(params.Limit)), int(lo.FromPtr(params.Offset))
+		if limit == 0 {
+			limit = defaultHistoryLimit
+		}
+		if limit < 0 || offset < 0 {
+			return nil, response.NewBadRequestError(errInvalidArgs)
+		}
+		// One more run is read to tell if there are older runs.
 		e := h.engineFactory.Create()
-		logs := e.GetRecentHistory(dagStatus.DAG, 30)
+		logs := e.GetRecentHistory(dagStatus.DAG, offset+limit+1)
+		hasMore := len(logs) > offset+limit
+		logs = logs[min(offset, len(logs)):min(offset+limit, len(logs))]
 		resp.LogData = response.ToDagLogResponse(logs)
+		resp.LogData.HasMore = hasMore
 
 	case dagTabTypeStepLog:
-		stepLog, err := h.getStepLog(dagStatus.DAG, lo.FromPtr(logFile), lo.FromPtr(stepName))
+		stepLog, err := h.getStepLog(dagStatus.DAG, lo.FromPtr(logFile), lo.FromPtr(stepName), requestID)
 		if err != nil {
 			return nil, response.NewNotFoundError(err)
 		}
@@ -215,11 +499,12 @@ func (h *DAGHandler) GetDetail(params operations.GetDagDetailsParams) (*models.G
 	return resp, nil
 }
 
-func (h *DAGHandler) getStepLog(d *dag.DAG, logFile, stepName string) (*models.DagStepLogResponse, error) {
+func (h *DAGHandler) getStepLog(d *dag.DAG, logFile, stepName, requestID string) (*models.DagStepLogResponse, error) {
 	var stepByName = map[string]*domain.Node{
 		constants.OnSuccess: nil,
 		constants.OnFailure: nil,
 		constants.OnCancel:  nil,
+		constants.OnTimeout: nil,
 		constants.OnExit:    nil,
 	}
 
@@ -227,13 +512,20 @@ func (h *DAGHandler) getStepLog(d *dag.DAG, logFile, stepName string) (*models.D
 
 	e := h.engineFactory.Create()
 
-	if logFile == "" {
+	switch {
+	case requestID != "":
+		s, err := e.GetStatusByRequestId(d, requestID)
+		if err != nil {
+			return nil, err
+		}
+		status = s
+	case logFile == "":
 		s, err := e.GetLatestStatus(d)
 		if err != nil {
 			return nil, ErrFailedToReadStatus
 		}
 		status = s
-	} else {
+	default:
 		// TODO: fix not to use json db directly
 		s, err := jsondb.ParseFile(logFile)
 		if err != nil {
@@ -245,6 +537,7 @@ func (h *DAGHandler) getStepLog(d *dag.DAG, logFile, stepName string) (*models.D
 	stepByName[constants.OnSuccess] = status.OnSuccess
 	stepByName[constants.OnFailure] = status.OnFailure
 	stepByName[constants.OnCancel] = status.OnCancel
+	stepByName[constants.OnTimeout] = status.OnTimeout
 	stepByName[constants.OnExit] = status.OnExit
 
 	node, ok := lo.Find(status.Nodes, func(item *domain.Node) bool {
@@ -254,15 +547,18 @@ func (h *DAGHandler) getStepLog(d *dag.DAG, logFile, stepName string) (*models.D
 		return nil, fmt.Errorf("%w: %s", ErrStepNotFound, stepName)
 	}
 
-	logContent, err := getLogFileContent(node.Log)
+	logContent, src, err := h.getLogFileContent(status, node.Log)
 	if err != nil {
 		return nil, fmt.Errorf("error reading %s: %w", node.Log, err)
 	}
+	if status.LogFormat == dag.LogFormatJSON {
+		logContent = string(scheduler.PlainLog([]byte(logContent)))
+	}
 
-	return response.ToDagStepLogResponse(node.Log, logContent, node), nil
+	return response.ToDagStepLogResponse(node.Log, logContent, src, node), nil
 }
 
-func getLogFileContent(fileName string) (string, error) {
+func (h *DAGHandler) getLogFileContent(status *domain.Status, fileName string) (string, response.LogSource, error) {
 	// TODO: fix this to change to dependency injection
 	enc := config.Get().LogEncodingCharset
 
@@ -270,31 +566,46 @@ func getLogFileContent(fileName string) (string, error) {
 	if strings.ToLower(enc) == "euc-jp" {
 		decoder = japanese.EUCJP.NewDecoder()
 	}
-	logContent, err := readFileContent(fileName, decoder)
-	return string(logContent), err
+	logContent, src, err := h.readFileContent(status, fileName, decoder)
+	return string(logContent), src, err
 }
 
+// readFileContent reads the log file f of the run, which may have been
+// compressed. The log that is no longer on the local disk is read from the
+// archive if the run is archived, or from the log backend, e.g. when it was
+// written on another machine. The source is returned.
 // TODO: refactor this
-func readFileContent(f string, decoder *encoding.Decoder) ([]byte, error) {
-	if decoder == nil {
-		return os.ReadFile(f)
+func (h *DAGHandler) readFileContent(status *domain.Status, f string, decoder *encoding.Decoder) ([]byte, response.LogSource, error) {
+	var src response.LogSource
+	r, err := logfile.Open(f)
+	switch {
+	case !errors.Is(err, fs.ErrNotExist):
+	case status.Archive != nil && h.archiveStore != nil:
+		src.ArchivedFrom = status.Archive.Location
+		r, err = archive.OpenLog(context.Background(), h.archiveStore, status, f)
+	case h.logStore != nil:
+		src.RemoteLocation = h.logStore.Location(status.Name, status.RequestId)
+		r, err = logfile.OpenFrom(func(name string) (io.ReadCloser, error) {
+			return h.logStore.Open(context.Background(), status.Name, status.RequestId, name)
+		}, filepath.Base(f))
 	}
-
-	r, err := os.Open(f)
 	if err != nil {
-		return nil, fmt.Errorf("error reading %s: %w", f, err)
+		return nil, response.LogSource{}, err
 	}
 	defer func() {
 		_ = r.Close()
 	}()
-	tr := transform.NewReader(r, decoder)
-	ret, err := io.ReadAll(tr)
-	return ret, err
+	var content io.Reader = r
+	if decoder != nil {
+		content = transform.NewReader(r, decoder)
+	}
+	ret, err := io.ReadAll(content)
+	return ret, src, err
 }
 
 func (h *DAGHandler) readSchedulerLog(d *dag.DAG, statusFile string) (*models.DagSchedulerLogResponse, error) {
 	var (
-		logFile string
+		status *domain.Status
 	)
 
 	e := h.engineFactory.Create()
@@ -304,20 +615,21 @@ func (h *DAGHandler) readSchedulerLog(d *dag.DAG, statusFile string) (*models.Da
 		if err != nil {
 			return nil, ErrReadingLastStatus
 		}
-		logFile = s.Log
+		status = s
 	} else {
 		// TODO: fix not to use json db directly
 		s, err := jsondb.ParseFile(statusFile)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing %s: %w", statusFile, err)
 		}
-		logFile = s.Log
+		status = s
 	}
-	content, err := readFileContent(logFile, nil)
+	logFile := status.Log
+	content, src, err := h.readFileContent(status, logFile, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error reading %s: %w", logFile, err)
 	}
-	return response.ToDagSchedulerLogResponse(logFile, string(content)), nil
+	return response.ToDagSchedulerLogResponse(logFile, string(content), src), nil
 }
 
 // nolint // cognitive complexity
@@ -326,28 +638,69 @@ func (h *DAGHandler) PostAction(params operations.PostDagActionParams) (*models.
 	d, err := e.GetStatus(params.DagID)
 
 	if err != nil && *params.Body.Action != "save" {
-		return nil, response.NewBadRequestError(err)
+		return nil, response.NewError(err)
+	}
+	if cerr := authorizeAction(params, d); cerr != nil {
+		return nil, cerr
 	}
 
 	switch *params.Body.Action {
 	case "start":
 		if d.Status.Status == scheduler.StatusRunning {
-			return nil, response.NewBadRequestError(errInvalidArgs)
+			return nil, response.NewConflictError(errDAGRunning)
+		}
+		if err := d.DAG.ValidateParams(params.Body.Params); err != nil {
+			return nil, response.NewBadRequestError(err)
+		}
+		if len(params.Body.Chaos) > 0 {
+			if !h.allowChaos {
+				return nil, response.NewError(errChaosNotAllowed)
+			}
+			if _, err := scheduler.ParseFaults(params.Body.Chaos); err != nil {
+				return nil, response.NewBadRequestError(err)
+			}
+		}
+		id, err := uuid.NewRandom()
+		if err != nil {
+			return nil, response.NewInternalError(err)
+		}
+		opts := engine.RunOptions{
+			Params:    params.Body.Params,
+			Initiator: initiator(params),
+			Faults:    params.Body.Chaos,
+			RequestId: id.String(),
+		}
+		if params.Wait != nil && *params.Wait {
+			return h.startAndWait(params, d.DAG, opts)
+		}
+		if h.triggerQueue != nil {
+			return h.enqueue(params, d.DAG, opts)
 		}
 		e := h.engineFactory.Create()
-		e.StartAsync(d.DAG, params.Body.Params)
+		e.StartAsyncWithOptions(d.DAG, opts)
+		h.auditAction(params, d.DAG.Name)
+		return &models.PostDagActionResponse{RequestID: opts.RequestId}, nil
 
 	case "suspend":
-		_ = e.ToggleSuspend(params.DagID, params.Body.Value == "true")
+		suspend := params.Body.Value == "true"
+		if err := e.ToggleSuspend(d.DAG.Name, suspend); err != nil {
+			return nil, response.NewInternalError(err)
+		}
+		entry := &domain.AuditEntry{Action: "suspend", DAG: d.DAG.Name}
+		if !suspend {
+			entry.Action = "resume"
+		}
+		h.audit(params.HTTPRequest, entry)
 
 	case "stop":
 		if d.Status.Status != scheduler.StatusRunning {
-			return nil, response.NewBadRequestError(fmt.Errorf("the DAG is not running: %w", errInvalidArgs))
+			return nil, response.NewConflictError(errDAGNotRunning)
 		}
 		e := h.engineFactory.Create()
 		if err := e.Stop(d.DAG); err != nil {
-			return nil, response.NewBadRequestError(fmt.Errorf("error trying to stop the DAG: %w", err))
+			return nil, response.NewError(fmt.Errorf("error trying to stop the DAG: %w", err))
 		}
+		h.auditAction(params, d.DAG.Name)
 
 	case "retry":
 		if params.Body.RequestID == "" {
@@ -356,47 +709,46 @@ func (h *DAGHandler) PostAction(params operations.PostDagActionParams) (*models.
 		e := h.engineFactory.Create()
 		err = e.Retry(d.DAG, params.Body.RequestID)
 		if err != nil {
-			return nil, response.NewInternalError(fmt.Errorf("error trying to retry the DAG: %w", err))
+			return nil, response.NewError(fmt.Errorf("error trying to retry the DAG: %w", err))
 		}
+		h.auditAction(params, d.DAG.Name)
 
-	case "mark-success":
-		if d.Status.Status == scheduler.StatusRunning {
-			return nil, response.NewBadRequestError(fmt.Errorf("the DAG is still running: %w", errInvalidArgs))
-		}
+	case "mark-success", "mark-failed":
 		if params.Body.RequestID == "" {
 			return nil, response.NewBadRequestError(fmt.Errorf("request-id is required: %w", errInvalidArgs))
 		}
 		if params.Body.Step == "" {
 			return nil, response.NewBadRequestError(fmt.Errorf("step name is required: %w", errInvalidArgs))
 		}
-
-		err = h.updateStatus(d.DAG, params.Body.RequestID, params.Body.Step, scheduler.NodeStatusSuccess)
-		if err != nil {
-			return nil, response.NewInternalError(err)
-		}
-
-	case "mark-failed":
-		if d.Status.Status == scheduler.StatusRunning {
-			return nil, response.NewBadRequestError(fmt.Errorf("the DAG is still running: %w", errInvalidArgs))
+		if params.Body.Reason == "" {
+			return nil, response.NewBadRequestError(fmt.Errorf("reason is required: %w", errInvalidArgs))
 		}
-		if params.Body.RequestID == "" {
-			return nil, response.NewBadRequestError(fmt.Errorf("request-id is required: %w", errInvalidArgs))
+		to := scheduler.NodeStatusSuccess
+		if *params.Body.Action == "mark-failed" {
+			to = scheduler.NodeStatusError
 		}
-		if params.Body.Step == "" {
-			return nil, response.NewBadRequestError(fmt.Errorf("step name is required: %w", errInvalidArgs))
+		// A step of the running run is taken over by the agent of the run,
+		// and the status of a finished run is updated in its history.
+		if d.Status.Status == scheduler.StatusRunning && d.Status.RequestId == params.Body.RequestID {
+			err = e.MarkStep(d.DAG, params.Body.Step, to, params.Body.Reason)
+		} else {
+			err = h.updateStatus(d.DAG, params.Body.RequestID, params.Body.Step, to, params.Body.Reason)
 		}
-
-		err = h.updateStatus(d.DAG, params.Body.RequestID, params.Body.Step, scheduler.NodeStatusError)
 		if err != nil {
-			return nil, response.NewInternalError(err)
+			return nil, response.NewError(err)
 		}
+		h.auditAction(params, d.DAG.Name)
 
 	case "save":
+		if h.changeControl {
+			return h.propose(params, d)
+		}
 		e := h.engineFactory.Create()
 		err := e.UpdateDAG(params.DagID, params.Body.Value)
 		if err != nil {
-			return nil, response.NewInternalError(err)
+			return nil, response.NewError(err)
 		}
+		h.audit(params.HTTPRequest, &domain.AuditEntry{Action: "save", DAG: params.DagID})
 
 	case "rename":
 		newName := params.Body.Value
@@ -405,10 +757,23 @@ func (h *DAGHandler) PostAction(params operations.PostDagActionParams) (*models.
 		}
 		e := h.engineFactory.Create()
 		if err := e.Rename(params.DagID, newName); err != nil {
-			return nil, response.NewInternalError(err)
+			return nil, response.NewError(err)
 		}
+		h.audit(params.HTTPRequest, &domain.AuditEntry{Action: "rename", DAG: params.DagID, Detail: newName})
 		return &models.PostDagActionResponse{NewDagID: params.Body.Value}, nil
 
+	case "move":
+		if params.Body.Value == "" {
+			return nil, response.NewBadRequestError(fmt.Errorf("folder is required: %w", errInvalidArgs))
+		}
+		newName := movedName(params.DagID, params.Body.Value)
+		e := h.engineFactory.Create()
+		if err := e.Rename(params.DagID, newName); err != nil {
+			return nil, response.NewError(err)
+		}
+		h.audit(params.HTTPRequest, &domain.AuditEntry{Action: "move", DAG: params.DagID, Detail: newName})
+		return &models.PostDagActionResponse{NewDagID: newName}, nil
+
 	default:
 		return nil, response.NewBadRequestError(fmt.Errorf("invalid action: %s", *params.Body.Action))
 	}
@@ -416,7 +781,72 @@ func (h *DAGHandler) PostAction(params operations.PostDagActionParams) (*models.
 	return &models.PostDagActionResponse{}, nil
 }
 
-func (h *DAGHandler) updateStatus(d *dag.DAG, reqId, step string, to scheduler.NodeStatus) error {
+// enqueue adds the start of the run to the trigger queue, so that the
+// scheduler starts it, later if it is down, with the request ID returned.
+func (h *DAGHandler) enqueue(params operations.PostDagActionParams, d *dag.DAG, opts engine.RunOptions) (*models.PostDagActionResponse, *response.CodedError) {
+	err := h.triggerQueue.Enqueue(&domain.Trigger{
+		DAG:       params.DagID,
+		RequestId: opts.RequestId,
+		Params:    opts.Params,
+		Initiator: opts.Initiator,
+		Faults:    opts.Faults,
+	})
+	if err != nil {
+		return nil, response.NewInternalError(fmt.Errorf("failed to queue the run: %w", err))
+	}
+	h.auditAction(params, d.Name)
+	return &models.PostDagActionResponse{RequestID: opts.RequestId, Queued: true}, nil
+}
+
+// startAndWait starts a run of the DAG and waits for it to finish, or for
+// the timeout of the request, to return its status and the outputs of its
+// steps. The run goes on if the timeout passes first.
+func (h *DAGHandler) startAndWait(params operations.PostDagActionParams, d *dag.DAG, opts engine.RunOptions) (*models.PostDagActionResponse, *response.CodedError) {
+	timeout := defaultWaitTimeout
+	if params.Timeout != nil && *params.Timeout != "" {
+		t, err := time.ParseDuration(*params.Timeout)
+		if err != nil || t <= 0 {
+			return nil, response.NewBadRequestError(fmt.Errorf("%w: timeout %s", errInvalidArgs, *params.Timeout))
+		}
+		timeout = t
+	}
+
+	e := h.engineFactory.Create()
+	done := make(chan error, 1)
+	go func() {
+		done <- e.StartWithOptions(d, opts)
+	}()
+	h.auditAction(params, d.Name)
+
+	ctx := context.Background()
+	if params.HTTPRequest != nil {
+		ctx = params.HTTPRequest.Context()
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	var err error
+	select {
+	case err = <-done:
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	ret := &models.PostDagActionResponse{RequestID: opts.RequestId}
+	status, serr := e.GetStatusByRequestId(d, opts.RequestId)
+	if serr != nil {
+		// The run has no status if it failed to start, or if it has
+		// not started before the timeout.
+		if err != nil {
+			return nil, response.NewError(fmt.Errorf("error trying to start the DAG: %w", err))
+		}
+		return ret, nil
+	}
+	ret.Status = response.ToDagStatusDetail(status)
+	ret.Outputs = response.ToOutputs(status)
+	return ret, nil
+}
+
+func (h *DAGHandler) updateStatus(d *dag.DAG, reqId, step string, to scheduler.NodeStatus, reason string) error {
 	e := h.engineFactory.Create()
 	status, err := e.GetStatusByRequestId(d, reqId)
 	if err != nil {
@@ -432,21 +862,110 @@ func (h *DAGHandler) updateStatus(d *dag.DAG, reqId, step string, to scheduler.N
 
 	status.Nodes[idx].Status = to
 	status.Nodes[idx].StatusText = to.String()
+	status.Nodes[idx].MarkedReason = reason
 
 	return e.UpdateStatus(d, status)
 }
 
+// authorizeAction returns an error if the access rules of the request do
+// not allow the action on the DAG. The operator role runs the DAG, and the
+// admin role edits it, moves it and renames it to a name the role is allowed
+// on.
+func authorizeAction(params operations.PostDagActionParams, d *persistence.DAGStatus) *response.CodedError {
+	access := accessOf(params.HTTPRequest)
+	role := pkgmiddleware.RoleOperator
+	switch *params.Body.Action {
+	case "save":
+		role = pkgmiddleware.RoleAdmin
+	case "rename":
+		role = pkgmiddleware.RoleAdmin
+		if d != nil && !access.AllowsName(params.Body.Value, d.DAG.Tags, role) {
+			return response.NewError(fmt.Errorf("%w: %s of %s", errPermissionDenied, role, params.Body.Value))
+		}
+	case "move":
+		role = pkgmiddleware.RoleAdmin
+		// The access rules match the IDs of the DAGs, which the moves to
+		// the folders change.
+		newName := movedName(params.DagID, params.Body.Value)
+		if d != nil && !access.AllowsName(newName, d.DAG.Tags, role) {
+			return response.NewError(fmt.Errorf("%w: %s of %s", errPermissionDenied, role, newName))
+		}
+	}
+	if d == nil || d.DAG == nil {
+		if !access.AllowsName(params.DagID, nil, role) {
+			return response.NewError(fmt.Errorf("%w: %s of %s", errPermissionDenied, role, params.DagID))
+		}
+		return nil
+	}
+	return authorizeDAG(params.HTTPRequest, d, role)
+}
+
+// movedName returns the name of the DAG moved to the folder, / being the
+// DAGs directory itself.
+func movedName(name, folder string) string {
+	return path.Join(strings.Trim(folder, "/"), path.Base(name))
+}
+
+// audit records the action of the request in the audit log.
+func (h *DAGHandler) audit(r *http.Request, entry *domain.AuditEntry) {
+	entry.Time = time.Now()
+	if r != nil {
+		identity := pkgmiddleware.IdentityFrom(r.Context())
+		entry.Actor, entry.Token = identity.User, identity.Token
+		entry.SourceIP = sourceIP(r)
+	}
+	utils.LogErr("write audit log", h.auditStore.Append(entry))
+}
+
+// auditAction records the action on the DAG in the audit log.
+func (h *DAGHandler) auditAction(params operations.PostDagActionParams, name string) {
+	h.audit(params.HTTPRequest, &domain.AuditEntry{
+		Action:    *params.Body.Action,
+		DAG:       name,
+		RequestId: params.Body.RequestID,
+		Step:      params.Body.Step,
+		Reason:    params.Body.Reason,
+	})
+}
+
+// sourceIP returns the address of the client of the request. It is the one
+// of the proxy if the server is behind one.
+func sourceIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// initiator returns who started a run with the request, or nil if the
+// server does not authenticate the requests.
+func initiator(params operations.PostDagActionParams) *domain.Initiator {
+	if params.HTTPRequest == nil {
+		return nil
+	}
+	identity := pkgmiddleware.IdentityFrom(params.HTTPRequest.Context())
+	if identity == (pkgmiddleware.Identity{}) {
+		return nil
+	}
+	return &domain.Initiator{Token: identity.Token, User: identity.User}
+}
+
 func (h *DAGHandler) Search(params operations.SearchDagsParams) (*models.SearchDagsResponse, *response.CodedError) {
 	query := params.Q
 	if query == "" {
 		return nil, response.NewBadRequestError(errInvalidArgs)
 	}
 
-	e := h.engineFactory.Create()
-	ret, errs, err := e.Grep(query)
+	ret, errs, err := h.search.Search(query, swag.BoolValue(params.Logs))
 	if err != nil {
 		return nil, response.NewInternalError(err)
 	}
+	if access := accessOf(params.HTTPRequest); access != nil {
+		ret = lo.Filter(ret, func(r *search.Result, _ int) bool {
+			return access.AllowsName(r.Name, r.DAG.Tags, pkgmiddleware.RoleViewer)
+		})
+	}
 
 	return response.ToSearchDAGsResponse(ret, errs), nil
 }
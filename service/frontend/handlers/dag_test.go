@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"net/http"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/engine"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/utils"
+	pkgmiddleware "github.com/dagu-dev/dagu/service/frontend/middleware"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStatuses(t *testing.T) {
+	tmpDir := utils.MustTempDir("dagu_test")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	dagsDir := path.Join(tmpDir, "dags")
+	require.NoError(t, os.MkdirAll(dagsDir, 0755))
+	for name, spec := range map[string]string{
+		"etl_daily":  "tags: finance\nsteps:\n  - name: extract\n    command: echo 1\n",
+		"etl_broken": "steps: [\n",
+		"report":     "tags: finance\nsteps:\n  - name: render\n    command: echo 1\n",
+		"broken":     "steps: [\n",
+		"team-a/etl": "tags: team\nsteps:\n  - name: extract\n    command: echo 1\n",
+	} {
+		require.NoError(t, os.MkdirAll(path.Dir(path.Join(dagsDir, name)), 0755))
+		require.NoError(t, os.WriteFile(path.Join(dagsDir, name+".yaml"), []byte(spec), 0600))
+	}
+	ds := client.NewDataStoreFactory(&config.Config{
+		DataDir: path.Join(tmpDir, "data"),
+		DAGs:    dagsDir,
+	})
+	h := &DAGHandler{engineFactory: engine.NewFactory(ds, &config.Config{})}
+
+	getStatuses := func(r *http.Request, names, tag string) *models.GetDagStatusesResponse {
+		params := operations.GetDagStatusesParams{HTTPRequest: r}
+		if names != "" {
+			params.Names = lo.ToPtr(names)
+		}
+		if tag != "" {
+			params.Tag = lo.ToPtr(tag)
+		}
+		resp, err := h.GetStatuses(params)
+		require.Nil(t, err)
+		return resp
+	}
+	dagNames := func(resp *models.GetDagStatusesResponse) []string {
+		return lo.Map(resp.DAGs, func(d *models.DagListItem, _ int) string {
+			return *d.DAG.Name
+		})
+	}
+	r, err := http.NewRequest("GET", "/api/v1/dags/statuses", nil)
+	require.NoError(t, err)
+
+	// The statuses are in the order of the names, without the errors of
+	// the other DAGs.
+	resp := getStatuses(r, "report, etl_daily", "")
+	require.Equal(t, []string{"report", "etl_daily"}, dagNames(resp))
+	require.Empty(t, resp.Errors)
+
+	// The statuses of the tag are sorted by the names.
+	resp = getStatuses(r, "", "finance")
+	require.Equal(t, []string{"etl_daily", "report"}, dagNames(resp))
+	require.Empty(t, resp.Errors)
+
+	// The unknown names are not found, and the DAGs of the names that
+	// cannot be loaded are reported with their errors.
+	resp = getStatuses(r, "unknown,broken", "")
+	require.Empty(t, resp.DAGs)
+	require.Len(t, resp.Errors, 2)
+	require.Equal(t, ErrDAGNotFound.Error()+": unknown", resp.Errors[0])
+	require.Contains(t, resp.Errors[1], "broken: ")
+
+	// A request without names or a tag is not valid.
+	_, cerr := h.GetStatuses(operations.GetDagStatusesParams{HTTPRequest: r, Names: lo.ToPtr(" , ")})
+	require.NotNil(t, cerr)
+	require.Equal(t, http.StatusBadRequest, cerr.Code)
+
+	// The DAGs the access rules hide are not found, the ones that cannot
+	// be loaded included.
+	restricted := r.WithContext(pkgmiddleware.WithAccess(r.Context(), []pkgmiddleware.AccessRule{
+		{User: "alice", Role: pkgmiddleware.RoleViewer, Prefixes: []string{"etl_"}},
+	}))
+	resp = getStatuses(restricted, "etl_daily,report,broken,etl_broken", "")
+	require.Equal(t, []string{"etl_daily"}, dagNames(resp))
+	require.Len(t, resp.Errors, 3)
+	require.Equal(t, ErrDAGNotFound.Error()+": report", resp.Errors[0])
+	require.Equal(t, ErrDAGNotFound.Error()+": broken", resp.Errors[1])
+	require.Contains(t, resp.Errors[2], "etl_broken: ")
+	resp = getStatuses(restricted, "", "finance")
+	require.Equal(t, []string{"etl_daily"}, dagNames(resp))
+	require.Empty(t, resp.Errors)
+
+	// The prefixes match the IDs of the DAGs in the folders, and not the
+	// names of their files.
+	resp = getStatuses(restricted, "", "team")
+	require.Empty(t, resp.DAGs)
+	team := r.WithContext(pkgmiddleware.WithAccess(r.Context(), []pkgmiddleware.AccessRule{
+		{User: "alice", Role: pkgmiddleware.RoleViewer, Prefixes: []string{"team-a/"}},
+	}))
+	resp = getStatuses(team, "", "team")
+	require.Equal(t, []string{"etl"}, dagNames(resp))
+	resp = getStatuses(team, "", "finance")
+	require.Empty(t, resp.DAGs)
+}
+
+func TestPostActionErrors(t *testing.T) {
+	tmpDir := utils.MustTempDir("dagu_test")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	dagsDir := path.Join(tmpDir, "dags")
+	require.NoError(t, os.MkdirAll(dagsDir, 0755))
+	for name, spec := range map[string]string{
+		"idle":   "steps:\n  - name: extract\n    command: echo 1\n",
+		"broken": "steps: [\n",
+		"cycle":  "steps:\n  - name: a\n    command: echo 1\n    depends: b\n  - name: b\n    command: echo 1\n    depends: a\n",
+	} {
+		require.NoError(t, os.WriteFile(path.Join(dagsDir, name+".yaml"), []byte(spec), 0600))
+	}
+	ds := client.NewDataStoreFactory(&config.Config{
+		DataDir: path.Join(tmpDir, "data"),
+		DAGs:    dagsDir,
+	})
+	h := &DAGHandler{engineFactory: engine.NewFactory(ds, &config.Config{})}
+
+	// The errors keep the codes of the status of the DAG, so that only the
+	// unknown DAGs are not found.
+	for _, tc := range []struct {
+		dagID  string
+		action string
+		status int
+		code   string
+	}{
+		{dagID: "unknown", action: "start", status: http.StatusNotFound, code: "not_found"},
+		{dagID: "broken", action: "start", status: http.StatusBadRequest, code: "invalid_dag"},
+		{dagID: "cycle", action: "start", status: http.StatusBadRequest, code: "invalid_dag"},
+		{dagID: "idle", action: "stop", status: http.StatusConflict, code: "dag_not_running"},
+	} {
+		_, cerr := h.PostAction(operations.PostDagActionParams{
+			DagID: tc.dagID,
+			Body:  operations.PostDagActionBody{Action: lo.ToPtr(tc.action)},
+		})
+		require.NotNil(t, cerr, tc.dagID)
+		require.Equal(t, tc.status, cerr.Code, tc.dagID)
+		require.Equal(t, tc.code, *cerr.APIError.Code, tc.dagID)
+	}
+}
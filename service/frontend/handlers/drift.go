@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/constants"
+	"github.com/dagu-dev/dagu/internal/drift"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/service/frontend/handlers/response"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+	"github.com/dagu-dev/dagu/service/frontend/server"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+type DriftHandler struct {
+	namespaces map[string]*DriftHandler
+
+	dagStore    persistence.DAGStore
+	remoteNodes []config.RemoteNode
+}
+
+func NewDrift(namespaces Namespaces) server.New {
+	return &DriftHandler{
+		namespaces: byNamespace(namespaces, func(ns *Namespace) *DriftHandler {
+			return &DriftHandler{
+				dagStore:    ns.DataStore.NewDAGStore(),
+				remoteNodes: ns.Config.RemoteNodes,
+			}
+		}),
+	}
+}
+
+func (h *DriftHandler) Configure(api *operations.DaguAPI) {
+	api.GetDriftInventoryHandler = operations.GetDriftInventoryHandlerFunc(
+		func(params operations.GetDriftInventoryParams) middleware.Responder {
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).GetInventory()
+			if err != nil {
+				return operations.NewGetDriftInventoryDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewGetDriftInventoryOK().WithPayload(resp)
+		})
+
+	api.GetDriftReportHandler = operations.GetDriftReportHandlerFunc(
+		func(params operations.GetDriftReportParams) middleware.Responder {
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).GetReport(params.HTTPRequest.Context())
+			if err != nil {
+				return operations.NewGetDriftReportDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewGetDriftReportOK().WithPayload(resp)
+		})
+}
+
+func (h *DriftHandler) GetInventory() (*models.DriftInventoryResponse, *response.CodedError) {
+	inv, _, err := drift.Collect(h.dagStore, constants.Version)
+	if err != nil {
+		return nil, response.NewInternalError(err)
+	}
+	return response.ToDriftInventoryResponse(inv), nil
+}
+
+// GetReport compares the DAGs of the server with the ones of the remote
+// nodes. A remote node that cannot be compared is reported with its error.
+func (h *DriftHandler) GetReport(ctx context.Context) (*models.DriftReportResponse, *response.CodedError) {
+	inv, errs, err := drift.Collect(h.dagStore, constants.Version)
+	if err != nil {
+		return nil, response.NewInternalError(err)
+	}
+	return response.ToDriftReportResponse(drift.Check(ctx, inv, h.remoteNodes), errs), nil
+}
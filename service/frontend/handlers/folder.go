@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	domain "github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/service/frontend/handlers/response"
+	pkgmiddleware "github.com/dagu-dev/dagu/service/frontend/middleware"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+)
+
+func (h *DAGHandler) ListFolders(_ operations.ListFoldersParams) (*models.ListFoldersResponse, *response.CodedError) {
+	folders, err := h.engineFactory.Create().Folders()
+	if err != nil {
+		return nil, response.NewError(err)
+	}
+	return &models.ListFoldersResponse{Folders: folders}, nil
+}
+
+func (h *DAGHandler) CreateFolder(params operations.CreateFolderParams) *response.CodedError {
+	folder := strings.Trim(*params.Body.Folder, "/")
+	if cerr := authorizeFolders(params.HTTPRequest); cerr != nil {
+		return cerr
+	}
+	if err := h.engineFactory.Create().CreateFolder(folder); err != nil {
+		return response.NewError(err)
+	}
+	h.audit(params.HTTPRequest, &domain.AuditEntry{Action: "create-folder", Detail: folder})
+	return nil
+}
+
+func (h *DAGHandler) DeleteFolder(params operations.DeleteFolderParams) *response.CodedError {
+	folder := strings.Trim(params.Folder, "/")
+	if cerr := authorizeFolders(params.HTTPRequest); cerr != nil {
+		return cerr
+	}
+	if err := h.engineFactory.Create().DeleteFolder(folder); err != nil {
+		return response.NewError(err)
+	}
+	h.audit(params.HTTPRequest, &domain.AuditEntry{Action: "delete-folder", Detail: folder})
+	return nil
+}
+
+// authorizeFolders returns an error if the access rules of the request do not
+// allow the admin role on all the DAGs, which the folders are shared by.
+func authorizeFolders(r *http.Request) *response.CodedError {
+	if accessOf(r).AllowsAll(pkgmiddleware.RoleAdmin) {
+		return nil
+	}
+	return response.NewError(fmt.Errorf("%w: %s of the folders", errPermissionDenied, pkgmiddleware.RoleAdmin))
+}
+
+// subfolders returns the folders directly in the folder, the empty one
+// being the DAGs directory itself.
+func subfolders(folders []string, folder string) []string {
+	prefix := ""
+	if folder != "" {
+		prefix = folder + "/"
+	}
+	ret := []string{}
+	for _, f := range folders {
+		if rest, ok := strings.CutPrefix(f, prefix); ok && rest != "" && !strings.Contains(rest, "/") {
+			ret = append(ret, f)
+		}
+	}
+	return ret
+}
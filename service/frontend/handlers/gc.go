@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/gc"
+	"github.com/dagu-dev/dagu/service/frontend/handlers/response"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+	"github.com/dagu-dev/dagu/service/frontend/server"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+var errNoGCReport = dagerrors.New(dagerrors.CodeNotFound, "no garbage collection has been run")
+
+type GCHandler struct {
+	namespaces map[string]*GCHandler
+
+	reportFile string
+}
+
+func NewGC(namespaces Namespaces) server.New {
+	return &GCHandler{
+		namespaces: byNamespace(namespaces, func(ns *Namespace) *GCHandler {
+			return &GCHandler{
+				reportFile: gc.ReportFile(ns.Config.DataDir),
+			}
+		}),
+	}
+}
+
+func (h *GCHandler) Configure(api *operations.DaguAPI) {
+	api.GetGcReportHandler = operations.GetGcReportHandlerFunc(
+		func(params operations.GetGcReportParams) middleware.Responder {
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).GetReport()
+			if err != nil {
+				return operations.NewGetGcReportDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewGetGcReportOK().WithPayload(resp)
+		})
+}
+
+func (h *GCHandler) GetReport() (*models.GcReportResponse, *response.CodedError) {
+	r, err := gc.ReadReport(h.reportFile)
+	if err != nil {
+		return nil, response.NewInternalError(err)
+	}
+	if r == nil {
+		return nil, response.NewNotFoundError(errNoGCReport)
+	}
+	return response.ToGCReportResponse(r), nil
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/dagu-dev/dagu/internal/engine"
+	domain "github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/report"
+	"github.com/dagu-dev/dagu/service/frontend/handlers/response"
+	pkgmiddleware "github.com/dagu-dev/dagu/service/frontend/middleware"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// GetGraph exports the dependency graph of the steps of the DAG, with the
+// steps colored by their statuses in the latest run, or in the run of the
+// request ID, if the request asks for the statuses.
+func (h *DAGHandler) GetGraph(params operations.GetDagGraphParams) (middleware.Responder, *response.CodedError) {
+	format := report.FormatMermaid
+	if params.Format != nil {
+		format = *params.Format
+	}
+	e := h.engineFactory.Create()
+	dagStatus, err := e.GetStatus(params.DagID)
+	if dagStatus == nil {
+		return nil, response.NewNotFoundError(err)
+	}
+	d := dagStatus.DAG
+	if cerr := authorizeDAG(params.HTTPRequest, dagStatus, pkgmiddleware.RoleViewer); cerr != nil {
+		return nil, cerr
+	}
+
+	var status *domain.Status
+	switch {
+	case params.RequestID != nil && *params.RequestID != "":
+		status, err = engine.RunStatus(e, d, *params.RequestID)
+		if err != nil {
+			return nil, response.NewNotFoundError(fmt.Errorf("run %s of %s: %w", *params.RequestID, params.DagID, err))
+		}
+	case params.Status != nil && *params.Status:
+		status, err = e.GetLatestStatus(d)
+		if err != nil {
+			return nil, response.NewError(fmt.Errorf("%w: %s", ErrReadingLastStatus, err))
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := report.Graph(&buf, format, d, status); err != nil {
+		return nil, response.NewError(err)
+	}
+	return middleware.ResponderFunc(func(w http.ResponseWriter, _ runtime.Producer) {
+		w.Header().Set("Content-Type", report.ContentTypes[format])
+		w.WriteHeader(http.StatusOK)
+		_, _ = buf.WriteTo(w)
+	}), nil
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	domain "github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/service/frontend/handlers/response"
+	pkgmiddleware "github.com/dagu-dev/dagu/service/frontend/middleware"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+	"github.com/go-openapi/swag"
+	"github.com/samber/lo"
+)
+
+var errNotConfirmed = dagerrors.New(dagerrors.CodeInvalidArgument, "confirm must be true to stop all the DAGs")
+
+// GetHalt returns whether the schedules of a namespace are halted.
+func (h *DAGHandler) GetHalt(_ operations.GetHaltParams) (*models.HaltResponse, *response.CodedError) {
+	halted := false
+	for _, name := range h.namespaceNames() {
+		halted = halted || h.namespaces[name].engineFactory.Create().IsHalted()
+	}
+	return &models.HaltResponse{Halted: swag.Bool(halted)}, nil
+}
+
+// PostHalt stops all the running DAGs and halts the schedules of all the
+// namespaces. The DAGs that fail to stop do not stop the others, and are
+// reported in the errors. The DAGs of the namespaces other than the default
+// one are reported as <namespace>:<name>.
+func (h *DAGHandler) PostHalt(params operations.PostHaltParams) (*models.HaltResponse, *response.CodedError) {
+	if cerr := h.authorizeHalt(params.HTTPRequest); cerr != nil {
+		return nil, cerr
+	}
+	if params.Body.Confirm == nil || !*params.Body.Confirm {
+		return nil, response.NewBadRequestError(errNotConfirmed)
+	}
+	ret := &models.HaltResponse{Halted: swag.Bool(true), Stopped: []string{}}
+	var errs []error
+	for _, name := range h.namespaceNames() {
+		nh := h.namespaces[name]
+		e := nh.engineFactory.Create()
+		stopped, err := e.StopAll()
+		nh.audit(params.HTTPRequest, &domain.AuditEntry{
+			Action: "stop-all",
+			Reason: params.Body.Reason,
+			Detail: strings.Join(stopped, ","),
+		})
+		for _, s := range stopped {
+			ret.Stopped = append(ret.Stopped, qualified(name, s))
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			if !e.IsHalted() {
+				ret.Halted = swag.Bool(false)
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		if !*ret.Halted {
+			return nil, response.NewError(err)
+		}
+		ret.Errors = strings.Split(err.Error(), "\n")
+	}
+	return ret, nil
+}
+
+// DeleteHalt resumes the schedules of the namespaces halted by PostHalt.
+func (h *DAGHandler) DeleteHalt(params operations.DeleteHaltParams) (*models.HaltResponse, *response.CodedError) {
+	if cerr := h.authorizeHalt(params.HTTPRequest); cerr != nil {
+		return nil, cerr
+	}
+	for _, name := range h.namespaceNames() {
+		nh := h.namespaces[name]
+		if err := nh.engineFactory.Create().SetHalted(false); err != nil {
+			return nil, response.NewError(fmt.Errorf("%s: %w", name, err))
+		}
+		nh.audit(params.HTTPRequest, &domain.AuditEntry{Action: "resume-all"})
+	}
+	return &models.HaltResponse{Halted: swag.Bool(false)}, nil
+}
+
+// authorizeHalt returns an error if the access rules of the request do not
+// allow the admin role on all the DAGs of all the namespaces, which the halt
+// stops.
+func (h *DAGHandler) authorizeHalt(r *http.Request) *response.CodedError {
+	if accessOf(r).AllowsAllIn(h.namespaceNames(), pkgmiddleware.RoleAdmin) {
+		return nil
+	}
+	return response.NewError(fmt.Errorf("%w: %s of all the DAGs of all the namespaces", errPermissionDenied, pkgmiddleware.RoleAdmin))
+}
+
+// namespaceNames returns the names of the namespaces, sorted.
+func (h *DAGHandler) namespaceNames() []string {
+	names := lo.Keys(h.namespaces)
+	slices.Sort(names)
+	return names
+}
+
+// qualified returns the name of the DAG of the namespace, prefixed with the
+// namespace unless it is the default one.
+func qualified(namespace, name string) string {
+	if namespace == pkgmiddleware.DefaultNamespace {
+		return name
+	}
+	return namespace + ":" + name
+}
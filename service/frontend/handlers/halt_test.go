@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/engine"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/utils"
+	pkgmiddleware "github.com/dagu-dev/dagu/service/frontend/middleware"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHaltNamespaces(t *testing.T) {
+	tmpDir := utils.MustTempDir("dagu_test")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	h := &DAGHandler{namespaces: map[string]*DAGHandler{}}
+	engines := map[string]engine.Engine{}
+	for _, name := range []string{pkgmiddleware.DefaultNamespace, "staging"} {
+		ds := client.NewDataStoreFactory(&config.Config{
+			DataDir: path.Join(tmpDir, name, "data"),
+			DAGs:    path.Join(tmpDir, name, "dags"),
+		})
+		ef := engine.NewFactory(ds, &config.Config{})
+		h.namespaces[name] = &DAGHandler{engineFactory: ef, auditStore: ds.NewAuditStore()}
+		engines[name] = ef.Create()
+	}
+	request := func(rules ...pkgmiddleware.AccessRule) *http.Request {
+		r, err := http.NewRequest("POST", "/api/v1/halt", nil)
+		require.NoError(t, err)
+		if rules == nil {
+			return r
+		}
+		return r.WithContext(pkgmiddleware.WithAccess(r.Context(), rules))
+	}
+	halt := func(r *http.Request) int {
+		_, cerr := h.PostHalt(operations.PostHaltParams{
+			HTTPRequest: r,
+			Body:        operations.PostHaltBody{Confirm: lo.ToPtr(true)},
+		})
+		if cerr != nil {
+			return cerr.Code
+		}
+		return http.StatusOK
+	}
+
+	// The admin of the DAGs of one namespace does not halt the others.
+	admin := pkgmiddleware.AccessRule{User: "alice", Role: pkgmiddleware.RoleAdmin, Namespaces: []string{"staging"}}
+	require.Equal(t, http.StatusForbidden, halt(request(admin)))
+	for _, e := range engines {
+		require.False(t, e.IsHalted())
+	}
+
+	// The halt halts the schedules of all the namespaces, and the resume
+	// resumes them.
+	require.Equal(t, http.StatusOK, halt(request()))
+	for _, e := range engines {
+		require.True(t, e.IsHalted())
+	}
+	resp, cerr := h.GetHalt(operations.GetHaltParams{HTTPRequest: request()})
+	require.Nil(t, cerr)
+	require.True(t, *resp.Halted)
+	_, cerr = h.DeleteHalt(operations.DeleteHaltParams{HTTPRequest: request()})
+	require.Nil(t, cerr)
+	for _, e := range engines {
+		require.False(t, e.IsHalted())
+	}
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/internal/engine"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/scheduler"
+	"github.com/dagu-dev/dagu/service/frontend/handlers/response"
+	pkgmiddleware "github.com/dagu-dev/dagu/service/frontend/middleware"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+	"github.com/dagu-dev/dagu/service/frontend/server"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+)
+
+// logPollInterval is the interval at which the streamed log and the status
+// of its step are read.
+const logPollInterval = 500 * time.Millisecond
+
+var errInvalidOffset = dagerrors.New(dagerrors.CodeInvalidArgument, "offset must be a non-negative number")
+
+type LogStreamHandler struct {
+	namespaces map[string]*LogStreamHandler
+
+	engineFactory engine.Factory
+}
+
+func NewLogStream(namespaces Namespaces) server.New {
+	return &LogStreamHandler{
+		namespaces: byNamespace(namespaces, func(ns *Namespace) *LogStreamHandler {
+			return &LogStreamHandler{
+				engineFactory: ns.EngineFactory,
+			}
+		}),
+	}
+}
+
+func (h *LogStreamHandler) Configure(api *operations.DaguAPI) {
+	api.StreamStepLogHandler = operations.StreamStepLogHandlerFunc(
+		func(params operations.StreamStepLogParams) middleware.Responder {
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).Stream(params)
+			if err != nil {
+				return operations.NewStreamStepLogDefault(err.Code).WithPayload(err.APIError)
+			}
+			return resp
+		})
+}
+
+// Stream sends the lines of the log of the step as server-sent events until
+// the step finished, or the client is gone. The id of each event is the
+// offset of the log after its line, from which a client that reconnects
+// with the Last-Event-ID header continues.
+func (h *LogStreamHandler) Stream(params operations.StreamStepLogParams) (middleware.Responder, *response.CodedError) {
+	offset, cerr := streamOffset(params)
+	if cerr != nil {
+		return nil, cerr
+	}
+	e := h.engineFactory.Create()
+	dagStatus, err := e.GetStatus(params.DagID)
+	if dagStatus == nil {
+		return nil, response.NewNotFoundError(err)
+	}
+	d := dagStatus.DAG
+	if cerr := authorizeDAG(params.HTTPRequest, dagStatus, pkgmiddleware.RoleViewer); cerr != nil {
+		return nil, cerr
+	}
+	status, err := engine.RunStatus(e, d, params.RequestID)
+	if err != nil {
+		return nil, response.NewNotFoundError(fmt.Errorf("run %s of %s: %w", params.RequestID, params.DagID, err))
+	}
+	if engine.FindNode(status, params.StepName) == nil {
+		return nil, response.NewNotFoundError(fmt.Errorf("%w: %s", ErrStepNotFound, params.StepName))
+	}
+
+	return middleware.ResponderFunc(func(w http.ResponseWriter, _ runtime.Producer) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		ew := &eventWriter{w: w, rc: http.NewResponseController(w), offset: offset, json: status.LogFormat == dag.LogFormatJSON}
+		if strings.ToLower(config.Get().LogEncodingCharset) == "euc-jp" {
+			ew.decoder = japanese.EUCJP.NewDecoder()
+		}
+		_ = ew.rc.Flush()
+
+		node, err := engine.FollowStepLog(params.HTTPRequest.Context(), e, d, params.RequestID, params.StepName, offset, logPollInterval, ew)
+		if err == nil {
+			err = ew.Close()
+		}
+		switch {
+		case errors.Is(err, context.Canceled):
+		case err != nil:
+			ew.event("error", err.Error())
+		case node == nil:
+			ew.event("end", "")
+		default:
+			ew.event("end", node.StatusText)
+		}
+	}), nil
+}
+
+// streamOffset returns the offset of the log to stream from, the one of the
+// Last-Event-ID header if the client reconnects.
+func streamOffset(params operations.StreamStepLogParams) (int64, *response.CodedError) {
+	offset := int64(0)
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+	if id := params.HTTPRequest.Header.Get("Last-Event-ID"); id != "" {
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return 0, response.NewBadRequestError(fmt.Errorf("%w: %s", errInvalidOffset, id))
+		}
+		offset = n
+	}
+	if offset < 0 {
+		return 0, response.NewBadRequestError(errInvalidOffset)
+	}
+	return offset, nil
+}
+
+// eventWriter sends each line written to it as the data of a message event
+// whose id is the offset of the log after the line.
+type eventWriter struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	offset  int64
+	json    bool
+	decoder *encoding.Decoder
+	buf     []byte
+}
+
+func (ew *eventWriter) Write(p []byte) (int, error) {
+	ew.buf = append(ew.buf, p...)
+	sent := false
+	for {
+		i := bytes.IndexByte(ew.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := ew.send(ew.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		ew.buf = ew.buf[i+1:]
+		sent = true
+	}
+	if sent {
+		if err := ew.rc.Flush(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Close sends the last line of the log if it does not end with a newline.
+func (ew *eventWriter) Close() error {
+	if len(ew.buf) == 0 {
+		return nil
+	}
+	if err := ew.send(ew.buf); err != nil {
+		return err
+	}
+	ew.buf = nil
+	return ew.rc.Flush()
+}
+
+func (ew *eventWriter) send(line []byte) error {
+	ew.offset += int64(len(line))
+	if ew.json {
+		line = scheduler.PlainLog(line)
+	}
+	if ew.decoder != nil {
+		if b, err := ew.decoder.Bytes(line); err == nil {
+			line = b
+		}
+	}
+	data := strings.TrimRight(string(line), "\r\n")
+	_, err := fmt.Fprintf(ew.w, "id: %d\ndata: %s\n\n", ew.offset, strings.ReplaceAll(data, "\r", ""))
+	return err
+}
+
+// event sends an event of the name with the data.
+func (ew *eventWriter) event(name, data string) {
+	_, _ = fmt.Fprintf(ew.w, "event: %s\ndata: %s\n\n", name, strings.ReplaceAll(data, "\n", " "))
+	_ = ew.rc.Flush()
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"slices"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/constants"
+	"github.com/dagu-dev/dagu/internal/executor"
+	pkgmiddleware "github.com/dagu-dev/dagu/service/frontend/middleware"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+	"github.com/dagu-dev/dagu/service/frontend/server"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/samber/lo"
+)
+
+// APIVersion is the version of the REST API served under /api/.
+const APIVersion = "v1"
+
+// The optional features of the API reported by the meta endpoint. A client
+// checks for a feature before it uses the endpoints or the fields of the
+// feature, instead of failing on the servers without it.
+const (
+	FeatureGCReport        = "gc-report"
+	FeatureImpersonation   = "impersonation"
+	FeatureArchive         = "archive"
+	FeatureLogBackend      = "log-backend"
+	FeatureDrift           = "drift"
+	FeatureOpenAPI         = "openapi"
+	FeatureArtifacts       = "artifacts"
+	FeatureLogStream       = "log-stream"
+	FeatureAPITokens       = "api-tokens"
+	FeatureValidate        = "validate"
+	FeatureNamespaces      = "namespaces"
+	FeatureChaos           = "chaos"
+	FeatureAudit           = "audit"
+	FeatureGraph           = "graph"
+	FeatureArtifactPreview = "artifact-preview"
+	FeatureTriggerQueue    = "trigger-queue"
+	FeatureChangeControl   = "change-control"
+	FeatureLogEncryption   = "log-encryption"
+)
+
+type MetaHandler struct {
+	meta *models.MetaResponse
+}
+
+func NewMeta(cfg *config.Config) server.New {
+	return &MetaHandler{
+		meta: Meta(cfg),
+	}
+}
+
+func (h *MetaHandler) Configure(api *operations.DaguAPI) {
+	api.GetMetaHandler = operations.GetMetaHandlerFunc(
+		func(params operations.GetMetaParams) middleware.Responder {
+			return operations.NewGetMetaOK().WithPayload(h.meta)
+		})
+}
+
+// Meta returns the version and the capabilities of the server of the
+// configuration.
+func Meta(cfg *config.Config) *models.MetaResponse {
+	features := []string{FeatureGCReport, FeatureDrift, FeatureOpenAPI, FeatureArtifacts, FeatureLogStream, FeatureAPITokens, FeatureValidate, FeatureAudit, FeatureGraph, FeatureArtifactPreview}
+	if slices.ContainsFunc(cfg.APITokens, func(t config.APIToken) bool {
+		return slices.Contains(t.Scopes, pkgmiddleware.ScopeImpersonate)
+	}) {
+		features = append(features, FeatureImpersonation)
+	}
+	storage := &models.MetaStorage{
+		History:   lo.ToPtr("file"),
+		Artifacts: lo.ToPtr("local"),
+		Logs:      lo.ToPtr("local"),
+	}
+	if b := cfg.HistoryBackend; b != nil && b.Type != "" {
+		storage.History = lo.ToPtr(b.Type)
+	}
+	if b := cfg.ArtifactBackend; b != nil && b.Type != "" {
+		storage.Artifacts = lo.ToPtr(b.Type)
+	}
+	if b := cfg.LogBackend; b != nil && b.Type != "" {
+		storage.Logs = lo.ToPtr(b.Type)
+		features = append(features, FeatureLogBackend)
+	}
+	if b := cfg.ArchiveBackend; b != nil && b.Type != "" {
+		storage.Archive = b.Type
+		features = append(features, FeatureArchive)
+	}
+	if q := cfg.TriggerQueue; q != nil && q.Type != "" {
+		features = append(features, FeatureTriggerQueue)
+	}
+
+	if cfg.AllowChaos {
+		features = append(features, FeatureChaos)
+	}
+
+	var namespaces []string
+	for _, ns := range cfg.Namespaces {
+		namespaces = append(namespaces, ns.Name)
+	}
+	if len(namespaces) > 0 {
+		features = append(features, FeatureNamespaces)
+	}
+	if cfg.ChangeControl || slices.ContainsFunc(cfg.Namespaces, func(ns config.Namespace) bool { return ns.ChangeControl }) {
+		features = append(features, FeatureChangeControl)
+	}
+	if cfg.EncryptionKeyFile != "" || slices.ContainsFunc(cfg.Namespaces, func(ns config.Namespace) bool { return ns.EncryptionKeyFile != "" }) {
+		features = append(features, FeatureLogEncryption)
+	}
+
+	var authModes []string
+	ldapEnabled := cfg.LDAP != nil && cfg.LDAP.URL != ""
+	if cfg.IsBasicAuth {
+		authModes = append(authModes, "basic")
+	}
+	if ldapEnabled {
+		authModes = append(authModes, "ldap")
+	}
+	// The tokens created with the API or the CLI are accepted with basic
+	// auth and LDAP too.
+	if cfg.IsAuthToken || len(cfg.APITokens) > 0 || cfg.IsBasicAuth || ldapEnabled {
+		authModes = append(authModes, "token")
+	}
+	if cfg.OIDC != nil && cfg.OIDC.Issuer != "" {
+		authModes = append(authModes, "oidc")
+	}
+	if len(authModes) == 0 {
+		authModes = []string{"none"}
+	}
+
+	return &models.MetaResponse{
+		Version:    lo.ToPtr(constants.Version),
+		APIVersion: lo.ToPtr(APIVersion),
+		Features:   features,
+		Executors:  executor.Names(),
+		AuthModes:  authModes,
+		Storage:    storage,
+		Namespaces: namespaces,
+	}
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	pkgmiddleware "github.com/dagu-dev/dagu/service/frontend/middleware"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeta(t *testing.T) {
+	base := []string{FeatureGCReport, FeatureDrift, FeatureOpenAPI, FeatureArtifacts, FeatureLogStream, FeatureAPITokens, FeatureValidate, FeatureAudit, FeatureGraph, FeatureArtifactPreview}
+	for _, tc := range []struct {
+		name      string
+		cfg       *config.Config
+		features  []string
+		authModes []string
+	}{
+		{
+			name:      "default",
+			cfg:       &config.Config{},
+			features:  base,
+			authModes: []string{"none"},
+		},
+		{
+			name: "backends",
+			cfg: &config.Config{
+				HistoryBackend: &config.HistoryBackend{Type: "sqlite"},
+				LogBackend:     &config.LogBackend{Type: "s3"},
+				ArchiveBackend: &config.ArchiveBackend{Type: "dir"},
+				TriggerQueue:   &config.TriggerQueue{Type: "sqlite"},
+				AllowChaos:     true,
+			},
+			features:  append(base[:len(base):len(base)], FeatureLogBackend, FeatureArchive, FeatureTriggerQueue, FeatureChaos),
+			authModes: []string{"none"},
+		},
+		{
+			name: "change control and encryption",
+			cfg: &config.Config{
+				ChangeControl:     true,
+				EncryptionKeyFile: "/etc/dagu/log.key",
+				IsBasicAuth:       true,
+			},
+			features:  append(base[:len(base):len(base)], FeatureChangeControl, FeatureLogEncryption),
+			authModes: []string{"basic", "token"},
+		},
+		{
+			name: "namespaces",
+			cfg: &config.Config{
+				Namespaces: []config.Namespace{
+					{Name: "staging", ChangeControl: true},
+					{Name: "prod", EncryptionKeyFile: "/etc/dagu/prod.key"},
+				},
+				APITokens: []config.APIToken{{Name: "ci", Scopes: []string{pkgmiddleware.ScopeImpersonate}}},
+			},
+			features:  append(base[:len(base):len(base)], FeatureImpersonation, FeatureNamespaces, FeatureChangeControl, FeatureLogEncryption),
+			authModes: []string{"token"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := Meta(tc.cfg)
+			require.Equal(t, tc.features, m.Features)
+			require.Equal(t, tc.authModes, m.AuthModes)
+			require.Equal(t, APIVersion, *m.APIVersion)
+		})
+	}
+
+	// The storage backends default to the local ones.
+	m := Meta(&config.Config{HistoryBackend: &config.HistoryBackend{Type: "postgres"}})
+	require.Equal(t, "postgres", *m.Storage.History)
+	require.Equal(t, "local", *m.Storage.Artifacts)
+	require.Equal(t, "local", *m.Storage.Logs)
+	require.Empty(t, m.Storage.Archive)
+	require.Empty(t, m.Namespaces)
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/engine"
+	"github.com/dagu-dev/dagu/internal/logger"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/search"
+	pkgmiddleware "github.com/dagu-dev/dagu/service/frontend/middleware"
+)
+
+// Namespace is the configuration, the engine and the stores of the DAGs
+// and the history of a namespace.
+type Namespace struct {
+	Config        *config.Config
+	EngineFactory engine.Factory
+	DataStore     persistence.DataStoreFactory
+
+	// Search is the index of the DAGs and their logs, which the server
+	// refreshes in the background.
+	Search *search.Index
+}
+
+// Namespaces are the namespaces the API serves by name, the default one
+// included.
+type Namespaces map[string]*Namespace
+
+// NewNamespaces returns the namespace of the configuration of the server,
+// with its engine and stores, and the other namespaces of the
+// configuration.
+func NewNamespaces(cfg *config.Config, engineFactory engine.Factory, ds persistence.DataStoreFactory, l logger.Logger) (Namespaces, error) {
+	name := cfg.Namespace
+	if name == "" {
+		name = pkgmiddleware.DefaultNamespace
+	}
+	namespaces := Namespaces{
+		name: {Config: cfg, EngineFactory: engineFactory, DataStore: ds, Search: newSearch(cfg, ds, l)},
+	}
+	names := []string{pkgmiddleware.DefaultNamespace}
+	for _, ns := range cfg.Namespaces {
+		names = append(names, ns.Name)
+	}
+	for _, name := range names {
+		if namespaces[name] != nil {
+			continue
+		}
+		c, err := cfg.ForNamespace(name)
+		if err != nil {
+			return nil, err
+		}
+		ds := client.NewDataStoreFactory(c)
+		namespaces[name] = &Namespace{
+			Config:        c,
+			EngineFactory: engine.NewFactory(ds, c),
+			DataStore:     ds,
+			Search:        newSearch(c, ds, l),
+		}
+	}
+	return namespaces, nil
+}
+
+func newSearch(cfg *config.Config, ds persistence.DataStoreFactory, l logger.Logger) *search.Index {
+	return search.New(&search.Config{
+		DAGStore:     ds.NewDAGStore(),
+		HistoryStore: ds.NewHistoryStore(),
+		LogRuns:      cfg.SearchLogRuns,
+		Logger:       l,
+	})
+}
+
+// byNamespace returns the handler of each of the namespaces.
+func byNamespace[H any](namespaces Namespaces, newHandler func(*Namespace) H) map[string]H {
+	handlers := make(map[string]H, len(namespaces))
+	for name, ns := range namespaces {
+		handlers[name] = newHandler(ns)
+	}
+	return handlers
+}
+
+// ofRequest returns the handler of the namespace of the request.
+func ofRequest[H any](handlers map[string]H, r *http.Request) H {
+	return handlers[pkgmiddleware.NamespaceFrom(r.Context())]
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/dagu-dev/dagu/internal/constants"
+	"github.com/dagu-dev/dagu/service/frontend/handlers/response"
+	"github.com/dagu-dev/dagu/service/frontend/openapi"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+	"github.com/dagu-dev/dagu/service/frontend/server"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+type OpenAPIHandler struct {
+	// document converts the spec once on the first request.
+	document func() ([]byte, error)
+}
+
+func NewOpenAPI() server.New {
+	return &OpenAPIHandler{
+		document: sync.OnceValues(func() ([]byte, error) {
+			return openapi.Document(constants.Version)
+		}),
+	}
+}
+
+func (h *OpenAPIHandler) Configure(api *operations.DaguAPI) {
+	api.GetOpenAPIHandler = operations.GetOpenAPIHandlerFunc(
+		func(params operations.GetOpenAPIParams) middleware.Responder {
+			b, err := h.document()
+			if err != nil {
+				cerr := response.NewInternalError(err)
+				return operations.NewGetOpenAPIDefault(cerr.Code).WithPayload(cerr.APIError)
+			}
+			return operations.NewGetOpenAPIOK().WithPayload(json.RawMessage(b))
+		})
+}
@@ -0,0 +1,33 @@
+package response
+
+import (
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/preview"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/samber/lo"
+)
+
+func ToListArtifactsResponse(artifacts []*persistence.Artifact) *models.ListArtifactsResponse {
+	return &models.ListArtifactsResponse{
+		Artifacts: lo.Map(artifacts, func(a *persistence.Artifact, _ int) *models.Artifact {
+			return &models.Artifact{
+				Path:    lo.ToPtr(a.Path),
+				Size:    lo.ToPtr(a.Size),
+				ModTime: lo.ToPtr(utils.FormatTime(a.ModTime)),
+				SHA256:  a.SHA256,
+				ETag:    a.ETag,
+				Type:    preview.TypeOf(a.Path),
+			}
+		}),
+	}
+}
+
+func ToArtifactURLResponse(url string, expiresAt time.Time) *models.ArtifactURLResponse {
+	return &models.ArtifactURLResponse{
+		URL:       lo.ToPtr(url),
+		ExpiresAt: lo.ToPtr(utils.FormatTime(expiresAt)),
+	}
+}
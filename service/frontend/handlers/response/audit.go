@@ -0,0 +1,31 @@
+package response
+
+import (
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/samber/lo"
+)
+
+func ToAuditEntry(e *model.AuditEntry) *models.AuditEntry {
+	return &models.AuditEntry{
+		Time:      lo.ToPtr(utils.FormatTime(e.Time)),
+		Actor:     e.Actor,
+		Token:     e.Token,
+		SourceIP:  e.SourceIP,
+		Action:    lo.ToPtr(e.Action),
+		DAG:       lo.ToPtr(e.DAG),
+		RequestID: e.RequestId,
+		Step:      e.Step,
+		Reason:    e.Reason,
+		Detail:    e.Detail,
+	}
+}
+
+func ToListAuditEntriesResponse(entries []*model.AuditEntry) *models.ListAuditEntriesResponse {
+	return &models.ListAuditEntriesResponse{
+		Entries: lo.Map(entries, func(e *model.AuditEntry, _ int) *models.AuditEntry {
+			return ToAuditEntry(e)
+		}),
+	}
+}
@@ -0,0 +1,34 @@
+package response
+
+import (
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/samber/lo"
+)
+
+func ToChange(c *model.Change) *models.Change {
+	ret := &models.Change{
+		ID:        lo.ToPtr(c.ID),
+		DAG:       lo.ToPtr(c.DAG),
+		Author:    lo.ToPtr(c.Author),
+		CreatedAt: lo.ToPtr(utils.FormatTime(c.CreatedAt)),
+		Spec:      lo.ToPtr(c.Spec),
+		Diff:      lo.ToPtr(c.Diff),
+		Status:    lo.ToPtr(c.Status),
+		Reviewer:  c.Reviewer,
+		Reason:    c.Reason,
+	}
+	if !c.ReviewedAt.IsZero() {
+		ret.ReviewedAt = utils.FormatTime(c.ReviewedAt)
+	}
+	return ret
+}
+
+func ToListChangesResponse(changes []*model.Change) *models.ListChangesResponse {
+	return &models.ListChangesResponse{
+		Changes: lo.Map(changes, func(c *model.Change, _ int) *models.Change {
+			return ToChange(c)
+		}),
+	}
+}
@@ -1,6 +1,8 @@
 package response
 
 import (
+	"strings"
+
 	"github.com/dagu-dev/dagu/internal/dag"
 	"github.com/dagu-dev/dagu/internal/persistence"
 	domain "github.com/dagu-dev/dagu/internal/persistence/model"
@@ -29,6 +31,7 @@ func ToDagStatusWithDetails(dagStatus *persistence.DAGStatus) *models.DagStatusW
 		Error:     lo.ToPtr(toErrorText(dagStatus.Error)),
 		ErrorT:    dagStatus.ErrorT,
 		File:      lo.ToPtr(dagStatus.File),
+		Folder:    dagStatus.Folder,
 		Status:    ToDagStatusDetail(dagStatus.Status),
 		Suspended: lo.ToPtr(dagStatus.Suspended),
 	}
@@ -41,6 +44,7 @@ func ToDagDetail(d *dag.DAG) *models.DagDetail {
 		Description:       lo.ToPtr(d.Description),
 		Env:               d.Env,
 		Group:             lo.ToPtr(d.Group),
+		Groups:            ToStepGroups(dag.StepGroups(d.Steps)),
 		HandlerOn:         ToHandlerOn(d.HandlerOn),
 		HistRetentionDays: lo.ToPtr(int64(d.HistRetentionDays)),
 		Location:          lo.ToPtr(d.Location),
@@ -48,6 +52,9 @@ func ToDagDetail(d *dag.DAG) *models.DagDetail {
 		MaxActiveRuns:     lo.ToPtr(int64(d.MaxActiveRuns)),
 		Name:              lo.ToPtr(d.Name),
 		Params:            d.Params,
+		ParamDefs: lo.Map(d.ParamDefs, func(item dag.ParamDef, _ int) *models.ParamDef {
+			return ToParamDef(item)
+		}),
 		Preconditions: lo.Map(d.Preconditions, func(item *dag.Condition, _ int) *models.Condition {
 			return ToCondition(item)
 		}),
@@ -58,9 +65,49 @@ func ToDagDetail(d *dag.DAG) *models.DagDetail {
 			return ToStepObject(item)
 		}),
 		Tags: d.Tags,
+		Warnings: lo.Map(d.Warnings, func(item dag.Warning, _ int) *models.DagWarning {
+			return &models.DagWarning{
+				Code:    lo.ToPtr(item.Code),
+				Field:   lo.ToPtr(item.Field),
+				Message: lo.ToPtr(item.Message),
+			}
+		}),
 	}
 }
 
+func ToStepGroups(groups []*dag.StepGroup) []*models.StepGroup {
+	return lo.Map(groups, func(item *dag.StepGroup, _ int) *models.StepGroup {
+		return &models.StepGroup{
+			Name:   item.Name,
+			Path:   item.Path,
+			Steps:  item.Steps,
+			Groups: ToStepGroups(item.Groups),
+		}
+	})
+}
+
+func ToParamDef(p dag.ParamDef) *models.ParamDef {
+	ret := &models.ParamDef{
+		Name:        lo.ToPtr(p.Name),
+		Type:        lo.ToPtr(p.Type),
+		Description: p.Description,
+		Default:     p.Default,
+		Required:    p.Required,
+		Values:      p.Values,
+		Pattern:     p.Pattern,
+	}
+	if p.Min != nil {
+		ret.Min = lo.ToPtr(int64(*p.Min))
+	}
+	if p.Max != nil {
+		ret.Max = lo.ToPtr(int64(*p.Max))
+	}
+	if p.Source != nil {
+		ret.Source = p.Source.Ref
+	}
+	return ret
+}
+
 func ToHandlerOn(handlerOn dag.HandlerOn) *models.HandlerOn {
 	ret := &models.HandlerOn{}
 	if handlerOn.Failure != nil {
@@ -72,6 +119,9 @@ func ToHandlerOn(handlerOn dag.HandlerOn) *models.HandlerOn {
 	if handlerOn.Cancel != nil {
 		ret.Cancel = ToStepObject(*handlerOn.Cancel)
 	}
+	if handlerOn.Timeout != nil {
+		ret.Timeout = ToStepObject(*handlerOn.Timeout)
+	}
 	if handlerOn.Exit != nil {
 		ret.Exit = ToStepObject(*handlerOn.Exit)
 	}
@@ -79,18 +129,51 @@ func ToHandlerOn(handlerOn dag.HandlerOn) *models.HandlerOn {
 }
 
 func ToDagStatusDetail(s *domain.Status) *models.DagStatusDetail {
-	return &models.DagStatusDetail{
-		Log:        lo.ToPtr(s.Log),
-		Name:       lo.ToPtr(s.Name),
-		Params:     lo.ToPtr(s.Params),
-		Pid:        lo.ToPtr(int64(s.Pid)),
-		RequestID:  lo.ToPtr(s.RequestId),
-		StartedAt:  lo.ToPtr(s.StartedAt),
-		FinishedAt: lo.ToPtr(s.FinishedAt),
-		Status:     lo.ToPtr(int64(s.Status)),
-		StatusText: lo.ToPtr(s.StatusText),
+	ret := &models.DagStatusDetail{
+		Log:           lo.ToPtr(s.Log),
+		Name:          lo.ToPtr(s.Name),
+		Params:        lo.ToPtr(s.Params),
+		Pid:           lo.ToPtr(int64(s.Pid)),
+		QueuePosition: int64(s.QueuePosition),
+		Lane:          s.Lane,
+		Overtook:      s.Overtook,
+		RequestID:     lo.ToPtr(s.RequestId),
+		StartedAt:     lo.ToPtr(s.StartedAt),
+		FinishedAt:    lo.ToPtr(s.FinishedAt),
+		Status:        lo.ToPtr(int64(s.Status)),
+		StatusText:    lo.ToPtr(s.StatusText),
+		Schedule:      s.Schedule,
+		StoppedBy:     s.StoppedBy,
+		RestartedFrom: s.RestartedFrom,
+		RestartedAs:   s.RestartedAs,
 		Nodes: lo.Map(s.Nodes, func(item *domain.Node, _ int) *models.StatusNode {
 			return ToNode(item)
 		}),
 	}
+	if s.Initiator != nil {
+		ret.InitiatedBy = s.Initiator.User
+		ret.InitiatorToken = s.Initiator.Token
+	}
+	if s.Archive != nil {
+		ret.ArchiveLocation = s.Archive.Location
+	}
+	return ret
+}
+
+// ToOutputs returns the output variables of the steps of the run, like the
+// outputs file of a sub DAG run.
+func ToOutputs(s *domain.Status) map[string]string {
+	outputs := map[string]string{}
+	for _, n := range s.Nodes {
+		if n.Step.OutputVariables == nil {
+			continue
+		}
+		n.Step.OutputVariables.Range(func(_, value any) bool {
+			if k, v, ok := strings.Cut(value.(string), "="); ok {
+				outputs[k] = v
+			}
+			return true
+		})
+	}
+	return outputs
 }
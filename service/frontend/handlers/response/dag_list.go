@@ -9,6 +9,7 @@ import (
 
 func ToListDagResponse(
 	dagStatusList []*persistence.DAGStatus,
+	total int,
 	errs []string,
 	hasError bool,
 ) *models.ListDagsResponse {
@@ -18,6 +19,16 @@ func ToListDagResponse(
 		}),
 		Errors:   errs,
 		HasError: lo.ToPtr(hasError),
+		Total:    lo.ToPtr(int64(total)),
+	}
+}
+
+func ToGetDagStatusesResponse(dagStatusList []*persistence.DAGStatus, errs []string) *models.GetDagStatusesResponse {
+	return &models.GetDagStatusesResponse{
+		DAGs: lo.Map(dagStatusList, func(item *persistence.DAGStatus, _ int) *models.DagListItem {
+			return ToDagListItem(item)
+		}),
+		Errors: errs,
 	}
 }
 
@@ -27,6 +38,7 @@ func ToDagListItem(s *persistence.DAGStatus) *models.DagListItem {
 		Error:     lo.ToPtr(toErrorText(s.Error)),
 		ErrorT:    s.ErrorT,
 		File:      lo.ToPtr(s.File),
+		Folder:    s.Folder,
 		Status:    ToDagStatus(s.Status),
 		Suspended: lo.ToPtr(s.Suspended),
 		DAG:       ToDAG(s.DAG),
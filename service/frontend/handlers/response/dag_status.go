@@ -7,15 +7,30 @@ import (
 )
 
 func ToDagStatus(s *domain.Status) *models.DagStatus {
-	return &models.DagStatus{
-		Log:        lo.ToPtr(s.Log),
-		Name:       lo.ToPtr(s.Name),
-		Params:     lo.ToPtr(s.Params),
-		Pid:        lo.ToPtr(int64(s.Pid)),
-		RequestID:  lo.ToPtr(s.RequestId),
-		StartedAt:  lo.ToPtr(s.StartedAt),
-		FinishedAt: lo.ToPtr(s.FinishedAt),
-		Status:     lo.ToPtr(int64(s.Status)),
-		StatusText: lo.ToPtr(s.StatusText),
+	ret := &models.DagStatus{
+		Log:           lo.ToPtr(s.Log),
+		Name:          lo.ToPtr(s.Name),
+		Params:        lo.ToPtr(s.Params),
+		Pid:           lo.ToPtr(int64(s.Pid)),
+		QueuePosition: int64(s.QueuePosition),
+		Lane:          s.Lane,
+		Overtook:      s.Overtook,
+		RequestID:     lo.ToPtr(s.RequestId),
+		StartedAt:     lo.ToPtr(s.StartedAt),
+		FinishedAt:    lo.ToPtr(s.FinishedAt),
+		Status:        lo.ToPtr(int64(s.Status)),
+		StatusText:    lo.ToPtr(s.StatusText),
+		Schedule:      s.Schedule,
+		StoppedBy:     s.StoppedBy,
+		RestartedFrom: s.RestartedFrom,
+		RestartedAs:   s.RestartedAs,
 	}
+	if s.Initiator != nil {
+		ret.InitiatedBy = s.Initiator.User
+		ret.InitiatorToken = s.Initiator.Token
+	}
+	if s.Archive != nil {
+		ret.ArchiveLocation = s.Archive.Location
+	}
+	return ret
 }
@@ -0,0 +1,49 @@
+package response
+
+import (
+	"github.com/dagu-dev/dagu/internal/drift"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/samber/lo"
+)
+
+func ToDriftInventoryResponse(inv *drift.Inventory) *models.DriftInventoryResponse {
+	return &models.DriftInventoryResponse{
+		Version: lo.ToPtr(inv.Version),
+		Definitions: lo.Map(inv.Definitions, func(d *drift.Definition, _ int) *models.DriftDefinition {
+			return &models.DriftDefinition{
+				Name:   lo.ToPtr(d.Name),
+				File:   lo.ToPtr(d.File),
+				Digest: lo.ToPtr(d.Digest),
+			}
+		}),
+	}
+}
+
+func ToDriftReportResponse(r *drift.Report, errs []string) *models.DriftReportResponse {
+	if errs == nil {
+		errs = []string{}
+	}
+	return &models.DriftReportResponse{
+		Version:   lo.ToPtr(r.Version),
+		CheckedAt: lo.ToPtr(utils.FormatTime(r.CheckedAt)),
+		Nodes: lo.Map(r.Nodes, func(n *drift.NodeReport, _ int) *models.DriftNode {
+			return &models.DriftNode{
+				Name:    lo.ToPtr(n.Name),
+				URL:     lo.ToPtr(n.URL),
+				Version: lo.ToPtr(n.Version),
+				Error:   n.Error,
+				InSync:  lo.ToPtr(n.InSync(r.Version)),
+				Differences: lo.Map(n.Differences, func(d *drift.Difference, _ int) *models.DriftDifference {
+					return &models.DriftDifference{
+						File:         lo.ToPtr(d.File),
+						Kind:         lo.ToPtr(d.Kind),
+						LocalDigest:  d.LocalDigest,
+						RemoteDigest: d.RemoteDigest,
+					}
+				}),
+			}
+		}),
+		Errors: errs,
+	}
+}
@@ -1,6 +1,9 @@
 package response
 
 import (
+	"net/http"
+
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
 	"github.com/dagu-dev/dagu/service/frontend/models"
 	"github.com/samber/lo"
 )
@@ -25,21 +28,46 @@ func NewCodedError(code int, apiError *models.APIError) *CodedError {
 	}
 }
 
-func NewAPIError(message, detailedMessage string) *models.APIError {
+func NewAPIError(message, detailedMessage string, code dagerrors.Code) *models.APIError {
 	return &models.APIError{
 		Message:         lo.ToPtr(message),
 		DetailedMessage: lo.ToPtr(detailedMessage),
+		Code:            lo.ToPtr(string(code)),
+		Category:        lo.ToPtr(string(code.Category())),
 	}
 }
 
+// NewError returns an error response whose status is derived from the
+// error code carried by err.
+func NewError(err error) *CodedError {
+	code := dagerrors.CodeOf(err)
+	status := code.Category().HTTPStatus()
+	return NewCodedError(status, NewAPIError(http.StatusText(status), err.Error(), code))
+}
+
 func NewInternalError(err error) *CodedError {
-	return NewCodedError(500, NewAPIError("Internal Server Error", err.Error()))
+	return newErrorWithCategory(dagerrors.CodeInternal, err)
 }
 
 func NewNotFoundError(err error) *CodedError {
-	return NewCodedError(404, NewAPIError("Not Found", err.Error()))
+	return newErrorWithCategory(dagerrors.CodeNotFound, err)
 }
 
 func NewBadRequestError(err error) *CodedError {
-	return NewCodedError(400, NewAPIError("Bad Request", err.Error()))
+	return newErrorWithCategory(dagerrors.CodeInvalidArgument, err)
+}
+
+func NewConflictError(err error) *CodedError {
+	return newErrorWithCategory(dagerrors.CodeConflict, err)
+}
+
+// newErrorWithCategory keeps the code carried by err if it belongs to the
+// same category as the fallback code.
+func newErrorWithCategory(fallback dagerrors.Code, err error) *CodedError {
+	code := dagerrors.CodeOf(err)
+	if code.Category() != fallback.Category() {
+		code = fallback
+	}
+	status := code.Category().HTTPStatus()
+	return NewCodedError(status, NewAPIError(http.StatusText(status), err.Error(), code))
 }
@@ -0,0 +1,29 @@
+package response
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConflictError(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code dagerrors.Code
+	}{
+		{err: dagerrors.New(dagerrors.CodeDAGNotRunning, "the DAG is not running"), code: dagerrors.CodeDAGNotRunning},
+		{err: dagerrors.New(dagerrors.CodeAlreadyExists, "the DAG file already exists"), code: dagerrors.CodeAlreadyExists},
+		// The errors of the other categories are conflicts without their
+		// codes.
+		{err: dagerrors.New(dagerrors.CodeNotFound, "the DAG file does not exist"), code: dagerrors.CodeConflict},
+		{err: errors.New("conflict"), code: dagerrors.CodeConflict},
+	} {
+		cerr := NewConflictError(tc.err)
+		require.Equal(t, http.StatusConflict, cerr.Code)
+		require.Equal(t, string(tc.code), *cerr.APIError.Code)
+		require.Equal(t, "conflict", *cerr.APIError.Category)
+	}
+}
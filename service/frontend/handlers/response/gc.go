@@ -0,0 +1,24 @@
+package response
+
+import (
+	"github.com/dagu-dev/dagu/internal/gc"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/samber/lo"
+)
+
+func ToGCReportResponse(r *gc.Report) *models.GcReportResponse {
+	return &models.GcReportResponse{
+		StartedAt:  lo.ToPtr(utils.FormatTime(r.StartedAt)),
+		FinishedAt: lo.ToPtr(utils.FormatTime(r.FinishedAt)),
+		Removed: lo.Map(r.Removed, func(item *gc.Item, _ int) *models.GcItem {
+			return &models.GcItem{
+				Kind: item.Kind,
+				Path: item.Path,
+				Size: item.Size,
+			}
+		}),
+		Size:   lo.ToPtr(r.Size()),
+		Errors: r.Errors,
+	}
+}
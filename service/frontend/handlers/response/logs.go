@@ -38,11 +38,14 @@ func ToDagLogResponse(logs []*domain.StatusFile) *models.DagLogResponse {
 		if l.Status.OnCancel != nil {
 			addStatusGridItem(hookStatusByName, len(logs), i, l.Status.OnCancel)
 		}
+		if l.Status.OnTimeout != nil {
+			addStatusGridItem(hookStatusByName, len(logs), i, l.Status.OnTimeout)
+		}
 		if l.Status.OnExit != nil {
 			addStatusGridItem(hookStatusByName, len(logs), i, l.Status.OnExit)
 		}
 	}
-	for _, k := range []string{constants.OnSuccess, constants.OnFailure, constants.OnCancel, constants.OnExit} {
+	for _, k := range []string{constants.OnSuccess, constants.OnFailure, constants.OnCancel, constants.OnTimeout, constants.OnExit} {
 		if v, ok := hookStatusByName[k]; ok {
 			grid = append(grid, ToDagLogGridItem(k, v))
 		}
@@ -83,17 +86,30 @@ func ToDagLogGridItem(name string, vals []scheduler.NodeStatus) *models.DagLogGr
 	}
 }
 
-func ToDagStepLogResponse(logFile, content string, step *domain.Node) *models.DagStepLogResponse {
+// LogSource is where a log that is not on the local disk was read from.
+type LogSource struct {
+	// ArchivedFrom is the location of the archive of the run.
+	ArchivedFrom string
+	// RemoteLocation is the location of the logs of the run in the log
+	// backend.
+	RemoteLocation string
+}
+
+func ToDagStepLogResponse(logFile, content string, src LogSource, step *domain.Node) *models.DagStepLogResponse {
 	return &models.DagStepLogResponse{
-		LogFile: lo.ToPtr(logFile),
-		Step:    ToNode(step),
-		Content: lo.ToPtr(content),
+		LogFile:        lo.ToPtr(logFile),
+		Step:           ToNode(step),
+		Content:        lo.ToPtr(content),
+		ArchivedFrom:   src.ArchivedFrom,
+		RemoteLocation: src.RemoteLocation,
 	}
 }
 
-func ToDagSchedulerLogResponse(logFile, content string) *models.DagSchedulerLogResponse {
+func ToDagSchedulerLogResponse(logFile, content string, src LogSource) *models.DagSchedulerLogResponse {
 	return &models.DagSchedulerLogResponse{
-		LogFile: lo.ToPtr(logFile),
-		Content: lo.ToPtr(content),
+		LogFile:        lo.ToPtr(logFile),
+		Content:        lo.ToPtr(content),
+		ArchivedFrom:   src.ArchivedFrom,
+		RemoteLocation: src.RemoteLocation,
 	}
 }
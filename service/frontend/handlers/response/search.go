@@ -2,30 +2,43 @@ package response
 
 import (
 	"github.com/dagu-dev/dagu/internal/grep"
-	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/search"
 	"github.com/dagu-dev/dagu/service/frontend/models"
 	"github.com/samber/lo"
 )
 
-func ToSearchDAGsResponse(ret []*persistence.GrepResult, errs []string) *models.SearchDagsResponse {
+func ToSearchDAGsResponse(ret []*search.Result, errs []string) *models.SearchDagsResponse {
 	return &models.SearchDagsResponse{
-		Results: lo.Map(ret, func(item *persistence.GrepResult, _ int) *models.SearchDagsResultItem {
+		Results: lo.Map(ret, func(item *search.Result, _ int) *models.SearchDagsResultItem {
 			return ToSearchDAGsResultItem(item)
 		}),
 		Errors: errs,
 	}
 }
 
-func ToSearchDAGsResultItem(workflow *persistence.GrepResult) *models.SearchDagsResultItem {
+func ToSearchDAGsResultItem(workflow *search.Result) *models.SearchDagsResultItem {
 	return &models.SearchDagsResultItem{
-		Name: workflow.Name,
-		DAG:  ToDAG(workflow.DAG),
-		Matches: lo.Map(workflow.Matches, func(item *grep.Match, _ int) *models.SearchDagsMatchItem {
-			return ToSearchDAGsMatchItem(item)
+		Name:    workflow.Name,
+		DAG:     ToDAG(workflow.DAG),
+		Fields:  workflow.Fields,
+		Score:   int64(workflow.Score),
+		Matches: toSearchDAGsMatchItems(workflow.Matches),
+		Logs: lo.Map(workflow.Logs, func(item *search.LogResult, _ int) *models.SearchDagsLogItem {
+			return &models.SearchDagsLogItem{
+				RequestID: item.RequestId,
+				Step:      item.Step,
+				Matches:   toSearchDAGsMatchItems(item.Matches),
+			}
 		}),
 	}
 }
 
+func toSearchDAGsMatchItems(matches []*grep.Match) []*models.SearchDagsMatchItem {
+	return lo.Map(matches, func(item *grep.Match, _ int) *models.SearchDagsMatchItem {
+		return ToSearchDAGsMatchItem(item)
+	})
+}
+
 func ToSearchDAGsMatchItem(match *grep.Match) *models.SearchDagsMatchItem {
 	return &models.SearchDagsMatchItem{
 		Line:       match.Line,
@@ -22,6 +22,8 @@ func ToStepObject(step dag.Step) *models.StepObject {
 		}),
 		RepeatPolicy: ToRepeatPolicy(step.RepeatPolicy),
 		Script:       lo.ToPtr(step.Script),
+		Stage:        step.Stage,
+		Group:        step.Group,
 		Variables:    step.Variables,
 	}
 	if step.SubWorkflow != nil {
@@ -0,0 +1,39 @@
+package response
+
+import (
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/samber/lo"
+)
+
+func ToAPIToken(t *model.APIToken) *models.APIToken {
+	expiresAt := ""
+	if !t.ExpiresAt.IsZero() {
+		expiresAt = utils.FormatTime(t.ExpiresAt)
+	}
+	return &models.APIToken{
+		Name:      lo.ToPtr(t.Name),
+		Scopes:    t.Scopes,
+		CreatedAt: lo.ToPtr(utils.FormatTime(t.CreatedAt)),
+		ExpiresAt: lo.ToPtr(expiresAt),
+		Expired:   lo.ToPtr(t.Expired(time.Now())),
+	}
+}
+
+func ToListAPITokensResponse(tokens []*model.APIToken) *models.ListAPITokensResponse {
+	return &models.ListAPITokensResponse{
+		Tokens: lo.Map(tokens, func(t *model.APIToken, _ int) *models.APIToken {
+			return ToAPIToken(t)
+		}),
+	}
+}
+
+func ToCreateAPITokenResponse(t *model.APIToken, secret string) *models.CreateAPITokenResponse {
+	return &models.CreateAPITokenResponse{
+		Token:  ToAPIToken(t),
+		Secret: lo.ToPtr(secret),
+	}
+}
@@ -0,0 +1,33 @@
+package response
+
+import (
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/samber/lo"
+)
+
+func ToTrigger(t *model.Trigger) *models.Trigger {
+	ret := &models.Trigger{
+		ID:         lo.ToPtr(t.ID),
+		DAG:        lo.ToPtr(t.DAG),
+		RequestID:  lo.ToPtr(t.RequestId),
+		Params:     t.Params,
+		ReceivedAt: lo.ToPtr(utils.FormatTime(t.ReceivedAt)),
+		Attempts:   lo.ToPtr(int64(t.Attempts)),
+		Error:      t.Error,
+	}
+	if t.Initiator != nil {
+		ret.InitiatedBy = t.Initiator.User
+		ret.InitiatorToken = t.Initiator.Token
+	}
+	return ret
+}
+
+func ToListTriggersResponse(triggers []*model.Trigger) *models.ListTriggersResponse {
+	return &models.ListTriggersResponse{
+		Triggers: lo.Map(triggers, func(t *model.Trigger, _ int) *models.Trigger {
+			return ToTrigger(t)
+		}),
+	}
+}
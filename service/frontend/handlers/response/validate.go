@@ -0,0 +1,27 @@
+package response
+
+import (
+	"github.com/dagu-dev/dagu/internal/dag"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/samber/lo"
+)
+
+func ToValidateDAGResponse(problems []dag.Problem) *models.ValidateDagResponse {
+	return &models.ValidateDagResponse{
+		Valid: lo.ToPtr(!lo.ContainsBy(problems, func(p dag.Problem) bool {
+			return p.Level == dag.LevelError
+		})),
+		Problems: lo.Map(problems, func(p dag.Problem, _ int) *models.DagProblem {
+			return ToDAGProblem(p)
+		}),
+	}
+}
+
+func ToDAGProblem(p dag.Problem) *models.DagProblem {
+	return &models.DagProblem{
+		Line:    lo.ToPtr(int64(p.Line)),
+		Field:   lo.ToPtr(p.Field),
+		Level:   lo.ToPtr(p.Level),
+		Message: lo.ToPtr(p.Message),
+	}
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/service/frontend/handlers/response"
+	pkgmiddleware "github.com/dagu-dev/dagu/service/frontend/middleware"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+	"github.com/dagu-dev/dagu/service/frontend/server"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/samber/lo"
+)
+
+type TokenHandler struct {
+	tokenStore persistence.TokenStore
+}
+
+func NewToken(ds persistence.DataStoreFactory) server.New {
+	return &TokenHandler{
+		tokenStore: ds.NewTokenStore(),
+	}
+}
+
+func (h *TokenHandler) Configure(api *operations.DaguAPI) {
+	api.ListAPITokensHandler = operations.ListAPITokensHandlerFunc(
+		func(params operations.ListAPITokensParams) middleware.Responder {
+			resp, err := h.List()
+			if err != nil {
+				return operations.NewListAPITokensDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewListAPITokensOK().WithPayload(resp)
+		})
+
+	api.CreateAPITokenHandler = operations.CreateAPITokenHandlerFunc(
+		func(params operations.CreateAPITokenParams) middleware.Responder {
+			resp, err := h.Create(params)
+			if err != nil {
+				return operations.NewCreateAPITokenDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewCreateAPITokenOK().WithPayload(resp)
+		})
+
+	api.DeleteAPITokenHandler = operations.DeleteAPITokenHandlerFunc(
+		func(params operations.DeleteAPITokenParams) middleware.Responder {
+			if err := h.Delete(params); err != nil {
+				return operations.NewDeleteAPITokenDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewDeleteAPITokenOK()
+		})
+}
+
+func (h *TokenHandler) List() (*models.ListAPITokensResponse, *response.CodedError) {
+	tokens, err := h.tokenStore.List()
+	if err != nil {
+		return nil, response.NewInternalError(err)
+	}
+	return response.ToListAPITokensResponse(tokens), nil
+}
+
+func (h *TokenHandler) Create(params operations.CreateAPITokenParams) (*models.CreateAPITokenResponse, *response.CodedError) {
+	body := params.Body
+	expiresIn := time.Duration(body.ExpiresIn) * time.Second
+	token, secret, err := pkgmiddleware.CreateToken(h.tokenStore, lo.FromPtr(body.Name), body.Scopes, expiresIn)
+	if err != nil {
+		return nil, response.NewError(err)
+	}
+	return response.ToCreateAPITokenResponse(token, secret), nil
+}
+
+func (h *TokenHandler) Delete(params operations.DeleteAPITokenParams) *response.CodedError {
+	err := h.tokenStore.Delete(params.TokenName)
+	if errors.Is(err, persistence.ErrTokenNotFound) {
+		return response.NewNotFoundError(err)
+	}
+	if err != nil {
+		return response.NewInternalError(err)
+	}
+	return nil
+}
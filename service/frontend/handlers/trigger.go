@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"github.com/dagu-dev/dagu/internal/engine"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/service/frontend/handlers/response"
+	pkgmiddleware "github.com/dagu-dev/dagu/service/frontend/middleware"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+	"github.com/dagu-dev/dagu/service/frontend/server"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/samber/lo"
+)
+
+var errNoTriggerQueue = dagerrors.New(dagerrors.CodeNotFound, "the trigger queue is not configured")
+
+type TriggerHandler struct {
+	namespaces    map[string]*TriggerHandler
+	engineFactory engine.Factory
+
+	// triggerQueue is nil if the trigger queue is not configured.
+	triggerQueue persistence.TriggerQueue
+}
+
+func NewTrigger(namespaces Namespaces) server.New {
+	return &TriggerHandler{
+		namespaces: byNamespace(namespaces, func(ns *Namespace) *TriggerHandler {
+			return &TriggerHandler{
+				engineFactory: ns.EngineFactory,
+				triggerQueue:  ns.DataStore.NewTriggerQueue(),
+			}
+		}),
+	}
+}
+
+func (h *TriggerHandler) Configure(api *operations.DaguAPI) {
+	api.ListTriggersHandler = operations.ListTriggersHandlerFunc(
+		func(params operations.ListTriggersParams) middleware.Responder {
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).List(params)
+			if err != nil {
+				return operations.NewListTriggersDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewListTriggersOK().WithPayload(resp)
+		})
+}
+
+// List returns the triggers of the DAGs the access rules of the request
+// allow the viewer role on.
+func (h *TriggerHandler) List(params operations.ListTriggersParams) (*models.ListTriggersResponse, *response.CodedError) {
+	if h.triggerQueue == nil {
+		return nil, response.NewError(errNoTriggerQueue)
+	}
+	triggers, err := h.triggerQueue.List()
+	if err != nil {
+		return nil, response.NewInternalError(err)
+	}
+	if access := accessOf(params.HTTPRequest); access != nil {
+		e := h.engineFactory.Create()
+		triggers = lo.Filter(triggers, func(t *model.Trigger, _ int) bool {
+			return access.AllowsName(t.DAG, tagsOf(e.GetStatus(t.DAG)), pkgmiddleware.RoleViewer)
+		})
+	}
+	return response.ToListTriggersResponse(triggers), nil
+}
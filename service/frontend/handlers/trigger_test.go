@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/engine"
+	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	"github.com/dagu-dev/dagu/internal/utils"
+	pkgmiddleware "github.com/dagu-dev/dagu/service/frontend/middleware"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTriggers(t *testing.T) {
+	tmpDir := utils.MustTempDir("dagu_test")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	cfg := &config.Config{
+		DataDir:      path.Join(tmpDir, "data"),
+		DAGs:         path.Join(tmpDir, "dags"),
+		TriggerQueue: &config.TriggerQueue{Type: "sqlite"},
+	}
+	ds := client.NewDataStoreFactory(cfg)
+	for _, name := range []string{"sales-etl", "hr-payroll"} {
+		_, err := ds.NewDAGStore().Create(name, []byte("steps:\n  - name: step1\n    command: echo 1\n"))
+		require.NoError(t, err)
+	}
+	q := ds.NewTriggerQueue()
+	require.NoError(t, q.Enqueue(&model.Trigger{DAG: "sales-etl", RequestId: "1"}))
+	require.NoError(t, q.Enqueue(&model.Trigger{DAG: "hr-payroll", RequestId: "2"}))
+	h := &TriggerHandler{engineFactory: engine.NewFactory(ds, cfg), triggerQueue: q}
+
+	r, err := http.NewRequest("GET", "/api/v1/triggers", nil)
+	require.NoError(t, err)
+	resp, cerr := h.List(operations.ListTriggersParams{HTTPRequest: r})
+	require.Nil(t, cerr)
+	require.Len(t, resp.Triggers, 2)
+
+	// The triggers of the DAGs the rules do not allow are not listed.
+	r = r.WithContext(pkgmiddleware.WithAccess(r.Context(), []pkgmiddleware.AccessRule{
+		{User: "alice", Role: pkgmiddleware.RoleViewer, Prefixes: []string{"sales-"}},
+	}))
+	resp, cerr = h.List(operations.ListTriggersParams{HTTPRequest: r})
+	require.Nil(t, cerr)
+	require.Len(t, resp.Triggers, 1)
+	require.Equal(t, "sales-etl", *resp.Triggers[0].DAG)
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/dagu-dev/dagu/internal/dag"
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/service/frontend/handlers/response"
+	"github.com/dagu-dev/dagu/service/frontend/models"
+	"github.com/dagu-dev/dagu/service/frontend/restapi/operations"
+	"github.com/dagu-dev/dagu/service/frontend/server"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// maxDefinitionSize is the size of the largest definition that is
+// validated.
+const maxDefinitionSize = 1 << 20
+
+var errDefinitionTooLarge = dagerrors.New(dagerrors.CodeInvalidArgument, "the definition is too large")
+
+type ValidateHandler struct {
+	namespaces map[string]*ValidateHandler
+
+	dagsDir string
+}
+
+func NewValidate(namespaces Namespaces) server.New {
+	return &ValidateHandler{
+		namespaces: byNamespace(namespaces, func(ns *Namespace) *ValidateHandler {
+			return &ValidateHandler{
+				dagsDir: ns.Config.DAGs,
+			}
+		}),
+	}
+}
+
+func (h *ValidateHandler) Configure(api *operations.DaguAPI) {
+	api.ValidateDagHandler = operations.ValidateDagHandlerFunc(
+		func(params operations.ValidateDagParams) middleware.Responder {
+			resp, err := ofRequest(h.namespaces, params.HTTPRequest).Validate(params)
+			if err != nil {
+				return operations.NewValidateDagDefault(err.Code).WithPayload(err.APIError)
+			}
+			return operations.NewValidateDagOK().WithPayload(resp)
+		})
+}
+
+// Validate validates the definition as if it were the file of the DAG in
+// the DAGs directory, without saving it.
+func (h *ValidateHandler) Validate(params operations.ValidateDagParams) (*models.ValidateDagResponse, *response.CodedError) {
+	def := *params.Body.Definition
+	if len(def) > maxDefinitionSize {
+		return nil, response.NewBadRequestError(fmt.Errorf("%w: %d bytes", errDefinitionTooLarge, len(def)))
+	}
+	name := "untitled"
+	if id := filepath.Base(params.Body.DagID); params.Body.DagID != "" {
+		name = strings.TrimSuffix(id, filepath.Ext(id))
+	}
+	problems := dag.ValidateData([]byte(def), filepath.Join(h.dagsDir, name+".yaml"))
+	return response.ToValidateDAGResponse(problems), nil
+}
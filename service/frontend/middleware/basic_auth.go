@@ -11,7 +11,7 @@ func BasicAuth(realm string, creds map[string]string) func(next http.Handler) ht
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := strings.Split(r.Header.Get("Authorization"), " ")
-			if skipBasicAuth(authHeader) {
+			if isAuthenticated(r.Context()) || skipBasicAuth(authHeader) {
 				next.ServeHTTP(w, r)
 				return
 			}
@@ -27,14 +27,14 @@ func BasicAuth(realm string, creds map[string]string) func(next http.Handler) ht
 				return
 			}
 
-			next.ServeHTTP(w, r.WithContext(withAuthenticated(r.Context())))
+			next.ServeHTTP(w, r.WithContext(withAuthenticated(r.Context(), Identity{User: user})))
 		})
 	}
 }
 
 // skipBasicAuth skips basic auth middleware when the auth token is set
 func skipBasicAuth(authHeader []string) bool {
-	return authToken != nil &&
+	return tokenAuthEnabled() &&
 		len(authHeader) >= 2 &&
 		authHeader[0] == "Bearer"
 }
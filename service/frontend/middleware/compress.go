@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"compress/flate"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/klauspost/compress/zstd"
+)
+
+// compressibleTypes are the content types of the responses that are
+// compressed: the ones of the API, the log stream included, and of the
+// assets of the UI.
+var compressibleTypes = []string{
+	"application/json",
+	"application/javascript",
+	"text/html",
+	"text/css",
+	"text/plain",
+	"text/javascript",
+	"text/event-stream",
+	"image/svg+xml",
+}
+
+// compress compresses the responses with zstd or gzip, the one the client
+// accepts, zstd first. The streamed responses are compressed as they are
+// flushed.
+func compress(next http.Handler) http.Handler {
+	c := middleware.NewCompressor(flate.DefaultCompression, compressibleTypes...)
+	c.SetEncoder("zstd", encoderZstd)
+	return c.Handler(next)
+}
+
+func encoderZstd(w io.Writer, _ int) io.Writer {
+	// The encoders are pooled, each one with a single goroutine.
+	zw, err := zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil
+	}
+	return zw
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompress(t *testing.T) {
+	body := strings.Repeat(`{"Name":"etl","Status":"finished"}`, 100)
+	server := httptest.NewServer(compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, body)
+	})))
+	defer server.Close()
+
+	request := func(acceptEncoding string) *http.Response {
+		r, err := http.NewRequest("GET", server.URL, nil)
+		require.NoError(t, err)
+		if acceptEncoding != "" {
+			r.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		resp, err := server.Client().Transport.RoundTrip(r)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = resp.Body.Close()
+		})
+		return resp
+	}
+
+	resp := request("gzip, deflate, br, zstd")
+	require.Equal(t, "zstd", resp.Header.Get("Content-Encoding"))
+	zr, err := zstd.NewReader(resp.Body)
+	require.NoError(t, err)
+	got, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	require.Equal(t, body, string(got))
+
+	resp = request("gzip")
+	require.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+	gr, err := gzip.NewReader(resp.Body)
+	require.NoError(t, err)
+	got, err = io.ReadAll(gr)
+	require.NoError(t, err)
+	require.Equal(t, body, string(got))
+
+	resp = request("")
+	require.Equal(t, "", resp.Header.Get("Content-Encoding"))
+	got, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, body, string(got))
+}
+
+func TestCompressStream(t *testing.T) {
+	next := make(chan struct{})
+	server := httptest.NewServer(compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, line := range []string{"data: first\n", "data: second\n"} {
+			_, _ = io.WriteString(w, line)
+			w.(http.Flusher).Flush()
+			<-next
+		}
+	})))
+	defer server.Close()
+
+	r, err := http.NewRequest("GET", server.URL, nil)
+	require.NoError(t, err)
+	r.Header.Set("Accept-Encoding", "zstd")
+	resp, err := server.Client().Transport.RoundTrip(r)
+	require.NoError(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	require.Equal(t, "zstd", resp.Header.Get("Content-Encoding"))
+
+	// Each event is read before the next one is written.
+	zr, err := zstd.NewReader(resp.Body)
+	require.NoError(t, err)
+	lines := bufio.NewReader(zr)
+	for _, want := range []string{"data: first\n", "data: second\n"} {
+		line, err := lines.ReadString('\n')
+		require.NoError(t, err)
+		require.Equal(t, want, line)
+		next <- struct{}{}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/dagu-dev/dagu/internal/persistence"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
@@ -13,19 +14,30 @@ func SetupGlobalMiddleware(handler http.Handler) http.Handler {
 	next = middleware.RequestID(next)
 	next = middleware.Logger(next)
 	next = middleware.Recoverer(next)
+	next = restrict(next)
+	next = authorize(next)
+	next = impersonate(next)
 
-	if authToken != nil {
-		next = TokenAuth("restricted", authToken.Token)(next)
+	if tokenAuthEnabled() {
+		next = TokenAuth("restricted", bearerTokens())(next)
 	}
 
-	if authBasic != nil {
+	if ldapAuth != nil {
+		next = LDAPAuth("restricted", ldapAuth)(next)
+	} else if authBasic != nil {
 		next = BasicAuth(
 			"restricted",
 			map[string]string{authBasic.Username: authBasic.Password},
 		)(next)
 	}
+	next = scopeNamespace(next)
 	next = prefixChecker(next)
 
+	if oidcAuth != nil {
+		next = OIDCAuth(oidcAuth)(next)
+	}
+	next = compress(next)
+
 	return next
 }
 
@@ -33,10 +45,15 @@ type authCtxKey struct{}
 
 type authCtx struct {
 	authenticated bool
+	identity      Identity
+	scopes        []string
+	// groups are the groups of the user of an OIDC session or of the
+	// directory.
+	groups []string
 }
 
-func withAuthenticated(ctx context.Context) context.Context {
-	return context.WithValue(ctx, authCtxKey{}, &authCtx{authenticated: true})
+func withAuthenticated(ctx context.Context, identity Identity, scopes ...string) context.Context {
+	return context.WithValue(ctx, authCtxKey{}, &authCtx{authenticated: true, identity: identity, scopes: scopes})
 }
 
 func isAuthenticated(ctx context.Context) bool {
@@ -51,12 +68,33 @@ var (
 	defaultHandler http.Handler
 	authBasic      *AuthBasic
 	authToken      *AuthToken
+	apiTokens      []APIToken
+	tokenStore     persistence.TokenStore
+	accessRules    []AccessRule
+	oidcAuth       *OIDC
+	ldapAuth       *LDAP
+	namespaces     []string
 )
 
 type Options struct {
 	Handler   http.Handler
 	AuthBasic *AuthBasic
 	AuthToken *AuthToken
+	APITokens []APIToken
+	// TokenStore keeps the tokens created with the API or the CLI. They
+	// are accepted if the server authenticates the requests.
+	TokenStore persistence.TokenStore
+	// AccessRules restrict the authenticated requests to the roles of
+	// their users and tokens on the DAGs.
+	AccessRules []AccessRule
+	// OIDC is the login with an OpenID Connect provider, in addition to
+	// basic auth and the bearer tokens.
+	OIDC *OIDC
+	// LDAP authenticates the users of basic auth with a directory.
+	LDAP *LDAP
+	// Namespaces are the names of the namespaces whose API is served under
+	// /api/v1/namespaces/<name>.
+	Namespaces []string
 }
 
 type AuthBasic struct {
@@ -68,10 +106,48 @@ type AuthToken struct {
 	Token string
 }
 
+// APIToken is a named bearer token with scopes, e.g. ScopeImpersonate.
+type APIToken struct {
+	Name   string
+	Token  string
+	Scopes []string
+}
+
+// defaultTokenName is the name of AuthToken in the identities.
+const defaultTokenName = "default"
+
 func Setup(opts *Options) {
 	defaultHandler = opts.Handler
 	authBasic = opts.AuthBasic
 	authToken = opts.AuthToken
+	apiTokens = opts.APITokens
+	tokenStore = opts.TokenStore
+	accessRules = opts.AccessRules
+	oidcAuth = opts.OIDC
+	ldapAuth = opts.LDAP
+	namespaces = opts.Namespaces
+}
+
+// bearerTokens returns the tokens the API accepts.
+func bearerTokens() []APIToken {
+	tokens := apiTokens
+	if authToken != nil {
+		tokens = append([]APIToken{{Name: defaultTokenName, Token: authToken.Token}}, tokens...)
+	}
+	return tokens
+}
+
+// tokenAuthEnabled returns true if the requests are authenticated with the
+// bearer tokens, the ones of the token store too if the server authenticates
+// the requests with passwords only.
+func tokenAuthEnabled() bool {
+	return len(bearerTokens()) > 0 || (tokenStore != nil && passwordAuthEnabled())
+}
+
+// passwordAuthEnabled returns true if the requests are authenticated with
+// the credentials of basic auth.
+func passwordAuthEnabled() bool {
+	return authBasic != nil || ldapAuth != nil
 }
 
 func prefixChecker(next http.Handler) http.Handler {
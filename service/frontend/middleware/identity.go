@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"slices"
+)
+
+const (
+	// ScopeImpersonate allows a token to act on behalf of the user named
+	// in ImpersonateHeader, e.g. for a portal that triggers the runs of
+	// its users with a single token.
+	ScopeImpersonate = "impersonate"
+	// ImpersonateHeader is the header of the user a request is made on
+	// behalf of.
+	ImpersonateHeader = "X-Dagu-Impersonate-User"
+)
+
+// Identity is who made a request: the name of the API token it was made
+// with and the user it was made by, or on behalf of with a token with the
+// impersonate scope. Both are empty if the server does not authenticate
+// the requests.
+type Identity struct {
+	Token string
+	User  string
+}
+
+// IdentityFrom returns the identity of the request of the context.
+func IdentityFrom(ctx context.Context) Identity {
+	if ctx == nil {
+		return Identity{}
+	}
+	if auth, ok := ctx.Value(authCtxKey{}).(*authCtx); ok {
+		return auth.identity
+	}
+	return Identity{}
+}
+
+// WithIdentity returns the context of the requests authenticated as the
+// identity.
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return withAuthenticated(ctx, identity)
+}
+
+// impersonate sets the user of the identity of the requests with the
+// impersonate header. The requests that are not made with a token with the
+// impersonate scope are forbidden.
+func impersonate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := r.Header.Get(ImpersonateHeader)
+		if user == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		auth, ok := r.Context().Value(authCtxKey{}).(*authCtx)
+		if !ok || !slices.Contains(auth.scopes, ScopeImpersonate) {
+			http.Error(w, "the token is not allowed to impersonate users", http.StatusForbidden)
+			return
+		}
+		identity := Identity{Token: auth.identity.Token, User: user}
+		ctx := context.WithValue(r.Context(), authCtxKey{}, &authCtx{authenticated: true, identity: identity, scopes: auth.scopes})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
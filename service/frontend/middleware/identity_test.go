@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImpersonate(t *testing.T) {
+	tokens := []APIToken{
+		{Name: "ci", Token: "ci-token"},
+		{Name: "portal", Token: "portal-token", Scopes: []string{ScopeImpersonate}},
+	}
+	var got Identity
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = IdentityFrom(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := TokenAuth("restricted", tokens)(impersonate(testHandler))
+
+	testCase := []struct {
+		name       string
+		token      string
+		user       string
+		httpStatus int
+		identity   Identity
+	}{
+		{
+			name:       "token without impersonation",
+			token:      "ci-token",
+			httpStatus: http.StatusOK,
+			identity:   Identity{Token: "ci"},
+		},
+		{
+			name:       "token without the scope impersonates",
+			token:      "ci-token",
+			user:       "alice",
+			httpStatus: http.StatusForbidden,
+		},
+		{
+			name:       "token with the scope impersonates",
+			token:      "portal-token",
+			user:       "alice",
+			httpStatus: http.StatusOK,
+			identity:   Identity{Token: "portal", User: "alice"},
+		},
+		{
+			name:       "unknown token",
+			token:      "other-token",
+			user:       "alice",
+			httpStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			got = Identity{}
+			r, err := http.NewRequest("GET", "/test", nil)
+			require.NoError(t, err)
+			r.Header.Add("Authorization", "Bearer "+tc.token)
+			if tc.user != "" {
+				r.Header.Add(ImpersonateHeader, tc.user)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			require.Equal(t, tc.httpStatus, w.Result().StatusCode)
+			require.Equal(t, tc.identity, got)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/ldap"
+)
+
+const (
+	// ldapCacheTTL is how long a successful login is not checked with the
+	// directory again, so that the web UI does not bind for each request.
+	ldapCacheTTL = time.Minute
+	// maxLDAPCacheSize is the number of the logins that are cached.
+	maxLDAPCacheSize = 1024
+)
+
+// LDAPAuthenticator authenticates the users with their passwords and
+// returns their groups, e.g. *ldap.Authenticator.
+type LDAPAuthenticator interface {
+	Authenticate(ctx context.Context, username, password string) ([]string, error)
+}
+
+// LDAP is the authentication of the users with an LDAP directory, instead
+// of the user of basic auth.
+type LDAP struct {
+	Authenticator LDAPAuthenticator
+
+	mu    sync.Mutex
+	cache map[[sha256.Size]byte]ldapLogin
+}
+
+type ldapLogin struct {
+	groups    []string
+	expiresAt time.Time
+}
+
+// LDAPAuth authenticates the requests with the credentials of basic auth
+// that the directory accepts. The user of basic auth, if there is one, is
+// accepted too. The groups of the users select their access rules.
+func LDAPAuth(realm string, l *LDAP) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authHeader := strings.Split(r.Header.Get("Authorization"), " ")
+			if isAuthenticated(r.Context()) || skipBasicAuth(authHeader) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			user, pass, ok := r.BasicAuth()
+			if !ok {
+				basicAuthFailed(w, realm)
+				return
+			}
+			if authBasic != nil && user == authBasic.Username &&
+				subtle.ConstantTimeCompare([]byte(pass), []byte(authBasic.Password)) == 1 {
+				next.ServeHTTP(w, r.WithContext(withAuthenticated(r.Context(), Identity{User: user})))
+				return
+			}
+			groups, err := l.authenticate(r.Context(), user, pass)
+			switch {
+			case errors.Is(err, ldap.ErrInvalidCredentials):
+				basicAuthFailed(w, realm)
+				return
+			case errors.Is(err, ldap.ErrNotInGroup):
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			case err != nil:
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+				return
+			}
+			ctx := context.WithValue(r.Context(), authCtxKey{}, &authCtx{
+				authenticated: true,
+				identity:      Identity{User: user},
+				groups:        groups,
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func (l *LDAP) authenticate(ctx context.Context, user, pass string) ([]string, error) {
+	key := sha256.Sum256([]byte(user + "\x00" + pass))
+	now := time.Now()
+	l.mu.Lock()
+	login, ok := l.cache[key]
+	l.mu.Unlock()
+	if ok && now.Before(login.expiresAt) {
+		return login.groups, nil
+	}
+	groups, err := l.Authenticator.Authenticate(ctx, user, pass)
+	if err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.cache == nil || len(l.cache) >= maxLDAPCacheSize {
+		l.cache = map[[sha256.Size]byte]ldapLogin{}
+	}
+	l.cache[key] = ldapLogin{groups: groups, expiresAt: now.Add(ldapCacheTTL)}
+	return groups, nil
+}
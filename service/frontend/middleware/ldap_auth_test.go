@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dagu-dev/dagu/internal/ldap"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDirectory accepts alice of data-eng and bob of no group.
+type fakeDirectory struct {
+	binds int
+}
+
+func (d *fakeDirectory) Authenticate(_ context.Context, username, password string) ([]string, error) {
+	d.binds++
+	switch {
+	case username == "alice" && password == "alice-secret":
+		return []string{"data-eng"}, nil
+	case username == "bob" && password == "bob-secret":
+		return nil, fmt.Errorf("%w: bob", ldap.ErrNotInGroup)
+	}
+	return nil, ldap.ErrInvalidCredentials
+}
+
+func TestLDAPAuth(t *testing.T) {
+	directory := &fakeDirectory{}
+	authBasic = &AuthBasic{Username: "admin", Password: "admin-secret"}
+	accessRules = []AccessRule{
+		{Group: "data-eng", Role: RoleOperator, Prefixes: []string{"etl_"}},
+		{User: "admin", Role: RoleAdmin},
+	}
+	defer func() {
+		authBasic = nil
+		accessRules = nil
+	}()
+	var access *Access
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		access = AccessFrom(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := LDAPAuth("restricted", &LDAP{Authenticator: directory})(restrict(testHandler))
+
+	request := func(user, password string) int {
+		r := httptest.NewRequest("GET", "/api/v1/dags", nil)
+		if user != "" {
+			r.SetBasicAuth(user, password)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Result().StatusCode
+	}
+
+	// The groups of the directory select the access rules, and the login
+	// is cached.
+	require.Equal(t, http.StatusOK, request("alice", "alice-secret"))
+	require.True(t, access.AllowsName("etl_daily", nil, RoleOperator))
+	require.False(t, access.AllowsName("billing", nil, RoleViewer))
+	require.Equal(t, http.StatusOK, request("alice", "alice-secret"))
+	require.Equal(t, 1, directory.binds)
+
+	require.Equal(t, http.StatusUnauthorized, request("alice", "wrong"))
+	require.Equal(t, http.StatusUnauthorized, request("", ""))
+	require.Equal(t, http.StatusForbidden, request("bob", "bob-secret"))
+
+	// The user of basic auth does not need the directory.
+	binds := directory.binds
+	require.Equal(t, http.StatusOK, request("admin", "admin-secret"))
+	require.True(t, access.AllowsAll(RoleAdmin))
+	require.Equal(t, binds, directory.binds)
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// DefaultNamespace is the namespace of the requests to the API without the
+// prefix of a namespace.
+const DefaultNamespace = "default"
+
+// namespacesPath is the prefix of the paths of the API of a namespace,
+// followed by its name and the path of the API, e.g.
+// /api/v1/namespaces/staging/dags.
+const namespacesPath = "/api/v1/namespaces/"
+
+type namespaceCtxKey struct{}
+
+// NamespaceFrom returns the namespace of the request of the context.
+func NamespaceFrom(ctx context.Context) string {
+	if ctx == nil {
+		return DefaultNamespace
+	}
+	if ns, ok := ctx.Value(namespaceCtxKey{}).(string); ok {
+		return ns
+	}
+	return DefaultNamespace
+}
+
+// scopeNamespace removes the prefix of the namespace from the paths of the
+// API, so that the requests are served by the same handlers, and sets the
+// namespace of the request. The requests to unknown namespaces are not
+// found.
+func scopeNamespace(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest, ok := strings.CutPrefix(r.URL.EscapedPath(), namespacesPath)
+		if !ok {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), namespaceCtxKey{}, DefaultNamespace)))
+			return
+		}
+		ns, p, _ := strings.Cut(rest, "/")
+		if ns != DefaultNamespace && !slices.Contains(namespaces, ns) {
+			http.Error(w, "unknown namespace", http.StatusNotFound)
+			return
+		}
+		// The escaped path keeps the escaped slashes of the names of the
+		// DAGs in the rest of the path.
+		escaped := "/api/v1/" + p
+		unescaped, err := url.PathUnescape(escaped)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		r2 := r.Clone(context.WithValue(r.Context(), namespaceCtxKey{}, ns))
+		r2.URL.Path = unescaped
+		r2.URL.RawPath = escaped
+		next.ServeHTTP(w, r2)
+	})
+}
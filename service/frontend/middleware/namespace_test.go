@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScopeNamespace(t *testing.T) {
+	namespaces = []string{"staging", "prod"}
+	defer func() {
+		namespaces = nil
+	}()
+	var path, namespace string
+	handler := scopeNamespace(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		namespace = NamespaceFrom(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	request := func(p string) int {
+		path, namespace = "", ""
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", p, nil))
+		return w.Result().StatusCode
+	}
+
+	require.Equal(t, http.StatusOK, request("/api/v1/dags"))
+	require.Equal(t, "/api/v1/dags", path)
+	require.Equal(t, DefaultNamespace, namespace)
+
+	require.Equal(t, http.StatusOK, request("/api/v1/namespaces/staging/dags/etl%2Fdaily"))
+	require.Equal(t, "/api/v1/dags/etl%2Fdaily", path)
+	require.Equal(t, "staging", namespace)
+
+	require.Equal(t, http.StatusOK, request("/api/v1/namespaces/default/dags"))
+	require.Equal(t, "/api/v1/dags", path)
+	require.Equal(t, DefaultNamespace, namespace)
+
+	require.Equal(t, http.StatusNotFound, request("/api/v1/namespaces/dev/dags"))
+	require.Equal(t, "", path)
+}
+
+func TestRestrictNamespace(t *testing.T) {
+	namespaces = []string{"staging", "prod"}
+	accessRules = []AccessRule{
+		{Token: "ci", Role: RoleAdmin, Namespaces: []string{"staging"}},
+		{Token: "ci", Role: RoleViewer},
+	}
+	defer func() {
+		namespaces = nil
+		accessRules = nil
+	}()
+	var access *Access
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		access = AccessFrom(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := scopeNamespace(TokenAuth("restricted", []APIToken{{Name: "ci", Token: "ci-token"}})(restrict(testHandler)))
+
+	request := func(path string) int {
+		access = nil
+		r := httptest.NewRequest("GET", path, nil)
+		r.Header.Add("Authorization", "Bearer ci-token")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Result().StatusCode
+	}
+
+	// The rules of other namespaces do not apply.
+	require.Equal(t, http.StatusOK, request("/api/v1/namespaces/staging/dags"))
+	require.True(t, access.AllowsAll(RoleAdmin))
+	require.Equal(t, http.StatusOK, request("/api/v1/namespaces/prod/dags"))
+	require.True(t, access.AllowsAll(RoleViewer))
+	require.False(t, access.AllowsAll(RoleOperator))
+	require.Equal(t, http.StatusOK, request("/api/v1/dags"))
+	require.False(t, access.AllowsAll(RoleOperator))
+}
@@ -0,0 +1,218 @@
+package middleware
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/health"
+	"github.com/dagu-dev/dagu/internal/oidc"
+)
+
+const (
+	oidcLoginPath    = "/oidc/login"
+	oidcCallbackPath = "/oidc/callback"
+	oidcLogoutPath   = "/oidc/logout"
+
+	sessionCookie   = "dagu_session"
+	oidcStateCookie = "dagu_oidc_state"
+	// oidcStateTTL is how long a user has to log in at the provider.
+	oidcStateTTL = time.Minute * 10
+)
+
+var errInvalidCookie = errors.New("invalid cookie")
+
+// OIDC is the login with an OpenID Connect provider. The users that logged
+// in have a session cookie, signed with SessionSecret, with their names and
+// groups from the claims of their ID tokens.
+type OIDC struct {
+	Provider *oidc.Provider
+	// UsernameClaim is the claim of the name of the user. The subject is
+	// the name if the ID token does not have it.
+	UsernameClaim string
+	// GroupsClaim is the claim of the groups of the user, which the access
+	// rules with a group apply to.
+	GroupsClaim   string
+	SessionSecret []byte
+	SessionTTL    time.Duration
+}
+
+// session is the content of a session cookie.
+type session struct {
+	User      string   `json:"u"`
+	Groups    []string `json:"g,omitempty"`
+	ExpiresAt int64    `json:"e"`
+}
+
+// loginState is the content of the cookie of a login in progress.
+type loginState struct {
+	State     string `json:"s"`
+	Nonce     string `json:"n"`
+	Verifier  string `json:"v"`
+	Next      string `json:"r"`
+	ExpiresAt int64  `json:"e"`
+}
+
+// OIDCAuth authenticates the requests with the session cookies of the users
+// that logged in with the provider. The requests with the Authorization
+// header are left to basic auth and the bearer tokens, and the other ones
+// are redirected to the login if they are for the web UI, and rejected
+// otherwise.
+func OIDCAuth(o *OIDC) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case oidcLoginPath:
+				o.login(w, r)
+				return
+			case oidcCallbackPath:
+				o.callback(w, r)
+				return
+			case oidcLogoutPath:
+				o.setCookie(w, r, sessionCookie, "", "/", -1)
+				http.Redirect(w, r, "/", http.StatusFound)
+				return
+			case health.LivenessPath, health.ReadinessPath:
+				// The probes of the containers have no session.
+				next.ServeHTTP(w, r)
+				return
+			}
+			var s session
+			if err := o.readCookie(r, sessionCookie, &s); err == nil && time.Now().Unix() < s.ExpiresAt {
+				ctx := context.WithValue(r.Context(), authCtxKey{}, &authCtx{
+					authenticated: true,
+					identity:      Identity{User: s.User},
+					groups:        s.Groups,
+				})
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+			switch {
+			case r.Header.Get("Authorization") != "" && (passwordAuthEnabled() || tokenAuthEnabled()):
+				next.ServeHTTP(w, r)
+			case !strings.HasPrefix(r.URL.Path, "/api") && r.Method == http.MethodGet:
+				http.Redirect(w, r, oidcLoginPath+"?"+url.Values{"next": {r.URL.RequestURI()}}.Encode(), http.StatusFound)
+			default:
+				http.Error(w, "login required", http.StatusUnauthorized)
+			}
+		})
+	}
+}
+
+// login redirects the user to the provider, with the state of the login in
+// a cookie that the callback checks.
+func (o *OIDC) login(w http.ResponseWriter, r *http.Request) {
+	st := loginState{
+		State:     oidc.RandomValue(),
+		Nonce:     oidc.RandomValue(),
+		Verifier:  oidc.RandomValue(),
+		Next:      localPath(r.URL.Query().Get("next")),
+		ExpiresAt: time.Now().Add(oidcStateTTL).Unix(),
+	}
+	authURL, err := o.Provider.AuthCodeURL(r.Context(), st.State, st.Nonce, st.Verifier)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	if err := o.writeCookie(w, r, oidcStateCookie, st, "/oidc/", oidcStateTTL); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// callback exchanges the code of the provider for the ID token of the user,
+// and starts the session of the user.
+func (o *OIDC) callback(w http.ResponseWriter, r *http.Request) {
+	var st loginState
+	q := r.URL.Query()
+	if err := o.readCookie(r, oidcStateCookie, &st); err != nil || time.Now().Unix() >= st.ExpiresAt ||
+		!hmac.Equal([]byte(q.Get("state")), []byte(st.State)) {
+		http.Error(w, "invalid or expired login, try again", http.StatusBadRequest)
+		return
+	}
+	o.setCookie(w, r, oidcStateCookie, "", "/oidc/", -1)
+	if e := q.Get("error"); e != "" {
+		http.Error(w, "login failed: "+e+" "+q.Get("error_description"), http.StatusUnauthorized)
+		return
+	}
+	claims, err := o.Provider.Exchange(r.Context(), q.Get("code"), st.Verifier, st.Nonce)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	user := claims.String(o.UsernameClaim)
+	if user == "" {
+		user = claims.String("sub")
+	}
+	s := session{User: user, Groups: claims.Strings(o.GroupsClaim), ExpiresAt: time.Now().Add(o.SessionTTL).Unix()}
+	if err := o.writeCookie(w, r, sessionCookie, s, "/", o.SessionTTL); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, st.Next, http.StatusFound)
+}
+
+// localPath returns the path if it is a path of the server, so that the
+// login does not redirect to another site, and the root otherwise.
+func localPath(p string) string {
+	if !strings.HasPrefix(p, "/") || strings.HasPrefix(p, "//") || strings.HasPrefix(p, "/\\") {
+		return "/"
+	}
+	return p
+}
+
+// writeCookie sets the cookie to the value signed with the session secret.
+func (o *OIDC) writeCookie(w http.ResponseWriter, r *http.Request, name string, v any, path string, ttl time.Duration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(b)
+	o.setCookie(w, r, name, payload+"."+o.sign(payload), path, int(ttl.Seconds()))
+	return nil
+}
+
+// readCookie decodes the cookie into v if its signature is valid.
+func (o *OIDC) readCookie(r *http.Request, name string, v any) error {
+	c, err := r.Cookie(name)
+	if err != nil {
+		return err
+	}
+	payload, sig, ok := strings.Cut(c.Value, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(o.sign(payload))) {
+		return errInvalidCookie
+	}
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return errInvalidCookie
+	}
+	return json.Unmarshal(b, v)
+}
+
+func (o *OIDC) sign(payload string) string {
+	mac := hmac.New(sha256.New, o.SessionSecret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// setCookie sets the cookie, or removes it if maxAge is negative. It is
+// secure if the request was made with HTTPS, e.g. behind a load balancer.
+func (o *OIDC) setCookie(w http.ResponseWriter, r *http.Request, name, value, path string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCSession(t *testing.T) {
+	o := &OIDC{SessionSecret: []byte("secret"), SessionTTL: time.Hour}
+	accessRules = []AccessRule{
+		{Group: "data-eng", Role: RoleOperator, Prefixes: []string{"etl_"}},
+		{User: "alice", Role: RoleViewer},
+	}
+	defer func() {
+		accessRules = nil
+	}()
+	var identity Identity
+	var access *Access
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity = IdentityFrom(r.Context())
+		access = AccessFrom(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := OIDCAuth(o)(restrict(testHandler))
+
+	cookie := func(s session) *http.Cookie {
+		w := httptest.NewRecorder()
+		require.NoError(t, o.writeCookie(w, httptest.NewRequest("GET", "/", nil), sessionCookie, s, "/", time.Hour))
+		return w.Result().Cookies()[0]
+	}
+	request := func(path string, c *http.Cookie) *http.Response {
+		r := httptest.NewRequest("GET", path, nil)
+		if c != nil {
+			r.AddCookie(c)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Result()
+	}
+
+	// The groups of the session select the access rules.
+	bob := cookie(session{User: "bob", Groups: []string{"data-eng"}, ExpiresAt: time.Now().Add(time.Hour).Unix()})
+	require.Equal(t, http.StatusOK, request("/api/v1/dags", bob).StatusCode)
+	require.Equal(t, Identity{User: "bob"}, identity)
+	require.True(t, access.AllowsName("etl_daily", nil, RoleOperator))
+	require.False(t, access.AllowsName("billing", nil, RoleViewer))
+	carol := cookie(session{User: "carol", Groups: []string{"finance"}, ExpiresAt: time.Now().Add(time.Hour).Unix()})
+	require.Equal(t, http.StatusForbidden, request("/api/v1/dags", carol).StatusCode)
+
+	// The sessions that expired or were not signed with the secret are
+	// not accepted: the web UI redirects to the login, and the API rejects
+	// the requests.
+	expired := cookie(session{User: "alice", ExpiresAt: time.Now().Add(-time.Minute).Unix()})
+	require.Equal(t, http.StatusUnauthorized, request("/api/v1/dags", expired).StatusCode)
+	forged := cookie(session{User: "alice", ExpiresAt: time.Now().Add(time.Hour).Unix()})
+	forged.Value += "x"
+	require.Equal(t, http.StatusUnauthorized, request("/api/v1/dags", forged).StatusCode)
+	resp := request("/dags/etl_daily?tab=log", nil)
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+	require.Equal(t, "/oidc/login?next=%2Fdags%2Fetl_daily%3Ftab%3Dlog", resp.Header.Get("Location"))
+
+	// The callback requires the state of the login.
+	require.Equal(t, http.StatusBadRequest, request("/oidc/callback?code=code&state=state", nil).StatusCode)
+
+	resp = request("/oidc/logout", bob)
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+	require.Equal(t, sessionCookie, resp.Cookies()[0].Name)
+	require.Negative(t, resp.Cookies()[0].MaxAge)
+}
+
+func TestLocalPath(t *testing.T) {
+	require.Equal(t, "/dags/etl?tab=log", localPath("/dags/etl?tab=log"))
+	require.Equal(t, "/", localPath(""))
+	require.Equal(t, "/", localPath("https://evil.example.com"))
+	require.Equal(t, "/", localPath("//evil.example.com"))
+	require.Equal(t, "/", localPath("/\\evil.example.com"))
+}
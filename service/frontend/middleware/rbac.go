@@ -0,0 +1,209 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// The roles of the access rules, each of which allows what the previous one
+// does: a viewer reads the DAGs and their runs, an operator runs them, and
+// an admin edits, renames and deletes them too.
+const (
+	RoleViewer   = "viewer"
+	RoleOperator = "operator"
+	RoleAdmin    = "admin"
+)
+
+var roleLevels = map[string]int{RoleViewer: 1, RoleOperator: 2, RoleAdmin: 3}
+
+// globalPaths are the paths of the endpoints about all the DAGs, which
+// require a rule without prefixes and tags.
+var globalPaths = []string{"/api/v1/gc", "/api/v1/drift"}
+
+var (
+	errUnknownRole   = errors.New("unknown role")
+	errNoRuleSubject = errors.New("an access rule must have one of user, group and token")
+)
+
+// AccessRule grants a user, the users of an OIDC or LDAP group or a token a
+// role on the DAGs whose IDs start with one of the prefixes or that have one
+// of the tags, or on all the DAGs if it has neither. The ID of a DAG is the
+// path of its file in the DAGs directory without the extension, e.g.
+// team-a/etl, so a prefix can be a folder.
+type AccessRule struct {
+	User     string
+	Group    string
+	Token    string
+	Role     string
+	Prefixes []string
+	Tags     []string
+
+	// Namespaces are the namespaces the rule applies to, all of them if it
+	// is empty.
+	Namespaces []string
+}
+
+// ValidateAccessRules returns an error if a rule has an unknown role, or
+// not exactly one of a user, a group and a token.
+func ValidateAccessRules(rules []AccessRule) error {
+	for i, r := range rules {
+		if _, ok := roleLevels[r.Role]; !ok {
+			return fmt.Errorf("access rule %d: %w: %s", i, errUnknownRole, r.Role)
+		}
+		n := 0
+		for _, s := range []string{r.User, r.Group, r.Token} {
+			if s != "" {
+				n++
+			}
+		}
+		if n != 1 {
+			return fmt.Errorf("access rule %d: %w", i, errNoRuleSubject)
+		}
+	}
+	return nil
+}
+
+// Access is what the access rules of the identity of a request allow.
+type Access struct {
+	rules []AccessRule
+	// everywhere are the rules of the identity in all the namespaces.
+	everywhere []AccessRule
+}
+
+type accessCtxKey struct{}
+
+// AccessFrom returns the access of the request of the context. It is nil if
+// the requests are not restricted, i.e. there are no access rules or the
+// server does not authenticate the requests.
+func AccessFrom(ctx context.Context) *Access {
+	if ctx == nil {
+		return nil
+	}
+	access, _ := ctx.Value(accessCtxKey{}).(*Access)
+	return access
+}
+
+// WithAccess returns the context of the requests restricted to what the
+// access rules allow.
+func WithAccess(ctx context.Context, rules []AccessRule) context.Context {
+	return context.WithValue(ctx, accessCtxKey{}, &Access{rules: rules, everywhere: rules})
+}
+
+// AllowsName returns true if the access allows the role on the DAG of the
+// ID with the tags. A nil access allows everything.
+func (a *Access) AllowsName(id string, tags []string, role string) bool {
+	if a == nil {
+		return true
+	}
+	return slices.ContainsFunc(a.rules, func(r AccessRule) bool {
+		return roleLevels[r.Role] >= roleLevels[role] && r.matches(id, tags)
+	})
+}
+
+// AllowsAll returns true if the access allows the role on all the DAGs.
+func (a *Access) AllowsAll(role string) bool {
+	if a == nil {
+		return true
+	}
+	return slices.ContainsFunc(a.rules, func(r AccessRule) bool {
+		return roleLevels[r.Role] >= roleLevels[role] && len(r.Prefixes) == 0 && len(r.Tags) == 0
+	})
+}
+
+// AllowsAllIn returns true if the access allows the role on all the DAGs of
+// each of the namespaces, e.g. to stop all of them.
+func (a *Access) AllowsAllIn(namespaces []string, role string) bool {
+	if a == nil {
+		return true
+	}
+	for _, ns := range namespaces {
+		if !slices.ContainsFunc(a.everywhere, func(r AccessRule) bool {
+			return roleLevels[r.Role] >= roleLevels[role] && len(r.Prefixes) == 0 && len(r.Tags) == 0 &&
+				(len(r.Namespaces) == 0 || slices.Contains(r.Namespaces, ns))
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r AccessRule) matches(id string, tags []string) bool {
+	if len(r.Prefixes) == 0 && len(r.Tags) == 0 {
+		return true
+	}
+	for _, p := range r.Prefixes {
+		if strings.HasPrefix(id, p) {
+			return true
+		}
+	}
+	for _, t := range r.Tags {
+		if slices.Contains(tags, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// rulesOf returns the access rules of the request that apply to the
+// namespace.
+func rulesOf(auth *authCtx, namespace string) []AccessRule {
+	var rules []AccessRule
+	for _, r := range identityRules(auth) {
+		if len(r.Namespaces) > 0 && !slices.Contains(r.Namespaces, namespace) {
+			continue
+		}
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+// identityRules returns the access rules of the identity of the request.
+// The ones of the user and of the groups of the user apply if the request is
+// made by or on behalf of a user, and the ones of the token otherwise.
+func identityRules(auth *authCtx) []AccessRule {
+	identity := auth.identity
+	var rules []AccessRule
+	for _, r := range accessRules {
+		if identity.User != "" && r.User == identity.User ||
+			identity.User != "" && r.Group != "" && slices.Contains(auth.groups, r.Group) ||
+			identity.User == "" && r.Token != "" && r.Token == identity.Token {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// restrict sets the access of the authenticated requests if there are
+// access rules. The requests of the identities without a rule are
+// forbidden, and so are the ones to the endpoints about all the DAGs that
+// the rules do not allow. The handlers check the access on each DAG.
+func restrict(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, ok := r.Context().Value(authCtxKey{}).(*authCtx)
+		if len(accessRules) == 0 || !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		rules := rulesOf(auth, NamespaceFrom(r.Context()))
+		if len(rules) == 0 || !(&Access{rules: rules}).allowsPath(r.URL.Path) {
+			http.Error(w, "the access rules do not allow the request", http.StatusForbidden)
+			return
+		}
+		access := &Access{rules: rules, everywhere: identityRules(auth)}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), accessCtxKey{}, access)))
+	})
+}
+
+func (a *Access) allowsPath(path string) bool {
+	switch {
+	case strings.HasPrefix(path, tokensPath), path == auditPath:
+		return a.AllowsAll(RoleAdmin)
+	case slices.ContainsFunc(globalPaths, func(p string) bool { return strings.HasPrefix(path, p) }):
+		return a.AllowsAll(RoleViewer)
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAccessRules(t *testing.T) {
+	require.NoError(t, ValidateAccessRules([]AccessRule{
+		{User: "alice", Role: RoleViewer},
+		{Token: "ci", Role: RoleOperator, Prefixes: []string{"etl_"}},
+		{Group: "data-eng", Role: RoleAdmin},
+	}))
+	require.ErrorIs(t, ValidateAccessRules([]AccessRule{{User: "alice", Role: "owner"}}), errUnknownRole)
+	require.ErrorIs(t, ValidateAccessRules([]AccessRule{{Role: RoleAdmin}}), errNoRuleSubject)
+	require.ErrorIs(t, ValidateAccessRules([]AccessRule{{User: "alice", Token: "ci", Role: RoleAdmin}}), errNoRuleSubject)
+	require.ErrorIs(t, ValidateAccessRules([]AccessRule{{User: "alice", Group: "data-eng", Role: RoleAdmin}}), errNoRuleSubject)
+}
+
+func TestRestrict(t *testing.T) {
+	accessRules = []AccessRule{
+		{User: "alice", Role: RoleOperator, Prefixes: []string{"etl_", "team-a/"}},
+		{User: "alice", Role: RoleViewer, Tags: []string{"finance"}},
+		{Token: "ci", Role: RoleAdmin},
+		{Token: "portal", Role: RoleViewer, Prefixes: []string{"report_"}},
+	}
+	defer func() {
+		accessRules = nil
+	}()
+	tokens := []APIToken{
+		{Name: "ci", Token: "ci-token"},
+		{Name: "portal", Token: "portal-token", Scopes: []string{ScopeImpersonate}},
+		{Name: "other", Token: "other-token"},
+	}
+	var access *Access
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		access = AccessFrom(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := TokenAuth("restricted", tokens)(impersonate(restrict(testHandler)))
+
+	request := func(token, user, path string) int {
+		access = nil
+		r, err := http.NewRequest("GET", path, nil)
+		require.NoError(t, err)
+		r.Header.Add("Authorization", "Bearer "+token)
+		if user != "" {
+			r.Header.Add(ImpersonateHeader, user)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Result().StatusCode
+	}
+
+	// A token without a rule is forbidden once there are rules.
+	require.Equal(t, http.StatusForbidden, request("other-token", "", "/api/v1/dags"))
+
+	// The rules of the user apply to the requests on behalf of the user.
+	require.Equal(t, http.StatusOK, request("portal-token", "alice", "/api/v1/dags"))
+	require.True(t, access.AllowsName("etl_daily", nil, RoleOperator))
+	require.False(t, access.AllowsName("etl_daily", nil, RoleAdmin))
+	finance := []string{"finance"}
+	require.True(t, access.AllowsName("billing", finance, RoleViewer))
+	require.False(t, access.AllowsName("billing", finance, RoleOperator))
+	require.False(t, access.AllowsName("report_daily", nil, RoleViewer))
+	// The prefixes match the IDs of the DAGs in the folders, not the names
+	// of their files.
+	require.True(t, access.AllowsName("team-a/etl", nil, RoleOperator))
+	require.True(t, access.AllowsName("team-a/reports/daily", nil, RoleOperator))
+	require.False(t, access.AllowsName("team-b/etl_daily", nil, RoleViewer))
+	require.Equal(t, http.StatusForbidden, request("portal-token", "alice", "/api/v1/drift"))
+	require.Equal(t, http.StatusForbidden, request("portal-token", "alice", "/api/v1/audit"))
+	require.Equal(t, http.StatusForbidden, request("portal-token", "bob", "/api/v1/dags"))
+
+	// The rules of the token apply to its own requests.
+	require.Equal(t, http.StatusOK, request("portal-token", "", "/api/v1/dags"))
+	require.True(t, access.AllowsName("report_daily", nil, RoleViewer))
+	require.False(t, access.AllowsName("etl_daily", nil, RoleViewer))
+
+	// A rule without prefixes and tags allows all the DAGs and endpoints.
+	require.Equal(t, http.StatusOK, request("ci-token", "", "/api/v1/tokens"))
+	require.Equal(t, http.StatusOK, request("ci-token", "", "/api/v1/audit"))
+	require.True(t, access.AllowsAll(RoleAdmin))
+	require.True(t, access.AllowsName("billing", finance, RoleAdmin))
+}
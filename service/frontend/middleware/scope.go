@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	// ScopeRead allows a token to read, i.e. to make the GET requests only.
+	ScopeRead = "read"
+	// ScopeTrigger allows a token to read, and to start and retry the runs
+	// of the DAGs.
+	ScopeTrigger = "trigger"
+	// ScopeAdmin allows a token to make all the requests, the ones that
+	// manage the API tokens too.
+	ScopeAdmin = "admin"
+)
+
+// accessScopes are the scopes that limit the requests of a token. The
+// tokens without any of them, e.g. the ones of the configuration that were
+// defined before the scopes, are allowed to make all the requests.
+var accessScopes = []string{ScopeRead, ScopeTrigger, ScopeAdmin}
+
+// tokensPath is the path of the endpoints that manage the API tokens.
+const tokensPath = "/api/v1/tokens"
+
+// auditPath is the path of the audit log, which is about all the DAGs and
+// the users.
+const auditPath = "/api/v1/audit"
+
+// validatePath is the path of the validation of a definition.
+const validatePath = "/api/v1/validate"
+
+// bulkPath is the path of the actions on several DAGs.
+const bulkPath = "/api/v1/bulk"
+
+// maxActionBodySize is the size of the body of a request that is read to
+// find the action on a DAG.
+const maxActionBodySize = 1 << 20
+
+var (
+	errUnknownScope  = errors.New("unknown scope")
+	errNoAccessScope = errors.New("a token requires the read, trigger or admin scope")
+
+	dagActionPath = regexp.MustCompile(`^/api/v1/dags/[^/]+$`)
+	// triggerActions are the actions on a DAG, or on several DAGs, of the
+	// trigger scope.
+	triggerActions = []string{"start", "retry"}
+)
+
+// ValidateScopes returns an error if a scope is unknown, or none of the
+// scopes is read, trigger or admin.
+func ValidateScopes(scopes []string) error {
+	for _, s := range scopes {
+		if !slices.Contains(accessScopes, s) && s != ScopeImpersonate {
+			return fmt.Errorf("%w: %s", errUnknownScope, s)
+		}
+	}
+	if !slices.ContainsFunc(scopes, isAccessScope) {
+		return errNoAccessScope
+	}
+	return nil
+}
+
+func isAccessScope(scope string) bool {
+	return slices.Contains(accessScopes, scope)
+}
+
+// authorize forbids the requests that the scopes of their token do not
+// allow.
+func authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, ok := r.Context().Value(authCtxKey{}).(*authCtx)
+		if !ok || allowed(r, auth.scopes) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		http.Error(w, "the token is not allowed to make the request", http.StatusForbidden)
+	})
+}
+
+// allowed returns true if the scopes allow the request.
+func allowed(r *http.Request, scopes []string) bool {
+	switch {
+	case slices.Contains(scopes, ScopeAdmin), !slices.ContainsFunc(scopes, isAccessScope):
+		return true
+	case strings.HasPrefix(r.URL.Path, tokensPath), r.URL.Path == auditPath:
+		return false
+	// The validation of a definition does not change anything.
+	case r.Method == http.MethodGet, r.Method == http.MethodHead, r.URL.Path == validatePath:
+		return true
+	case slices.Contains(scopes, ScopeTrigger):
+		return isTrigger(r)
+	}
+	return false
+}
+
+// isTrigger returns true if the request starts or retries the runs of a
+// DAG, or of several DAGs. The body of the request is read, and restored for
+// the handler.
+func isTrigger(r *http.Request) bool {
+	isAction := dagActionPath.MatchString(r.URL.Path) || r.URL.Path == bulkPath
+	if r.Method != http.MethodPost || !isAction || r.Body == nil {
+		return false
+	}
+	body := r.Body
+	b, err := io.ReadAll(io.LimitReader(body, maxActionBodySize))
+	r.Body = readCloser{io.MultiReader(bytes.NewReader(b), body), body}
+	if err != nil {
+		return false
+	}
+	var action struct {
+		Action string `json:"action"`
+	}
+	if err := json.Unmarshal(b, &action); err != nil {
+		return false
+	}
+	return slices.Contains(triggerActions, action.Action)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/persistence/local"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScopes(t *testing.T) {
+	store := local.NewTokenStore(filepath.Join(t.TempDir(), "tokens"))
+	secrets := map[string]string{}
+	for name, scopes := range map[string][]string{
+		"reader":    {ScopeRead},
+		"triggerer": {ScopeTrigger},
+		"admin":     {ScopeAdmin},
+	} {
+		_, secret, err := CreateToken(store, name, scopes, 0)
+		require.NoError(t, err)
+		secrets[name] = secret
+	}
+	_, expired, err := CreateToken(store, "expired", []string{ScopeAdmin}, time.Millisecond)
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond * 5)
+
+	_, _, err = CreateToken(store, "other", []string{"write"}, 0)
+	require.ErrorIs(t, err, errUnknownScope)
+	_, _, err = CreateToken(store, "other", []string{ScopeImpersonate}, 0)
+	require.ErrorIs(t, err, errNoAccessScope)
+
+	tokenStore = store
+	defer func() {
+		tokenStore = nil
+	}()
+	var body string
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusOK)
+	})
+	tokens := []APIToken{{Name: "legacy", Token: "legacy-token"}}
+	handler := TokenAuth("restricted", tokens)(authorize(testHandler))
+
+	testCase := []struct {
+		name       string
+		token      string
+		method     string
+		path       string
+		body       string
+		httpStatus int
+	}{
+		{"read with read", secrets["reader"], "GET", "/api/v1/dags", "", http.StatusOK},
+		{"start with read", secrets["reader"], "POST", "/api/v1/dags/etl", `{"action":"start"}`, http.StatusForbidden},
+		{"start with trigger", secrets["triggerer"], "POST", "/api/v1/dags/etl", `{"action":"start"}`, http.StatusOK},
+		{"retry with trigger", secrets["triggerer"], "POST", "/api/v1/dags/etl", `{"action":"retry","requestId":"1"}`, http.StatusOK},
+		{"stop with trigger", secrets["triggerer"], "POST", "/api/v1/dags/etl", `{"action":"stop"}`, http.StatusForbidden},
+		{"delete with trigger", secrets["triggerer"], "DELETE", "/api/v1/dags/etl", "", http.StatusForbidden},
+		{"bulk start with trigger", secrets["triggerer"], "POST", "/api/v1/bulk", `{"action":"start","tag":"etl"}`, http.StatusOK},
+		{"bulk suspend with trigger", secrets["triggerer"], "POST", "/api/v1/bulk", `{"action":"suspend","tag":"etl"}`, http.StatusForbidden},
+		{"list tokens with trigger", secrets["triggerer"], "GET", "/api/v1/tokens", "", http.StatusForbidden},
+		{"read audit log with read", secrets["reader"], "GET", "/api/v1/audit", "", http.StatusForbidden},
+		{"read audit log with admin", secrets["admin"], "GET", "/api/v1/audit", "", http.StatusOK},
+		{"create token with admin", secrets["admin"], "POST", "/api/v1/tokens", `{"Name":"x"}`, http.StatusOK},
+		{"token without scopes", "legacy-token", "DELETE", "/api/v1/tokens/admin", "", http.StatusOK},
+		{"expired token", expired, "GET", "/api/v1/dags", "", http.StatusUnauthorized},
+		{"unknown token", "dagu_unknown", "GET", "/api/v1/dags", "", http.StatusUnauthorized},
+	}
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			body = ""
+			r, err := http.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			require.NoError(t, err)
+			r.Header.Add("Authorization", "Bearer "+tc.token)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			require.Equal(t, tc.httpStatus, w.Result().StatusCode)
+			if tc.httpStatus == http.StatusOK {
+				// The handler reads the body the scopes were checked with.
+				require.Equal(t, tc.body, body)
+			}
+		})
+	}
+}
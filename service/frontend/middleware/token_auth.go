@@ -2,13 +2,21 @@ package middleware
 
 import (
 	"crypto/subtle"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
+
+	dagerrors "github.com/dagu-dev/dagu/internal/errors"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
 )
 
+var errNegativeExpiry = dagerrors.New(dagerrors.CodeInvalidArgument, "the expiry of a token must not be negative")
+
 // TokenAuth implements a similar middleware handler like go-chi's BasicAuth middleware but for bearer tokens
-func TokenAuth(realm string, token string) func(next http.Handler) http.Handler {
+func TokenAuth(realm string, tokens []APIToken) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if skipTokenAuth(*r) {
@@ -28,17 +36,87 @@ func TokenAuth(realm string, token string) func(next http.Handler) http.Handler
 				return
 			}
 
-			if subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			token, ok := findToken(tokens, bearer)
+			if !ok {
+				token, ok = findStoredToken(bearer)
+			}
+			if !ok {
 				tokenAuthFailed(w, realm)
 				return
 			}
 
-			next.ServeHTTP(w, r)
+			ctx := withAuthenticated(r.Context(), Identity{Token: token.Name}, token.Scopes...)
+			next.ServeHTTP(w, r.WithContext(ctx))
 
 		})
 	}
 }
 
+// findToken returns the token of the bearer. All the tokens are compared
+// so that the time does not tell which one matched.
+func findToken(tokens []APIToken, bearer string) (APIToken, bool) {
+	var found APIToken
+	ok := false
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare([]byte(bearer), []byte(t.Token)) == 1 && !ok {
+			found, ok = t, true
+		}
+	}
+	return found, ok
+}
+
+// findStoredToken returns the token of the token store of the bearer, unless
+// it expired.
+func findStoredToken(bearer string) (APIToken, bool) {
+	if tokenStore == nil {
+		return APIToken{}, false
+	}
+	tokens, err := tokenStore.List()
+	if err != nil {
+		return APIToken{}, false
+	}
+	hash := []byte(model.HashAPIToken(bearer))
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare(hash, []byte(t.Hash)) == 1 {
+			if t.Expired(time.Now()) {
+				return APIToken{}, false
+			}
+			return APIToken{Name: t.Name, Scopes: t.Scopes}, true
+		}
+	}
+	return APIToken{}, false
+}
+
+// CreateToken creates a token of the name with the scopes in the store,
+// which expires after expiresIn unless it is zero. It returns the token and
+// its secret, which is not stored.
+func CreateToken(store persistence.TokenStore, name string, scopes []string, expiresIn time.Duration) (*model.APIToken, string, error) {
+	if err := ValidateScopes(scopes); err != nil {
+		return nil, "", dagerrors.WithCode(dagerrors.CodeInvalidArgument, err)
+	}
+	if expiresIn < 0 {
+		return nil, "", errNegativeExpiry
+	}
+	var expiresAt time.Time
+	if expiresIn > 0 {
+		expiresAt = time.Now().Add(expiresIn)
+	}
+	token, secret, err := model.NewAPIToken(name, scopes, expiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+	err = store.Create(token)
+	switch {
+	case errors.Is(err, persistence.ErrTokenExists):
+		return nil, "", dagerrors.WithCode(dagerrors.CodeAlreadyExists, err)
+	case errors.Is(err, persistence.ErrInvalidTokenName):
+		return nil, "", dagerrors.WithCode(dagerrors.CodeInvalidArgument, err)
+	case err != nil:
+		return nil, "", err
+	}
+	return token, secret, nil
+}
+
 func skipTokenAuth(r http.Request) bool {
 	return isAuthenticated(r.Context())
 }
@@ -7,6 +7,7 @@ package models
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
@@ -19,6 +20,15 @@ import (
 // swagger:model ApiError
 type APIError struct {
 
+	// Category of the error code.
+	// Required: true
+	// Enum: [internal validation not_found conflict timeout permission]
+	Category *string `json:"category"`
+
+	// Stable machine-readable error code.
+	// Required: true
+	Code *string `json:"code"`
+
 	// detailed message
 	// Required: true
 	DetailedMessage *string `json:"detailedMessage"`
@@ -32,6 +42,14 @@ type APIError struct {
 func (m *APIError) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateCategory(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateCode(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateDetailedMessage(formats); err != nil {
 		res = append(res, err)
 	}
@@ -46,6 +64,70 @@ func (m *APIError) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+var aPIErrorTypeCategoryPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["internal","validation","not_found","conflict","timeout","permission"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		aPIErrorTypeCategoryPropEnum = append(aPIErrorTypeCategoryPropEnum, v)
+	}
+}
+
+const (
+
+	// APIErrorCategoryInternal captures enum value "internal"
+	APIErrorCategoryInternal string = "internal"
+
+	// APIErrorCategoryValidation captures enum value "validation"
+	APIErrorCategoryValidation string = "validation"
+
+	// APIErrorCategoryNotFound captures enum value "not_found"
+	APIErrorCategoryNotFound string = "not_found"
+
+	// APIErrorCategoryConflict captures enum value "conflict"
+	APIErrorCategoryConflict string = "conflict"
+
+	// APIErrorCategoryTimeout captures enum value "timeout"
+	APIErrorCategoryTimeout string = "timeout"
+
+	// APIErrorCategoryPermission captures enum value "permission"
+	APIErrorCategoryPermission string = "permission"
+)
+
+// prop value enum
+func (m *APIError) validateCategoryEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, aPIErrorTypeCategoryPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *APIError) validateCategory(formats strfmt.Registry) error {
+
+	if err := validate.Required("category", "body", m.Category); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateCategoryEnum("category", "body", *m.Category); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *APIError) validateCode(formats strfmt.Registry) error {
+
+	if err := validate.Required("code", "body", m.Code); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *APIError) validateDetailedMessage(formats strfmt.Registry) error {
 
 	if err := validate.Required("detailedMessage", "body", m.DetailedMessage); err != nil {
@@ -40,6 +40,9 @@ type DagDetail struct {
 	// Required: true
 	Group *string `json:"Group"`
 
+	// groups
+	Groups []*StepGroup `json:"Groups"`
+
 	// handler on
 	// Required: true
 	HandlerOn *HandlerOn `json:"HandlerOn"`
@@ -64,6 +67,9 @@ type DagDetail struct {
 	// Required: true
 	Name *string `json:"Name"`
 
+	// param defs
+	ParamDefs []*ParamDef `json:"ParamDefs"`
+
 	// params
 	// Required: true
 	Params []string `json:"Params"`
@@ -83,6 +89,9 @@ type DagDetail struct {
 	// tags
 	// Required: true
 	Tags []string `json:"Tags"`
+
+	// warnings
+	Warnings []*DagWarning `json:"Warnings"`
 }
 
 // Validate validates this dag detail
@@ -109,6 +118,10 @@ func (m *DagDetail) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateGroups(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateHandlerOn(formats); err != nil {
 		res = append(res, err)
 	}
@@ -133,6 +146,10 @@ func (m *DagDetail) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateParamDefs(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateParams(formats); err != nil {
 		res = append(res, err)
 	}
@@ -153,6 +170,10 @@ func (m *DagDetail) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateWarnings(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -204,6 +225,32 @@ func (m *DagDetail) validateGroup(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *DagDetail) validateGroups(formats strfmt.Registry) error {
+	if swag.IsZero(m.Groups) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Groups); i++ {
+		if swag.IsZero(m.Groups[i]) { // not required
+			continue
+		}
+
+		if m.Groups[i] != nil {
+			if err := m.Groups[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Groups" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("Groups" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *DagDetail) validateHandlerOn(formats strfmt.Registry) error {
 
 	if err := validate.Required("HandlerOn", "body", m.HandlerOn); err != nil {
@@ -269,6 +316,32 @@ func (m *DagDetail) validateName(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *DagDetail) validateParamDefs(formats strfmt.Registry) error {
+	if swag.IsZero(m.ParamDefs) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.ParamDefs); i++ {
+		if swag.IsZero(m.ParamDefs[i]) { // not required
+			continue
+		}
+
+		if m.ParamDefs[i] != nil {
+			if err := m.ParamDefs[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("ParamDefs" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("ParamDefs" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *DagDetail) validateParams(formats strfmt.Registry) error {
 
 	if err := validate.Required("Params", "body", m.Params); err != nil {
@@ -368,14 +441,48 @@ func (m *DagDetail) validateTags(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *DagDetail) validateWarnings(formats strfmt.Registry) error {
+	if swag.IsZero(m.Warnings) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Warnings); i++ {
+		if swag.IsZero(m.Warnings[i]) { // not required
+			continue
+		}
+
+		if m.Warnings[i] != nil {
+			if err := m.Warnings[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Warnings" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("Warnings" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 // ContextValidate validate this dag detail based on the context it is used
 func (m *DagDetail) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.contextValidateGroups(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateHandlerOn(ctx, formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateParamDefs(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidatePreconditions(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -388,12 +495,41 @@ func (m *DagDetail) ContextValidate(ctx context.Context, formats strfmt.Registry
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateWarnings(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
 
+func (m *DagDetail) contextValidateGroups(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Groups); i++ {
+
+		if m.Groups[i] != nil {
+
+			if swag.IsZero(m.Groups[i]) { // not required
+				return nil
+			}
+
+			if err := m.Groups[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Groups" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("Groups" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *DagDetail) contextValidateHandlerOn(ctx context.Context, formats strfmt.Registry) error {
 
 	if m.HandlerOn != nil {
@@ -411,6 +547,31 @@ func (m *DagDetail) contextValidateHandlerOn(ctx context.Context, formats strfmt
 	return nil
 }
 
+func (m *DagDetail) contextValidateParamDefs(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.ParamDefs); i++ {
+
+		if m.ParamDefs[i] != nil {
+
+			if swag.IsZero(m.ParamDefs[i]) { // not required
+				return nil
+			}
+
+			if err := m.ParamDefs[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("ParamDefs" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("ParamDefs" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *DagDetail) contextValidatePreconditions(ctx context.Context, formats strfmt.Registry) error {
 
 	for i := 0; i < len(m.Preconditions); i++ {
@@ -486,6 +647,31 @@ func (m *DagDetail) contextValidateSteps(ctx context.Context, formats strfmt.Reg
 	return nil
 }
 
+func (m *DagDetail) contextValidateWarnings(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Warnings); i++ {
+
+		if m.Warnings[i] != nil {
+
+			if swag.IsZero(m.Warnings[i]) { // not required
+				return nil
+			}
+
+			if err := m.Warnings[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Warnings" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("Warnings" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *DagDetail) MarshalBinary() ([]byte, error) {
 	if m == nil {
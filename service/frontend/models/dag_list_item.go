@@ -39,6 +39,9 @@ type DagListItem struct {
 	// Required: true
 	File *string `json:"File"`
 
+	// Folder of the DAG in the DAGs directory, e.g. team-a/etl, empty in the directory itself.
+	Folder string `json:"Folder,omitempty"`
+
 	// status
 	// Required: true
 	Status *DagStatus `json:"Status"`
@@ -24,6 +24,9 @@ type DagLogResponse struct {
 	// Required: true
 	GridData []*DagLogGridItem `json:"GridData"`
 
+	// Whether there are older runs after the page.
+	HasMore bool `json:"HasMore,omitempty"`
+
 	// logs
 	// Required: true
 	Logs []*DagStatusFile `json:"Logs"`
@@ -19,10 +19,22 @@ import (
 // swagger:model dagStatus
 type DagStatus struct {
 
+	// Location the log files of the run were moved to when it was archived.
+	ArchiveLocation string `json:"ArchiveLocation,omitempty"`
+
 	// finished at
 	// Required: true
 	FinishedAt *string `json:"FinishedAt"`
 
+	// User the run was started by through the API, or on behalf of with a token with the impersonate scope.
+	InitiatedBy string `json:"InitiatedBy,omitempty"`
+
+	// Name of the API token the run was started with.
+	InitiatorToken string `json:"InitiatorToken,omitempty"`
+
+	// manual if the run was started by hand and queued ahead of the scheduled runs.
+	Lane string `json:"Lane,omitempty"`
+
 	// log
 	// Required: true
 	Log *string `json:"Log"`
@@ -31,6 +43,9 @@ type DagStatus struct {
 	// Required: true
 	Name *string `json:"Name"`
 
+	// Request IDs of the scheduled runs queued before the run that it took the slot of its pool ahead of.
+	Overtook []string `json:"Overtook"`
+
 	// params
 	// Required: true
 	Params *string `json:"Params"`
@@ -39,10 +54,22 @@ type DagStatus struct {
 	// Required: true
 	Pid *int64 `json:"Pid"`
 
+	// Position of the run in the queue of its concurrency pool while it waits for a slot. It is 0 if the run does not wait.
+	QueuePosition int64 `json:"QueuePosition,omitempty"`
+
 	// request Id
 	// Required: true
 	RequestID *string `json:"RequestId"`
 
+	// Request ID of the run that restarted this run.
+	RestartedAs string `json:"RestartedAs,omitempty"`
+
+	// Request ID of the run this run restarted.
+	RestartedFrom string `json:"RestartedFrom,omitempty"`
+
+	// Cron expression of the schedule entry that started the run.
+	Schedule string `json:"Schedule,omitempty"`
+
 	// started at
 	// Required: true
 	StartedAt *string `json:"StartedAt"`
@@ -54,6 +81,9 @@ type DagStatus struct {
 	// status text
 	// Required: true
 	StatusText *string `json:"StatusText"`
+
+	// Cron expression of the schedule entry that stopped the run.
+	StoppedBy string `json:"StoppedBy,omitempty"`
 }
 
 // Validate validates this dag status
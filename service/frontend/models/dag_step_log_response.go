@@ -19,6 +19,9 @@ import (
 // swagger:model dagStepLogResponse
 type DagStepLogResponse struct {
 
+	// Location of the archive the log was read from if it is no longer on the local disk.
+	ArchivedFrom string `json:"ArchivedFrom,omitempty"`
+
 	// content
 	// Required: true
 	Content *string `json:"Content"`
@@ -27,6 +30,9 @@ type DagStepLogResponse struct {
 	// Required: true
 	LogFile *string `json:"LogFile"`
 
+	// Location of the logs of the run in the log backend if the log was read from it because it is not on the local disk.
+	RemoteLocation string `json:"RemoteLocation,omitempty"`
+
 	// step
 	// Required: true
 	Step *StatusNode `json:"Step"`
@@ -29,6 +29,9 @@ type HandlerOn struct {
 
 	// success
 	Success *StepObject `json:"Success,omitempty"`
+
+	// timeout
+	Timeout *StepObject `json:"Timeout,omitempty"`
 }
 
 // Validate validates this handler on
@@ -51,6 +54,10 @@ func (m *HandlerOn) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateTimeout(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -133,6 +140,25 @@ func (m *HandlerOn) validateSuccess(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *HandlerOn) validateTimeout(formats strfmt.Registry) error {
+	if swag.IsZero(m.Timeout) { // not required
+		return nil
+	}
+
+	if m.Timeout != nil {
+		if err := m.Timeout.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("Timeout")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("Timeout")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ContextValidate validate this handler on based on the context it is used
 func (m *HandlerOn) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
@@ -153,6 +179,10 @@ func (m *HandlerOn) ContextValidate(ctx context.Context, formats strfmt.Registry
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateTimeout(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -243,6 +273,27 @@ func (m *HandlerOn) contextValidateSuccess(ctx context.Context, formats strfmt.R
 	return nil
 }
 
+func (m *HandlerOn) contextValidateTimeout(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Timeout != nil {
+
+		if swag.IsZero(m.Timeout) { // not required
+			return nil
+		}
+
+		if err := m.Timeout.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("Timeout")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("Timeout")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *HandlerOn) MarshalBinary() ([]byte, error) {
 	if m == nil {
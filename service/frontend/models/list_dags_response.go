@@ -28,9 +28,16 @@ type ListDagsResponse struct {
 	// Required: true
 	Errors []string `json:"Errors"`
 
+	// Subfolders of the folder of the request, e.g. team-a/etl, if it is set.
+	Folders []string `json:"Folders"`
+
 	// has error
 	// Required: true
 	HasError *bool `json:"HasError"`
+
+	// Number of the DAGs that match the filters of the request.
+	// Required: true
+	Total *int64 `json:"Total"`
 }
 
 // Validate validates this list dags response
@@ -49,6 +56,10 @@ func (m *ListDagsResponse) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateTotal(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -100,6 +111,15 @@ func (m *ListDagsResponse) validateHasError(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ListDagsResponse) validateTotal(formats strfmt.Registry) error {
+
+	if err := validate.Required("Total", "body", m.Total); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // ContextValidate validate this list dags response based on the context it is used
 func (m *ListDagsResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
@@ -8,6 +8,7 @@ package models
 import (
 	"context"
 
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 )
@@ -17,17 +18,90 @@ import (
 // swagger:model postDagActionResponse
 type PostDagActionResponse struct {
 
+	// ID of the pending change of save if the namespace is under change control.
+	ChangeID string `json:"ChangeId,omitempty"`
+
 	// new dag ID
 	NewDagID string `json:"NewDagID,omitempty"`
+
+	// Output variables of the steps of the run, if it was waited for.
+	Outputs map[string]string `json:"Outputs,omitempty"`
+
+	// True if the run of start is in the trigger queue until the scheduler starts it.
+	Queued bool `json:"Queued,omitempty"`
+
+	// Request ID of the run of start.
+	RequestID string `json:"RequestId,omitempty"`
+
+	// status
+	Status *DagStatusDetail `json:"Status,omitempty"`
 }
 
 // Validate validates this post dag action response
 func (m *PostDagActionResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateStatus(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *PostDagActionResponse) validateStatus(formats strfmt.Registry) error {
+	if swag.IsZero(m.Status) { // not required
+		return nil
+	}
+
+	if m.Status != nil {
+		if err := m.Status.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("Status")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("Status")
+			}
+			return err
+		}
+	}
+
 	return nil
 }
 
-// ContextValidate validates this post dag action response based on context it is used
+// ContextValidate validate this post dag action response based on the context it is used
 func (m *PostDagActionResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateStatus(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *PostDagActionResponse) contextValidateStatus(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Status != nil {
+
+		if swag.IsZero(m.Status) { // not required
+			return nil
+		}
+
+		if err := m.Status.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("Status")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("Status")
+			}
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -22,11 +22,20 @@ type SearchDagsResultItem struct {
 	// d a g
 	DAG *Dag `json:"DAG,omitempty"`
 
-	// matches
+	// The fields that matched, the most relevant first, of name, tag, description, command and log.
+	Fields []string `json:"Fields"`
+
+	// The step logs of the recent runs that matched, the latest first.
+	Logs []*SearchDagsLogItem `json:"Logs"`
+
+	// The lines of the definition that matched.
 	Matches []*SearchDagsMatchItem `json:"Matches"`
 
 	// name
 	Name string `json:"Name,omitempty"`
+
+	// The relevance of the DAG, higher for the matches of the more relevant fields.
+	Score int64 `json:"Score,omitempty"`
 }
 
 // Validate validates this search dags result item
@@ -37,6 +46,10 @@ func (m *SearchDagsResultItem) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateLogs(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateMatches(formats); err != nil {
 		res = append(res, err)
 	}
@@ -66,6 +79,32 @@ func (m *SearchDagsResultItem) validateDAG(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *SearchDagsResultItem) validateLogs(formats strfmt.Registry) error {
+	if swag.IsZero(m.Logs) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Logs); i++ {
+		if swag.IsZero(m.Logs[i]) { // not required
+			continue
+		}
+
+		if m.Logs[i] != nil {
+			if err := m.Logs[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Logs" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("Logs" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *SearchDagsResultItem) validateMatches(formats strfmt.Registry) error {
 	if swag.IsZero(m.Matches) { // not required
 		return nil
@@ -100,6 +139,10 @@ func (m *SearchDagsResultItem) ContextValidate(ctx context.Context, formats strf
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateLogs(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateMatches(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -131,6 +174,31 @@ func (m *SearchDagsResultItem) contextValidateDAG(ctx context.Context, formats s
 	return nil
 }
 
+func (m *SearchDagsResultItem) contextValidateLogs(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Logs); i++ {
+
+		if m.Logs[i] != nil {
+
+			if swag.IsZero(m.Logs[i]) { // not required
+				return nil
+			}
+
+			if err := m.Logs[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Logs" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("Logs" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *SearchDagsResultItem) contextValidateMatches(ctx context.Context, formats strfmt.Registry) error {
 
 	for i := 0; i < len(m.Matches); i++ {
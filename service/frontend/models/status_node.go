@@ -35,6 +35,9 @@ type StatusNode struct {
 	// Required: true
 	Log *string `json:"Log"`
 
+	// Command of the step with the variables expanded and the secret values masked.
+	Preview string `json:"Preview,omitempty"`
+
 	// retry count
 	// Required: true
 	RetryCount *int64 `json:"RetryCount"`
@@ -54,6 +57,9 @@ type StatusNode struct {
 	// step
 	// Required: true
 	Step *StepObject `json:"Step"`
+
+	// Request ID of the sub DAG run of the step.
+	SubRunRequestID string `json:"SubRunRequestId,omitempty"`
 }
 
 // Validate validates this status node
@@ -44,6 +44,9 @@ type StepObject struct {
 	// Required: true
 	Dir *string `json:"Dir"`
 
+	// group
+	Group string `json:"Group,omitempty"`
+
 	// mail on error
 	// Required: true
 	MailOnError *bool `json:"MailOnError"`
@@ -74,6 +77,9 @@ type StepObject struct {
 	// Required: true
 	Script *string `json:"Script"`
 
+	// stage
+	Stage string `json:"Stage,omitempty"`
+
 	// stderr
 	// Required: true
 	Stderr *string `json:"Stderr"`
@@ -0,0 +1,27 @@
+// Command gen writes the OpenAPI 3 document of the REST API to the file of
+// the argument, from which the clients of the API are generated.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dagu-dev/dagu/internal/constants"
+	"github.com/dagu-dev/dagu/service/frontend/openapi"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: gen <file>")
+		os.Exit(2)
+	}
+	b, err := openapi.Document(constants.Version)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(os.Args[1], b, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,83 @@
+// Package openapi converts the Swagger 2.0 spec of the REST API, from which
+// the server is generated, to an OpenAPI 3 document for the integrators and
+// the generators of the clients of the API.
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dagu-dev/dagu/service/frontend/restapi"
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// The security schemes of the document. The server accepts the basic auth
+// or the bearer tokens of its configuration, or none if it has neither.
+const (
+	SchemeBasic  = "basicAuth"
+	SchemeBearer = "bearerAuth"
+)
+
+// Document returns the OpenAPI 3 document of the REST API of the version of
+// the server in JSON.
+func Document(version string) ([]byte, error) {
+	doc, err := Convert(restapi.SwaggerJSON)
+	if err != nil {
+		return nil, err
+	}
+	doc.Info.Version = version
+	return json.Marshal(doc)
+}
+
+// Convert converts the Swagger 2.0 spec in JSON to a valid OpenAPI 3
+// document. The servers are relative to the host serving the document.
+func Convert(swagger []byte) (*openapi3.T, error) {
+	var doc2 openapi2.T
+	if err := json.Unmarshal(swagger, &doc2); err != nil {
+		return nil, err
+	}
+	doc, err := openapi2conv.ToV3(&doc2)
+	if err != nil {
+		return nil, err
+	}
+	doc.Servers = openapi3.Servers{{URL: doc2.BasePath}}
+	convertFiles(doc)
+	doc.Components.SecuritySchemes = openapi3.SecuritySchemes{
+		SchemeBasic: &openapi3.SecuritySchemeRef{
+			Value: &openapi3.SecurityScheme{Type: "http", Scheme: "basic"},
+		},
+		SchemeBearer: &openapi3.SecuritySchemeRef{
+			Value: &openapi3.SecurityScheme{Type: "http", Scheme: "bearer"},
+		},
+	}
+	doc.Security = openapi3.SecurityRequirements{
+		openapi3.NewSecurityRequirement().Authenticate(SchemeBasic),
+		openapi3.NewSecurityRequirement().Authenticate(SchemeBearer),
+		openapi3.NewSecurityRequirement(),
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// convertFiles replaces the file responses of Swagger 2.0, which are not
+// converted, with the binary strings of OpenAPI 3.
+func convertFiles(doc *openapi3.T) {
+	for _, p := range doc.Paths.Map() {
+		for _, op := range p.Operations() {
+			for _, resp := range op.Responses.Map() {
+				if resp.Value == nil {
+					continue
+				}
+				for _, mt := range resp.Value.Content {
+					if mt.Schema != nil && mt.Schema.Value != nil && mt.Schema.Value.Type == "file" {
+						mt.Schema.Value = openapi3.NewStringSchema().WithFormat("binary")
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/dagu-dev/dagu/service/frontend/restapi"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-openapi/loads"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument(t *testing.T) {
+	b, err := Document("1.2.0")
+	require.NoError(t, err)
+
+	doc, err := openapi3.NewLoader().LoadFromData(b)
+	require.NoError(t, err)
+	require.Equal(t, "3.0.3", doc.OpenAPI)
+	require.Equal(t, "1.2.0", doc.Info.Version)
+	require.Equal(t, "/api/v1", doc.Servers[0].URL)
+	require.Contains(t, doc.Components.SecuritySchemes, SchemeBearer)
+
+	// The document has every operation of the spec of the server.
+	spec, err := loads.Analyzed(restapi.SwaggerJSON, "")
+	require.NoError(t, err)
+	ops := map[string]bool{}
+	for _, p := range doc.Paths.Map() {
+		for _, op := range p.Operations() {
+			ops[op.OperationID] = true
+		}
+	}
+	for _, m := range spec.Analyzer.Operations() {
+		for _, op := range m {
+			require.True(t, ops[op.ID], op.ID)
+		}
+	}
+	require.True(t, ops["getOpenAPI"])
+}
@@ -35,6 +35,213 @@ func init() {
   "host": "localhost:8080",
   "basePath": "/api/v1",
   "paths": {
+    "/audit": {
+      "get": {
+        "description": "Returns the entries of the audit log of the actions on the DAGs and the runs, the latest first. It requires a token with the admin scope.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "listAuditEntries",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Returns the entries of the DAG.",
+            "name": "dag",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Returns the entries of the action, e.g. start or delete.",
+            "name": "action",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Returns the entries of the user or of the API token of the name.",
+            "name": "actor",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Returns the entries at or after the time, in RFC 3339.",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Returns the entries before the time, in RFC 3339.",
+            "name": "until",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Maximum number of the entries, 100 by default.",
+            "name": "limit",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/listAuditEntriesResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/bulk": {
+      "post": {
+        "description": "Performs an action on several DAGs in one request, the ones of the names, of the runs and of the tag, and returns the result of each of them. The action on a DAG that fails does not stop the others.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "postBulkAction",
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "type": "object",
+              "required": [
+                "action"
+              ],
+              "properties": {
+                "action": {
+                  "type": "string",
+                  "enum": [
+                    "start",
+                    "stop",
+                    "suspend",
+                    "resume",
+                    "retry"
+                  ]
+                },
+                "names": {
+                  "description": "Names of the DAGs, e.g. etl and team-a/report.",
+                  "type": "array",
+                  "items": {
+                    "type": "string"
+                  }
+                },
+                "params": {
+                  "description": "Parameters of the runs of start.",
+                  "type": "string"
+                },
+                "runs": {
+                  "description": "Runs to retry, of the retry action only. The DAGs of the names and of the tag retry their latest runs.",
+                  "type": "array",
+                  "items": {
+                    "$ref": "#/definitions/bulkActionRun"
+                  }
+                },
+                "tag": {
+                  "description": "Tag of the DAGs the request is allowed to view, in the order of their names.",
+                  "type": "string"
+                }
+              }
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/postBulkActionResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/changes": {
+      "get": {
+        "description": "Returns the changes of the DAGs of the namespace under change control, the latest first.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "listChanges",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Returns the changes of the DAG.",
+            "name": "dag",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Returns the changes of the status, pending, approved or rejected.",
+            "name": "status",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/listChangesResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/changes/{changeId}": {
+      "post": {
+        "description": "Approves or rejects a pending change of a DAG. The definition of an approved change is saved, and a user other than the author of the change approves it.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "reviewChange",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "changeId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/reviewChangeRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/change"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
     "/dags": {
       "get": {
         "description": "Returns a list of DAGs.",
@@ -42,6 +249,64 @@ func init() {
           "application/json"
         ],
         "operationId": "listDags",
+        "parameters": [
+          {
+            "type": "integer",
+            "description": "Maximum number of the DAGs, all of them if it is not set.",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Number of the DAGs to skip.",
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "name",
+              "lastRun",
+              "status"
+            ],
+            "type": "string",
+            "description": "Order of the DAGs, name by default. The DAGs of the same order are sorted by their names.",
+            "name": "sort",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "asc",
+              "desc"
+            ],
+            "type": "string",
+            "name": "order",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Returns the DAGs with the tag.",
+            "name": "tag",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Returns the DAGs whose latest runs have the status, e.g. running or failed.",
+            "name": "status",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "Returns the DAGs with schedules if it is true, and the ones without them if it is false.",
+            "name": "scheduled",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Returns the DAGs in the folder of the DAGs directory, e.g. team-a/etl, and not the ones of its subfolders. The DAGs directory itself is /.",
+            "name": "folder",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
             "description": "A successful response.",
@@ -128,6 +393,24 @@ func init() {
             "type": "string",
             "name": "step",
             "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Request ID of the run to show instead of the latest run.",
+            "name": "requestId",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Maximum number of the runs of the history tab, 30 by default.",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Number of the latest runs to skip in the history tab.",
+            "name": "offset",
+            "in": "query"
           }
         ],
         "responses": {
@@ -158,6 +441,18 @@ func init() {
             "in": "path",
             "required": true
           },
+          {
+            "type": "boolean",
+            "description": "Waits for the run of start to finish, and returns its status and the outputs of its steps.",
+            "name": "wait",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Maximum time to wait for the run, e.g. 300s or 10m, 5 minutes by default. The status of the run is returned as it is if it has not finished by then.",
+            "name": "timeout",
+            "in": "query"
+          },
           {
             "name": "body",
             "in": "body",
@@ -177,12 +472,24 @@ func init() {
                     "mark-success",
                     "mark-failed",
                     "save",
-                    "rename"
+                    "rename",
+                    "move"
                   ]
                 },
+                "chaos": {
+                  "description": "Faults injected into the steps of the run of start, e.g. fail=extract or delay=load:30s. Only the servers that allow chaos accept them.",
+                  "type": "array",
+                  "items": {
+                    "type": "string"
+                  }
+                },
                 "params": {
                   "type": "string"
                 },
+                "reason": {
+                  "description": "Reason of the operator who marks the status of a step. It is required by mark-success and mark-failed.",
+                  "type": "string"
+                },
                 "requestId": {
                   "type": "string"
                 },
@@ -190,6 +497,7 @@ func init() {
                   "type": "string"
                 },
                 "value": {
+                  "description": "The new name of rename, which is a path in the DAGs directory to move the DAG to a folder, e.g. team-a/etl, or the folder of move, / being the DAGs directory itself.",
                   "type": "string"
                 }
               }
@@ -238,26 +546,52 @@ func init() {
         }
       }
     },
-    "/search": {
+    "/dags/{dagId}/graph": {
       "get": {
-        "description": "Searches for DAGs.",
+        "description": "Exports the dependency graph of the steps of a DAG, to embed it in documents and dashboards.",
         "produces": [
+          "text/plain",
+          "text/vnd.graphviz",
+          "image/svg+xml",
           "application/json"
         ],
-        "operationId": "searchDags",
+        "operationId": "getDagGraph",
         "parameters": [
           {
             "type": "string",
-            "name": "q",
-            "in": "query",
+            "name": "dagId",
+            "in": "path",
             "required": true
+          },
+          {
+            "enum": [
+              "dot",
+              "mermaid",
+              "svg"
+            ],
+            "type": "string",
+            "description": "Format of the graph, mermaid by default.",
+            "name": "format",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "Colors the steps by their statuses in the latest run, or in the run of requestId.",
+            "name": "status",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Request ID of the run to color the steps by instead of the latest run.",
+            "name": "requestId",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "A successful response.",
+            "description": "The graph in the format.",
             "schema": {
-              "$ref": "#/definitions/searchDagsResponse"
+              "type": "string"
             }
           },
           "default": {
@@ -268,83 +602,4070 @@ func init() {
           }
         }
       }
-    }
-  },
-  "definitions": {
-    "ApiError": {
-      "type": "object",
-      "required": [
-        "message",
-        "detailedMessage"
-      ],
-      "properties": {
-        "detailedMessage": {
-          "type": "string"
-        },
-        "message": {
-          "type": "string"
-        }
-      }
-    },
-    "condition": {
-      "type": "object",
-      "properties": {
-        "Condition": {
-          "type": "string"
-        },
-        "Expected": {
-          "type": "string"
-        }
-      }
     },
-    "createDagResponse": {
-      "type": "object",
-      "required": [
-        "DagID"
-      ],
-      "properties": {
+    "/dags/{dagId}/runs/{requestId}/artifacts": {
+      "get": {
+        "description": "Returns the artifacts of a run of a DAG with their sizes and digests.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "listArtifacts",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "requestId",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/listArtifactsResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Deletes an artifact of a run of a DAG, from the bucket of the artifacts too.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "deleteArtifact",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "requestId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Path of the artifact relative to the artifacts of the run.",
+            "name": "path",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response."
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/runs/{requestId}/artifacts/download": {
+      "get": {
+        "description": "Downloads an artifact of a run of a DAG.",
+        "produces": [
+          "application/octet-stream",
+          "application/json"
+        ],
+        "operationId": "downloadArtifact",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "requestId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Path of the artifact relative to the artifacts of the run.",
+            "name": "path",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The contents of the artifact.",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/runs/{requestId}/artifacts/preview": {
+      "get": {
+        "description": "Returns the preview of an image, HTML, JSON or CSV artifact of a run of a DAG to show it in the browser. The HTML documents are sanitized.",
+        "produces": [
+          "image/png",
+          "text/html",
+          "application/json"
+        ],
+        "operationId": "previewArtifact",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "requestId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Path of the artifact relative to the artifacts of the run.",
+            "name": "path",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The preview of the artifact.",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/runs/{requestId}/artifacts/url": {
+      "get": {
+        "description": "Returns a pre-signed URL of an artifact of a run of a DAG, which downloads the artifact from the bucket of the artifacts without other credentials until it expires. It requires the s3 artifact backend.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "getArtifactURL",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "requestId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Path of the artifact relative to the artifacts of the run.",
+            "name": "path",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "description": "Seconds until the URL expires. The default is 900 and the maximum is 604800, 7 days.",
+            "name": "expiresIn",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/artifactURLResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/runs/{requestId}/steps/{stepName}/log/stream": {
+      "get": {
+        "description": "Streams the log of a step of a run of a DAG as server-sent events, like tail -f. Each line of the log is sent as the data of a message event whose id is the offset of the log after the line, and an end event is sent once the step finished and its log was sent. A client that reconnects with the Last-Event-ID header continues from the offset.",
+        "produces": [
+          "text/event-stream",
+          "application/json"
+        ],
+        "operationId": "streamStepLog",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "requestId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "stepName",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "description": "Offset in bytes of the log to stream from. The default is 0, the start of the log.",
+            "name": "offset",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The events of the log.",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/drift": {
+      "get": {
+        "description": "Compares the DAG definitions and the version of the server with the ones of the remote nodes of the configuration.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "getDriftReport",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/driftReportResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/drift/inventory": {
+      "get": {
+        "description": "Returns the version of the server and the digests of the files of its DAGs, which the other servers compare theirs with.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "getDriftInventory",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/driftInventoryResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/folders": {
+      "get": {
+        "description": "Returns the folders of the DAGs directory, e.g. team-a and team-a/etl.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "listFolders",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/listFoldersResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Creates a folder and its parents in the DAGs directory.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "createFolder",
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "type": "object",
+              "required": [
+                "folder"
+              ],
+              "properties": {
+                "folder": {
+                  "type": "string"
+                }
+              }
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response."
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/folders/{folder}": {
+      "delete": {
+        "description": "Deletes a folder of the DAGs directory, which must be empty. The slashes of the folder are escaped, e.g. team-a%2Fetl.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "deleteFolder",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "folder",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response."
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/gc/report": {
+      "get": {
+        "description": "Returns the report of the last garbage collection of orphaned files.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "getGcReport",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/gcReportResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/halt": {
+      "get": {
+        "description": "Returns whether the schedules of all the DAGs are halted by a stop of all the DAGs.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "getHalt",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/haltResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Stops all the running DAGs and halts the schedules of all the DAGs until they are resumed, e.g. during a data corruption incident. It requires the admin role on all the DAGs.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "postHalt",
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "type": "object",
+              "required": [
+                "confirm"
+              ],
+              "properties": {
+                "confirm": {
+                  "description": "Must be true, so that the DAGs are not stopped by mistake.",
+                  "type": "boolean"
+                },
+                "reason": {
+                  "description": "Reason of the stop, recorded in the audit log.",
+                  "type": "string"
+                }
+              }
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/haltResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Resumes the schedules halted by a stop of all the DAGs. It requires the admin role on all the DAGs.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "deleteHalt",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/haltResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/meta": {
+      "get": {
+        "description": "Returns the version and the capabilities of the server, so that the clients can adapt to the servers of older versions.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "getMeta",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/metaResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/openapi.json": {
+      "get": {
+        "description": "Returns the OpenAPI 3 document of the REST API, from which the clients of the API are generated.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "getOpenAPI",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "type": "object"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/search": {
+      "get": {
+        "description": "Searches the names, the descriptions, the tags and the step commands of the DAGs, and optionally the logs of their recent runs, for the words of the query, the most relevant DAGs first.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "searchDags",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The words the DAGs must all have, each as the prefix of a word.",
+            "name": "q",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "boolean",
+            "description": "Searches the step logs of the recent runs too if it is true.",
+            "name": "logs",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/searchDagsResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/status": {
+      "get": {
+        "description": "Returns the latest status of each of the DAGs of the names or of the tag in one response, e.g. for a status page.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "getDagStatuses",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Comma-separated names of the DAGs, e.g. etl,report. The statuses are in the order of the names.",
+            "name": "names",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Returns the statuses of the DAGs with the tag, sorted by their names.",
+            "name": "tag",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/getDagStatusesResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/tokens": {
+      "get": {
+        "description": "Returns the API tokens created with the API or the CLI, without their secrets. It requires a token with the admin scope.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "listApiTokens",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/listApiTokensResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Creates an API token with scopes, which expires after expiresIn seconds unless it is omitted. Its secret is returned once and is not stored. It requires a token with the admin scope.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "createApiToken",
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createApiTokenRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/createApiTokenResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/tokens/{tokenName}": {
+      "delete": {
+        "description": "Deletes an API token created with the API or the CLI. It requires a token with the admin scope.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "deleteApiToken",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "tokenName",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response."
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/triggers": {
+      "get": {
+        "description": "Returns the runs started with the API that are in the trigger queue until the scheduler starts them, the oldest first.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "listTriggers",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/listTriggersResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/validate": {
+      "post": {
+        "description": "Validates a DAG definition without saving it, e.g. the content of an editor as the user types, and returns its problems with their lines.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "validateDag",
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/validateDagRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/validateDagResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "ApiError": {
+      "type": "object",
+      "required": [
+        "message",
+        "detailedMessage",
+        "code",
+        "category"
+      ],
+      "properties": {
+        "category": {
+          "description": "Category of the error code.",
+          "type": "string",
+          "enum": [
+            "internal",
+            "validation",
+            "not_found",
+            "conflict",
+            "timeout",
+            "permission"
+          ]
+        },
+        "code": {
+          "description": "Stable machine-readable error code.",
+          "type": "string"
+        },
+        "detailedMessage": {
+          "type": "string"
+        },
+        "message": {
+          "type": "string"
+        }
+      }
+    },
+    "apiToken": {
+      "type": "object",
+      "required": [
+        "Name",
+        "Scopes",
+        "CreatedAt",
+        "ExpiresAt",
+        "Expired"
+      ],
+      "properties": {
+        "CreatedAt": {
+          "type": "string"
+        },
+        "Expired": {
+          "type": "boolean"
+        },
+        "ExpiresAt": {
+          "description": "Time the token expires at, empty if it does not expire.",
+          "type": "string"
+        },
+        "Name": {
+          "type": "string"
+        },
+        "Scopes": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "artifact": {
+      "type": "object",
+      "required": [
+        "Path",
+        "Size",
+        "ModTime"
+      ],
+      "properties": {
+        "ETag": {
+          "description": "ETag of the object of the artifact that is only in the bucket of the artifacts.",
+          "type": "string"
+        },
+        "ModTime": {
+          "type": "string"
+        },
+        "Path": {
+          "description": "Path of the artifact relative to the artifacts of the run.",
+          "type": "string"
+        },
+        "SHA256": {
+          "description": "SHA-256 of the contents in hex, empty if the artifact is only in the bucket of the artifacts.",
+          "type": "string"
+        },
+        "Size": {
+          "type": "integer"
+        },
+        "Type": {
+          "description": "Type of the artifact by the extension of its file, file if it has no preview.",
+          "type": "string",
+          "enum": [
+            "image",
+            "html",
+            "json",
+            "csv",
+            "file"
+          ]
+        }
+      }
+    },
+    "artifactURLResponse": {
+      "type": "object",
+      "required": [
+        "URL",
+        "ExpiresAt"
+      ],
+      "properties": {
+        "ExpiresAt": {
+          "type": "string"
+        },
+        "URL": {
+          "type": "string"
+        }
+      }
+    },
+    "auditEntry": {
+      "type": "object",
+      "required": [
+        "Time",
+        "Action",
+        "DAG"
+      ],
+      "properties": {
+        "Action": {
+          "type": "string"
+        },
+        "Actor": {
+          "description": "User who took the action, empty if the server does not authenticate the users.",
+          "type": "string"
+        },
+        "DAG": {
+          "type": "string"
+        },
+        "Detail": {
+          "description": "About the action, e.g. the new name of a renamed DAG.",
+          "type": "string"
+        },
+        "Reason": {
+          "type": "string"
+        },
+        "RequestId": {
+          "type": "string"
+        },
+        "SourceIP": {
+          "description": "Address of the client of the request.",
+          "type": "string"
+        },
+        "Step": {
+          "type": "string"
+        },
+        "Time": {
+          "type": "string"
+        },
+        "Token": {
+          "description": "Name of the API token the action was taken with.",
+          "type": "string"
+        }
+      }
+    },
+    "bulkActionResult": {
+      "type": "object",
+      "required": [
+        "Name",
+        "Ok"
+      ],
+      "properties": {
+        "Error": {
+          "$ref": "#/definitions/ApiError"
+        },
+        "Name": {
+          "type": "string"
+        },
+        "Ok": {
+          "type": "boolean"
+        },
+        "RequestId": {
+          "description": "Request ID of the run that is retried or stopped.",
+          "type": "string"
+        }
+      }
+    },
+    "bulkActionRun": {
+      "type": "object",
+      "required": [
+        "name",
+        "requestId"
+      ],
+      "properties": {
+        "name": {
+          "type": "string"
+        },
+        "requestId": {
+          "type": "string"
+        }
+      }
+    },
+    "change": {
+      "type": "object",
+      "required": [
+        "Id",
+        "DAG",
+        "Author",
+        "CreatedAt",
+        "Spec",
+        "Diff",
+        "Status"
+      ],
+      "properties": {
+        "Author": {
+          "type": "string"
+        },
+        "CreatedAt": {
+          "type": "string"
+        },
+        "DAG": {
+          "type": "string"
+        },
+        "Diff": {
+          "description": "Unified diff of the definition of the DAG when the change was made and the one of the change.",
+          "type": "string"
+        },
+        "Id": {
+          "type": "string"
+        },
+        "Reason": {
+          "description": "Reason of the rejection of the change.",
+          "type": "string"
+        },
+        "ReviewedAt": {
+          "type": "string"
+        },
+        "Reviewer": {
+          "description": "User who approved or rejected the change.",
+          "type": "string"
+        },
+        "Spec": {
+          "description": "Definition of the DAG of the change.",
+          "type": "string"
+        },
+        "Status": {
+          "type": "string",
+          "enum": [
+            "pending",
+            "approved",
+            "rejected"
+          ]
+        }
+      }
+    },
+    "condition": {
+      "type": "object",
+      "properties": {
+        "Condition": {
+          "type": "string"
+        },
+        "Expected": {
+          "type": "string"
+        }
+      }
+    },
+    "createApiTokenRequest": {
+      "type": "object",
+      "required": [
+        "Name",
+        "Scopes"
+      ],
+      "properties": {
+        "ExpiresIn": {
+          "description": "Seconds until the token expires. The token does not expire if it is omitted.",
+          "type": "integer"
+        },
+        "Name": {
+          "description": "Name of the token, consisting of letters, digits, '-', '_' and '.'.",
+          "type": "string"
+        },
+        "Scopes": {
+          "description": "Scopes of the token, at least one of read, trigger and admin, and impersonate.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "createApiTokenResponse": {
+      "type": "object",
+      "required": [
+        "Token",
+        "Secret"
+      ],
+      "properties": {
+        "Secret": {
+          "description": "Secret of the token, the bearer token of the requests. It is not returned again.",
+          "type": "string"
+        },
+        "Token": {
+          "$ref": "#/definitions/apiToken"
+        }
+      }
+    },
+    "createDagResponse": {
+      "type": "object",
+      "required": [
+        "DagID"
+      ],
+      "properties": {
         "DagID": {
           "type": "string"
         }
       }
     },
-    "dag": {
+    "dag": {
+      "type": "object",
+      "required": [
+        "Group",
+        "Name",
+        "Schedule",
+        "Description",
+        "Params",
+        "DefaultParams",
+        "Tags"
+      ],
+      "properties": {
+        "DefaultParams": {
+          "type": "string"
+        },
+        "Description": {
+          "type": "string"
+        },
+        "Group": {
+          "type": "string"
+        },
+        "Name": {
+          "type": "string"
+        },
+        "Params": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Schedule": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/schedule"
+          }
+        },
+        "Tags": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "dagDetail": {
+      "type": "object",
+      "required": [
+        "Location",
+        "Group",
+        "Name",
+        "Schedule",
+        "Description",
+        "Env",
+        "LogDir",
+        "HandlerOn",
+        "Steps",
+        "Delay",
+        "HistRetentionDays",
+        "Preconditions",
+        "MaxActiveRuns",
+        "Params",
+        "DefaultParams",
+        "Tags"
+      ],
+      "properties": {
+        "DefaultParams": {
+          "type": "string"
+        },
+        "Delay": {
+          "type": "integer"
+        },
+        "Description": {
+          "type": "string"
+        },
+        "Env": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Group": {
+          "type": "string"
+        },
+        "Groups": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/stepGroup"
+          }
+        },
+        "HandlerOn": {
+          "$ref": "#/definitions/handlerOn"
+        },
+        "HistRetentionDays": {
+          "type": "integer"
+        },
+        "Location": {
+          "type": "string"
+        },
+        "LogDir": {
+          "type": "string"
+        },
+        "MaxActiveRuns": {
+          "type": "integer"
+        },
+        "Name": {
+          "type": "string"
+        },
+        "ParamDefs": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/paramDef"
+          }
+        },
+        "Params": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Preconditions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/condition"
+          }
+        },
+        "Schedule": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/schedule"
+          }
+        },
+        "Steps": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/stepObject"
+          }
+        },
+        "Tags": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Warnings": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/dagWarning"
+          }
+        }
+      }
+    },
+    "dagListItem": {
+      "type": "object",
+      "required": [
+        "File",
+        "Dir",
+        "DAG",
+        "Status",
+        "Suspended",
+        "Error",
+        "ErrorT"
+      ],
+      "properties": {
+        "DAG": {
+          "$ref": "#/definitions/dag"
+        },
+        "Dir": {
+          "type": "string"
+        },
+        "Error": {
+          "type": "string"
+        },
+        "ErrorT": {
+          "type": "string"
+        },
+        "File": {
+          "type": "string"
+        },
+        "Folder": {
+          "description": "Folder of the DAG in the DAGs directory, e.g. team-a/etl, empty in the directory itself.",
+          "type": "string"
+        },
+        "Status": {
+          "$ref": "#/definitions/dagStatus"
+        },
+        "Suspended": {
+          "type": "boolean"
+        }
+      }
+    },
+    "dagLogGridItem": {
+      "type": "object",
+      "required": [
+        "Name",
+        "Vals"
+      ],
+      "properties": {
+        "Name": {
+          "type": "string"
+        },
+        "Vals": {
+          "type": "array",
+          "items": {
+            "type": "integer"
+          }
+        }
+      }
+    },
+    "dagLogResponse": {
+      "type": "object",
+      "required": [
+        "GridData",
+        "Logs"
+      ],
+      "properties": {
+        "GridData": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/dagLogGridItem"
+          }
+        },
+        "HasMore": {
+          "description": "Whether there are older runs after the page.",
+          "type": "boolean"
+        },
+        "Logs": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/dagStatusFile"
+          }
+        }
+      }
+    },
+    "dagProblem": {
+      "type": "object",
+      "required": [
+        "Line",
+        "Field",
+        "Level",
+        "Message"
+      ],
+      "properties": {
+        "Field": {
+          "description": "Path of the field, e.g. steps[0].depends[1], if the problem is about a field.",
+          "type": "string"
+        },
+        "Level": {
+          "type": "string",
+          "enum": [
+            "error",
+            "warning"
+          ]
+        },
+        "Line": {
+          "description": "Line of the problem, starting at 1, or 0 if it is not known.",
+          "type": "integer"
+        },
+        "Message": {
+          "type": "string"
+        }
+      }
+    },
+    "dagSchedulerLogResponse": {
+      "type": "object",
+      "required": [
+        "LogFile",
+        "Content"
+      ],
+      "properties": {
+        "ArchivedFrom": {
+          "description": "Location of the archive the log was read from if it is no longer on the local disk.",
+          "type": "string"
+        },
+        "Content": {
+          "type": "string"
+        },
+        "LogFile": {
+          "type": "string"
+        },
+        "RemoteLocation": {
+          "description": "Location of the logs of the run in the log backend if the log was read from it because it is not on the local disk.",
+          "type": "string"
+        }
+      }
+    },
+    "dagStatus": {
+      "type": "object",
+      "required": [
+        "RequestId",
+        "Name",
+        "Status",
+        "StatusText",
+        "Pid",
+        "StartedAt",
+        "FinishedAt",
+        "Log",
+        "Params"
+      ],
+      "properties": {
+        "ArchiveLocation": {
+          "description": "Location the log files of the run were moved to when it was archived.",
+          "type": "string"
+        },
+        "FinishedAt": {
+          "type": "string"
+        },
+        "InitiatedBy": {
+          "description": "User the run was started by through the API, or on behalf of with a token with the impersonate scope.",
+          "type": "string"
+        },
+        "InitiatorToken": {
+          "description": "Name of the API token the run was started with.",
+          "type": "string"
+        },
+        "Lane": {
+          "description": "manual if the run was started by hand and queued ahead of the scheduled runs.",
+          "type": "string"
+        },
+        "Log": {
+          "type": "string"
+        },
+        "Name": {
+          "type": "string"
+        },
+        "Overtook": {
+          "description": "Request IDs of the scheduled runs queued before the run that it took the slot of its pool ahead of.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Params": {
+          "type": "string"
+        },
+        "Pid": {
+          "type": "integer"
+        },
+        "QueuePosition": {
+          "description": "Position of the run in the queue of its concurrency pool while it waits for a slot. It is 0 if the run does not wait.",
+          "type": "integer"
+        },
+        "RequestId": {
+          "type": "string"
+        },
+        "RestartedAs": {
+          "description": "Request ID of the run that restarted this run.",
+          "type": "string"
+        },
+        "RestartedFrom": {
+          "description": "Request ID of the run this run restarted.",
+          "type": "string"
+        },
+        "Schedule": {
+          "description": "Cron expression of the schedule entry that started the run.",
+          "type": "string"
+        },
+        "StartedAt": {
+          "type": "string"
+        },
+        "Status": {
+          "type": "integer"
+        },
+        "StatusText": {
+          "type": "string"
+        },
+        "StoppedBy": {
+          "description": "Cron expression of the schedule entry that stopped the run.",
+          "type": "string"
+        }
+      }
+    },
+    "dagStatusDetail": {
+      "type": "object",
+      "required": [
+        "RequestId",
+        "Name",
+        "Status",
+        "StatusText",
+        "Pid",
+        "Nodes",
+        "OnExit",
+        "OnSuccess",
+        "OnFailure",
+        "OnCancel",
+        "StartedAt",
+        "FinishedAt",
+        "Log",
+        "Params"
+      ],
+      "properties": {
+        "ArchiveLocation": {
+          "description": "Location the log files of the run were moved to when it was archived.",
+          "type": "string"
+        },
+        "FinishedAt": {
+          "type": "string"
+        },
+        "InitiatedBy": {
+          "description": "User the run was started by through the API, or on behalf of with a token with the impersonate scope.",
+          "type": "string"
+        },
+        "InitiatorToken": {
+          "description": "Name of the API token the run was started with.",
+          "type": "string"
+        },
+        "Lane": {
+          "description": "manual if the run was started by hand and queued ahead of the scheduled runs.",
+          "type": "string"
+        },
+        "Log": {
+          "type": "string"
+        },
+        "Name": {
+          "type": "string"
+        },
+        "Nodes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/statusNode"
+          }
+        },
+        "OnCancel": {
+          "$ref": "#/definitions/statusNode"
+        },
+        "OnExit": {
+          "$ref": "#/definitions/statusNode"
+        },
+        "OnFailure": {
+          "$ref": "#/definitions/statusNode"
+        },
+        "OnSuccess": {
+          "$ref": "#/definitions/statusNode"
+        },
+        "Overtook": {
+          "description": "Request IDs of the scheduled runs queued before the run that it took the slot of its pool ahead of.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Params": {
+          "type": "string"
+        },
+        "Pid": {
+          "type": "integer"
+        },
+        "QueuePosition": {
+          "description": "Position of the run in the queue of its concurrency pool while it waits for a slot. It is 0 if the run does not wait.",
+          "type": "integer"
+        },
+        "RequestId": {
+          "type": "string"
+        },
+        "RestartedAs": {
+          "description": "Request ID of the run that restarted this run.",
+          "type": "string"
+        },
+        "RestartedFrom": {
+          "description": "Request ID of the run this run restarted.",
+          "type": "string"
+        },
+        "Schedule": {
+          "description": "Cron expression of the schedule entry that started the run.",
+          "type": "string"
+        },
+        "StartedAt": {
+          "type": "string"
+        },
+        "Status": {
+          "type": "integer"
+        },
+        "StatusText": {
+          "type": "string"
+        },
+        "StoppedBy": {
+          "description": "Cron expression of the schedule entry that stopped the run.",
+          "type": "string"
+        }
+      }
+    },
+    "dagStatusFile": {
+      "type": "object",
+      "required": [
+        "File"
+      ],
+      "properties": {
+        "File": {
+          "type": "string"
+        },
+        "Status": {
+          "$ref": "#/definitions/dagStatusDetail"
+        }
+      }
+    },
+    "dagStatusWithDetails": {
+      "type": "object",
+      "required": [
+        "File",
+        "Dir",
+        "DAG",
+        "Status",
+        "Suspended",
+        "Error",
+        "ErrorT"
+      ],
+      "properties": {
+        "DAG": {
+          "$ref": "#/definitions/dagDetail"
+        },
+        "Dir": {
+          "type": "string"
+        },
+        "Error": {
+          "type": "string"
+        },
+        "ErrorT": {
+          "type": "string"
+        },
+        "File": {
+          "type": "string"
+        },
+        "Folder": {
+          "description": "Folder of the DAG in the DAGs directory, e.g. team-a/etl, empty in the directory itself.",
+          "type": "string"
+        },
+        "Status": {
+          "$ref": "#/definitions/dagStatusDetail"
+        },
+        "Suspended": {
+          "type": "boolean"
+        }
+      }
+    },
+    "dagStepLogResponse": {
+      "type": "object",
+      "required": [
+        "Step",
+        "LogFile",
+        "Content"
+      ],
+      "properties": {
+        "ArchivedFrom": {
+          "description": "Location of the archive the log was read from if it is no longer on the local disk.",
+          "type": "string"
+        },
+        "Content": {
+          "type": "string"
+        },
+        "LogFile": {
+          "type": "string"
+        },
+        "RemoteLocation": {
+          "description": "Location of the logs of the run in the log backend if the log was read from it because it is not on the local disk.",
+          "type": "string"
+        },
+        "Step": {
+          "$ref": "#/definitions/statusNode"
+        }
+      }
+    },
+    "dagWarning": {
+      "type": "object",
+      "required": [
+        "Code",
+        "Field",
+        "Message"
+      ],
+      "properties": {
+        "Code": {
+          "type": "string",
+          "enum": [
+            "deprecated",
+            "fieldCase"
+          ]
+        },
+        "Field": {
+          "type": "string"
+        },
+        "Message": {
+          "type": "string"
+        }
+      }
+    },
+    "driftDefinition": {
+      "type": "object",
+      "required": [
+        "Name",
+        "File",
+        "Digest"
+      ],
+      "properties": {
+        "Digest": {
+          "description": "SHA-256 of the contents of the file in hex.",
+          "type": "string"
+        },
+        "File": {
+          "description": "Name of the file of the DAG in the DAGs directory.",
+          "type": "string"
+        },
+        "Name": {
+          "type": "string"
+        }
+      }
+    },
+    "driftDifference": {
+      "type": "object",
+      "required": [
+        "File",
+        "Kind"
+      ],
+      "properties": {
+        "File": {
+          "type": "string"
+        },
+        "Kind": {
+          "description": "changed if the definitions differ, missing if the remote node does not have the DAG, and extra if only the remote node has it.",
+          "type": "string",
+          "enum": [
+            "changed",
+            "missing",
+            "extra"
+          ]
+        },
+        "LocalDigest": {
+          "type": "string"
+        },
+        "RemoteDigest": {
+          "type": "string"
+        }
+      }
+    },
+    "driftInventoryResponse": {
+      "type": "object",
+      "required": [
+        "Version",
+        "Definitions"
+      ],
+      "properties": {
+        "Definitions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/driftDefinition"
+          }
+        },
+        "Version": {
+          "type": "string"
+        }
+      }
+    },
+    "driftNode": {
+      "type": "object",
+      "required": [
+        "Name",
+        "URL",
+        "Version",
+        "InSync",
+        "Differences"
+      ],
+      "properties": {
+        "Differences": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/driftDifference"
+          }
+        },
+        "Error": {
+          "description": "Why the remote node could not be compared, e.g. it is unreachable.",
+          "type": "string"
+        },
+        "InSync": {
+          "description": "Whether the remote node has the same version and DAG definitions.",
+          "type": "boolean"
+        },
+        "Name": {
+          "type": "string"
+        },
+        "URL": {
+          "type": "string"
+        },
+        "Version": {
+          "description": "Version of the remote node, empty if it could not be compared.",
+          "type": "string"
+        }
+      }
+    },
+    "driftReportResponse": {
+      "type": "object",
+      "required": [
+        "Version",
+        "CheckedAt",
+        "Nodes",
+        "Errors"
+      ],
+      "properties": {
+        "CheckedAt": {
+          "type": "string"
+        },
+        "Errors": {
+          "description": "DAGs of the server that could not be read.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Nodes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/driftNode"
+          }
+        },
+        "Version": {
+          "type": "string"
+        }
+      }
+    },
+    "gcItem": {
+      "type": "object",
+      "properties": {
+        "Kind": {
+          "type": "string",
+          "enum": [
+            "socket",
+            "temp",
+            "artifact",
+            "lock"
+          ]
+        },
+        "Path": {
+          "type": "string"
+        },
+        "Size": {
+          "type": "integer"
+        }
+      }
+    },
+    "gcReportResponse": {
+      "type": "object",
+      "required": [
+        "StartedAt",
+        "FinishedAt",
+        "Removed",
+        "Size",
+        "Errors"
+      ],
+      "properties": {
+        "Errors": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "FinishedAt": {
+          "type": "string"
+        },
+        "Removed": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/gcItem"
+          }
+        },
+        "Size": {
+          "description": "Total size of the removed files in bytes.",
+          "type": "integer"
+        },
+        "StartedAt": {
+          "type": "string"
+        }
+      }
+    },
+    "getDagDetailsResponse": {
+      "type": "object",
+      "required": [
+        "Title",
+        "DAG",
+        "Tab",
+        "Graph",
+        "Definition",
+        "LogData",
+        "LogUrl",
+        "StepLog",
+        "ScLog",
+        "Errors"
+      ],
+      "properties": {
+        "DAG": {
+          "$ref": "#/definitions/dagStatusWithDetails"
+        },
+        "Definition": {
+          "type": "string"
+        },
+        "Errors": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Graph": {
+          "type": "string"
+        },
+        "LogData": {
+          "$ref": "#/definitions/dagLogResponse"
+        },
+        "LogUrl": {
+          "type": "string"
+        },
+        "ScLog": {
+          "$ref": "#/definitions/dagSchedulerLogResponse"
+        },
+        "StepLog": {
+          "$ref": "#/definitions/dagStepLogResponse"
+        },
+        "Tab": {
+          "type": "string"
+        },
+        "Title": {
+          "type": "string"
+        }
+      }
+    },
+    "getDagStatusesResponse": {
+      "type": "object",
+      "required": [
+        "DAGs",
+        "Errors"
+      ],
+      "properties": {
+        "DAGs": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/dagListItem"
+          }
+        },
+        "Errors": {
+          "description": "Errors of the DAGs of the response, of the names that are not the ones of DAGs the request is allowed to view, and of the requested DAG files that cannot be read.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "haltResponse": {
+      "type": "object",
+      "required": [
+        "Halted"
+      ],
+      "properties": {
+        "Errors": {
+          "description": "Errors of the DAGs that could not be stopped.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Halted": {
+          "type": "boolean"
+        },
+        "Stopped": {
+          "description": "Names of the DAGs that were stopped.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "handlerOn": {
+      "type": "object",
+      "properties": {
+        "Cancel": {
+          "$ref": "#/definitions/stepObject"
+        },
+        "Exit": {
+          "$ref": "#/definitions/stepObject"
+        },
+        "Failure": {
+          "$ref": "#/definitions/stepObject"
+        },
+        "Success": {
+          "$ref": "#/definitions/stepObject"
+        },
+        "Timeout": {
+          "$ref": "#/definitions/stepObject"
+        }
+      }
+    },
+    "listApiTokensResponse": {
+      "type": "object",
+      "required": [
+        "Tokens"
+      ],
+      "properties": {
+        "Tokens": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/apiToken"
+          }
+        }
+      }
+    },
+    "listArtifactsResponse": {
+      "type": "object",
+      "required": [
+        "Artifacts"
+      ],
+      "properties": {
+        "Artifacts": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/artifact"
+          }
+        }
+      }
+    },
+    "listAuditEntriesResponse": {
+      "type": "object",
+      "required": [
+        "Entries"
+      ],
+      "properties": {
+        "Entries": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/auditEntry"
+          }
+        }
+      }
+    },
+    "listChangesResponse": {
+      "type": "object",
+      "required": [
+        "Changes"
+      ],
+      "properties": {
+        "Changes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/change"
+          }
+        }
+      }
+    },
+    "listDagsResponse": {
+      "type": "object",
+      "required": [
+        "DAGs",
+        "Errors",
+        "HasError",
+        "Total"
+      ],
+      "properties": {
+        "DAGs": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/dagListItem"
+          }
+        },
+        "Errors": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Folders": {
+          "description": "Subfolders of the folder of the request, e.g. team-a/etl, if it is set.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "HasError": {
+          "type": "boolean"
+        },
+        "Total": {
+          "description": "Number of the DAGs that match the filters of the request.",
+          "type": "integer"
+        }
+      }
+    },
+    "listFoldersResponse": {
+      "type": "object",
+      "required": [
+        "Folders"
+      ],
+      "properties": {
+        "Folders": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "listTriggersResponse": {
+      "type": "object",
+      "required": [
+        "Triggers"
+      ],
+      "properties": {
+        "Triggers": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/trigger"
+          }
+        }
+      }
+    },
+    "metaResponse": {
+      "type": "object",
+      "required": [
+        "Version",
+        "APIVersion",
+        "Features",
+        "Executors",
+        "AuthModes",
+        "Storage"
+      ],
+      "properties": {
+        "APIVersion": {
+          "description": "Version of the REST API, the path prefix of its endpoints without the leading /api/.",
+          "type": "string"
+        },
+        "AuthModes": {
+          "description": "Modes of the authentication of the requests, none if the requests are not authenticated.",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "enum": [
+              "none",
+              "basic",
+              "token",
+              "oidc",
+              "ldap"
+            ]
+          }
+        },
+        "Executors": {
+          "description": "Types of the executors built into the server.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Features": {
+          "description": "Optional features of the API that the server has and that are enabled.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Namespaces": {
+          "description": "Namespaces whose API is served under /api/v1/namespaces/{name} besides the default one.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Storage": {
+          "$ref": "#/definitions/metaStorage"
+        },
+        "Version": {
+          "type": "string"
+        }
+      }
+    },
+    "metaStorage": {
+      "type": "object",
+      "required": [
+        "History",
+        "Artifacts",
+        "Logs"
+      ],
+      "properties": {
+        "Archive": {
+          "description": "Backend of the archive of the old runs, empty if the runs are not archived.",
+          "type": "string"
+        },
+        "Artifacts": {
+          "description": "Backend of the artifacts of the runs.",
+          "type": "string"
+        },
+        "History": {
+          "description": "Backend of the history of the runs.",
+          "type": "string"
+        },
+        "Logs": {
+          "description": "Backend of the step logs.",
+          "type": "string"
+        }
+      }
+    },
+    "paramDef": {
+      "type": "object",
+      "required": [
+        "Name",
+        "Type"
+      ],
+      "properties": {
+        "Default": {
+          "type": "string"
+        },
+        "Description": {
+          "type": "string"
+        },
+        "Max": {
+          "type": "integer",
+          "x-nullable": true
+        },
+        "Min": {
+          "type": "integer",
+          "x-nullable": true
+        },
+        "Name": {
+          "type": "string"
+        },
+        "Pattern": {
+          "type": "string"
+        },
+        "Required": {
+          "type": "boolean"
+        },
+        "Source": {
+          "description": "The URL of the source the default is read from when a run is started",
+          "type": "string"
+        },
+        "Type": {
+          "type": "string",
+          "enum": [
+            "string",
+            "int",
+            "bool",
+            "enum",
+            "date"
+          ]
+        },
+        "Values": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "postBulkActionResponse": {
+      "type": "object",
+      "required": [
+        "Results"
+      ],
+      "properties": {
+        "Results": {
+          "description": "Results of the DAGs and of the runs, in the order of the names, of the tag and of the runs.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/bulkActionResult"
+          }
+        }
+      }
+    },
+    "postDagActionResponse": {
+      "type": "object",
+      "properties": {
+        "ChangeId": {
+          "description": "ID of the pending change of save if the namespace is under change control.",
+          "type": "string"
+        },
+        "NewDagID": {
+          "type": "string"
+        },
+        "Outputs": {
+          "description": "Output variables of the steps of the run, if it was waited for.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "Queued": {
+          "description": "True if the run of start is in the trigger queue until the scheduler starts it.",
+          "type": "boolean"
+        },
+        "RequestId": {
+          "description": "Request ID of the run of start.",
+          "type": "string"
+        },
+        "Status": {
+          "$ref": "#/definitions/dagStatusDetail"
+        }
+      }
+    },
+    "repeatPolicy": {
+      "type": "object",
+      "properties": {
+        "Interval": {
+          "type": "integer"
+        },
+        "Repeat": {
+          "type": "boolean"
+        }
+      }
+    },
+    "reviewChangeRequest": {
+      "type": "object",
+      "required": [
+        "Action"
+      ],
+      "properties": {
+        "Action": {
+          "type": "string",
+          "enum": [
+            "approve",
+            "reject"
+          ]
+        },
+        "Reason": {
+          "description": "Reason of the rejection.",
+          "type": "string"
+        }
+      }
+    },
+    "schedule": {
+      "type": "object",
+      "required": [
+        "Expression"
+      ],
+      "properties": {
+        "Expression": {
+          "type": "string"
+        }
+      }
+    },
+    "searchDagsLogItem": {
+      "type": "object",
+      "properties": {
+        "Matches": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/searchDagsMatchItem"
+          }
+        },
+        "RequestId": {
+          "type": "string"
+        },
+        "Step": {
+          "type": "string"
+        }
+      }
+    },
+    "searchDagsMatchItem": {
+      "type": "object",
+      "properties": {
+        "Line": {
+          "type": "string"
+        },
+        "LineNumber": {
+          "type": "integer"
+        },
+        "StartLine": {
+          "type": "integer"
+        }
+      }
+    },
+    "searchDagsResponse": {
+      "type": "object",
+      "required": [
+        "Results",
+        "Errors"
+      ],
+      "properties": {
+        "Errors": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Results": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/searchDagsResultItem"
+          }
+        }
+      }
+    },
+    "searchDagsResultItem": {
+      "type": "object",
+      "properties": {
+        "DAG": {
+          "$ref": "#/definitions/dag"
+        },
+        "Fields": {
+          "description": "The fields that matched, the most relevant first, of name, tag, description, command and log.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Logs": {
+          "description": "The step logs of the recent runs that matched, the latest first.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/searchDagsLogItem"
+          }
+        },
+        "Matches": {
+          "description": "The lines of the definition that matched.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/searchDagsMatchItem"
+          }
+        },
+        "Name": {
+          "type": "string"
+        },
+        "Score": {
+          "description": "The relevance of the DAG, higher for the matches of the more relevant fields.",
+          "type": "integer"
+        }
+      }
+    },
+    "statusNode": {
+      "type": "object",
+      "required": [
+        "Step",
+        "Log",
+        "StartedAt",
+        "FinishedAt",
+        "Status",
+        "RetryCount",
+        "DoneCount",
+        "Error",
+        "StatusText"
+      ],
+      "properties": {
+        "DoneCount": {
+          "type": "integer"
+        },
+        "Error": {
+          "type": "string"
+        },
+        "FinishedAt": {
+          "type": "string"
+        },
+        "Log": {
+          "type": "string"
+        },
+        "Preview": {
+          "description": "Command of the step with the variables expanded and the secret values masked.",
+          "type": "string"
+        },
+        "RetryCount": {
+          "type": "integer"
+        },
+        "StartedAt": {
+          "type": "string"
+        },
+        "Status": {
+          "type": "integer"
+        },
+        "StatusText": {
+          "type": "string"
+        },
+        "Step": {
+          "$ref": "#/definitions/stepObject"
+        },
+        "SubRunRequestId": {
+          "description": "Request ID of the sub DAG run of the step.",
+          "type": "string"
+        }
+      }
+    },
+    "stepGroup": {
+      "type": "object",
+      "properties": {
+        "Groups": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/stepGroup"
+          }
+        },
+        "Name": {
+          "type": "string"
+        },
+        "Path": {
+          "type": "string"
+        },
+        "Steps": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "stepObject": {
+      "type": "object",
+      "required": [
+        "Name",
+        "Description",
+        "Variables",
+        "Dir",
+        "CmdWithArgs",
+        "Command",
+        "Script",
+        "Stdout",
+        "Stderr",
+        "Output",
+        "Args",
+        "Depends",
+        "RepeatPolicy",
+        "MailOnError",
+        "Preconditions"
+      ],
+      "properties": {
+        "Args": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "CmdWithArgs": {
+          "type": "string"
+        },
+        "Command": {
+          "type": "string"
+        },
+        "Depends": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Description": {
+          "type": "string"
+        },
+        "Dir": {
+          "type": "string"
+        },
+        "Group": {
+          "type": "string"
+        },
+        "MailOnError": {
+          "type": "boolean"
+        },
+        "Name": {
+          "type": "string"
+        },
+        "Output": {
+          "type": "string"
+        },
+        "Params": {
+          "type": "string"
+        },
+        "Preconditions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/condition"
+          }
+        },
+        "RepeatPolicy": {
+          "$ref": "#/definitions/repeatPolicy"
+        },
+        "Run": {
+          "type": "string"
+        },
+        "Script": {
+          "type": "string"
+        },
+        "Stage": {
+          "type": "string"
+        },
+        "Stderr": {
+          "type": "string"
+        },
+        "Stdout": {
+          "type": "string"
+        },
+        "Variables": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "trigger": {
+      "type": "object",
+      "required": [
+        "Id",
+        "DAG",
+        "RequestId",
+        "ReceivedAt",
+        "Attempts"
+      ],
+      "properties": {
+        "Attempts": {
+          "description": "Number of the times the scheduler read the trigger.",
+          "type": "integer"
+        },
+        "DAG": {
+          "type": "string"
+        },
+        "Error": {
+          "description": "Reason the last attempt did not start the run, e.g. the DAG was running.",
+          "type": "string"
+        },
+        "Id": {
+          "type": "integer"
+        },
+        "InitiatedBy": {
+          "description": "User the run was started by through the API.",
+          "type": "string"
+        },
+        "InitiatorToken": {
+          "description": "Name of the API token the run was started with.",
+          "type": "string"
+        },
+        "Params": {
+          "type": "string"
+        },
+        "ReceivedAt": {
+          "type": "string"
+        },
+        "RequestId": {
+          "type": "string"
+        }
+      }
+    },
+    "validateDagRequest": {
+      "type": "object",
+      "required": [
+        "Definition"
+      ],
+      "properties": {
+        "DagId": {
+          "description": "Name of the DAG the definition is of, whose directory the included files are relative to. The DAG does not need to exist.",
+          "type": "string"
+        },
+        "Definition": {
+          "description": "YAML definition of the DAG.",
+          "type": "string"
+        }
+      }
+    },
+    "validateDagResponse": {
+      "type": "object",
+      "required": [
+        "Valid",
+        "Problems"
+      ],
+      "properties": {
+        "Problems": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/dagProblem"
+          }
+        },
+        "Valid": {
+          "description": "Whether the definition has no errors. It may have warnings.",
+          "type": "boolean"
+        }
+      }
+    }
+  }
+}`))
+	FlatSwaggerJSON = json.RawMessage([]byte(`{
+  "consumes": [
+    "application/json"
+  ],
+  "produces": [
+    "application/json"
+  ],
+  "schemes": [
+    "http"
+  ],
+  "swagger": "2.0",
+  "info": {
+    "title": "Dagu",
+    "version": "0.0.1"
+  },
+  "host": "localhost:8080",
+  "basePath": "/api/v1",
+  "paths": {
+    "/audit": {
+      "get": {
+        "description": "Returns the entries of the audit log of the actions on the DAGs and the runs, the latest first. It requires a token with the admin scope.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "listAuditEntries",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Returns the entries of the DAG.",
+            "name": "dag",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Returns the entries of the action, e.g. start or delete.",
+            "name": "action",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Returns the entries of the user or of the API token of the name.",
+            "name": "actor",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Returns the entries at or after the time, in RFC 3339.",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Returns the entries before the time, in RFC 3339.",
+            "name": "until",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Maximum number of the entries, 100 by default.",
+            "name": "limit",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/listAuditEntriesResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/bulk": {
+      "post": {
+        "description": "Performs an action on several DAGs in one request, the ones of the names, of the runs and of the tag, and returns the result of each of them. The action on a DAG that fails does not stop the others.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "postBulkAction",
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "type": "object",
+              "required": [
+                "action"
+              ],
+              "properties": {
+                "action": {
+                  "type": "string",
+                  "enum": [
+                    "start",
+                    "stop",
+                    "suspend",
+                    "resume",
+                    "retry"
+                  ]
+                },
+                "names": {
+                  "description": "Names of the DAGs, e.g. etl and team-a/report.",
+                  "type": "array",
+                  "items": {
+                    "type": "string"
+                  }
+                },
+                "params": {
+                  "description": "Parameters of the runs of start.",
+                  "type": "string"
+                },
+                "runs": {
+                  "description": "Runs to retry, of the retry action only. The DAGs of the names and of the tag retry their latest runs.",
+                  "type": "array",
+                  "items": {
+                    "$ref": "#/definitions/bulkActionRun"
+                  }
+                },
+                "tag": {
+                  "description": "Tag of the DAGs the request is allowed to view, in the order of their names.",
+                  "type": "string"
+                }
+              }
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/postBulkActionResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/changes": {
+      "get": {
+        "description": "Returns the changes of the DAGs of the namespace under change control, the latest first.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "listChanges",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Returns the changes of the DAG.",
+            "name": "dag",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Returns the changes of the status, pending, approved or rejected.",
+            "name": "status",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/listChangesResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/changes/{changeId}": {
+      "post": {
+        "description": "Approves or rejects a pending change of a DAG. The definition of an approved change is saved, and a user other than the author of the change approves it.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "reviewChange",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "changeId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/reviewChangeRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/change"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/dags": {
+      "get": {
+        "description": "Returns a list of DAGs.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "listDags",
+        "parameters": [
+          {
+            "type": "integer",
+            "description": "Maximum number of the DAGs, all of them if it is not set.",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Number of the DAGs to skip.",
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "name",
+              "lastRun",
+              "status"
+            ],
+            "type": "string",
+            "description": "Order of the DAGs, name by default. The DAGs of the same order are sorted by their names.",
+            "name": "sort",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "asc",
+              "desc"
+            ],
+            "type": "string",
+            "name": "order",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Returns the DAGs with the tag.",
+            "name": "tag",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Returns the DAGs whose latest runs have the status, e.g. running or failed.",
+            "name": "status",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "Returns the DAGs with schedules if it is true, and the ones without them if it is false.",
+            "name": "scheduled",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Returns the DAGs in the folder of the DAGs directory, e.g. team-a/etl, and not the ones of its subfolders. The DAGs directory itself is /.",
+            "name": "folder",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/listDagsResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Creates a new DAG.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "createDag",
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "schema": {
+              "type": "object",
+              "required": [
+                "action",
+                "value"
+              ],
+              "properties": {
+                "action": {
+                  "type": "string"
+                },
+                "value": {
+                  "type": "string"
+                }
+              }
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/createDagResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}": {
+      "get": {
+        "description": "Returns details of a DAG.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "getDagDetails",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "tab",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "name": "file",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "name": "step",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Request ID of the run to show instead of the latest run.",
+            "name": "requestId",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Maximum number of the runs of the history tab, 30 by default.",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Number of the latest runs to skip in the history tab.",
+            "name": "offset",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/getDagDetailsResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Performs an action on a DAG.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "postDagAction",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "boolean",
+            "description": "Waits for the run of start to finish, and returns its status and the outputs of its steps.",
+            "name": "wait",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Maximum time to wait for the run, e.g. 300s or 10m, 5 minutes by default. The status of the run is returned as it is if it has not finished by then.",
+            "name": "timeout",
+            "in": "query"
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "schema": {
+              "type": "object",
+              "required": [
+                "action"
+              ],
+              "properties": {
+                "action": {
+                  "type": "string",
+                  "enum": [
+                    "start",
+                    "suspend",
+                    "stop",
+                    "retry",
+                    "mark-success",
+                    "mark-failed",
+                    "save",
+                    "rename",
+                    "move"
+                  ]
+                },
+                "chaos": {
+                  "description": "Faults injected into the steps of the run of start, e.g. fail=extract or delay=load:30s. Only the servers that allow chaos accept them.",
+                  "type": "array",
+                  "items": {
+                    "type": "string"
+                  }
+                },
+                "params": {
+                  "type": "string"
+                },
+                "reason": {
+                  "description": "Reason of the operator who marks the status of a step. It is required by mark-success and mark-failed.",
+                  "type": "string"
+                },
+                "requestId": {
+                  "type": "string"
+                },
+                "step": {
+                  "type": "string"
+                },
+                "value": {
+                  "description": "The new name of rename, which is a path in the DAGs directory to move the DAG to a folder, e.g. team-a/etl, or the folder of move, / being the DAGs directory itself.",
+                  "type": "string"
+                }
+              }
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/postDagActionResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Deletes a DAG.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "deleteDag",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response."
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/graph": {
+      "get": {
+        "description": "Exports the dependency graph of the steps of a DAG, to embed it in documents and dashboards.",
+        "produces": [
+          "text/plain",
+          "text/vnd.graphviz",
+          "image/svg+xml",
+          "application/json"
+        ],
+        "operationId": "getDagGraph",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "enum": [
+              "dot",
+              "mermaid",
+              "svg"
+            ],
+            "type": "string",
+            "description": "Format of the graph, mermaid by default.",
+            "name": "format",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "Colors the steps by their statuses in the latest run, or in the run of requestId.",
+            "name": "status",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Request ID of the run to color the steps by instead of the latest run.",
+            "name": "requestId",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The graph in the format.",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/runs/{requestId}/artifacts": {
+      "get": {
+        "description": "Returns the artifacts of a run of a DAG with their sizes and digests.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "listArtifacts",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "requestId",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/listArtifactsResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Deletes an artifact of a run of a DAG, from the bucket of the artifacts too.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "deleteArtifact",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "requestId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Path of the artifact relative to the artifacts of the run.",
+            "name": "path",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response."
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/runs/{requestId}/artifacts/download": {
+      "get": {
+        "description": "Downloads an artifact of a run of a DAG.",
+        "produces": [
+          "application/octet-stream",
+          "application/json"
+        ],
+        "operationId": "downloadArtifact",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "requestId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Path of the artifact relative to the artifacts of the run.",
+            "name": "path",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The contents of the artifact.",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/runs/{requestId}/artifacts/preview": {
+      "get": {
+        "description": "Returns the preview of an image, HTML, JSON or CSV artifact of a run of a DAG to show it in the browser. The HTML documents are sanitized.",
+        "produces": [
+          "image/png",
+          "text/html",
+          "application/json"
+        ],
+        "operationId": "previewArtifact",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "requestId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Path of the artifact relative to the artifacts of the run.",
+            "name": "path",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The preview of the artifact.",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/runs/{requestId}/artifacts/url": {
+      "get": {
+        "description": "Returns a pre-signed URL of an artifact of a run of a DAG, which downloads the artifact from the bucket of the artifacts without other credentials until it expires. It requires the s3 artifact backend.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "getArtifactURL",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "requestId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Path of the artifact relative to the artifacts of the run.",
+            "name": "path",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "description": "Seconds until the URL expires. The default is 900 and the maximum is 604800, 7 days.",
+            "name": "expiresIn",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/artifactURLResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/runs/{requestId}/steps/{stepName}/log/stream": {
+      "get": {
+        "description": "Streams the log of a step of a run of a DAG as server-sent events, like tail -f. Each line of the log is sent as the data of a message event whose id is the offset of the log after the line, and an end event is sent once the step finished and its log was sent. A client that reconnects with the Last-Event-ID header continues from the offset.",
+        "produces": [
+          "text/event-stream",
+          "application/json"
+        ],
+        "operationId": "streamStepLog",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "requestId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "stepName",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "description": "Offset in bytes of the log to stream from. The default is 0, the start of the log.",
+            "name": "offset",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The events of the log.",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/drift": {
+      "get": {
+        "description": "Compares the DAG definitions and the version of the server with the ones of the remote nodes of the configuration.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "getDriftReport",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/driftReportResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/drift/inventory": {
+      "get": {
+        "description": "Returns the version of the server and the digests of the files of its DAGs, which the other servers compare theirs with.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "getDriftInventory",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/driftInventoryResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/folders": {
+      "get": {
+        "description": "Returns the folders of the DAGs directory, e.g. team-a and team-a/etl.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "listFolders",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/listFoldersResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Creates a folder and its parents in the DAGs directory.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "createFolder",
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "type": "object",
+              "required": [
+                "folder"
+              ],
+              "properties": {
+                "folder": {
+                  "type": "string"
+                }
+              }
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response."
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/folders/{folder}": {
+      "delete": {
+        "description": "Deletes a folder of the DAGs directory, which must be empty. The slashes of the folder are escaped, e.g. team-a%2Fetl.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "deleteFolder",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "folder",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response."
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/gc/report": {
+      "get": {
+        "description": "Returns the report of the last garbage collection of orphaned files.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "getGcReport",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/gcReportResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/halt": {
+      "get": {
+        "description": "Returns whether the schedules of all the DAGs are halted by a stop of all the DAGs.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "getHalt",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/haltResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Stops all the running DAGs and halts the schedules of all the DAGs until they are resumed, e.g. during a data corruption incident. It requires the admin role on all the DAGs.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "postHalt",
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "type": "object",
+              "required": [
+                "confirm"
+              ],
+              "properties": {
+                "confirm": {
+                  "description": "Must be true, so that the DAGs are not stopped by mistake.",
+                  "type": "boolean"
+                },
+                "reason": {
+                  "description": "Reason of the stop, recorded in the audit log.",
+                  "type": "string"
+                }
+              }
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/haltResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Resumes the schedules halted by a stop of all the DAGs. It requires the admin role on all the DAGs.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "deleteHalt",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/haltResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/meta": {
+      "get": {
+        "description": "Returns the version and the capabilities of the server, so that the clients can adapt to the servers of older versions.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "getMeta",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/metaResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/openapi.json": {
+      "get": {
+        "description": "Returns the OpenAPI 3 document of the REST API, from which the clients of the API are generated.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "getOpenAPI",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "type": "object"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/search": {
+      "get": {
+        "description": "Searches the names, the descriptions, the tags and the step commands of the DAGs, and optionally the logs of their recent runs, for the words of the query, the most relevant DAGs first.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "searchDags",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The words the DAGs must all have, each as the prefix of a word.",
+            "name": "q",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "boolean",
+            "description": "Searches the step logs of the recent runs too if it is true.",
+            "name": "logs",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/searchDagsResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/status": {
+      "get": {
+        "description": "Returns the latest status of each of the DAGs of the names or of the tag in one response, e.g. for a status page.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "getDagStatuses",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Comma-separated names of the DAGs, e.g. etl,report. The statuses are in the order of the names.",
+            "name": "names",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Returns the statuses of the DAGs with the tag, sorted by their names.",
+            "name": "tag",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/getDagStatusesResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/tokens": {
+      "get": {
+        "description": "Returns the API tokens created with the API or the CLI, without their secrets. It requires a token with the admin scope.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "listApiTokens",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/listApiTokensResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Creates an API token with scopes, which expires after expiresIn seconds unless it is omitted. Its secret is returned once and is not stored. It requires a token with the admin scope.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "createApiToken",
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createApiTokenRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/createApiTokenResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/tokens/{tokenName}": {
+      "delete": {
+        "description": "Deletes an API token created with the API or the CLI. It requires a token with the admin scope.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "deleteApiToken",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "tokenName",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response."
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/triggers": {
+      "get": {
+        "description": "Returns the runs started with the API that are in the trigger queue until the scheduler starts them, the oldest first.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "listTriggers",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/listTriggersResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    },
+    "/validate": {
+      "post": {
+        "description": "Validates a DAG definition without saving it, e.g. the content of an editor as the user types, and returns its problems with their lines.",
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "validateDag",
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/validateDagRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/validateDagResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/ApiError"
+            }
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "ApiError": {
+      "type": "object",
+      "required": [
+        "message",
+        "detailedMessage",
+        "code",
+        "category"
+      ],
+      "properties": {
+        "category": {
+          "description": "Category of the error code.",
+          "type": "string",
+          "enum": [
+            "internal",
+            "validation",
+            "not_found",
+            "conflict",
+            "timeout",
+            "permission"
+          ]
+        },
+        "code": {
+          "description": "Stable machine-readable error code.",
+          "type": "string"
+        },
+        "detailedMessage": {
+          "type": "string"
+        },
+        "message": {
+          "type": "string"
+        }
+      }
+    },
+    "apiToken": {
       "type": "object",
       "required": [
-        "Group",
         "Name",
-        "Schedule",
-        "Description",
-        "Params",
-        "DefaultParams",
-        "Tags"
+        "Scopes",
+        "CreatedAt",
+        "ExpiresAt",
+        "Expired"
       ],
       "properties": {
-        "DefaultParams": {
+        "CreatedAt": {
           "type": "string"
         },
-        "Description": {
-          "type": "string"
+        "Expired": {
+          "type": "boolean"
         },
-        "Group": {
+        "ExpiresAt": {
+          "description": "Time the token expires at, empty if it does not expire.",
           "type": "string"
         },
         "Name": {
           "type": "string"
         },
-        "Params": {
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
-        },
-        "Schedule": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/schedule"
-          }
-        },
-        "Tags": {
+        "Scopes": {
           "type": "array",
           "items": {
             "type": "string"
@@ -352,563 +4673,611 @@ func init() {
         }
       }
     },
-    "dagDetail": {
+    "artifact": {
       "type": "object",
       "required": [
-        "Location",
-        "Group",
-        "Name",
-        "Schedule",
-        "Description",
-        "Env",
-        "LogDir",
-        "HandlerOn",
-        "Steps",
-        "Delay",
-        "HistRetentionDays",
-        "Preconditions",
-        "MaxActiveRuns",
-        "Params",
-        "DefaultParams",
-        "Tags"
+        "Path",
+        "Size",
+        "ModTime"
       ],
       "properties": {
-        "DefaultParams": {
+        "ETag": {
+          "description": "ETag of the object of the artifact that is only in the bucket of the artifacts.",
           "type": "string"
         },
-        "Delay": {
+        "ModTime": {
+          "type": "string"
+        },
+        "Path": {
+          "description": "Path of the artifact relative to the artifacts of the run.",
+          "type": "string"
+        },
+        "SHA256": {
+          "description": "SHA-256 of the contents in hex, empty if the artifact is only in the bucket of the artifacts.",
+          "type": "string"
+        },
+        "Size": {
           "type": "integer"
         },
-        "Description": {
+        "Type": {
+          "description": "Type of the artifact by the extension of its file, file if it has no preview.",
+          "type": "string",
+          "enum": [
+            "image",
+            "html",
+            "json",
+            "csv",
+            "file"
+          ]
+        }
+      }
+    },
+    "artifactURLResponse": {
+      "type": "object",
+      "required": [
+        "URL",
+        "ExpiresAt"
+      ],
+      "properties": {
+        "ExpiresAt": {
           "type": "string"
         },
-        "Env": {
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
+        "URL": {
+          "type": "string"
+        }
+      }
+    },
+    "auditEntry": {
+      "type": "object",
+      "required": [
+        "Time",
+        "Action",
+        "DAG"
+      ],
+      "properties": {
+        "Action": {
+          "type": "string"
         },
-        "Group": {
+        "Actor": {
+          "description": "User who took the action, empty if the server does not authenticate the users.",
           "type": "string"
         },
-        "HandlerOn": {
-          "$ref": "#/definitions/handlerOn"
+        "DAG": {
+          "type": "string"
         },
-        "HistRetentionDays": {
-          "type": "integer"
+        "Detail": {
+          "description": "About the action, e.g. the new name of a renamed DAG.",
+          "type": "string"
         },
-        "Location": {
+        "Reason": {
           "type": "string"
         },
-        "LogDir": {
+        "RequestId": {
           "type": "string"
         },
-        "MaxActiveRuns": {
-          "type": "integer"
+        "SourceIP": {
+          "description": "Address of the client of the request.",
+          "type": "string"
         },
-        "Name": {
+        "Step": {
           "type": "string"
         },
-        "Params": {
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
+        "Time": {
+          "type": "string"
         },
-        "Preconditions": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/condition"
-          }
+        "Token": {
+          "description": "Name of the API token the action was taken with.",
+          "type": "string"
+        }
+      }
+    },
+    "bulkActionResult": {
+      "type": "object",
+      "required": [
+        "Name",
+        "Ok"
+      ],
+      "properties": {
+        "Error": {
+          "$ref": "#/definitions/ApiError"
         },
-        "Schedule": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/schedule"
-          }
+        "Name": {
+          "type": "string"
         },
-        "Steps": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/stepObject"
-          }
+        "Ok": {
+          "type": "boolean"
         },
-        "Tags": {
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
+        "RequestId": {
+          "description": "Request ID of the run that is retried or stopped.",
+          "type": "string"
         }
       }
     },
-    "dagListItem": {
+    "bulkActionRun": {
       "type": "object",
       "required": [
-        "File",
-        "Dir",
+        "name",
+        "requestId"
+      ],
+      "properties": {
+        "name": {
+          "type": "string"
+        },
+        "requestId": {
+          "type": "string"
+        }
+      }
+    },
+    "change": {
+      "type": "object",
+      "required": [
+        "Id",
         "DAG",
-        "Status",
-        "Suspended",
-        "Error",
-        "ErrorT"
+        "Author",
+        "CreatedAt",
+        "Spec",
+        "Diff",
+        "Status"
       ],
       "properties": {
+        "Author": {
+          "type": "string"
+        },
+        "CreatedAt": {
+          "type": "string"
+        },
         "DAG": {
-          "$ref": "#/definitions/dag"
+          "type": "string"
         },
-        "Dir": {
+        "Diff": {
+          "description": "Unified diff of the definition of the DAG when the change was made and the one of the change.",
           "type": "string"
         },
-        "Error": {
+        "Id": {
           "type": "string"
         },
-        "ErrorT": {
+        "Reason": {
+          "description": "Reason of the rejection of the change.",
           "type": "string"
         },
-        "File": {
+        "ReviewedAt": {
+          "type": "string"
+        },
+        "Reviewer": {
+          "description": "User who approved or rejected the change.",
+          "type": "string"
+        },
+        "Spec": {
+          "description": "Definition of the DAG of the change.",
           "type": "string"
         },
         "Status": {
-          "$ref": "#/definitions/dagStatus"
+          "type": "string",
+          "enum": [
+            "pending",
+            "approved",
+            "rejected"
+          ]
+        }
+      }
+    },
+    "condition": {
+      "type": "object",
+      "properties": {
+        "Condition": {
+          "type": "string"
         },
-        "Suspended": {
-          "type": "boolean"
+        "Expected": {
+          "type": "string"
         }
       }
     },
-    "dagLogGridItem": {
+    "createApiTokenRequest": {
       "type": "object",
       "required": [
         "Name",
-        "Vals"
+        "Scopes"
       ],
       "properties": {
+        "ExpiresIn": {
+          "description": "Seconds until the token expires. The token does not expire if it is omitted.",
+          "type": "integer"
+        },
         "Name": {
+          "description": "Name of the token, consisting of letters, digits, '-', '_' and '.'.",
           "type": "string"
         },
-        "Vals": {
+        "Scopes": {
+          "description": "Scopes of the token, at least one of read, trigger and admin, and impersonate.",
           "type": "array",
           "items": {
-            "type": "integer"
+            "type": "string"
           }
         }
       }
     },
-    "dagLogResponse": {
+    "createApiTokenResponse": {
       "type": "object",
       "required": [
-        "GridData",
-        "Logs"
+        "Token",
+        "Secret"
       ],
       "properties": {
-        "GridData": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/dagLogGridItem"
-          }
+        "Secret": {
+          "description": "Secret of the token, the bearer token of the requests. It is not returned again.",
+          "type": "string"
         },
-        "Logs": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/dagStatusFile"
-          }
+        "Token": {
+          "$ref": "#/definitions/apiToken"
         }
       }
     },
-    "dagSchedulerLogResponse": {
+    "createDagResponse": {
       "type": "object",
       "required": [
-        "LogFile",
-        "Content"
+        "DagID"
       ],
       "properties": {
-        "Content": {
-          "type": "string"
-        },
-        "LogFile": {
+        "DagID": {
           "type": "string"
         }
       }
     },
-    "dagStatus": {
+    "dag": {
       "type": "object",
       "required": [
-        "RequestId",
+        "Group",
         "Name",
-        "Status",
-        "StatusText",
-        "Pid",
-        "StartedAt",
-        "FinishedAt",
-        "Log",
-        "Params"
+        "Schedule",
+        "Description",
+        "Params",
+        "DefaultParams",
+        "Tags"
       ],
       "properties": {
-        "FinishedAt": {
-          "type": "string"
-        },
-        "Log": {
+        "DefaultParams": {
           "type": "string"
         },
-        "Name": {
+        "Description": {
           "type": "string"
         },
-        "Params": {
+        "Group": {
           "type": "string"
         },
-        "Pid": {
-          "type": "integer"
-        },
-        "RequestId": {
+        "Name": {
           "type": "string"
         },
-        "StartedAt": {
-          "type": "string"
+        "Params": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         },
-        "Status": {
-          "type": "integer"
+        "Schedule": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/schedule"
+          }
         },
-        "StatusText": {
-          "type": "string"
+        "Tags": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         }
       }
     },
-    "dagStatusDetail": {
+    "dagDetail": {
       "type": "object",
       "required": [
-        "RequestId",
+        "Location",
+        "Group",
         "Name",
-        "Status",
-        "StatusText",
-        "Pid",
-        "Nodes",
-        "OnExit",
-        "OnSuccess",
-        "OnFailure",
-        "OnCancel",
-        "StartedAt",
-        "FinishedAt",
-        "Log",
-        "Params"
+        "Schedule",
+        "Description",
+        "Env",
+        "LogDir",
+        "HandlerOn",
+        "Steps",
+        "Delay",
+        "HistRetentionDays",
+        "Preconditions",
+        "MaxActiveRuns",
+        "Params",
+        "DefaultParams",
+        "Tags"
       ],
       "properties": {
-        "FinishedAt": {
+        "DefaultParams": {
           "type": "string"
         },
-        "Log": {
-          "type": "string"
+        "Delay": {
+          "type": "integer"
         },
-        "Name": {
+        "Description": {
           "type": "string"
         },
-        "Nodes": {
+        "Env": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/statusNode"
+            "type": "string"
           }
         },
-        "OnCancel": {
-          "$ref": "#/definitions/statusNode"
-        },
-        "OnExit": {
-          "$ref": "#/definitions/statusNode"
-        },
-        "OnFailure": {
-          "$ref": "#/definitions/statusNode"
+        "Group": {
+          "type": "string"
         },
-        "OnSuccess": {
-          "$ref": "#/definitions/statusNode"
+        "Groups": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/stepGroup"
+          }
         },
-        "Params": {
-          "type": "string"
+        "HandlerOn": {
+          "$ref": "#/definitions/handlerOn"
         },
-        "Pid": {
+        "HistRetentionDays": {
           "type": "integer"
         },
-        "RequestId": {
+        "Location": {
           "type": "string"
         },
-        "StartedAt": {
+        "LogDir": {
           "type": "string"
         },
-        "Status": {
+        "MaxActiveRuns": {
           "type": "integer"
         },
-        "StatusText": {
-          "type": "string"
-        }
-      }
-    },
-    "dagStatusFile": {
-      "type": "object",
-      "required": [
-        "File"
-      ],
-      "properties": {
-        "File": {
-          "type": "string"
-        },
-        "Status": {
-          "$ref": "#/definitions/dagStatusDetail"
-        }
-      }
-    },
-    "dagStatusWithDetails": {
-      "type": "object",
-      "required": [
-        "File",
-        "Dir",
-        "DAG",
-        "Status",
-        "Suspended",
-        "Error",
-        "ErrorT"
-      ],
-      "properties": {
-        "DAG": {
-          "$ref": "#/definitions/dagDetail"
-        },
-        "Dir": {
+        "Name": {
           "type": "string"
         },
-        "Error": {
-          "type": "string"
+        "ParamDefs": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/paramDef"
+          }
         },
-        "ErrorT": {
-          "type": "string"
+        "Params": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         },
-        "File": {
-          "type": "string"
+        "Preconditions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/condition"
+          }
         },
-        "Status": {
-          "$ref": "#/definitions/dagStatusDetail"
+        "Schedule": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/schedule"
+          }
         },
-        "Suspended": {
-          "type": "boolean"
-        }
-      }
-    },
-    "dagStepLogResponse": {
-      "type": "object",
-      "required": [
-        "Step",
-        "LogFile",
-        "Content"
-      ],
-      "properties": {
-        "Content": {
-          "type": "string"
+        "Steps": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/stepObject"
+          }
         },
-        "LogFile": {
-          "type": "string"
+        "Tags": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         },
-        "Step": {
-          "$ref": "#/definitions/statusNode"
+        "Warnings": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/dagWarning"
+          }
         }
       }
     },
-    "getDagDetailsResponse": {
+    "dagListItem": {
       "type": "object",
-      "required": [
-        "Title",
-        "DAG",
-        "Tab",
-        "Graph",
-        "Definition",
-        "LogData",
-        "LogUrl",
-        "StepLog",
-        "ScLog",
-        "Errors"
+      "required": [
+        "File",
+        "Dir",
+        "DAG",
+        "Status",
+        "Suspended",
+        "Error",
+        "ErrorT"
       ],
       "properties": {
         "DAG": {
-          "$ref": "#/definitions/dagStatusWithDetails"
+          "$ref": "#/definitions/dag"
         },
-        "Definition": {
+        "Dir": {
           "type": "string"
         },
-        "Errors": {
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
-        },
-        "Graph": {
+        "Error": {
           "type": "string"
         },
-        "LogData": {
-          "$ref": "#/definitions/dagLogResponse"
-        },
-        "LogUrl": {
+        "ErrorT": {
           "type": "string"
         },
-        "ScLog": {
-          "$ref": "#/definitions/dagSchedulerLogResponse"
-        },
-        "StepLog": {
-          "$ref": "#/definitions/dagStepLogResponse"
-        },
-        "Tab": {
+        "File": {
           "type": "string"
         },
-        "Title": {
+        "Folder": {
+          "description": "Folder of the DAG in the DAGs directory, e.g. team-a/etl, empty in the directory itself.",
           "type": "string"
+        },
+        "Status": {
+          "$ref": "#/definitions/dagStatus"
+        },
+        "Suspended": {
+          "type": "boolean"
         }
       }
     },
-    "handlerOn": {
+    "dagLogGridItem": {
       "type": "object",
+      "required": [
+        "Name",
+        "Vals"
+      ],
       "properties": {
-        "Cancel": {
-          "$ref": "#/definitions/stepObject"
-        },
-        "Exit": {
-          "$ref": "#/definitions/stepObject"
-        },
-        "Failure": {
-          "$ref": "#/definitions/stepObject"
+        "Name": {
+          "type": "string"
         },
-        "Success": {
-          "$ref": "#/definitions/stepObject"
+        "Vals": {
+          "type": "array",
+          "items": {
+            "type": "integer"
+          }
         }
       }
     },
-    "listDagsResponse": {
+    "dagLogResponse": {
       "type": "object",
       "required": [
-        "DAGs",
-        "Errors",
-        "HasError"
+        "GridData",
+        "Logs"
       ],
       "properties": {
-        "DAGs": {
+        "GridData": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/dagListItem"
+            "$ref": "#/definitions/dagLogGridItem"
           }
         },
-        "Errors": {
+        "HasMore": {
+          "description": "Whether there are older runs after the page.",
+          "type": "boolean"
+        },
+        "Logs": {
           "type": "array",
           "items": {
-            "type": "string"
+            "$ref": "#/definitions/dagStatusFile"
           }
-        },
-        "HasError": {
-          "type": "boolean"
         }
       }
     },
-    "postDagActionResponse": {
+    "dagProblem": {
       "type": "object",
+      "required": [
+        "Line",
+        "Field",
+        "Level",
+        "Message"
+      ],
       "properties": {
-        "NewDagID": {
+        "Field": {
+          "description": "Path of the field, e.g. steps[0].depends[1], if the problem is about a field.",
           "type": "string"
-        }
-      }
-    },
-    "repeatPolicy": {
-      "type": "object",
-      "properties": {
-        "Interval": {
+        },
+        "Level": {
+          "type": "string",
+          "enum": [
+            "error",
+            "warning"
+          ]
+        },
+        "Line": {
+          "description": "Line of the problem, starting at 1, or 0 if it is not known.",
           "type": "integer"
         },
-        "Repeat": {
-          "type": "boolean"
+        "Message": {
+          "type": "string"
         }
       }
     },
-    "schedule": {
+    "dagSchedulerLogResponse": {
       "type": "object",
       "required": [
-        "Expression"
+        "LogFile",
+        "Content"
       ],
       "properties": {
-        "Expression": {
+        "ArchivedFrom": {
+          "description": "Location of the archive the log was read from if it is no longer on the local disk.",
           "type": "string"
-        }
-      }
-    },
-    "searchDagsMatchItem": {
-      "type": "object",
-      "properties": {
-        "Line": {
+        },
+        "Content": {
           "type": "string"
         },
-        "LineNumber": {
-          "type": "integer"
+        "LogFile": {
+          "type": "string"
         },
-        "StartLine": {
-          "type": "integer"
+        "RemoteLocation": {
+          "description": "Location of the logs of the run in the log backend if the log was read from it because it is not on the local disk.",
+          "type": "string"
         }
       }
     },
-    "searchDagsResponse": {
+    "dagStatus": {
       "type": "object",
       "required": [
-        "Results",
-        "Errors"
+        "RequestId",
+        "Name",
+        "Status",
+        "StatusText",
+        "Pid",
+        "StartedAt",
+        "FinishedAt",
+        "Log",
+        "Params"
       ],
       "properties": {
-        "Errors": {
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
+        "ArchiveLocation": {
+          "description": "Location the log files of the run were moved to when it was archived.",
+          "type": "string"
         },
-        "Results": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/searchDagsResultItem"
-          }
-        }
-      }
-    },
-    "searchDagsResultItem": {
-      "type": "object",
-      "properties": {
-        "DAG": {
-          "$ref": "#/definitions/dag"
+        "FinishedAt": {
+          "type": "string"
         },
-        "Matches": {
+        "InitiatedBy": {
+          "description": "User the run was started by through the API, or on behalf of with a token with the impersonate scope.",
+          "type": "string"
+        },
+        "InitiatorToken": {
+          "description": "Name of the API token the run was started with.",
+          "type": "string"
+        },
+        "Lane": {
+          "description": "manual if the run was started by hand and queued ahead of the scheduled runs.",
+          "type": "string"
+        },
+        "Log": {
+          "type": "string"
+        },
+        "Name": {
+          "type": "string"
+        },
+        "Overtook": {
+          "description": "Request IDs of the scheduled runs queued before the run that it took the slot of its pool ahead of.",
           "type": "array",
           "items": {
-            "$ref": "#/definitions/searchDagsMatchItem"
+            "type": "string"
           }
         },
-        "Name": {
+        "Params": {
           "type": "string"
-        }
-      }
-    },
-    "statusNode": {
-      "type": "object",
-      "required": [
-        "Step",
-        "Log",
-        "StartedAt",
-        "FinishedAt",
-        "Status",
-        "RetryCount",
-        "DoneCount",
-        "Error",
-        "StatusText"
-      ],
-      "properties": {
-        "DoneCount": {
+        },
+        "Pid": {
           "type": "integer"
         },
-        "Error": {
+        "QueuePosition": {
+          "description": "Position of the run in the queue of its concurrency pool while it waits for a slot. It is 0 if the run does not wait.",
+          "type": "integer"
+        },
+        "RequestId": {
           "type": "string"
         },
-        "FinishedAt": {
+        "RestartedAs": {
+          "description": "Request ID of the run that restarted this run.",
           "type": "string"
         },
-        "Log": {
+        "RestartedFrom": {
+          "description": "Request ID of the run this run restarted.",
           "type": "string"
         },
-        "RetryCount": {
-          "type": "integer"
+        "Schedule": {
+          "description": "Cron expression of the schedule entry that started the run.",
+          "type": "string"
         },
         "StartedAt": {
           "type": "string"
@@ -919,515 +5288,500 @@ func init() {
         "StatusText": {
           "type": "string"
         },
-        "Step": {
-          "$ref": "#/definitions/stepObject"
+        "StoppedBy": {
+          "description": "Cron expression of the schedule entry that stopped the run.",
+          "type": "string"
         }
       }
     },
-    "stepObject": {
+    "dagStatusDetail": {
       "type": "object",
       "required": [
+        "RequestId",
         "Name",
-        "Description",
-        "Variables",
-        "Dir",
-        "CmdWithArgs",
-        "Command",
-        "Script",
-        "Stdout",
-        "Stderr",
-        "Output",
-        "Args",
-        "Depends",
-        "RepeatPolicy",
-        "MailOnError",
-        "Preconditions"
+        "Status",
+        "StatusText",
+        "Pid",
+        "Nodes",
+        "OnExit",
+        "OnSuccess",
+        "OnFailure",
+        "OnCancel",
+        "StartedAt",
+        "FinishedAt",
+        "Log",
+        "Params"
       ],
       "properties": {
-        "Args": {
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
-        },
-        "CmdWithArgs": {
+        "ArchiveLocation": {
+          "description": "Location the log files of the run were moved to when it was archived.",
           "type": "string"
         },
-        "Command": {
+        "FinishedAt": {
           "type": "string"
         },
-        "Depends": {
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
-        },
-        "Description": {
+        "InitiatedBy": {
+          "description": "User the run was started by through the API, or on behalf of with a token with the impersonate scope.",
           "type": "string"
         },
-        "Dir": {
+        "InitiatorToken": {
+          "description": "Name of the API token the run was started with.",
           "type": "string"
         },
-        "MailOnError": {
-          "type": "boolean"
-        },
-        "Name": {
+        "Lane": {
+          "description": "manual if the run was started by hand and queued ahead of the scheduled runs.",
           "type": "string"
         },
-        "Output": {
+        "Log": {
           "type": "string"
         },
-        "Params": {
+        "Name": {
           "type": "string"
         },
-        "Preconditions": {
+        "Nodes": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/condition"
+            "$ref": "#/definitions/statusNode"
           }
         },
-        "RepeatPolicy": {
-          "$ref": "#/definitions/repeatPolicy"
-        },
-        "Run": {
-          "type": "string"
+        "OnCancel": {
+          "$ref": "#/definitions/statusNode"
         },
-        "Script": {
-          "type": "string"
+        "OnExit": {
+          "$ref": "#/definitions/statusNode"
         },
-        "Stderr": {
-          "type": "string"
+        "OnFailure": {
+          "$ref": "#/definitions/statusNode"
         },
-        "Stdout": {
-          "type": "string"
+        "OnSuccess": {
+          "$ref": "#/definitions/statusNode"
         },
-        "Variables": {
+        "Overtook": {
+          "description": "Request IDs of the scheduled runs queued before the run that it took the slot of its pool ahead of.",
           "type": "array",
           "items": {
             "type": "string"
           }
-        }
-      }
-    }
-  }
-}`))
-	FlatSwaggerJSON = json.RawMessage([]byte(`{
-  "consumes": [
-    "application/json"
-  ],
-  "produces": [
-    "application/json"
-  ],
-  "schemes": [
-    "http"
-  ],
-  "swagger": "2.0",
-  "info": {
-    "title": "Dagu",
-    "version": "0.0.1"
-  },
-  "host": "localhost:8080",
-  "basePath": "/api/v1",
-  "paths": {
-    "/dags": {
-      "get": {
-        "description": "Returns a list of DAGs.",
-        "produces": [
-          "application/json"
-        ],
-        "operationId": "listDags",
-        "responses": {
-          "200": {
-            "description": "A successful response.",
-            "schema": {
-              "$ref": "#/definitions/listDagsResponse"
-            }
-          },
-          "default": {
-            "description": "Generic error response.",
-            "schema": {
-              "$ref": "#/definitions/ApiError"
-            }
-          }
-        }
-      },
-      "post": {
-        "description": "Creates a new DAG.",
-        "produces": [
-          "application/json"
-        ],
-        "operationId": "createDag",
-        "parameters": [
-          {
-            "name": "body",
-            "in": "body",
-            "schema": {
-              "type": "object",
-              "required": [
-                "action",
-                "value"
-              ],
-              "properties": {
-                "action": {
-                  "type": "string"
-                },
-                "value": {
-                  "type": "string"
-                }
-              }
-            }
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "A successful response.",
-            "schema": {
-              "$ref": "#/definitions/createDagResponse"
-            }
-          },
-          "default": {
-            "description": "Generic error response.",
-            "schema": {
-              "$ref": "#/definitions/ApiError"
-            }
-          }
-        }
-      }
-    },
-    "/dags/{dagId}": {
-      "get": {
-        "description": "Returns details of a DAG.",
-        "produces": [
-          "application/json"
-        ],
-        "operationId": "getDagDetails",
-        "parameters": [
-          {
-            "type": "string",
-            "name": "dagId",
-            "in": "path",
-            "required": true
-          },
-          {
-            "type": "string",
-            "name": "tab",
-            "in": "query"
-          },
-          {
-            "type": "string",
-            "name": "file",
-            "in": "query"
-          },
-          {
-            "type": "string",
-            "name": "step",
-            "in": "query"
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "A successful response.",
-            "schema": {
-              "$ref": "#/definitions/getDagDetailsResponse"
-            }
-          },
-          "default": {
-            "description": "Generic error response.",
-            "schema": {
-              "$ref": "#/definitions/ApiError"
-            }
-          }
-        }
-      },
-      "post": {
-        "description": "Performs an action on a DAG.",
-        "produces": [
-          "application/json"
-        ],
-        "operationId": "postDagAction",
-        "parameters": [
-          {
-            "type": "string",
-            "name": "dagId",
-            "in": "path",
-            "required": true
-          },
-          {
-            "name": "body",
-            "in": "body",
-            "schema": {
-              "type": "object",
-              "required": [
-                "action"
-              ],
-              "properties": {
-                "action": {
-                  "type": "string",
-                  "enum": [
-                    "start",
-                    "suspend",
-                    "stop",
-                    "retry",
-                    "mark-success",
-                    "mark-failed",
-                    "save",
-                    "rename"
-                  ]
-                },
-                "params": {
-                  "type": "string"
-                },
-                "requestId": {
-                  "type": "string"
-                },
-                "step": {
-                  "type": "string"
-                },
-                "value": {
-                  "type": "string"
-                }
-              }
-            }
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "A successful response.",
-            "schema": {
-              "$ref": "#/definitions/postDagActionResponse"
-            }
-          },
-          "default": {
-            "description": "Generic error response.",
-            "schema": {
-              "$ref": "#/definitions/ApiError"
-            }
-          }
-        }
-      },
-      "delete": {
-        "description": "Deletes a DAG.",
-        "produces": [
-          "application/json"
-        ],
-        "operationId": "deleteDag",
-        "parameters": [
-          {
-            "type": "string",
-            "name": "dagId",
-            "in": "path",
-            "required": true
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "A successful response."
-          },
-          "default": {
-            "description": "Generic error response.",
-            "schema": {
-              "$ref": "#/definitions/ApiError"
-            }
-          }
+        },
+        "Params": {
+          "type": "string"
+        },
+        "Pid": {
+          "type": "integer"
+        },
+        "QueuePosition": {
+          "description": "Position of the run in the queue of its concurrency pool while it waits for a slot. It is 0 if the run does not wait.",
+          "type": "integer"
+        },
+        "RequestId": {
+          "type": "string"
+        },
+        "RestartedAs": {
+          "description": "Request ID of the run that restarted this run.",
+          "type": "string"
+        },
+        "RestartedFrom": {
+          "description": "Request ID of the run this run restarted.",
+          "type": "string"
+        },
+        "Schedule": {
+          "description": "Cron expression of the schedule entry that started the run.",
+          "type": "string"
+        },
+        "StartedAt": {
+          "type": "string"
+        },
+        "Status": {
+          "type": "integer"
+        },
+        "StatusText": {
+          "type": "string"
+        },
+        "StoppedBy": {
+          "description": "Cron expression of the schedule entry that stopped the run.",
+          "type": "string"
         }
       }
     },
-    "/search": {
-      "get": {
-        "description": "Searches for DAGs.",
-        "produces": [
-          "application/json"
-        ],
-        "operationId": "searchDags",
-        "parameters": [
-          {
-            "type": "string",
-            "name": "q",
-            "in": "query",
-            "required": true
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "A successful response.",
-            "schema": {
-              "$ref": "#/definitions/searchDagsResponse"
-            }
-          },
-          "default": {
-            "description": "Generic error response.",
-            "schema": {
-              "$ref": "#/definitions/ApiError"
-            }
-          }
+    "dagStatusFile": {
+      "type": "object",
+      "required": [
+        "File"
+      ],
+      "properties": {
+        "File": {
+          "type": "string"
+        },
+        "Status": {
+          "$ref": "#/definitions/dagStatusDetail"
         }
       }
-    }
-  },
-  "definitions": {
-    "ApiError": {
+    },
+    "dagStatusWithDetails": {
       "type": "object",
       "required": [
-        "message",
-        "detailedMessage"
+        "File",
+        "Dir",
+        "DAG",
+        "Status",
+        "Suspended",
+        "Error",
+        "ErrorT"
       ],
       "properties": {
-        "detailedMessage": {
+        "DAG": {
+          "$ref": "#/definitions/dagDetail"
+        },
+        "Dir": {
           "type": "string"
         },
-        "message": {
+        "Error": {
+          "type": "string"
+        },
+        "ErrorT": {
+          "type": "string"
+        },
+        "File": {
+          "type": "string"
+        },
+        "Folder": {
+          "description": "Folder of the DAG in the DAGs directory, e.g. team-a/etl, empty in the directory itself.",
           "type": "string"
+        },
+        "Status": {
+          "$ref": "#/definitions/dagStatusDetail"
+        },
+        "Suspended": {
+          "type": "boolean"
         }
       }
     },
-    "condition": {
+    "dagStepLogResponse": {
       "type": "object",
+      "required": [
+        "Step",
+        "LogFile",
+        "Content"
+      ],
       "properties": {
-        "Condition": {
+        "ArchivedFrom": {
+          "description": "Location of the archive the log was read from if it is no longer on the local disk.",
           "type": "string"
         },
-        "Expected": {
+        "Content": {
+          "type": "string"
+        },
+        "LogFile": {
           "type": "string"
+        },
+        "RemoteLocation": {
+          "description": "Location of the logs of the run in the log backend if the log was read from it because it is not on the local disk.",
+          "type": "string"
+        },
+        "Step": {
+          "$ref": "#/definitions/statusNode"
         }
       }
     },
-    "createDagResponse": {
+    "dagWarning": {
       "type": "object",
       "required": [
-        "DagID"
+        "Code",
+        "Field",
+        "Message"
       ],
       "properties": {
-        "DagID": {
+        "Code": {
+          "type": "string",
+          "enum": [
+            "deprecated",
+            "fieldCase"
+          ]
+        },
+        "Field": {
+          "type": "string"
+        },
+        "Message": {
           "type": "string"
         }
       }
     },
-    "dag": {
+    "driftDefinition": {
       "type": "object",
       "required": [
-        "Group",
         "Name",
-        "Schedule",
-        "Description",
-        "Params",
-        "DefaultParams",
-        "Tags"
+        "File",
+        "Digest"
       ],
       "properties": {
-        "DefaultParams": {
+        "Digest": {
+          "description": "SHA-256 of the contents of the file in hex.",
           "type": "string"
         },
-        "Description": {
+        "File": {
+          "description": "Name of the file of the DAG in the DAGs directory.",
           "type": "string"
         },
-        "Group": {
+        "Name": {
+          "type": "string"
+        }
+      }
+    },
+    "driftDifference": {
+      "type": "object",
+      "required": [
+        "File",
+        "Kind"
+      ],
+      "properties": {
+        "File": {
           "type": "string"
         },
-        "Name": {
+        "Kind": {
+          "description": "changed if the definitions differ, missing if the remote node does not have the DAG, and extra if only the remote node has it.",
+          "type": "string",
+          "enum": [
+            "changed",
+            "missing",
+            "extra"
+          ]
+        },
+        "LocalDigest": {
           "type": "string"
         },
-        "Params": {
+        "RemoteDigest": {
+          "type": "string"
+        }
+      }
+    },
+    "driftInventoryResponse": {
+      "type": "object",
+      "required": [
+        "Version",
+        "Definitions"
+      ],
+      "properties": {
+        "Definitions": {
           "type": "array",
           "items": {
-            "type": "string"
+            "$ref": "#/definitions/driftDefinition"
           }
         },
-        "Schedule": {
+        "Version": {
+          "type": "string"
+        }
+      }
+    },
+    "driftNode": {
+      "type": "object",
+      "required": [
+        "Name",
+        "URL",
+        "Version",
+        "InSync",
+        "Differences"
+      ],
+      "properties": {
+        "Differences": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/schedule"
+            "$ref": "#/definitions/driftDifference"
           }
         },
-        "Tags": {
+        "Error": {
+          "description": "Why the remote node could not be compared, e.g. it is unreachable.",
+          "type": "string"
+        },
+        "InSync": {
+          "description": "Whether the remote node has the same version and DAG definitions.",
+          "type": "boolean"
+        },
+        "Name": {
+          "type": "string"
+        },
+        "URL": {
+          "type": "string"
+        },
+        "Version": {
+          "description": "Version of the remote node, empty if it could not be compared.",
+          "type": "string"
+        }
+      }
+    },
+    "driftReportResponse": {
+      "type": "object",
+      "required": [
+        "Version",
+        "CheckedAt",
+        "Nodes",
+        "Errors"
+      ],
+      "properties": {
+        "CheckedAt": {
+          "type": "string"
+        },
+        "Errors": {
+          "description": "DAGs of the server that could not be read.",
           "type": "array",
           "items": {
             "type": "string"
           }
+        },
+        "Nodes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/driftNode"
+          }
+        },
+        "Version": {
+          "type": "string"
         }
       }
     },
-    "dagDetail": {
+    "gcItem": {
+      "type": "object",
+      "properties": {
+        "Kind": {
+          "type": "string",
+          "enum": [
+            "socket",
+            "temp",
+            "artifact",
+            "lock"
+          ]
+        },
+        "Path": {
+          "type": "string"
+        },
+        "Size": {
+          "type": "integer"
+        }
+      }
+    },
+    "gcReportResponse": {
       "type": "object",
       "required": [
-        "Location",
-        "Group",
-        "Name",
-        "Schedule",
-        "Description",
-        "Env",
-        "LogDir",
-        "HandlerOn",
-        "Steps",
-        "Delay",
-        "HistRetentionDays",
-        "Preconditions",
-        "MaxActiveRuns",
-        "Params",
-        "DefaultParams",
-        "Tags"
+        "StartedAt",
+        "FinishedAt",
+        "Removed",
+        "Size",
+        "Errors"
       ],
       "properties": {
-        "DefaultParams": {
+        "Errors": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "FinishedAt": {
           "type": "string"
         },
-        "Delay": {
+        "Removed": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/gcItem"
+          }
+        },
+        "Size": {
+          "description": "Total size of the removed files in bytes.",
           "type": "integer"
         },
-        "Description": {
+        "StartedAt": {
+          "type": "string"
+        }
+      }
+    },
+    "getDagDetailsResponse": {
+      "type": "object",
+      "required": [
+        "Title",
+        "DAG",
+        "Tab",
+        "Graph",
+        "Definition",
+        "LogData",
+        "LogUrl",
+        "StepLog",
+        "ScLog",
+        "Errors"
+      ],
+      "properties": {
+        "DAG": {
+          "$ref": "#/definitions/dagStatusWithDetails"
+        },
+        "Definition": {
           "type": "string"
         },
-        "Env": {
+        "Errors": {
           "type": "array",
           "items": {
             "type": "string"
           }
         },
-        "Group": {
+        "Graph": {
           "type": "string"
         },
-        "HandlerOn": {
-          "$ref": "#/definitions/handlerOn"
-        },
-        "HistRetentionDays": {
-          "type": "integer"
+        "LogData": {
+          "$ref": "#/definitions/dagLogResponse"
         },
-        "Location": {
+        "LogUrl": {
           "type": "string"
         },
-        "LogDir": {
-          "type": "string"
+        "ScLog": {
+          "$ref": "#/definitions/dagSchedulerLogResponse"
         },
-        "MaxActiveRuns": {
-          "type": "integer"
+        "StepLog": {
+          "$ref": "#/definitions/dagStepLogResponse"
         },
-        "Name": {
+        "Tab": {
           "type": "string"
         },
-        "Params": {
+        "Title": {
+          "type": "string"
+        }
+      }
+    },
+    "getDagStatusesResponse": {
+      "type": "object",
+      "required": [
+        "DAGs",
+        "Errors"
+      ],
+      "properties": {
+        "DAGs": {
           "type": "array",
           "items": {
-            "type": "string"
+            "$ref": "#/definitions/dagListItem"
           }
         },
-        "Preconditions": {
+        "Errors": {
+          "description": "Errors of the DAGs of the response, of the names that are not the ones of DAGs the request is allowed to view, and of the requested DAG files that cannot be read.",
           "type": "array",
           "items": {
-            "$ref": "#/definitions/condition"
+            "type": "string"
           }
-        },
-        "Schedule": {
+        }
+      }
+    },
+    "haltResponse": {
+      "type": "object",
+      "required": [
+        "Halted"
+      ],
+      "properties": {
+        "Errors": {
+          "description": "Errors of the DAGs that could not be stopped.",
           "type": "array",
           "items": {
-            "$ref": "#/definitions/schedule"
+            "type": "string"
           }
         },
-        "Steps": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/stepObject"
-          }
+        "Halted": {
+          "type": "boolean"
         },
-        "Tags": {
+        "Stopped": {
+          "description": "Names of the DAGs that were stopped.",
           "type": "array",
           "items": {
             "type": "string"
@@ -1435,369 +5789,325 @@ func init() {
         }
       }
     },
-    "dagListItem": {
+    "handlerOn": {
       "type": "object",
-      "required": [
-        "File",
-        "Dir",
-        "DAG",
-        "Status",
-        "Suspended",
-        "Error",
-        "ErrorT"
-      ],
       "properties": {
-        "DAG": {
-          "$ref": "#/definitions/dag"
-        },
-        "Dir": {
-          "type": "string"
-        },
-        "Error": {
-          "type": "string"
+        "Cancel": {
+          "$ref": "#/definitions/stepObject"
         },
-        "ErrorT": {
-          "type": "string"
+        "Exit": {
+          "$ref": "#/definitions/stepObject"
         },
-        "File": {
-          "type": "string"
+        "Failure": {
+          "$ref": "#/definitions/stepObject"
         },
-        "Status": {
-          "$ref": "#/definitions/dagStatus"
+        "Success": {
+          "$ref": "#/definitions/stepObject"
         },
-        "Suspended": {
-          "type": "boolean"
+        "Timeout": {
+          "$ref": "#/definitions/stepObject"
         }
       }
     },
-    "dagLogGridItem": {
+    "listApiTokensResponse": {
       "type": "object",
       "required": [
-        "Name",
-        "Vals"
+        "Tokens"
       ],
       "properties": {
-        "Name": {
-          "type": "string"
-        },
-        "Vals": {
+        "Tokens": {
           "type": "array",
           "items": {
-            "type": "integer"
+            "$ref": "#/definitions/apiToken"
           }
         }
       }
     },
-    "dagLogResponse": {
+    "listArtifactsResponse": {
       "type": "object",
       "required": [
-        "GridData",
-        "Logs"
+        "Artifacts"
       ],
       "properties": {
-        "GridData": {
+        "Artifacts": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/dagLogGridItem"
+            "$ref": "#/definitions/artifact"
           }
-        },
-        "Logs": {
+        }
+      }
+    },
+    "listAuditEntriesResponse": {
+      "type": "object",
+      "required": [
+        "Entries"
+      ],
+      "properties": {
+        "Entries": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/dagStatusFile"
+            "$ref": "#/definitions/auditEntry"
           }
         }
       }
     },
-    "dagSchedulerLogResponse": {
+    "listChangesResponse": {
       "type": "object",
       "required": [
-        "LogFile",
-        "Content"
+        "Changes"
       ],
       "properties": {
-        "Content": {
-          "type": "string"
-        },
-        "LogFile": {
-          "type": "string"
+        "Changes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/change"
+          }
         }
       }
     },
-    "dagStatus": {
+    "listDagsResponse": {
       "type": "object",
       "required": [
-        "RequestId",
-        "Name",
-        "Status",
-        "StatusText",
-        "Pid",
-        "StartedAt",
-        "FinishedAt",
-        "Log",
-        "Params"
+        "DAGs",
+        "Errors",
+        "HasError",
+        "Total"
       ],
       "properties": {
-        "FinishedAt": {
-          "type": "string"
-        },
-        "Log": {
-          "type": "string"
-        },
-        "Name": {
-          "type": "string"
-        },
-        "Params": {
-          "type": "string"
+        "DAGs": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/dagListItem"
+          }
         },
-        "Pid": {
-          "type": "integer"
+        "Errors": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         },
-        "RequestId": {
-          "type": "string"
+        "Folders": {
+          "description": "Subfolders of the folder of the request, e.g. team-a/etl, if it is set.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         },
-        "StartedAt": {
-          "type": "string"
+        "HasError": {
+          "type": "boolean"
         },
-        "Status": {
+        "Total": {
+          "description": "Number of the DAGs that match the filters of the request.",
           "type": "integer"
-        },
-        "StatusText": {
-          "type": "string"
         }
       }
     },
-    "dagStatusDetail": {
+    "listFoldersResponse": {
       "type": "object",
       "required": [
-        "RequestId",
-        "Name",
-        "Status",
-        "StatusText",
-        "Pid",
-        "Nodes",
-        "OnExit",
-        "OnSuccess",
-        "OnFailure",
-        "OnCancel",
-        "StartedAt",
-        "FinishedAt",
-        "Log",
-        "Params"
+        "Folders"
       ],
       "properties": {
-        "FinishedAt": {
-          "type": "string"
-        },
-        "Log": {
-          "type": "string"
-        },
-        "Name": {
-          "type": "string"
-        },
-        "Nodes": {
+        "Folders": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/statusNode"
+            "type": "string"
           }
-        },
-        "OnCancel": {
-          "$ref": "#/definitions/statusNode"
-        },
-        "OnExit": {
-          "$ref": "#/definitions/statusNode"
-        },
-        "OnFailure": {
-          "$ref": "#/definitions/statusNode"
-        },
-        "OnSuccess": {
-          "$ref": "#/definitions/statusNode"
-        },
-        "Params": {
-          "type": "string"
-        },
-        "Pid": {
-          "type": "integer"
-        },
-        "RequestId": {
-          "type": "string"
-        },
-        "StartedAt": {
-          "type": "string"
-        },
-        "Status": {
-          "type": "integer"
-        },
-        "StatusText": {
-          "type": "string"
         }
       }
     },
-    "dagStatusFile": {
+    "listTriggersResponse": {
       "type": "object",
       "required": [
-        "File"
+        "Triggers"
       ],
       "properties": {
-        "File": {
-          "type": "string"
-        },
-        "Status": {
-          "$ref": "#/definitions/dagStatusDetail"
+        "Triggers": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/trigger"
+          }
         }
       }
     },
-    "dagStatusWithDetails": {
+    "metaResponse": {
       "type": "object",
       "required": [
-        "File",
-        "Dir",
-        "DAG",
-        "Status",
-        "Suspended",
-        "Error",
-        "ErrorT"
+        "Version",
+        "APIVersion",
+        "Features",
+        "Executors",
+        "AuthModes",
+        "Storage"
       ],
       "properties": {
-        "DAG": {
-          "$ref": "#/definitions/dagDetail"
-        },
-        "Dir": {
+        "APIVersion": {
+          "description": "Version of the REST API, the path prefix of its endpoints without the leading /api/.",
           "type": "string"
         },
-        "Error": {
-          "type": "string"
+        "AuthModes": {
+          "description": "Modes of the authentication of the requests, none if the requests are not authenticated.",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "enum": [
+              "none",
+              "basic",
+              "token",
+              "oidc",
+              "ldap"
+            ]
+          }
         },
-        "ErrorT": {
-          "type": "string"
+        "Executors": {
+          "description": "Types of the executors built into the server.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         },
-        "File": {
-          "type": "string"
+        "Features": {
+          "description": "Optional features of the API that the server has and that are enabled.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         },
-        "Status": {
-          "$ref": "#/definitions/dagStatusDetail"
+        "Namespaces": {
+          "description": "Namespaces whose API is served under /api/v1/namespaces/{name} besides the default one.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         },
-        "Suspended": {
-          "type": "boolean"
+        "Storage": {
+          "$ref": "#/definitions/metaStorage"
+        },
+        "Version": {
+          "type": "string"
         }
       }
     },
-    "dagStepLogResponse": {
+    "metaStorage": {
       "type": "object",
       "required": [
-        "Step",
-        "LogFile",
-        "Content"
+        "History",
+        "Artifacts",
+        "Logs"
       ],
       "properties": {
-        "Content": {
+        "Archive": {
+          "description": "Backend of the archive of the old runs, empty if the runs are not archived.",
           "type": "string"
         },
-        "LogFile": {
+        "Artifacts": {
+          "description": "Backend of the artifacts of the runs.",
           "type": "string"
         },
-        "Step": {
-          "$ref": "#/definitions/statusNode"
+        "History": {
+          "description": "Backend of the history of the runs.",
+          "type": "string"
+        },
+        "Logs": {
+          "description": "Backend of the step logs.",
+          "type": "string"
         }
       }
     },
-    "getDagDetailsResponse": {
+    "paramDef": {
       "type": "object",
       "required": [
-        "Title",
-        "DAG",
-        "Tab",
-        "Graph",
-        "Definition",
-        "LogData",
-        "LogUrl",
-        "StepLog",
-        "ScLog",
-        "Errors"
+        "Name",
+        "Type"
       ],
       "properties": {
-        "DAG": {
-          "$ref": "#/definitions/dagStatusWithDetails"
-        },
-        "Definition": {
-          "type": "string"
-        },
-        "Errors": {
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
-        },
-        "Graph": {
+        "Default": {
           "type": "string"
         },
-        "LogData": {
-          "$ref": "#/definitions/dagLogResponse"
-        },
-        "LogUrl": {
+        "Description": {
           "type": "string"
         },
-        "ScLog": {
-          "$ref": "#/definitions/dagSchedulerLogResponse"
+        "Max": {
+          "type": "integer",
+          "x-nullable": true
         },
-        "StepLog": {
-          "$ref": "#/definitions/dagStepLogResponse"
+        "Min": {
+          "type": "integer",
+          "x-nullable": true
         },
-        "Tab": {
+        "Name": {
           "type": "string"
         },
-        "Title": {
+        "Pattern": {
           "type": "string"
-        }
-      }
-    },
-    "handlerOn": {
-      "type": "object",
-      "properties": {
-        "Cancel": {
-          "$ref": "#/definitions/stepObject"
         },
-        "Exit": {
-          "$ref": "#/definitions/stepObject"
+        "Required": {
+          "type": "boolean"
         },
-        "Failure": {
-          "$ref": "#/definitions/stepObject"
+        "Source": {
+          "description": "The URL of the source the default is read from when a run is started",
+          "type": "string"
         },
-        "Success": {
-          "$ref": "#/definitions/stepObject"
+        "Type": {
+          "type": "string",
+          "enum": [
+            "string",
+            "int",
+            "bool",
+            "enum",
+            "date"
+          ]
+        },
+        "Values": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         }
       }
     },
-    "listDagsResponse": {
+    "postBulkActionResponse": {
       "type": "object",
       "required": [
-        "DAGs",
-        "Errors",
-        "HasError"
+        "Results"
       ],
       "properties": {
-        "DAGs": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/dagListItem"
-          }
-        },
-        "Errors": {
+        "Results": {
+          "description": "Results of the DAGs and of the runs, in the order of the names, of the tag and of the runs.",
           "type": "array",
           "items": {
-            "type": "string"
+            "$ref": "#/definitions/bulkActionResult"
           }
-        },
-        "HasError": {
-          "type": "boolean"
         }
       }
     },
     "postDagActionResponse": {
       "type": "object",
       "properties": {
+        "ChangeId": {
+          "description": "ID of the pending change of save if the namespace is under change control.",
+          "type": "string"
+        },
         "NewDagID": {
           "type": "string"
+        },
+        "Outputs": {
+          "description": "Output variables of the steps of the run, if it was waited for.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "Queued": {
+          "description": "True if the run of start is in the trigger queue until the scheduler starts it.",
+          "type": "boolean"
+        },
+        "RequestId": {
+          "description": "Request ID of the run of start.",
+          "type": "string"
+        },
+        "Status": {
+          "$ref": "#/definitions/dagStatusDetail"
         }
       }
     },
@@ -1812,6 +6122,25 @@ func init() {
         }
       }
     },
+    "reviewChangeRequest": {
+      "type": "object",
+      "required": [
+        "Action"
+      ],
+      "properties": {
+        "Action": {
+          "type": "string",
+          "enum": [
+            "approve",
+            "reject"
+          ]
+        },
+        "Reason": {
+          "description": "Reason of the rejection.",
+          "type": "string"
+        }
+      }
+    },
     "schedule": {
       "type": "object",
       "required": [
@@ -1823,6 +6152,23 @@ func init() {
         }
       }
     },
+    "searchDagsLogItem": {
+      "type": "object",
+      "properties": {
+        "Matches": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/searchDagsMatchItem"
+          }
+        },
+        "RequestId": {
+          "type": "string"
+        },
+        "Step": {
+          "type": "string"
+        }
+      }
+    },
     "searchDagsMatchItem": {
       "type": "object",
       "properties": {
@@ -1864,7 +6210,22 @@ func init() {
         "DAG": {
           "$ref": "#/definitions/dag"
         },
+        "Fields": {
+          "description": "The fields that matched, the most relevant first, of name, tag, description, command and log.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Logs": {
+          "description": "The step logs of the recent runs that matched, the latest first.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/searchDagsLogItem"
+          }
+        },
         "Matches": {
+          "description": "The lines of the definition that matched.",
           "type": "array",
           "items": {
             "$ref": "#/definitions/searchDagsMatchItem"
@@ -1872,6 +6233,10 @@ func init() {
         },
         "Name": {
           "type": "string"
+        },
+        "Score": {
+          "description": "The relevance of the DAG, higher for the matches of the more relevant fields.",
+          "type": "integer"
         }
       }
     },
@@ -1901,6 +6266,10 @@ func init() {
         "Log": {
           "type": "string"
         },
+        "Preview": {
+          "description": "Command of the step with the variables expanded and the secret values masked.",
+          "type": "string"
+        },
         "RetryCount": {
           "type": "integer"
         },
@@ -1915,6 +6284,33 @@ func init() {
         },
         "Step": {
           "$ref": "#/definitions/stepObject"
+        },
+        "SubRunRequestId": {
+          "description": "Request ID of the sub DAG run of the step.",
+          "type": "string"
+        }
+      }
+    },
+    "stepGroup": {
+      "type": "object",
+      "properties": {
+        "Groups": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/stepGroup"
+          }
+        },
+        "Name": {
+          "type": "string"
+        },
+        "Path": {
+          "type": "string"
+        },
+        "Steps": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         }
       }
     },
@@ -1962,6 +6358,9 @@ func init() {
         "Dir": {
           "type": "string"
         },
+        "Group": {
+          "type": "string"
+        },
         "MailOnError": {
           "type": "boolean"
         },
@@ -1989,6 +6388,9 @@ func init() {
         "Script": {
           "type": "string"
         },
+        "Stage": {
+          "type": "string"
+        },
         "Stderr": {
           "type": "string"
         },
@@ -2002,6 +6404,84 @@ func init() {
           }
         }
       }
+    },
+    "trigger": {
+      "type": "object",
+      "required": [
+        "Id",
+        "DAG",
+        "RequestId",
+        "ReceivedAt",
+        "Attempts"
+      ],
+      "properties": {
+        "Attempts": {
+          "description": "Number of the times the scheduler read the trigger.",
+          "type": "integer"
+        },
+        "DAG": {
+          "type": "string"
+        },
+        "Error": {
+          "description": "Reason the last attempt did not start the run, e.g. the DAG was running.",
+          "type": "string"
+        },
+        "Id": {
+          "type": "integer"
+        },
+        "InitiatedBy": {
+          "description": "User the run was started by through the API.",
+          "type": "string"
+        },
+        "InitiatorToken": {
+          "description": "Name of the API token the run was started with.",
+          "type": "string"
+        },
+        "Params": {
+          "type": "string"
+        },
+        "ReceivedAt": {
+          "type": "string"
+        },
+        "RequestId": {
+          "type": "string"
+        }
+      }
+    },
+    "validateDagRequest": {
+      "type": "object",
+      "required": [
+        "Definition"
+      ],
+      "properties": {
+        "DagId": {
+          "description": "Name of the DAG the definition is of, whose directory the included files are relative to. The DAG does not need to exist.",
+          "type": "string"
+        },
+        "Definition": {
+          "description": "YAML definition of the DAG.",
+          "type": "string"
+        }
+      }
+    },
+    "validateDagResponse": {
+      "type": "object",
+      "required": [
+        "Valid",
+        "Problems"
+      ],
+      "properties": {
+        "Problems": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/dagProblem"
+          }
+        },
+        "Valid": {
+          "description": "Whether the definition has no errors. It may have warnings.",
+          "type": "boolean"
+        }
+      }
     }
   }
 }`))
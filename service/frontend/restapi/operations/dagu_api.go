@@ -40,26 +40,111 @@ func NewDaguAPI(spec *loads.Document) *DaguAPI {
 
 		JSONConsumer: runtime.JSONConsumer(),
 
+		BinProducer:  runtime.ByteStreamProducer(),
 		JSONProducer: runtime.JSONProducer(),
 
+		CreateAPITokenHandler: CreateAPITokenHandlerFunc(func(params CreateAPITokenParams) middleware.Responder {
+			return middleware.NotImplemented("operation CreateAPIToken has not yet been implemented")
+		}),
 		CreateDagHandler: CreateDagHandlerFunc(func(params CreateDagParams) middleware.Responder {
 			return middleware.NotImplemented("operation CreateDag has not yet been implemented")
 		}),
+		CreateFolderHandler: CreateFolderHandlerFunc(func(params CreateFolderParams) middleware.Responder {
+			return middleware.NotImplemented("operation CreateFolder has not yet been implemented")
+		}),
+		DeleteAPITokenHandler: DeleteAPITokenHandlerFunc(func(params DeleteAPITokenParams) middleware.Responder {
+			return middleware.NotImplemented("operation DeleteAPIToken has not yet been implemented")
+		}),
+		DeleteArtifactHandler: DeleteArtifactHandlerFunc(func(params DeleteArtifactParams) middleware.Responder {
+			return middleware.NotImplemented("operation DeleteArtifact has not yet been implemented")
+		}),
 		DeleteDagHandler: DeleteDagHandlerFunc(func(params DeleteDagParams) middleware.Responder {
 			return middleware.NotImplemented("operation DeleteDag has not yet been implemented")
 		}),
+		DeleteFolderHandler: DeleteFolderHandlerFunc(func(params DeleteFolderParams) middleware.Responder {
+			return middleware.NotImplemented("operation DeleteFolder has not yet been implemented")
+		}),
+		DeleteHaltHandler: DeleteHaltHandlerFunc(func(params DeleteHaltParams) middleware.Responder {
+			return middleware.NotImplemented("operation DeleteHalt has not yet been implemented")
+		}),
+		DownloadArtifactHandler: DownloadArtifactHandlerFunc(func(params DownloadArtifactParams) middleware.Responder {
+			return middleware.NotImplemented("operation DownloadArtifact has not yet been implemented")
+		}),
+		GetArtifactURLHandler: GetArtifactURLHandlerFunc(func(params GetArtifactURLParams) middleware.Responder {
+			return middleware.NotImplemented("operation GetArtifactURL has not yet been implemented")
+		}),
 		GetDagDetailsHandler: GetDagDetailsHandlerFunc(func(params GetDagDetailsParams) middleware.Responder {
 			return middleware.NotImplemented("operation GetDagDetails has not yet been implemented")
 		}),
+		GetDagGraphHandler: GetDagGraphHandlerFunc(func(params GetDagGraphParams) middleware.Responder {
+			return middleware.NotImplemented("operation GetDagGraph has not yet been implemented")
+		}),
+		GetDagStatusesHandler: GetDagStatusesHandlerFunc(func(params GetDagStatusesParams) middleware.Responder {
+			return middleware.NotImplemented("operation GetDagStatuses has not yet been implemented")
+		}),
+		GetDriftInventoryHandler: GetDriftInventoryHandlerFunc(func(params GetDriftInventoryParams) middleware.Responder {
+			return middleware.NotImplemented("operation GetDriftInventory has not yet been implemented")
+		}),
+		GetDriftReportHandler: GetDriftReportHandlerFunc(func(params GetDriftReportParams) middleware.Responder {
+			return middleware.NotImplemented("operation GetDriftReport has not yet been implemented")
+		}),
+		GetGcReportHandler: GetGcReportHandlerFunc(func(params GetGcReportParams) middleware.Responder {
+			return middleware.NotImplemented("operation GetGcReport has not yet been implemented")
+		}),
+		GetHaltHandler: GetHaltHandlerFunc(func(params GetHaltParams) middleware.Responder {
+			return middleware.NotImplemented("operation GetHalt has not yet been implemented")
+		}),
+		GetMetaHandler: GetMetaHandlerFunc(func(params GetMetaParams) middleware.Responder {
+			return middleware.NotImplemented("operation GetMeta has not yet been implemented")
+		}),
+		GetOpenAPIHandler: GetOpenAPIHandlerFunc(func(params GetOpenAPIParams) middleware.Responder {
+			return middleware.NotImplemented("operation GetOpenAPI has not yet been implemented")
+		}),
+		ListAPITokensHandler: ListAPITokensHandlerFunc(func(params ListAPITokensParams) middleware.Responder {
+			return middleware.NotImplemented("operation ListAPITokens has not yet been implemented")
+		}),
+		ListArtifactsHandler: ListArtifactsHandlerFunc(func(params ListArtifactsParams) middleware.Responder {
+			return middleware.NotImplemented("operation ListArtifacts has not yet been implemented")
+		}),
+		ListAuditEntriesHandler: ListAuditEntriesHandlerFunc(func(params ListAuditEntriesParams) middleware.Responder {
+			return middleware.NotImplemented("operation ListAuditEntries has not yet been implemented")
+		}),
+		ListChangesHandler: ListChangesHandlerFunc(func(params ListChangesParams) middleware.Responder {
+			return middleware.NotImplemented("operation ListChanges has not yet been implemented")
+		}),
 		ListDagsHandler: ListDagsHandlerFunc(func(params ListDagsParams) middleware.Responder {
 			return middleware.NotImplemented("operation ListDags has not yet been implemented")
 		}),
+		ListFoldersHandler: ListFoldersHandlerFunc(func(params ListFoldersParams) middleware.Responder {
+			return middleware.NotImplemented("operation ListFolders has not yet been implemented")
+		}),
+		ListTriggersHandler: ListTriggersHandlerFunc(func(params ListTriggersParams) middleware.Responder {
+			return middleware.NotImplemented("operation ListTriggers has not yet been implemented")
+		}),
+		PostBulkActionHandler: PostBulkActionHandlerFunc(func(params PostBulkActionParams) middleware.Responder {
+			return middleware.NotImplemented("operation PostBulkAction has not yet been implemented")
+		}),
 		PostDagActionHandler: PostDagActionHandlerFunc(func(params PostDagActionParams) middleware.Responder {
 			return middleware.NotImplemented("operation PostDagAction has not yet been implemented")
 		}),
+		PostHaltHandler: PostHaltHandlerFunc(func(params PostHaltParams) middleware.Responder {
+			return middleware.NotImplemented("operation PostHalt has not yet been implemented")
+		}),
+		PreviewArtifactHandler: PreviewArtifactHandlerFunc(func(params PreviewArtifactParams) middleware.Responder {
+			return middleware.NotImplemented("operation PreviewArtifact has not yet been implemented")
+		}),
+		ReviewChangeHandler: ReviewChangeHandlerFunc(func(params ReviewChangeParams) middleware.Responder {
+			return middleware.NotImplemented("operation ReviewChange has not yet been implemented")
+		}),
 		SearchDagsHandler: SearchDagsHandlerFunc(func(params SearchDagsParams) middleware.Responder {
 			return middleware.NotImplemented("operation SearchDags has not yet been implemented")
 		}),
+		StreamStepLogHandler: StreamStepLogHandlerFunc(func(params StreamStepLogParams) middleware.Responder {
+			return middleware.NotImplemented("operation StreamStepLog has not yet been implemented")
+		}),
+		ValidateDagHandler: ValidateDagHandlerFunc(func(params ValidateDagParams) middleware.Responder {
+			return middleware.NotImplemented("operation ValidateDag has not yet been implemented")
+		}),
 	}
 }
 
@@ -92,22 +177,81 @@ type DaguAPI struct {
 	//   - application/json
 	JSONConsumer runtime.Consumer
 
+	// BinProducer registers a producer for the following mime types:
+	//   - application/octet-stream
+	BinProducer runtime.Producer
 	// JSONProducer registers a producer for the following mime types:
 	//   - application/json
 	JSONProducer runtime.Producer
 
+	// CreateAPITokenHandler sets the operation handler for the create API token operation
+	CreateAPITokenHandler CreateAPITokenHandler
 	// CreateDagHandler sets the operation handler for the create dag operation
 	CreateDagHandler CreateDagHandler
+	// CreateFolderHandler sets the operation handler for the create folder operation
+	CreateFolderHandler CreateFolderHandler
+	// DeleteAPITokenHandler sets the operation handler for the delete API token operation
+	DeleteAPITokenHandler DeleteAPITokenHandler
+	// DeleteArtifactHandler sets the operation handler for the delete artifact operation
+	DeleteArtifactHandler DeleteArtifactHandler
 	// DeleteDagHandler sets the operation handler for the delete dag operation
 	DeleteDagHandler DeleteDagHandler
+	// DeleteFolderHandler sets the operation handler for the delete folder operation
+	DeleteFolderHandler DeleteFolderHandler
+	// DeleteHaltHandler sets the operation handler for the delete halt operation
+	DeleteHaltHandler DeleteHaltHandler
+	// DownloadArtifactHandler sets the operation handler for the download artifact operation
+	DownloadArtifactHandler DownloadArtifactHandler
+	// GetArtifactURLHandler sets the operation handler for the get artifact URL operation
+	GetArtifactURLHandler GetArtifactURLHandler
 	// GetDagDetailsHandler sets the operation handler for the get dag details operation
 	GetDagDetailsHandler GetDagDetailsHandler
+	// GetDagGraphHandler sets the operation handler for the get dag graph operation
+	GetDagGraphHandler GetDagGraphHandler
+	// GetDagStatusesHandler sets the operation handler for the get dag statuses operation
+	GetDagStatusesHandler GetDagStatusesHandler
+	// GetDriftInventoryHandler sets the operation handler for the get drift inventory operation
+	GetDriftInventoryHandler GetDriftInventoryHandler
+	// GetDriftReportHandler sets the operation handler for the get drift report operation
+	GetDriftReportHandler GetDriftReportHandler
+	// GetGcReportHandler sets the operation handler for the get gc report operation
+	GetGcReportHandler GetGcReportHandler
+	// GetHaltHandler sets the operation handler for the get halt operation
+	GetHaltHandler GetHaltHandler
+	// GetMetaHandler sets the operation handler for the get meta operation
+	GetMetaHandler GetMetaHandler
+	// GetOpenAPIHandler sets the operation handler for the get open API operation
+	GetOpenAPIHandler GetOpenAPIHandler
+	// ListAPITokensHandler sets the operation handler for the list API tokens operation
+	ListAPITokensHandler ListAPITokensHandler
+	// ListArtifactsHandler sets the operation handler for the list artifacts operation
+	ListArtifactsHandler ListArtifactsHandler
+	// ListAuditEntriesHandler sets the operation handler for the list audit entries operation
+	ListAuditEntriesHandler ListAuditEntriesHandler
+	// ListChangesHandler sets the operation handler for the list changes operation
+	ListChangesHandler ListChangesHandler
 	// ListDagsHandler sets the operation handler for the list dags operation
 	ListDagsHandler ListDagsHandler
+	// ListFoldersHandler sets the operation handler for the list folders operation
+	ListFoldersHandler ListFoldersHandler
+	// ListTriggersHandler sets the operation handler for the list triggers operation
+	ListTriggersHandler ListTriggersHandler
+	// PostBulkActionHandler sets the operation handler for the post bulk action operation
+	PostBulkActionHandler PostBulkActionHandler
 	// PostDagActionHandler sets the operation handler for the post dag action operation
 	PostDagActionHandler PostDagActionHandler
+	// PostHaltHandler sets the operation handler for the post halt operation
+	PostHaltHandler PostHaltHandler
+	// PreviewArtifactHandler sets the operation handler for the preview artifact operation
+	PreviewArtifactHandler PreviewArtifactHandler
+	// ReviewChangeHandler sets the operation handler for the review change operation
+	ReviewChangeHandler ReviewChangeHandler
 	// SearchDagsHandler sets the operation handler for the search dags operation
 	SearchDagsHandler SearchDagsHandler
+	// StreamStepLogHandler sets the operation handler for the stream step log operation
+	StreamStepLogHandler StreamStepLogHandler
+	// ValidateDagHandler sets the operation handler for the validate dag operation
+	ValidateDagHandler ValidateDagHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -181,28 +325,115 @@ func (o *DaguAPI) Validate() error {
 		unregistered = append(unregistered, "JSONConsumer")
 	}
 
+	if o.BinProducer == nil {
+		unregistered = append(unregistered, "BinProducer")
+	}
 	if o.JSONProducer == nil {
 		unregistered = append(unregistered, "JSONProducer")
 	}
 
+	if o.CreateAPITokenHandler == nil {
+		unregistered = append(unregistered, "CreateAPITokenHandler")
+	}
 	if o.CreateDagHandler == nil {
 		unregistered = append(unregistered, "CreateDagHandler")
 	}
+	if o.CreateFolderHandler == nil {
+		unregistered = append(unregistered, "CreateFolderHandler")
+	}
+	if o.DeleteAPITokenHandler == nil {
+		unregistered = append(unregistered, "DeleteAPITokenHandler")
+	}
+	if o.DeleteArtifactHandler == nil {
+		unregistered = append(unregistered, "DeleteArtifactHandler")
+	}
 	if o.DeleteDagHandler == nil {
 		unregistered = append(unregistered, "DeleteDagHandler")
 	}
+	if o.DeleteFolderHandler == nil {
+		unregistered = append(unregistered, "DeleteFolderHandler")
+	}
+	if o.DeleteHaltHandler == nil {
+		unregistered = append(unregistered, "DeleteHaltHandler")
+	}
+	if o.DownloadArtifactHandler == nil {
+		unregistered = append(unregistered, "DownloadArtifactHandler")
+	}
+	if o.GetArtifactURLHandler == nil {
+		unregistered = append(unregistered, "GetArtifactURLHandler")
+	}
 	if o.GetDagDetailsHandler == nil {
 		unregistered = append(unregistered, "GetDagDetailsHandler")
 	}
+	if o.GetDagGraphHandler == nil {
+		unregistered = append(unregistered, "GetDagGraphHandler")
+	}
+	if o.GetDagStatusesHandler == nil {
+		unregistered = append(unregistered, "GetDagStatusesHandler")
+	}
+	if o.GetDriftInventoryHandler == nil {
+		unregistered = append(unregistered, "GetDriftInventoryHandler")
+	}
+	if o.GetDriftReportHandler == nil {
+		unregistered = append(unregistered, "GetDriftReportHandler")
+	}
+	if o.GetGcReportHandler == nil {
+		unregistered = append(unregistered, "GetGcReportHandler")
+	}
+	if o.GetHaltHandler == nil {
+		unregistered = append(unregistered, "GetHaltHandler")
+	}
+	if o.GetMetaHandler == nil {
+		unregistered = append(unregistered, "GetMetaHandler")
+	}
+	if o.GetOpenAPIHandler == nil {
+		unregistered = append(unregistered, "GetOpenAPIHandler")
+	}
+	if o.ListAPITokensHandler == nil {
+		unregistered = append(unregistered, "ListAPITokensHandler")
+	}
+	if o.ListArtifactsHandler == nil {
+		unregistered = append(unregistered, "ListArtifactsHandler")
+	}
+	if o.ListAuditEntriesHandler == nil {
+		unregistered = append(unregistered, "ListAuditEntriesHandler")
+	}
+	if o.ListChangesHandler == nil {
+		unregistered = append(unregistered, "ListChangesHandler")
+	}
 	if o.ListDagsHandler == nil {
 		unregistered = append(unregistered, "ListDagsHandler")
 	}
+	if o.ListFoldersHandler == nil {
+		unregistered = append(unregistered, "ListFoldersHandler")
+	}
+	if o.ListTriggersHandler == nil {
+		unregistered = append(unregistered, "ListTriggersHandler")
+	}
+	if o.PostBulkActionHandler == nil {
+		unregistered = append(unregistered, "PostBulkActionHandler")
+	}
 	if o.PostDagActionHandler == nil {
 		unregistered = append(unregistered, "PostDagActionHandler")
 	}
+	if o.PostHaltHandler == nil {
+		unregistered = append(unregistered, "PostHaltHandler")
+	}
+	if o.PreviewArtifactHandler == nil {
+		unregistered = append(unregistered, "PreviewArtifactHandler")
+	}
+	if o.ReviewChangeHandler == nil {
+		unregistered = append(unregistered, "ReviewChangeHandler")
+	}
 	if o.SearchDagsHandler == nil {
 		unregistered = append(unregistered, "SearchDagsHandler")
 	}
+	if o.StreamStepLogHandler == nil {
+		unregistered = append(unregistered, "StreamStepLogHandler")
+	}
+	if o.ValidateDagHandler == nil {
+		unregistered = append(unregistered, "ValidateDagHandler")
+	}
 
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
@@ -249,6 +480,8 @@ func (o *DaguAPI) ProducersFor(mediaTypes []string) map[string]runtime.Producer
 	result := make(map[string]runtime.Producer, len(mediaTypes))
 	for _, mt := range mediaTypes {
 		switch mt {
+		case "application/octet-stream":
+			result["application/octet-stream"] = o.BinProducer
 		case "application/json":
 			result["application/json"] = o.JSONProducer
 		}
@@ -291,14 +524,46 @@ func (o *DaguAPI) initHandlerCache() {
 		o.handlers = make(map[string]map[string]http.Handler)
 	}
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/tokens"] = NewCreateAPIToken(o.context, o.CreateAPITokenHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/dags"] = NewCreateDag(o.context, o.CreateDagHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/folders"] = NewCreateFolder(o.context, o.CreateFolderHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/tokens/{tokenName}"] = NewDeleteAPIToken(o.context, o.DeleteAPITokenHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/dags/{dagId}/runs/{requestId}/artifacts"] = NewDeleteArtifact(o.context, o.DeleteArtifactHandler)
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
 	o.handlers["DELETE"]["/dags/{dagId}"] = NewDeleteDag(o.context, o.DeleteDagHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/folders/{folder}"] = NewDeleteFolder(o.context, o.DeleteFolderHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/halt"] = NewDeleteHalt(o.context, o.DeleteHaltHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/dags/{dagId}/runs/{requestId}/artifacts/download"] = NewDownloadArtifact(o.context, o.DownloadArtifactHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/dags/{dagId}/runs/{requestId}/artifacts/url"] = NewGetArtifactURL(o.context, o.GetArtifactURLHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -306,15 +571,95 @@ func (o *DaguAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/dags/{dagId}/graph"] = NewGetDagGraph(o.context, o.GetDagGraphHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/status"] = NewGetDagStatuses(o.context, o.GetDagStatusesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/drift/inventory"] = NewGetDriftInventory(o.context, o.GetDriftInventoryHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/drift"] = NewGetDriftReport(o.context, o.GetDriftReportHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/gc/report"] = NewGetGcReport(o.context, o.GetGcReportHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/halt"] = NewGetHalt(o.context, o.GetHaltHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/meta"] = NewGetMeta(o.context, o.GetMetaHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/openapi.json"] = NewGetOpenAPI(o.context, o.GetOpenAPIHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/tokens"] = NewListAPITokens(o.context, o.ListAPITokensHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/dags/{dagId}/runs/{requestId}/artifacts"] = NewListArtifacts(o.context, o.ListArtifactsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/audit"] = NewListAuditEntries(o.context, o.ListAuditEntriesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/changes"] = NewListChanges(o.context, o.ListChangesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/dags"] = NewListDags(o.context, o.ListDagsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/folders"] = NewListFolders(o.context, o.ListFoldersHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/triggers"] = NewListTriggers(o.context, o.ListTriggersHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/bulk"] = NewPostBulkAction(o.context, o.PostBulkActionHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/dags/{dagId}"] = NewPostDagAction(o.context, o.PostDagActionHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/halt"] = NewPostHalt(o.context, o.PostHaltHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/dags/{dagId}/runs/{requestId}/artifacts/preview"] = NewPreviewArtifact(o.context, o.PreviewArtifactHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/changes/{changeId}"] = NewReviewChange(o.context, o.ReviewChangeHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/search"] = NewSearchDags(o.context, o.SearchDagsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/dags/{dagId}/runs/{requestId}/steps/{stepName}/log/stream"] = NewStreamStepLog(o.context, o.StreamStepLogHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/validate"] = NewValidateDag(o.context, o.ValidateDagHandler)
 }
 
 // Serve creates a http handler to serve the API over HTTP
@@ -12,6 +12,7 @@ import (
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewGetDagDetailsParams creates a new GetDagDetailsParams object
@@ -40,6 +41,18 @@ type GetDagDetailsParams struct {
 	  In: query
 	*/
 	File *string
+	/*Maximum number of the runs of the history tab, 30 by default.
+	  In: query
+	*/
+	Limit *int64
+	/*Number of the latest runs to skip in the history tab.
+	  In: query
+	*/
+	Offset *int64
+	/*Request ID of the run to show instead of the latest run.
+	  In: query
+	*/
+	RequestID *string
 	/*
 	  In: query
 	*/
@@ -71,6 +84,21 @@ func (o *GetDagDetailsParams) BindRequest(r *http.Request, route *middleware.Mat
 		res = append(res, err)
 	}
 
+	qLimit, qhkLimit, _ := qs.GetOK("limit")
+	if err := o.bindLimit(qLimit, qhkLimit, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qOffset, qhkOffset, _ := qs.GetOK("offset")
+	if err := o.bindOffset(qOffset, qhkOffset, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qRequestID, qhkRequestID, _ := qs.GetOK("requestId")
+	if err := o.bindRequestID(qRequestID, qhkRequestID, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qStep, qhkStep, _ := qs.GetOK("step")
 	if err := o.bindStep(qStep, qhkStep, route.Formats); err != nil {
 		res = append(res, err)
@@ -118,6 +146,70 @@ func (o *GetDagDetailsParams) bindFile(rawData []string, hasKey bool, formats st
 	return nil
 }
 
+// bindLimit binds and validates parameter Limit from query.
+func (o *GetDagDetailsParams) bindLimit(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("limit", "query", "int64", raw)
+	}
+	o.Limit = &value
+
+	return nil
+}
+
+// bindOffset binds and validates parameter Offset from query.
+func (o *GetDagDetailsParams) bindOffset(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("offset", "query", "int64", raw)
+	}
+	o.Offset = &value
+
+	return nil
+}
+
+// bindRequestID binds and validates parameter RequestID from query.
+func (o *GetDagDetailsParams) bindRequestID(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.RequestID = &raw
+
+	return nil
+}
+
 // bindStep binds and validates parameter Step from query.
 func (o *GetDagDetailsParams) bindStep(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
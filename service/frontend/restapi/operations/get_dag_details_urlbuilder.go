@@ -10,15 +10,20 @@ import (
 	"net/url"
 	golangswaggerpaths "path"
 	"strings"
+
+	"github.com/go-openapi/swag"
 )
 
 // GetDagDetailsURL generates an URL for the get dag details operation
 type GetDagDetailsURL struct {
 	DagID string
 
-	File *string
-	Step *string
-	Tab  *string
+	File      *string
+	Limit     *int64
+	Offset    *int64
+	RequestID *string
+	Step      *string
+	Tab       *string
 
 	_basePath string
 	// avoid unkeyed usage
@@ -69,6 +74,30 @@ func (o *GetDagDetailsURL) Build() (*url.URL, error) {
 		qs.Set("file", fileQ)
 	}
 
+	var limitQ string
+	if o.Limit != nil {
+		limitQ = swag.FormatInt64(*o.Limit)
+	}
+	if limitQ != "" {
+		qs.Set("limit", limitQ)
+	}
+
+	var offsetQ string
+	if o.Offset != nil {
+		offsetQ = swag.FormatInt64(*o.Offset)
+	}
+	if offsetQ != "" {
+		qs.Set("offset", offsetQ)
+	}
+
+	var requestIDQ string
+	if o.RequestID != nil {
+		requestIDQ = *o.RequestID
+	}
+	if requestIDQ != "" {
+		qs.Set("requestId", requestIDQ)
+	}
+
 	var stepQ string
 	if o.Step != nil {
 		stepQ = *o.Step
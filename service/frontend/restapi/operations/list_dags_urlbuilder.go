@@ -9,11 +9,24 @@ import (
 	"errors"
 	"net/url"
 	golangswaggerpaths "path"
+
+	"github.com/go-openapi/swag"
 )
 
 // ListDagsURL generates an URL for the list dags operation
 type ListDagsURL struct {
+	Folder    *string
+	Limit     *int64
+	Offset    *int64
+	Order     *string
+	Scheduled *bool
+	Sort      *string
+	Status    *string
+	Tag       *string
+
 	_basePath string
+	// avoid unkeyed usage
+	_ struct{}
 }
 
 // WithBasePath sets the base path for this url builder, only required when it's different from the
@@ -43,6 +56,74 @@ func (o *ListDagsURL) Build() (*url.URL, error) {
 	}
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var folderQ string
+	if o.Folder != nil {
+		folderQ = *o.Folder
+	}
+	if folderQ != "" {
+		qs.Set("folder", folderQ)
+	}
+
+	var limitQ string
+	if o.Limit != nil {
+		limitQ = swag.FormatInt64(*o.Limit)
+	}
+	if limitQ != "" {
+		qs.Set("limit", limitQ)
+	}
+
+	var offsetQ string
+	if o.Offset != nil {
+		offsetQ = swag.FormatInt64(*o.Offset)
+	}
+	if offsetQ != "" {
+		qs.Set("offset", offsetQ)
+	}
+
+	var orderQ string
+	if o.Order != nil {
+		orderQ = *o.Order
+	}
+	if orderQ != "" {
+		qs.Set("order", orderQ)
+	}
+
+	var scheduledQ string
+	if o.Scheduled != nil {
+		scheduledQ = swag.FormatBool(*o.Scheduled)
+	}
+	if scheduledQ != "" {
+		qs.Set("scheduled", scheduledQ)
+	}
+
+	var sortQ string
+	if o.Sort != nil {
+		sortQ = *o.Sort
+	}
+	if sortQ != "" {
+		qs.Set("sort", sortQ)
+	}
+
+	var statusQ string
+	if o.Status != nil {
+		statusQ = *o.Status
+	}
+	if statusQ != "" {
+		qs.Set("status", statusQ)
+	}
+
+	var tagQ string
+	if o.Tag != nil {
+		tagQ = *o.Tag
+	}
+	if tagQ != "" {
+		qs.Set("tag", tagQ)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
@@ -68,19 +68,25 @@ type PostDagActionBody struct {
 
 	// action
 	// Required: true
-	// Enum: [start suspend stop retry mark-success mark-failed save rename]
+	// Enum: [start suspend stop retry mark-success mark-failed save rename move]
 	Action *string `json:"action"`
 
+	// Faults injected into the steps of the run of start, e.g. fail=extract or delay=load:30s. Only the servers that allow chaos accept them.
+	Chaos []string `json:"chaos"`
+
 	// params
 	Params string `json:"params,omitempty"`
 
+	// Reason of the operator who marks the status of a step. It is required by mark-success and mark-failed.
+	Reason string `json:"reason,omitempty"`
+
 	// request Id
 	RequestID string `json:"requestId,omitempty"`
 
 	// step
 	Step string `json:"step,omitempty"`
 
-	// value
+	// The new name of rename, which is a path in the DAGs directory to move the DAG to a folder, e.g. team-a/etl, or the folder of move, / being the DAGs directory itself.
 	Value string `json:"value,omitempty"`
 }
 
@@ -102,7 +108,7 @@ var postDagActionBodyTypeActionPropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["start","suspend","stop","retry","mark-success","mark-failed","save","rename"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["start","suspend","stop","retry","mark-success","mark-failed","save","rename","move"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -135,6 +141,9 @@ const (
 
 	// PostDagActionBodyActionRename captures enum value "rename"
 	PostDagActionBodyActionRename string = "rename"
+
+	// PostDagActionBodyActionMove captures enum value "move"
+	PostDagActionBodyActionMove string = "move"
 )
 
 // prop value enum
@@ -12,6 +12,7 @@ import (
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
 )
 
@@ -41,6 +42,14 @@ type PostDagActionParams struct {
 	  In: path
 	*/
 	DagID string
+	/*Maximum time to wait for the run, e.g. 300s or 10m, 5 minutes by default. The status of the run is returned as it is if it has not finished by then.
+	  In: query
+	*/
+	Timeout *string
+	/*Waits for the run of start to finish, and returns its status and the outputs of its steps.
+	  In: query
+	*/
+	Wait *bool
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -52,6 +61,8 @@ func (o *PostDagActionParams) BindRequest(r *http.Request, route *middleware.Mat
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
 	if runtime.HasBody(r) {
 		defer r.Body.Close()
 		var body PostDagActionBody
@@ -78,6 +89,16 @@ func (o *PostDagActionParams) BindRequest(r *http.Request, route *middleware.Mat
 	if err := o.bindDagID(rDagID, rhkDagID, route.Formats); err != nil {
 		res = append(res, err)
 	}
+
+	qTimeout, qhkTimeout, _ := qs.GetOK("timeout")
+	if err := o.bindTimeout(qTimeout, qhkTimeout, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qWait, qhkWait, _ := qs.GetOK("wait")
+	if err := o.bindWait(qWait, qhkWait, route.Formats); err != nil {
+		res = append(res, err)
+	}
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -97,3 +118,44 @@ func (o *PostDagActionParams) bindDagID(rawData []string, hasKey bool, formats s
 
 	return nil
 }
+
+// bindTimeout binds and validates parameter Timeout from query.
+func (o *PostDagActionParams) bindTimeout(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.Timeout = &raw
+
+	return nil
+}
+
+// bindWait binds and validates parameter Wait from query.
+func (o *PostDagActionParams) bindWait(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("wait", "query", "bool", raw)
+	}
+	o.Wait = &value
+
+	return nil
+}
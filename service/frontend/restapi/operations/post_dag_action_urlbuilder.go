@@ -10,12 +10,17 @@ import (
 	"net/url"
 	golangswaggerpaths "path"
 	"strings"
+
+	"github.com/go-openapi/swag"
 )
 
 // PostDagActionURL generates an URL for the post dag action operation
 type PostDagActionURL struct {
 	DagID string
 
+	Timeout *string
+	Wait    *bool
+
 	_basePath string
 	// avoid unkeyed usage
 	_ struct{}
@@ -55,6 +60,26 @@ func (o *PostDagActionURL) Build() (*url.URL, error) {
 	}
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var timeoutQ string
+	if o.Timeout != nil {
+		timeoutQ = *o.Timeout
+	}
+	if timeoutQ != "" {
+		qs.Set("timeout", timeoutQ)
+	}
+
+	var waitQ string
+	if o.Wait != nil {
+		waitQ = swag.FormatBool(*o.Wait)
+	}
+	if waitQ != "" {
+		qs.Set("wait", waitQ)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
@@ -32,7 +32,7 @@ func NewSearchDags(ctx *middleware.Context, handler SearchDagsHandler) *SearchDa
 /*
 	SearchDags swagger:route GET /search searchDags
 
-Searches for DAGs.
+Searches the names, the descriptions, the tags and the step commands of the DAGs, and optionally the logs of their recent runs, for the words of the query, the most relevant DAGs first.
 */
 type SearchDags struct {
 	Context *middleware.Context
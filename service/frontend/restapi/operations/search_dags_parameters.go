@@ -12,6 +12,7 @@ import (
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
 )
 
@@ -32,7 +33,11 @@ type SearchDagsParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
-	/*
+	/*Searches the step logs of the recent runs too if it is true.
+	  In: query
+	*/
+	Logs *bool
+	/*The words the DAGs must all have, each as the prefix of a word.
 	  Required: true
 	  In: query
 	*/
@@ -50,6 +55,11 @@ func (o *SearchDagsParams) BindRequest(r *http.Request, route *middleware.Matche
 
 	qs := runtime.Values(r.URL.Query())
 
+	qLogs, qhkLogs, _ := qs.GetOK("logs")
+	if err := o.bindLogs(qLogs, qhkLogs, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qQ, qhkQ, _ := qs.GetOK("q")
 	if err := o.bindQ(qQ, qhkQ, route.Formats); err != nil {
 		res = append(res, err)
@@ -60,6 +70,29 @@ func (o *SearchDagsParams) BindRequest(r *http.Request, route *middleware.Matche
 	return nil
 }
 
+// bindLogs binds and validates parameter Logs from query.
+func (o *SearchDagsParams) bindLogs(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("logs", "query", "bool", raw)
+	}
+	o.Logs = &value
+
+	return nil
+}
+
 // bindQ binds and validates parameter Q from query.
 func (o *SearchDagsParams) bindQ(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	if !hasKey {
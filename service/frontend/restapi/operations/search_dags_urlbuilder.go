@@ -9,11 +9,14 @@ import (
 	"errors"
 	"net/url"
 	golangswaggerpaths "path"
+
+	"github.com/go-openapi/swag"
 )
 
 // SearchDagsURL generates an URL for the search dags operation
 type SearchDagsURL struct {
-	Q string
+	Logs *bool
+	Q    string
 
 	_basePath string
 	// avoid unkeyed usage
@@ -49,6 +52,14 @@ func (o *SearchDagsURL) Build() (*url.URL, error) {
 
 	qs := make(url.Values)
 
+	var logsQ string
+	if o.Logs != nil {
+		logsQ = swag.FormatBool(*o.Logs)
+	}
+	if logsQ != "" {
+		qs.Set("logs", logsQ)
+	}
+
 	qQ := o.Q
 	if qQ != "" {
 		qs.Set("q", qQ)
@@ -1,12 +1,18 @@
 package server
 
 import (
+	"encoding/json"
 	"net/http"
 
+	"github.com/dagu-dev/dagu/internal/health"
 	"github.com/go-chi/chi/v5"
 )
 
 func (svr *Server) defaultRoutes(r *chi.Mux) *chi.Mux {
+	if svr.health != nil {
+		r.Get(health.LivenessPath, svr.handleProbe(svr.health.Liveness))
+		r.Get(health.ReadinessPath, svr.handleProbe(svr.health.Readiness))
+	}
 	r.Get("/assets/*", svr.handleGetAssets())
 	r.Get("/*", svr.handleRequest())
 
@@ -20,6 +26,20 @@ func (svr *Server) handleRequest() http.HandlerFunc {
 	}
 }
 
+// handleProbe responds with the report of the checks, with 503 if a check
+// failed so that the probe fails.
+func (svr *Server) handleProbe(check func() *health.Report) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := check()
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if !report.OK() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	}
+}
+
 func (svr *Server) handleGetAssets() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Cache-Control", "max-age=86400")
@@ -7,11 +7,17 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/health"
+	"github.com/dagu-dev/dagu/internal/ldap"
 	"github.com/dagu-dev/dagu/internal/logger"
 	"github.com/dagu-dev/dagu/internal/logger/tag"
+	"github.com/dagu-dev/dagu/internal/oidc"
+	"github.com/dagu-dev/dagu/internal/persistence"
 	"github.com/dagu-dev/dagu/service/frontend/restapi"
 	"github.com/go-openapi/loads"
 	flags "github.com/jessevdk/go-flags"
@@ -31,15 +37,50 @@ type AuthToken struct {
 	Token string
 }
 
+// APIToken is a named bearer token of the API with its scopes.
+type APIToken struct {
+	Name   string
+	Token  string
+	Scopes []string
+}
+
+// AccessRule grants a user, the users of an OIDC or LDAP group or a token a
+// role on the DAGs of the prefixes or the tags of the namespaces.
+type AccessRule struct {
+	User     string
+	Token    string
+	Role     string
+	Prefixes []string
+	Tags     []string
+
+	Group      string
+	Namespaces []string
+}
+
 type Params struct {
 	Host      string
 	Port      int
 	BasicAuth *BasicAuth
 	AuthToken *AuthToken
+	APITokens []APIToken
 	TLS       *config.TLS
 	Logger    logger.Logger
 	Handlers  []New
 	AssetsFS  fs.FS
+	// TokenStore keeps the API tokens created with the API or the CLI.
+	TokenStore  persistence.TokenStore
+	AccessRules []AccessRule
+	// OIDC is the login with an OpenID Connect provider if its issuer is
+	// set.
+	OIDC *config.OIDC
+	// LDAP authenticates the users of basic auth with a directory if its
+	// URL is set.
+	LDAP *config.LDAP
+	// Namespaces are the names of the namespaces served besides the
+	// default one.
+	Namespaces []string
+	// Health serves the probes of the containers if it is not nil.
+	Health *health.Checker
 }
 
 type Server struct {
@@ -47,11 +88,19 @@ type Server struct {
 	port      int
 	basicAuth *BasicAuth
 	authToken *AuthToken
+	apiTokens []APIToken
 	tls       *config.TLS
 	logger    logger.Logger
 	server    *restapi.Server
 	handlers  []New
 	assets    fs.FS
+
+	tokenStore  persistence.TokenStore
+	accessRules []AccessRule
+	oidc        *config.OIDC
+	ldap        *config.LDAP
+	namespaces  []string
+	health      *health.Checker
 }
 
 type New interface {
@@ -64,10 +113,18 @@ func NewServer(params Params) *Server {
 		port:      params.Port,
 		basicAuth: params.BasicAuth,
 		authToken: params.AuthToken,
+		apiTokens: params.APITokens,
 		tls:       params.TLS,
 		logger:    params.Logger,
 		handlers:  params.Handlers,
 		assets:    params.AssetsFS,
+
+		tokenStore:  params.TokenStore,
+		accessRules: params.AccessRules,
+		oidc:        params.OIDC,
+		ldap:        params.LDAP,
+		namespaces:  params.Namespaces,
+		health:      params.Health,
 	}
 }
 
@@ -81,21 +138,118 @@ func (svr *Server) Shutdown() {
 	}
 }
 
+// oidcAuth returns the login with the OpenID Connect provider of the
+// configuration.
+func (svr *Server) oidcAuth() (*pkgmiddleware.OIDC, error) {
+	cfg := svr.oidc
+	provider, err := oidc.New(oidc.Config{
+		Issuer:       cfg.Issuer,
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  cfg.RedirectURL,
+		Scopes:       cfg.Scopes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	secret := []byte(cfg.SessionSecret)
+	if len(secret) == 0 {
+		secret = []byte(oidc.RandomValue())
+		svr.logger.Warn("no OIDC session secret, the sessions end with the server")
+	}
+	return &pkgmiddleware.OIDC{
+		Provider:      provider,
+		UsernameClaim: cfg.UsernameClaim,
+		GroupsClaim:   cfg.GroupsClaim,
+		SessionSecret: secret,
+		SessionTTL:    time.Second * time.Duration(cfg.SessionTTLSec),
+	}, nil
+}
+
+// ldapAuth returns the authentication with the directory of the
+// configuration.
+func (svr *Server) ldapAuth() (*pkgmiddleware.LDAP, error) {
+	cfg := svr.ldap
+	a, err := ldap.New(ldap.Config{
+		URL:                cfg.URL,
+		StartTLS:           cfg.StartTLS,
+		CACertFile:         cfg.CACertFile,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		BindDN:             cfg.BindDN,
+		BindPassword:       cfg.BindPassword,
+		BaseDN:             cfg.BaseDN,
+		UserFilter:         cfg.UserFilter,
+		GroupBaseDN:        cfg.GroupBaseDN,
+		GroupFilter:        cfg.GroupFilter,
+		GroupAttribute:     cfg.GroupAttribute,
+		RequiredGroups:     cfg.RequiredGroups,
+		Timeout:            time.Second * time.Duration(cfg.TimeoutSec),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(cfg.URL, "ldap://") && !cfg.StartTLS {
+		svr.logger.Warn("the passwords are sent to the LDAP server in plain text without StartTLS")
+	}
+	return &pkgmiddleware.LDAP{Authenticator: a}, nil
+}
+
 func (svr *Server) Serve(ctx context.Context) (err error) {
 	middlewareOptions := &pkgmiddleware.Options{
-		Handler: svr.defaultRoutes(chi.NewRouter()),
+		Handler:    svr.defaultRoutes(chi.NewRouter()),
+		TokenStore: svr.tokenStore,
+		Namespaces: svr.namespaces,
 	}
 	if svr.authToken != nil {
 		middlewareOptions.AuthToken = &pkgmiddleware.AuthToken{
 			Token: svr.authToken.Token,
 		}
 	}
+	for _, t := range svr.apiTokens {
+		middlewareOptions.APITokens = append(middlewareOptions.APITokens, pkgmiddleware.APIToken{
+			Name:   t.Name,
+			Token:  t.Token,
+			Scopes: t.Scopes,
+		})
+	}
 	if svr.basicAuth != nil {
 		middlewareOptions.AuthBasic = &pkgmiddleware.AuthBasic{
 			Username: svr.basicAuth.Username,
 			Password: svr.basicAuth.Password,
 		}
 	}
+	for _, r := range svr.accessRules {
+		middlewareOptions.AccessRules = append(middlewareOptions.AccessRules, pkgmiddleware.AccessRule{
+			User:     r.User,
+			Token:    r.Token,
+			Role:     r.Role,
+			Prefixes: r.Prefixes,
+			Tags:     r.Tags,
+			Group:    r.Group,
+
+			Namespaces: r.Namespaces,
+		})
+	}
+	if err := pkgmiddleware.ValidateAccessRules(middlewareOptions.AccessRules); err != nil {
+		svr.logger.Error("invalid access rules", tag.Error(err))
+		return err
+	}
+	if svr.oidc != nil && svr.oidc.Issuer != "" {
+		o, err := svr.oidcAuth()
+		if err != nil {
+			svr.logger.Error("invalid OIDC configuration", tag.Error(err))
+			return err
+		}
+		middlewareOptions.OIDC = o
+	}
+	if svr.ldap != nil && svr.ldap.URL != "" {
+		l, err := svr.ldapAuth()
+		if err != nil {
+			svr.logger.Error("invalid LDAP configuration", tag.Error(err))
+			return err
+		}
+		middlewareOptions.LDAP = l
+	}
 	pkgmiddleware.Setup(middlewareOptions)
 
 	swaggerSpec, err := loads.Analyzed(restapi.SwaggerJSON, "")
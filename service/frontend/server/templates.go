@@ -2,6 +2,7 @@ package server
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"log"
 	"net/http"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/dagu-dev/dagu/internal/config"
 	"github.com/dagu-dev/dagu/internal/constants"
+	pkgmiddleware "github.com/dagu-dev/dagu/service/frontend/middleware"
 )
 
 var (
@@ -59,6 +61,14 @@ func defaultFunctions() template.FuncMap {
 		"apiURL": func() string {
 			return cfg.GetAPIBaseURL()
 		},
+		"namespaces": func() string {
+			names := []string{pkgmiddleware.DefaultNamespace}
+			for _, ns := range cfg.Namespaces {
+				names = append(names, ns.Name)
+			}
+			b, _ := json.Marshal(names)
+			return string(b)
+		},
 	}
 }
 
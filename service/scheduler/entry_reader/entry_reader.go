@@ -1,6 +1,7 @@
 package entry_reader
 
 import (
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"github.com/dagu-dev/dagu/internal/engine"
 	"github.com/dagu-dev/dagu/internal/logger"
 	"github.com/dagu-dev/dagu/internal/logger/tag"
+	dagscheduler "github.com/dagu-dev/dagu/internal/scheduler"
 	"github.com/dagu-dev/dagu/service/scheduler/filenotify"
 	"github.com/dagu-dev/dagu/service/scheduler/scheduler"
 
@@ -19,7 +21,7 @@ import (
 )
 
 type JobFactory interface {
-	NewJob(d *dag.DAG, next time.Time) scheduler.Job
+	NewJob(d *dag.DAG, next time.Time, schedule *dag.Schedule) scheduler.Job
 }
 
 type Params struct {
@@ -27,8 +29,15 @@ type Params struct {
 	JobFactory    JobFactory
 	Logger        logger.Logger
 	EngineFactory engine.Factory
+	// CheckInterval is the interval of the checks whether the DAG directory
+	// is available. It is a minute if it is zero.
+	CheckInterval time.Duration
 }
 
+// EntryReader reads the schedules of the DAGs in the DAG directory. It
+// keeps the last known-good DAGs while the directory is unavailable, e.g.
+// while a network or Git mount is briefly unreadable, and schedules them
+// from the cache instead of treating them as removed.
 type EntryReader struct {
 	dagsDir       string
 	dagsLock      sync.Mutex
@@ -36,6 +45,10 @@ type EntryReader struct {
 	jf            JobFactory
 	logger        logger.Logger
 	engineFactory engine.Factory
+	checkInterval time.Duration
+	// unavailableSince is when the DAG directory became unavailable. It is
+	// zero while the directory is available.
+	unavailableSince time.Time
 }
 
 func New(params Params) *EntryReader {
@@ -46,13 +59,28 @@ func New(params Params) *EntryReader {
 		jf:            params.JobFactory,
 		logger:        params.Logger,
 		engineFactory: params.EngineFactory,
+		checkInterval: params.CheckInterval,
+	}
+	if er.checkInterval <= 0 {
+		er.checkInterval = time.Minute
 	}
 	if err := er.initDags(); err != nil {
 		er.logger.Error("failed to init entry_reader dags", tag.Error(err))
+		er.dagsLock.Lock()
+		er.markUnavailable(err)
+		er.dagsLock.Unlock()
 	}
 	return er
 }
 
+// Degraded returns true while the DAG directory is unavailable and the DAGs
+// are scheduled from the cache.
+func (er *EntryReader) Degraded() bool {
+	er.dagsLock.Lock()
+	defer er.dagsLock.Unlock()
+	return !er.unavailableSince.IsZero()
+}
+
 func (er *EntryReader) Start(done chan any) {
 	go er.watchDags(done)
 }
@@ -62,20 +90,35 @@ func (er *EntryReader) Read(now time.Time) ([]*scheduler.Entry, error) {
 	er.dagsLock.Lock()
 	defer er.dagsLock.Unlock()
 
-	f := func(d *dag.DAG, s []*dag.Schedule, e scheduler.Type) {
+	if !er.unavailableSince.IsZero() {
+		er.logger.Warn("the DAG directory is unavailable, scheduling from the cached DAGs",
+			"dir", er.dagsDir, "since", utils.FormatTime(er.unavailableSince), "dags", len(er.dags))
+	}
+
+	e := er.engineFactory.Create()
+	if e.IsHalted() {
+		er.logger.Warn("the schedules are halted by a stop of all the DAGs, resume them with dagu stop --resume")
+		return nil, nil
+	}
+	f := func(d *dag.DAG, s []*dag.Schedule, t scheduler.Type) {
 		for _, ss := range s {
 			next := ss.Parsed.Next(now)
+			if ss.Interval > 0 {
+				var ok bool
+				if next, ok = nextInterval(e, d, ss, now); !ok {
+					continue
+				}
+			}
 			entries = append(entries, &scheduler.Entry{
-				Next: ss.Parsed.Next(now),
+				Next: next,
 				// TODO: fix this
-				Job:       er.jf.NewJob(d, next),
-				EntryType: e,
+				Job:       er.jf.NewJob(d, next, ss),
+				EntryType: t,
 				Logger:    er.logger,
 			})
 		}
 	}
 
-	e := er.engineFactory.Create()
 	for _, d := range er.dags {
 		if e.IsSuspended(d.Name) {
 			continue
@@ -88,28 +131,203 @@ func (er *EntryReader) Read(now time.Time) ([]*scheduler.Entry, error) {
 	return entries, nil
 }
 
+// nextInterval returns the time the next run of an interval schedule is
+// due, which is the interval after the latest run finished, so that a run
+// that takes longer than the interval does not overlap with the next one. A
+// DAG that has not run yet is due now. It returns false while a run is
+// running.
+func nextInterval(e engine.Engine, d *dag.DAG, s *dag.Schedule, now time.Time) (time.Time, bool) {
+	current, err := e.GetCurrentStatus(d)
+	if err != nil || current.Status == dagscheduler.StatusRunning {
+		return time.Time{}, false
+	}
+	recent := e.GetRecentHistory(d, 1)
+	if len(recent) == 0 || recent[0].Status == nil {
+		return now, true
+	}
+	// The latest run is not running even if its status says so, since the
+	// agent of the DAG is not running.
+	finished, err := utils.ParseTime(recent[0].Status.FinishedAt)
+	if err != nil {
+		return now, true
+	}
+	return finished.Add(s.Interval), true
+}
+
 func (er *EntryReader) initDags() error {
 	er.dagsLock.Lock()
 	defer er.dagsLock.Unlock()
-	cl := dag.Loader{}
-	fis, err := os.ReadDir(er.dagsDir)
+	fileNames, err := er.loadDags()
 	if err != nil {
 		return err
 	}
+	er.logger.Info("init backend dags", "files", strings.Join(fileNames, ","))
+	return nil
+}
+
+// loadDags loads the DAGs in the DAG directory and its folders and removes
+// the ones that are no longer in them. The cached DAG is kept if its file
+// cannot be loaded. It returns the names of the files loaded, which are
+// their paths in the directory, e.g. team-a/etl.yaml.
+func (er *EntryReader) loadDags() ([]string, error) {
+	cl := dag.Loader{}
+	files, _, err := er.readDir()
+	if err != nil {
+		return nil, err
+	}
 	var fileNames []string
-	for _, fi := range fis {
-		if utils.MatchExtension(fi.Name(), dag.EXTENSIONS) {
-			d, err := cl.LoadMetadata(filepath.Join(er.dagsDir, fi.Name()))
-			if err != nil {
-				er.logger.Error("failed to read DAG cfg", tag.Error(err))
-				continue
+	found := map[string]bool{}
+	names := map[string]string{}
+	for _, name := range files {
+		found[name] = true
+		d, err := cl.LoadMetadata(er.file(name))
+		if err != nil {
+			er.logger.Error("failed to read DAG cfg", tag.Error(err))
+			continue
+		}
+		// The history and the suspend flags of the DAGs are keyed by their
+		// names, so a DAG of the name of another one is not scheduled.
+		if other, ok := names[d.Name]; ok {
+			er.logger.Error("skip the DAG of the same name as another one", "file", name, "other", other, "name", d.Name)
+			delete(er.dags, name)
+			continue
+		}
+		names[d.Name] = name
+		er.dags[name] = d
+		fileNames = append(fileNames, name)
+	}
+	for name := range er.dags {
+		if !found[name] {
+			delete(er.dags, name)
+		}
+	}
+	return fileNames, nil
+}
+
+// sameName returns the file of another DAG of the name of the DAG of the
+// file.
+func (er *EntryReader) sameName(file string, d *dag.DAG) (string, bool) {
+	for other, o := range er.dags {
+		if other != file && o.Name == d.Name {
+			return other, true
+		}
+	}
+	return "", false
+}
+
+// readDir returns the names of the DAG files in the DAG directory and its
+// folders, and the directories to watch, which are the DAG directory and
+// its folders. The hidden folders, e.g. .git, are skipped.
+func (er *EntryReader) readDir() (files, dirs []string, err error) {
+	err = filepath.WalkDir(er.dagsDir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if p == er.dagsDir {
+				return err
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			if p != er.dagsDir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
 			}
-			er.dags[fi.Name()] = d
-			fileNames = append(fileNames, fi.Name())
+			dirs = append(dirs, p)
+			return nil
 		}
+		if utils.MatchExtension(entry.Name(), dag.EXTENSIONS) {
+			files = append(files, er.name(p))
+		}
+		return nil
+	})
+	return files, dirs, err
+}
+
+// name returns the name of the DAG file, which is its path in the DAG
+// directory.
+func (er *EntryReader) name(file string) string {
+	rel, err := filepath.Rel(er.dagsDir, file)
+	if err != nil {
+		return filepath.Base(file)
+	}
+	return filepath.ToSlash(rel)
+}
+
+func (er *EntryReader) file(name string) string {
+	return filepath.Join(er.dagsDir, filepath.FromSlash(name))
+}
+
+// isFolder returns true if the file is a folder of the DAG directory, or
+// was the one of the DAGs, e.g. when it is removed.
+func (er *EntryReader) isFolder(file string) bool {
+	if fi, err := os.Stat(file); err == nil {
+		return fi.IsDir()
+	}
+	prefix := er.name(file) + "/"
+	for name := range er.dags {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// check checks whether the DAG directory is available. The DAGs are
+// reloaded when the directory becomes available again, since the changes
+// while it was unavailable were not watched.
+func (er *EntryReader) check() {
+	er.dagsLock.Lock()
+	defer er.dagsLock.Unlock()
+	if er.unavailableSince.IsZero() {
+		if _, err := os.ReadDir(er.dagsDir); err != nil {
+			er.markUnavailable(err)
+		}
+		return
+	}
+	fileNames, err := er.loadDags()
+	if err != nil {
+		return
+	}
+	er.logger.Info("the DAG directory is available again, reloaded the DAGs",
+		"dir", er.dagsDir, "files", strings.Join(fileNames, ","))
+	er.unavailableSince = time.Time{}
+}
+
+func (er *EntryReader) markUnavailable(err error) {
+	if !er.unavailableSince.IsZero() {
+		return
+	}
+	er.unavailableSince = time.Now()
+	er.logger.Error("the DAG directory is unavailable, scheduling from the cached DAGs",
+		"dir", er.dagsDir, "dags", len(er.dags), tag.Error(err))
+}
+
+// remove removes the DAG of the file that was removed from the DAG
+// directory. It is kept if the directory is unavailable, since the file may
+// only look removed.
+func (er *EntryReader) remove(name string) {
+	if _, err := os.ReadDir(er.dagsDir); err != nil {
+		er.markUnavailable(err)
+		return
+	}
+	if _, err := os.Stat(er.file(name)); err == nil {
+		return
+	} else if !os.IsNotExist(err) {
+		er.markUnavailable(err)
+		return
+	}
+	delete(er.dags, name)
+	er.logger.Info("remove DAG entry_reader", "file", name)
+}
+
+// watch watches the DAG directory and its folders. The folders that are
+// already watched are kept.
+func (er *EntryReader) watch(watcher filenotify.FileWatcher) {
+	_, dirs, err := er.readDir()
+	if err != nil {
+		dirs = []string{er.dagsDir}
+	}
+	for _, dir := range dirs {
+		_ = watcher.Add(dir)
 	}
-	er.logger.Info("init backend dags", "files", strings.Join(fileNames, ","))
-	return nil
 }
 
 func (er *EntryReader) watchDags(done chan any) {
@@ -122,31 +340,58 @@ func (er *EntryReader) watchDags(done chan any) {
 	defer func() {
 		_ = watcher.Close()
 	}()
-	_ = watcher.Add(er.dagsDir)
+	er.watch(watcher)
+	ticker := time.NewTicker(er.checkInterval)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-done:
 			return
+		case <-ticker.C:
+			degraded := er.Degraded()
+			er.check()
+			if degraded && !er.Degraded() {
+				// The watch may be lost with the directory.
+				_ = watcher.Remove(er.dagsDir)
+				er.watch(watcher)
+			}
 		case event, ok := <-watcher.Events():
 			if !ok {
 				return
 			}
 			if !utils.MatchExtension(event.Name, dag.EXTENSIONS) {
+				er.dagsLock.Lock()
+				folder := er.isFolder(event.Name)
+				if folder {
+					// The DAGs of a folder that is created, moved or
+					// removed are not notified one by one.
+					if _, err := er.loadDags(); err != nil {
+						er.logger.Error("failed to reload DAGs", tag.Error(err))
+					}
+					er.logger.Info("reload DAG folder entry_reader", "folder", event.Name)
+				}
+				er.dagsLock.Unlock()
+				if folder {
+					er.watch(watcher)
+				}
 				continue
 			}
+			name := er.name(event.Name)
 			er.dagsLock.Lock()
 			if event.Op == fsnotify.Create || event.Op == fsnotify.Write {
-				d, err := cl.LoadMetadata(filepath.Join(er.dagsDir, filepath.Base(event.Name)))
+				d, err := cl.LoadMetadata(er.file(name))
 				if err != nil {
 					er.logger.Error("failed to read DAG cfg", tag.Error(err))
+				} else if other, ok := er.sameName(name, d); ok {
+					er.logger.Error("skip the DAG of the same name as another one", "file", name, "other", other, "name", d.Name)
+					delete(er.dags, name)
 				} else {
-					er.dags[filepath.Base(event.Name)] = d
+					er.dags[name] = d
 					er.logger.Info("reload DAG entry_reader", "file", event.Name)
 				}
 			}
 			if event.Op == fsnotify.Rename || event.Op == fsnotify.Remove {
-				delete(er.dags, filepath.Base(event.Name))
-				er.logger.Info("remove DAG entry_reader", "file", event.Name)
+				er.remove(name)
 			}
 			er.dagsLock.Unlock()
 		case err, ok := <-watcher.Errors():
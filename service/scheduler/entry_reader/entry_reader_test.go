@@ -11,6 +11,8 @@ import (
 	"github.com/dagu-dev/dagu/internal/engine"
 	"github.com/dagu-dev/dagu/internal/logger"
 	"github.com/dagu-dev/dagu/internal/persistence/client"
+	"github.com/dagu-dev/dagu/internal/persistence/model"
+	dagscheduler "github.com/dagu-dev/dagu/internal/scheduler"
 	"github.com/dagu-dev/dagu/internal/utils"
 	"github.com/dagu-dev/dagu/service/scheduler/scheduler"
 
@@ -24,7 +26,9 @@ var (
 )
 
 func TestMain(m *testing.M) {
-	goleak.VerifyTestMain(m)
+	// The cache of the history store evicts its entries for the lifetime of
+	// the process.
+	goleak.VerifyTestMain(m, goleak.IgnoreTopFunction("github.com/dagu-dev/dagu/internal/persistence/filecache.(*Cache[...]).StartEviction.func1"))
 	code := m.Run()
 	os.Exit(code)
 }
@@ -80,19 +84,20 @@ func TestReadEntries(t *testing.T) {
 	entries, err = er.Read(now)
 	require.NoError(t, err)
 	require.GreaterOrEqual(t, len(entries), 1)
+	require.False(t, er.Degraded())
 
-	next := entries[0].Next
-	require.Equal(t, now.Add(time.Second), next)
-
-	// suspend
+	// The entries are not in the order of the DAGs.
 	var j scheduler.Job
 	for _, e := range entries {
-		jj := e.Job
-		if jj.GetDAG().Name == "scheduled_job" {
-			j = jj
+		if e.Job.GetDAG().Name == "scheduled_job" {
+			require.Equal(t, now.Add(time.Second), e.Next)
+			j = e.Job
 			break
 		}
 	}
+	require.NotNil(t, j)
+
+	// suspend
 
 	e := ef.Create()
 	err = e.ToggleSuspend(j.GetDAG().Name, true)
@@ -104,9 +109,129 @@ func TestReadEntries(t *testing.T) {
 	require.Equal(t, len(entries)-1, len(lives))
 }
 
+func TestReadHaltedEntries(t *testing.T) {
+	tmpDir, ef := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	er := New(Params{
+		DagsDir:       testdataDir,
+		JobFactory:    &mockJobFactory{},
+		Logger:        logger.NewSlogLogger(),
+		EngineFactory: ef,
+	})
+	done := make(chan any)
+	defer close(done)
+	er.Start(done)
+
+	now := time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC).Add(-time.Second)
+	e := ef.Create()
+	require.NoError(t, e.SetHalted(true))
+	entries, err := er.Read(now)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	// The schedules are read again once they are resumed.
+	require.NoError(t, e.SetHalted(false))
+	entries, err = er.Read(now)
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+}
+
+func TestReadIntervalEntries(t *testing.T) {
+	tmpDir, ef := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	dir := path.Join(testdataDir, "interval")
+	er := New(Params{
+		DagsDir:       dir,
+		JobFactory:    &mockJobFactory{},
+		Logger:        logger.NewSlogLogger(),
+		EngineFactory: ef,
+	})
+
+	// it is due now if it has not run yet
+	now := time.Date(2020, 1, 1, 1, 0, 0, 0, time.Local).Add(-time.Second)
+	entries, err := er.Read(now)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, now, entries[0].Next)
+
+	// it is due the interval after the latest run finished
+	d := entries[0].Job.GetDAG()
+	finished := time.Date(2020, 1, 1, 0, 30, 0, 0, time.Local)
+	hs := client.NewDataStoreFactory(&config.Config{
+		DataDir: path.Join(tmpDir, ".dagu", "data"),
+	}).NewHistoryStore()
+	require.NoError(t, hs.Open(d.Location, finished, "request-1"))
+	st := model.NewStatus(d, nil, dagscheduler.StatusSuccess, 0, model.Time(finished.Add(-time.Hour*3)), model.Time(finished))
+	require.NoError(t, hs.Write(st))
+	require.NoError(t, hs.Close())
+
+	entries, err = er.Read(now)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, finished.Add(time.Hour*2), entries[0].Next)
+}
+
+func TestReadEntriesFromCache(t *testing.T) {
+	tmpDir, ef := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	dir := path.Join(tmpDir, "dags")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	spec := "schedule: \"* * * * *\"\nsteps:\n  - name: step1\n    command: echo 1\n"
+	for _, name := range []string{"a.yaml", "b.yaml"} {
+		require.NoError(t, os.WriteFile(path.Join(dir, name), []byte(spec), 0600))
+	}
+	er := New(Params{
+		DagsDir:       dir,
+		JobFactory:    &mockJobFactory{},
+		Logger:        logger.NewSlogLogger(),
+		EngineFactory: ef,
+	})
+	now := time.Now()
+	entries, err := er.Read(now)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	// The DAGs are kept while the directory is unavailable.
+	require.NoError(t, os.Rename(dir, dir+".unmounted"))
+	er.remove("a.yaml")
+	require.True(t, er.Degraded())
+	er.check()
+	require.True(t, er.Degraded())
+	entries, err = er.Read(now)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	// The DAGs are reloaded when it is available again.
+	require.NoError(t, os.Rename(dir+".unmounted", dir))
+	require.NoError(t, os.Remove(path.Join(dir, "b.yaml")))
+	er.check()
+	require.False(t, er.Degraded())
+	entries, err = er.Read(now)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "a", entries[0].Job.GetDAG().Name)
+
+	// A DAG removed from the available directory is removed.
+	require.NoError(t, os.Remove(path.Join(dir, "a.yaml")))
+	er.remove("a.yaml")
+	require.False(t, er.Degraded())
+	entries, err = er.Read(now)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
 type mockJobFactory struct{}
 
-func (f *mockJobFactory) NewJob(d *dag.DAG, next time.Time) scheduler.Job {
+func (f *mockJobFactory) NewJob(d *dag.DAG, next time.Time, _ *dag.Schedule) scheduler.Job {
 	return &mockJob{DAG: d}
 }
 
@@ -147,3 +272,49 @@ func (j *mockJob) Restart() error {
 	j.RestartCount++
 	return nil
 }
+
+func TestReadEntriesInFolders(t *testing.T) {
+	tmpDir, ef := setupTest(t)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	dir := path.Join(tmpDir, "dags")
+	spec := "schedule: \"* * * * *\"\nsteps:\n  - name: step1\n    command: echo 1\n"
+	for _, name := range []string{"a.yaml", "team/b.yaml", ".git/c.yaml"} {
+		require.NoError(t, os.MkdirAll(path.Dir(path.Join(dir, name)), 0755))
+		require.NoError(t, os.WriteFile(path.Join(dir, name), []byte(spec), 0600))
+	}
+	er := New(Params{
+		DagsDir:       dir,
+		JobFactory:    &mockJobFactory{},
+		Logger:        logger.NewSlogLogger(),
+		EngineFactory: ef,
+	})
+	entries, err := er.Read(time.Now())
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Contains(t, er.dags, "team/b.yaml")
+
+	// The DAGs of a moved folder are reloaded from it.
+	require.NoError(t, os.Rename(path.Join(dir, "team"), path.Join(dir, "ops")))
+	er.dagsLock.Lock()
+	require.True(t, er.isFolder(path.Join(dir, "team")))
+	_, err = er.loadDags()
+	er.dagsLock.Unlock()
+	require.NoError(t, err)
+	require.NotContains(t, er.dags, "team/b.yaml")
+	require.Equal(t, path.Join(dir, "ops", "b.yaml"), er.dags["ops/b.yaml"].Location)
+
+	// A DAG of the name of another one in another folder is not scheduled.
+	require.NoError(t, os.WriteFile(path.Join(dir, "ops", "a.yaml"), []byte(spec), 0600))
+	er.dagsLock.Lock()
+	_, err = er.loadDags()
+	er.dagsLock.Unlock()
+	require.NoError(t, err)
+	require.Contains(t, er.dags, "a.yaml")
+	require.NotContains(t, er.dags, "ops/a.yaml")
+	entries, err = er.Read(time.Now())
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+}
@@ -15,9 +15,10 @@ type jobFactory struct {
 	EngineFactory engine.Factory
 }
 
-func (jf jobFactory) NewJob(d *dag.DAG, next time.Time) scheduler.Job {
+func (jf jobFactory) NewJob(d *dag.DAG, next time.Time, schedule *dag.Schedule) scheduler.Job {
 	return &job.Job{
 		DAG:           d,
+		Schedule:      schedule,
 		Executable:    jf.Executable,
 		WorkDir:       jf.WorkDir,
 		Next:          next,
@@ -2,10 +2,21 @@ package scheduler
 
 import (
 	"context"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/archive"
+	"github.com/dagu-dev/dagu/internal/clock"
 	"github.com/dagu-dev/dagu/internal/config"
+	"github.com/dagu-dev/dagu/internal/dispatch"
 	"github.com/dagu-dev/dagu/internal/engine"
+	"github.com/dagu-dev/dagu/internal/gc"
+	"github.com/dagu-dev/dagu/internal/health"
 	dagulogger "github.com/dagu-dev/dagu/internal/logger"
+	"github.com/dagu-dev/dagu/internal/persistence"
+	"github.com/dagu-dev/dagu/internal/prune"
+	"github.com/dagu-dev/dagu/internal/recovery"
 	"github.com/dagu-dev/dagu/service/scheduler/entry_reader"
+	"github.com/dagu-dev/dagu/service/scheduler/leader"
 	"github.com/dagu-dev/dagu/service/scheduler/scheduler"
 	"go.uber.org/fx"
 )
@@ -22,6 +33,9 @@ type Params struct {
 	Config      *config.Config
 	Logger      dagulogger.Logger
 	EntryReader scheduler.EntryReader
+	DataStore   persistence.DataStoreFactory
+	// EngineFactory starts the runs of the queued triggers.
+	EngineFactory engine.Factory
 }
 
 func EntryReaderProvider(
@@ -48,11 +62,80 @@ func JobFactoryProvider(cfg *config.Config, engineFactory engine.Factory) entry_
 }
 
 func New(params Params) *scheduler.Scheduler {
+	var elector scheduler.Elector
+	if params.Config.IsSchedulerHA {
+		// The schedulers sharing a PostgreSQL history share its lease too.
+		var postgresURL string
+		if b := params.Config.HistoryBackend; b != nil && b.Type == "postgres" {
+			postgresURL = b.URL
+		}
+		elector = leader.New(leader.Params{
+			LeaseFile:   params.Config.SchedulerLeaseFile,
+			PostgresURL: postgresURL,
+			TTL:         time.Second * time.Duration(params.Config.SchedulerLeaseTTLSec),
+			Logger:      params.Logger,
+		})
+	}
+	var collector scheduler.Collector
+	if params.Config.GCIntervalSec > 0 {
+		collector = gc.New(&gc.Config{
+			DataStore:  params.DataStore,
+			DataDir:    params.Config.DataDir,
+			MinAge:     time.Second * time.Duration(params.Config.GCMinAgeSec),
+			ReportFile: gc.ReportFile(params.Config.DataDir),
+			Interval:   time.Second * time.Duration(params.Config.GCIntervalSec),
+			Logger:     params.Logger,
+		})
+	}
+	var pruner scheduler.Collector
+	if params.Config.PruneIntervalSec > 0 {
+		pruner = prune.New(&prune.Config{
+			DataStore: params.DataStore,
+			Interval:  time.Second * time.Duration(params.Config.PruneIntervalSec),
+			Workers:   params.Config.PruneWorkers,
+			Logger:    params.Logger,
+		})
+	}
+	var archiver scheduler.Collector
+	if b := params.Config.ArchiveBackend; b != nil && b.Type != "" && b.IntervalSec > 0 {
+		archiver = archive.New(&archive.Config{
+			DataStore: params.DataStore,
+			AfterDays: b.AfterDays,
+			Interval:  time.Second * time.Duration(b.IntervalSec),
+			Logger:    params.Logger,
+		})
+	}
+	var recoverer scheduler.Collector
+	if params.Config.RecoverLostRuns {
+		recoverer = recovery.New(&recovery.Config{
+			DataStore:      params.DataStore,
+			FailureHandler: params.Config.LostRunFailureHandler,
+			LockFile:       recovery.LockFile(params.Config.DataDir),
+			Logger:         params.Logger,
+		})
+	}
+	var dispatcher scheduler.Collector
+	if q := params.DataStore.NewTriggerQueue(); q != nil {
+		dispatcher = dispatch.New(&dispatch.Config{
+			Queue:         q,
+			EngineFactory: params.EngineFactory,
+			Interval:      time.Second * time.Duration(max(params.Config.TriggerQueue.IntervalSec, 1)),
+			Logger:        params.Logger,
+		})
+	}
 	return scheduler.New(scheduler.Params{
 		EntryReader: params.EntryReader,
 		Logger:      params.Logger,
 		// TODO: check this is used
-		LogDir: params.Config.LogDir,
+		LogDir:     params.Config.LogDir,
+		Elector:    elector,
+		Collector:  collector,
+		Pruner:     pruner,
+		Archiver:   archiver,
+		Recoverer:  recoverer,
+		Dispatcher: dispatcher,
+		Heartbeat:  &health.Heartbeat{File: health.HeartbeatFile(params.Config.DataDir)},
+		ClockFile:  clock.ControlFile(params.Config.DataDir),
 	})
 }
 
@@ -13,6 +13,7 @@ import (
 // TODO: write tests
 type Job struct {
 	DAG           *dag.DAG
+	Schedule      *dag.Schedule
 	Executable    string
 	WorkDir       string
 	Next          time.Time
@@ -50,7 +51,9 @@ func (j *Job) Start() error {
 		}
 	}
 	// should not be here
-	return e.Start(j.DAG, "")
+	opts := j.runOptions()
+	opts.LogicalDate = j.Next
+	return e.StartWithOptions(j.DAG, opts)
 }
 
 func (j *Job) Stop() error {
@@ -62,12 +65,24 @@ func (j *Job) Stop() error {
 	if s.Status != scheduler.StatusRunning {
 		return ErrJobIsNotRunning
 	}
-	return e.Stop(j.DAG)
+	return e.StopWithOptions(j.DAG, j.runOptions())
 }
 
 func (j *Job) Restart() error {
 	e := j.EngineFactory.Create()
-	return e.Restart(j.DAG)
+	return e.RestartWithOptions(j.DAG, j.runOptions())
+}
+
+// runOptions returns the options of the schedule entry of the job.
+func (j *Job) runOptions() engine.RunOptions {
+	if j.Schedule == nil {
+		return engine.RunOptions{}
+	}
+	return engine.RunOptions{
+		Params:   j.Schedule.Params,
+		Steps:    j.Schedule.Steps,
+		Schedule: j.Schedule.Expression,
+	}
 }
 
 func (j *Job) String() string {
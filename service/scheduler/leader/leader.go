@@ -0,0 +1,223 @@
+package leader
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/logger"
+	"github.com/dagu-dev/dagu/internal/logger/tag"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"golang.org/x/sys/unix"
+)
+
+// Elector elects a single leader among scheduler instances sharing the same
+// lease. The leader renews the lease periodically and a standby takes over
+// once the lease has expired.
+type Elector struct {
+	lease    leaseStore
+	id       string
+	ttl      time.Duration
+	isLeader atomic.Bool
+	logger   logger.Logger
+}
+
+type Params struct {
+	// LeaseFile is the path of the file that holds the lease. It must be on
+	// storage shared by all scheduler instances.
+	LeaseFile string
+	// PostgresURL is the URL of the PostgreSQL database that holds the
+	// lease instead of LeaseFile, so that the instances do not need shared
+	// storage.
+	PostgresURL string
+	// ID identifies this instance. Defaults to hostname and pid.
+	ID string
+	// TTL is how long a lease stays valid without being renewed.
+	TTL    time.Duration
+	Logger logger.Logger
+}
+
+// leaseStore keeps the lease shared by the instances.
+type leaseStore interface {
+	// acquire takes the lease for the instance, or renews it. It returns
+	// false if another instance holds a lease that has not expired.
+	acquire(id string, ttl time.Duration) (bool, error)
+	// release gives up the lease if the instance holds it.
+	release(id string) error
+	// String describes the lease in the logs.
+	String() string
+}
+
+type lease struct {
+	Holder    string
+	ExpiresAt time.Time
+}
+
+const defaultTTL = time.Second * 15
+
+func New(params Params) *Elector {
+	id := params.ID
+	if id == "" {
+		host, _ := os.Hostname()
+		id = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+	ttl := params.TTL
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+	var ls leaseStore = &fileLease{file: params.LeaseFile}
+	if params.PostgresURL != "" {
+		ls = newPostgresLease(params.PostgresURL)
+	}
+	return &Elector{
+		lease:  ls,
+		id:     id,
+		ttl:    ttl,
+		logger: params.Logger,
+	}
+}
+
+// Start tries to acquire the lease right away and keeps renewing or
+// acquiring it in the background until done is closed.
+func (e *Elector) Start(done chan any) {
+	e.tick()
+	go func() {
+		ticker := time.NewTicker(e.ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				e.tick()
+			case <-done:
+				if err := e.release(); err != nil {
+					e.logger.Error("failed to release leader lease", tag.Error(err))
+				}
+				return
+			}
+		}
+	}()
+}
+
+// IsLeader returns true if this instance currently holds the lease.
+func (e *Elector) IsLeader() bool {
+	return e.isLeader.Load()
+}
+
+// ID returns the identifier of this instance.
+func (e *Elector) ID() string {
+	return e.id
+}
+
+func (e *Elector) tick() {
+	acquired, err := e.lease.acquire(e.id, e.ttl)
+	if err != nil {
+		e.logger.Error("failed to acquire leader lease", "lease", e.lease.String(), tag.Error(err))
+	}
+	acquired = acquired && err == nil
+	if was := e.isLeader.Swap(acquired); was != acquired {
+		if acquired {
+			e.logger.Info("became scheduler leader", "id", e.id)
+		} else {
+			e.logger.Warn("lost scheduler leadership", "id", e.id)
+		}
+	}
+}
+
+func (e *Elector) release() error {
+	if !e.isLeader.Swap(false) {
+		return nil
+	}
+	return e.lease.release(e.id)
+}
+
+// fileLease is the lease in a file locked while it is read and updated.
+type fileLease struct {
+	file string
+}
+
+func (l *fileLease) String() string {
+	return l.file
+}
+
+func (l *fileLease) acquire(id string, ttl time.Duration) (acquired bool, err error) {
+	err = l.withLock(func(f *os.File, current *lease) error {
+		now := utils.Now()
+		if current != nil && current.Holder != id && now.Before(current.ExpiresAt) {
+			return nil
+		}
+		if err := writeLease(f, &lease{Holder: id, ExpiresAt: now.Add(ttl)}); err != nil {
+			return err
+		}
+		acquired = true
+		return nil
+	})
+	return acquired && err == nil, err
+}
+
+func (l *fileLease) release(id string) error {
+	return l.withLock(func(f *os.File, current *lease) error {
+		if current == nil || current.Holder != id {
+			return nil
+		}
+		return f.Truncate(0)
+	})
+}
+
+// withLock opens the lease file and holds an exclusive lock on it while
+// fn is running, so that reading and updating the lease is atomic.
+func (l *fileLease) withLock(fn func(f *os.File, current *lease) error) error {
+	if err := os.MkdirAll(filepath.Dir(l.file), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.file, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	if err := unix.Flock(int(f.Fd()), unix.LOCK_EX); err != nil {
+		return err
+	}
+	defer func() {
+		_ = unix.Flock(int(f.Fd()), unix.LOCK_UN)
+	}()
+	current, err := readLease(f)
+	if err != nil {
+		return err
+	}
+	return fn(f, current)
+}
+func readLease(f *os.File) (*lease, error) {
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	l := &lease{}
+	if err := json.Unmarshal(data, l); err != nil {
+		// A corrupted lease file is treated as no lease at all.
+		return nil, nil
+	}
+	return l, nil
+}
+
+func writeLease(f *os.File, l *lease) error {
+	data, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := f.WriteAt(data, 0); err != nil {
+		return err
+	}
+	return f.Sync()
+}
@@ -0,0 +1,61 @@
+package leader
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/dagu-dev/dagu/internal/logger"
+	"github.com/dagu-dev/dagu/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElector(t *testing.T) {
+	tmpDir := utils.MustTempDir("leader_test")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	utils.SetFixedTime(now)
+	defer utils.SetFixedTime(time.Time{})
+
+	file := path.Join(tmpDir, "scheduler.lease")
+	newElector := func(id string) *Elector {
+		return New(Params{
+			LeaseFile: file,
+			ID:        id,
+			TTL:       time.Second * 15,
+			Logger:    logger.NewSlogLogger(),
+		})
+	}
+	e1 := newElector("a")
+	e2 := newElector("b")
+
+	e1.tick()
+	e2.tick()
+	require.True(t, e1.IsLeader())
+	require.False(t, e2.IsLeader())
+
+	// The leader keeps the lease by renewing it.
+	utils.SetFixedTime(now.Add(time.Second * 10))
+	e1.tick()
+	utils.SetFixedTime(now.Add(time.Second * 20))
+	e2.tick()
+	require.True(t, e1.IsLeader())
+	require.False(t, e2.IsLeader())
+
+	// The standby takes over once the lease expires.
+	utils.SetFixedTime(now.Add(time.Minute))
+	e2.tick()
+	require.True(t, e2.IsLeader())
+	e1.tick()
+	require.False(t, e1.IsLeader())
+
+	// Releasing the lease lets the other instance take over immediately.
+	require.NoError(t, e2.release())
+	require.False(t, e2.IsLeader())
+	e1.tick()
+	require.True(t, e1.IsLeader())
+}
@@ -0,0 +1,91 @@
+package leader
+
+import (
+	"database/sql"
+	"net/url"
+	"sync"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// postgresLease is the lease in a row of the scheduler_lease table. The
+// expiry is compared with the time of the database, so that the clocks of
+// the instances do not need to agree.
+type postgresLease struct {
+	dsn  string
+	once sync.Once
+	db   *sql.DB
+	err  error
+}
+
+const leaseSchema = `
+CREATE TABLE IF NOT EXISTS scheduler_lease (
+	name       TEXT PRIMARY KEY,
+	holder     TEXT NOT NULL,
+	expires_at TIMESTAMPTZ NOT NULL
+)`
+
+// leaseName is the row of the lease of the schedulers.
+const leaseName = "scheduler"
+
+func newPostgresLease(dsn string) *postgresLease {
+	return &postgresLease{dsn: dsn}
+}
+
+func (l *postgresLease) String() string {
+	u, err := url.Parse(l.dsn)
+	if err != nil || u.User == nil {
+		return l.dsn
+	}
+	return u.Redacted()
+}
+
+func (l *postgresLease) open() (*sql.DB, error) {
+	l.once.Do(func() {
+		db, err := sql.Open("postgres", l.dsn)
+		if err != nil {
+			l.err = err
+			return
+		}
+		if _, err := db.Exec(leaseSchema); err != nil {
+			_ = db.Close()
+			l.err = err
+			return
+		}
+		l.db = db
+	})
+	return l.db, l.err
+}
+
+func (l *postgresLease) acquire(id string, ttl time.Duration) (bool, error) {
+	db, err := l.open()
+	if err != nil {
+		return false, err
+	}
+	// The row is only updated if the instance holds the lease or the lease
+	// expired, and no row is returned otherwise.
+	row := db.QueryRow(`INSERT INTO scheduler_lease (name, holder, expires_at)
+VALUES ($1, $2, now() + $3::double precision * interval '1 millisecond')
+ON CONFLICT (name) DO UPDATE SET holder = excluded.holder, expires_at = excluded.expires_at
+WHERE scheduler_lease.holder = excluded.holder OR scheduler_lease.expires_at < now()
+RETURNING holder`, leaseName, id, ttl.Milliseconds())
+	var holder string
+	switch err := row.Scan(&holder); err {
+	case nil:
+		return true, nil
+	case sql.ErrNoRows:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (l *postgresLease) release(id string) error {
+	db, err := l.open()
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`DELETE FROM scheduler_lease WHERE name = $1 AND holder = $2`, leaseName, id)
+	return err
+}
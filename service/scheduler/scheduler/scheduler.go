@@ -10,8 +10,10 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/dagu-dev/dagu/internal/clock"
 	"github.com/dagu-dev/dagu/internal/dag"
 	"github.com/dagu-dev/dagu/internal/logger"
+	"github.com/dagu-dev/dagu/internal/secret"
 	"github.com/dagu-dev/dagu/internal/utils"
 )
 
@@ -21,11 +23,51 @@ type Scheduler struct {
 	stop        chan struct{}
 	running     atomic.Bool
 	logger      logger.Logger
+	elector     Elector
+	collector   Collector
+	pruner      Collector
+	archiver    Collector
+	recoverer   Collector
+	dispatcher  Collector
+	heartbeat   Heartbeat
+	clock       clock.Clock
+	clockFile   string
 }
 
+const (
+	// beatInterval is how often the loop records the heartbeat in real
+	// time, whichever the clock of the schedules is.
+	beatInterval = time.Second * 30
+	// clockInterval is how often the loop reads the control file of a
+	// frozen clock.
+	clockInterval = time.Second
+)
+
 type EntryReader interface {
 	Start(done chan any)
 	Read(now time.Time) ([]*Entry, error)
+	// Degraded returns true while the DAGs are read from the cache because
+	// the DAG directory is unavailable.
+	Degraded() bool
+}
+
+// Elector decides whether this scheduler instance is allowed to fire
+// schedules when several instances are running at the same time.
+type Elector interface {
+	Start(done chan any)
+	IsLeader() bool
+}
+
+// Heartbeat records the ticks of the loop of the scheduler, so that the
+// health checks tell whether it is stuck.
+type Heartbeat interface {
+	Beat(now time.Time, degraded bool) error
+	Stop() error
+}
+
+// Collector removes the files left behind by the agents in the background.
+type Collector interface {
+	Start(done chan any)
 }
 
 type Entry struct {
@@ -73,6 +115,30 @@ type Params struct {
 	EntryReader EntryReader
 	Logger      logger.Logger
 	LogDir      string
+	// Elector is optional. If set, only the leader fires schedules.
+	Elector Elector
+	// Collector is optional.
+	Collector Collector
+	// Pruner is optional. It removes the runs of the history that are
+	// older than the retention of the DAGs.
+	Pruner Collector
+	// Archiver is optional. It moves the log files of the old runs to the
+	// archive.
+	Archiver Collector
+	// Recoverer is optional. It marks the runs whose agents are gone as
+	// lost once the scheduler starts.
+	Recoverer Collector
+	// Dispatcher is optional. It starts the runs of the triggers the
+	// server queued, the ones queued while the scheduler was down too.
+	Dispatcher Collector
+	// Heartbeat is optional.
+	Heartbeat Heartbeat
+	// Clock is the time of the schedules, the clock of the process if it
+	// is nil.
+	Clock clock.Clock
+	// ClockFile is the control file of the clock, see clock.ControlFile.
+	// It is only read if the clock is frozen, a *clock.Fake.
+	ClockFile string
 }
 
 func New(params Params) *Scheduler {
@@ -81,6 +147,15 @@ func New(params Params) *Scheduler {
 		logDir:      params.LogDir,
 		stop:        make(chan struct{}),
 		logger:      params.Logger,
+		elector:     params.Elector,
+		collector:   params.Collector,
+		pruner:      params.Pruner,
+		archiver:    params.Archiver,
+		recoverer:   params.Recoverer,
+		dispatcher:  params.Dispatcher,
+		heartbeat:   params.Heartbeat,
+		clock:       clock.OrDefault(params.Clock),
+		clockFile:   params.ClockFile,
 	}
 }
 
@@ -94,15 +169,43 @@ func (s *Scheduler) Start() error {
 	defer close(done)
 
 	s.entryReader.Start(done)
+	if s.elector != nil {
+		s.elector.Start(done)
+	}
+	if s.collector != nil {
+		s.collector.Start(done)
+	}
+	if s.pruner != nil {
+		s.pruner.Start(done)
+	}
+	if s.archiver != nil {
+		s.archiver.Start(done)
+	}
+	if s.recoverer != nil {
+		s.recoverer.Start(done)
+	}
+	if s.dispatcher != nil {
+		s.dispatcher.Start(done)
+	}
 
-	signal.Notify(sig, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	// SIGHUP reads the secrets again instead of stopping the scheduler, so
+	// that the rotated credentials are used without interrupting the runs.
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
 
 	go func() {
-		select {
-		case <-done:
-			return
-		case <-sig:
-			s.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-hup:
+				s.logger.Info("refresh secrets")
+				secret.Refresh()
+			case <-sig:
+				s.Stop()
+				return
+			}
 		}
 	}()
 
@@ -123,23 +226,77 @@ func (s *Scheduler) setupLogFile() (err error) {
 }
 
 func (s *Scheduler) start() {
-	t := utils.Now().Truncate(time.Second * 60)
-	timer := time.NewTimer(0)
+	t := s.clock.Now().Truncate(time.Second * 60)
+	tick := s.clock.After(0)
+	beats := time.NewTicker(beatInterval)
+	defer beats.Stop()
+	var control <-chan time.Time
+	if fake, ok := s.clock.(*clock.Fake); ok && s.clockFile != "" {
+		utils.LogErr("failed to write the clock", clock.WriteControl(s.clockFile, fake.Now()))
+		ticker := time.NewTicker(clockInterval)
+		defer ticker.Stop()
+		control = ticker.C
+	}
 	s.running.Store(true)
 	for {
 		select {
-		case <-timer.C:
+		case <-tick:
+			s.beat()
 			s.run(t)
 			t = s.nextTick(t)
-			timer = time.NewTimer(t.Sub(utils.Now()))
+			tick = s.clock.After(t.Sub(s.clock.Now()))
+		case <-beats.C:
+			s.beat()
+		case <-control:
+			s.readClock()
 		case <-s.stop:
-			_ = timer.Stop()
+			if control != nil {
+				// The commands find no frozen clock once the scheduler stops.
+				utils.LogErr("failed to remove the clock", os.Remove(s.clockFile))
+			}
+			if s.heartbeat != nil {
+				utils.LogErr("failed to stop the heartbeat", s.heartbeat.Stop())
+			}
 			return
 		}
 	}
 }
 
+// beat records the tick, of the standby schedulers too since their loop
+// ticks as well. It records the real time, which the health checks compare
+// with theirs, so that a frozen clock does not make the loop stuck. The
+// readiness check reports the scheduler degraded with it.
+func (s *Scheduler) beat() {
+	if s.heartbeat != nil {
+		utils.LogErr("failed to record the heartbeat", s.heartbeat.Beat(time.Now(), s.entryReader.Degraded()))
+	}
+}
+
+// readClock moves the frozen clock forward to the time of the control file,
+// so that the schedules up to that time fire, and the runs started then
+// inherit it through DAGU_CLOCK. The times before the clock are ignored.
+func (s *Scheduler) readClock() {
+	fake := s.clock.(*clock.Fake)
+	t, err := clock.ReadControl(s.clockFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			s.logger.Error("failed to read the clock", "error", err)
+		}
+		return
+	}
+	if !t.After(fake.Now()) {
+		return
+	}
+	s.logger.Info("set the clock", "time", t.Format(time.RFC3339))
+	utils.LogErr("failed to set DAGU_CLOCK", os.Setenv("DAGU_CLOCK", t.Format(time.RFC3339)))
+	fake.Set(t)
+}
+
 func (s *Scheduler) run(now time.Time) {
+	if s.elector != nil && !s.elector.IsLeader() {
+		s.logger.Debug("skip schedules on standby scheduler", "time", now.Format("2006-01-02 15:04:05"))
+		return
+	}
 	entries, err := s.entryReader.Read(now.Add(-time.Second))
 	utils.LogErr("failed to read entries", err)
 	sort.SliceStable(entries, func(i, j int) bool {
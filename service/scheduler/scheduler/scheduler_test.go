@@ -3,10 +3,12 @@ package scheduler
 import (
 	"go.uber.org/goleak"
 	"os"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/dagu-dev/dagu/internal/clock"
 	"github.com/dagu-dev/dagu/internal/dag"
 	"github.com/dagu-dev/dagu/internal/logger"
 
@@ -37,7 +39,6 @@ func changeHomeDir(homeDir string) {
 
 func TestRun(t *testing.T) {
 	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
-	utils.SetFixedTime(now)
 
 	er := &mockEntryReader{
 		Entries: []*Entry{
@@ -58,6 +59,7 @@ func TestRun(t *testing.T) {
 		EntryReader: er,
 		LogDir:      testHomeDir,
 		Logger:      logger.NewSlogLogger(),
+		Clock:       clock.NewFake(now),
 	})
 
 	go func() {
@@ -73,7 +75,6 @@ func TestRun(t *testing.T) {
 
 func TestRestart(t *testing.T) {
 	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
-	utils.SetFixedTime(now)
 
 	er := &mockEntryReader{
 		Entries: []*Entry{
@@ -90,6 +91,7 @@ func TestRestart(t *testing.T) {
 		EntryReader: er,
 		LogDir:      testHomeDir,
 		Logger:      logger.NewSlogLogger(),
+		Clock:       clock.NewFake(now),
 	})
 
 	go func() {
@@ -101,9 +103,121 @@ func TestRestart(t *testing.T) {
 	require.Equal(t, int32(1), er.Entries[0].Job.(*mockJob).RestartCount.Load())
 }
 
+func TestStandby(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	er := &mockEntryReader{
+		Entries: []*Entry{
+			{
+				Job:    &mockJob{},
+				Next:   now,
+				Logger: logger.NewSlogLogger(),
+			},
+		},
+	}
+
+	r := New(Params{
+		EntryReader: er,
+		LogDir:      testHomeDir,
+		Logger:      logger.NewSlogLogger(),
+		Elector:     &mockElector{},
+		Clock:       clock.NewFake(now),
+	})
+
+	go func() {
+		_ = r.Start()
+	}()
+
+	time.Sleep(time.Second + time.Millisecond*100)
+	r.Stop()
+
+	require.Equal(t, int32(0), er.Entries[0].Job.(*mockJob).RunCount.Load())
+}
+
+func TestRunNextMinute(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 30, 0, time.UTC)
+	c := clock.NewFake(now)
+
+	er := &mockEntryReader{
+		Entries: []*Entry{
+			{
+				Job:    &mockJob{},
+				Next:   now.Add(30 * time.Second),
+				Logger: logger.NewSlogLogger(),
+			},
+		},
+	}
+	job := er.Entries[0].Job.(*mockJob)
+
+	r := New(Params{
+		EntryReader: er,
+		LogDir:      testHomeDir,
+		Logger:      logger.NewSlogLogger(),
+		Clock:       c,
+	})
+
+	go func() {
+		_ = r.Start()
+	}()
+	defer r.Stop()
+
+	// The loop waits for the next minute.
+	require.Eventually(t, func() bool { return c.Waiters() == 1 }, time.Second, time.Millisecond)
+	require.Equal(t, int32(0), job.RunCount.Load())
+
+	c.Advance(30 * time.Second)
+	require.Eventually(t, func() bool { return job.RunCount.Load() == 1 }, time.Second, time.Millisecond)
+}
+
+func TestFrozenClock(t *testing.T) {
+	t.Setenv("DAGU_CLOCK", "")
+	now := time.Date(2020, 1, 1, 0, 0, 30, 0, time.UTC)
+	c := clock.NewFake(now)
+	er := &mockEntryReader{
+		Entries: []*Entry{
+			{
+				Job:    &mockJob{},
+				Next:   now.Add(30 * time.Second),
+				Logger: logger.NewSlogLogger(),
+			},
+		},
+	}
+	job := er.Entries[0].Job.(*mockJob)
+	hb := &mockHeartbeat{}
+	file := clock.ControlFile(t.TempDir())
+
+	r := New(Params{
+		EntryReader: er,
+		LogDir:      testHomeDir,
+		Logger:      logger.NewSlogLogger(),
+		Heartbeat:   hb,
+		Clock:       c,
+		ClockFile:   file,
+	})
+	go func() {
+		_ = r.Start()
+	}()
+	defer r.Stop()
+
+	// The heartbeat is in real time, and the scheduler writes the time of
+	// its clock to the control file.
+	require.Eventually(t, func() bool { return c.Waiters() == 1 }, time.Second, time.Millisecond)
+	require.WithinDuration(t, time.Now(), hb.last(), time.Minute)
+	got, err := clock.ReadControl(file)
+	require.NoError(t, err)
+	require.True(t, now.Equal(got))
+
+	// The clock moves to the time written to the file, and the schedules
+	// up to it fire.
+	next := now.Add(30 * time.Second)
+	require.NoError(t, clock.WriteControl(file, next))
+	require.Eventually(t, func() bool { return job.RunCount.Load() == 1 }, time.Second*3, time.Millisecond*10)
+	require.True(t, next.Equal(c.Now()))
+	require.Equal(t, next.Format(time.RFC3339), os.Getenv("DAGU_CLOCK"))
+}
+
 func TestNextTick(t *testing.T) {
 	n := time.Date(2020, 1, 1, 1, 0, 50, 0, time.UTC)
-	utils.SetFixedTime(n)
 	r := New(Params{
 		EntryReader: &mockEntryReader{},
 		LogDir:      testHomeDir,
@@ -125,6 +239,20 @@ func (er *mockEntryReader) Read(_ time.Time) ([]*Entry, error) {
 
 func (er *mockEntryReader) Start(chan any) {}
 
+func (er *mockEntryReader) Degraded() bool { return false }
+
+type mockElector struct {
+	Leader bool
+}
+
+var _ Elector = (*mockElector)(nil)
+
+func (e *mockElector) Start(chan any) {}
+
+func (e *mockElector) IsLeader() bool {
+	return e.Leader
+}
+
 // TODO: fix to use mock library
 type mockJob struct {
 	Name         string
@@ -161,3 +289,23 @@ func (j *mockJob) Restart() error {
 	j.RestartCount.Add(1)
 	return nil
 }
+
+type mockHeartbeat struct {
+	mu   sync.Mutex
+	beat time.Time
+}
+
+func (h *mockHeartbeat) Beat(now time.Time, _ bool) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.beat = now
+	return nil
+}
+
+func (h *mockHeartbeat) Stop() error { return nil }
+
+func (h *mockHeartbeat) last() time.Time {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.beat
+}
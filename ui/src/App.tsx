@@ -14,6 +14,8 @@ export type Config = {
   title: string;
   navbarColor: string;
   version: string;
+  namespace: string;
+  namespaces: string[];
 };
 
 type Props = {
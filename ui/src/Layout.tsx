@@ -8,7 +8,7 @@ import Toolbar from '@mui/material/Toolbar';
 import List from '@mui/material/List';
 import Typography from '@mui/material/Typography';
 import { mainListItems } from './menu';
-import { Grid } from '@mui/material';
+import { Grid, MenuItem, Select } from '@mui/material';
 import { AppBarContext } from './contexts/AppBarContext';
 
 const drawerWidthClosed = 64;
@@ -71,10 +71,18 @@ type DashboardContentProps = {
   title: string;
   navbarColor: string;
   version: string;
+  namespace: string;
+  namespaces: string[];
   children?: React.ReactElement | React.ReactElement[];
 };
 
-function Content({ title, navbarColor, children }: DashboardContentProps) {
+function Content({
+  title,
+  navbarColor,
+  namespace,
+  namespaces,
+  children,
+}: DashboardContentProps) {
   const [scrolled, setScrolled] = React.useState(false);
   const containerRef = React.useRef<HTMLDivElement>(null);
   const gradientColor = navbarColor || '#485fc7';
@@ -137,7 +145,28 @@ function Content({ title, navbarColor, children }: DashboardContentProps) {
                   </NavBarTitleText>
                 )}
               </AppBarContext.Consumer>
-              <NavBarTitleText>{title || 'Dagu'}</NavBarTitleText>
+              <Box sx={{ display: 'flex', alignItems: 'center' }}>
+                {namespaces.length > 1 ? (
+                  <Select
+                    size="small"
+                    value={namespace}
+                    onChange={(e) => {
+                      // The API URL of the namespace is set on the load of
+                      // the page.
+                      localStorage.setItem('dagu-namespace', e.target.value);
+                      window.location.reload();
+                    }}
+                    sx={{ mr: 2, backgroundColor: 'white' }}
+                  >
+                    {namespaces.map((ns) => (
+                      <MenuItem key={ns} value={ns}>
+                        {ns}
+                      </MenuItem>
+                    ))}
+                  </Select>
+                ) : null}
+                <NavBarTitleText>{title || 'Dagu'}</NavBarTitleText>
+              </Box>
             </Toolbar>
           </AppBar>
           <Grid
@@ -1,18 +1,54 @@
 import React from 'react';
-import MonacoEditor from 'react-monaco-editor';
+import MonacoEditor, { EditorDidMount } from 'react-monaco-editor';
+import { DAGProblem } from '../../models/api';
 
 type Props = {
   value: string;
   onChange: (value: string) => void;
+  problems?: DAGProblem[];
 };
 
-function DAGEditor({ value, onChange }: Props) {
+type Editor = Parameters<EditorDidMount>[0];
+type Monaco = Parameters<EditorDidMount>[1];
+
+function DAGEditor({ value, onChange, problems }: Props) {
+  const ref = React.useRef<{ editor: Editor; monaco: Monaco }>();
+  const onMount: EditorDidMount = (editor, monaco) => {
+    ref.current = { editor, monaco };
+  };
+  React.useEffect(() => {
+    const model = ref.current?.editor.getModel();
+    if (!ref.current || !model) {
+      return;
+    }
+    const { monaco } = ref.current;
+    monaco.editor.setModelMarkers(
+      model,
+      'dagu',
+      (problems || []).map((p) => {
+        // The problems without a line are marked on the first one.
+        const line = Math.min(Math.max(p.Line, 1), model.getLineCount());
+        return {
+          severity:
+            p.Level == 'error'
+              ? monaco.MarkerSeverity.Error
+              : monaco.MarkerSeverity.Warning,
+          message: p.Field ? `${p.Field}: ${p.Message}` : p.Message,
+          startLineNumber: line,
+          startColumn: model.getLineFirstNonWhitespaceColumn(line) || 1,
+          endLineNumber: line,
+          endColumn: model.getLineMaxColumn(line),
+        };
+      })
+    );
+  }, [problems]);
   return (
     <MonacoEditor
       height="60vh"
       value={value}
       onChange={onChange}
       language="yaml"
+      editorDidMount={onMount}
     />
   );
 }
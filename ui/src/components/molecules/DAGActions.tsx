@@ -51,7 +51,9 @@ function DAGActions({
       requestId?: string;
       params?: string;
     }) => {
-      const url = `${getConfig().apiURL}/dags/${params.name}`;
+      const url = `${getConfig().apiURL}/dags/${encodeURIComponent(
+        params.name
+      )}`;
       const ret = await fetch(url, {
         method: 'POST',
         headers: {
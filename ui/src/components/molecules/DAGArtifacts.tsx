@@ -0,0 +1,186 @@
+import {
+  Box,
+  Button,
+  Link,
+  Stack,
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableRow,
+} from '@mui/material';
+import React from 'react';
+import useSWR from 'swr';
+import { Artifact, CSVPreview, ListArtifactsResponse } from '../../models/api';
+import BorderedBox from '../atoms/BorderedBox';
+import SubTitle from '../atoms/SubTitle';
+
+type Props = {
+  name: string;
+  requestId: string;
+};
+
+// DAGArtifacts lists the artifacts of the run with their downloads, and
+// shows the preview of the selected one by its type. Nothing is shown if the
+// run has no artifacts.
+function DAGArtifacts({ name, requestId }: Props) {
+  const base = `/dags/${encodeURIComponent(name)}/runs/${encodeURIComponent(
+    requestId
+  )}/artifacts`;
+  const { data } = useSWR<ListArtifactsResponse>(base, null, {
+    refreshInterval: 5000,
+    shouldRetryOnError: false,
+  });
+  const [selected, setSelected] = React.useState<Artifact | undefined>(
+    undefined
+  );
+  if (!data?.Artifacts?.length) {
+    return null;
+  }
+  const url = (action: string, a: Artifact) =>
+    `${base}/${action}?path=${encodeURIComponent(a.Path)}`;
+  return (
+    <Box sx={{ mt: 3 }}>
+      <SubTitle>Artifacts</SubTitle>
+      <BorderedBox sx={{ mt: 2 }}>
+        <Table size="small">
+          <TableHead>
+            <TableRow>
+              <TableCell>Path</TableCell>
+              <TableCell>Size</TableCell>
+              <TableCell>Modified</TableCell>
+              <TableCell></TableCell>
+            </TableRow>
+          </TableHead>
+          <TableBody>
+            {data.Artifacts.map((a) => (
+              <TableRow key={a.Path} selected={selected?.Path == a.Path}>
+                <TableCell>{a.Path}</TableCell>
+                <TableCell>{a.Size}</TableCell>
+                <TableCell>{a.ModTime}</TableCell>
+                <TableCell align="right">
+                  <Stack direction="row" justifyContent="flex-end">
+                    {a.Type && a.Type != 'file' ? (
+                      <Button
+                        size="small"
+                        onClick={() =>
+                          setSelected(selected?.Path == a.Path ? undefined : a)
+                        }
+                      >
+                        Preview
+                      </Button>
+                    ) : null}
+                    <Button
+                      size="small"
+                      href={`${getConfig().apiURL}${url('download', a)}`}
+                    >
+                      Download
+                    </Button>
+                  </Stack>
+                </TableCell>
+              </TableRow>
+            ))}
+          </TableBody>
+        </Table>
+      </BorderedBox>
+      {selected ? (
+        <BorderedBox sx={{ mt: 2, p: 2 }}>
+          <ArtifactPreview artifact={selected} url={url('preview', selected)} />
+        </BorderedBox>
+      ) : null}
+    </Box>
+  );
+}
+
+type PreviewProps = {
+  artifact: Artifact;
+  url: string;
+};
+
+// ArtifactPreview shows the preview of the artifact. The HTML reports are
+// shown in a sandboxed frame, since the server only sanitizes them.
+function ArtifactPreview({ artifact, url }: PreviewProps) {
+  const src = `${getConfig().apiURL}${url}`;
+  switch (artifact.Type) {
+    case 'image':
+      return (
+        <Box
+          component="img"
+          src={src}
+          alt={artifact.Path}
+          sx={{ maxWidth: '100%' }}
+        />
+      );
+    case 'html':
+      return (
+        <Box
+          component="iframe"
+          src={src}
+          title={artifact.Path}
+          sandbox=""
+          sx={{ width: '100%', height: '600px', border: 'none' }}
+        />
+      );
+    case 'json':
+      return <JSONPreview url={url} />;
+    case 'csv':
+      return <CSVTable url={url} />;
+    default:
+      return null;
+  }
+}
+
+function JSONPreview({ url }: { url: string }) {
+  const { data, error } = useSWR<unknown>(url, null, {
+    shouldRetryOnError: false,
+  });
+  if (error) {
+    return <Link href={`${getConfig().apiURL}${url}`}>Open the document</Link>;
+  }
+  if (data === undefined) {
+    return null;
+  }
+  return (
+    <pre>
+      <code>{JSON.stringify(data, null, 2)}</code>
+    </pre>
+  );
+}
+
+function CSVTable({ url }: { url: string }) {
+  const { data } = useSWR<CSVPreview>(url, null, {
+    shouldRetryOnError: false,
+  });
+  if (!data) {
+    return null;
+  }
+  return (
+    <Box sx={{ overflowX: 'auto' }}>
+      <Table size="small">
+        <TableHead>
+          <TableRow>
+            {(data.Header || []).map((h, i) => (
+              <TableCell key={i}>{h}</TableCell>
+            ))}
+          </TableRow>
+        </TableHead>
+        <TableBody>
+          {data.Rows.map((row, i) => (
+            <TableRow key={i}>
+              {row.map((v, j) => (
+                <TableCell key={j}>{v}</TableCell>
+              ))}
+            </TableRow>
+          ))}
+        </TableBody>
+      </Table>
+      {data.Truncated ? (
+        <Box sx={{ mt: 1, color: 'grey.600' }}>
+          The preview shows the first rows of the artifact only.
+        </Box>
+      ) : null}
+    </Box>
+  );
+}
+
+export default DAGArtifacts;
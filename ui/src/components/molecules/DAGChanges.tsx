@@ -0,0 +1,92 @@
+import { Box, Button, Stack } from '@mui/material';
+import React from 'react';
+import useSWR from 'swr';
+import { ListChangesResponse } from '../../models/api';
+import BorderedBox from '../atoms/BorderedBox';
+import SubTitle from '../atoms/SubTitle';
+
+type Props = {
+  name: string;
+  onReviewed: () => void;
+};
+
+// DAGChanges shows the pending changes of the DAG of a namespace under
+// change control, which another user approves or rejects. Nothing is shown
+// otherwise.
+function DAGChanges({ name, onReviewed }: Props) {
+  const { data, mutate } = useSWR<ListChangesResponse>(
+    `/changes?dag=${encodeURIComponent(name)}&status=pending`,
+    null,
+    { refreshInterval: 5000, shouldRetryOnError: false }
+  );
+  const review = React.useCallback(
+    async (id: string, action: 'approve' | 'reject') => {
+      let reason: string | null = '';
+      if (action == 'reject') {
+        reason = prompt('Reason of the rejection');
+        if (reason == null) {
+          return;
+        }
+      }
+      const resp = await fetch(
+        `${getConfig().apiURL}/changes/${encodeURIComponent(id)}`,
+        {
+          method: 'POST',
+          headers: {
+            'Content-Type': 'application/json',
+          },
+          body: JSON.stringify({ Action: action, Reason: reason }),
+        }
+      );
+      if (!resp.ok) {
+        alert(await resp.text());
+      }
+      mutate();
+      onReviewed();
+    },
+    [mutate, onReviewed]
+  );
+  if (!data?.Changes?.length) {
+    return null;
+  }
+  return (
+    <Box sx={{ mt: 3 }}>
+      <SubTitle>Pending Changes</SubTitle>
+      {data.Changes.map((c) => (
+        <BorderedBox key={c.Id} sx={{ mt: 2, px: 2, py: 1 }}>
+          <Stack
+            direction="row"
+            justifyContent="space-between"
+            alignItems="center"
+          >
+            <Box sx={{ color: 'grey.600' }}>
+              {c.Author} at {c.CreatedAt}
+            </Box>
+            <Stack direction="row">
+              <Button
+                color="primary"
+                variant="outlined"
+                onClick={() => review(c.Id, 'approve')}
+              >
+                Approve
+              </Button>
+              <Button
+                color="error"
+                variant="outlined"
+                sx={{ ml: 2 }}
+                onClick={() => review(c.Id, 'reject')}
+              >
+                Reject
+              </Button>
+            </Stack>
+          </Stack>
+          <pre>
+            <code className="language-diff">{c.Diff}</code>
+          </pre>
+        </BorderedBox>
+      ))}
+    </Box>
+  );
+}
+
+export default DAGChanges;
@@ -18,7 +18,7 @@ function DAGEditButtons({ name }: Props) {
             alert('DAG name cannot contain space');
             return;
           }
-          const url = `${getConfig().apiURL}/dags/${name}`;
+          const url = `${getConfig().apiURL}/dags/${encodeURIComponent(name)}`;
           const resp = await fetch(url, {
             method: 'POST',
             headers: {
@@ -30,7 +30,7 @@ function DAGEditButtons({ name }: Props) {
             }),
           });
           if (resp.ok) {
-            window.location.href = `/dags/${val}`;
+            window.location.href = `/dags/${encodeURIComponent(val)}`;
           } else {
             const e = await resp.text();
             alert(e);
@@ -44,7 +44,7 @@ function DAGEditButtons({ name }: Props) {
           if (!confirm('Are you sure to delete the DAG?')) {
             return;
           }
-          const url = `${getConfig().apiURL}/dags/${name}`;
+          const url = `${getConfig().apiURL}/dags/${encodeURIComponent(name)}`;
           const resp = await fetch(url, {
             method: 'DELETE',
             headers: {
@@ -0,0 +1,28 @@
+import React from 'react';
+import { DAGWarning } from '../../models';
+
+type Props = {
+  warnings?: DAGWarning[];
+};
+
+function DAGSpecWarnings({ warnings }: Props) {
+  if (!warnings || warnings.length == 0) {
+    return null;
+  }
+  return (
+    <div className="notification is-warning mt-0 mb-0">
+      <div>The definition has the below warnings.</div>
+      <div className="content">
+        <ul>
+          {warnings.map((w, i) => (
+            <li key={`${i}`}>
+              <code>{w.Field}</code>: {w.Message}
+            </li>
+          ))}
+        </ul>
+      </div>
+    </div>
+  );
+}
+
+export default DAGSpecWarnings;
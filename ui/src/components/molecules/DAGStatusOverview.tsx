@@ -1,7 +1,7 @@
 import React from 'react';
 import { Status } from '../../models';
 import StatusChip from '../atoms/StatusChip';
-import { Stack } from '@mui/material';
+import { Link as MuiLink, Stack } from '@mui/material';
 import LabeledItem from '../atoms/LabeledItem';
 import { Link } from 'react-router-dom';
 
@@ -9,17 +9,32 @@ type Props = {
   status?: Status;
   name: string;
   file?: string;
+  onSelectRun?: (requestId: string) => void;
 };
 
-function DAGStatusOverview({ status, name, file = '' }: Props) {
-  const url = `/dags/${name}/scheduler-log?&file=${encodeURI(file)}`;
+function DAGStatusOverview({ status, name, file = '', onSelectRun }: Props) {
+  const url = `/dags/${encodeURIComponent(
+    name
+  )}/scheduler-log?&file=${encodeURI(file)}`;
   if (!status) {
     return null;
   }
+  const runLink = (requestId: string) =>
+    onSelectRun ? (
+      <MuiLink component="button" onClick={() => onSelectRun(requestId)}>
+        {requestId}
+      </MuiLink>
+    ) : (
+      requestId
+    );
   return (
     <Stack direction="column" spacing={1}>
       <LabeledItem label="Status">
-        <StatusChip status={status.Status}>{status.StatusText}</StatusChip>
+        <StatusChip status={status.Status}>
+          {status.QueuePosition
+            ? `queued (#${status.QueuePosition})`
+            : status.StatusText}
+        </StatusChip>
       </LabeledItem>
       <LabeledItem label="Request ID">{status.RequestId}</LabeledItem>
       <Stack direction="row" sx={{ alignItems: 'center' }} spacing={2}>
@@ -27,9 +42,42 @@ function DAGStatusOverview({ status, name, file = '' }: Props) {
         <LabeledItem label="Finished At">{status.FinishedAt}</LabeledItem>
       </Stack>
       <LabeledItem label="Params">{status.Params}</LabeledItem>
+      {status.InitiatedBy || status.InitiatorToken ? (
+        <LabeledItem label="Started By">
+          {status.InitiatorToken
+            ? `${status.InitiatedBy || '-'} (token: ${status.InitiatorToken})`
+            : status.InitiatedBy}
+        </LabeledItem>
+      ) : null}
+      {status.Schedule ? (
+        <LabeledItem label="Schedule">{status.Schedule}</LabeledItem>
+      ) : null}
+      {status.StoppedBy ? (
+        <LabeledItem label="Stopped By">{status.StoppedBy}</LabeledItem>
+      ) : null}
+      {status.RestartedFrom ? (
+        <LabeledItem label="Restarted From">
+          {runLink(status.RestartedFrom)}
+        </LabeledItem>
+      ) : null}
+      {status.RestartedAs ? (
+        <LabeledItem label="Restarted As">
+          {runLink(status.RestartedAs)}
+        </LabeledItem>
+      ) : null}
+      {status.Lane ? (
+        <LabeledItem label="Lane">
+          {status.Overtook?.length
+            ? `${status.Lane} (ahead of ${status.Overtook.join(', ')})`
+            : status.Lane}
+        </LabeledItem>
+      ) : null}
       <LabeledItem label="Scheduler Log">
         <Link to={url}>{status.Log}</Link>
       </LabeledItem>
+      {status.ArchiveLocation ? (
+        <LabeledItem label="Archived To">{status.ArchiveLocation}</LabeledItem>
+      ) : null}
     </Stack>
   );
 }
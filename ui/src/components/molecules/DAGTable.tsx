@@ -44,6 +44,7 @@ import {
   KeyboardArrowUp,
 } from '@mui/icons-material';
 import LiveSwitch from './LiveSwitch';
+import { dagId } from '../../lib/dagId';
 import moment from 'moment';
 import 'moment-duration-format';
 import Ticker from '../atoms/Ticker';
@@ -113,8 +114,7 @@ const defaultColumns = [
       if (data.Type == DAGDataType.Group) {
         return getValue();
       } else {
-        const name = data.DAGStatus.File.replace(/.y[a]{0,1}ml$/, '');
-        const url = `/dags/${encodeURI(name)}`;
+        const url = `/dags/${encodeURIComponent(dagId(data.DAGStatus))}`;
         return (
           <div
             style={{
@@ -373,7 +373,7 @@ const defaultColumns = [
         <DAGActions
           dag={data.DAGStatus.DAG}
           status={data.DAGStatus.Status}
-          name={data.DAGStatus.DAG.Name}
+          name={dagId(data.DAGStatus)}
           label={false}
           refresh={props.table.options.meta?.refreshFn}
         />
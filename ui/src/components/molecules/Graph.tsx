@@ -2,6 +2,7 @@ import React from 'react';
 import { Node, NodeStatus } from '../../models';
 import { Step } from '../../models';
 import Mermaid from '../atoms/Mermaid';
+import { Button, Stack } from '@mui/material';
 
 type onClickNode = (name: string) => void;
 
@@ -37,6 +38,25 @@ function Graph({
     borderRadius: '0.5em',
     backgroundSize: '20px 20px',
   };
+  const groups = React.useMemo(() => groupPaths(steps, type), [steps, type]);
+  // The groups are collapsed at first in the graphs of the large DAGs.
+  const [collapsed, setCollapsed] = React.useState<Set<string>>(
+    () =>
+      new Set(
+        steps && steps.length > collapseThreshold
+          ? groups.filter((g) => !g.includes('/'))
+          : []
+      )
+  );
+  const toggle = (group: string) => {
+    const next = new Set(collapsed);
+    if (next.has(group)) {
+      next.delete(group);
+    } else {
+      next.add(group);
+    }
+    setCollapsed(next);
+  };
   const graph = React.useMemo(() => {
     if (!steps) {
       return '';
@@ -45,25 +65,52 @@ function Graph({
     if (onClickNode) {
       window.onClickMermaidNode = onClickNode;
     }
-    const addNodeFn = (step: Step, status: NodeStatus) => {
+    // The steps of a group are drawn in nested subgraphs of the segments of
+    // its path. A collapsed group is drawn as a single node with the status
+    // of its steps, and the links to its steps go to the node.
+    const tree: GroupTree = { nodes: [], groups: {} };
+    const folded: { [group: string]: NodeStatus[] } = {};
+    const ids: { [name: string]: string } = {};
+    const links: string[] = [];
+    const items: [Step, NodeStatus][] =
+      type == 'status'
+        ? (steps as Node[]).map((s) => [s.Step, s.Status])
+        : (steps as Step[]).map((s) => [s, NodeStatus.None]);
+    items.forEach(([step, status]) => {
+      const path = stepGroup(step);
+      const fold = collapsedAncestor(path, collapsed);
+      if (fold) {
+        ids[step.Name] = groupId(fold);
+        (folded[fold] = folded[fold] || []).push(status);
+        return;
+      }
       const id = step.Name.replace(/\s/g, '_');
+      ids[step.Name] = id;
       const c = graphStatusMap[status] || '';
-      dat.push(`${id}[${step.Name}]${c};`);
-      if (step.Depends) {
-        step.Depends.forEach((d) => {
-          const depId = d.replace(/\s/g, '_');
-          dat.push(`${depId} --> ${id};`);
-        });
-      }
+      subtree(tree, path).nodes.push(`${id}[${step.Name}]${c};`);
       if (onClickNode) {
-        dat.push(`click ${id} onClickMermaidNode`);
+        links.push(`click ${id} onClickMermaidNode`);
       }
-    };
-    if (type == 'status') {
-      (steps as Node[]).forEach((s) => addNodeFn(s.Step, s.Status));
-    } else {
-      (steps as Step[]).forEach((s) => addNodeFn(s, NodeStatus.None));
-    }
+    });
+    Object.keys(folded).forEach((group) => {
+      const c = graphStatusMap[foldStatus(folded[group])] || '';
+      const name = group.split('/').pop();
+      subtree(tree, parentGroup(group)).nodes.push(
+        `${groupId(group)}[[${name} (${folded[group].length})]]${c};`
+      );
+    });
+    const edges = new Set<string>();
+    items.forEach(([step]) => {
+      (step.Depends || []).forEach((d) => {
+        const from = ids[d] || d.replace(/\s/g, '_');
+        const to = ids[step.Name];
+        if (from != to) {
+          edges.add(`${from} --> ${to};`);
+        }
+      });
+    });
+    dat.push(...drawTree(tree, ''));
+    dat.push(...edges, ...links);
     dat.push(
       'linkStyle default stroke:#999,stroke-width:1px,fill:none,color:#333'
     );
@@ -74,8 +121,26 @@ function Graph({
     dat.push('classDef done color:#333,fill:white,stroke:green,stroke-width:1.2px');
     dat.push('classDef skipped color:#333,fill:white,stroke:gray,stroke-width:1.2px');
     return dat.join('\n');
-  }, [steps, onClickNode, flowchart]);
-  return <Mermaid style={mermaidStyle} def={graph} />;
+  }, [steps, onClickNode, flowchart, collapsed]);
+  return (
+    <React.Fragment>
+      {groups.length > 0 ? (
+        <Stack direction="row" spacing={1} sx={{ flexWrap: 'wrap', px: 2 }}>
+          {groups.map((g) => (
+            <Button
+              key={g}
+              size="small"
+              variant={collapsed.has(g) ? 'outlined' : 'text'}
+              onClick={() => toggle(g)}
+            >
+              {collapsed.has(g) ? '+' : '-'} {g}
+            </Button>
+          ))}
+        </Stack>
+      ) : null}
+      <Mermaid style={mermaidStyle} def={graph} />
+    </React.Fragment>
+  );
 }
 
 export default Graph;
@@ -87,4 +152,97 @@ const graphStatusMap = {
   [NodeStatus.Cancel]: ':::cancel',
   [NodeStatus.Success]: ':::done',
   [NodeStatus.Skipped]: ':::skipped',
+  [NodeStatus.Timeout]: ':::error',
+};
+
+// collapseThreshold is the number of steps above which the groups are
+// collapsed when the graph is shown.
+const collapseThreshold = 20;
+
+type GroupTree = {
+  nodes: string[];
+  groups: { [name: string]: GroupTree };
 };
+
+function stepGroup(step: Step): string {
+  return step.Group || step.Stage || '';
+}
+
+function groupId(group: string): string {
+  return 'group_' + group.replace(/[\s/]/g, '_');
+}
+
+function parentGroup(group: string): string {
+  return group.split('/').slice(0, -1).join('/');
+}
+
+// groupPaths returns the paths of all the groups and their parents in the
+// order of their first steps.
+function groupPaths(steps: Step[] | Node[] | undefined, type: string): string[] {
+  const paths: string[] = [];
+  (steps || []).forEach((s) => {
+    const segments = stepGroup(type == 'status' ? (s as Node).Step : (s as Step))
+      .split('/')
+      .filter((seg) => seg != '');
+    segments.forEach((_, i) => {
+      const p = segments.slice(0, i + 1).join('/');
+      if (!paths.includes(p)) {
+        paths.push(p);
+      }
+    });
+  });
+  return paths;
+}
+
+// collapsedAncestor returns the outermost collapsed group of the path.
+function collapsedAncestor(path: string, collapsed: Set<string>): string {
+  const segments = path.split('/').filter((seg) => seg != '');
+  for (let i = 1; i <= segments.length; i++) {
+    const p = segments.slice(0, i).join('/');
+    if (collapsed.has(p)) {
+      return p;
+    }
+  }
+  return '';
+}
+
+function subtree(tree: GroupTree, path: string): GroupTree {
+  return path
+    .split('/')
+    .filter((seg) => seg != '')
+    .reduce((t, seg) => {
+      t.groups[seg] = t.groups[seg] || { nodes: [], groups: {} };
+      return t.groups[seg];
+    }, tree);
+}
+
+function drawTree(tree: GroupTree, path: string): string[] {
+  const dat = [...tree.nodes];
+  Object.keys(tree.groups).forEach((name) => {
+    const p = path ? `${path}/${name}` : name;
+    dat.push(`subgraph ${groupId(p)} [${name}]`);
+    dat.push(...drawTree(tree.groups[name], p));
+    dat.push('end');
+  });
+  return dat;
+}
+
+// foldStatus returns the status shown for the steps of a collapsed group.
+function foldStatus(statuses: NodeStatus[]): NodeStatus {
+  for (const s of [
+    NodeStatus.Error,
+    NodeStatus.Timeout,
+    NodeStatus.Running,
+    NodeStatus.Cancel,
+  ]) {
+    if (statuses.includes(s)) {
+      return s;
+    }
+  }
+  if (statuses.every((s) => s == NodeStatus.Success || s == NodeStatus.Skipped)) {
+    return statuses.every((s) => s == NodeStatus.Skipped)
+      ? NodeStatus.Skipped
+      : NodeStatus.Success;
+  }
+  return NodeStatus.None;
+}
@@ -1,6 +1,7 @@
 import { Switch } from '@mui/material';
 import React from 'react';
 import { WorkflowListItem } from '../../models/api';
+import { dagId } from '../../lib/dagId';
 
 type Props = {
   inputProps?: React.HTMLProps<HTMLInputElement>;
@@ -12,7 +13,9 @@ function LiveSwitch({ DAG, refresh, inputProps }: Props) {
   const [checked, setChecked] = React.useState(!DAG.Suspended);
   const onSubmit = React.useCallback(
     async (params: { name: string; action: string; value: string }) => {
-      const url = `${getConfig().apiURL}/dags/${params.name}`;
+      const url = `${getConfig().apiURL}/dags/${encodeURIComponent(
+        params.name
+      )}`;
       const ret = await fetch(url, {
         method: 'POST',
         mode: 'cors',
@@ -40,7 +43,7 @@ function LiveSwitch({ DAG, refresh, inputProps }: Props) {
     const enabled = !checked;
     setChecked(enabled);
     onSubmit({
-      name: DAG.DAG.Name,
+      name: dagId(DAG),
       action: 'suspend',
       value: enabled ? 'false' : 'true',
     });
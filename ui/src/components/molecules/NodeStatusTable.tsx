@@ -32,10 +32,8 @@ function NodeStatusTable({ nodes, status, name, refresh, file = '' }: Props) {
     requestId: status.RequestId,
   });
   const requireModal = (step: Step) => {
-    if (
-      status?.Status != SchedulerStatus.Running &&
-      status?.Status != SchedulerStatus.None
-    ) {
+    // The running steps of a running DAG can be taken over as well.
+    if (status?.Status != SchedulerStatus.None) {
       setCurrent(step);
       setModal(true);
     }
@@ -44,8 +42,8 @@ function NodeStatusTable({ nodes, status, name, refresh, file = '' }: Props) {
     setModal(false);
   }, [setModal]);
   const onUpdateStatus = React.useCallback(
-    async (step: Step, action: string) => {
-      doPost(action, step.Name);
+    async (step: Step, action: string, reason: string) => {
+      doPost(action, step.Name, reason);
       dismissModal();
       refresh();
     },
@@ -82,6 +80,7 @@ function NodeStatusTable({ nodes, status, name, refresh, file = '' }: Props) {
                 node={n}
                 file={file}
                 name={name}
+                requestId={status.RequestId}
                 onRequireModal={requireModal}
               ></NodeStatusTableRow>
             ))}
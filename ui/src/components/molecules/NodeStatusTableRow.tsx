@@ -12,6 +12,7 @@ type Props = {
   node: Node;
   file: string;
   name: string;
+  requestId?: string;
   onRequireModal: (step: Step) => void;
 };
 
@@ -20,9 +21,13 @@ function NodeStatusTableRow({
   rownum,
   node,
   file,
+  requestId,
   onRequireModal,
 }: Props) {
-  const url = `/dags/${name}/log?file=${file}&step=${node.Step.Name}`;
+  let url = `/dags/${encodeURIComponent(name)}/log?file=${file}&step=${node.Step.Name}`;
+  if (requestId) {
+    url += `&requestId=${requestId}`;
+  }
   const buttonStyle = {
     margin: '0px',
     padding: '0px',
@@ -37,8 +42,19 @@ function NodeStatusTableRow({
       <TableCell>
         <MultilineText>{node.Step.Description}</MultilineText>
       </TableCell>
-      <TableCell> {node.Step.Command} </TableCell>
-      <TableCell> {node.Step.Args ? node.Step.Args.join(' ') : ''} </TableCell>
+      {node.Preview ? (
+        <TableCell colSpan={2}>
+          <code>{node.Preview}</code>
+        </TableCell>
+      ) : (
+        <React.Fragment>
+          <TableCell> {node.Step.Command} </TableCell>
+          <TableCell>
+            {' '}
+            {node.Step.Args ? node.Step.Args.join(' ') : ''}{' '}
+          </TableCell>
+        </React.Fragment>
+      )}
       <TableCell> {node.StartedAt} </TableCell>
       <TableCell> {node.FinishedAt} </TableCell>
       <TableCell>
@@ -1,7 +1,7 @@
 import { Divider, List, ListItem, Stack, Typography } from '@mui/material';
 import React, { ReactElement, useEffect } from 'react';
 import { Link } from 'react-router-dom';
-import { SearchResult } from '../../models/api';
+import { Match, SearchResult } from '../../models/api';
 import DAGDefinition from './DAGDefinition';
 import Prism from '../../assets/js/prism';
 
@@ -13,23 +13,47 @@ function SearchResult({ results }: Props) {
   const elements = React.useMemo(
     () =>
       results.map((result, i) => {
+        const dagUrl = `/dags/${encodeURIComponent(result.Name)}`;
         const ret = [] as ReactElement[];
-        result.Matches.forEach((m, j) => {
+        ret.push(
+          <ListItem key={`${result.Name}-name`}>
+            <Stack direction="row" spacing={2} alignItems="baseline">
+              <Link to={`${dagUrl}/spec`}>
+                <Typography variant="h6">{result.Name}</Typography>
+              </Link>
+              {result.Fields && result.Fields.length > 0 ? (
+                <Typography variant="body2" color="text.secondary">
+                  {result.Fields.join(', ')}
+                </Typography>
+              ) : null}
+            </Stack>
+          </ListItem>
+        );
+        result.Matches.forEach((m) => {
           ret.push(
             <ListItem key={`${result.Name}-${m.LineNumber}`}>
+              {matchedLines(m)}
+            </ListItem>
+          );
+        });
+        result.Logs?.forEach((log) => {
+          ret.push(
+            <ListItem key={`${result.Name}-${log.RequestId}-${log.Step}`}>
               <Stack direction="column" spacing={1} style={{ width: '100%' }}>
-                {j == 0 ? (
-                  <Link to={`/dags/${encodeURI(result.Name)}/spec`}>
-                    <Typography variant="h6">{result.Name}</Typography>
-                  </Link>
-                ) : null}
-                <DAGDefinition
-                  value={m.Line}
-                  lineNumbers
-                  startLine={m.StartLine}
-                  highlightLine={m.LineNumber - m.StartLine}
-                  noHighlight
-                />
+                <Link
+                  to={`${dagUrl}/log?step=${encodeURIComponent(
+                    log.Step
+                  )}&requestId=${encodeURIComponent(log.RequestId)}`}
+                >
+                  <Typography variant="subtitle2">
+                    {log.Step} ({log.RequestId})
+                  </Typography>
+                </Link>
+                {log.Matches.map((m) => (
+                  <React.Fragment key={m.LineNumber}>
+                    {matchedLines(m)}
+                  </React.Fragment>
+                ))}
               </Stack>
             </ListItem>
           );
@@ -44,4 +68,18 @@ function SearchResult({ results }: Props) {
   useEffect(() => Prism.highlightAll(), [elements]);
   return <List>{elements}</List>;
 }
+
+function matchedLines(m: Match) {
+  return (
+    <Stack direction="column" spacing={1} style={{ width: '100%' }}>
+      <DAGDefinition
+        value={m.Line}
+        lineNumbers
+        startLine={m.StartLine}
+        highlightLine={m.LineNumber - m.StartLine}
+        noHighlight
+      />
+    </Stack>
+  );
+}
 export default SearchResult;
@@ -1,4 +1,11 @@
-import { Box, Button, Modal, Stack, Typography } from '@mui/material';
+import {
+  Box,
+  Button,
+  Modal,
+  Stack,
+  TextField,
+  Typography,
+} from '@mui/material';
 import React from 'react';
 import { Step } from '../../models';
 
@@ -6,7 +13,7 @@ type Props = {
   visible: boolean;
   dismissModal: () => void;
   step?: Step;
-  onSubmit: (step: Step, action: string) => void;
+  onSubmit: (step: Step, action: string, reason: string) => void;
 };
 
 const style = {
@@ -22,6 +29,10 @@ const style = {
 };
 
 function StatusUpdateModal({ visible, dismissModal, step, onSubmit }: Props) {
+  const [reason, setReason] = React.useState('');
+  React.useEffect(() => {
+    setReason('');
+  }, [step, visible]);
   React.useEffect(() => {
     const callback = (event: KeyboardEvent) => {
       const e = event || window.event;
@@ -50,6 +61,15 @@ function StatusUpdateModal({ visible, dismissModal, step, onSubmit }: Props) {
           spacing={2}
           mt={2}
         >
+          <TextField
+            label="Reason"
+            multiline
+            required
+            placeholder="Why the status is changed, e.g. confirmed by the upstream system"
+            variant="outlined"
+            value={reason}
+            onChange={(e) => setReason(e.target.value)}
+          />
           <Stack
             direction="row"
             alignContent="center"
@@ -58,13 +78,15 @@ function StatusUpdateModal({ visible, dismissModal, step, onSubmit }: Props) {
           >
             <Button
               variant="outlined"
-              onClick={() => onSubmit(step, 'mark-success')}
+              disabled={!reason}
+              onClick={() => onSubmit(step, 'mark-success', reason)}
             >
               Mark Success
             </Button>
             <Button
               variant="outlined"
-              onClick={() => onSubmit(step, 'mark-failed')}
+              disabled={!reason}
+              onClick={() => onSubmit(step, 'mark-failed', reason)}
             >
               Mark Failed
             </Button>
@@ -1,6 +1,11 @@
 import { Box, Button, Stack } from '@mui/material';
 import React from 'react';
-import { GetDAGResponse } from '../../models/api';
+import {
+  DAGProblem,
+  GetDAGResponse,
+  PostDAGActionResponse,
+  ValidateDAGResponse,
+} from '../../models/api';
 import { DAGContext } from '../../contexts/DAGContext';
 import { DAG, Step } from '../../models';
 import DAGEditor from '../atoms/DAGEditor';
@@ -11,6 +16,7 @@ import DAGStepTable from '../molecules/DAGStepTable';
 import BorderedBox from '../atoms/BorderedBox';
 import SubTitle from '../atoms/SubTitle';
 import FlowchartSwitch from '../molecules/FlowchartSwitch';
+import DAGChanges from '../molecules/DAGChanges';
 import { useCookies } from 'react-cookie';
 import { FontAwesomeIcon } from '@fortawesome/react-fontawesome';
 import {
@@ -26,6 +32,8 @@ type Props = {
 function DAGSpec({ data }: Props) {
   const [editing, setEditing] = React.useState(false);
   const [currentValue, setCurrentValue] = React.useState(data.Definition);
+  const [problems, setProblems] = React.useState<DAGProblem[]>([]);
+  const { name } = React.useContext(DAGContext);
   const handlers = getHandlers(data.DAG?.DAG);
   const [cookie, setCookie] = useCookies(['flowchart']);
   const [flowchart, setFlowchart] = React.useState(cookie['flowchart']);
@@ -36,6 +44,27 @@ function DAGSpec({ data }: Props) {
     },
     [setCookie, flowchart, setFlowchart]
   );
+  React.useEffect(() => {
+    if (!editing) {
+      setProblems([]);
+      return;
+    }
+    // The definition is validated once the user stops typing.
+    const timer = setTimeout(async () => {
+      const resp = await fetch(`${getConfig().apiURL}/validate`, {
+        method: 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+        },
+        body: JSON.stringify({ Definition: currentValue, DagId: name }),
+      });
+      if (resp.ok) {
+        const result: ValidateDAGResponse = await resp.json();
+        setProblems(result.Problems);
+      }
+    }, 300);
+    return () => clearTimeout(timer);
+  }, [editing, currentValue, name]);
   if (data.DAG?.DAG == null) {
     return null;
   }
@@ -96,6 +125,8 @@ function DAGSpec({ data }: Props) {
               </Box>
             ) : null}
 
+            <DAGChanges name={props.name} onReviewed={props.refresh} />
+
             <Box sx={{ mt: 3 }}>
               <SubTitle>Spec</SubTitle>
               <BorderedBox
@@ -131,9 +162,9 @@ function DAGSpec({ data }: Props) {
                           </span>
                         }
                         onClick={async () => {
-                          const url = `${getConfig().apiURL}/dags/${
-                            props.name
-                          }`;
+                          const url = `${
+                            getConfig().apiURL
+                          }/dags/${encodeURIComponent(props.name)}`;
                           const resp = await fetch(url, {
                             method: 'POST',
                             headers: {
@@ -145,6 +176,13 @@ function DAGSpec({ data }: Props) {
                             }),
                           });
                           if (resp.ok) {
+                            const result: PostDAGActionResponse =
+                              await resp.json();
+                            if (result.ChangeId) {
+                              alert(
+                                'The change is pending until another user approves it.'
+                              );
+                            }
                             setEditing(false);
                             props.refresh();
                           } else {
@@ -194,6 +232,7 @@ function DAGSpec({ data }: Props) {
                       onChange={(newValue) => {
                         setCurrentValue(newValue);
                       }}
+                      problems={problems}
                     ></DAGEditor>
                   </Box>
                 ) : (
@@ -224,6 +263,9 @@ function getHandlers(dag?: DAG) {
   if (h.Cancel) {
     r.push(h.Cancel);
   }
+  if (h.Timeout) {
+    r.push(h.Timeout);
+  }
   if (h.Exit) {
     r.push(h.Exit);
   }
@@ -14,6 +14,7 @@ import SubTitle from '../atoms/SubTitle';
 import BorderedBox from '../atoms/BorderedBox';
 import { useCookies } from 'react-cookie';
 import FlowchartSwitch from '../molecules/FlowchartSwitch';
+import DAGArtifacts from '../molecules/DAGArtifacts';
 import { FontAwesomeIcon } from '@fortawesome/react-fontawesome';
 import { faChartGantt, faShareNodes } from '@fortawesome/free-solid-svg-icons';
 
@@ -38,8 +39,8 @@ function DAGStatus({ DAG, name, refresh }: Props) {
     setModal(false);
   }, [setModal]);
   const onUpdateStatus = React.useCallback(
-    async (step: Step, action: string) => {
-      doPost(action, step.Name);
+    async (step: Step, action: string, reason: string) => {
+      doPost(action, step.Name, reason);
       dismissModal();
     },
     [refresh, dismissModal]
@@ -47,7 +48,7 @@ function DAGStatus({ DAG, name, refresh }: Props) {
   const onSelectStepOnGraph = React.useCallback(
     async (id: string) => {
       const status = DAG.Status?.Status;
-      if (status == SchedulerStatus.Running || status == SchedulerStatus.None) {
+      if (status == SchedulerStatus.None) {
         return;
       }
       // find the clicked step
@@ -171,6 +172,8 @@ function DAGStatus({ DAG, name, refresh }: Props) {
                   </Box>
                 </Box>
               ) : null}
+
+              <DAGArtifacts name={name} requestId={DAG.Status!.RequestId} />
             </React.Fragment>
           )}
         </DAGContext.Consumer>
@@ -36,6 +36,12 @@ function DAGHistoryTable({ GridData, Logs }: HistoryTableProps) {
   }, [Logs]);
 
   const handlers = logs.length > idx ? Handlers(logs[idx].Status) : null;
+  const selectRun = (requestId: string) => {
+    const i = logs.findIndex((l) => l.Status.RequestId == requestId);
+    if (i >= 0) {
+      setIdx(i);
+    }
+  };
 
   return (
     <DAGContext.Consumer>
@@ -59,6 +65,7 @@ function DAGHistoryTable({ GridData, Logs }: HistoryTableProps) {
                   <DAGStatusOverview
                     status={logs[idx].Status}
                     file={logs[idx].File}
+                    onSelectRun={selectRun}
                     {...props}
                   />
                 </Box>
@@ -1,5 +1,6 @@
 import { Box, Stack } from '@mui/material';
 import React from 'react';
+import { NodeStatus } from '../../models';
 import { LogFile } from '../../models/api';
 import BorderedBox from '../atoms/BorderedBox';
 import LabeledItem from '../atoms/LabeledItem';
@@ -8,9 +9,30 @@ import NodeStatusChip from '../molecules/NodeStatusChip';
 
 type Props = {
   log?: LogFile;
+  // streamUrl is the URL of the stream of the log that is followed while
+  // the step runs, instead of reloading the whole log.
+  streamUrl?: string;
 };
 
-function ExecutionLog({ log }: Props) {
+function ExecutionLog({ log, streamUrl }: Props) {
+  const [streamed, setStreamed] = React.useState<string | undefined>();
+  const running = log?.Step?.Status == NodeStatus.Running;
+
+  React.useEffect(() => {
+    if (!streamUrl || !running) {
+      return;
+    }
+    const source = new EventSource(streamUrl);
+    let content = '';
+    source.onmessage = (e) => {
+      content += e.data + '\n';
+      setStreamed(content);
+    };
+    source.addEventListener('end', () => source.close());
+    source.addEventListener('error', () => source.close());
+    return () => source.close();
+  }, [streamUrl, running]);
+
   if (!log) {
     return <LoadingIndicator />;
   }
@@ -18,6 +40,16 @@ function ExecutionLog({ log }: Props) {
     <Box>
       <Stack spacing={1} direction="column" sx={{ width: '100%' }}>
         <LabeledItem label="Log File">{log.LogFile}</LabeledItem>
+        {log.ArchivedFrom ? (
+          <LabeledItem label="Retrieved From Archive">
+            {log.ArchivedFrom}
+          </LabeledItem>
+        ) : null}
+        {log.RemoteLocation ? (
+          <LabeledItem label="Retrieved From Log Backend">
+            {log.RemoteLocation}
+          </LabeledItem>
+        ) : null}
         {log.Step ? (
           <React.Fragment>
             <LabeledItem label="Step Name">{log.Step.Step.Name}</LabeledItem>
@@ -52,7 +84,7 @@ function ExecutionLog({ log }: Props) {
             fontFamily: 'Courier New, Courier, monospace',
           }}
         >
-          {log.Content || '<No log output>'}
+          {(running && streamed) || log.Content || '<No log output>'}
         </pre>
       </BorderedBox>
     </Box>
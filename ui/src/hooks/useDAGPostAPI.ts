@@ -9,8 +9,10 @@ type Options = {
 
 export function useDAGPostAPI(opts: Options) {
   const doPost = React.useCallback(
-    async (action: string, step?: string) => {
-      const url = `${getConfig().apiURL}/dags/${opts.name}`;
+    async (action: string, step?: string, reason?: string) => {
+      const url = `${getConfig().apiURL}/dags/${encodeURIComponent(
+        opts.name
+      )}`;
       const ret = await fetch(url, {
         method: 'POST',
         mode: 'cors',
@@ -20,6 +22,7 @@ export function useDAGPostAPI(opts: Options) {
         body: JSON.stringify({
           action: action,
           step: step,
+          reason: reason,
           requestId: opts.requestId,
         }),
       });
@@ -0,0 +1,11 @@
+type DAGFile = {
+  File: string;
+  Folder?: string;
+};
+
+// dagId returns the ID of the DAG in the API, which is the path of its file
+// in the DAGs directory without the extension, e.g. team-a/etl.
+export function dagId(status: DAGFile): string {
+  const name = status.File.replace(/.y[a]{0,1}ml$/, '');
+  return status.Folder ? `${status.Folder}/${name}` : name;
+}
@@ -21,6 +21,15 @@ export type GetSearchResponse = {
 export type SearchResult = {
   Name: string;
   DAG?: DAG;
+  Fields?: string[];
+  Score?: number;
+  Matches: Match[];
+  Logs?: LogMatch[];
+};
+
+export type LogMatch = {
+  RequestId: string;
+  Step: string;
   Matches: Match[];
 };
 
@@ -30,15 +39,30 @@ export type Match = {
   StartLine: number;
 };
 
+export type ValidateDAGResponse = {
+  Valid: boolean;
+  Problems: DAGProblem[];
+};
+
+export type DAGProblem = {
+  Line: number;
+  Field: string;
+  Level: 'error' | 'warning';
+  Message: string;
+};
+
 export type LogData = {
   GridData: GridData[];
   Logs: StatusFile[];
+  HasMore?: boolean;
 };
 
 export type LogFile = {
   Step?: Node;
   LogFile: string;
   Content: string;
+  ArchivedFrom?: string;
+  RemoteLocation?: string;
 };
 
 export type GridData = {
@@ -50,11 +74,14 @@ export type ListWorkflowsResponse = {
   DAGs: WorkflowListItem[];
   Errors: string[];
   HasError: boolean;
+  Total: number;
+  Folders?: string[];
 };
 
 export type WorkflowListItem = {
   File: string;
   Dir: string;
+  Folder?: string;
   Status?: WorkflowStatus;
   Suspended: boolean;
   ErrorT: string;
@@ -81,4 +108,47 @@ export type WorkflowStatus = {
   FinishedAt: string;
   Log: string;
   Params: string;
-};
\ No newline at end of file
+};
+export type Change = {
+  Id: string;
+  DAG: string;
+  Author: string;
+  CreatedAt: string;
+  Spec: string;
+  Diff: string;
+  Status: 'pending' | 'approved' | 'rejected';
+  Reviewer?: string;
+  ReviewedAt?: string;
+  Reason?: string;
+};
+
+export type PostDAGActionResponse = {
+  NewDagID?: string;
+  RequestId?: string;
+  Queued?: boolean;
+  ChangeId?: string;
+};
+
+export type ListChangesResponse = {
+  Changes: Change[];
+};
+
+export type Artifact = {
+  Path: string;
+  Size: number;
+  ModTime: string;
+  SHA256?: string;
+  ETag?: string;
+  Type?: 'image' | 'html' | 'json' | 'csv' | 'file';
+};
+
+export type ListArtifactsResponse = {
+  Artifacts: Artifact[];
+};
+
+// CSVPreview is the preview of a CSV artifact.
+export type CSVPreview = {
+  Header: string[] | null;
+  Rows: string[][];
+  Truncated: boolean;
+};
@@ -7,7 +7,8 @@ export enum SchedulerStatus {
   Error,
   Cancel,
   Success,
-  Skipped_Unused,
+  Expired,
+  Lost,
 }
 
 export type Status = {
@@ -21,10 +22,21 @@ export type Status = {
   OnSuccess?: Node;
   OnFailure?: Node;
   OnCancel?: Node;
+  OnTimeout?: Node;
   StartedAt: string;
   FinishedAt: string;
   Log: string;
   Params: string;
+  QueuePosition?: number;
+  Lane?: string;
+  Overtook?: string[];
+  InitiatedBy?: string;
+  InitiatorToken?: string;
+  ArchiveLocation?: string;
+  Schedule?: string;
+  StoppedBy?: string;
+  RestartedFrom?: string;
+  RestartedAs?: string;
 };
 
 export function Handlers(s: Status) {
@@ -38,6 +50,9 @@ export function Handlers(s: Status) {
   if (s.OnCancel) {
     r.push(s.OnCancel);
   }
+  if (s.OnTimeout) {
+    r.push(s.OnTimeout);
+  }
   if (s.OnExit) {
     r.push(s.OnExit);
   }
@@ -67,6 +82,21 @@ export type DAG = {
   DefaultParams?: string;
   Delay: number;
   MaxCleanUpTime: number;
+  Warnings?: DAGWarning[];
+  Groups?: StepGroup[];
+};
+
+export type StepGroup = {
+  Name: string;
+  Path: string;
+  Steps: string[];
+  Groups?: StepGroup[];
+};
+
+export type DAGWarning = {
+  Code: 'deprecated' | 'fieldCase';
+  Field: string;
+  Message: string;
 };
 
 export type Schedule = {
@@ -77,12 +107,14 @@ export type HandlerOn = {
   Failure: Step;
   Success: Step;
   Cancel: Step;
+  Timeout?: Step;
   Exit: Step;
 };
 
 export type DAGStatus = {
   File: string;
   Dir: string;
+  Folder?: string;
   DAG: DAG;
   Status?: Status;
   Suspended: boolean;
@@ -150,7 +182,13 @@ export function getNextSchedule(data: WorkflowListItem): number {
   if (!schedules || schedules.length == 0 || data.Suspended) {
     return Number.MAX_SAFE_INTEGER;
   }
-  const datesToRun = schedules.map((s) =>
+  // The next run of an interval schedule depends on the end of the latest
+  // run, so only the cron expressions are used.
+  const crons = schedules.filter((s) => !s.Expression.startsWith('every '));
+  if (crons.length == 0) {
+    return Number.MAX_SAFE_INTEGER;
+  }
+  const datesToRun = crons.map((s) =>
     cronParser.parseExpression(s.Expression).next()
   );
   const sorted = datesToRun.sort((a, b) => a.getTime() - b.getTime());
@@ -164,6 +202,7 @@ export enum NodeStatus {
   Cancel,
   Success,
   Skipped,
+  Timeout,
 }
 
 export type Node = {
@@ -176,6 +215,7 @@ export type Node = {
   DoneCount: number;
   Error: string;
   StatusText: string;
+  Preview?: string;
 };
 
 export type StatusFile = {
@@ -202,6 +242,8 @@ export type Step = {
   Preconditions: Condition[];
   Run: string;
   Params: string;
+  Stage?: string;
+  Group?: string;
 };
 
 export type RetryPolicy = {
@@ -2,6 +2,7 @@ import React, { useMemo } from 'react';
 import { Link, useParams, Routes, Route, useLocation } from 'react-router-dom';
 import { GetDAGResponse } from '../../../models/api';
 import DAGSpecErrors from '../../../components/molecules/DAGSpecErrors';
+import DAGSpecWarnings from '../../../components/molecules/DAGSpecWarnings';
 import DAGStatus from '../../../components/organizations/DAGStatus';
 import { DAGContext } from '../../../contexts/DAGContext';
 import DAGSpec from '../../../components/organizations/DAGSpec';
@@ -26,11 +27,11 @@ function DAGDetails() {
   const { pathname } = useLocation();
 
   const baseUrl = useMemo(
-    () => `/dags/${encodeURI(params.name!)}`,
+    () => `/dags/${encodeURIComponent(params.name!)}`,
     [params.name]
   );
   const { data, isValidating, mutate } = useSWR<GetDAGResponse>(
-    `/dags/${params.name}?tab=${params.tab ?? ''}&${new URLSearchParams(
+    `/dags/${encodeURIComponent(params.name!)}?tab=${params.tab ?? ''}&${new URLSearchParams(
       window.location.search
     ).toString()}`,
     null,
@@ -114,6 +115,7 @@ function DAGDetails() {
 
         <Box sx={{ mt: 2, mx: 4 }}>
           <DAGSpecErrors errors={data.Errors} />
+          <DAGSpecWarnings warnings={data.DAG?.DAG?.Warnings} />
         </Box>
 
         <Box sx={{ mx: 4, flex: 1 }}>
@@ -125,7 +127,12 @@ function DAGDetails() {
             <ExecutionHistory logData={data.LogData} isLoading={isValidating} />
           ) : null}
           {tab == 'scheduler-log' ? <ExecutionLog log={data.ScLog} /> : null}
-          {tab == 'log' ? <ExecutionLog log={data.StepLog} /> : null}
+          {tab == 'log' ? (
+            <ExecutionLog
+              log={data.StepLog}
+              streamUrl={stepLogStreamUrl(params.name)}
+            />
+          ) : null}
         </Box>
       </Stack>
     </DAGContext.Provider>
@@ -133,6 +140,22 @@ function DAGDetails() {
 }
 export default DAGDetails;
 
+// stepLogStreamUrl returns the URL of the stream of the step log of the log
+// tab, or undefined if the tab does not show the log of a run.
+function stepLogStreamUrl(name: string) {
+  const search = new URLSearchParams(window.location.search);
+  const step = search.get('step');
+  const requestId = search.get('requestId');
+  if (!step || !requestId) {
+    return undefined;
+  }
+  return `${getConfig().apiURL}/dags/${encodeURIComponent(
+    name
+  )}/runs/${encodeURIComponent(requestId)}/steps/${encodeURIComponent(
+    step
+  )}/log/stream`;
+}
+
 interface LinkTabProps {
   label?: string;
   value: string;
@@ -1,5 +1,14 @@
 import React, { useEffect, useRef } from 'react';
-import { Box, Button, Grid, Stack, TextField, Typography } from '@mui/material';
+import {
+  Box,
+  Button,
+  Checkbox,
+  FormControlLabel,
+  Grid,
+  Stack,
+  TextField,
+  Typography,
+} from '@mui/material';
 import useSWR from 'swr';
 import { useSearchParams } from 'react-router-dom';
 import Title from '../../components/atoms/Title';
@@ -10,9 +19,12 @@ import LoadingIndicator from '../../components/atoms/LoadingIndicator';
 function Search() {
   const [searchParams, setSearchParams] = useSearchParams();
   const [searchVal, setSearchVal] = React.useState(searchParams.get('q') || '');
+  const logs = searchParams.get('logs') == 'true';
 
   const { data, error } = useSWR<GetSearchResponse>(
-    `/search?q=${searchParams.get('q') || ''}`
+    `/search?q=${encodeURIComponent(searchParams.get('q') || '')}${
+      logs ? '&logs=true' : ''
+    }`
   );
   const ref = useRef<HTMLInputElement>(null);
 
@@ -20,10 +32,8 @@ function Search() {
     ref.current?.focus();
   }, [ref.current]);
 
-  const onSubmit = React.useCallback((value: string) => {
-    setSearchParams({
-      q: value,
-    });
+  const onSubmit = React.useCallback((value: string, logs: boolean) => {
+    setSearchParams(logs ? { q: value, logs: 'true' } : { q: value });
   }, []);
 
   return (
@@ -48,7 +58,7 @@ function Search() {
             onKeyDown={(e) => {
               if (e.key === 'Enter') {
                 if (searchVal) {
-                  onSubmit(searchVal);
+                  onSubmit(searchVal, logs);
                 }
               }
             }}
@@ -61,11 +71,24 @@ function Search() {
               border: 0,
             }}
             onClick={async () => {
-              onSubmit(searchVal);
+              onSubmit(searchVal, logs);
             }}
           >
             Search
           </Button>
+          <FormControlLabel
+            control={
+              <Checkbox
+                checked={logs}
+                onChange={(e) => {
+                  if (searchVal) {
+                    onSubmit(searchVal, e.target.checked);
+                  }
+                }}
+              />
+            }
+            label="Search logs"
+          />
         </Stack>
 
         <Box mt={2}>